@@ -14,6 +14,7 @@ import (
 	"github.com/Elpulgo/azdo/internal/config"
 	"github.com/Elpulgo/azdo/internal/demo"
 	"github.com/Elpulgo/azdo/internal/github"
+	"github.com/Elpulgo/azdo/internal/localgit"
 	"github.com/Elpulgo/azdo/internal/provider"
 	"github.com/Elpulgo/azdo/internal/state"
 	"github.com/Elpulgo/azdo/internal/ui/components"
@@ -261,13 +262,29 @@ func runAuthGitHub(store *config.KeyringStore) error {
 }
 
 func runTUI() error {
+	store := config.NewKeyringStore()
+
+	// Started inside a git working copy, its remotes scope the TUI to the
+	// repository and can stand in for a missing config file.
+	localRepo, err := localgit.Open(".")
+	if err != nil {
+		localRepo = nil
+	}
+	var remotes []localgit.Remote
+	if localRepo != nil {
+		remotes, _ = localRepo.Remotes()
+	}
+
 	// Load configuration
 	cfg, err := config.Load()
 	if err != nil {
 		if errors.Is(err, config.ErrConfigNotFound) {
-			cfg, err = runSetupWizard()
-			if err != nil {
-				return err
+			cfg = configFromRemotes(remotes, store)
+			if cfg == nil {
+				cfg, err = runSetupWizard()
+				if err != nil {
+					return err
+				}
 			}
 		} else {
 			return err
@@ -275,7 +292,6 @@ func runTUI() error {
 	}
 
 	// Build the configured backends and assemble a CompositeProvider.
-	store := config.NewKeyringStore()
 
 	var backends []provider.Provider
 	var azureMC *azdevops.MultiClient
@@ -298,6 +314,11 @@ func runTUI() error {
 		if err != nil {
 			return fmt.Errorf("failed to create Azure DevOps client: %w", err)
 		}
+		for _, p := range cfg.Projects {
+			if team := cfg.WorkItems.TeamFor(p); team != "" {
+				client.ClientFor(p).SetTeam(team)
+			}
+		}
 		azureMC = client
 		backends = append(backends, azdevops.NewAdapter(client))
 	}
@@ -341,9 +362,38 @@ func runTUI() error {
 		return fmt.Errorf("load state: %w", err)
 	}
 
+	// Pending-review drafts and viewed-file marks are written through on
+	// every change, so unlike the navigation state they need no flush on exit.
+	reviewsPath, err := state.ReviewsPath()
+	if err != nil {
+		return fmt.Errorf("resolve review drafts path: %w", err)
+	}
+	reviewDrafts, err := state.NewDraftStore(reviewsPath)
+	if err != nil {
+		return fmt.Errorf("load review drafts: %w", err)
+	}
+	viewedPath, err := state.ViewedPath()
+	if err != nil {
+		return fmt.Errorf("resolve viewed files path: %w", err)
+	}
+	viewedFiles, err := state.NewViewedStore(viewedPath)
+	if err != nil {
+		return fmt.Errorf("load viewed files: %w", err)
+	}
+
 	// Create and run the TUI application.
 	model := app.NewModel(composite, azureMC, cfg, version, commit)
 	model.SetStateStore(stateStore)
+	model.SetReviewDrafts(reviewDrafts)
+	model.SetViewedFiles(viewedFiles)
+	// Inside a git working copy, PRs of its repository can be checked out,
+	// and a configured repository pre-filters the PR list.
+	if localRepo != nil {
+		model.SetLocalRepo(localRepo)
+		if remote, ok := localgit.ConfiguredRemote(remotes, cfg); ok {
+			model.SetRepoScope(remote, localRepo.CurrentBranch())
+		}
+	}
 	model.ApplyState(stateStore.State())
 	p := tea.NewProgram(model, tea.WithAltScreen())
 
@@ -376,6 +426,34 @@ func runTUI() error {
 	return nil
 }
 
+// configFromRemotes derives a config for this session from the first
+// remote whose backend has a stored token: its organization and project
+// for Azure DevOps, its "owner/repo" slug for GitHub. The config is kept in
+// memory, so changing the theme does not save it. It returns nil when no
+// remote qualifies.
+func configFromRemotes(remotes []localgit.Remote, store *config.KeyringStore) *config.Config {
+	for _, r := range remotes {
+		cfg := config.Defaults()
+		switch r.Kind {
+		case provider.KindAzure:
+			if _, err := store.GetPAT(); err != nil {
+				continue
+			}
+			cfg.Organization, cfg.Projects = r.Org, []string{r.Project}
+		case provider.KindGitHub:
+			if _, err := store.GetGitHubToken(); err != nil {
+				continue
+			}
+			cfg.GitHub.Repos = []string{r.Scope()}
+		default:
+			continue
+		}
+		cfg.KeepInMemory()
+		return cfg
+	}
+	return nil
+}
+
 // runSetupWizard launches the interactive setup wizard and saves the config.
 func runSetupWizard() (*config.Config, error) {
 	model := setupwizard.NewModel()
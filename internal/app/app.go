@@ -7,6 +7,8 @@ import (
 
 	"github.com/Elpulgo/azdo/internal/azdevops"
 	"github.com/Elpulgo/azdo/internal/config"
+	"github.com/Elpulgo/azdo/internal/diff"
+	"github.com/Elpulgo/azdo/internal/localgit"
 	"github.com/Elpulgo/azdo/internal/polling"
 	"github.com/Elpulgo/azdo/internal/provider"
 	"github.com/Elpulgo/azdo/internal/state"
@@ -96,7 +98,12 @@ type Model struct {
 	height           int
 	footerRows       int
 	err              error
-	stateStore       *state.Store // optional; nil when persistence is disabled
+	stateStore       *state.Store       // optional; nil when persistence is disabled
+	reviewDrafts     *state.DraftStore  // optional; nil keeps review drafts in memory
+	viewedFiles      *state.ViewedStore // optional; nil keeps viewed marks in memory
+	localRepo        *localgit.Repo     // optional; nil when not started in a git working copy
+	repoRemote       *localgit.Remote   // configured repository of the working copy; nil when none
+	repoBranch       string             // branch checked out in the working copy
 }
 
 // SetStateStore attaches a state store to the model so navigation changes
@@ -106,6 +113,51 @@ func (m *Model) SetStateStore(s *state.Store) {
 	m.stateStore = s
 }
 
+// SetReviewDrafts attaches the store that persists pending-review drafts
+// written in the PR diff view. Wired up by cmd/azdo-tui; tests may omit it.
+func (m *Model) SetReviewDrafts(d *state.DraftStore) {
+	m.reviewDrafts = d
+	m.pullRequestsView = m.pullRequestsView.WithReviewDrafts(d)
+}
+
+// SetViewedFiles attaches the store that persists which PR files were
+// marked as viewed. Wired up by cmd/azdo-tui; tests may omit it.
+func (m *Model) SetViewedFiles(v *state.ViewedStore) {
+	m.viewedFiles = v
+	m.pullRequestsView = m.pullRequestsView.WithViewedFiles(v)
+}
+
+// SetLocalRepo attaches the git working copy azdo was started in, so PRs
+// of its repository can be checked out. Wired up by cmd/azdo-tui; tests
+// may omit it.
+func (m *Model) SetLocalRepo(r *localgit.Repo) {
+	m.localRepo = r
+	m.pullRequestsView = m.pullRequestsView.WithLocalRepo(r)
+}
+
+// SetRepoScope scopes the PR list to the configured repository the
+// working copy belongs to, marking the PR for the checked-out branch.
+// Wired up by cmd/azdo-tui; tests may omit it.
+func (m *Model) SetRepoScope(remote localgit.Remote, branch string) {
+	m.repoRemote = &remote
+	m.repoBranch = branch
+	m.pullRequestsView = m.pullRequestsView.WithRepoScope(remote, branch)
+}
+
+// newPullRequestsView builds the PR view with everything attached through
+// the Set* methods, for when the view is recreated.
+func (m *Model) newPullRequestsView() pullrequests.Model {
+	v := pullrequests.NewModelWithStyles(m.client, m.styles).
+		WithDiffOptions(diffOptions(m.config)).
+		WithReviewDrafts(m.reviewDrafts).
+		WithViewedFiles(m.viewedFiles).
+		WithLocalRepo(m.localRepo)
+	if m.repoRemote != nil {
+		v = v.WithRepoScope(*m.repoRemote, m.repoBranch)
+	}
+	return v
+}
+
 // tabIDForTab maps the internal Tab iota to the on-disk TabID.
 func tabIDForTab(t Tab) state.TabID {
 	switch t {
@@ -297,6 +349,46 @@ func displayScopes(p provider.Provider, cfg *config.Config) []string {
 	return scopes
 }
 
+// diffOptions maps the diff section of the config onto the options the PR
+// diff view passes to diff.ComputeDiffWithOptions.
+func diffOptions(cfg *config.Config) diff.Options {
+	return diff.Options{
+		Context:          cfg.Diff.ContextLines,
+		IgnoreWhitespace: cfg.Diff.IgnoreWhitespace,
+		MaxLines:         cfg.Diff.MaxLines,
+	}
+}
+
+// workItemQueries maps the queries of the work_items config section onto
+// the queries the work items view offers. Queries without columns of their
+// own use the section's columns.
+func workItemQueries(cfg *config.Config) []provider.WorkItemQuery {
+	queries := make([]provider.WorkItemQuery, len(cfg.WorkItems.Queries))
+	for i, q := range cfg.WorkItems.Queries {
+		columns := q.Columns
+		if len(columns) == 0 {
+			columns = cfg.WorkItems.Columns
+		}
+		queries[i] = provider.WorkItemQuery{
+			Name:  q.Name,
+			Scope: q.Project,
+			WIQL:  q.WIQL,
+			Criteria: provider.WorkItemQueryCriteria{
+				Types:         q.Criteria.Types,
+				States:        q.Criteria.States,
+				Tags:          q.Criteria.Tags,
+				AssignedTo:    q.Criteria.AssignedTo,
+				AreaPath:      q.Criteria.AreaPath,
+				IterationPath: q.Criteria.IterationPath,
+			},
+			Tree:    q.Mode == "tree",
+			Columns: columns,
+			Top:     q.Top,
+		}
+	}
+	return queries
+}
+
 // NewModel creates a new application model.
 //
 // p is the backend-neutral provider used by the three main views. It is stored
@@ -456,8 +548,8 @@ func NewModel(p provider.Provider, mc *azdevops.MultiClient, cfg *config.Config,
 		logo:          logo,
 		// pullRequestsView, workItemsView, and pipelinesView all consume provider.Provider (tasks 7-9).
 		pipelinesView:    pipelines.NewModelWithStyles(p, appStyles),
-		pullRequestsView: pullrequests.NewModelWithStyles(p, appStyles),
-		workItemsView:    workitems.NewModelWithStyles(p, appStyles),
+		pullRequestsView: pullrequests.NewModelWithStyles(p, appStyles).WithDiffOptions(diffOptions(cfg)),
+		workItemsView:    workitems.NewModelWithStyles(p, appStyles).WithQueries(workItemQueries(cfg)),
 		metricsView:      mv,
 		statusBar:        statusBar,
 		helpModal:        helpModal,
@@ -597,6 +689,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, nil
 		case "left":
+			if m.activeTab == TabWorkItems && (m.workItemsView.IsBoardMode() || m.workItemsView.IsSprintMode()) {
+				// The board selects columns and the sprint view sprints with the arrows
+				break
+			}
 			prev := m.prevTab()
 			if prev != m.activeTab {
 				m.activeTab = prev
@@ -606,6 +702,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, nil
 		case "right":
+			if m.activeTab == TabWorkItems && (m.workItemsView.IsBoardMode() || m.workItemsView.IsSprintMode()) {
+				break
+			}
 			next := m.nextTab()
 			if next != m.activeTab {
 				m.activeTab = next
@@ -673,8 +772,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Recreate views with new styles.
 		// pullRequestsView, workItemsView, and pipelinesView all use provider.Provider (tasks 7-9).
 		m.pipelinesView = pipelines.NewModelWithStyles(m.client, m.styles)
-		m.pullRequestsView = pullrequests.NewModelWithStyles(m.client, m.styles)
-		m.workItemsView = workitems.NewModelWithStyles(m.client, m.styles)
+		m.pullRequestsView = m.newPullRequestsView()
+		m.workItemsView = workitems.NewModelWithStyles(m.client, m.styles).WithQueries(workItemQueries(m.config))
 		// Re-style the metrics view in place rather than reconstructing it —
 		// recreating would erase its loaded snapshots, sprint selection and
 		// fetched rows, blanking the section on theme change.
@@ -833,9 +932,13 @@ func (m Model) isActiveViewCapturingInput() bool {
 	case TabWorkItems:
 		return m.workItemsView.IsTagPickerVisible() ||
 			m.workItemsView.IsStatePickerVisible() ||
+			m.workItemsView.IsCreateFormVisible() ||
+			m.workItemsView.IsQueryPickerVisible() ||
 			m.workItemsView.IsCommentFormVisible()
 	case TabPipelines:
 		return m.pipelinesView.IsStatusPickerVisible()
+	case TabPullRequests:
+		return m.pullRequestsView.IsFilterPanelVisible()
 	case TabMetrics:
 		return m.metricsView.IsTagPickerVisible()
 	}
@@ -931,6 +1034,26 @@ func (m Model) workItemsKeybindings() string {
 		Foreground(lipgloss.Color(m.styles.Theme.Border))
 	sep := sepStyle.Render(" • ")
 
+	if m.workItemsView.IsBoardMode() {
+		return m.styles.Key.Render("←→↑↓") + m.styles.Description.Render(" select") + sep +
+			m.styles.Key.Render("</>") + m.styles.Description.Render(" move card") + sep +
+			m.styles.Key.Render("enter") + m.styles.Description.Render(" details") + sep +
+			m.styles.Key.Render("b") + m.styles.Description.Render(" board") + sep +
+			m.styles.Key.Render("p") + m.styles.Description.Render(" project") + sep +
+			m.styles.Key.Render("r") + m.styles.Description.Render(" refresh") + sep +
+			m.styles.Key.Render("B/esc") + m.styles.Description.Render(" list") + sep +
+			m.styles.Key.Render("q") + m.styles.Description.Render(" quit")
+	}
+	if m.workItemsView.IsSprintMode() {
+		return m.styles.Key.Render("←→") + m.styles.Description.Render(" sprint") + sep +
+			m.styles.Key.Render("↑↓") + m.styles.Description.Render(" navigate") + sep +
+			m.styles.Key.Render("enter") + m.styles.Description.Render(" details") + sep +
+			m.styles.Key.Render("g") + m.styles.Description.Render(" group") + sep +
+			m.styles.Key.Render("p") + m.styles.Description.Render(" project") + sep +
+			m.styles.Key.Render("r") + m.styles.Description.Render(" refresh") + sep +
+			m.styles.Key.Render("S/esc") + m.styles.Description.Render(" list") + sep +
+			m.styles.Key.Render("q") + m.styles.Description.Render(" quit")
+	}
 	return m.styles.Key.Render("r") + m.styles.Description.Render(" refresh") + sep +
 		m.styles.Key.Render("↑↓") + m.styles.Description.Render(" navigate") + sep +
 		m.styles.Key.Render("enter") + m.styles.Description.Render(" details") + sep +
@@ -938,6 +1061,11 @@ func (m Model) workItemsKeybindings() string {
 		m.styles.Key.Render("m") + m.styles.Description.Render(" my items") + sep +
 		m.styles.Key.Render("T") + m.styles.Description.Render(" tags") + sep +
 		m.styles.Key.Render("s") + m.styles.Description.Render(" state") + sep +
+		m.styles.Key.Render("n") + m.styles.Description.Render(" new") + sep +
+		m.styles.Key.Render("H") + m.styles.Description.Render(" tree") + sep +
+		m.styles.Key.Render("Q") + m.styles.Description.Render(" queries") + sep +
+		m.styles.Key.Render("B") + m.styles.Description.Render(" board") + sep +
+		m.styles.Key.Render("S") + m.styles.Description.Render(" sprint") + sep +
 		m.styles.Key.Render("esc") + m.styles.Description.Render(" back") + sep +
 		m.styles.Key.Render("?") + m.styles.Description.Render(" help") + sep +
 		m.styles.Key.Render("q") + m.styles.Description.Render(" quit")
@@ -1079,12 +1207,30 @@ func (m Model) View() string {
 		return m.workItemsView.StatePickerView()
 	}
 
+	// Work item query picker overlay
+	if m.activeTab == TabWorkItems && m.workItemsView.IsQueryPickerVisible() {
+		m.workItemsView.SetQueryPickerSize(m.width, m.height)
+		return m.workItemsView.QueryPickerView()
+	}
+
+	// Work item create form overlay
+	if m.activeTab == TabWorkItems && m.workItemsView.IsCreateFormVisible() {
+		m.workItemsView.SetCreateFormSize(m.width, m.height)
+		return m.workItemsView.CreateFormView()
+	}
+
 	// If status picker is visible, show it as overlay
 	if m.activeTab == TabPipelines && m.pipelinesView.IsStatusPickerVisible() {
 		m.pipelinesView.SetStatusPickerSize(m.width, m.height)
 		return m.pipelinesView.StatusPickerView()
 	}
 
+	// PR filter panel overlay
+	if m.activeTab == TabPullRequests && m.pullRequestsView.IsFilterPanelVisible() {
+		m.pullRequestsView.SetFilterPanelSize(m.width, m.height)
+		return m.pullRequestsView.FilterPanelView()
+	}
+
 	// Metrics tag picker overlay
 	if m.activeTab == TabMetrics && m.metricsView.IsTagPickerVisible() {
 		m.metricsView.SetTagPickerSize(m.width, m.height)
@@ -1170,12 +1316,25 @@ func (m Model) View() string {
 			m.statusBar.ClearFilterLabel()
 		}
 	} else if m.activeTab == TabPullRequests {
+		var labels []string
 		switch {
 		case m.pullRequestsView.IsMyPRsActive():
-			m.statusBar.SetFilterLabel("My PRs")
+			labels = append(labels, "My PRs")
 		case m.pullRequestsView.IsAsReviewerActive():
-			m.statusBar.SetFilterLabel("Reviewer")
-		default:
+			labels = append(labels, "Reviewer")
+		}
+		if m.pullRequestsView.IsRepoFilterActive() {
+			labels = append(labels, "Repo: "+m.pullRequestsView.ActiveRepo())
+		}
+		if summary := m.pullRequestsView.FilterSummary(); summary != "" {
+			labels = append(labels, "Filter: "+summary)
+		}
+		if order := m.pullRequestsView.SortOrder(); order != "" {
+			labels = append(labels, "Sort: "+order)
+		}
+		if len(labels) > 0 {
+			m.statusBar.SetFilterLabel(strings.Join(labels, " + "))
+		} else {
 			m.statusBar.ClearFilterLabel()
 		}
 	} else if m.activeTab == TabMetrics {
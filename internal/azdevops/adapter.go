@@ -1,7 +1,13 @@
 package azdevops
 
 import (
+	"errors"
 	"fmt"
+	"html"
+	"slices"
+	"sort"
+	"strconv"
+	"time"
 
 	"github.com/Elpulgo/azdo/internal/provider"
 )
@@ -46,54 +52,52 @@ func (a *Adapter) Scopes() []string {
 
 // --- Pull-request surface ---
 
-// ListPullRequests returns up to top active pull requests across all projects,
+// ListPullRequests returns up to top pull requests across all projects,
 // mapping wire types to neutral types with identity stamped per project.
-// opts is accepted for interface compliance; PR filtering is handled by the
-// REST API's searchCriteria parameters rather than WIQL, so opts fields other
-// than Top are unused at this layer (they will be wired in Task 9).
+// The status, target branch and age filters of opts go into the REST API's
+// searchCriteria; the remaining filters are applied to the mapped results.
 func (a *Adapter) ListPullRequests(top int, opts provider.ListOpts) ([]provider.PullRequest, error) {
 	if a.mc == nil {
 		return nil, fmt.Errorf("no client configured")
 	}
-	wire, err := a.mc.ListPullRequests(top)
-	if err != nil {
-		return nil, err
-	}
-	result := make([]provider.PullRequest, len(wire))
-	for i, pr := range wire {
-		result[i] = MapPullRequest(pr, pr.ProjectName, pr.ProjectDisplayName)
-	}
-	return result, nil
+	return a.searchPullRequests(top, BuildPRSearchCriteria(opts), opts)
 }
 
 // ListMyPullRequests returns up to top pull requests created by the
-// authenticated user, mapped to neutral types.
-// opts is accepted for interface compliance; additional filtering will be
-// wired in Task 9.
+// authenticated user, mapped to neutral types and filtered by opts.
 func (a *Adapter) ListMyPullRequests(top int, opts provider.ListOpts) ([]provider.PullRequest, error) {
 	if a.mc == nil {
 		return nil, fmt.Errorf("no client configured")
 	}
-	wire, err := a.mc.ListMyPullRequests(top)
+	userID, err := a.mc.currentUserID()
 	if err != nil {
 		return nil, err
 	}
-	result := make([]provider.PullRequest, len(wire))
-	for i, pr := range wire {
-		result[i] = MapPullRequest(pr, pr.ProjectName, pr.ProjectDisplayName)
-	}
-	return result, nil
+	criteria := BuildPRSearchCriteria(opts)
+	criteria.CreatorID = userID
+	return a.searchPullRequests(top, criteria, opts)
 }
 
 // ListPullRequestsAsReviewer returns up to top pull requests where the
-// authenticated user is a reviewer, mapped to neutral types.
-// opts is accepted for interface compliance; additional filtering will be
-// wired in Task 9.
+// authenticated user is a reviewer, mapped to neutral types and filtered by
+// opts.
 func (a *Adapter) ListPullRequestsAsReviewer(top int, opts provider.ListOpts) ([]provider.PullRequest, error) {
 	if a.mc == nil {
 		return nil, fmt.Errorf("no client configured")
 	}
-	wire, err := a.mc.ListPullRequestsAsReviewer(top)
+	userID, err := a.mc.currentUserID()
+	if err != nil {
+		return nil, err
+	}
+	criteria := BuildPRSearchCriteria(opts)
+	criteria.ReviewerID = userID
+	return a.searchPullRequests(top, criteria, opts)
+}
+
+// searchPullRequests runs criteria across all projects, maps the results
+// and applies the filters of opts the API could not.
+func (a *Adapter) searchPullRequests(top int, criteria PRSearchCriteria, opts provider.ListOpts) ([]provider.PullRequest, error) {
+	wire, err := a.mc.SearchPullRequests(top, criteria)
 	if err != nil {
 		return nil, err
 	}
@@ -101,7 +105,7 @@ func (a *Adapter) ListPullRequestsAsReviewer(top int, opts provider.ListOpts) ([
 	for i, pr := range wire {
 		result[i] = MapPullRequest(pr, pr.ProjectName, pr.ProjectDisplayName)
 	}
-	return result, nil
+	return provider.FilterPullRequests(result, opts), nil
 }
 
 // GetPRThreads returns the comment threads for the given pull request.
@@ -119,9 +123,15 @@ func (a *Adapter) GetPRThreads(scope, repositoryID string, pullRequestID int) ([
 		return nil, err
 	}
 	scopeDisplay := a.mc.DisplayNameFor(scope)
+	// The user ID is fetched once and cached. Without it likes still show,
+	// just not as the user's own.
+	userID, _ := c.GetCurrentUserID()
 	result := make([]provider.Thread, len(wire))
 	for i, t := range wire {
 		result[i] = MapThread(t, scope, scopeDisplay)
+		for j, wc := range t.Comments {
+			result[i].Comments[j].Reactions = mapCommentLikes(wc, userID)
+		}
 	}
 	return result, nil
 }
@@ -194,9 +204,75 @@ func (a *Adapter) GetFileContent(scope, repositoryID string, filePath string, br
 	return c.GetFileContent(repositoryID, filePath, branchName)
 }
 
-// AddPRCodeComment creates a new inline code comment on the given line.
+// GetFileContentAtCommit returns the raw file content at the given commit.
+// scope routes to the correct project sub-client.
+func (a *Adapter) GetFileContentAtCommit(scope, repositoryID string, filePath string, commitID string) (string, error) {
+	if a.mc == nil {
+		return "", fmt.Errorf("no client configured")
+	}
+	c := a.mc.ClientFor(scope)
+	if c == nil {
+		return "", fmt.Errorf("no client for scope %q", scope)
+	}
+	return c.GetFileContentAtCommit(repositoryID, filePath, commitID)
+}
+
+// GetPRCommits returns the commits of the given pull request, oldest first.
+// scope routes to the correct project sub-client.
+func (a *Adapter) GetPRCommits(scope, repositoryID string, pullRequestID int) ([]provider.Commit, error) {
+	if a.mc == nil {
+		return nil, fmt.Errorf("no client configured")
+	}
+	c := a.mc.ClientFor(scope)
+	if c == nil {
+		return nil, fmt.Errorf("no client for scope %q", scope)
+	}
+	wire, err := c.GetPRCommits(repositoryID, pullRequestID)
+	if err != nil {
+		return nil, err
+	}
+	// Azure DevOps lists the newest commit first.
+	result := make([]provider.Commit, len(wire))
+	for i, commit := range wire {
+		result[len(wire)-1-i] = MapCommit(commit)
+	}
+	return result, nil
+}
+
+// GetCommitChanges returns the files changed by a single commit and the
+// first parent they are relative to.
+// scope routes to the correct project sub-client.
+func (a *Adapter) GetCommitChanges(scope, repositoryID string, commitID string) (*provider.CommitChanges, error) {
+	if a.mc == nil {
+		return nil, fmt.Errorf("no client configured")
+	}
+	c := a.mc.ClientFor(scope)
+	if c == nil {
+		return nil, fmt.Errorf("no client for scope %q", scope)
+	}
+	commit, err := c.GetCommit(repositoryID, commitID)
+	if err != nil {
+		return nil, err
+	}
+	wire, err := c.GetCommitChanges(repositoryID, commitID)
+	if err != nil {
+		return nil, err
+	}
+	result := &provider.CommitChanges{}
+	if len(commit.Parents) > 0 {
+		result.ParentID = commit.Parents[0]
+	}
+	for _, change := range wire {
+		result.Changes = append(result.Changes, MapGitChange(change))
+	}
+	return result, nil
+}
+
+// GetPRMergeability re-fetches the pull request for its current merge
+// status (list responses can be stale) and, when it conflicts, lists the
+// conflicting files.
 // scope routes to the correct project sub-client.
-func (a *Adapter) AddPRCodeComment(scope, repositoryID string, pullRequestID int, filePath string, line int, content string) (*provider.Thread, error) {
+func (a *Adapter) GetPRMergeability(scope, repositoryID string, pullRequestID int) (*provider.Mergeability, error) {
 	if a.mc == nil {
 		return nil, fmt.Errorf("no client configured")
 	}
@@ -204,7 +280,35 @@ func (a *Adapter) AddPRCodeComment(scope, repositoryID string, pullRequestID int
 	if c == nil {
 		return nil, fmt.Errorf("no client for scope %q", scope)
 	}
-	wire, err := c.AddPRCodeComment(repositoryID, pullRequestID, filePath, line, content)
+	pr, err := c.GetPullRequest(repositoryID, pullRequestID)
+	if err != nil {
+		return nil, err
+	}
+	result := &provider.Mergeability{Status: MapMergeStatus(pr.MergeStatus)}
+	if result.Status != provider.MergeStatusConflicts {
+		return result, nil
+	}
+	conflicts, err := c.GetPRConflicts(repositoryID, pullRequestID)
+	if err != nil {
+		return nil, err
+	}
+	for _, conflict := range conflicts {
+		result.Conflicts = append(result.Conflicts, MapMergeConflict(conflict))
+	}
+	return result, nil
+}
+
+// AddPRCodeComment creates a new inline code comment anchored to rng.
+// scope routes to the correct project sub-client.
+func (a *Adapter) AddPRCodeComment(scope, repositoryID string, pullRequestID int, filePath string, rng provider.LineRange, content string) (*provider.Thread, error) {
+	if a.mc == nil {
+		return nil, fmt.Errorf("no client configured")
+	}
+	c := a.mc.ClientFor(scope)
+	if c == nil {
+		return nil, fmt.Errorf("no client for scope %q", scope)
+	}
+	wire, err := c.AddPRCodeComment(repositoryID, pullRequestID, threadContextFor(filePath, rng), content)
 	if err != nil {
 		return nil, err
 	}
@@ -264,6 +368,125 @@ func (a *Adapter) UpdateThreadStatus(scope, repositoryID string, pullRequestID i
 	return c.UpdateThreadStatus(repositoryID, pullRequestID, threadID, status)
 }
 
+// EditPRComment replaces the content of a comment in a thread.
+// scope routes to the correct project sub-client.
+func (a *Adapter) EditPRComment(scope, repositoryID string, pullRequestID, threadID, commentID int, content string) error {
+	if a.mc == nil {
+		return fmt.Errorf("no client configured")
+	}
+	c := a.mc.ClientFor(scope)
+	if c == nil {
+		return fmt.Errorf("no client for scope %q", scope)
+	}
+	return c.EditPRComment(repositoryID, pullRequestID, threadID, commentID, content)
+}
+
+// DeletePRComment deletes a comment from a thread.
+// scope routes to the correct project sub-client.
+func (a *Adapter) DeletePRComment(scope, repositoryID string, pullRequestID, threadID, commentID int) error {
+	if a.mc == nil {
+		return fmt.Errorf("no client configured")
+	}
+	c := a.mc.ClientFor(scope)
+	if c == nil {
+		return fmt.Errorf("no client for scope %q", scope)
+	}
+	return c.DeletePRComment(repositoryID, pullRequestID, threadID, commentID)
+}
+
+// ReactToPRComment likes or unlikes a comment. Pull request comments only
+// support likes, so any other reaction returns provider.ErrReactionUnsupported.
+func (a *Adapter) ReactToPRComment(scope, repositoryID string, pullRequestID, threadID, commentID int, reaction provider.Reaction, remove bool) error {
+	if reaction != provider.ReactionLike {
+		return provider.ErrReactionUnsupported
+	}
+	if a.mc == nil {
+		return fmt.Errorf("no client configured")
+	}
+	c := a.mc.ClientFor(scope)
+	if c == nil {
+		return fmt.Errorf("no client for scope %q", scope)
+	}
+	return c.LikePRComment(repositoryID, pullRequestID, threadID, commentID, remove)
+}
+
+// SubmitReview posts each drafted comment as its own thread, then the
+// summary as a general comment, then casts the vote for the verdict. Azure
+// DevOps has no pending-review concept, so a failure part-way returns a
+// *provider.ReviewError recording how many comments, and whether the
+// summary, were already posted.
+// VerdictComment leaves the current vote untouched.
+func (a *Adapter) SubmitReview(scope, repositoryID string, pullRequestID int, review provider.Review) error {
+	if a.mc == nil {
+		return fmt.Errorf("no client configured")
+	}
+	c := a.mc.ClientFor(scope)
+	if c == nil {
+		return fmt.Errorf("no client for scope %q", scope)
+	}
+	for i, rc := range review.Comments {
+		if _, err := c.AddPRCodeComment(repositoryID, pullRequestID, threadContextFor(rc.FilePath, rc.Range), rc.Content); err != nil {
+			return &provider.ReviewError{Posted: i, Err: err}
+		}
+	}
+	posted := len(review.Comments)
+	if review.Body != "" {
+		if _, err := c.AddPRComment(repositoryID, pullRequestID, review.Body); err != nil {
+			return &provider.ReviewError{Posted: posted, Err: err}
+		}
+	}
+	var vote int
+	switch review.Verdict {
+	case provider.VerdictApprove:
+		vote = VoteApprove
+	case provider.VerdictRequestChanges:
+		vote = VoteWaitForAuthor
+	default:
+		return nil
+	}
+	if err := c.VotePullRequest(repositoryID, pullRequestID, vote); err != nil {
+		return &provider.ReviewError{Posted: posted, BodyPosted: review.Body != "", Err: err}
+	}
+	return nil
+}
+
+// ApplyFileEdit commits edit with a push on its branch. The file is read at
+// the branch head commit and the push is based on that commit, so Azure
+// DevOps rejects it if someone pushed in between.
+func (a *Adapter) ApplyFileEdit(scope, repositoryID string, edit provider.FileEdit) error {
+	if a.mc == nil {
+		return fmt.Errorf("no client configured")
+	}
+	c := a.mc.ClientFor(scope)
+	if c == nil {
+		return fmt.Errorf("no client for scope %q", scope)
+	}
+	head, err := c.GetBranchHead(repositoryID, edit.Branch)
+	if err != nil {
+		return err
+	}
+	content, err := c.GetFileContentAtCommit(repositoryID, edit.FilePath, head)
+	if err != nil {
+		return err
+	}
+	updated, err := edit.Apply(content)
+	if err != nil {
+		return err
+	}
+	return c.PushFileEdit(repositoryID, edit.Branch, head, edit.FilePath, updated, edit.Message)
+}
+
+// GetViewedFiles returns nil: Azure DevOps keeps no viewed-file state that
+// the REST API exposes, so viewed marks are local only.
+func (a *Adapter) GetViewedFiles(scope, repositoryID string, pullRequestID int) ([]string, error) {
+	return nil, nil
+}
+
+// SetFileViewed is a no-op on Azure DevOps (see GetViewedFiles).
+func (a *Adapter) SetFileViewed(scope, repositoryID string, pullRequestID int, filePath string, viewed bool) error {
+	return nil
+}
+
 // --- Work-item surface ---
 
 // ListWorkItems returns up to top work items across all projects,
@@ -339,6 +562,612 @@ func (a *Adapter) UpdateWorkItemState(scope string, id int, state string) error
 	return c.UpdateWorkItemState(id, state)
 }
 
+// GetWorkItemTypes returns the work item types that can be created in the
+// project. scope routes to the correct project sub-client.
+func (a *Adapter) GetWorkItemTypes(scope string) ([]string, error) {
+	if a.mc == nil {
+		return nil, fmt.Errorf("no client configured")
+	}
+	c := a.mc.ClientFor(scope)
+	if c == nil {
+		return nil, fmt.Errorf("no client for scope %q", scope)
+	}
+	return c.GetWorkItemTypes()
+}
+
+// CreateWorkItem creates a work item with a JSON Patch document setting
+// each non-empty field, linking it to its parent when one is given.
+// scope routes to the correct project sub-client.
+func (a *Adapter) CreateWorkItem(scope string, item provider.NewWorkItem) (*provider.WorkItem, error) {
+	if a.mc == nil {
+		return nil, fmt.Errorf("no client configured")
+	}
+	c := a.mc.ClientFor(scope)
+	if c == nil {
+		return nil, fmt.Errorf("no client for scope %q", scope)
+	}
+	wi, err := c.CreateWorkItem(item.Type, createOps(c, item))
+	if err != nil {
+		return nil, err
+	}
+	result := MapWorkItem(*wi, scope, a.mc.DisplayNameFor(scope))
+	return &result, nil
+}
+
+// createOps returns the JSON Patch operations creating item.
+func createOps(c *Client, item provider.NewWorkItem) []PatchOperation {
+	ops := []PatchOperation{{Op: "add", Path: FieldPath(FieldTitle), Value: item.Title}}
+	add := func(field string, value any) {
+		ops = append(ops, PatchOperation{Op: "add", Path: FieldPath(field), Value: value})
+	}
+	if item.Description != "" {
+		// System.Description is HTML; the form collects plain text.
+		add(FieldDescription, html.EscapeString(item.Description))
+	}
+	if item.AssignedTo != "" {
+		add(FieldAssignedTo, item.AssignedTo)
+	}
+	if item.Priority > 0 {
+		add(FieldPriority, item.Priority)
+	}
+	if item.IterationPath != "" {
+		add(FieldIterationPath, item.IterationPath)
+	}
+	if item.AreaPath != "" {
+		add(FieldAreaPath, item.AreaPath)
+	}
+	if len(item.Tags) > 0 {
+		add(FieldTags, JoinTags(item.Tags))
+	}
+	if item.ParentID > 0 {
+		ops = append(ops, PatchOperation{Op: "add", Path: "/relations/-", Value: WorkItemRelation{
+			Rel: LinkTypeParent,
+			URL: c.WorkItemURL(item.ParentID),
+		}})
+	}
+	return ops
+}
+
+// GetWorkItem returns a single work item as it is now. scope routes to the
+// correct project sub-client.
+func (a *Adapter) GetWorkItem(scope string, id int) (*provider.WorkItem, error) {
+	if a.mc == nil {
+		return nil, fmt.Errorf("no client configured")
+	}
+	c := a.mc.ClientFor(scope)
+	if c == nil {
+		return nil, fmt.Errorf("no client for scope %q", scope)
+	}
+	wi, err := c.GetWorkItem(id)
+	if err != nil {
+		return nil, err
+	}
+	result := MapWorkItem(*wi, scope, a.mc.DisplayNameFor(scope))
+	return &result, nil
+}
+
+// UpdateWorkItemFields applies update as JSON Patch operations on the
+// fields, preceded by a test of the revision when update.Rev is set. scope
+// routes to the correct project sub-client.
+func (a *Adapter) UpdateWorkItemFields(scope string, id int, update provider.WorkItemUpdate) (*provider.WorkItem, error) {
+	if a.mc == nil {
+		return nil, fmt.Errorf("no client configured")
+	}
+	c := a.mc.ClientFor(scope)
+	if c == nil {
+		return nil, fmt.Errorf("no client for scope %q", scope)
+	}
+	ops, err := updateOps(update)
+	if err != nil {
+		return nil, err
+	}
+	wi, err := c.UpdateWorkItem(id, ops)
+	if errors.Is(err, ErrConflict) {
+		return nil, fmt.Errorf("work item %d: %w", id, provider.ErrWorkItemConflict)
+	}
+	if err != nil {
+		return nil, err
+	}
+	result := MapWorkItem(*wi, scope, a.mc.DisplayNameFor(scope))
+	return &result, nil
+}
+
+// fieldRefNames maps the neutral editable fields to field reference names;
+// any other field is taken to be a reference name already.
+var fieldRefNames = map[provider.WorkItemField]string{
+	provider.FieldTitle:       FieldTitle,
+	provider.FieldAssignedTo:  FieldAssignedTo,
+	provider.FieldPriority:    FieldPriority,
+	provider.FieldStoryPoints: FieldStoryPoints,
+	provider.FieldIteration:   FieldIterationPath,
+	provider.FieldArea:        FieldAreaPath,
+	provider.FieldTags:        FieldTags,
+	provider.FieldDescription: FieldDescription,
+	provider.FieldReproSteps:  FieldReproSteps,
+}
+
+// updateOps returns the JSON Patch operations applying update, in field
+// order so requests are reproducible. Numeric fields are sent as numbers
+// and removed when cleared; other fields are set to the empty string.
+func updateOps(update provider.WorkItemUpdate) ([]PatchOperation, error) {
+	var ops []PatchOperation
+	if update.Rev > 0 {
+		ops = append(ops, PatchOperation{Op: "test", Path: "/rev", Value: update.Rev})
+	}
+	fields := make([]provider.WorkItemField, 0, len(update.Fields))
+	for field := range update.Fields {
+		fields = append(fields, field)
+	}
+	sort.Slice(fields, func(i, j int) bool { return fields[i] < fields[j] })
+
+	for _, field := range fields {
+		value := update.Fields[field]
+		ref, ok := fieldRefNames[field]
+		if !ok {
+			ref = string(field)
+		}
+		switch {
+		case field == provider.FieldTitle && value == "":
+			return nil, fmt.Errorf("the title cannot be empty")
+		case (field == provider.FieldPriority || field == provider.FieldStoryPoints) && value == "":
+			ops = append(ops, PatchOperation{Op: "remove", Path: FieldPath(ref)})
+		case field == provider.FieldPriority:
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("priority must be a whole number, got %q", value)
+			}
+			ops = append(ops, PatchOperation{Op: "add", Path: FieldPath(ref), Value: n})
+		case field == provider.FieldStoryPoints:
+			f, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return nil, fmt.Errorf("story points must be a number, got %q", value)
+			}
+			ops = append(ops, PatchOperation{Op: "add", Path: FieldPath(ref), Value: f})
+		default:
+			ops = append(ops, PatchOperation{Op: "add", Path: FieldPath(ref), Value: value})
+		}
+	}
+	return ops, nil
+}
+
+// GetFieldOptions returns the project's iteration or area paths, or the
+// default team's members as "Name <unique name>", which System.AssignedTo
+// accepts. Other fields have no options. scope routes to the correct
+// project sub-client.
+func (a *Adapter) GetFieldOptions(scope string, field provider.WorkItemField) ([]string, error) {
+	if a.mc == nil {
+		return nil, fmt.Errorf("no client configured")
+	}
+	c := a.mc.ClientFor(scope)
+	if c == nil {
+		return nil, fmt.Errorf("no client for scope %q", scope)
+	}
+	switch field {
+	case provider.FieldIteration:
+		return c.GetClassificationPaths("Iterations")
+	case provider.FieldArea:
+		return c.GetClassificationPaths("Areas")
+	case provider.FieldAssignedTo:
+		members, err := c.GetTeamMembers()
+		if err != nil {
+			return nil, err
+		}
+		options := make([]string, len(members))
+		for i, m := range members {
+			options[i] = fmt.Sprintf("%s <%s>", m.DisplayName, m.UniqueName)
+		}
+		sort.Strings(options)
+		return options, nil
+	}
+	return nil, nil
+}
+
+// GetWorkItemHistory returns the revisions of the given work item, oldest
+// first, read from its update history. scope routes to the correct
+// project sub-client.
+func (a *Adapter) GetWorkItemHistory(scope string, id int) ([]provider.WorkItemRevision, error) {
+	if a.mc == nil {
+		return nil, fmt.Errorf("no client configured")
+	}
+	c := a.mc.ClientFor(scope)
+	if c == nil {
+		return nil, fmt.Errorf("no client for scope %q", scope)
+	}
+	updates, err := c.GetWorkItemUpdates(id)
+	if err != nil {
+		return nil, err
+	}
+	return MapWorkItemUpdates(updates), nil
+}
+
+// GetWorkItemAttachments returns the files attached to the given work
+// item. Who attached each file is read from the item's update history;
+// when that cannot be fetched the attachments are returned without it.
+// scope routes to the correct project sub-client.
+func (a *Adapter) GetWorkItemAttachments(scope string, id int) ([]provider.WorkItemAttachment, error) {
+	if a.mc == nil {
+		return nil, fmt.Errorf("no client configured")
+	}
+	c := a.mc.ClientFor(scope)
+	if c == nil {
+		return nil, fmt.Errorf("no client for scope %q", scope)
+	}
+	wi, err := c.GetWorkItemRelations(id)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.ContainsFunc(wi.Relations, func(r WorkItemRelation) bool { return r.Rel == LinkTypeAttachedFile }) {
+		return nil, nil
+	}
+	updates, _ := c.GetWorkItemUpdates(id)
+	return MapAttachments(wi.Relations, updates), nil
+}
+
+// DownloadWorkItemAttachment returns the content of an attachment.
+// scope routes to the correct project sub-client.
+func (a *Adapter) DownloadWorkItemAttachment(scope string, attachment provider.WorkItemAttachment) ([]byte, error) {
+	if a.mc == nil {
+		return nil, fmt.Errorf("no client configured")
+	}
+	c := a.mc.ClientFor(scope)
+	if c == nil {
+		return nil, fmt.Errorf("no client for scope %q", scope)
+	}
+	return c.DownloadAttachment(attachment.URL)
+}
+
+// AddWorkItemAttachment uploads content to the project's attachment store
+// and attaches it to the given work item. scope routes to the correct
+// project sub-client.
+func (a *Adapter) AddWorkItemAttachment(scope string, id int, name string, content []byte) (*provider.WorkItemAttachment, error) {
+	if a.mc == nil {
+		return nil, fmt.Errorf("no client configured")
+	}
+	c := a.mc.ClientFor(scope)
+	if c == nil {
+		return nil, fmt.Errorf("no client for scope %q", scope)
+	}
+	ref, err := c.UploadAttachment(name, content)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.AddAttachmentRelation(id, ref.URL); err != nil {
+		return nil, err
+	}
+	return &provider.WorkItemAttachment{Name: name, URL: ref.URL, Size: int64(len(content))}, nil
+}
+
+// linkRels maps the neutral link types to relation types.
+var linkRels = map[provider.WorkItemLinkType]string{
+	provider.LinkParent:  LinkTypeParent,
+	provider.LinkChild:   LinkTypeChild,
+	provider.LinkRelated: LinkTypeRelated,
+}
+
+// GetWorkItemLinks returns the work items the given one links to as its
+// parent, children and related items, read from its relations. scope routes
+// to the correct project sub-client.
+func (a *Adapter) GetWorkItemLinks(scope string, id int) ([]provider.WorkItemLink, error) {
+	if a.mc == nil {
+		return nil, fmt.Errorf("no client configured")
+	}
+	c := a.mc.ClientFor(scope)
+	if c == nil {
+		return nil, fmt.Errorf("no client for scope %q", scope)
+	}
+	wi, err := c.GetWorkItemRelations(id)
+	if err != nil {
+		return nil, err
+	}
+
+	order := []provider.WorkItemLinkType{provider.LinkParent, provider.LinkChild, provider.LinkRelated}
+	targets := map[provider.WorkItemLinkType][]int{}
+	var ids []int
+	for _, r := range wi.Relations {
+		target := RelationTargetID(r)
+		if target == 0 {
+			continue
+		}
+		for _, link := range order {
+			if linkRels[link] == r.Rel {
+				targets[link] = append(targets[link], target)
+				ids = append(ids, target)
+			}
+		}
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	items, err := c.GetWorkItems(ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[int]WorkItem, len(items))
+	for _, item := range items {
+		byID[item.ID] = item
+	}
+	var links []provider.WorkItemLink
+	for _, link := range order {
+		sort.Ints(targets[link])
+		for _, target := range targets[link] {
+			if item, ok := byID[target]; ok {
+				links = append(links, provider.WorkItemLink{
+					Type: link,
+					Item: MapWorkItem(item, scope, a.mc.DisplayNameFor(scope)),
+				})
+			}
+		}
+	}
+	return links, nil
+}
+
+// AddWorkItemLink adds a relation from the given work item to targetID.
+// scope routes to the correct project sub-client.
+func (a *Adapter) AddWorkItemLink(scope string, id int, link provider.WorkItemLinkType, targetID int) error {
+	if a.mc == nil {
+		return fmt.Errorf("no client configured")
+	}
+	c := a.mc.ClientFor(scope)
+	if c == nil {
+		return fmt.Errorf("no client for scope %q", scope)
+	}
+	rel, ok := linkRels[link]
+	if !ok {
+		return provider.ErrLinkUnsupported
+	}
+	return c.AddWorkItemRelation(id, rel, targetID)
+}
+
+// RemoveWorkItemLink removes the relation from the given work item to
+// targetID. scope routes to the correct project sub-client.
+func (a *Adapter) RemoveWorkItemLink(scope string, id int, link provider.WorkItemLinkType, targetID int) error {
+	if a.mc == nil {
+		return fmt.Errorf("no client configured")
+	}
+	c := a.mc.ClientFor(scope)
+	if c == nil {
+		return fmt.Errorf("no client for scope %q", scope)
+	}
+	rel, ok := linkRels[link]
+	if !ok {
+		return provider.ErrLinkUnsupported
+	}
+	err := c.RemoveWorkItemRelation(id, rel, targetID)
+	if errors.Is(err, ErrConflict) {
+		return fmt.Errorf("work item %d: %w", id, provider.ErrWorkItemConflict)
+	}
+	return err
+}
+
+// ListWorkItemQueries returns the saved queries of the project. scope
+// routes to the correct project sub-client.
+func (a *Adapter) ListWorkItemQueries(scope string) ([]provider.WorkItemQuery, error) {
+	if a.mc == nil {
+		return nil, fmt.Errorf("no client configured")
+	}
+	c := a.mc.ClientFor(scope)
+	if c == nil {
+		return nil, fmt.Errorf("no client for scope %q", scope)
+	}
+	wire, err := c.ListQueries()
+	if err != nil {
+		return nil, err
+	}
+	queries := make([]provider.WorkItemQuery, len(wire))
+	for i, q := range wire {
+		queries[i] = provider.WorkItemQuery{
+			ID:    q.ID,
+			Name:  q.Name,
+			Path:  q.Path,
+			Scope: scope,
+			WIQL:  q.WIQL,
+			Tree:  q.QueryType == "tree" || q.QueryType == "oneHop",
+		}
+	}
+	return queries, nil
+}
+
+// RunWorkItemQuery runs a saved query by ID, or else the query's WIQL or
+// the WIQL built from its criteria, and fetches the result's work items
+// with the column fields. scope routes to the correct project sub-client.
+func (a *Adapter) RunWorkItemQuery(scope string, query provider.WorkItemQuery) (*provider.WorkItemQueryResult, error) {
+	if a.mc == nil {
+		return nil, fmt.Errorf("no client configured")
+	}
+	c := a.mc.ClientFor(scope)
+	if c == nil {
+		return nil, fmt.Errorf("no client for scope %q", scope)
+	}
+
+	top := query.Top
+	if top <= 0 {
+		top = DefaultQueryTop
+	}
+	var resp *WIQLResponse
+	var err error
+	switch {
+	case query.ID != "":
+		resp, err = c.RunQuery(query.ID, top)
+	case query.WIQL != "":
+		resp, err = c.RunWIQL(query.WIQL, top)
+	default:
+		resp, err = c.RunWIQL(BuildCriteriaWIQL(query.Criteria), top)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make([]provider.WorkItemQueryColumn, 0, len(resp.Columns))
+	for _, col := range resp.Columns {
+		columns = append(columns, provider.WorkItemQueryColumn{Field: col.ReferenceName, Name: col.Name})
+	}
+	if len(query.Columns) > 0 {
+		columns = columns[:0]
+		for _, field := range query.Columns {
+			columns = append(columns, provider.WorkItemQueryColumn{Field: field, Name: fieldDisplayName(field)})
+		}
+	}
+	fields := make([]string, len(columns))
+	for i, col := range columns {
+		fields[i] = col.Field
+	}
+
+	ids, parents := resp.ResultIDs()
+	wire, err := c.GetWorkItemsWithFields(ids, fields)
+	if err != nil {
+		return nil, err
+	}
+	display := a.mc.DisplayNameFor(scope)
+	result := &provider.WorkItemQueryResult{
+		Items:   make([]provider.WorkItem, len(wire)),
+		Columns: columns,
+		Tree:    query.Tree || resp.IsLinkResult(),
+	}
+	for i, wi := range wire {
+		item := MapWorkItem(wi, scope, display)
+		if resp.IsLinkResult() {
+			item.ParentID = parents[wi.ID]
+		}
+		item.Fields = make(map[string]string, len(columns))
+		for _, col := range columns {
+			item.Fields[col.Field] = FieldValueText(fieldValue(wi.Fields.Values, col.Field))
+		}
+		result.Items[i] = item
+	}
+	return result, nil
+}
+
+// GetBoards returns the boards of the configured or default team, one per
+// backlog level. scope routes to the correct per-project Client.
+func (a *Adapter) GetBoards(scope string) ([]provider.Board, error) {
+	if a.mc == nil {
+		return nil, fmt.Errorf("no client configured")
+	}
+	c := a.mc.ClientFor(scope)
+	if c == nil {
+		return nil, fmt.Errorf("no client for scope %q", scope)
+	}
+	boards, err := c.GetTeamBoards()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]provider.Board, len(boards))
+	for i, b := range boards {
+		result[i] = MapBoard(b)
+	}
+	return result, nil
+}
+
+// MoveWorkItemOnBoard sets the state the column maps workItemType to and,
+// when the board keeps its own column field, the column. scope routes to
+// the correct per-project Client.
+func (a *Adapter) MoveWorkItemOnBoard(scope string, id int, workItemType string, board provider.Board, column string) error {
+	if a.mc == nil {
+		return fmt.Errorf("no client configured")
+	}
+	c := a.mc.ClientFor(scope)
+	if c == nil {
+		return fmt.Errorf("no client for scope %q", scope)
+	}
+	for _, col := range board.Columns {
+		if col.Name != column {
+			continue
+		}
+		state, ok := col.StateFor(workItemType)
+		if !ok {
+			return fmt.Errorf("%s items cannot be moved to column %q", workItemType, column)
+		}
+		ops := []PatchOperation{{Op: "add", Path: FieldPath("System.State"), Value: state}}
+		if board.ColumnField != "" {
+			ops = append(ops, PatchOperation{Op: "add", Path: FieldPath(board.ColumnField), Value: column})
+		}
+		_, err := c.UpdateWorkItem(id, ops)
+		return err
+	}
+	return fmt.Errorf("board %q has no column %q", board.Name, column)
+}
+
+// GetSprints returns the iterations the configured or default team has
+// selected, ordered by date, with the one under way marked current. scope
+// routes to the correct per-project Client.
+func (a *Adapter) GetSprints(scope string) ([]provider.Sprint, error) {
+	if a.mc == nil {
+		return nil, fmt.Errorf("no client configured")
+	}
+	c := a.mc.ClientFor(scope)
+	if c == nil {
+		return nil, fmt.Errorf("no client for scope %q", scope)
+	}
+	team, err := c.teamOrDefault()
+	if err != nil {
+		return nil, err
+	}
+	iterations, err := c.ListTeamIterations(team, "")
+	if err != nil {
+		return nil, err
+	}
+	current, err := c.ListTeamIterations(team, "current")
+	if err != nil {
+		return nil, err
+	}
+	sprints := make([]provider.Sprint, len(iterations))
+	for i, it := range iterations {
+		sprints[i] = MapTeamIteration(it)
+		for _, cur := range current {
+			if cur.ID == it.ID {
+				sprints[i].Current = true
+			}
+		}
+	}
+	return sprints, nil
+}
+
+// GetSprintBacklog returns the work items planned into sprint and the
+// hours each team member with capacity has left in it. scope routes to
+// the correct per-project Client.
+func (a *Adapter) GetSprintBacklog(scope string, sprint provider.Sprint) (*provider.SprintBacklog, error) {
+	if a.mc == nil {
+		return nil, fmt.Errorf("no client configured")
+	}
+	c := a.mc.ClientFor(scope)
+	if c == nil {
+		return nil, fmt.Errorf("no client for scope %q", scope)
+	}
+	team, err := c.teamOrDefault()
+	if err != nil {
+		return nil, err
+	}
+	ids, err := c.GetIterationWorkItemIDs(team, sprint.ID)
+	if err != nil {
+		return nil, err
+	}
+	wire, err := c.GetWorkItemsWithFields(ids, nil)
+	if err != nil {
+		return nil, err
+	}
+	members, err := c.GetIterationCapacities(team, sprint.ID)
+	if err != nil {
+		return nil, err
+	}
+	daysOff, err := c.GetIterationDaysOff(team, sprint.ID)
+	if err != nil {
+		return nil, err
+	}
+	workingDays, err := c.GetTeamWorkingDays(team)
+	if err != nil {
+		return nil, err
+	}
+
+	display := a.mc.DisplayNameFor(scope)
+	backlog := &provider.SprintBacklog{Items: make([]provider.WorkItem, len(wire))}
+	for i, wi := range wire {
+		backlog.Items[i] = MapWorkItem(wi, scope, display)
+	}
+	backlog.DaysLeft, backlog.Capacity = MapSprintCapacity(sprint, members, daysOff, workingDays, time.Now())
+	return backlog, nil
+}
+
 // GetWorkItemComments returns discussion comments for the given work item,
 // ordered newest first. scope routes to the correct project sub-client.
 func (a *Adapter) GetWorkItemComments(scope string, id int) ([]provider.WorkItemComment, error) {
@@ -380,6 +1209,113 @@ func (a *Adapter) AddWorkItemComment(scope string, id int, text string) (*provid
 	return &mapped, nil
 }
 
+// AddWorkItemCommentMarkdown posts a new comment stored as markdown.
+// scope routes to the correct project sub-client.
+func (a *Adapter) AddWorkItemCommentMarkdown(scope string, id int, markdown string) (*provider.WorkItemComment, error) {
+	if a.mc == nil {
+		return nil, fmt.Errorf("no client configured")
+	}
+	c := a.mc.ClientFor(scope)
+	if c == nil {
+		return nil, fmt.Errorf("no client for scope %q", scope)
+	}
+	wire, err := c.AddWorkItemCommentMarkdown(id, markdown)
+	if err != nil {
+		return nil, err
+	}
+	scopeDisplay := a.mc.DisplayNameFor(scope)
+	mapped := MapWorkItemComment(*wire, scope, scopeDisplay)
+	return &mapped, nil
+}
+
+// EditWorkItemComment replaces the text of a work-item comment.
+// scope routes to the correct project sub-client.
+func (a *Adapter) EditWorkItemComment(scope string, id, commentID int, text string) error {
+	if a.mc == nil {
+		return fmt.Errorf("no client configured")
+	}
+	c := a.mc.ClientFor(scope)
+	if c == nil {
+		return fmt.Errorf("no client for scope %q", scope)
+	}
+	return c.UpdateWorkItemComment(id, commentID, text)
+}
+
+// DeleteWorkItemComment deletes a work-item comment.
+// scope routes to the correct project sub-client.
+func (a *Adapter) DeleteWorkItemComment(scope string, id, commentID int) error {
+	if a.mc == nil {
+		return fmt.Errorf("no client configured")
+	}
+	c := a.mc.ClientFor(scope)
+	if c == nil {
+		return fmt.Errorf("no client for scope %q", scope)
+	}
+	return c.DeleteWorkItemComment(id, commentID)
+}
+
+// ReactToWorkItemComment adds or removes a reaction on a work-item comment.
+// Rocket and eyes have no Azure DevOps equivalent and return
+// provider.ErrReactionUnsupported.
+func (a *Adapter) ReactToWorkItemComment(scope string, id, commentID int, reaction provider.Reaction, remove bool) error {
+	wire, ok := workItemReactionTypes[reaction]
+	if !ok {
+		return provider.ErrReactionUnsupported
+	}
+	if a.mc == nil {
+		return fmt.Errorf("no client configured")
+	}
+	c := a.mc.ClientFor(scope)
+	if c == nil {
+		return fmt.Errorf("no client for scope %q", scope)
+	}
+	return c.SetWorkItemCommentReaction(id, commentID, wire, remove)
+}
+
+// --- People ---
+
+// SearchPeople returns the organization's users matching query, with
+// "@<GUID>" mention markup. scope routes to the correct project sub-client.
+func (a *Adapter) SearchPeople(scope, query string) ([]provider.Person, error) {
+	if a.mc == nil {
+		return nil, fmt.Errorf("no client configured")
+	}
+	c := a.mc.ClientFor(scope)
+	if c == nil {
+		return nil, fmt.Errorf("no client for scope %q", scope)
+	}
+	wire, err := c.SearchIdentities(query)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]provider.Person, len(wire))
+	for i, id := range wire {
+		result[i] = MapPickerIdentity(id)
+	}
+	return result, nil
+}
+
+// ResolvePeople looks up the identities behind "@<GUID>" mentions.
+// scope routes to the correct project sub-client.
+func (a *Adapter) ResolvePeople(scope string, ids []string) ([]provider.Person, error) {
+	if a.mc == nil {
+		return nil, fmt.Errorf("no client configured")
+	}
+	c := a.mc.ClientFor(scope)
+	if c == nil {
+		return nil, fmt.Errorf("no client for scope %q", scope)
+	}
+	wire, err := c.GetIdentities(ids)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]provider.Person, len(wire))
+	for i, id := range wire {
+		result[i] = MapIdentityRecord(id)
+	}
+	return result, nil
+}
+
 // --- Pipeline surface ---
 
 // ListPipelineRuns returns up to top recent pipeline runs across all projects,
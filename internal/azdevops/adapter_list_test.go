@@ -3,6 +3,7 @@ package azdevops_test
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/Elpulgo/azdo/internal/azdevops"
 	"github.com/Elpulgo/azdo/internal/provider"
@@ -205,3 +206,48 @@ func TestStateCategoryToWIQLStates(t *testing.T) {
 		})
 	}
 }
+
+// TestBuildPRSearchCriteria verifies which pull request filters of ListOpts
+// are pushed into the searchCriteria of the PR list endpoint.
+func TestBuildPRSearchCriteria(t *testing.T) {
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		opts provider.ListOpts
+		want azdevops.PRSearchCriteria
+	}{
+		{
+			name: "zero value — default active query",
+			opts: provider.ListOpts{},
+			want: azdevops.PRSearchCriteria{},
+		},
+		{
+			name: "single status",
+			opts: provider.ListOpts{States: []provider.StateCategory{provider.StateCategoryRemoved}},
+			want: azdevops.PRSearchCriteria{Status: "abandoned"},
+		},
+		{
+			name: "several statuses fetch all",
+			opts: provider.ListOpts{States: []provider.StateCategory{provider.StateCategoryActive, provider.StateCategoryClosedDone}},
+			want: azdevops.PRSearchCriteria{Status: "all"},
+		},
+		{
+			name: "target branch and age",
+			opts: provider.ListOpts{TargetBranch: "main", CreatedAfter: since},
+			want: azdevops.PRSearchCriteria{TargetRefName: "refs/heads/main", MinTime: since},
+		},
+		{
+			name: "post-filtered fields are not sent",
+			opts: provider.ListOpts{Repository: "repo", Author: "ann", Labels: []string{"bug"}, Draft: provider.DraftOnly},
+			want: azdevops.PRSearchCriteria{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := azdevops.BuildPRSearchCriteria(tt.opts); got != tt.want {
+				t.Errorf("BuildPRSearchCriteria(%+v) = %+v, want %+v", tt.opts, got, tt.want)
+			}
+		})
+	}
+}
@@ -1,7 +1,12 @@
 package azdevops_test
 
 import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"sort"
+	"strings"
 	"testing"
 
 	"github.com/Elpulgo/azdo/internal/azdevops"
@@ -87,3 +92,368 @@ func TestAdapterScopes(t *testing.T) {
 		})
 	}
 }
+
+// newReviewTestAdapter returns an adapter whose "proj" client talks to a
+// server that records each request and fails the threads call number
+// failThread (1-based; 0 never fails).
+func newReviewTestAdapter(t *testing.T, failThread int) (*azdevops.Adapter, *[]string) {
+	t.Helper()
+	var calls []string
+	threads := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		if strings.HasSuffix(r.URL.Path, "/threads") {
+			threads++
+			if threads == failThread {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id": 1, "status": "active", "comments": []}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	mc, err := azdevops.NewMultiClient("org", []string{"proj"}, "pat", nil)
+	if err != nil {
+		t.Fatalf("NewMultiClient: %v", err)
+	}
+	mc.ClientFor("proj").SetBaseURL(srv.URL)
+	mc.ClientFor("proj").SetUserID("me")
+	return azdevops.NewAdapter(mc), &calls
+}
+
+func TestAdapter_SubmitReview_PostsThreadsSummaryThenVote(t *testing.T) {
+	a, calls := newReviewTestAdapter(t, 0)
+	review := provider.Review{
+		Verdict: provider.VerdictApprove,
+		Body:    "Looks good overall",
+		Comments: []provider.ReviewComment{
+			{FilePath: "/a.go", Range: provider.LineAt(provider.SideRight, 3), Content: "nit"},
+			{FilePath: "/b.go", Range: provider.LineAt(provider.SideLeft, 7), Content: "why?"},
+		},
+	}
+	if err := a.SubmitReview("proj", "repo", 5, review); err != nil {
+		t.Fatalf("SubmitReview() error = %v", err)
+	}
+	want := []string{
+		"POST /git/repositories/repo/pullRequests/5/threads",
+		"POST /git/repositories/repo/pullRequests/5/threads",
+		"POST /git/repositories/repo/pullRequests/5/threads",
+		"PUT /git/repositories/repo/pullRequests/5/reviewers/me",
+	}
+	if strings.Join(*calls, "\n") != strings.Join(want, "\n") {
+		t.Errorf("calls = %v, want %v", *calls, want)
+	}
+}
+
+func TestAdapter_SubmitReview_CommentVerdictDoesNotVote(t *testing.T) {
+	a, calls := newReviewTestAdapter(t, 0)
+	review := provider.Review{
+		Verdict:  provider.VerdictComment,
+		Comments: []provider.ReviewComment{{FilePath: "/a.go", Range: provider.LineAt(provider.SideRight, 1), Content: "x"}},
+	}
+	if err := a.SubmitReview("proj", "repo", 5, review); err != nil {
+		t.Fatalf("SubmitReview() error = %v", err)
+	}
+	if len(*calls) != 1 {
+		t.Errorf("calls = %v, want only the thread POST", *calls)
+	}
+}
+
+func TestAdapter_SubmitReview_PartialFailureReportsPosted(t *testing.T) {
+	a, calls := newReviewTestAdapter(t, 2)
+	review := provider.Review{
+		Verdict: provider.VerdictRequestChanges,
+		Comments: []provider.ReviewComment{
+			{FilePath: "/a.go", Range: provider.LineAt(provider.SideRight, 1), Content: "one"},
+			{FilePath: "/a.go", Range: provider.LineAt(provider.SideRight, 2), Content: "two"},
+			{FilePath: "/a.go", Range: provider.LineAt(provider.SideRight, 3), Content: "three"},
+		},
+	}
+	err := a.SubmitReview("proj", "repo", 5, review)
+	var reviewErr *provider.ReviewError
+	if !errors.As(err, &reviewErr) {
+		t.Fatalf("SubmitReview() error = %v, want *provider.ReviewError", err)
+	}
+	if reviewErr.Posted != 1 {
+		t.Errorf("Posted = %d, want 1", reviewErr.Posted)
+	}
+	for _, c := range *calls {
+		if strings.HasPrefix(c, "PUT") {
+			t.Errorf("vote cast despite failed thread: %v", *calls)
+		}
+	}
+}
+
+func TestAdapter_SubmitReview_VoteFailureReportsPostedSummary(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PUT" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id": 1, "status": "active", "comments": []}`))
+	}))
+	defer srv.Close()
+	mc, _ := azdevops.NewMultiClient("org", []string{"proj"}, "pat", nil)
+	mc.ClientFor("proj").SetBaseURL(srv.URL)
+	mc.ClientFor("proj").SetUserID("me")
+
+	review := provider.Review{
+		Verdict:  provider.VerdictApprove,
+		Body:     "Looks good",
+		Comments: []provider.ReviewComment{{FilePath: "/a.go", Range: provider.LineAt(provider.SideRight, 1), Content: "nit"}},
+	}
+	err := azdevops.NewAdapter(mc).SubmitReview("proj", "repo", 5, review)
+	var reviewErr *provider.ReviewError
+	if !errors.As(err, &reviewErr) {
+		t.Fatalf("SubmitReview() error = %v, want *provider.ReviewError", err)
+	}
+	if reviewErr.Posted != 1 || !reviewErr.BodyPosted {
+		t.Errorf("ReviewError = %+v, want the comment and the summary posted", reviewErr)
+	}
+}
+
+func TestAdapter_ApplyFileEdit_PushesOnBranchHead(t *testing.T) {
+	var calls []string
+	var pushBody string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/refs"):
+			w.Write([]byte(`{"value": [{"name": "refs/heads/feat", "objectId": "head1"}]}`))
+		case strings.HasSuffix(r.URL.Path, "/items"):
+			if r.URL.Query().Get("versionType") != "commit" || r.URL.Query().Get("version") != "head1" {
+				t.Errorf("items query = %s, want the branch head commit", r.URL.RawQuery)
+			}
+			w.Write([]byte("a\nb\n"))
+		case strings.HasSuffix(r.URL.Path, "/pushes"):
+			buf := new(strings.Builder)
+			_, _ = io.Copy(buf, r.Body)
+			pushBody = buf.String()
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	mc, err := azdevops.NewMultiClient("org", []string{"proj"}, "pat", nil)
+	if err != nil {
+		t.Fatalf("NewMultiClient: %v", err)
+	}
+	mc.ClientFor("proj").SetBaseURL(srv.URL)
+	a := azdevops.NewAdapter(mc)
+
+	edit := provider.FileEdit{
+		Branch: "feat", FilePath: "/a.go", StartLine: 2, EndLine: 2,
+		Expected: []string{"b"}, Lines: []string{"B"}, Message: "Apply suggestion",
+	}
+	if err := a.ApplyFileEdit("proj", "repo", edit); err != nil {
+		t.Fatalf("ApplyFileEdit() error = %v", err)
+	}
+	if len(calls) != 3 || !strings.HasPrefix(calls[2], "POST") {
+		t.Fatalf("calls = %v, want refs, items, push", calls)
+	}
+	for _, want := range []string{`"oldObjectId":"head1"`, `"content":"a\nB\n"`, `"comment":"Apply suggestion"`} {
+		if !strings.Contains(pushBody, want) {
+			t.Errorf("push body missing %s: %s", want, pushBody)
+		}
+	}
+}
+
+func TestAdapter_GetPRThreads_MarksOwnLikes(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"value": [{"id": 1, "status": "active", "comments": [
+			{"id": 1, "content": "nit", "commentType": "text", "author": {"id": "u1"},
+			 "usersLiked": [{"id": "u2"}, {"id": "me"}]}
+		]}], "count": 1}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	mc, err := azdevops.NewMultiClient("org", []string{"proj"}, "pat", nil)
+	if err != nil {
+		t.Fatalf("NewMultiClient: %v", err)
+	}
+	mc.ClientFor("proj").SetBaseURL(srv.URL)
+	mc.ClientFor("proj").SetUserID("me")
+	a := azdevops.NewAdapter(mc)
+
+	threads, err := a.GetPRThreads("proj", "repo", 1)
+	if err != nil {
+		t.Fatalf("GetPRThreads() error = %v", err)
+	}
+	if len(threads) != 1 || len(threads[0].Comments) != 1 {
+		t.Fatalf("threads = %+v", threads)
+	}
+	want := provider.ReactionCount{Reaction: provider.ReactionLike, Count: 2, Mine: true}
+	if got := threads[0].Comments[0].Reactions; len(got) != 1 || got[0] != want {
+		t.Errorf("Reactions = %+v, want [%+v]", got, want)
+	}
+}
+
+func TestAdapter_ReactToPRComment_OnlyLikes(t *testing.T) {
+	var calls []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	mc, err := azdevops.NewMultiClient("org", []string{"proj"}, "pat", nil)
+	if err != nil {
+		t.Fatalf("NewMultiClient: %v", err)
+	}
+	mc.ClientFor("proj").SetBaseURL(srv.URL)
+	a := azdevops.NewAdapter(mc)
+
+	if err := a.ReactToPRComment("proj", "repo", 1, 2, 3, provider.ReactionHeart, false); !errors.Is(err, provider.ErrReactionUnsupported) {
+		t.Errorf("heart error = %v, want ErrReactionUnsupported", err)
+	}
+	if err := a.ReactToPRComment("proj", "repo", 1, 2, 3, provider.ReactionLike, false); err != nil {
+		t.Fatalf("like error = %v", err)
+	}
+	if len(calls) != 1 || calls[0] != "POST /git/repositories/repo/pullRequests/1/threads/2/comments/3/likes" {
+		t.Errorf("calls = %v, want one like", calls)
+	}
+}
+
+func TestAdapter_GetPRCommits_OldestFirst(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"count": 2, "value": [{"commitId": "new"}, {"commitId": "old"}]}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	mc, err := azdevops.NewMultiClient("org", []string{"proj"}, "pat", nil)
+	if err != nil {
+		t.Fatalf("NewMultiClient: %v", err)
+	}
+	mc.ClientFor("proj").SetBaseURL(srv.URL)
+	a := azdevops.NewAdapter(mc)
+
+	got, err := a.GetPRCommits("proj", "repo", 1)
+	if err != nil {
+		t.Fatalf("GetPRCommits() error = %v", err)
+	}
+	if len(got) != 2 || got[0].ID != "old" || got[1].ID != "new" {
+		t.Errorf("GetPRCommits() = %+v, want old then new", got)
+	}
+}
+
+func TestAdapter_GetCommitChanges_UsesFirstParent(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/changes") {
+			w.Write([]byte(`{"changes": [{"item": {"path": "/a.go", "gitObjectType": "blob"}, "changeType": "edit"}]}`))
+			return
+		}
+		w.Write([]byte(`{"commitId": "abc", "parents": ["p1", "p2"]}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	mc, err := azdevops.NewMultiClient("org", []string{"proj"}, "pat", nil)
+	if err != nil {
+		t.Fatalf("NewMultiClient: %v", err)
+	}
+	mc.ClientFor("proj").SetBaseURL(srv.URL)
+	a := azdevops.NewAdapter(mc)
+
+	got, err := a.GetCommitChanges("proj", "repo", "abc")
+	if err != nil {
+		t.Fatalf("GetCommitChanges() error = %v", err)
+	}
+	if got.ParentID != "p1" || len(got.Changes) != 1 || got.Changes[0].Path != "/a.go" {
+		t.Errorf("GetCommitChanges() = %+v, want parent p1 and /a.go", got)
+	}
+}
+
+func TestAdapter_GetPRMergeability_ListsConflictsOnlyWhenConflicting(t *testing.T) {
+	tests := []struct {
+		name          string
+		mergeStatus   string
+		wantStatus    provider.MergeStatus
+		wantConflicts int
+	}{
+		{name: "conflicts", mergeStatus: "conflicts", wantStatus: provider.MergeStatusConflicts, wantConflicts: 1},
+		{name: "clean", mergeStatus: "succeeded", wantStatus: provider.MergeStatusMergeable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls = append(calls, r.URL.Path)
+				if strings.HasSuffix(r.URL.Path, "/conflicts") {
+					w.Write([]byte(`{"count": 1, "value": [{"conflictId": 7, "conflictPath": "/a.go",
+						"conflictType": "editEdit", "mergeBaseCommit": {"commitId": "base"},
+						"mergeSourceCommit": {"commitId": "src"}, "mergeTargetCommit": {"commitId": "tgt"}}]}`))
+					return
+				}
+				w.Write([]byte(`{"pullRequestId": 1, "mergeStatus": "` + tt.mergeStatus + `"}`))
+			}))
+			t.Cleanup(srv.Close)
+
+			mc, err := azdevops.NewMultiClient("org", []string{"proj"}, "pat", nil)
+			if err != nil {
+				t.Fatalf("NewMultiClient: %v", err)
+			}
+			mc.ClientFor("proj").SetBaseURL(srv.URL)
+			a := azdevops.NewAdapter(mc)
+
+			got, err := a.GetPRMergeability("proj", "repo", 1)
+			if err != nil {
+				t.Fatalf("GetPRMergeability() error = %v", err)
+			}
+			if got.Status != tt.wantStatus || len(got.Conflicts) != tt.wantConflicts {
+				t.Fatalf("GetPRMergeability() = %+v, want status %v with %d conflicts (calls %v)",
+					got, tt.wantStatus, tt.wantConflicts, calls)
+			}
+			if tt.wantConflicts == 0 {
+				return
+			}
+			want := provider.MergeConflict{ID: 7, Path: "/a.go", Kind: "editEdit",
+				BaseCommit: "base", SourceCommit: "src", TargetCommit: "tgt"}
+			if got.Conflicts[0] != want {
+				t.Errorf("Conflicts[0] = %+v, want %+v", got.Conflicts[0], want)
+			}
+		})
+	}
+}
+
+func TestAdapter_ListPullRequests_AppliesFilters(t *testing.T) {
+	var status string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status = r.URL.Query().Get("searchCriteria.status")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"count": 3, "value": [
+			{"pullRequestId": 1, "status": "completed", "repository": {"name": "api"}, "isDraft": true},
+			{"pullRequestId": 2, "status": "completed", "repository": {"name": "api"}},
+			{"pullRequestId": 3, "status": "completed", "repository": {"name": "web"}}
+		]}`))
+	}))
+	defer srv.Close()
+
+	mc, err := azdevops.NewMultiClient("org", []string{"proj"}, "pat", nil)
+	if err != nil {
+		t.Fatalf("NewMultiClient: %v", err)
+	}
+	mc.ClientFor("proj").SetBaseURL(srv.URL)
+
+	prs, err := azdevops.NewAdapter(mc).ListPullRequests(25, provider.ListOpts{
+		States:     []provider.StateCategory{provider.StateCategoryClosedDone},
+		Repository: "API",
+		Draft:      provider.DraftExcluded,
+	})
+	if err != nil {
+		t.Fatalf("ListPullRequests() error = %v", err)
+	}
+	if status != "completed" {
+		t.Errorf("searchCriteria.status = %q, want completed", status)
+	}
+	if len(prs) != 1 || prs[0].Identity.ID != "2" {
+		t.Errorf("ListPullRequests() = %+v, want only PR 2", prs)
+	}
+}
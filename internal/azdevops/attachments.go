@@ -0,0 +1,82 @@
+package azdevops
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// AttachmentReference is an uploaded file in the project's attachment
+// store.
+type AttachmentReference struct {
+	ID  string `json:"id"`
+	URL string `json:"url"`
+}
+
+// UploadAttachment uploads content to the attachment store under the given
+// file name. The file is not attached to anything until a work item gets
+// an AttachedFile relation to the returned URL.
+func (c *Client) UploadAttachment(name string, content []byte) (*AttachmentReference, error) {
+	path := "/wit/attachments?fileName=" + url.QueryEscape(name) + "&api-version=7.1"
+	body, err := c.doRequestWithContentType("POST", path, bytes.NewReader(content), "application/octet-stream")
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload attachment: %w", err)
+	}
+
+	var ref AttachmentReference
+	if err := json.Unmarshal(body, &ref); err != nil {
+		return nil, fmt.Errorf("failed to parse Azure DevOps API response for uploaded attachment: %w. "+
+			"This may indicate an API structure change. Please check for updates or report this issue", err)
+	}
+	return &ref, nil
+}
+
+// AddAttachmentRelation attaches the uploaded file at attachmentURL to the
+// work item.
+func (c *Client) AddAttachmentRelation(id int, attachmentURL string) error {
+	ops := []PatchOperation{{
+		Op:    "add",
+		Path:  "/relations/-",
+		Value: WorkItemRelation{Rel: LinkTypeAttachedFile, URL: attachmentURL},
+	}}
+	if _, err := c.UpdateWorkItem(id, ops); err != nil {
+		return fmt.Errorf("failed to attach file: %w", err)
+	}
+	return nil
+}
+
+// DownloadAttachment returns the content of the attachment at
+// attachmentURL. The PAT is only sent to Azure DevOps, so URLs on other
+// hosts are refused.
+func (c *Client) DownloadAttachment(attachmentURL string) ([]byte, error) {
+	u, err := url.Parse(attachmentURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid attachment URL: %w", err)
+	}
+	if !c.isAzureDevOpsURL(u) {
+		return nil, fmt.Errorf("refusing to download attachment from %s://%s", u.Scheme, u.Host)
+	}
+	q := u.Query()
+	q.Set("api-version", "7.1")
+	u.RawQuery = q.Encode()
+
+	body, err := c.doURL("GET", u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download attachment: %w", err)
+	}
+	return body, nil
+}
+
+// isAzureDevOpsURL reports whether u serves the organization, so the PAT
+// may be sent to it: an https URL on dev.azure.com or a legacy
+// *.visualstudio.com host, or a URL on the client's base URL, which is
+// plain http only in tests and demo mode.
+func (c *Client) isAzureDevOpsURL(u *url.URL) bool {
+	if base, err := url.Parse(c.baseURL); err == nil && strings.EqualFold(u.Host, base.Host) && u.Scheme == base.Scheme {
+		return true
+	}
+	host := strings.ToLower(u.Hostname())
+	return u.Scheme == "https" && (host == "dev.azure.com" || strings.HasSuffix(host, ".visualstudio.com"))
+}
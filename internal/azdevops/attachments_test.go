@@ -0,0 +1,115 @@
+package azdevops
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Elpulgo/azdo/internal/provider"
+)
+
+func TestAdapter_GetWorkItemAttachments_WithUploaders(t *testing.T) {
+	a := newEditTestAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/wit/workitems/7":
+			w.Write([]byte(`{"id": 7, "rev": 4, "relations": [
+				{"rel": "System.LinkTypes.Related", "url": "https://x/_apis/wit/workItems/5"},
+				{"rel": "AttachedFile", "url": "https://x/_apis/wit/attachments/a1",
+					"attributes": {"name": "crash.png", "resourceSize": 2048, "resourceCreatedDate": "2026-10-02T10:00:00Z"}},
+				{"rel": "AttachedFile", "url": "https://x/_apis/wit/attachments/a2", "attributes": {"name": "app.log"}}
+			]}`))
+		case "/wit/workitems/7/updates":
+			w.Write([]byte(`{"count": 2, "value": [
+				{"id": 1, "rev": 2, "revisedBy": {"displayName": "Ada"}, "revisedDate": "2026-10-02T10:00:05Z",
+					"relations": {"added": [{"rel": "AttachedFile", "url": "https://x/_apis/wit/attachments/a1"}]}},
+				{"id": 2, "rev": 3, "revisedBy": {"displayName": "Bob"}, "revisedDate": "2026-10-03T08:00:00Z",
+					"relations": {"added": [{"rel": "AttachedFile", "url": "https://x/_apis/wit/attachments/a2"}]}}
+			]}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+		}
+	})
+
+	attachments, err := a.GetWorkItemAttachments("proj", 7)
+	if err != nil {
+		t.Fatalf("GetWorkItemAttachments() error = %v", err)
+	}
+	want := []provider.WorkItemAttachment{
+		{Name: "crash.png", URL: "https://x/_apis/wit/attachments/a1", Size: 2048, UploadedBy: "Ada",
+			UploadedAt: time.Date(2026, 10, 2, 10, 0, 0, 0, time.UTC)},
+		{Name: "app.log", URL: "https://x/_apis/wit/attachments/a2", UploadedBy: "Bob",
+			UploadedAt: time.Date(2026, 10, 3, 8, 0, 0, 0, time.UTC)},
+	}
+	if !reflect.DeepEqual(attachments, want) {
+		t.Errorf("attachments = %+v\nwant %+v", attachments, want)
+	}
+}
+
+func TestAdapter_AddWorkItemAttachment_UploadsThenLinks(t *testing.T) {
+	var uploaded string
+	var ops []PatchOperation
+	a := newEditTestAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/wit/attachments":
+			if r.URL.Query().Get("fileName") != "notes v2.txt" || r.Header.Get("Content-Type") != "application/octet-stream" {
+				t.Errorf("upload %s with %q", r.URL, r.Header.Get("Content-Type"))
+			}
+			body, _ := io.ReadAll(r.Body)
+			uploaded = string(body)
+			w.Write([]byte(`{"id": "a9", "url": "https://x/_apis/wit/attachments/a9"}`))
+		case r.Method == "PATCH" && r.URL.Path == "/wit/workitems/7":
+			json.NewDecoder(r.Body).Decode(&ops)
+			w.Write([]byte(`{"id": 7, "rev": 5}`))
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL)
+		}
+	})
+
+	got, err := a.AddWorkItemAttachment("proj", 7, "notes v2.txt", []byte("hello"))
+	if err != nil {
+		t.Fatalf("AddWorkItemAttachment() error = %v", err)
+	}
+	if uploaded != "hello" {
+		t.Errorf("uploaded %q", uploaded)
+	}
+	if len(ops) != 1 || ops[0].Path != "/relations/-" {
+		t.Fatalf("ops = %+v", ops)
+	}
+	rel := ops[0].Value.(map[string]any)
+	if rel["rel"] != LinkTypeAttachedFile || rel["url"] != "https://x/_apis/wit/attachments/a9" {
+		t.Errorf("relation = %v", rel)
+	}
+	if got.Name != "notes v2.txt" || got.Size != 5 {
+		t.Errorf("attachment = %+v", got)
+	}
+}
+
+func TestClient_DownloadAttachment_OnlyFromAzureDevOps(t *testing.T) {
+	a := newEditTestAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/_apis/wit/attachments/a1" || r.URL.Query().Get("fileName") != "a.txt" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "Basic ") {
+			t.Error("the download should be authenticated")
+		}
+		w.Write([]byte("content"))
+	})
+	c := a.mc.ClientFor("proj")
+
+	body, err := c.DownloadAttachment(c.baseURL + "/_apis/wit/attachments/a1?fileName=a.txt")
+	if err != nil || string(body) != "content" {
+		t.Errorf("DownloadAttachment() = %q, %v", body, err)
+	}
+	for _, u := range []string{
+		"https://evil.example.com/_apis/wit/attachments/a1",
+		"http://dev.azure.com/org/_apis/wit/attachments/a1",
+	} {
+		if _, err := c.DownloadAttachment(u); err == nil {
+			t.Errorf("%s should be refused: the PAT only goes to Azure DevOps over https", u)
+		}
+	}
+}
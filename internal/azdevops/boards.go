@@ -0,0 +1,103 @@
+package azdevops
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// BoardReference names a board of a team
+type BoardReference struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// BoardReferencesResponse represents the response from listing a team's boards
+type BoardReferencesResponse struct {
+	Count int              `json:"count"`
+	Value []BoardReference `json:"value"`
+}
+
+// BoardColumn is a column of a board. StateMappings maps each work item
+// type shown on the board to the state its items have in the column.
+type BoardColumn struct {
+	ID            string            `json:"id"`
+	Name          string            `json:"name"`
+	ItemLimit     int               `json:"itemLimit"`
+	StateMappings map[string]string `json:"stateMappings"`
+	ColumnType    string            `json:"columnType"` // "incoming", "inProgress" or "outgoing"
+}
+
+// BoardFields names the fields a board keeps its layout in. ColumnField is
+// the team-specific field holding an item's column.
+type BoardFields struct {
+	ColumnField WorkItemFieldReference `json:"columnField"`
+}
+
+// Board is a Kanban board of a team with its columns in order
+type Board struct {
+	ID      string        `json:"id"`
+	Name    string        `json:"name"`
+	Columns []BoardColumn `json:"columns"`
+	Fields  BoardFields   `json:"fields"`
+}
+
+// teamURL returns the API root of the given team of the project, under
+// which the team's boards and iterations live.
+func (c *Client) teamURL(team string) string {
+	return strings.TrimSuffix(c.baseURL, "/_apis") + "/" + url.PathEscape(team) + "/_apis"
+}
+
+// ListBoards returns the boards of the given team, one per backlog level.
+func (c *Client) ListBoards(team string) ([]BoardReference, error) {
+	body, err := c.doURL("GET", c.teamURL(team)+"/work/boards?api-version=7.1", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list boards: %w", err)
+	}
+
+	var response BoardReferencesResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse Azure DevOps API response for boards: %w. "+
+			"This may indicate an API structure change. Please check for updates or report this issue", err)
+	}
+	return response.Value, nil
+}
+
+// GetBoard returns a board of the given team with its columns.
+func (c *Client) GetBoard(team, id string) (*Board, error) {
+	u := fmt.Sprintf("%s/work/boards/%s?api-version=7.1", c.teamURL(team), url.PathEscape(id))
+	body, err := c.doURL("GET", u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get board: %w", err)
+	}
+
+	var board Board
+	if err := json.Unmarshal(body, &board); err != nil {
+		return nil, fmt.Errorf("failed to parse Azure DevOps API response for board: %w. "+
+			"This may indicate an API structure change. Please check for updates or report this issue", err)
+	}
+	return &board, nil
+}
+
+// GetTeamBoards returns every board of the configured team, or of the
+// project's default team, with its columns.
+func (c *Client) GetTeamBoards() ([]Board, error) {
+	team, err := c.teamOrDefault()
+	if err != nil {
+		return nil, err
+	}
+	refs, err := c.ListBoards(team)
+	if err != nil {
+		return nil, err
+	}
+	boards := make([]Board, 0, len(refs))
+	for _, ref := range refs {
+		board, err := c.GetBoard(team, ref.ID)
+		if err != nil {
+			return nil, err
+		}
+		boards = append(boards, *board)
+	}
+	return boards, nil
+}
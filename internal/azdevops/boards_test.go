@@ -0,0 +1,95 @@
+package azdevops
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Elpulgo/azdo/internal/provider"
+)
+
+func TestAdapter_GetBoards_ReadsDefaultTeamBoards(t *testing.T) {
+	a := newEditTestAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/projects/proj":
+			w.Write([]byte(`{"defaultTeam": {"id": "team-1"}}`))
+		case "/team-1/_apis/work/boards":
+			w.Write([]byte(`{"count": 1, "value": [{"id": "b1", "name": "Stories"}]}`))
+		case "/team-1/_apis/work/boards/b1":
+			w.Write([]byte(`{"id": "b1", "name": "Stories",
+				"columns": [
+					{"name": "New", "itemLimit": 0, "columnType": "incoming", "stateMappings": {"User Story": "New", "Bug": "New"}},
+					{"name": "Doing", "itemLimit": 5, "columnType": "inProgress", "stateMappings": {"User Story": "Active", "Bug": "Active"}},
+					{"name": "Done", "itemLimit": 0, "columnType": "outgoing", "stateMappings": {"User Story": "Closed", "Bug": "Closed"}}
+				],
+				"fields": {"columnField": {"referenceName": "WEF_1_Kanban.Column"}}}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+		}
+	})
+
+	boards, err := a.GetBoards("proj")
+	if err != nil {
+		t.Fatalf("GetBoards() error = %v", err)
+	}
+	if len(boards) != 1 {
+		t.Fatalf("boards = %+v, want one", boards)
+	}
+	b := boards[0]
+	if b.Name != "Stories" || b.ColumnField != "WEF_1_Kanban.Column" {
+		t.Errorf("board = %+v", b)
+	}
+	var names []string
+	for _, col := range b.Columns {
+		names = append(names, col.Name)
+	}
+	if !reflect.DeepEqual(names, []string{"New", "Doing", "Done"}) {
+		t.Errorf("columns = %v, want the board's order", names)
+	}
+	if b.Columns[1].WIPLimit != 5 {
+		t.Errorf("WIPLimit = %d, want 5", b.Columns[1].WIPLimit)
+	}
+	if state, ok := b.Columns[1].StateFor("Bug"); !ok || state != "Active" {
+		t.Errorf("StateFor(Bug) = %q, %v, want Active", state, ok)
+	}
+	if _, ok := b.Columns[1].StateFor("Task"); ok {
+		t.Error("a type the board does not show should have no state")
+	}
+}
+
+func TestAdapter_MoveWorkItemOnBoard_SetsStateAndColumn(t *testing.T) {
+	var ops []PatchOperation
+	a := newEditTestAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PATCH" || r.URL.Path != "/wit/workitems/7" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+		json.NewDecoder(r.Body).Decode(&ops)
+		w.Write([]byte(`{"id": 7}`))
+	})
+	board := provider.Board{
+		Name:        "Stories",
+		ColumnField: "WEF_1_Kanban.Column",
+		Columns: []provider.BoardColumn{
+			{Name: "New", States: map[string]string{"Bug": "New"}},
+			{Name: "Review", States: map[string]string{"Bug": "Active"}},
+		},
+	}
+
+	if err := a.MoveWorkItemOnBoard("proj", 7, "Bug", board, "Review"); err != nil {
+		t.Fatalf("MoveWorkItemOnBoard() error = %v", err)
+	}
+	want := []PatchOperation{
+		{Op: "add", Path: "/fields/System.State", Value: "Active"},
+		{Op: "add", Path: "/fields/WEF_1_Kanban.Column", Value: "Review"},
+	}
+	if !reflect.DeepEqual(ops, want) {
+		t.Errorf("ops = %+v\nwant %+v", ops, want)
+	}
+
+	err := a.MoveWorkItemOnBoard("proj", 7, "Task", board, "Review")
+	if err == nil || !strings.Contains(err.Error(), "cannot be moved") {
+		t.Errorf("moving a type without a state in the column: err = %v", err)
+	}
+}
@@ -15,8 +15,11 @@ type Client struct {
 	project    string
 	pat        string
 	baseURL    string
+	orgURL     string // organization-level API root, for identity search
+	vsspsURL   string // identity service API root, for identity lookup by ID
 	httpClient *http.Client
 	userID     string // cached authenticated user ID
+	team       string // team whose boards and sprints are read; empty uses the project's default team
 }
 
 // GetOrg returns the organization name
@@ -33,6 +36,8 @@ func (c *Client) GetProject() string {
 // This is used by the demo mode to point to a local mock server.
 func (c *Client) SetBaseURL(url string) {
 	c.baseURL = url
+	c.orgURL = url
+	c.vsspsURL = url
 }
 
 // SetUserID sets the cached user ID, bypassing the connectionData API call.
@@ -41,6 +46,12 @@ func (c *Client) SetUserID(id string) {
 	c.userID = id
 }
 
+// SetTeam sets the team whose members, boards and sprints are read, by
+// name or ID. An empty team uses the project's default team.
+func (c *Client) SetTeam(team string) {
+	c.team = team
+}
+
 // NewClient creates a new Azure DevOps API client
 func NewClient(org, project, pat string) (*Client, error) {
 	if org == "" {
@@ -58,10 +69,12 @@ func NewClient(org, project, pat string) (*Client, error) {
 	baseURL := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis", org, project)
 
 	return &Client{
-		org:     org,
-		project: project,
-		pat:     pat,
-		baseURL: baseURL,
+		org:      org,
+		project:  project,
+		pat:      pat,
+		baseURL:  baseURL,
+		orgURL:   fmt.Sprintf("https://dev.azure.com/%s/_apis", org),
+		vsspsURL: fmt.Sprintf("https://vssps.dev.azure.com/%s/_apis", org),
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
@@ -98,7 +111,7 @@ func (c *Client) get(path string) ([]byte, error) {
 
 	// Check for HTTP errors
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, formatHTTPError(resp.StatusCode, body)
+		return nil, formatHTTPError(resp.StatusCode)
 	}
 
 	return body, nil
@@ -127,6 +140,11 @@ func (c *Client) post(path string, body io.Reader) ([]byte, error) {
 	return c.doRequest("POST", path, body)
 }
 
+// delete performs a DELETE request to the Azure DevOps API
+func (c *Client) delete(path string) ([]byte, error) {
+	return c.doRequest("DELETE", path, nil)
+}
+
 // doRequestWithContentType performs an HTTP request with a custom Content-Type header.
 func (c *Client) doRequestWithContentType(method, path string, body io.Reader, contentType string) ([]byte, error) {
 	url := c.baseURL + path
@@ -151,16 +169,32 @@ func (c *Client) doRequestWithContentType(method, path string, body io.Reader, c
 	}
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, formatHTTPError(resp.StatusCode, respBody)
+		return nil, &httpError{statusCode: resp.StatusCode, body: respBody, err: formatHTTPError(resp.StatusCode)}
 	}
 
 	return respBody, nil
 }
 
+// httpError is the formatHTTPError of a response, keeping its status and
+// body for callers that tell particular rejections apart.
+type httpError struct {
+	statusCode int
+	body       []byte
+	err        error
+}
+
+func (e *httpError) Error() string {
+	return e.err.Error()
+}
+
 // doRequest performs an HTTP request with the given method
 func (c *Client) doRequest(method, path string, body io.Reader) ([]byte, error) {
-	url := c.baseURL + path
+	return c.doURL(method, c.baseURL+path, body)
+}
 
+// doURL performs an HTTP request against an absolute URL, for the
+// organization-level APIs that live outside the project-scoped baseURL.
+func (c *Client) doURL(method, url string, body io.Reader) ([]byte, error) {
 	req, err := http.NewRequest(method, url, body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
@@ -181,7 +215,7 @@ func (c *Client) doRequest(method, path string, body io.Reader) ([]byte, error)
 	}
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, formatHTTPError(resp.StatusCode, respBody)
+		return nil, formatHTTPError(resp.StatusCode)
 	}
 
 	return respBody, nil
@@ -223,7 +257,7 @@ func (c *Client) GetCurrentUserID() (string, error) {
 	}
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return "", formatHTTPError(resp.StatusCode, body)
+		return "", formatHTTPError(resp.StatusCode)
 	}
 
 	var data connectionDataResponse
@@ -240,7 +274,7 @@ func (c *Client) GetCurrentUserID() (string, error) {
 }
 
 // formatHTTPError creates a user-friendly error message based on the HTTP status code
-func formatHTTPError(statusCode int, _ []byte) error {
+func formatHTTPError(statusCode int) error {
 	switch statusCode {
 	case http.StatusUnauthorized:
 		return fmt.Errorf("authentication failed (HTTP 401): your PAT may be expired or invalid. " +
@@ -324,7 +324,7 @@ func TestClient_Get_ContentTypeHeader(t *testing.T) {
 }
 
 func TestFormatHTTPError_NotFound(t *testing.T) {
-	err := formatHTTPError(http.StatusNotFound, []byte(`{}`))
+	err := formatHTTPError(http.StatusNotFound)
 
 	if !strings.Contains(err.Error(), "404") {
 		t.Errorf("Expected error to contain '404', got %q", err.Error())
@@ -338,7 +338,7 @@ func TestFormatHTTPError_NotFound(t *testing.T) {
 }
 
 func TestFormatHTTPError_RateLimit(t *testing.T) {
-	err := formatHTTPError(http.StatusTooManyRequests, []byte(`{}`))
+	err := formatHTTPError(http.StatusTooManyRequests)
 
 	if !strings.Contains(err.Error(), "429") {
 		t.Errorf("Expected error to contain '429', got %q", err.Error())
@@ -352,7 +352,7 @@ func TestFormatHTTPError_RateLimit(t *testing.T) {
 }
 
 func TestFormatHTTPError_ServerError(t *testing.T) {
-	err := formatHTTPError(http.StatusInternalServerError, []byte(`{}`))
+	err := formatHTTPError(http.StatusInternalServerError)
 
 	if !strings.Contains(err.Error(), "500") {
 		t.Errorf("Expected error to contain '500', got %q", err.Error())
@@ -363,7 +363,7 @@ func TestFormatHTTPError_ServerError(t *testing.T) {
 }
 
 func TestFormatHTTPError_ServiceUnavailable(t *testing.T) {
-	err := formatHTTPError(http.StatusServiceUnavailable, []byte(`{}`))
+	err := formatHTTPError(http.StatusServiceUnavailable)
 
 	if !strings.Contains(err.Error(), "503") {
 		t.Errorf("Expected error to contain '503', got %q", err.Error())
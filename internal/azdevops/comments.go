@@ -17,12 +17,27 @@ const commentsTopLimit = 200
 
 // WorkItemComment is a single comment from a work item's Discussion section.
 type WorkItemComment struct {
-	ID          int       `json:"id"`
-	Text        string    `json:"text"`
-	CreatedBy   Identity  `json:"createdBy"`
-	CreatedDate time.Time `json:"createdDate"`
+	ID          int               `json:"id"`
+	Text        string            `json:"text"`
+	CreatedBy   Identity          `json:"createdBy"`
+	CreatedDate time.Time         `json:"createdDate"`
+	IsDeleted   bool              `json:"isDeleted"`
+	Format      string            `json:"format"` // "html" or "markdown"
+	Reactions   []CommentReaction `json:"reactions"`
 }
 
+// CommentReaction is the per-type reaction summary on a work item comment,
+// returned when comments are fetched with $expand=reactions.
+type CommentReaction struct {
+	Type                 string `json:"type"` // like, dislike, heart, hooray, smile, confused
+	Count                int    `json:"count"`
+	IsCurrentUserEngaged bool   `json:"isCurrentUserEngaged"`
+}
+
+// commentReactionsAPIVersion is the api-version for the comment reactions
+// endpoint, which lags behind the comments API.
+const commentReactionsAPIVersion = "7.1-preview.1"
+
 // workItemCommentsResponse is the CommentList wrapper returned by the GET endpoint.
 type workItemCommentsResponse struct {
 	TotalCount int               `json:"totalCount"`
@@ -33,7 +48,7 @@ type workItemCommentsResponse struct {
 // GetWorkItemComments returns up to commentsTopLimit comments for a work item,
 // sorted newest first (server-side via order=desc).
 func (c *Client) GetWorkItemComments(id int) ([]WorkItemComment, error) {
-	path := fmt.Sprintf("/wit/workItems/%d/comments?api-version=%s&order=desc&$top=%d",
+	path := fmt.Sprintf("/wit/workItems/%d/comments?api-version=%s&order=desc&$top=%d&$expand=reactions",
 		id, commentsAPIVersion, commentsTopLimit)
 
 	body, err := c.get(path)
@@ -53,11 +68,26 @@ func (c *Client) GetWorkItemComments(id int) ([]WorkItemComment, error) {
 // AddWorkItemComment posts a new comment to a work item and returns the created
 // comment. The text must be non-empty; createdBy is set server-side from the PAT.
 func (c *Client) AddWorkItemComment(id int, text string) (*WorkItemComment, error) {
+	return c.addWorkItemComment(id, text, "")
+}
+
+// AddWorkItemCommentMarkdown posts a new comment whose text is markdown,
+// which Azure DevOps stores and renders as such instead of as HTML.
+func (c *Client) AddWorkItemCommentMarkdown(id int, markdown string) (*WorkItemComment, error) {
+	return c.addWorkItemComment(id, markdown, "markdown")
+}
+
+// addWorkItemComment posts a comment in the given format, or in the
+// default HTML when format is empty.
+func (c *Client) addWorkItemComment(id int, text, format string) (*WorkItemComment, error) {
 	if strings.TrimSpace(text) == "" {
 		return nil, fmt.Errorf("comment text cannot be empty")
 	}
 
 	path := fmt.Sprintf("/wit/workItems/%d/comments?api-version=%s", id, commentsAPIVersion)
+	if format != "" {
+		path = fmt.Sprintf("/wit/workItems/%d/comments?format=%s&api-version=%s", id, format, commentsAPIVersion)
+	}
 
 	payload := fmt.Sprintf(`{"text": %s}`, escapeJSONString(text))
 	body, err := c.post(path, strings.NewReader(payload))
@@ -73,3 +103,47 @@ func (c *Client) AddWorkItemComment(id int, text string) (*WorkItemComment, erro
 
 	return &comment, nil
 }
+
+// UpdateWorkItemComment replaces the text of a work item comment.
+func (c *Client) UpdateWorkItemComment(id, commentID int, text string) error {
+	if strings.TrimSpace(text) == "" {
+		return fmt.Errorf("comment text cannot be empty")
+	}
+
+	path := fmt.Sprintf("/wit/workItems/%d/comments/%d?api-version=%s", id, commentID, commentsAPIVersion)
+
+	payload := fmt.Sprintf(`{"text": %s}`, escapeJSONString(text))
+	if _, err := c.patch(path, strings.NewReader(payload)); err != nil {
+		return fmt.Errorf("failed to update work item comment: %w", err)
+	}
+	return nil
+}
+
+// DeleteWorkItemComment deletes a work item comment.
+func (c *Client) DeleteWorkItemComment(id, commentID int) error {
+	path := fmt.Sprintf("/wit/workItems/%d/comments/%d?api-version=%s", id, commentID, commentsAPIVersion)
+
+	if _, err := c.delete(path); err != nil {
+		return fmt.Errorf("failed to delete work item comment: %w", err)
+	}
+	return nil
+}
+
+// SetWorkItemCommentReaction adds the authenticated user's reaction of the
+// given type (like, dislike, heart, hooray, smile, confused) to a comment,
+// or removes it when remove is true.
+func (c *Client) SetWorkItemCommentReaction(id, commentID int, reactionType string, remove bool) error {
+	path := fmt.Sprintf("/wit/workItems/%d/comments/%d/reactions/%s?api-version=%s",
+		id, commentID, reactionType, commentReactionsAPIVersion)
+
+	var err error
+	if remove {
+		_, err = c.delete(path)
+	} else {
+		_, err = c.put(path, nil)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update work item comment reaction: %w", err)
+	}
+	return nil
+}
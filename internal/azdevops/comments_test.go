@@ -143,6 +143,28 @@ func TestClient_AddWorkItemComment(t *testing.T) {
 	}
 }
 
+func TestClient_AddWorkItemCommentMarkdown(t *testing.T) {
+	var capturedQuery string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		capturedQuery = r.URL.RawQuery
+		w.Write([]byte(`{"id": 101, "text": "**done**", "format": "markdown"}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+
+	comment, err := client.AddWorkItemCommentMarkdown(299, "**done**")
+	if err != nil {
+		t.Fatalf("AddWorkItemCommentMarkdown() error = %v", err)
+	}
+	if !strings.Contains(capturedQuery, "format=markdown") || !strings.Contains(capturedQuery, "api-version=7.1-preview.4") {
+		t.Errorf("query = %q, want format=markdown and the comments api-version", capturedQuery)
+	}
+	if comment.Format != "markdown" {
+		t.Errorf("comment.Format = %q, want markdown", comment.Format)
+	}
+}
+
 func TestClient_AddWorkItemComment_RejectsEmpty(t *testing.T) {
 	called := false
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -161,3 +183,45 @@ func TestClient_AddWorkItemComment_RejectsEmpty(t *testing.T) {
 		t.Error("Expected no HTTP call for empty/whitespace text")
 	}
 }
+
+func TestClient_WorkItemCommentEndpoints(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path+"?"+r.URL.Query().Get("api-version"))
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+
+	if err := client.UpdateWorkItemComment(299, 45, "edited"); err != nil {
+		t.Fatalf("UpdateWorkItemComment() error = %v", err)
+	}
+	if err := client.DeleteWorkItemComment(299, 45); err != nil {
+		t.Fatalf("DeleteWorkItemComment() error = %v", err)
+	}
+	if err := client.SetWorkItemCommentReaction(299, 45, "heart", false); err != nil {
+		t.Fatalf("SetWorkItemCommentReaction() error = %v", err)
+	}
+	if err := client.SetWorkItemCommentReaction(299, 45, "heart", true); err != nil {
+		t.Fatalf("SetWorkItemCommentReaction(remove) error = %v", err)
+	}
+
+	const comment = "/test-org/test-project/_apis/wit/workItems/299/comments/45"
+	want := []string{
+		"PATCH " + comment + "?7.1-preview.4",
+		"DELETE " + comment + "?7.1-preview.4",
+		"PUT " + comment + "/reactions/heart?7.1-preview.1",
+		"DELETE " + comment + "/reactions/heart?7.1-preview.1",
+	}
+	if strings.Join(requests, "\n") != strings.Join(want, "\n") {
+		t.Errorf("requests = %q, want %q", requests, want)
+	}
+}
+
+func TestClient_UpdateWorkItemComment_RejectsEmpty(t *testing.T) {
+	client := newTestClient("http://unused")
+	if err := client.UpdateWorkItemComment(1, 2, "  "); err == nil {
+		t.Error("UpdateWorkItemComment() with blank text expected error, got nil")
+	}
+}
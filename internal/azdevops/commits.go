@@ -0,0 +1,115 @@
+package azdevops
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// GitUserDate identifies the author or committer of a commit.
+type GitUserDate struct {
+	Name  string    `json:"name"`
+	Email string    `json:"email"`
+	Date  time.Time `json:"date"`
+}
+
+// GitCommit represents a commit as returned by the commits APIs. Parents is
+// only populated when the commit is fetched on its own.
+type GitCommit struct {
+	CommitID  string      `json:"commitId"`
+	Author    GitUserDate `json:"author"`
+	Committer GitUserDate `json:"committer"`
+	Comment   string      `json:"comment"`
+	Parents   []string    `json:"parents,omitempty"`
+}
+
+// GitCommitsResponse represents the API response for listing commits
+type GitCommitsResponse struct {
+	Count int         `json:"count"`
+	Value []GitCommit `json:"value"`
+}
+
+// GitChange represents a file changed by a commit. SourceServerItem holds
+// the previous path of a renamed file.
+type GitChange struct {
+	Item             ChangeItem `json:"item"`
+	ChangeType       string     `json:"changeType"` // e.g. "edit", "add", "delete", "rename", "edit, rename"
+	SourceServerItem string     `json:"sourceServerItem,omitempty"`
+}
+
+// GitCommitChangesResponse represents the API response for a commit's changes
+type GitCommitChangesResponse struct {
+	Changes []GitChange `json:"changes"`
+}
+
+// GetPRCommits retrieves the commits of a pull request, newest first.
+// repositoryID: the ID of the repository
+// pullRequestID: the ID of the pull request
+func (c *Client) GetPRCommits(repositoryID string, pullRequestID int) ([]GitCommit, error) {
+	path := fmt.Sprintf("/git/repositories/%s/pullRequests/%d/commits?api-version=7.1",
+		repositoryID, pullRequestID)
+
+	body, err := c.get(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request commits: %w", err)
+	}
+
+	var response GitCommitsResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse Azure DevOps API response for pull request commits: %w. "+
+			"This may indicate an API structure change. Please check for updates or report this issue", err)
+	}
+	return response.Value, nil
+}
+
+// GetCommit retrieves a single commit, including its parents.
+// repositoryID: the ID of the repository
+// commitID: the full commit SHA
+func (c *Client) GetCommit(repositoryID string, commitID string) (*GitCommit, error) {
+	path := fmt.Sprintf("/git/repositories/%s/commits/%s?api-version=7.1", repositoryID, commitID)
+
+	body, err := c.get(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit: %w", err)
+	}
+
+	var commit GitCommit
+	if err := json.Unmarshal(body, &commit); err != nil {
+		return nil, fmt.Errorf("failed to parse Azure DevOps API response for commit: %w. "+
+			"This may indicate an API structure change. Please check for updates or report this issue", err)
+	}
+	return &commit, nil
+}
+
+// GetCommitChanges retrieves the files a commit changed relative to its
+// first parent.
+// repositoryID: the ID of the repository
+// commitID: the full commit SHA
+func (c *Client) GetCommitChanges(repositoryID string, commitID string) ([]GitChange, error) {
+	path := fmt.Sprintf("/git/repositories/%s/commits/%s/changes?api-version=7.1", repositoryID, commitID)
+
+	body, err := c.get(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit changes: %w", err)
+	}
+
+	var response GitCommitChangesResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse Azure DevOps API response for commit changes: %w. "+
+			"This may indicate an API structure change. Please check for updates or report this issue", err)
+	}
+	return response.Changes, nil
+}
+
+// normalizeChangeType reduces a combined change type such as "edit, rename"
+// to the single kind the diff view handles, preferring rename.
+func normalizeChangeType(changeType string) string {
+	kinds := strings.Split(changeType, ",")
+	for _, kind := range kinds {
+		if strings.TrimSpace(kind) == "rename" {
+			return "rename"
+		}
+	}
+	return strings.TrimSpace(kinds[0])
+}
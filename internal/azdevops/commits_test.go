@@ -0,0 +1,69 @@
+package azdevops
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetPRCommits_ParsesAuthorAndMessage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/git/repositories/repo-123/pullRequests/42/commits" {
+			t.Errorf("path = %s, want the PR commits endpoint", r.URL.Path)
+		}
+		w.Write([]byte(`{"count": 1, "value": [
+			{"commitId": "abc123", "comment": "Fix login\n\nDetails",
+			 "author": {"name": "Ann", "email": "ann@example.com", "date": "2024-03-01T10:00:00Z"}}
+		]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-org", "test-project", "test-pat")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	client.baseURL = server.URL
+
+	commits, err := client.GetPRCommits("repo-123", 42)
+	if err != nil {
+		t.Fatalf("GetPRCommits() error = %v", err)
+	}
+	if len(commits) != 1 {
+		t.Fatalf("len(commits) = %d, want 1", len(commits))
+	}
+	c := commits[0]
+	if c.CommitID != "abc123" || c.Comment != "Fix login\n\nDetails" || c.Author.Name != "Ann" || c.Author.Date.IsZero() {
+		t.Errorf("commits[0] = %+v", c)
+	}
+}
+
+func TestGetCommitChanges_ParsesRenames(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/git/repositories/repo-123/commits/abc123/changes" {
+			t.Errorf("path = %s, want the commit changes endpoint", r.URL.Path)
+		}
+		w.Write([]byte(`{"changes": [
+			{"item": {"objectId": "o1", "path": "/new.go", "gitObjectType": "blob"},
+			 "changeType": "edit, rename", "sourceServerItem": "/old.go"}
+		]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-org", "test-project", "test-pat")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	client.baseURL = server.URL
+
+	changes, err := client.GetCommitChanges("repo-123", "abc123")
+	if err != nil {
+		t.Fatalf("GetCommitChanges() error = %v", err)
+	}
+	if len(changes) != 1 {
+		t.Fatalf("len(changes) = %d, want 1", len(changes))
+	}
+	got := MapGitChange(changes[0])
+	if got.Path != "/new.go" || got.OriginalPath != "/old.go" || got.ChangeType != "rename" {
+		t.Errorf("MapGitChange() = %+v, want a rename from /old.go", got)
+	}
+}
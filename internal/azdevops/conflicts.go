@@ -0,0 +1,81 @@
+package azdevops
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Merge status values of a pull request's mergeStatus field.
+const (
+	MergeStatusNotSet           = "notSet"
+	MergeStatusQueued           = "queued"
+	MergeStatusConflicts        = "conflicts"
+	MergeStatusSucceeded        = "succeeded"
+	MergeStatusRejectedByPolicy = "rejectedByPolicy"
+	MergeStatusFailure          = "failure"
+)
+
+// CommitRef identifies a commit in a conflict's merge.
+type CommitRef struct {
+	CommitID string `json:"commitId"`
+}
+
+// Conflict represents a file that conflicts when merging a pull request.
+// The merge commits are the three sides: the merge base, the source branch
+// and the target branch.
+type Conflict struct {
+	ConflictID        int       `json:"conflictId"`
+	ConflictPath      string    `json:"conflictPath"`
+	ConflictType      string    `json:"conflictType"` // e.g. "editEdit", "editDelete", "addAdd"
+	MergeBaseCommit   CommitRef `json:"mergeBaseCommit"`
+	MergeSourceCommit CommitRef `json:"mergeSourceCommit"`
+	MergeTargetCommit CommitRef `json:"mergeTargetCommit"`
+}
+
+// ConflictsResponse represents the API response for listing pull request
+// conflicts.
+type ConflictsResponse struct {
+	Count int        `json:"count"`
+	Value []Conflict `json:"value"`
+}
+
+// GetPullRequest retrieves a single pull request, including its current
+// mergeStatus.
+// repositoryID: the ID of the repository
+// pullRequestID: the ID of the pull request
+func (c *Client) GetPullRequest(repositoryID string, pullRequestID int) (*PullRequest, error) {
+	path := fmt.Sprintf("/git/repositories/%s/pullRequests/%d?api-version=7.1",
+		repositoryID, pullRequestID)
+
+	body, err := c.get(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request: %w", err)
+	}
+
+	var pr PullRequest
+	if err := json.Unmarshal(body, &pr); err != nil {
+		return nil, fmt.Errorf("failed to parse Azure DevOps API response for pull request: %w. "+
+			"This may indicate an API structure change. Please check for updates or report this issue", err)
+	}
+	return &pr, nil
+}
+
+// GetPRConflicts retrieves the unresolved merge conflicts of a pull request.
+// repositoryID: the ID of the repository
+// pullRequestID: the ID of the pull request
+func (c *Client) GetPRConflicts(repositoryID string, pullRequestID int) ([]Conflict, error) {
+	path := fmt.Sprintf("/git/repositories/%s/pullRequests/%d/conflicts?excludeResolved=true&api-version=7.1",
+		repositoryID, pullRequestID)
+
+	body, err := c.get(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request conflicts: %w", err)
+	}
+
+	var response ConflictsResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse Azure DevOps API response for conflicts: %w. "+
+			"This may indicate an API structure change. Please check for updates or report this issue", err)
+	}
+	return response.Value, nil
+}
@@ -0,0 +1,68 @@
+package azdevops
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetPRConflicts_ExcludesResolved(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/git/repositories/repo-123/pullRequests/42/conflicts" {
+			t.Errorf("path = %s, want the PR conflicts endpoint", r.URL.Path)
+		}
+		if r.URL.Query().Get("excludeResolved") != "true" {
+			t.Errorf("query = %s, want excludeResolved=true", r.URL.RawQuery)
+		}
+		w.Write([]byte(`{"count": 2, "value": [
+			{"conflictId": 1, "conflictPath": "/a.go", "conflictType": "editEdit",
+			 "mergeBaseCommit": {"commitId": "base"}, "mergeSourceCommit": {"commitId": "src"},
+			 "mergeTargetCommit": {"commitId": "tgt"}},
+			{"conflictId": 2, "conflictPath": "/b.go", "conflictType": "editDelete"}
+		]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-org", "test-project", "test-pat")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	client.baseURL = server.URL
+
+	conflicts, err := client.GetPRConflicts("repo-123", 42)
+	if err != nil {
+		t.Fatalf("GetPRConflicts() error = %v", err)
+	}
+	if len(conflicts) != 2 {
+		t.Fatalf("len(conflicts) = %d, want 2", len(conflicts))
+	}
+	c := conflicts[0]
+	if c.ConflictPath != "/a.go" || c.ConflictType != "editEdit" || c.MergeBaseCommit.CommitID != "base" ||
+		c.MergeSourceCommit.CommitID != "src" || c.MergeTargetCommit.CommitID != "tgt" {
+		t.Errorf("conflicts[0] = %+v", c)
+	}
+}
+
+func TestGetPullRequest_ParsesMergeStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/git/repositories/repo-123/pullRequests/42" {
+			t.Errorf("path = %s, want the PR endpoint", r.URL.Path)
+		}
+		w.Write([]byte(`{"pullRequestId": 42, "mergeStatus": "conflicts"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-org", "test-project", "test-pat")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	client.baseURL = server.URL
+
+	pr, err := client.GetPullRequest("repo-123", 42)
+	if err != nil {
+		t.Fatalf("GetPullRequest() error = %v", err)
+	}
+	if pr.ID != 42 || pr.MergeStatus != MergeStatusConflicts {
+		t.Errorf("GetPullRequest() = %+v, want #42 with conflicts", pr)
+	}
+}
@@ -1,6 +1,14 @@
 package azdevops
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrConflict is wrapped by the errors of requests rejected because the
+// resource changed since it was read, such as a work item update whose
+// revision test failed.
+var ErrConflict = errors.New("the resource was changed by someone else")
 
 // PartialError indicates that some (but not all) projects failed during a
 // multi-project fetch. The caller receives valid data from the successful
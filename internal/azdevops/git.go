@@ -1,10 +1,12 @@
 package azdevops
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 )
@@ -16,12 +18,15 @@ type PullRequest struct {
 	Description        string     `json:"description"`
 	Status             string     `json:"status"` // "active", "completed", "abandoned"
 	CreationDate       time.Time  `json:"creationDate"`
-	SourceRefName      string     `json:"sourceRefName"` // e.g., "refs/heads/feature/my-feature"
-	TargetRefName      string     `json:"targetRefName"` // e.g., "refs/heads/main"
+	ClosedDate         *time.Time `json:"closedDate,omitempty"` // set once completed or abandoned
+	SourceRefName      string     `json:"sourceRefName"`        // e.g., "refs/heads/feature/my-feature"
+	TargetRefName      string     `json:"targetRefName"`        // e.g., "refs/heads/main"
 	IsDraft            bool       `json:"isDraft"`
+	MergeStatus        string     `json:"mergeStatus"` // see the MergeStatus* constants
 	CreatedBy          Identity   `json:"createdBy"`
 	Repository         Repository `json:"repository"`
 	Reviewers          []Reviewer `json:"reviewers"`
+	Labels             []Label    `json:"labels"`
 	ProjectName        string     `json:"-"` // Set by MultiClient, not from API
 	ProjectDisplayName string     `json:"-"` // Set by MultiClient, display name for UI
 }
@@ -46,6 +51,13 @@ type Reviewer struct {
 	Vote        int    `json:"vote"` // 10: approved, 5: approved with suggestions, 0: no vote, -5: waiting, -10: rejected
 }
 
+// Label represents a tag on a pull request
+type Label struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Active bool   `json:"active"`
+}
+
 // PullRequestsResponse represents the API response for listing pull requests
 type PullRequestsResponse struct {
 	Count int           `json:"count"`
@@ -100,42 +112,14 @@ func (r *Reviewer) VoteDescription() string {
 // top: maximum number of pull requests to return (typically 25-100)
 // Results are ordered by creation date descending (most recent first)
 func (c *Client) ListPullRequests(top int) ([]PullRequest, error) {
-	path := fmt.Sprintf("/git/pullrequests?api-version=7.1&$top=%d&searchCriteria.status=active", top)
-
-	body, err := c.get(path)
-	if err != nil {
-		return nil, fmt.Errorf("failed to list pull requests: %w", err)
-	}
-
-	var response PullRequestsResponse
-	err = json.Unmarshal(body, &response)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse Azure DevOps API response for pull requests: %w. "+
-			"This may indicate an API structure change. Please check for updates or report this issue", err)
-	}
-
-	return response.Value, nil
+	return c.SearchPullRequests(top, PRSearchCriteria{})
 }
 
 // ListMyPullRequests retrieves active pull requests created by the given user.
 // creatorID: the Azure DevOps user ID (UUID) of the creator to filter by.
 // top: maximum number of pull requests to return.
 func (c *Client) ListMyPullRequests(creatorID string, top int) ([]PullRequest, error) {
-	path := fmt.Sprintf("/git/pullrequests?api-version=7.1&$top=%d&searchCriteria.status=active&searchCriteria.creatorId=%s", top, creatorID)
-
-	body, err := c.get(path)
-	if err != nil {
-		return nil, fmt.Errorf("failed to list my pull requests: %w", err)
-	}
-
-	var response PullRequestsResponse
-	err = json.Unmarshal(body, &response)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse Azure DevOps API response for pull requests: %w. "+
-			"This may indicate an API structure change. Please check for updates or report this issue", err)
-	}
-
-	return response.Value, nil
+	return c.SearchPullRequests(top, PRSearchCriteria{CreatorID: creatorID})
 }
 
 // ListPullRequestsAsReviewer retrieves active pull requests where the given
@@ -143,11 +127,18 @@ func (c *Client) ListMyPullRequests(creatorID string, top int) ([]PullRequest, e
 // reviewerID: the Azure DevOps user ID (UUID) of the reviewer to filter by.
 // top: maximum number of pull requests to return.
 func (c *Client) ListPullRequestsAsReviewer(reviewerID string, top int) ([]PullRequest, error) {
-	path := fmt.Sprintf("/git/pullrequests?api-version=7.1&$top=%d&searchCriteria.status=active&searchCriteria.reviewerId=%s", top, reviewerID)
+	return c.SearchPullRequests(top, PRSearchCriteria{ReviewerID: reviewerID})
+}
+
+// SearchPullRequests retrieves pull requests across all repositories in the
+// project matching criteria.
+// top: maximum number of pull requests to return.
+func (c *Client) SearchPullRequests(top int, criteria PRSearchCriteria) ([]PullRequest, error) {
+	path := fmt.Sprintf("/git/pullrequests?api-version=7.1&$top=%d%s", top, criteria.query())
 
 	body, err := c.get(path)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list pull requests as reviewer: %w", err)
+		return nil, fmt.Errorf("failed to list pull requests: %w", err)
 	}
 
 	var response PullRequestsResponse
@@ -160,6 +151,17 @@ func (c *Client) ListPullRequestsAsReviewer(reviewerID string, top int) ([]PullR
 	return response.Value, nil
 }
 
+// ActiveLabelNames returns the names of the pull request's active labels
+func (pr *PullRequest) ActiveLabelNames() []string {
+	var names []string
+	for _, l := range pr.Labels {
+		if l.Active {
+			names = append(names, l.Name)
+		}
+	}
+	return names
+}
+
 // Thread represents a comment thread on a pull request
 type Thread struct {
 	ID              int            `json:"id"`
@@ -171,11 +173,15 @@ type Thread struct {
 	IsDeleted       bool           `json:"isDeleted"`
 }
 
-// ThreadContext contains location information for code comments
+// ThreadContext contains location information for code comments.
+// Left positions refer to the target-branch (old) file, right positions to
+// the source-branch (new) file; a thread normally carries only one pair.
 type ThreadContext struct {
 	FilePath       string        `json:"filePath"`
-	RightFileStart *FilePosition `json:"rightFileStart"`
-	RightFileEnd   *FilePosition `json:"rightFileEnd"`
+	LeftFileStart  *FilePosition `json:"leftFileStart,omitempty"`
+	LeftFileEnd    *FilePosition `json:"leftFileEnd,omitempty"`
+	RightFileStart *FilePosition `json:"rightFileStart,omitempty"`
+	RightFileEnd   *FilePosition `json:"rightFileEnd,omitempty"`
 }
 
 // FilePosition represents a position in a file
@@ -186,13 +192,15 @@ type FilePosition struct {
 
 // Comment represents a single comment in a thread
 type Comment struct {
-	ID              int       `json:"id"`
-	ParentCommentID int       `json:"parentCommentId"`
-	Content         string    `json:"content"`
-	PublishedDate   time.Time `json:"publishedDate"`
-	LastUpdatedDate time.Time `json:"lastUpdatedDate"`
-	CommentType     string    `json:"commentType"` // "text", "system"
-	Author          Identity  `json:"author"`
+	ID              int        `json:"id"`
+	ParentCommentID int        `json:"parentCommentId"`
+	Content         string     `json:"content"`
+	PublishedDate   time.Time  `json:"publishedDate"`
+	LastUpdatedDate time.Time  `json:"lastUpdatedDate"`
+	CommentType     string     `json:"commentType"` // "text", "system"
+	Author          Identity   `json:"author"`
+	UsersLiked      []Identity `json:"usersLiked"`
+	IsDeleted       bool       `json:"isDeleted"`
 }
 
 // ThreadsResponse represents the API response for listing threads
@@ -397,8 +405,22 @@ func (c *Client) GetPRIterationChanges(repositoryID string, pullRequestID int, i
 // filePath: the path of the file in the repository
 // branchName: the short branch name (e.g., "main", not "refs/heads/main")
 func (c *Client) GetFileContent(repositoryID string, filePath string, branchName string) (string, error) {
-	path := fmt.Sprintf("/git/repositories/%s/items?path=%s&versionType=branch&version=%s&api-version=7.1",
-		repositoryID, filePath, branchName)
+	return c.getItemContent(repositoryID, filePath, "branch", branchName)
+}
+
+// GetFileContentAtCommit retrieves raw file content at a specific commit
+// repositoryID: the ID of the repository
+// filePath: the path of the file in the repository
+// commitID: the full commit SHA
+func (c *Client) GetFileContentAtCommit(repositoryID string, filePath string, commitID string) (string, error) {
+	return c.getItemContent(repositoryID, filePath, "commit", commitID)
+}
+
+// getItemContent fetches raw file content for a version descriptor
+// (versionType "branch" or "commit").
+func (c *Client) getItemContent(repositoryID, filePath, versionType, version string) (string, error) {
+	path := fmt.Sprintf("/git/repositories/%s/items?path=%s&versionType=%s&version=%s&api-version=7.1",
+		repositoryID, filePath, versionType, version)
 
 	// Use doRequest directly to set Accept header for raw text
 	url := c.baseURL + path
@@ -423,7 +445,7 @@ func (c *Client) GetFileContent(repositoryID string, filePath string, branchName
 	}
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return "", formatHTTPError(resp.StatusCode, respBody)
+		return "", formatHTTPError(resp.StatusCode)
 	}
 
 	return string(respBody), nil
@@ -474,16 +496,70 @@ func (c *Client) UpdateThreadStatus(repositoryID string, pullRequestID int, thre
 	return nil
 }
 
-// AddPRCodeComment creates a new comment thread attached to a specific file and line
+// EditPRComment replaces the content of a comment
 // repositoryID: the ID of the repository
 // pullRequestID: the ID of the pull request
-// filePath: the path of the file to comment on
-// line: the line number in the new file to attach the comment to
+// threadID: the ID of the thread holding the comment
+// commentID: the ID of the comment within the thread
+// content: the new comment text
+func (c *Client) EditPRComment(repositoryID string, pullRequestID, threadID, commentID int, content string) error {
+	path := fmt.Sprintf("/git/repositories/%s/pullRequests/%d/threads/%d/comments/%d?api-version=7.1",
+		repositoryID, pullRequestID, threadID, commentID)
+
+	payload := fmt.Sprintf(`{"content": %s}`, escapeJSONString(content))
+
+	if _, err := c.patch(path, strings.NewReader(payload)); err != nil {
+		return fmt.Errorf("failed to edit comment: %w", err)
+	}
+	return nil
+}
+
+// DeletePRComment deletes a comment. Azure DevOps keeps it in the thread
+// with isDeleted set.
+func (c *Client) DeletePRComment(repositoryID string, pullRequestID, threadID, commentID int) error {
+	path := fmt.Sprintf("/git/repositories/%s/pullRequests/%d/threads/%d/comments/%d?api-version=7.1",
+		repositoryID, pullRequestID, threadID, commentID)
+
+	if _, err := c.delete(path); err != nil {
+		return fmt.Errorf("failed to delete comment: %w", err)
+	}
+	return nil
+}
+
+// LikePRComment likes a comment as the authenticated user, or removes the
+// like when unlike is true. Likes are the only reaction pull request
+// comments support.
+func (c *Client) LikePRComment(repositoryID string, pullRequestID, threadID, commentID int, unlike bool) error {
+	path := fmt.Sprintf("/git/repositories/%s/pullRequests/%d/threads/%d/comments/%d/likes?api-version=7.1",
+		repositoryID, pullRequestID, threadID, commentID)
+
+	var err error
+	if unlike {
+		_, err = c.delete(path)
+	} else {
+		_, err = c.post(path, nil)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update comment like: %w", err)
+	}
+	return nil
+}
+
+// AddPRCodeComment creates a new comment thread attached to a file location
+// repositoryID: the ID of the repository
+// pullRequestID: the ID of the pull request
+// threadContext: the file path plus left (old file) or right (new file)
+// start/end positions the thread is anchored to
 // content: the comment text
-func (c *Client) AddPRCodeComment(repositoryID string, pullRequestID int, filePath string, line int, content string) (*Thread, error) {
+func (c *Client) AddPRCodeComment(repositoryID string, pullRequestID int, threadContext ThreadContext, content string) (*Thread, error) {
 	path := fmt.Sprintf("/git/repositories/%s/pullRequests/%d/threads?api-version=7.1",
 		repositoryID, pullRequestID)
 
+	contextJSON, err := json.Marshal(threadContext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode thread context: %w", err)
+	}
+
 	payload := fmt.Sprintf(`{
 		"comments": [
 			{
@@ -493,12 +569,8 @@ func (c *Client) AddPRCodeComment(repositoryID string, pullRequestID int, filePa
 			}
 		],
 		"status": "active",
-		"threadContext": {
-			"filePath": %s,
-			"rightFileStart": {"line": %d, "offset": 1},
-			"rightFileEnd": {"line": %d, "offset": 1}
-		}
-	}`, escapeJSONString(content), escapeJSONString(filePath), line, line)
+		"threadContext": %s
+	}`, escapeJSONString(content), contextJSON)
 
 	body, err := c.post(path, strings.NewReader(payload))
 	if err != nil {
@@ -514,6 +586,104 @@ func (c *Client) AddPRCodeComment(repositoryID string, pullRequestID int, filePa
 	return &thread, nil
 }
 
+// gitRef is a single entry of the refs API response.
+type gitRef struct {
+	Name     string `json:"name"`
+	ObjectID string `json:"objectId"`
+}
+
+// GetBranchHead returns the commit SHA the given branch points to
+// repositoryID: the ID of the repository
+// branchName: the short branch name (e.g., "main", not "refs/heads/main")
+func (c *Client) GetBranchHead(repositoryID string, branchName string) (string, error) {
+	path := fmt.Sprintf("/git/repositories/%s/refs?filter=heads/%s&api-version=7.1",
+		repositoryID, url.QueryEscape(branchName))
+
+	body, err := c.get(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to get branch ref: %w", err)
+	}
+
+	var response struct {
+		Value []gitRef `json:"value"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return "", fmt.Errorf("failed to parse branch ref response: %w", err)
+	}
+
+	// filter is a prefix match, so "heads/feat" also returns "heads/feature".
+	want := "refs/heads/" + branchName
+	for _, ref := range response.Value {
+		if ref.Name == want {
+			return ref.ObjectID, nil
+		}
+	}
+	return "", fmt.Errorf("branch %q not found", branchName)
+}
+
+// pushRequest is the JSON body for POST /git/repositories/{id}/pushes.
+type pushRequest struct {
+	RefUpdates []pushRefUpdate `json:"refUpdates"`
+	Commits    []pushCommit    `json:"commits"`
+}
+
+type pushRefUpdate struct {
+	Name        string `json:"name"`
+	OldObjectID string `json:"oldObjectId"`
+}
+
+type pushCommit struct {
+	Comment string       `json:"comment"`
+	Changes []pushChange `json:"changes"`
+}
+
+type pushChange struct {
+	ChangeType string          `json:"changeType"`
+	Item       pushItem        `json:"item"`
+	NewContent pushItemContent `json:"newContent"`
+}
+
+type pushItem struct {
+	Path string `json:"path"`
+}
+
+type pushItemContent struct {
+	Content     string `json:"content"`
+	ContentType string `json:"contentType"`
+}
+
+// PushFileEdit commits new content for a single file on top of a branch
+// repositoryID: the ID of the repository
+// branchName: the short branch name to update
+// oldObjectID: the commit the change is based on; the push is rejected if
+// the branch has moved since, so a concurrent push is never overwritten
+// filePath: the path of the file to replace
+// content: the full new file content
+// message: the commit message
+func (c *Client) PushFileEdit(repositoryID, branchName, oldObjectID, filePath, content, message string) error {
+	path := fmt.Sprintf("/git/repositories/%s/pushes?api-version=7.1", repositoryID)
+
+	payload, err := json.Marshal(pushRequest{
+		RefUpdates: []pushRefUpdate{{Name: "refs/heads/" + branchName, OldObjectID: oldObjectID}},
+		Commits: []pushCommit{{
+			Comment: message,
+			Changes: []pushChange{{
+				ChangeType: "edit",
+				Item:       pushItem{Path: filePath},
+				NewContent: pushItemContent{Content: content, ContentType: "rawtext"},
+			}},
+		}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode push: %w", err)
+	}
+
+	if _, err := c.post(path, bytes.NewReader(payload)); err != nil {
+		return fmt.Errorf("failed to push file edit: %w", err)
+	}
+	return nil
+}
+
 // FilterSystemThreads filters out threads that are system-generated comments
 // (e.g., threads whose first comment starts with "Microsoft.VisualStudio")
 func FilterSystemThreads(threads []Thread) []Thread {
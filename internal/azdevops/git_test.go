@@ -1,9 +1,13 @@
 package azdevops
 
 import (
+	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestListPullRequests_Success(t *testing.T) {
@@ -262,6 +266,55 @@ func TestListPullRequests_NetworkError(t *testing.T) {
 	}
 }
 
+func TestSearchPullRequests_SendsCriteria(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		want := map[string]string{
+			"searchCriteria.status":             "completed",
+			"searchCriteria.targetRefName":      "refs/heads/main",
+			"searchCriteria.creatorId":          "user-1",
+			"searchCriteria.minTime":            "2024-02-01T00:00:00Z",
+			"searchCriteria.queryTimeRangeType": "created",
+		}
+		for key, value := range want {
+			if got := query.Get(key); got != value {
+				t.Errorf("Expected %s=%s, got %s", key, value, got)
+			}
+		}
+		if query.Has("searchCriteria.reviewerId") {
+			t.Error("Expected no reviewerId for empty criteria field")
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"count": 1, "value": [{"pullRequestId": 7, "status": "completed",
+			"labels": [{"name": "bug", "active": true}, {"name": "old", "active": false}]}]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-org", "test-project", "test-pat")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	client.baseURL = server.URL
+
+	prs, err := client.SearchPullRequests(25, PRSearchCriteria{
+		Status:        "completed",
+		TargetRefName: "refs/heads/main",
+		CreatorID:     "user-1",
+		MinTime:       time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(prs) != 1 {
+		t.Fatalf("Expected 1 pull request, got %d", len(prs))
+	}
+	if labels := prs[0].ActiveLabelNames(); len(labels) != 1 || labels[0] != "bug" {
+		t.Errorf("Expected active labels [bug], got %v", labels)
+	}
+}
+
 func TestPullRequest_SourceBranchShortName(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -1296,7 +1349,12 @@ func TestAddPRCodeComment_Success(t *testing.T) {
 	}
 	client.baseURL = server.URL
 
-	thread, err := client.AddPRCodeComment("repo-123", 101, "/src/main.go", 42, "Should we add error handling here?")
+	tc := ThreadContext{
+		FilePath:       "/src/main.go",
+		RightFileStart: &FilePosition{Line: 42, Offset: 1},
+		RightFileEnd:   &FilePosition{Line: 42, Offset: 1},
+	}
+	thread, err := client.AddPRCodeComment("repo-123", 101, tc, "Should we add error handling here?")
 	if err != nil {
 		t.Fatalf("AddPRCodeComment() error = %v", err)
 	}
@@ -1318,6 +1376,46 @@ func TestAddPRCodeComment_Success(t *testing.T) {
 	}
 }
 
+func TestAddPRCodeComment_LeftSideRangePayload(t *testing.T) {
+	var captured struct {
+		ThreadContext map[string]json.RawMessage `json:"threadContext"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&captured); err != nil {
+			t.Errorf("failed to decode request body: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id": 11, "status": "active"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-org", "test-project", "test-pat")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	client.baseURL = server.URL
+
+	tc := ThreadContext{
+		FilePath:      "/src/main.go",
+		LeftFileStart: &FilePosition{Line: 5, Offset: 1},
+		LeftFileEnd:   &FilePosition{Line: 8, Offset: 1},
+	}
+	if _, err := client.AddPRCodeComment("repo-123", 101, tc, "why was this removed?"); err != nil {
+		t.Fatalf("AddPRCodeComment() error = %v", err)
+	}
+
+	if _, ok := captured.ThreadContext["rightFileStart"]; ok {
+		t.Error("rightFileStart should be omitted for a left-side comment")
+	}
+	if got := string(captured.ThreadContext["leftFileStart"]); got != `{"line":5,"offset":1}` {
+		t.Errorf("leftFileStart = %s, want line 5", got)
+	}
+	if got := string(captured.ThreadContext["leftFileEnd"]); got != `{"line":8,"offset":1}` {
+		t.Errorf("leftFileEnd = %s, want line 8", got)
+	}
+}
+
 func TestAddPRCodeComment_HTTPError(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusBadRequest)
@@ -1331,7 +1429,7 @@ func TestAddPRCodeComment_HTTPError(t *testing.T) {
 	}
 	client.baseURL = server.URL
 
-	_, err = client.AddPRCodeComment("repo-123", 101, "/src/main.go", 10, "comment")
+	_, err = client.AddPRCodeComment("repo-123", 101, ThreadContext{FilePath: "/src/main.go"}, "comment")
 	if err == nil {
 		t.Error("Expected error for 400 response, got nil")
 	}
@@ -1606,3 +1704,119 @@ func TestFilterSystemThreads(t *testing.T) {
 		})
 	}
 }
+
+func TestGetBranchHead_MatchesExactBranchName(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The filter is a prefix match, so both lookups see both refs.
+		if got := r.URL.Query().Get("filter"); !strings.HasPrefix(got, "heads/fea") {
+			t.Errorf("filter = %q, want a heads/ prefix", got)
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"value": [
+			{"name": "refs/heads/feature", "objectId": "bbb"},
+			{"name": "refs/heads/feat", "objectId": "aaa"}
+		]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-org", "test-project", "test-pat")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	client.baseURL = server.URL
+
+	head, err := client.GetBranchHead("repo-123", "feat")
+	if err != nil {
+		t.Fatalf("GetBranchHead() error = %v", err)
+	}
+	if head != "aaa" {
+		t.Errorf("GetBranchHead() = %q, want aaa", head)
+	}
+
+	if _, err := client.GetBranchHead("repo-123", "fea"); err == nil {
+		t.Error("GetBranchHead() for a prefix-only match should fail")
+	}
+}
+
+func TestPushFileEdit_SendsEditAgainstOldObjectID(t *testing.T) {
+	var got pushRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/git/repositories/repo-123/pushes" {
+			t.Errorf("request = %s %s, want POST pushes", r.Method, r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"pushId": 1}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-org", "test-project", "test-pat")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	client.baseURL = server.URL
+
+	if err := client.PushFileEdit("repo-123", "feat", "aaa", "/src/main.go", "x\n", "Apply suggestion"); err != nil {
+		t.Fatalf("PushFileEdit() error = %v", err)
+	}
+	if len(got.RefUpdates) != 1 || got.RefUpdates[0].Name != "refs/heads/feat" || got.RefUpdates[0].OldObjectID != "aaa" {
+		t.Errorf("refUpdates = %+v", got.RefUpdates)
+	}
+	if len(got.Commits) != 1 || got.Commits[0].Comment != "Apply suggestion" || len(got.Commits[0].Changes) != 1 {
+		t.Fatalf("commits = %+v", got.Commits)
+	}
+	change := got.Commits[0].Changes[0]
+	if change.ChangeType != "edit" || change.Item.Path != "/src/main.go" || change.NewContent.Content != "x\n" {
+		t.Errorf("change = %+v", change)
+	}
+}
+
+func TestCommentEndpoints_UseThreadCommentPaths(t *testing.T) {
+	var requests []string
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		if r.Method == "PATCH" {
+			b, _ := io.ReadAll(r.Body)
+			body = string(b)
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-org", "test-project", "test-pat")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	client.baseURL = server.URL
+
+	if err := client.EditPRComment("repo-123", 42, 7, 2, "Updated \"text\""); err != nil {
+		t.Fatalf("EditPRComment() error = %v", err)
+	}
+	if err := client.DeletePRComment("repo-123", 42, 7, 2); err != nil {
+		t.Fatalf("DeletePRComment() error = %v", err)
+	}
+	if err := client.LikePRComment("repo-123", 42, 7, 2, false); err != nil {
+		t.Fatalf("LikePRComment() error = %v", err)
+	}
+	if err := client.LikePRComment("repo-123", 42, 7, 2, true); err != nil {
+		t.Fatalf("LikePRComment(unlike) error = %v", err)
+	}
+
+	const comment = "/git/repositories/repo-123/pullRequests/42/threads/7/comments/2"
+	want := []string{
+		"PATCH " + comment,
+		"DELETE " + comment,
+		"POST " + comment + "/likes",
+		"DELETE " + comment + "/likes",
+	}
+	if strings.Join(requests, "\n") != strings.Join(want, "\n") {
+		t.Errorf("requests = %q, want %q", requests, want)
+	}
+	if body != `{"content": "Updated \"text\""}` {
+		t.Errorf("edit body = %s", body)
+	}
+}
@@ -0,0 +1,68 @@
+package azdevops
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// workItemUpdatesPageSize is the most updates the /updates endpoint
+// returns per request.
+const workItemUpdatesPageSize = 200
+
+// WorkItemFieldUpdate is the change one revision made to a field. OldValue
+// is nil when the field was set and NewValue when it was cleared.
+type WorkItemFieldUpdate struct {
+	OldValue any `json:"oldValue"`
+	NewValue any `json:"newValue"`
+}
+
+// WorkItemRelationUpdates lists the relations one revision added, removed
+// or changed the attributes of.
+type WorkItemRelationUpdates struct {
+	Added   []WorkItemRelation `json:"added"`
+	Removed []WorkItemRelation `json:"removed"`
+	Updated []WorkItemRelation `json:"updated"`
+}
+
+// WorkItemRevisionUpdate is one entry of a work item's update history: the
+// fields and relations one revision changed. Comments show up as changes
+// to System.History.
+type WorkItemRevisionUpdate struct {
+	ID          int                            `json:"id"`
+	Rev         int                            `json:"rev"`
+	RevisedBy   Identity                       `json:"revisedBy"`
+	RevisedDate time.Time                      `json:"revisedDate"`
+	Fields      map[string]WorkItemFieldUpdate `json:"fields"`
+	Relations   *WorkItemRelationUpdates       `json:"relations"`
+}
+
+// WorkItemRevisionUpdatesResponse represents the response from listing a work item's updates
+type WorkItemRevisionUpdatesResponse struct {
+	Count int                      `json:"count"`
+	Value []WorkItemRevisionUpdate `json:"value"`
+}
+
+// GetWorkItemUpdates retrieves the full update history of a work item,
+// oldest first, fetching it page by page.
+func (c *Client) GetWorkItemUpdates(id int) ([]WorkItemRevisionUpdate, error) {
+	var updates []WorkItemRevisionUpdate
+	for {
+		path := fmt.Sprintf("/wit/workitems/%d/updates?$top=%d&$skip=%d&api-version=7.1",
+			id, workItemUpdatesPageSize, len(updates))
+		body, err := c.get(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get work item updates: %w", err)
+		}
+
+		var response WorkItemRevisionUpdatesResponse
+		if err := json.Unmarshal(body, &response); err != nil {
+			return nil, fmt.Errorf("failed to parse Azure DevOps API response for work item updates: %w. "+
+				"This may indicate an API structure change. Please check for updates or report this issue", err)
+		}
+		updates = append(updates, response.Value...)
+		if len(response.Value) < workItemUpdatesPageSize {
+			return updates, nil
+		}
+	}
+}
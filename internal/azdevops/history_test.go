@@ -0,0 +1,94 @@
+package azdevops
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Elpulgo/azdo/internal/provider"
+)
+
+func TestAdapter_GetWorkItemHistory_MapsFieldsLinksAndComments(t *testing.T) {
+	a := newEditTestAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/wit/workitems/7/updates" || r.URL.Query().Get("$skip") != "0" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		w.Write([]byte(`{"count": 3, "value": [
+			{"id": 1, "rev": 1, "revisedBy": {"displayName": "Ada", "uniqueName": "ada@example.com"},
+				"fields": {
+					"System.Title": {"newValue": "Crash"},
+					"System.State": {"newValue": "New"},
+					"System.ChangedDate": {"newValue": "2026-10-01T09:30:00Z"},
+					"System.AreaLevel1": {"newValue": "proj"}
+				}},
+			{"id": 2, "rev": 2, "revisedBy": {"displayName": "Bob", "uniqueName": "bob@example.com"},
+				"revisedDate": "2026-10-02T10:00:00Z",
+				"fields": {
+					"System.State": {"oldValue": "New", "newValue": "Active"},
+					"System.AssignedTo": {"newValue": {"displayName": "Bob", "uniqueName": "bob@example.com"}},
+					"System.History": {"newValue": "<div>On it</div>"},
+					"System.Rev": {"oldValue": 1, "newValue": 2}
+				},
+				"relations": {
+					"added": [{"rel": "System.LinkTypes.Hierarchy-Reverse", "url": "https://x/_apis/wit/workItems/3", "attributes": {"name": "Parent"}},
+						{"rel": "AttachedFile", "url": "https://x/_apis/wit/attachments/a1", "attributes": {"name": "log.txt"}}],
+					"removed": [{"rel": "System.LinkTypes.Related", "url": "https://x/_apis/wit/workItems/5"}]
+				}},
+			{"id": 3, "rev": 3, "revisedBy": {"displayName": "Bob"},
+				"fields": {"System.Watermark": {"oldValue": 10, "newValue": 11}}}
+		]}`))
+	})
+
+	history, err := a.GetWorkItemHistory("proj", 7)
+	if err != nil {
+		t.Fatalf("GetWorkItemHistory() error = %v", err)
+	}
+	want := []provider.WorkItemRevision{
+		{
+			Rev: 1, AuthorName: "Ada", AuthorLogin: "ada@example.com",
+			Date: time.Date(2026, 10, 1, 9, 30, 0, 0, time.UTC),
+			Changes: []provider.WorkItemChange{
+				{Kind: provider.ChangeField, Field: "State", New: "New"},
+				{Kind: provider.ChangeField, Field: "Title", New: "Crash"},
+			},
+		},
+		{
+			Rev: 2, AuthorName: "Bob", AuthorLogin: "bob@example.com",
+			Date: time.Date(2026, 10, 2, 10, 0, 0, 0, time.UTC),
+			Changes: []provider.WorkItemChange{
+				{Kind: provider.ChangeField, Field: "AssignedTo", New: "Bob"},
+				{Kind: provider.ChangeComment, Field: "Comment", New: "<div>On it</div>"},
+				{Kind: provider.ChangeField, Field: "State", Old: "New", New: "Active"},
+				{Kind: provider.ChangeLink, Field: "Links", New: "Parent #3"},
+				{Kind: provider.ChangeLink, Field: "Links", New: "Attachment log.txt"},
+				{Kind: provider.ChangeLink, Field: "Links", Old: "Related #5"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(history, want) {
+		t.Errorf("history = %+v\nwant %+v", history, want)
+	}
+}
+
+func TestClient_GetWorkItemUpdates_FetchesEveryPage(t *testing.T) {
+	var skips []string
+	a := newEditTestAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		skip := r.URL.Query().Get("$skip")
+		skips = append(skips, skip)
+		if skip != "0" {
+			w.Write([]byte(`{"count": 1, "value": [{"id": 201, "rev": 201}]}`))
+			return
+		}
+		w.Write([]byte(`{"count": 200, "value": [` + strings.Repeat(`{"id": 1, "rev": 1}, `, 199) + `{"id": 200, "rev": 200}]}`))
+	})
+
+	updates, err := a.mc.ClientFor("proj").GetWorkItemUpdates(7)
+	if err != nil {
+		t.Fatalf("GetWorkItemUpdates() error = %v", err)
+	}
+	if len(updates) != 201 || !reflect.DeepEqual(skips, []string{"0", "200"}) {
+		t.Errorf("got %d updates with skips %v, want 201 over two pages", len(updates), skips)
+	}
+}
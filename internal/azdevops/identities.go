@@ -0,0 +1,137 @@
+package azdevops
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// identityPickerAPIVersion is the api-version for the Identity Picker, the
+// people search behind the web UI's @mention box. It is only offered as a
+// preview API.
+const identityPickerAPIVersion = "7.1-preview.1"
+
+// identityPickerMaxResults caps how many people a search returns.
+const identityPickerMaxResults = 10
+
+// PickerIdentity is a user returned by the Identity Picker. LocalID is the
+// identity GUID that "@<GUID>" mentions refer to.
+type PickerIdentity struct {
+	LocalID       string `json:"localId"`
+	DisplayName   string `json:"displayName"`
+	SignInAddress string `json:"signInAddress"`
+	Mail          string `json:"mail"`
+}
+
+// identityPickerRequest is the body of the Identity Picker search.
+type identityPickerRequest struct {
+	Query           string                `json:"query"`
+	IdentityTypes   []string              `json:"identityTypes"`
+	OperationScopes []string              `json:"operationScopes"`
+	Properties      []string              `json:"properties"`
+	Options         identityPickerOptions `json:"options"`
+}
+
+type identityPickerOptions struct {
+	MinResults int `json:"MinResults"`
+	MaxResults int `json:"MaxResults"`
+}
+
+// identityPickerResponse wraps one result set per query token.
+type identityPickerResponse struct {
+	Results []struct {
+		Identities []PickerIdentity `json:"identities"`
+	} `json:"results"`
+}
+
+// SearchIdentities returns the users of the organization whose name or
+// email starts with query, as offered by the web UI's mention box.
+func (c *Client) SearchIdentities(query string) ([]PickerIdentity, error) {
+	if strings.TrimSpace(query) == "" {
+		return nil, nil
+	}
+
+	reqBody := identityPickerRequest{
+		Query:           query,
+		IdentityTypes:   []string{"user"},
+		OperationScopes: []string{"ims", "source"},
+		Properties:      []string{"DisplayName", "Mail", "SignInAddress"},
+		Options:         identityPickerOptions{MinResults: 1, MaxResults: identityPickerMaxResults},
+	}
+	payload, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal identity search: %w", err)
+	}
+
+	u := fmt.Sprintf("%s/IdentityPicker/Identities?api-version=%s", c.orgURL, identityPickerAPIVersion)
+	body, err := c.doURL("POST", u, strings.NewReader(string(payload)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to search identities: %w", err)
+	}
+
+	var response identityPickerResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse Azure DevOps API response for identity search: %w. "+
+			"This may indicate an API structure change. Please check for updates or report this issue", err)
+	}
+
+	var result []PickerIdentity
+	for _, r := range response.Results {
+		for _, id := range r.Identities {
+			if id.LocalID != "" {
+				result = append(result, id)
+			}
+		}
+	}
+	return result, nil
+}
+
+// IdentityRecord is an identity as returned by the identity service when
+// read by ID.
+type IdentityRecord struct {
+	ID                  string `json:"id"`
+	ProviderDisplayName string `json:"providerDisplayName"`
+	CustomDisplayName   string `json:"customDisplayName"`
+	Properties          struct {
+		Account struct {
+			Value string `json:"$value"`
+		} `json:"Account"`
+	} `json:"properties"`
+}
+
+// identitiesResponse is the list wrapper returned by the identities endpoint.
+type identitiesResponse struct {
+	Count int              `json:"count"`
+	Value []IdentityRecord `json:"value"`
+}
+
+// GetIdentities reads the identities with the given GUIDs, such as the
+// ones referenced by "@<GUID>" mentions. Unknown IDs are left out.
+func (c *Client) GetIdentities(ids []string) ([]IdentityRecord, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	u := fmt.Sprintf("%s/identities?identityIds=%s&queryMembership=None&api-version=7.1",
+		c.vsspsURL, url.QueryEscape(strings.Join(ids, ",")))
+	body, err := c.doURL("GET", u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get identities: %w", err)
+	}
+
+	var response identitiesResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse Azure DevOps API response for identities: %w. "+
+			"This may indicate an API structure change. Please check for updates or report this issue", err)
+	}
+
+	// The endpoint answers unknown IDs with null entries.
+	result := make([]IdentityRecord, 0, len(response.Value))
+	for _, id := range response.Value {
+		if id.ID != "" {
+			result = append(result, id)
+		}
+	}
+	return result, nil
+}
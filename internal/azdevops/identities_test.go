@@ -0,0 +1,115 @@
+package azdevops
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestClient_SearchIdentities(t *testing.T) {
+	var capturedPath, capturedQuery string
+	var captured identityPickerRequest
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		capturedPath = r.URL.Path
+		capturedQuery = r.URL.RawQuery
+		json.NewDecoder(r.Body).Decode(&captured)
+		w.Write([]byte(`{
+			"results": [{
+				"queryToken": "ja",
+				"identities": [
+					{"localId": "id-1", "displayName": "Jane Doe", "signInAddress": "jane@x.com"},
+					{"localId": "", "displayName": "Not in org"}
+				]
+			}]
+		}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	client.orgURL = server.URL + "/test-org/_apis"
+
+	found, err := client.SearchIdentities("ja")
+	if err != nil {
+		t.Fatalf("SearchIdentities() error = %v", err)
+	}
+
+	if capturedPath != "/test-org/_apis/IdentityPicker/Identities" {
+		t.Errorf("path = %q, want the org-level Identity Picker", capturedPath)
+	}
+	if !strings.Contains(capturedQuery, "api-version=7.1-preview.1") {
+		t.Errorf("query = %q, want preview api-version", capturedQuery)
+	}
+	if captured.Query != "ja" || len(captured.IdentityTypes) != 1 || captured.IdentityTypes[0] != "user" {
+		t.Errorf("request body = %+v", captured)
+	}
+	if len(found) != 1 || found[0].LocalID != "id-1" || found[0].DisplayName != "Jane Doe" {
+		t.Errorf("found = %+v, want only Jane Doe", found)
+	}
+}
+
+func TestClient_SearchIdentities_EmptyQuerySkipsRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("no request expected for an empty query")
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	client.orgURL = server.URL
+
+	if found, err := client.SearchIdentities("  "); err != nil || found != nil {
+		t.Errorf("SearchIdentities() = %v, %v; want nil, nil", found, err)
+	}
+}
+
+func TestClient_GetIdentities(t *testing.T) {
+	var capturedPath, capturedIDs string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		capturedPath = r.URL.Path
+		capturedIDs = r.URL.Query().Get("identityIds")
+		w.Write([]byte(`{
+			"count": 2,
+			"value": [
+				{"id": "id-1", "providerDisplayName": "Jane Doe", "properties": {"Account": {"$type": "System.String", "$value": "jane@x.com"}}},
+				null
+			]
+		}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	client.vsspsURL = server.URL + "/test-org/_apis"
+
+	found, err := client.GetIdentities([]string{"id-1", "gone"})
+	if err != nil {
+		t.Fatalf("GetIdentities() error = %v", err)
+	}
+
+	if capturedPath != "/test-org/_apis/identities" {
+		t.Errorf("path = %q, want /test-org/_apis/identities", capturedPath)
+	}
+	if capturedIDs != "id-1,gone" {
+		t.Errorf("identityIds = %q, want id-1,gone", capturedIDs)
+	}
+	if len(found) != 1 || found[0].ID != "id-1" || found[0].Properties.Account.Value != "jane@x.com" {
+		t.Errorf("found = %+v, want only Jane Doe", found)
+	}
+}
+
+func TestMapPickerIdentity_MentionMarkup(t *testing.T) {
+	p := MapPickerIdentity(PickerIdentity{LocalID: "id-1", DisplayName: "Liam O'Brien", Mail: "liam@x.com"})
+
+	if p.ID != "id-1" || p.Login != "liam@x.com" {
+		t.Errorf("person = %+v", p)
+	}
+	if p.Mention != "@<id-1>" {
+		t.Errorf("Mention = %q, want @<id-1>", p.Mention)
+	}
+	want := `<a href="#" data-vss-mention="version:2.0,id-1">@Liam O&#39;Brien</a>`
+	if p.HTMLMention != want {
+		t.Errorf("HTMLMention = %q, want %q", p.HTMLMention, want)
+	}
+}
@@ -2,7 +2,10 @@ package azdevops
 
 import (
 	"fmt"
+	"net/url"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/Elpulgo/azdo/internal/provider"
 )
@@ -62,14 +65,67 @@ func BuildWIQLFilters(opts provider.ListOpts) string {
 	}
 
 	if opts.Search != "" {
-		// Escape single quotes to prevent WIQL injection: ' → ''
-		escaped := strings.ReplaceAll(opts.Search, "'", "''")
-		parts = append(parts, fmt.Sprintf("  AND [System.Title] CONTAINS '%s'", escaped))
+		parts = append(parts, "  AND [System.Title] CONTAINS "+WIQLString(opts.Search))
 	}
 
 	return strings.Join(parts, "\n")
 }
 
+// WIQLString quotes s as a WIQL string literal. Single quotes are doubled
+// to prevent WIQL injection.
+func WIQLString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// wiqlMacro matches the WIQL macros a criteria value may use unquoted,
+// e.g. @Me, @CurrentIteration or @Today - 7.
+var wiqlMacro = regexp.MustCompile(`^@[A-Za-z]+(\s*[+-]\s*\d+)?$`)
+
+// wiqlValue renders a criteria value: macros as they are, anything else
+// as a string literal.
+func wiqlValue(s string) string {
+	if wiqlMacro.MatchString(s) {
+		return s
+	}
+	return WIQLString(s)
+}
+
+// BuildCriteriaWIQL builds the WIQL query for criteria, scoped to the
+// project of the client that runs it and ordered by last change. Every
+// value is escaped, so criteria from the config cannot alter the query.
+func BuildCriteriaWIQL(c provider.WorkItemQueryCriteria) string {
+	parts := []string{"[System.TeamProject] = @project"}
+
+	in := func(field string, values []string) {
+		if len(values) == 0 {
+			return
+		}
+		literals := make([]string, len(values))
+		for i, v := range values {
+			literals[i] = wiqlValue(v)
+		}
+		parts = append(parts, fmt.Sprintf("%s IN (%s)", field, strings.Join(literals, ", ")))
+	}
+	in("[System.WorkItemType]", c.Types)
+	in("[System.State]", c.States)
+
+	for _, tag := range c.Tags {
+		parts = append(parts, "[System.Tags] CONTAINS "+WIQLString(tag))
+	}
+	if c.AssignedTo != "" {
+		parts = append(parts, "[System.AssignedTo] = "+wiqlValue(c.AssignedTo))
+	}
+	if c.AreaPath != "" {
+		parts = append(parts, "[System.AreaPath] UNDER "+wiqlValue(c.AreaPath))
+	}
+	if c.IterationPath != "" {
+		parts = append(parts, "[System.IterationPath] UNDER "+wiqlValue(c.IterationPath))
+	}
+
+	return "SELECT [System.Id] FROM WorkItems\nWHERE " + strings.Join(parts, "\n  AND ") +
+		"\nORDER BY [System.ChangedDate] DESC"
+}
+
 // StateCategoryToWIQLStates maps a neutral StateCategory to the set of Azure
 // DevOps wire state strings used in WIQL IN clauses.
 //
@@ -96,3 +152,90 @@ func StateCategoryToWIQLStates(cat provider.StateCategory) []string {
 		return nil
 	}
 }
+
+// PRSearchCriteria holds the searchCriteria parameters of the pull request
+// list endpoint. Empty fields are not sent.
+type PRSearchCriteria struct {
+	// Status is "active", "completed", "abandoned" or "all"; empty means
+	// "active", the historical default of the PR list.
+	Status string
+	// TargetRefName is the full ref name of the target branch.
+	TargetRefName string
+	CreatorID     string
+	ReviewerID    string
+	// MinTime restricts results to pull requests created at or after it.
+	MinTime time.Time
+}
+
+// query renders the criteria as query parameters, each starting with &.
+func (sc PRSearchCriteria) query() string {
+	status := sc.Status
+	if status == "" {
+		status = "active"
+	}
+	q := "&searchCriteria.status=" + status
+	if sc.TargetRefName != "" {
+		q += "&searchCriteria.targetRefName=" + url.QueryEscape(sc.TargetRefName)
+	}
+	if sc.CreatorID != "" {
+		q += "&searchCriteria.creatorId=" + sc.CreatorID
+	}
+	if sc.ReviewerID != "" {
+		q += "&searchCriteria.reviewerId=" + sc.ReviewerID
+	}
+	if !sc.MinTime.IsZero() {
+		q += "&searchCriteria.minTime=" + url.QueryEscape(sc.MinTime.UTC().Format(time.RFC3339)) +
+			"&searchCriteria.queryTimeRangeType=created"
+	}
+	return q
+}
+
+// BuildPRSearchCriteria translates the pull request filters of a
+// provider.ListOpts into search criteria. Filters the endpoint cannot
+// express (repository name, author name, draft state, labels and title
+// search) are left to provider.FilterPullRequests. The creator and reviewer
+// IDs are set by the caller.
+//
+// Zero-value opts produces the default query for active pull requests.
+func BuildPRSearchCriteria(opts provider.ListOpts) PRSearchCriteria {
+	var sc PRSearchCriteria
+
+	statuses := map[string]bool{}
+	for _, cat := range opts.States {
+		if status := stateCategoryToPRStatus(cat); status != "" {
+			statuses[status] = true
+		}
+	}
+	switch len(statuses) {
+	case 0:
+	case 1:
+		for status := range statuses {
+			sc.Status = status
+		}
+	default:
+		// The endpoint takes one status; fetch all and let the
+		// post-filter drop the unwanted ones.
+		sc.Status = "all"
+	}
+
+	if opts.TargetBranch != "" {
+		sc.TargetRefName = "refs/heads/" + strings.TrimPrefix(opts.TargetBranch, "refs/heads/")
+	}
+	sc.MinTime = opts.CreatedAfter
+	return sc
+}
+
+// stateCategoryToPRStatus maps a neutral StateCategory to the Azure DevOps
+// pull request status, or "" for categories pull requests do not have.
+func stateCategoryToPRStatus(cat provider.StateCategory) string {
+	switch cat {
+	case provider.StateCategoryActive:
+		return "active"
+	case provider.StateCategoryClosedDone:
+		return "completed"
+	case provider.StateCategoryRemoved:
+		return "abandoned"
+	default:
+		return ""
+	}
+}
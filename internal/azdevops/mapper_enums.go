@@ -73,6 +73,24 @@ func MapVoteKind(vote int) provider.VoteKind {
 	}
 }
 
+// MapMergeStatus translates an Azure DevOps wire PR mergeStatus into a
+// neutral provider.MergeStatus. "notSet" and "queued" (the merge has not
+// been computed yet) and unknown values map to MergeStatusUnknown.
+func MapMergeStatus(status string) provider.MergeStatus {
+	switch status {
+	case MergeStatusSucceeded:
+		return provider.MergeStatusMergeable
+	case MergeStatusConflicts:
+		return provider.MergeStatusConflicts
+	case MergeStatusRejectedByPolicy:
+		return provider.MergeStatusBlocked
+	case MergeStatusFailure:
+		return provider.MergeStatusFailed
+	default:
+		return provider.MergeStatusUnknown
+	}
+}
+
 // MapRunStatus translates an Azure DevOps wire pipeline run status+result pair
 // into a neutral provider.RunStatus. Status is checked before result because
 // in-flight states (inProgress, notStarted, canceling) are authoritative
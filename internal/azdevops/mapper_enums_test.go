@@ -107,6 +107,31 @@ func TestMapVoteKind(t *testing.T) {
 	}
 }
 
+// --- MergeStatus mapping ---
+
+func TestMapMergeStatus(t *testing.T) {
+	tests := []struct {
+		input string
+		want  provider.MergeStatus
+	}{
+		{input: "succeeded", want: provider.MergeStatusMergeable},
+		{input: "conflicts", want: provider.MergeStatusConflicts},
+		{input: "rejectedByPolicy", want: provider.MergeStatusBlocked},
+		{input: "failure", want: provider.MergeStatusFailed},
+		{input: "queued", want: provider.MergeStatusUnknown},
+		{input: "notSet", want: provider.MergeStatusUnknown},
+		{input: "", want: provider.MergeStatusUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := azdevops.MapMergeStatus(tt.input); got != tt.want {
+				t.Errorf("MapMergeStatus(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
 // --- RunStatus mapping ---
 
 func TestMapRunStatus(t *testing.T) {
@@ -2,6 +2,11 @@ package azdevops
 
 import (
 	"fmt"
+	"html"
+	"path"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/Elpulgo/azdo/internal/provider"
 )
@@ -10,9 +15,10 @@ import (
 // scope is the project API name (ProjectName) and scopeDisplay is its human-readable
 // display name (ProjectDisplayName). Both are stamped onto Identity at this boundary.
 func MapWorkItem(w WorkItem, scope, scopeDisplay string) provider.WorkItem {
-	assignedTo := ""
+	assignedTo, assignedToLogin := "", ""
 	if w.Fields.AssignedTo != nil {
 		assignedTo = w.Fields.AssignedTo.DisplayName
+		assignedToLogin = w.Fields.AssignedTo.UniqueName
 	}
 	return provider.WorkItem{
 		Identity: provider.Identity{
@@ -21,24 +27,31 @@ func MapWorkItem(w WorkItem, scope, scopeDisplay string) provider.WorkItem {
 			ScopeDisplay: scopeDisplay,
 			ID:           fmt.Sprintf("%d", w.ID),
 		},
-		Title:           w.Fields.Title,
-		State:           w.Fields.State,
-		WorkItemType:    w.Fields.WorkItemType,
-		StateCategory:   MapStateCategory(w.Fields.State),
-		ItemKind:        MapItemType(w.Fields.WorkItemType),
-		AssignedToName:  assignedTo,
-		Priority:        w.Fields.Priority,
-		ChangedDate:     w.Fields.ChangedDate,
-		CreatedDate:     w.Fields.CreatedDate,
-		StateChangeDate: w.Fields.StateChangeDate,
-		ActivatedDate:   w.Fields.ActivatedDate,
-		ClosedDate:      w.Fields.ClosedDate,
-		IterationPath:   w.Fields.IterationPath,
-		Description:     w.Fields.Description,
-		ReproSteps:      w.Fields.ReproSteps,
-		Tags:            w.Fields.Tags,
-		StoryPoints:     w.Fields.StoryPoints,
-		URL:             w.URL,
+		Title:              w.Fields.Title,
+		State:              w.Fields.State,
+		WorkItemType:       w.Fields.WorkItemType,
+		StateCategory:      MapStateCategory(w.Fields.State),
+		ItemKind:           MapItemType(w.Fields.WorkItemType),
+		AssignedToName:     assignedTo,
+		AssignedTo:         assignedToLogin,
+		Priority:           w.Fields.Priority,
+		ChangedDate:        w.Fields.ChangedDate,
+		CreatedDate:        w.Fields.CreatedDate,
+		StateChangeDate:    w.Fields.StateChangeDate,
+		ActivatedDate:      w.Fields.ActivatedDate,
+		ClosedDate:         w.Fields.ClosedDate,
+		IterationPath:      w.Fields.IterationPath,
+		AreaPath:           w.Fields.AreaPath,
+		Description:        w.Fields.Description,
+		ReproSteps:         w.Fields.ReproSteps,
+		AcceptanceCriteria: w.Fields.AcceptanceCriteria,
+		Tags:               w.Fields.Tags,
+		BoardColumn:        w.Fields.BoardColumn,
+		StoryPoints:        w.Fields.StoryPoints,
+		RemainingWork:      w.Fields.RemainingWork,
+		URL:                w.URL,
+		ParentID:           w.Fields.Parent,
+		Rev:                w.Rev,
 	}
 }
 
@@ -53,6 +66,12 @@ func MapPullRequest(pr PullRequest, scope, scopeDisplay string) provider.PullReq
 			Kind:        MapVoteKind(r.Vote),
 		}
 	}
+	// Azure DevOps reports no last-activity date; the closed date is the
+	// latest one known, and open PRs fall back to their creation date.
+	updated := pr.CreationDate
+	if pr.ClosedDate != nil {
+		updated = *pr.ClosedDate
+	}
 	return provider.PullRequest{
 		Identity: provider.Identity{
 			Kind:         provider.KindAzure,
@@ -65,14 +84,29 @@ func MapPullRequest(pr PullRequest, scope, scopeDisplay string) provider.PullReq
 		Status:         pr.Status,
 		StatusCategory: MapStateCategory(pr.Status),
 		CreationDate:   pr.CreationDate,
+		UpdatedDate:    updated,
 		SourceRefName:  pr.SourceRefName,
 		TargetRefName:  pr.TargetRefName,
 		IsDraft:        pr.IsDraft,
+		Labels:         pr.ActiveLabelNames(),
 		CreatedByName:  pr.CreatedBy.DisplayName,
 		CreatedByID:    pr.CreatedBy.ID,
 		RepositoryID:   pr.Repository.ID,
 		RepositoryName: pr.Repository.Name,
 		Reviewers:      reviewers,
+		MergeStatus:    MapMergeStatus(pr.MergeStatus),
+	}
+}
+
+// MapMergeConflict maps an azdevops wire Conflict to a provider.MergeConflict.
+func MapMergeConflict(c Conflict) provider.MergeConflict {
+	return provider.MergeConflict{
+		ID:           c.ConflictID,
+		Path:         c.ConflictPath,
+		Kind:         c.ConflictType,
+		BaseCommit:   c.MergeBaseCommit.CommitID,
+		SourceCommit: c.MergeSourceCommit.CommitID,
+		TargetCommit: c.MergeTargetCommit.CommitID,
 	}
 }
 
@@ -101,16 +135,15 @@ func MapPipelineRun(p PipelineRun, scope, scopeDisplay string) provider.Pipeline
 }
 
 // MapThread maps an azdevops wire Thread to a provider.Thread.
-// The Line field is populated from ThreadContext.RightFileStart.Line; it is 0
-// for general (non-file) comment threads.
+// Range is populated from the right-side positions when present, otherwise
+// from the left-side ones; Line is the range start. Both are zero for general
+// (non-file) comment threads.
 func MapThread(t Thread, scope, scopeDisplay string) provider.Thread {
 	var filePath string
-	var line int
+	var rng provider.LineRange
 	if t.ThreadContext != nil {
 		filePath = t.ThreadContext.FilePath
-		if t.ThreadContext.RightFileStart != nil {
-			line = t.ThreadContext.RightFileStart.Line
-		}
+		rng = mapThreadRange(*t.ThreadContext)
 	}
 
 	comments := make([]provider.Comment, len(t.Comments))
@@ -129,12 +162,58 @@ func MapThread(t Thread, scope, scopeDisplay string) provider.Thread {
 		LastUpdatedDate: t.LastUpdatedDate,
 		Status:          t.Status,
 		FilePath:        filePath,
-		Line:            line,
+		Line:            rng.StartLine,
+		Range:           rng,
 		Comments:        comments,
 		IsDeleted:       t.IsDeleted,
 	}
 }
 
+// mapThreadRange converts the left/right file positions of a thread context
+// into a neutral LineRange. Right-side positions win when both are present.
+// A missing end position collapses the range to its start line.
+func mapThreadRange(tc ThreadContext) provider.LineRange {
+	side := provider.SideRight
+	start, end := tc.RightFileStart, tc.RightFileEnd
+	if start == nil && tc.LeftFileStart != nil {
+		side = provider.SideLeft
+		start, end = tc.LeftFileStart, tc.LeftFileEnd
+	}
+	if start == nil {
+		return provider.LineRange{}
+	}
+	if end == nil {
+		end = start
+	}
+	return provider.LineRange{
+		Side:        side,
+		StartLine:   start.Line,
+		StartOffset: start.Offset,
+		EndLine:     end.Line,
+		EndOffset:   end.Offset,
+	}
+}
+
+// threadContextFor builds the wire ThreadContext for a new code comment.
+// Azure offsets are 1-based; a zero (whole-line) offset maps to column 1 on
+// both ends, which is what the web UI sends for a line-level comment.
+func threadContextFor(filePath string, rng provider.LineRange) ThreadContext {
+	endLine := rng.EndLine
+	if endLine < rng.StartLine {
+		endLine = rng.StartLine
+	}
+	start := &FilePosition{Line: rng.StartLine, Offset: max(rng.StartOffset, 1)}
+	end := &FilePosition{Line: endLine, Offset: max(rng.EndOffset, 1)}
+
+	tc := ThreadContext{FilePath: filePath}
+	if rng.IsLeft() {
+		tc.LeftFileStart, tc.LeftFileEnd = start, end
+	} else {
+		tc.RightFileStart, tc.RightFileEnd = start, end
+	}
+	return tc
+}
+
 // MapComment maps an azdevops wire Comment to a provider.Comment.
 func MapComment(c Comment, scope, scopeDisplay string) provider.Comment {
 	return provider.Comment{
@@ -151,7 +230,48 @@ func MapComment(c Comment, scope, scopeDisplay string) provider.Comment {
 		CommentType:     c.CommentType,
 		AuthorName:      c.Author.DisplayName,
 		AuthorID:        c.Author.ID,
+		Reactions:       mapCommentLikes(c, ""),
+		IsDeleted:       c.IsDeleted,
+	}
+}
+
+// mapCommentLikes converts a pull request comment's likes into a reaction
+// count; Mine is set when userID is among the users who liked it.
+func mapCommentLikes(c Comment, userID string) []provider.ReactionCount {
+	if len(c.UsersLiked) == 0 {
+		return nil
 	}
+	mine := false
+	for _, u := range c.UsersLiked {
+		if userID != "" && u.ID == userID {
+			mine = true
+		}
+	}
+	return []provider.ReactionCount{{Reaction: provider.ReactionLike, Count: len(c.UsersLiked), Mine: mine}}
+}
+
+// workItemReactionTypes maps neutral reactions to the reaction types of the
+// work item comments API. Rocket and eyes have no equivalent.
+var workItemReactionTypes = map[provider.Reaction]string{
+	provider.ReactionLike:     "like",
+	provider.ReactionDislike:  "dislike",
+	provider.ReactionHeart:    "heart",
+	provider.ReactionHooray:   "hooray",
+	provider.ReactionLaugh:    "smile",
+	provider.ReactionConfused: "confused",
+}
+
+// mapWorkItemReactions converts the reaction summary of a work item comment.
+func mapWorkItemReactions(rs []CommentReaction) []provider.ReactionCount {
+	var result []provider.ReactionCount
+	for _, r := range rs {
+		for neutral, wire := range workItemReactionTypes {
+			if wire == r.Type && r.Count > 0 {
+				result = append(result, provider.ReactionCount{Reaction: neutral, Count: r.Count, Mine: r.IsCurrentUserEngaged})
+			}
+		}
+	}
+	return result
 }
 
 // MapTimeline maps an azdevops wire Timeline to a provider.Timeline.
@@ -238,6 +358,31 @@ func MapIterationChange(ic IterationChange) provider.IterationChange {
 		GitObjectType: ic.Item.GitObjectType,
 		ChangeType:    ic.ChangeType,
 		OriginalPath:  ic.OriginalPath,
+		ObjectID:      ic.Item.ObjectID,
+	}
+}
+
+// MapCommit maps an azdevops wire GitCommit to a provider.Commit.
+// Commits are sub-entities of a PR and carry no Identity.
+func MapCommit(c GitCommit) provider.Commit {
+	return provider.Commit{
+		ID:          c.CommitID,
+		Message:     c.Comment,
+		AuthorName:  c.Author.Name,
+		AuthorEmail: c.Author.Email,
+		Date:        c.Author.Date,
+	}
+}
+
+// MapGitChange maps an azdevops wire GitChange to a provider.IterationChange
+// so a commit's files can be shown like a PR iteration's.
+func MapGitChange(gc GitChange) provider.IterationChange {
+	return provider.IterationChange{
+		Path:          gc.Item.Path,
+		GitObjectType: gc.Item.GitObjectType,
+		ChangeType:    normalizeChangeType(gc.ChangeType),
+		OriginalPath:  gc.SourceServerItem,
+		ObjectID:      gc.Item.ObjectID,
 	}
 }
 
@@ -251,6 +396,230 @@ func MapWorkItemTypeState(s WorkItemTypeState) provider.WorkItemTypeState {
 	}
 }
 
+// MapBoard maps a team's board to a provider.Board. Columns keep the
+// board's state mapping per work item type.
+func MapBoard(b Board) provider.Board {
+	columns := make([]provider.BoardColumn, len(b.Columns))
+	for i, col := range b.Columns {
+		columns[i] = provider.BoardColumn{
+			Name:     col.Name,
+			States:   col.StateMappings,
+			WIPLimit: col.ItemLimit,
+		}
+	}
+	return provider.Board{
+		ID:          b.ID,
+		Name:        b.Name,
+		Columns:     columns,
+		ColumnField: b.Fields.ColumnField.ReferenceName,
+	}
+}
+
+// MapTeamIteration maps an azdevops wire TeamIteration to a provider.Sprint.
+func MapTeamIteration(it TeamIteration) provider.Sprint {
+	s := provider.Sprint{
+		ID:      it.ID,
+		Name:    it.Name,
+		Path:    it.Path,
+		Current: it.Attributes.TimeFrame == "current",
+	}
+	if it.Attributes.StartDate != nil {
+		s.Start = *it.Attributes.StartDate
+	}
+	if it.Attributes.FinishDate != nil {
+		s.Finish = *it.Attributes.FinishDate
+	}
+	return s
+}
+
+// MapSprintCapacity maps the capacities of a sprint's team members to the
+// hours each has left as of now: their hours per day times the working
+// days left that are neither team nor personal days off. Members without
+// capacity are left out. It also returns the team's working days left.
+func MapSprintCapacity(sprint provider.Sprint, members []TeamMemberCapacity, teamDaysOff []DateRange, workingDays []time.Weekday, now time.Time) (int, []provider.SprintCapacity) {
+	daysLeft := workingDaysLeft(sprint.Start, sprint.Finish, now, workingDays, teamDaysOff)
+	var capacity []provider.SprintCapacity
+	for _, m := range members {
+		perDay := m.CapacityPerDay()
+		if perDay <= 0 {
+			continue
+		}
+		daysOff := append(append([]DateRange{}, teamDaysOff...), m.DaysOff...)
+		days := workingDaysLeft(sprint.Start, sprint.Finish, now, workingDays, daysOff)
+		capacity = append(capacity, provider.SprintCapacity{
+			Name:  m.TeamMember.DisplayName,
+			Login: m.TeamMember.UniqueName,
+			Hours: perDay * float64(days),
+		})
+	}
+	return daysLeft, capacity
+}
+
+// historyFieldHidden lists bookkeeping fields every revision changes or
+// that repeat another change, left out of the history.
+var historyFieldHidden = map[string]bool{
+	"System.Id": true, "System.Rev": true, "System.Watermark": true, "System.PersonId": true,
+	"System.ChangedDate": true, "System.ChangedBy": true, "System.RevisedDate": true,
+	"System.AuthorizedDate": true, "System.AuthorizedAs": true,
+	"System.CreatedDate": true, "System.CreatedBy": true, "System.TeamProject": true,
+	"System.AreaId": true, "System.IterationId": true, "System.NodeName": true, "System.Parent": true,
+	"System.CommentCount": true, "System.AttachedFileCount": true, "System.ExternalLinkCount": true,
+	"System.HyperLinkCount": true, "System.RelatedLinkCount": true, "System.RemoteLinkCount": true,
+	"System.BoardColumnDone": true, "Microsoft.VSTS.Common.StateChangeDate": true,
+}
+
+// historyFieldPrefixes are prefixes of the bookkeeping fields left out of
+// the history: the per-level copies of the area and iteration paths and
+// the per-board Kanban fields.
+var historyFieldPrefixes = []string{"System.AreaLevel", "System.IterationLevel", "WEF_"}
+
+// hiddenInHistory reports whether field is left out of the history.
+func hiddenInHistory(field string) bool {
+	if historyFieldHidden[field] {
+		return true
+	}
+	for _, prefix := range historyFieldPrefixes {
+		if strings.HasPrefix(field, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// relationNames names the link types of relations without a name
+// attribute.
+var relationNames = map[string]string{
+	LinkTypeParent:       "Parent",
+	LinkTypeChild:        "Child",
+	LinkTypeRelated:      "Related",
+	LinkTypeAttachedFile: "Attachment",
+	LinkTypeHyperlink:    "Hyperlink",
+}
+
+// relationText describes a relation by its link type and target: a work
+// item ID, the name of an attached file or a hyperlink's URL.
+func relationText(r WorkItemRelation) string {
+	name := relationNames[r.Rel]
+	switch {
+	case r.Rel == LinkTypeAttachedFile:
+		if file, ok := r.Attributes["name"].(string); ok && file != "" {
+			return name + " " + file
+		}
+		return name
+	case r.Rel == LinkTypeHyperlink:
+		return name + " " + r.URL
+	}
+	if attr, ok := r.Attributes["name"].(string); ok && attr != "" {
+		name = attr
+	}
+	if name == "" {
+		name = fieldDisplayName(r.Rel)
+	}
+	if id := RelationTargetID(r); id != 0 {
+		return fmt.Sprintf("%s #%d", name, id)
+	}
+	return name
+}
+
+// MapWorkItemUpdates maps the update history of a work item to its
+// revisions, oldest first. Bookkeeping fields are left out, comments
+// (System.History) become comment changes and relations link changes;
+// revisions changing nothing else are dropped.
+func MapWorkItemUpdates(updates []WorkItemRevisionUpdate) []provider.WorkItemRevision {
+	var revisions []provider.WorkItemRevision
+	for _, u := range updates {
+		rev := provider.WorkItemRevision{
+			Rev:         u.Rev,
+			AuthorName:  u.RevisedBy.DisplayName,
+			AuthorLogin: u.RevisedBy.UniqueName,
+		}
+		if changed, ok := u.Fields["System.ChangedDate"].NewValue.(string); ok {
+			rev.Date, _ = time.Parse(time.RFC3339, changed)
+		}
+		if rev.Date.IsZero() && u.RevisedDate.Year() < 9999 {
+			rev.Date = u.RevisedDate
+		}
+
+		names := make([]string, 0, len(u.Fields))
+		for name := range u.Fields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			change := u.Fields[name]
+			switch {
+			case name == "System.History":
+				if text := FieldValueText(change.NewValue); text != "" {
+					rev.Changes = append(rev.Changes, provider.WorkItemChange{Kind: provider.ChangeComment, Field: "Comment", New: text})
+				}
+			case !hiddenInHistory(name):
+				rev.Changes = append(rev.Changes, provider.WorkItemChange{
+					Kind:  provider.ChangeField,
+					Field: fieldDisplayName(name),
+					Old:   FieldValueText(change.OldValue),
+					New:   FieldValueText(change.NewValue),
+				})
+			}
+		}
+		if u.Relations != nil {
+			for _, r := range u.Relations.Added {
+				rev.Changes = append(rev.Changes, provider.WorkItemChange{Kind: provider.ChangeLink, Field: "Links", New: relationText(r)})
+			}
+			for _, r := range u.Relations.Removed {
+				rev.Changes = append(rev.Changes, provider.WorkItemChange{Kind: provider.ChangeLink, Field: "Links", Old: relationText(r)})
+			}
+		}
+		if len(rev.Changes) > 0 {
+			revisions = append(revisions, rev)
+		}
+	}
+	return revisions
+}
+
+// MapAttachments maps the AttachedFile relations of a work item to its
+// attachments, in the order they were attached. The relation names the
+// file and its size; who attached it is read from the revision of updates
+// that added the relation, if updates are given.
+func MapAttachments(relations []WorkItemRelation, updates []WorkItemRevisionUpdate) []provider.WorkItemAttachment {
+	uploaders := map[string]WorkItemRevisionUpdate{}
+	for _, u := range updates {
+		if u.Relations == nil {
+			continue
+		}
+		for _, r := range u.Relations.Added {
+			if r.Rel == LinkTypeAttachedFile {
+				uploaders[r.URL] = u
+			}
+		}
+	}
+
+	var attachments []provider.WorkItemAttachment
+	for _, r := range relations {
+		if r.Rel != LinkTypeAttachedFile {
+			continue
+		}
+		a := provider.WorkItemAttachment{URL: r.URL}
+		a.Name, _ = r.Attributes["name"].(string)
+		if size, ok := r.Attributes["resourceSize"].(float64); ok {
+			a.Size = int64(size)
+		}
+		if created, ok := r.Attributes["resourceCreatedDate"].(string); ok {
+			a.UploadedAt, _ = time.Parse(time.RFC3339, created)
+		}
+		if u, ok := uploaders[r.URL]; ok {
+			a.UploadedBy = u.RevisedBy.DisplayName
+			if a.UploadedAt.IsZero() && u.RevisedDate.Year() < 9999 {
+				a.UploadedAt = u.RevisedDate
+			}
+		}
+		if a.Name == "" {
+			a.Name = path.Base(r.URL)
+		}
+		attachments = append(attachments, a)
+	}
+	return attachments
+}
+
 // MapWorkItemComment maps an azdevops wire WorkItemComment to a provider.WorkItemComment.
 func MapWorkItemComment(c WorkItemComment, scope, scopeDisplay string) provider.WorkItemComment {
 	return provider.WorkItemComment{
@@ -262,7 +631,44 @@ func MapWorkItemComment(c WorkItemComment, scope, scopeDisplay string) provider.
 		},
 		ID:          c.ID,
 		Text:        c.Text,
+		Markdown:    strings.EqualFold(c.Format, "markdown"),
 		AuthorName:  c.CreatedBy.DisplayName,
 		CreatedDate: c.CreatedDate,
+		Reactions:   mapWorkItemReactions(c.Reactions),
+	}
+}
+
+// MapPickerIdentity maps an Identity Picker result to a provider.Person
+// carrying Azure DevOps mention markup.
+func MapPickerIdentity(id PickerIdentity) provider.Person {
+	login := id.SignInAddress
+	if login == "" {
+		login = id.Mail
+	}
+	return newPerson(id.LocalID, id.DisplayName, login)
+}
+
+// MapIdentityRecord maps an identity read by ID to a provider.Person.
+func MapIdentityRecord(id IdentityRecord) provider.Person {
+	name := id.CustomDisplayName
+	if name == "" {
+		name = id.ProviderDisplayName
+	}
+	return newPerson(id.ID, name, id.Properties.Account.Value)
+}
+
+// newPerson builds a provider.Person with the markup Azure DevOps uses for
+// mentions: "@<GUID>" in pull request comments and a data-vss-mention link
+// in the HTML of work item comments.
+func newPerson(id, name, login string) provider.Person {
+	if name == "" {
+		name = login
+	}
+	return provider.Person{
+		ID:          id,
+		DisplayName: name,
+		Login:       login,
+		Mention:     "@<" + id + ">",
+		HTMLMention: fmt.Sprintf(`<a href="#" data-vss-mention="version:2.0,%s">@%s</a>`, id, html.EscapeString(name)),
 	}
 }
@@ -200,8 +200,8 @@ func TestMapPullRequest_StatusCategoryAndVoteKind_AllVariants(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.status, func(t *testing.T) {
 			wire := azdevops.PullRequest{
-				ID:     1,
-				Status: tt.status,
+				ID:         1,
+				Status:     tt.status,
 				Repository: azdevops.Repository{ID: "r", Name: "r"},
 				Reviewers: []azdevops.Reviewer{
 					{ID: "u", DisplayName: "U", Vote: tt.vote},
@@ -355,6 +355,49 @@ func TestMapThread(t *testing.T) {
 	}
 }
 
+func TestMapThread_RightRange(t *testing.T) {
+	wire := azdevops.Thread{
+		ID: 14,
+		ThreadContext: &azdevops.ThreadContext{
+			FilePath:       "/src/main.go",
+			RightFileStart: &azdevops.FilePosition{Line: 10, Offset: 1},
+			RightFileEnd:   &azdevops.FilePosition{Line: 14, Offset: 7},
+		},
+	}
+
+	got := azdevops.MapThread(wire, testScope, testScopeDisplay)
+
+	want := provider.LineRange{Side: provider.SideRight, StartLine: 10, StartOffset: 1, EndLine: 14, EndOffset: 7}
+	if got.Range != want {
+		t.Errorf("Range = %+v, want %+v", got.Range, want)
+	}
+	if got.Line != 10 {
+		t.Errorf("Line = %d, want range start 10", got.Line)
+	}
+}
+
+func TestMapThread_LeftSideOnly(t *testing.T) {
+	wire := azdevops.Thread{
+		ID: 15,
+		ThreadContext: &azdevops.ThreadContext{
+			FilePath:      "/src/main.go",
+			LeftFileStart: &azdevops.FilePosition{Line: 7, Offset: 1},
+		},
+	}
+
+	got := azdevops.MapThread(wire, testScope, testScopeDisplay)
+
+	if !got.Range.IsLeft() {
+		t.Errorf("Range.Side = %q, want left", got.Range.Side)
+	}
+	if got.Range.StartLine != 7 || got.Range.EndLine != 7 {
+		t.Errorf("Range = %+v, want single line 7 (missing end collapses to start)", got.Range)
+	}
+	if got.Line != 7 {
+		t.Errorf("Line = %d, want 7", got.Line)
+	}
+}
+
 func TestMapThread_NoThreadContext_ZeroLine(t *testing.T) {
 	now := time.Now()
 	wire := azdevops.Thread{
@@ -517,6 +560,32 @@ func TestMapWorkItemComment(t *testing.T) {
 	if got.AuthorName != wire.CreatedBy.DisplayName {
 		t.Errorf("expected AuthorName %q, got %q", wire.CreatedBy.DisplayName, got.AuthorName)
 	}
+	if got.Markdown {
+		t.Error("expected an HTML comment, got Markdown")
+	}
+
+	wire.Format = "markdown"
+	if got := azdevops.MapWorkItemComment(wire, testScope, testScopeDisplay); !got.Markdown {
+		t.Error("expected Markdown for a comment in markdown format")
+	}
+}
+
+func TestMapWorkItemComment_Reactions(t *testing.T) {
+	wire := azdevops.WorkItemComment{
+		ID: 56,
+		Reactions: []azdevops.CommentReaction{
+			{Type: "smile", Count: 2, IsCurrentUserEngaged: true},
+			{Type: "heart", Count: 0},
+			{Type: "unknown", Count: 3},
+		},
+	}
+
+	got := azdevops.MapWorkItemComment(wire, testScope, testScopeDisplay)
+
+	want := []provider.ReactionCount{{Reaction: provider.ReactionLaugh, Count: 2, Mine: true}}
+	if len(got.Reactions) != 1 || got.Reactions[0] != want[0] {
+		t.Errorf("Reactions = %+v, want %+v", got.Reactions, want)
+	}
 }
 
 // --- Iteration ---
@@ -123,139 +123,48 @@ func (mc *MultiClient) ListPipelineRuns(top int) ([]PipelineRun, error) {
 // ListPullRequests fetches PRs from all projects concurrently,
 // tags each with ProjectName, merges and sorts by CreationDate descending.
 func (mc *MultiClient) ListPullRequests(top int) ([]PullRequest, error) {
-	type result struct {
-		project string
-		prs     []PullRequest
-		err     error
-	}
-
-	var wg sync.WaitGroup
-	ch := make(chan result, len(mc.clients))
-
-	for project, client := range mc.clients {
-		wg.Add(1)
-		go func(p string, c *Client) {
-			defer wg.Done()
-			prs, err := c.ListPullRequests(top)
-			ch <- result{p, prs, err}
-		}(project, client)
-	}
-
-	go func() {
-		wg.Wait()
-		close(ch)
-	}()
-
-	var allPRs []PullRequest
-	var errs []error
-	for r := range ch {
-		if r.err != nil {
-			errs = append(errs, r.err)
-			continue
-		}
-		for i := range r.prs {
-			r.prs[i].ProjectName = r.project
-			r.prs[i].ProjectDisplayName = mc.DisplayNameFor(r.project)
-		}
-		allPRs = append(allPRs, r.prs...)
-	}
-
-	if len(errs) == len(mc.clients) {
-		return nil, fmt.Errorf("all projects failed: %v", errs)
-	}
-
-	sort.Slice(allPRs, func(i, j int) bool {
-		return allPRs[i].CreationDate.After(allPRs[j].CreationDate)
-	})
-
-	if len(errs) > 0 {
-		return allPRs, &PartialError{Failed: len(errs), Total: len(mc.clients), Errors: errs}
-	}
-
-	return allPRs, nil
+	return mc.SearchPullRequests(top, PRSearchCriteria{})
 }
 
 // ListMyPullRequests fetches PRs created by the authenticated user from all
 // projects concurrently, tags each with ProjectName, merges and sorts by
 // CreationDate descending.
 func (mc *MultiClient) ListMyPullRequests(top int) ([]PullRequest, error) {
-	// Resolve user ID from any project client (all share the same PAT/org)
-	var userID string
-	for _, client := range mc.clients {
-		id, err := client.GetCurrentUserID()
-		if err != nil {
-			return nil, fmt.Errorf("failed to get current user ID: %w", err)
-		}
-		userID = id
-		break
-	}
-
-	type result struct {
-		project string
-		prs     []PullRequest
-		err     error
-	}
-
-	var wg sync.WaitGroup
-	ch := make(chan result, len(mc.clients))
-
-	for project, client := range mc.clients {
-		wg.Add(1)
-		go func(p string, c *Client) {
-			defer wg.Done()
-			prs, err := c.ListMyPullRequests(userID, top)
-			ch <- result{p, prs, err}
-		}(project, client)
-	}
-
-	go func() {
-		wg.Wait()
-		close(ch)
-	}()
-
-	var allPRs []PullRequest
-	var errs []error
-	for r := range ch {
-		if r.err != nil {
-			errs = append(errs, r.err)
-			continue
-		}
-		for i := range r.prs {
-			r.prs[i].ProjectName = r.project
-			r.prs[i].ProjectDisplayName = mc.DisplayNameFor(r.project)
-		}
-		allPRs = append(allPRs, r.prs...)
+	userID, err := mc.currentUserID()
+	if err != nil {
+		return nil, err
 	}
-
-	if len(errs) == len(mc.clients) {
-		return nil, fmt.Errorf("all projects failed: %v", errs)
-	}
-
-	sort.Slice(allPRs, func(i, j int) bool {
-		return allPRs[i].CreationDate.After(allPRs[j].CreationDate)
-	})
-
-	if len(errs) > 0 {
-		return allPRs, &PartialError{Failed: len(errs), Total: len(mc.clients), Errors: errs}
-	}
-
-	return allPRs, nil
+	return mc.SearchPullRequests(top, PRSearchCriteria{CreatorID: userID})
 }
 
 // ListPullRequestsAsReviewer fetches PRs where the authenticated user is a
 // reviewer from all projects concurrently, tags each with ProjectName, merges
 // and sorts by CreationDate descending.
 func (mc *MultiClient) ListPullRequestsAsReviewer(top int) ([]PullRequest, error) {
-	var userID string
+	userID, err := mc.currentUserID()
+	if err != nil {
+		return nil, err
+	}
+	return mc.SearchPullRequests(top, PRSearchCriteria{ReviewerID: userID})
+}
+
+// currentUserID resolves the authenticated user's ID from any project
+// client; all share the same PAT and organization.
+func (mc *MultiClient) currentUserID() (string, error) {
 	for _, client := range mc.clients {
 		id, err := client.GetCurrentUserID()
 		if err != nil {
-			return nil, fmt.Errorf("failed to get current user ID: %w", err)
+			return "", fmt.Errorf("failed to get current user ID: %w", err)
 		}
-		userID = id
-		break
+		return id, nil
 	}
+	return "", nil
+}
 
+// SearchPullRequests fetches PRs matching criteria from all projects
+// concurrently, tags each with ProjectName, merges and sorts by CreationDate
+// descending.
+func (mc *MultiClient) SearchPullRequests(top int, criteria PRSearchCriteria) ([]PullRequest, error) {
 	type result struct {
 		project string
 		prs     []PullRequest
@@ -269,7 +178,7 @@ func (mc *MultiClient) ListPullRequestsAsReviewer(top int) ([]PullRequest, error
 		wg.Add(1)
 		go func(p string, c *Client) {
 			defer wg.Done()
-			prs, err := c.ListPullRequestsAsReviewer(userID, top)
+			prs, err := c.SearchPullRequests(top, criteria)
 			ch <- result{p, prs, err}
 		}(project, client)
 	}
@@ -0,0 +1,260 @@
+package azdevops
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultQueryTop is the number of results a query returns when it sets
+// no limit of its own.
+const DefaultQueryTop = 200
+
+// maxWorkItemsPerRequest is the number of IDs the work items endpoint
+// accepts at once.
+const maxWorkItemsPerRequest = 200
+
+// QueryHierarchyItem is a saved query or a folder of saved queries
+type QueryHierarchyItem struct {
+	ID          string               `json:"id"`
+	Name        string               `json:"name"`
+	Path        string               `json:"path"` // e.g. "Shared Queries/Team/Open bugs"
+	IsFolder    bool                 `json:"isFolder"`
+	HasChildren bool                 `json:"hasChildren"`
+	QueryType   string               `json:"queryType"` // "flat", "oneHop" or "tree"
+	WIQL        string               `json:"wiql"`
+	Children    []QueryHierarchyItem `json:"children"`
+}
+
+// QueryHierarchyResponse represents the response from listing the query tree
+type QueryHierarchyResponse struct {
+	Count int                  `json:"count"`
+	Value []QueryHierarchyItem `json:"value"`
+}
+
+// WorkItemFieldReference names a field, e.g. a column of a query
+type WorkItemFieldReference struct {
+	ReferenceName string `json:"referenceName"`
+	Name          string `json:"name"`
+}
+
+// WorkItemLinkReference is a link in the result of a tree or one-hop query.
+// Source is nil for the top-level items of the result.
+type WorkItemLinkReference struct {
+	Rel    string             `json:"rel"`
+	Source *WorkItemReference `json:"source"`
+	Target *WorkItemReference `json:"target"`
+}
+
+// ListQueries returns the saved queries of the project, "My Queries" and
+// "Shared Queries", without the folders. The API returns two levels of
+// children at a time, so deeper folders are fetched one by one.
+func (c *Client) ListQueries() ([]QueryHierarchyItem, error) {
+	body, err := c.get("/wit/queries?$depth=2&$expand=wiql&api-version=7.1")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list queries: %w", err)
+	}
+
+	var response QueryHierarchyResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse Azure DevOps API response for queries: %w. "+
+			"This may indicate an API structure change. Please check for updates or report this issue", err)
+	}
+
+	var queries []QueryHierarchyItem
+	var walk func(items []QueryHierarchyItem) error
+	walk = func(items []QueryHierarchyItem) error {
+		for _, item := range items {
+			if !item.IsFolder {
+				queries = append(queries, item)
+				continue
+			}
+			children := item.Children
+			if item.HasChildren && len(children) == 0 {
+				folder, err := c.getQueryFolder(item.ID)
+				if err != nil {
+					return err
+				}
+				children = folder.Children
+			}
+			if err := walk(children); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := walk(response.Value); err != nil {
+		return nil, err
+	}
+	return queries, nil
+}
+
+// getQueryFolder returns a query folder with two levels of children.
+func (c *Client) getQueryFolder(id string) (*QueryHierarchyItem, error) {
+	path := fmt.Sprintf("/wit/queries/%s?$depth=2&$expand=wiql&api-version=7.1", url.PathEscape(id))
+
+	body, err := c.get(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get query folder: %w", err)
+	}
+
+	var folder QueryHierarchyItem
+	if err := json.Unmarshal(body, &folder); err != nil {
+		return nil, fmt.Errorf("failed to parse Azure DevOps API response for query folder: %w. "+
+			"This may indicate an API structure change. Please check for updates or report this issue", err)
+	}
+	return &folder, nil
+}
+
+// RunQuery executes the saved query with the given ID
+// top: maximum number of results to return
+func (c *Client) RunQuery(id string, top int) (*WIQLResponse, error) {
+	path := fmt.Sprintf("/wit/wiql/%s?api-version=7.1&$top=%d", url.PathEscape(id), top)
+
+	body, err := c.get(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to run query: %w", err)
+	}
+
+	var response WIQLResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse Azure DevOps API response for work item query: %w. "+
+			"This may indicate an API structure change. Please check for updates or report this issue", err)
+	}
+	return &response, nil
+}
+
+// IsLinkResult reports whether the result lists work item links (tree and
+// one-hop queries) rather than work items.
+func (r *WIQLResponse) IsLinkResult() bool {
+	return r.QueryResultType == "workItemLink"
+}
+
+// ResultIDs returns the IDs of the result's work items in query order.
+// For link results each item is listed once and parents maps it to the
+// item it is linked from; top-level items have no entry.
+func (r *WIQLResponse) ResultIDs() (ids []int, parents map[int]int) {
+	if !r.IsLinkResult() {
+		ids = make([]int, len(r.WorkItems))
+		for i, wi := range r.WorkItems {
+			ids[i] = wi.ID
+		}
+		return ids, nil
+	}
+
+	parents = map[int]int{}
+	seen := map[int]bool{}
+	for _, link := range r.WorkItemRelations {
+		if link.Target == nil || seen[link.Target.ID] {
+			continue
+		}
+		seen[link.Target.ID] = true
+		ids = append(ids, link.Target.ID)
+		if link.Source != nil {
+			parents[link.Target.ID] = link.Source.ID
+		}
+	}
+	return ids, parents
+}
+
+// GetWorkItemsWithFields retrieves work items by their IDs with the fields
+// GetWorkItems retrieves plus extra, in the order of ids. Any number of
+// IDs can be given; they are fetched in batches.
+func (c *Client) GetWorkItemsWithFields(ids []int, extra []string) ([]WorkItem, error) {
+	fields := append([]string{}, workItemFields...)
+	for _, f := range extra {
+		if !containsFold(fields, f) {
+			fields = append(fields, f)
+		}
+	}
+
+	byID := make(map[int]WorkItem, len(ids))
+	for start := 0; start < len(ids); start += maxWorkItemsPerRequest {
+		end := min(start+maxWorkItemsPerRequest, len(ids))
+		batch, err := c.getWorkItems(ids[start:end], fields)
+		if err != nil {
+			return nil, err
+		}
+		for _, wi := range batch {
+			byID[wi.ID] = wi
+		}
+	}
+
+	items := make([]WorkItem, 0, len(ids))
+	for _, id := range ids {
+		if wi, ok := byID[id]; ok {
+			items = append(items, wi)
+		}
+	}
+	return items, nil
+}
+
+// containsFold reports whether list contains s, ignoring case as field
+// reference names do.
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldValue looks up a field by reference name, ignoring case.
+func fieldValue(values map[string]any, field string) any {
+	if v, ok := values[field]; ok {
+		return v
+	}
+	for name, v := range values {
+		if strings.EqualFold(name, field) {
+			return v
+		}
+	}
+	return nil
+}
+
+// fieldDisplayName derives a column title from a field reference name,
+// e.g. "Microsoft.VSTS.Scheduling.StoryPoints" → "StoryPoints".
+func fieldDisplayName(field string) string {
+	return field[strings.LastIndex(field, ".")+1:]
+}
+
+// UnmarshalJSON decodes the known fields and keeps every value in Values,
+// so query columns can show fields without a member of their own.
+func (f *WorkItemFields) UnmarshalJSON(data []byte) error {
+	type plain WorkItemFields
+	if err := json.Unmarshal(data, (*plain)(f)); err != nil {
+		return err
+	}
+	return json.Unmarshal(data, &f.Values)
+}
+
+// FieldValueText renders a field value for a table cell: identities by
+// display name, dates without the time of day and numbers without
+// trailing zeros.
+func FieldValueText(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		if t, err := time.Parse(time.RFC3339, v); err == nil {
+			return t.Local().Format("2006-01-02")
+		}
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		if v {
+			return "Yes"
+		}
+		return "No"
+	case map[string]any:
+		if name, ok := v["displayName"].(string); ok {
+			return name
+		}
+	}
+	return fmt.Sprint(v)
+}
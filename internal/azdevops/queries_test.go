@@ -0,0 +1,210 @@
+package azdevops
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Elpulgo/azdo/internal/provider"
+)
+
+func TestAdapter_ListWorkItemQueries_WalksFolders(t *testing.T) {
+	a := newEditTestAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/wit/queries":
+			w.Write([]byte(`{"count": 2, "value": [
+				{"id": "f1", "name": "My Queries", "path": "My Queries", "isFolder": true, "hasChildren": true, "children": [
+					{"id": "q1", "name": "Mine", "path": "My Queries/Mine", "queryType": "flat", "wiql": "SELECT 1"}
+				]},
+				{"id": "f2", "name": "Shared Queries", "path": "Shared Queries", "isFolder": true, "hasChildren": true, "children": [
+					{"id": "f3", "name": "Team", "path": "Shared Queries/Team", "isFolder": true, "hasChildren": true}
+				]}
+			]}`))
+		case "/wit/queries/f3":
+			w.Write([]byte(`{"id": "f3", "name": "Team", "isFolder": true, "hasChildren": true, "children": [
+				{"id": "q2", "name": "Epics", "path": "Shared Queries/Team/Epics", "queryType": "tree"},
+				{"id": "q3", "name": "Related", "path": "Shared Queries/Team/Related", "queryType": "oneHop"}
+			]}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+		}
+	})
+
+	queries, err := a.ListWorkItemQueries("proj")
+	if err != nil {
+		t.Fatalf("ListWorkItemQueries() error = %v", err)
+	}
+	want := []provider.WorkItemQuery{
+		{ID: "q1", Name: "Mine", Path: "My Queries/Mine", Scope: "proj", WIQL: "SELECT 1"},
+		{ID: "q2", Name: "Epics", Path: "Shared Queries/Team/Epics", Scope: "proj", Tree: true},
+		{ID: "q3", Name: "Related", Path: "Shared Queries/Team/Related", Scope: "proj", Tree: true},
+	}
+	if !reflect.DeepEqual(queries, want) {
+		t.Errorf("queries = %+v\nwant %+v", queries, want)
+	}
+}
+
+func TestAdapter_RunWorkItemQuery_FlatWithColumns(t *testing.T) {
+	a := newEditTestAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/wit/wiql/q1":
+			if got := r.URL.Query().Get("$top"); got != "25" {
+				t.Errorf("$top = %q, want 25", got)
+			}
+			w.Write([]byte(`{"queryResultType": "workItem",
+				"columns": [{"referenceName": "System.Id", "name": "ID"}, {"referenceName": "Microsoft.VSTS.Scheduling.StoryPoints", "name": "Story Points"}],
+				"workItems": [{"id": 2}, {"id": 1}]}`))
+		case "/wit/workitems":
+			if fields := r.URL.Query().Get("fields"); !strings.Contains(fields, "Microsoft.VSTS.Scheduling.StoryPoints") {
+				t.Errorf("fields = %q, want the query's columns", fields)
+			}
+			w.Write([]byte(`{"count": 2, "value": [
+				{"id": 1, "fields": {"System.Title": "One", "Microsoft.VSTS.Scheduling.StoryPoints": 3}},
+				{"id": 2, "fields": {"System.Title": "Two", "Microsoft.VSTS.Scheduling.StoryPoints": 0.5}}
+			]}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+		}
+	})
+
+	result, err := a.RunWorkItemQuery("proj", provider.WorkItemQuery{ID: "q1", Top: 25})
+	if err != nil {
+		t.Fatalf("RunWorkItemQuery() error = %v", err)
+	}
+	if result.Tree {
+		t.Error("Tree = true for a flat query")
+	}
+	if len(result.Columns) != 2 || result.Columns[1].Name != "Story Points" {
+		t.Errorf("Columns = %+v", result.Columns)
+	}
+	if len(result.Items) != 2 || result.Items[0].Title != "Two" || result.Items[1].Title != "One" {
+		t.Fatalf("Items = %+v, want the query's order", result.Items)
+	}
+	if got := result.Items[0].Fields["Microsoft.VSTS.Scheduling.StoryPoints"]; got != "0.5" {
+		t.Errorf("story points = %q, want 0.5", got)
+	}
+}
+
+func TestAdapter_RunWorkItemQuery_TreeSetsParents(t *testing.T) {
+	a := newEditTestAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/wit/wiql":
+			w.Write([]byte(`{"queryResultType": "workItemLink", "workItemRelations": [
+				{"target": {"id": 1}},
+				{"rel": "System.LinkTypes.Hierarchy-Forward", "source": {"id": 1}, "target": {"id": 2}},
+				{"rel": "System.LinkTypes.Hierarchy-Forward", "source": {"id": 2}, "target": {"id": 3}}
+			]}`))
+		case "/wit/workitems":
+			if got := r.URL.Query().Get("ids"); got != "1,2,3" {
+				t.Errorf("ids = %q", got)
+			}
+			w.Write([]byte(`{"count": 3, "value": [{"id": 1}, {"id": 2}, {"id": 3}]}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+		}
+	})
+
+	result, err := a.RunWorkItemQuery("proj", provider.WorkItemQuery{Name: "Tree", WIQL: "SELECT [System.Id] FROM WorkItemLinks"})
+	if err != nil {
+		t.Fatalf("RunWorkItemQuery() error = %v", err)
+	}
+	if !result.Tree {
+		t.Error("Tree = false for a link result")
+	}
+	var parents []int
+	for _, wi := range result.Items {
+		parents = append(parents, wi.ParentID)
+	}
+	if !reflect.DeepEqual(parents, []int{0, 1, 2}) {
+		t.Errorf("parents = %v, want [0 1 2]", parents)
+	}
+}
+
+func TestAdapter_RunWorkItemQuery_CriteriaQuery(t *testing.T) {
+	var query string
+	a := newEditTestAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/wit/wiql":
+			body, _ := io.ReadAll(r.Body)
+			var req struct {
+				Query string `json:"query"`
+			}
+			json.Unmarshal(body, &req)
+			query = req.Query
+			w.Write([]byte(`{"queryResultType": "workItem", "workItems": []}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+		}
+	})
+
+	result, err := a.RunWorkItemQuery("proj", provider.WorkItemQuery{
+		Name:     "Bugs",
+		Criteria: provider.WorkItemQueryCriteria{Types: []string{"Bug"}},
+		Columns:  []string{"System.Tags"},
+	})
+	if err != nil {
+		t.Fatalf("RunWorkItemQuery() error = %v", err)
+	}
+	if !strings.Contains(query, "[System.WorkItemType] IN ('Bug')") {
+		t.Errorf("query = %q", query)
+	}
+	if len(result.Items) != 0 || len(result.Columns) != 1 || result.Columns[0].Name != "Tags" {
+		t.Errorf("result = %+v", result)
+	}
+}
+
+func TestBuildCriteriaWIQL(t *testing.T) {
+	got := BuildCriteriaWIQL(provider.WorkItemQueryCriteria{
+		Types:         []string{"Bug", "User Story"},
+		States:        []string{"Active"},
+		Tags:          []string{"it's urgent"},
+		AssignedTo:    "@Me",
+		AreaPath:      `proj\Team`,
+		IterationPath: "@CurrentIteration",
+	})
+	want := "SELECT [System.Id] FROM WorkItems\n" +
+		"WHERE [System.TeamProject] = @project\n" +
+		"  AND [System.WorkItemType] IN ('Bug', 'User Story')\n" +
+		"  AND [System.State] IN ('Active')\n" +
+		"  AND [System.Tags] CONTAINS 'it''s urgent'\n" +
+		"  AND [System.AssignedTo] = @Me\n" +
+		`  AND [System.AreaPath] UNDER 'proj\Team'` + "\n" +
+		"  AND [System.IterationPath] UNDER @CurrentIteration\n" +
+		"ORDER BY [System.ChangedDate] DESC"
+	if got != want {
+		t.Errorf("BuildCriteriaWIQL() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestBuildCriteriaWIQL_QuotesNonMacros(t *testing.T) {
+	got := BuildCriteriaWIQL(provider.WorkItemQueryCriteria{AssignedTo: "@Me' OR 1=1"})
+	if !strings.Contains(got, "[System.AssignedTo] = '@Me'' OR 1=1'") {
+		t.Errorf("BuildCriteriaWIQL() = %q, want the value quoted", got)
+	}
+}
+
+func TestFieldValueText(t *testing.T) {
+	date := time.Date(2026, 3, 4, 12, 0, 0, 0, time.Local).Format(time.RFC3339)
+	tests := []struct {
+		value any
+		want  string
+	}{
+		{nil, ""},
+		{"text", "text"},
+		{date, "2026-03-04"},
+		{float64(5), "5"},
+		{1.25, "1.25"},
+		{true, "Yes"},
+		{false, "No"},
+		{map[string]any{"displayName": "Ada"}, "Ada"},
+	}
+	for _, tt := range tests {
+		if got := FieldValueText(tt.value); got != tt.want {
+			t.Errorf("FieldValueText(%v) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
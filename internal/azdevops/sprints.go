@@ -0,0 +1,233 @@
+package azdevops
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// TeamIterationAttributes holds the dates of a team iteration. The dates
+// are nil for iterations that have not been scheduled.
+type TeamIterationAttributes struct {
+	StartDate  *time.Time `json:"startDate"`
+	FinishDate *time.Time `json:"finishDate"`
+	TimeFrame  string     `json:"timeFrame"` // "past", "current" or "future"
+}
+
+// TeamIteration is an iteration (sprint) a team has selected
+type TeamIteration struct {
+	ID         string                  `json:"id"`
+	Name       string                  `json:"name"`
+	Path       string                  `json:"path"`
+	Attributes TeamIterationAttributes `json:"attributes"`
+}
+
+// TeamIterationsResponse represents the response from listing a team's iterations
+type TeamIterationsResponse struct {
+	Count int             `json:"count"`
+	Value []TeamIteration `json:"value"`
+}
+
+// IterationWorkItemLink links a work item planned into an iteration to its
+// parent, if the parent is planned into the iteration too.
+type IterationWorkItemLink struct {
+	Rel    string             `json:"rel"`
+	Source *WorkItemReference `json:"source"`
+	Target WorkItemReference  `json:"target"`
+}
+
+// IterationWorkItemsResponse represents the response from listing the work
+// items of an iteration
+type IterationWorkItemsResponse struct {
+	WorkItemRelations []IterationWorkItemLink `json:"workItemRelations"`
+}
+
+// DateRange is an inclusive range of days off
+type DateRange struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// Activity is the capacity a team member has per day for one kind of work
+type Activity struct {
+	Name           string  `json:"name"`
+	CapacityPerDay float64 `json:"capacityPerDay"`
+}
+
+// TeamMemberCapacity is the capacity a team member has in an iteration
+type TeamMemberCapacity struct {
+	TeamMember Identity    `json:"teamMember"`
+	Activities []Activity  `json:"activities"`
+	DaysOff    []DateRange `json:"daysOff"`
+}
+
+// CapacityPerDay returns the hours per day the member has over all
+// activities.
+func (m TeamMemberCapacity) CapacityPerDay() float64 {
+	var total float64
+	for _, a := range m.Activities {
+		total += a.CapacityPerDay
+	}
+	return total
+}
+
+// TeamCapacityResponse represents the response from reading the capacity of an iteration
+type TeamCapacityResponse struct {
+	TeamMembers []TeamMemberCapacity `json:"teamMembers"`
+}
+
+// TeamDaysOffResponse represents the response from reading the days the whole team is off in an iteration
+type TeamDaysOffResponse struct {
+	DaysOff []DateRange `json:"daysOff"`
+}
+
+// TeamSettingsResponse represents the settings of a team; only the working days are read
+type TeamSettingsResponse struct {
+	WorkingDays []string `json:"workingDays"` // e.g. "monday"
+}
+
+// ListTeamIterations returns the iterations the given team has selected,
+// ordered by date. timeframe "current" returns only the iteration under
+// way; an empty timeframe returns all of them.
+func (c *Client) ListTeamIterations(team, timeframe string) ([]TeamIteration, error) {
+	u := c.teamURL(team) + "/work/teamsettings/iterations?api-version=7.1"
+	if timeframe != "" {
+		u += "&$timeframe=" + url.QueryEscape(timeframe)
+	}
+	body, err := c.doURL("GET", u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list iterations: %w", err)
+	}
+
+	var response TeamIterationsResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse Azure DevOps API response for iterations: %w. "+
+			"This may indicate an API structure change. Please check for updates or report this issue", err)
+	}
+	return response.Value, nil
+}
+
+// GetIterationWorkItemIDs returns the IDs of the work items planned into
+// the given iteration of the team, parents before their children.
+func (c *Client) GetIterationWorkItemIDs(team, iterationID string) ([]int, error) {
+	u := fmt.Sprintf("%s/work/teamsettings/iterations/%s/workitems?api-version=7.1", c.teamURL(team), url.PathEscape(iterationID))
+	body, err := c.doURL("GET", u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get iteration work items: %w", err)
+	}
+
+	var response IterationWorkItemsResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse Azure DevOps API response for iteration work items: %w. "+
+			"This may indicate an API structure change. Please check for updates or report this issue", err)
+	}
+	ids := make([]int, 0, len(response.WorkItemRelations))
+	seen := make(map[int]bool, len(response.WorkItemRelations))
+	for _, link := range response.WorkItemRelations {
+		if id := link.Target.ID; id != 0 && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// GetIterationCapacities returns the capacity each team member has set for
+// the given iteration.
+func (c *Client) GetIterationCapacities(team, iterationID string) ([]TeamMemberCapacity, error) {
+	u := fmt.Sprintf("%s/work/teamsettings/iterations/%s/capacities?api-version=7.1", c.teamURL(team), url.PathEscape(iterationID))
+	body, err := c.doURL("GET", u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get iteration capacity: %w", err)
+	}
+
+	var response TeamCapacityResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse Azure DevOps API response for iteration capacity: %w. "+
+			"This may indicate an API structure change. Please check for updates or report this issue", err)
+	}
+	return response.TeamMembers, nil
+}
+
+// GetIterationDaysOff returns the days the whole team is off in the given
+// iteration.
+func (c *Client) GetIterationDaysOff(team, iterationID string) ([]DateRange, error) {
+	u := fmt.Sprintf("%s/work/teamsettings/iterations/%s/teamdaysoff?api-version=7.1", c.teamURL(team), url.PathEscape(iterationID))
+	body, err := c.doURL("GET", u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team days off: %w", err)
+	}
+
+	var response TeamDaysOffResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse Azure DevOps API response for team days off: %w. "+
+			"This may indicate an API structure change. Please check for updates or report this issue", err)
+	}
+	return response.DaysOff, nil
+}
+
+// GetTeamWorkingDays returns the days of the week the given team works.
+func (c *Client) GetTeamWorkingDays(team string) ([]time.Weekday, error) {
+	body, err := c.doURL("GET", c.teamURL(team)+"/work/teamsettings?api-version=7.1", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team settings: %w", err)
+	}
+
+	var response TeamSettingsResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse Azure DevOps API response for team settings: %w. "+
+			"This may indicate an API structure change. Please check for updates or report this issue", err)
+	}
+	days := make([]time.Weekday, 0, len(response.WorkingDays))
+	for _, name := range response.WorkingDays {
+		for d := time.Sunday; d <= time.Saturday; d++ {
+			if strings.EqualFold(name, d.String()) {
+				days = append(days, d)
+			}
+		}
+	}
+	return days, nil
+}
+
+// workingDaysLeft counts the working days from today, or from start if the
+// sprint has not begun, through finish that fall on none of daysOff.
+// Iteration dates are whole days, so all times are compared as UTC dates.
+func workingDaysLeft(start, finish, now time.Time, workingDays []time.Weekday, daysOff []DateRange) int {
+	if start.IsZero() || finish.IsZero() {
+		return 0
+	}
+	day := utcDate(now)
+	if s := utcDate(start); s.After(day) {
+		day = s
+	}
+	working := make(map[time.Weekday]bool, len(workingDays))
+	for _, d := range workingDays {
+		working[d] = true
+	}
+
+	count := 0
+	for last := utcDate(finish); !day.After(last); day = day.AddDate(0, 0, 1) {
+		if working[day.Weekday()] && !isDayOff(day, daysOff) {
+			count++
+		}
+	}
+	return count
+}
+
+// isDayOff reports whether day falls in any of daysOff.
+func isDayOff(day time.Time, daysOff []DateRange) bool {
+	for _, off := range daysOff {
+		if !day.Before(utcDate(off.Start)) && !day.After(utcDate(off.End)) {
+			return true
+		}
+	}
+	return false
+}
+
+// utcDate returns midnight UTC of the date t falls on in UTC.
+func utcDate(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
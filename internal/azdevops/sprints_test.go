@@ -0,0 +1,132 @@
+package azdevops
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Elpulgo/azdo/internal/provider"
+)
+
+func TestWorkingDaysLeft(t *testing.T) {
+	weekdays := []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+	day := func(d int) time.Time { return time.Date(2026, 10, d, 0, 0, 0, 0, time.UTC) }
+	// Sprint from Monday the 5th through Friday the 16th.
+	start, finish := day(5), day(16)
+
+	tests := []struct {
+		name    string
+		now     time.Time
+		daysOff []DateRange
+		want    int
+	}{
+		{"before the sprint counts all of it", day(1), nil, 10},
+		{"counts today", day(14).Add(15 * time.Hour), nil, 3},
+		{"skips the weekend", day(9), nil, 6},
+		{"skips days off", day(5), []DateRange{{Start: day(7), End: day(8)}, {Start: day(16), End: day(16)}}, 7},
+		{"after the sprint", day(17), nil, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := workingDaysLeft(start, finish, tt.now, weekdays, tt.daysOff); got != tt.want {
+				t.Errorf("workingDaysLeft() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+	if got := workingDaysLeft(time.Time{}, time.Time{}, day(5), weekdays, nil); got != 0 {
+		t.Errorf("an unscheduled sprint has %d days left, want 0", got)
+	}
+}
+
+func TestAdapter_GetSprints_MarksCurrentIteration(t *testing.T) {
+	a := newEditTestAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/web/_apis/work/teamsettings/iterations" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		if r.URL.Query().Get("$timeframe") == "current" {
+			w.Write([]byte(`{"count": 1, "value": [{"id": "it-24", "name": "Sprint 24"}]}`))
+			return
+		}
+		w.Write([]byte(`{"count": 2, "value": [
+			{"id": "it-23", "name": "Sprint 23", "path": "proj\\Sprint 23",
+				"attributes": {"startDate": "2026-09-21T00:00:00Z", "finishDate": "2026-10-02T00:00:00Z"}},
+			{"id": "it-24", "name": "Sprint 24", "path": "proj\\Sprint 24",
+				"attributes": {"startDate": "2026-10-05T00:00:00Z", "finishDate": "2026-10-16T00:00:00Z"}}
+		]}`))
+	})
+	a.mc.ClientFor("proj").SetTeam("web")
+
+	sprints, err := a.GetSprints("proj")
+	if err != nil {
+		t.Fatalf("GetSprints() error = %v", err)
+	}
+	if len(sprints) != 2 {
+		t.Fatalf("sprints = %+v, want two", sprints)
+	}
+	if sprints[0].Current || !sprints[1].Current {
+		t.Errorf("only Sprint 24 should be current: %+v", sprints)
+	}
+	if sprints[1].Path != `proj\Sprint 24` || !sprints[1].Finish.Equal(time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("sprint = %+v", sprints[1])
+	}
+}
+
+func TestAdapter_GetSprintBacklog_ItemsAndCapacity(t *testing.T) {
+	a := newEditTestAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/web/_apis/work/teamsettings/iterations/it-1/workitems":
+			w.Write([]byte(`{"workItemRelations": [
+				{"rel": null, "source": null, "target": {"id": 1}},
+				{"rel": "System.LinkTypes.Hierarchy-Forward", "source": {"id": 1}, "target": {"id": 2}}
+			]}`))
+		case "/wit/workitems":
+			w.Write([]byte(`{"count": 2, "value": [
+				{"id": 2, "fields": {"System.Title": "Task", "Microsoft.VSTS.Scheduling.RemainingWork": 4.5}},
+				{"id": 1, "fields": {"System.Title": "Story"}}
+			]}`))
+		case "/web/_apis/work/teamsettings/iterations/it-1/capacities":
+			w.Write([]byte(`{"teamMembers": [
+				{"teamMember": {"displayName": "Ada", "uniqueName": "ada@example.com"},
+					"activities": [{"name": "Development", "capacityPerDay": 4}, {"name": "Testing", "capacityPerDay": 2}],
+					"daysOff": [{"start": "2100-01-11T00:00:00Z", "end": "2100-01-12T00:00:00Z"}]},
+				{"teamMember": {"displayName": "Bob", "uniqueName": "bob@example.com"},
+					"activities": [{"name": "", "capacityPerDay": 0}], "daysOff": []}
+			]}`))
+		case "/web/_apis/work/teamsettings/iterations/it-1/teamdaysoff":
+			w.Write([]byte(`{"daysOff": [{"start": "2100-01-08T00:00:00Z", "end": "2100-01-08T00:00:00Z"}]}`))
+		case "/web/_apis/work/teamsettings":
+			w.Write([]byte(`{"workingDays": ["monday", "tuesday", "wednesday", "thursday", "friday"]}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+		}
+	})
+	a.mc.ClientFor("proj").SetTeam("web")
+	sprint := provider.Sprint{
+		ID:     "it-1",
+		Start:  time.Date(2100, 1, 4, 0, 0, 0, 0, time.UTC),
+		Finish: time.Date(2100, 1, 15, 0, 0, 0, 0, time.UTC),
+	}
+
+	backlog, err := a.GetSprintBacklog("proj", sprint)
+	if err != nil {
+		t.Fatalf("GetSprintBacklog() error = %v", err)
+	}
+	var titles []string
+	for _, wi := range backlog.Items {
+		titles = append(titles, wi.Title)
+	}
+	if !reflect.DeepEqual(titles, []string{"Story", "Task"}) {
+		t.Errorf("items = %v, want the iteration's order", titles)
+	}
+	if backlog.Items[1].RemainingWork != 4.5 {
+		t.Errorf("RemainingWork = %v, want 4.5", backlog.Items[1].RemainingWork)
+	}
+	if backlog.DaysLeft != 9 {
+		t.Errorf("DaysLeft = %d, want 9 net of the team's day off", backlog.DaysLeft)
+	}
+	want := []provider.SprintCapacity{{Name: "Ada", Login: "ada@example.com", Hours: 42}}
+	if !reflect.DeepEqual(backlog.Capacity, want) {
+		t.Errorf("Capacity = %+v\nwant %+v", backlog.Capacity, want)
+	}
+}
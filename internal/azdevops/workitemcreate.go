@@ -0,0 +1,145 @@
+package azdevops
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// Work item field reference names written by the create and edit forms.
+const (
+	FieldTitle         = "System.Title"
+	FieldDescription   = "System.Description"
+	FieldAssignedTo    = "System.AssignedTo"
+	FieldIterationPath = "System.IterationPath"
+	FieldAreaPath      = "System.AreaPath"
+	FieldTags          = "System.Tags"
+	FieldPriority      = "Microsoft.VSTS.Common.Priority"
+	FieldStoryPoints   = "Microsoft.VSTS.Scheduling.StoryPoints"
+	FieldReproSteps    = "Microsoft.VSTS.TCM.ReproSteps"
+)
+
+// Work item link types, the Rel of a WorkItemRelation.
+const (
+	LinkTypeParent  = "System.LinkTypes.Hierarchy-Reverse" // from a child to its parent
+	LinkTypeChild   = "System.LinkTypes.Hierarchy-Forward" // from a parent to a child
+	LinkTypeRelated = "System.LinkTypes.Related"
+
+	LinkTypeAttachedFile = "AttachedFile" // to a file in the attachment store
+	LinkTypeHyperlink    = "Hyperlink"
+)
+
+// hiddenTypeCategory is the category of work item types that are not
+// created by hand (test cases, code review requests and the like).
+const hiddenTypeCategory = "Microsoft.HiddenCategory"
+
+// PatchOperation is a single JSON Patch operation on a work item.
+type PatchOperation struct {
+	Op    string `json:"op"` // "add", "replace", "remove" or "test"
+	Path  string `json:"path"`
+	Value any    `json:"value,omitempty"`
+}
+
+// FieldPath returns the JSON Patch path of a work item field.
+func FieldPath(field string) string {
+	return "/fields/" + field
+}
+
+// WorkItemRelation is a link from a work item to another resource.
+// Attributes hold details such as the name of the link type or of an
+// attached file; they are left out when a link is added.
+type WorkItemRelation struct {
+	Rel        string         `json:"rel"`
+	URL        string         `json:"url"`
+	Attributes map[string]any `json:"attributes,omitempty"`
+}
+
+// WorkItemType represents a work item type of a project.
+type WorkItemType struct {
+	Name       string `json:"name"`
+	IsDisabled bool   `json:"isDisabled"`
+}
+
+// WorkItemTypesResponse represents the response from the work item types API
+type WorkItemTypesResponse struct {
+	Count int            `json:"count"`
+	Value []WorkItemType `json:"value"`
+}
+
+// WorkItemTypeCategory represents a category grouping work item types.
+type WorkItemTypeCategory struct {
+	ReferenceName string         `json:"referenceName"`
+	WorkItemTypes []WorkItemType `json:"workItemTypes"`
+}
+
+// WorkItemURL returns the API URL of a work item, as used in relations.
+func (c *Client) WorkItemURL(id int) string {
+	return fmt.Sprintf("%s/wit/workItems/%d", c.baseURL, id)
+}
+
+// GetWorkItemTypes retrieves the names of the work item types that can be
+// created in the project, sorted by name. Disabled types and the types of
+// the hidden category are left out.
+func (c *Client) GetWorkItemTypes() ([]string, error) {
+	body, err := c.get("/wit/workitemtypes?api-version=7.1")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get work item types: %w", err)
+	}
+
+	var response WorkItemTypesResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse Azure DevOps API response for work item types: %w. "+
+			"This may indicate an API structure change. Please check for updates or report this issue", err)
+	}
+
+	// Not every process has the hidden category, so failing to read it
+	// only means nothing is hidden.
+	hidden := map[string]bool{}
+	if body, err := c.get("/wit/workitemtypecategories/" + hiddenTypeCategory + "?api-version=7.1"); err == nil {
+		var category WorkItemTypeCategory
+		if json.Unmarshal(body, &category) == nil {
+			for _, t := range category.WorkItemTypes {
+				hidden[t.Name] = true
+			}
+		}
+	}
+
+	var names []string
+	for _, t := range response.Value {
+		if !t.IsDisabled && !hidden[t.Name] {
+			names = append(names, t.Name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// CreateWorkItem creates a work item of the given type from JSON Patch
+// operations and returns it as created.
+func (c *Client) CreateWorkItem(workItemType string, ops []PatchOperation) (*WorkItem, error) {
+	path := fmt.Sprintf("/wit/workitems/$%s?api-version=7.1", url.PathEscape(workItemType))
+
+	payload, err := json.Marshal(ops)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode work item: %w", err)
+	}
+	body, err := c.doRequestWithContentType("POST", path, bytes.NewReader(payload), "application/json-patch+json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create work item: %w", err)
+	}
+
+	var wi WorkItem
+	if err := json.Unmarshal(body, &wi); err != nil {
+		return nil, fmt.Errorf("failed to parse Azure DevOps API response for created work item: %w. "+
+			"This may indicate an API structure change. Please check for updates or report this issue", err)
+	}
+	return &wi, nil
+}
+
+// JoinTags joins tags in the "a; b" form of the System.Tags field.
+func JoinTags(tags []string) string {
+	return strings.Join(tags, "; ")
+}
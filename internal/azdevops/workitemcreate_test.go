@@ -0,0 +1,137 @@
+package azdevops
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/Elpulgo/azdo/internal/provider"
+)
+
+func TestClient_GetWorkItemTypes_SkipsDisabledAndHidden(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/wit/workitemtypes":
+			w.Write([]byte(`{"count": 4, "value": [
+				{"name": "User Story"}, {"name": "Bug"},
+				{"name": "Test Case"}, {"name": "Old Type", "isDisabled": true}
+			]}`))
+		case "/wit/workitemtypecategories/Microsoft.HiddenCategory":
+			w.Write([]byte(`{"referenceName": "Microsoft.HiddenCategory", "workItemTypes": [{"name": "Test Case"}]}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-org", "test-project", "test-pat")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	client.baseURL = server.URL
+
+	types, err := client.GetWorkItemTypes()
+	if err != nil {
+		t.Fatalf("GetWorkItemTypes() error = %v", err)
+	}
+	if want := []string{"Bug", "User Story"}; !reflect.DeepEqual(types, want) {
+		t.Errorf("GetWorkItemTypes() = %v, want %v", types, want)
+	}
+}
+
+func TestClient_GetWorkItemTypes_WithoutHiddenCategory(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/wit/workitemtypes" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"count": 1, "value": [{"name": "Task"}]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-org", "test-project", "test-pat")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	client.baseURL = server.URL
+
+	types, err := client.GetWorkItemTypes()
+	if err != nil || len(types) != 1 || types[0] != "Task" {
+		t.Errorf("GetWorkItemTypes() = %v, %v; want [Task]", types, err)
+	}
+}
+
+func TestAdapter_CreateWorkItem_SendsJSONPatch(t *testing.T) {
+	var gotPath, gotContentType string
+	var ops []PatchOperation
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.EscapedPath()
+		gotContentType = r.Header.Get("Content-Type")
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &ops); err != nil {
+			t.Errorf("body is not a JSON Patch document: %v", err)
+		}
+		w.Write([]byte(`{"id": 77, "rev": 1, "fields": {"System.Title": "Crash on save",
+			"System.WorkItemType": "User Story", "System.State": "New"}}`))
+	}))
+	defer server.Close()
+
+	mc, err := NewMultiClient("test-org", []string{"proj"}, "test-pat", nil)
+	if err != nil {
+		t.Fatalf("NewMultiClient: %v", err)
+	}
+	mc.ClientFor("proj").SetBaseURL(server.URL)
+
+	got, err := NewAdapter(mc).CreateWorkItem("proj", provider.NewWorkItem{
+		Type:          "User Story",
+		Title:         "Crash on save",
+		Description:   "a < b",
+		Priority:      2,
+		AreaPath:      `proj\Web`,
+		Tags:          []string{"ui", "crash"},
+		ParentID:      12,
+		IterationPath: "",
+	})
+	if err != nil {
+		t.Fatalf("CreateWorkItem() error = %v", err)
+	}
+	if gotPath != "/wit/workitems/$User%20Story" {
+		t.Errorf("path = %q, want the $User Story create endpoint", gotPath)
+	}
+	if gotContentType != "application/json-patch+json" {
+		t.Errorf("Content-Type = %q, want JSON Patch", gotContentType)
+	}
+	if got.Identity.ID != "77" || got.Identity.Scope != "proj" || got.Title != "Crash on save" {
+		t.Errorf("CreateWorkItem() = %+v", got)
+	}
+
+	values := map[string]any{}
+	for _, op := range ops {
+		if op.Op != "add" {
+			t.Errorf("op %+v, want add", op)
+		}
+		values[op.Path] = op.Value
+	}
+	want := map[string]any{
+		"/fields/System.Title":                   "Crash on save",
+		"/fields/System.Description":             "a &lt; b",
+		"/fields/Microsoft.VSTS.Common.Priority": float64(2),
+		"/fields/System.AreaPath":                `proj\Web`,
+		"/fields/System.Tags":                    "ui; crash",
+	}
+	for path, value := range want {
+		if values[path] != value {
+			t.Errorf("%s = %v, want %v", path, values[path], value)
+		}
+	}
+	if _, ok := values["/fields/System.IterationPath"]; ok {
+		t.Error("an empty iteration should not be sent")
+	}
+	relation, _ := values["/relations/-"].(map[string]any)
+	if relation["rel"] != LinkTypeParent || relation["url"] != server.URL+"/wit/workItems/12" {
+		t.Errorf("parent relation = %v", relation)
+	}
+}
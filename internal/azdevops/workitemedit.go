@@ -0,0 +1,167 @@
+package azdevops
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// classificationDepth is how many levels of iterations and areas are read
+// below the project's root node.
+const classificationDepth = 10
+
+// ClassificationNode is an iteration or area of a project, with its
+// children when read with $depth.
+type ClassificationNode struct {
+	Name     string               `json:"name"`
+	Children []ClassificationNode `json:"children,omitempty"`
+}
+
+// TeamMember is a member of a team as returned by the team members API.
+type TeamMember struct {
+	Identity Identity `json:"identity"`
+}
+
+// TeamMembersResponse represents the response from the team members API
+type TeamMembersResponse struct {
+	Count int          `json:"count"`
+	Value []TeamMember `json:"value"`
+}
+
+// projectInfo holds the part of a project read to find its default team.
+type projectInfo struct {
+	DefaultTeam struct {
+		ID string `json:"id"`
+	} `json:"defaultTeam"`
+}
+
+// GetWorkItem retrieves a single work item with the fields the list shows.
+func (c *Client) GetWorkItem(id int) (*WorkItem, error) {
+	items, err := c.GetWorkItems([]int{id})
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("work item %d not found", id)
+	}
+	return &items[0], nil
+}
+
+// UpdateWorkItem applies JSON Patch operations to a work item and returns
+// it as updated. When ops include a test of /rev that fails, the error
+// wraps ErrConflict.
+func (c *Client) UpdateWorkItem(id int, ops []PatchOperation) (*WorkItem, error) {
+	path := fmt.Sprintf("/wit/workitems/%d?api-version=7.1", id)
+
+	payload, err := json.Marshal(ops)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode work item update: %w", err)
+	}
+	body, err := c.doRequestWithContentType("PATCH", path, bytes.NewReader(payload), "application/json-patch+json")
+	if isRevisionConflict(err) {
+		return nil, fmt.Errorf("failed to update work item: %w", ErrConflict)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update work item: %w", err)
+	}
+
+	var wi WorkItem
+	if err := json.Unmarshal(body, &wi); err != nil {
+		return nil, fmt.Errorf("failed to parse Azure DevOps API response for updated work item: %w. "+
+			"This may indicate an API structure change. Please check for updates or report this issue", err)
+	}
+	return &wi, nil
+}
+
+// isRevisionConflict reports whether err rejects a work item update whose
+// revision test failed: HTTP 412, or TF26071 "This work item has been
+// changed by someone else since you opened it".
+func isRevisionConflict(err error) bool {
+	var httpErr *httpError
+	if !errors.As(err, &httpErr) {
+		return false
+	}
+	return httpErr.statusCode == http.StatusPreconditionFailed || bytes.Contains(httpErr.body, []byte("TF26071"))
+}
+
+// GetClassificationPaths retrieves the paths of the project's iterations
+// (group "Iterations") or areas (group "Areas") in the form the
+// System.IterationPath and System.AreaPath fields use, e.g.
+// "Project\Sprint 1". The project's root comes first.
+func (c *Client) GetClassificationPaths(group string) ([]string, error) {
+	path := fmt.Sprintf("/wit/classificationnodes/%s?$depth=%d&api-version=7.1", group, classificationDepth)
+
+	body, err := c.get(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s: %w", group, err)
+	}
+
+	var root ClassificationNode
+	if err := json.Unmarshal(body, &root); err != nil {
+		return nil, fmt.Errorf("failed to parse Azure DevOps API response for %s: %w. "+
+			"This may indicate an API structure change. Please check for updates or report this issue", group, err)
+	}
+
+	var paths []string
+	var walk func(node ClassificationNode, path string)
+	walk = func(node ClassificationNode, path string) {
+		paths = append(paths, path)
+		for _, child := range node.Children {
+			walk(child, path+`\`+child.Name)
+		}
+	}
+	walk(root, root.Name)
+	return paths, nil
+}
+
+// teamOrDefault returns the configured team, or the ID of the project's
+// default team when none is configured.
+func (c *Client) teamOrDefault() (string, error) {
+	if c.team != "" {
+		return c.team, nil
+	}
+	return c.defaultTeamID()
+}
+
+// defaultTeamID returns the ID of the project's default team.
+func (c *Client) defaultTeamID() (string, error) {
+	body, err := c.doURL("GET", fmt.Sprintf("%s/projects/%s?api-version=7.1", c.orgURL, url.PathEscape(c.project)), nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to get project: %w", err)
+	}
+	var info projectInfo
+	if err := json.Unmarshal(body, &info); err != nil {
+		return "", fmt.Errorf("failed to parse Azure DevOps API response for project: %w. "+
+			"This may indicate an API structure change. Please check for updates or report this issue", err)
+	}
+	return info.DefaultTeam.ID, nil
+}
+
+// GetTeamMembers retrieves the members of the configured team, or of the
+// project's default team.
+func (c *Client) GetTeamMembers() ([]Identity, error) {
+	team, err := c.teamOrDefault()
+	if err != nil {
+		return nil, err
+	}
+
+	u := fmt.Sprintf("%s/projects/%s/teams/%s/members?api-version=7.1", c.orgURL, url.PathEscape(c.project), url.PathEscape(team))
+	body, err := c.doURL("GET", u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team members: %w", err)
+	}
+	var response TeamMembersResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse Azure DevOps API response for team members: %w. "+
+			"This may indicate an API structure change. Please check for updates or report this issue", err)
+	}
+
+	members := make([]Identity, len(response.Value))
+	for i, m := range response.Value {
+		members[i] = m.Identity
+	}
+	return members, nil
+}
@@ -0,0 +1,182 @@
+package azdevops
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/Elpulgo/azdo/internal/provider"
+)
+
+// newEditTestAdapter returns an adapter for project "proj" talking to
+// handler.
+func newEditTestAdapter(t *testing.T, handler http.HandlerFunc) *Adapter {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	mc, err := NewMultiClient("test-org", []string{"proj"}, "test-pat", nil)
+	if err != nil {
+		t.Fatalf("NewMultiClient: %v", err)
+	}
+	mc.ClientFor("proj").SetBaseURL(server.URL)
+	return NewAdapter(mc)
+}
+
+func TestClient_GetClassificationPaths_FlattensTree(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/wit/classificationnodes/Iterations" || r.URL.Query().Get("$depth") == "" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		w.Write([]byte(`{"name": "proj", "children": [
+			{"name": "2026", "children": [{"name": "Sprint 1"}, {"name": "Sprint 2"}]},
+			{"name": "Backlog"}
+		]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-org", "proj", "test-pat")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	client.baseURL = server.URL
+
+	paths, err := client.GetClassificationPaths("Iterations")
+	if err != nil {
+		t.Fatalf("GetClassificationPaths() error = %v", err)
+	}
+	want := []string{`proj`, `proj\2026`, `proj\2026\Sprint 1`, `proj\2026\Sprint 2`, `proj\Backlog`}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("GetClassificationPaths() = %v, want %v", paths, want)
+	}
+}
+
+func TestAdapter_GetFieldOptions_TeamMembers(t *testing.T) {
+	a := newEditTestAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/projects/proj":
+			w.Write([]byte(`{"name": "proj", "defaultTeam": {"id": "team-1", "name": "proj Team"}}`))
+		case "/projects/proj/teams/team-1/members":
+			w.Write([]byte(`{"count": 2, "value": [
+				{"identity": {"displayName": "Zoe", "uniqueName": "zoe@example.com"}},
+				{"identity": {"displayName": "Adam", "uniqueName": "adam@example.com"}}
+			]}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	})
+
+	options, err := a.GetFieldOptions("proj", provider.FieldAssignedTo)
+	if err != nil {
+		t.Fatalf("GetFieldOptions() error = %v", err)
+	}
+	want := []string{"Adam <adam@example.com>", "Zoe <zoe@example.com>"}
+	if !reflect.DeepEqual(options, want) {
+		t.Errorf("GetFieldOptions() = %v, want %v", options, want)
+	}
+
+	if options, err := a.GetFieldOptions("proj", provider.FieldStoryPoints); options != nil || err != nil {
+		t.Errorf("story points should have no options, got %v, %v", options, err)
+	}
+}
+
+func TestAdapter_UpdateWorkItemFields_SendsRevTestAndFields(t *testing.T) {
+	var gotPath, gotContentType string
+	var ops []PatchOperation
+	a := newEditTestAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.Method + " " + r.URL.Path
+		gotContentType = r.Header.Get("Content-Type")
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &ops); err != nil {
+			t.Errorf("body is not a JSON Patch document: %v", err)
+		}
+		w.Write([]byte(`{"id": 7, "rev": 4, "fields": {"System.Title": "T",
+			"System.AreaPath": "proj\\Web",
+			"System.AssignedTo": {"displayName": "Adam", "uniqueName": "adam@example.com"}}}`))
+	})
+
+	got, err := a.UpdateWorkItemFields("proj", 7, provider.WorkItemUpdate{
+		Rev: 3,
+		Fields: map[provider.WorkItemField]string{
+			provider.FieldPriority:    "2",
+			provider.FieldStoryPoints: "",
+			provider.FieldAssignedTo:  "adam@example.com",
+			provider.FieldDescription: "<p>Steps</p>",
+			"Custom.Severity":         "High",
+		},
+	})
+	if err != nil {
+		t.Fatalf("UpdateWorkItemFields() error = %v", err)
+	}
+	if gotPath != "PATCH /wit/workitems/7" || gotContentType != "application/json-patch+json" {
+		t.Errorf("request = %s (%s)", gotPath, gotContentType)
+	}
+
+	want := []PatchOperation{
+		{Op: "test", Path: "/rev", Value: float64(3)},
+		{Op: "add", Path: "/fields/Custom.Severity", Value: "High"},
+		{Op: "add", Path: "/fields/System.AssignedTo", Value: "adam@example.com"},
+		{Op: "add", Path: "/fields/System.Description", Value: "<p>Steps</p>"},
+		{Op: "add", Path: "/fields/Microsoft.VSTS.Common.Priority", Value: float64(2)},
+		{Op: "remove", Path: "/fields/Microsoft.VSTS.Scheduling.StoryPoints"},
+	}
+	if !reflect.DeepEqual(ops, want) {
+		t.Errorf("ops = %+v\nwant %+v", ops, want)
+	}
+	if got.Rev != 4 || got.AreaPath != `proj\Web` || got.AssignedTo != "adam@example.com" {
+		t.Errorf("UpdateWorkItemFields() = %+v", got)
+	}
+}
+
+func TestAdapter_UpdateWorkItemFields_Conflict(t *testing.T) {
+	a := newEditTestAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"message": "TF26071: This work item has been changed by someone else since you opened it."}`))
+	})
+
+	_, err := a.UpdateWorkItemFields("proj", 7, provider.WorkItemUpdate{
+		Rev:    3,
+		Fields: map[provider.WorkItemField]string{provider.FieldTitle: "New"},
+	})
+	if !errors.Is(err, provider.ErrWorkItemConflict) {
+		t.Errorf("UpdateWorkItemFields() error = %v, want ErrWorkItemConflict", err)
+	}
+}
+
+// Only a failed revision test is a conflict: a 409 elsewhere, such as a
+// comment rejected by the server, keeps its own error.
+func TestClient_ConflictOnlyForWorkItemUpdates(t *testing.T) {
+	a := newEditTestAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+	})
+	c := a.mc.ClientFor("proj")
+
+	if _, err := c.AddPRComment("repo", 5, "hi"); err == nil || errors.Is(err, ErrConflict) {
+		t.Errorf("AddPRComment() error = %v, want a plain HTTP error", err)
+	}
+	if _, err := c.UpdateWorkItem(7, nil); err == nil || errors.Is(err, ErrConflict) {
+		t.Errorf("UpdateWorkItem() error = %v, want a plain HTTP error for a 409", err)
+	}
+}
+
+func TestAdapter_UpdateWorkItemFields_RejectsBadValues(t *testing.T) {
+	a := newEditTestAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("no request expected, got %s %s", r.Method, r.URL.Path)
+	})
+
+	for field, value := range map[provider.WorkItemField]string{
+		provider.FieldTitle:       "",
+		provider.FieldPriority:    "high",
+		provider.FieldStoryPoints: "many",
+	} {
+		_, err := a.UpdateWorkItemFields("proj", 7, provider.WorkItemUpdate{
+			Fields: map[provider.WorkItemField]string{field: value},
+		})
+		if err == nil {
+			t.Errorf("%s = %q should be rejected", field, value)
+		}
+	}
+}
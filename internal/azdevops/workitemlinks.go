@@ -0,0 +1,85 @@
+package azdevops
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// GetWorkItemRelations retrieves a work item with its relations. Only the
+// ID, revision and relations are meant to be read from the result; the
+// fields come without the field list GetWorkItems asks for.
+func (c *Client) GetWorkItemRelations(id int) (*WorkItem, error) {
+	path := fmt.Sprintf("/wit/workitems/%d?$expand=relations&api-version=7.1", id)
+
+	body, err := c.get(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get work item relations: %w", err)
+	}
+
+	var wi WorkItem
+	if err := json.Unmarshal(body, &wi); err != nil {
+		return nil, fmt.Errorf("failed to parse Azure DevOps API response for work item relations: %w. "+
+			"This may indicate an API structure change. Please check for updates or report this issue", err)
+	}
+	return &wi, nil
+}
+
+// RelationTargetID returns the ID of the work item a relation points to,
+// or 0 when it points to something else (a commit, a hyperlink, ...).
+func RelationTargetID(r WorkItemRelation) int {
+	i := strings.LastIndex(strings.ToLower(r.URL), "/workitems/")
+	if i < 0 {
+		return 0
+	}
+	id, err := strconv.Atoi(r.URL[i+len("/workitems/"):])
+	if err != nil {
+		return 0
+	}
+	return id
+}
+
+// AddWorkItemRelation links targetID to the work item with the given link
+// type, e.g. LinkTypeChild to add a child.
+func (c *Client) AddWorkItemRelation(id int, rel string, targetID int) error {
+	ops := []PatchOperation{{
+		Op:    "add",
+		Path:  "/relations/-",
+		Value: WorkItemRelation{Rel: rel, URL: c.WorkItemURL(targetID)},
+	}}
+	if _, err := c.UpdateWorkItem(id, ops); err != nil {
+		return fmt.Errorf("failed to add link: %w", err)
+	}
+	return nil
+}
+
+// RemoveWorkItemRelation removes the link of the given type from the work
+// item to targetID. Relations are removed by index, so the patch tests the
+// revision they were read at; a concurrent change fails with ErrConflict
+// instead of removing the wrong link.
+func (c *Client) RemoveWorkItemRelation(id int, rel string, targetID int) error {
+	wi, err := c.GetWorkItemRelations(id)
+	if err != nil {
+		return err
+	}
+	index := -1
+	for i, r := range wi.Relations {
+		if r.Rel == rel && RelationTargetID(r) == targetID {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return fmt.Errorf("work item %d has no such link to %d", id, targetID)
+	}
+
+	ops := []PatchOperation{
+		{Op: "test", Path: "/rev", Value: wi.Rev},
+		{Op: "remove", Path: fmt.Sprintf("/relations/%d", index)},
+	}
+	if _, err := c.UpdateWorkItem(id, ops); err != nil {
+		return fmt.Errorf("failed to remove link: %w", err)
+	}
+	return nil
+}
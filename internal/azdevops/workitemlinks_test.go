@@ -0,0 +1,137 @@
+package azdevops
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/Elpulgo/azdo/internal/provider"
+)
+
+func TestRelationTargetID(t *testing.T) {
+	tests := []struct {
+		url  string
+		want int
+	}{
+		{"https://dev.azure.com/org/_apis/wit/workItems/42", 42},
+		{"https://dev.azure.com/org/proj/_apis/wit/workitems/7", 7},
+		{"vstfs:///Git/Commit/abc", 0},
+		{"https://dev.azure.com/org/_apis/wit/workItems/x", 0},
+	}
+	for _, tt := range tests {
+		if got := RelationTargetID(WorkItemRelation{URL: tt.url}); got != tt.want {
+			t.Errorf("RelationTargetID(%q) = %d, want %d", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestAdapter_GetWorkItemLinks_GroupsByType(t *testing.T) {
+	a := newEditTestAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/wit/workitems/10" && r.URL.Query().Get("$expand") == "relations":
+			w.Write([]byte(`{"id": 10, "rev": 4, "relations": [
+				{"rel": "System.LinkTypes.Related", "url": "https://x/_apis/wit/workItems/30"},
+				{"rel": "System.LinkTypes.Hierarchy-Forward", "url": "https://x/_apis/wit/workItems/22"},
+				{"rel": "ArtifactLink", "url": "vstfs:///Git/Commit/abc"},
+				{"rel": "System.LinkTypes.Hierarchy-Reverse", "url": "https://x/_apis/wit/workItems/1"},
+				{"rel": "System.LinkTypes.Hierarchy-Forward", "url": "https://x/_apis/wit/workItems/21"}
+			]}`))
+		case r.URL.Path == "/wit/workitems":
+			if got := r.URL.Query().Get("ids"); got != "30,22,1,21" {
+				t.Errorf("ids = %q", got)
+			}
+			w.Write([]byte(`{"count": 4, "value": [
+				{"id": 1, "fields": {"System.Title": "Epic"}},
+				{"id": 21, "fields": {"System.Title": "Task A"}},
+				{"id": 22, "fields": {"System.Title": "Task B"}},
+				{"id": 30, "fields": {"System.Title": "Other"}}
+			]}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+		}
+	})
+
+	links, err := a.GetWorkItemLinks("proj", 10)
+	if err != nil {
+		t.Fatalf("GetWorkItemLinks() error = %v", err)
+	}
+	var got []string
+	for _, l := range links {
+		got = append(got, string(l.Type)+":"+l.Item.Title)
+	}
+	want := []string{"parent:Epic", "child:Task A", "child:Task B", "related:Other"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("links = %v, want %v", got, want)
+	}
+}
+
+func TestAdapter_AddWorkItemLink_AddsRelation(t *testing.T) {
+	var ops []map[string]any
+	a := newEditTestAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PATCH" || r.URL.Path != "/wit/workitems/10" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+		json.NewDecoder(r.Body).Decode(&ops)
+		w.Write([]byte(`{"id": 10, "rev": 5}`))
+	})
+
+	if err := a.AddWorkItemLink("proj", 10, provider.LinkChild, 22); err != nil {
+		t.Fatalf("AddWorkItemLink() error = %v", err)
+	}
+	if len(ops) != 1 || ops[0]["op"] != "add" || ops[0]["path"] != "/relations/-" {
+		t.Fatalf("ops = %v", ops)
+	}
+	value := ops[0]["value"].(map[string]any)
+	if value["rel"] != LinkTypeChild || value["url"] != a.mc.ClientFor("proj").WorkItemURL(22) {
+		t.Errorf("relation = %v", value)
+	}
+}
+
+func TestAdapter_RemoveWorkItemLink_RemovesByIndexAtRev(t *testing.T) {
+	var ops []PatchOperation
+	a := newEditTestAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			w.Write([]byte(`{"id": 10, "rev": 4, "relations": [
+				{"rel": "System.LinkTypes.Related", "url": "https://x/_apis/wit/workItems/22"},
+				{"rel": "System.LinkTypes.Hierarchy-Forward", "url": "https://x/_apis/wit/workItems/22"}
+			]}`))
+			return
+		}
+		json.NewDecoder(r.Body).Decode(&ops)
+		w.Write([]byte(`{"id": 10, "rev": 5}`))
+	})
+
+	if err := a.RemoveWorkItemLink("proj", 10, provider.LinkChild, 22); err != nil {
+		t.Fatalf("RemoveWorkItemLink() error = %v", err)
+	}
+	want := []PatchOperation{
+		{Op: "test", Path: "/rev", Value: float64(4)},
+		{Op: "remove", Path: "/relations/1"},
+	}
+	if !reflect.DeepEqual(ops, want) {
+		t.Errorf("ops = %+v, want %+v", ops, want)
+	}
+
+	if err := a.RemoveWorkItemLink("proj", 10, provider.LinkParent, 22); err == nil {
+		t.Error("removing a missing link should fail")
+	}
+}
+
+func TestAdapter_RemoveWorkItemLink_Conflict(t *testing.T) {
+	a := newEditTestAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			w.Write([]byte(`{"id": 10, "rev": 4, "relations": [
+				{"rel": "System.LinkTypes.Related", "url": "https://x/_apis/wit/workItems/22"}
+			]}`))
+			return
+		}
+		w.WriteHeader(http.StatusPreconditionFailed)
+	})
+
+	err := a.RemoveWorkItemLink("proj", 10, provider.LinkRelated, 22)
+	if !errors.Is(err, provider.ErrWorkItemConflict) {
+		t.Errorf("error = %v, want ErrWorkItemConflict", err)
+	}
+}
@@ -27,28 +27,38 @@ type WorkItem struct {
 	Rev         int            `json:"rev"`
 	Fields      WorkItemFields `json:"fields"`
 	URL         string         `json:"url"`
+	Relations   []WorkItemRelation `json:"relations,omitempty"` // only read with $expand=relations
 	ProjectName        string         `json:"-"` // Set by MultiClient, not from API
 	ProjectDisplayName string         `json:"-"` // Set by MultiClient, display name for UI
 }
 
 // WorkItemFields represents the fields of a work item
 type WorkItemFields struct {
-	Title         string    `json:"System.Title"`
-	State         string    `json:"System.State"`
-	WorkItemType  string    `json:"System.WorkItemType"`
-	AssignedTo    *Identity `json:"System.AssignedTo"`
-	Priority      int       `json:"Microsoft.VSTS.Common.Priority"`
-	ChangedDate   time.Time `json:"System.ChangedDate"`
-	IterationPath string    `json:"System.IterationPath"`
-	Description   string    `json:"System.Description"`
-	ReproSteps    string    `json:"Microsoft.VSTS.TCM.ReproSteps"`
-	Tags          string    `json:"System.Tags"`
+	Title              string    `json:"System.Title"`
+	State              string    `json:"System.State"`
+	WorkItemType       string    `json:"System.WorkItemType"`
+	AssignedTo         *Identity `json:"System.AssignedTo"`
+	Priority           int       `json:"Microsoft.VSTS.Common.Priority"`
+	ChangedDate        time.Time `json:"System.ChangedDate"`
+	IterationPath      string    `json:"System.IterationPath"`
+	AreaPath           string    `json:"System.AreaPath"`
+	Description        string    `json:"System.Description"`
+	ReproSteps         string    `json:"Microsoft.VSTS.TCM.ReproSteps"`
+	AcceptanceCriteria string    `json:"Microsoft.VSTS.Common.AcceptanceCriteria"`
+	Tags               string    `json:"System.Tags"`
+	BoardColumn        string    `json:"System.BoardColumn"`
+	Parent             int       `json:"System.Parent"`
 
 	StoryPoints     float64   `json:"Microsoft.VSTS.Scheduling.StoryPoints"`
+	RemainingWork   float64   `json:"Microsoft.VSTS.Scheduling.RemainingWork"`
 	StateChangeDate time.Time `json:"Microsoft.VSTS.Common.StateChangeDate"`
 	ActivatedDate   time.Time `json:"Microsoft.VSTS.Common.ActivatedDate"`
 	ClosedDate      time.Time `json:"Microsoft.VSTS.Common.ClosedDate"`
 	CreatedDate     time.Time `json:"System.CreatedDate"`
+
+	// Values holds every field as returned, including those without a
+	// member above; query columns are read from it.
+	Values map[string]any `json:"-"`
 }
 
 // WorkItemReference represents a reference to a work item from WIQL queries
@@ -57,9 +67,14 @@ type WorkItemReference struct {
 	URL string `json:"url"`
 }
 
-// WIQLResponse represents the response from a WIQL query
+// WIQLResponse represents the response from a WIQL query. Queries over
+// work item links (tree and one-hop queries) return WorkItemRelations
+// instead of WorkItems.
 type WIQLResponse struct {
-	WorkItems []WorkItemReference `json:"workItems"`
+	QueryResultType   string                   `json:"queryResultType"` // "workItem" or "workItemLink"
+	Columns           []WorkItemFieldReference `json:"columns"`
+	WorkItems         []WorkItemReference      `json:"workItems"`
+	WorkItemRelations []WorkItemLinkReference  `json:"workItemRelations"`
 }
 
 // WorkItemsResponse represents the response from getting work items
@@ -148,6 +163,22 @@ func (wi *WorkItem) AssignedToName() string {
 // QueryWorkItemIDs executes a WIQL query and returns the work item IDs
 // top: maximum number of results to return
 func (c *Client) QueryWorkItemIDs(query string, top int) ([]int, error) {
+	response, err := c.RunWIQL(query, top)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int, len(response.WorkItems))
+	for i, wi := range response.WorkItems {
+		ids[i] = wi.ID
+	}
+
+	return ids, nil
+}
+
+// RunWIQL executes a WIQL query and returns the raw result
+// top: maximum number of results to return
+func (c *Client) RunWIQL(query string, top int) (*WIQLResponse, error) {
 	path := fmt.Sprintf("/wit/wiql?api-version=7.1&$top=%d", top)
 
 	payload := fmt.Sprintf(`{"query": %s}`, escapeJSONString(query))
@@ -162,17 +193,42 @@ func (c *Client) QueryWorkItemIDs(query string, top int) ([]int, error) {
 			"This may indicate an API structure change. Please check for updates or report this issue", err)
 	}
 
-	ids := make([]int, len(response.WorkItems))
-	for i, wi := range response.WorkItems {
-		ids[i] = wi.ID
-	}
+	return &response, nil
+}
 
-	return ids, nil
+// workItemFields are the fields GetWorkItems retrieves
+var workItemFields = []string{
+	"System.Id",
+	"System.Title",
+	"System.State",
+	"System.WorkItemType",
+	"System.AssignedTo",
+	"Microsoft.VSTS.Common.Priority",
+	"System.ChangedDate",
+	"System.IterationPath",
+	"System.AreaPath",
+	"System.Description",
+	"Microsoft.VSTS.TCM.ReproSteps",
+	"Microsoft.VSTS.Common.AcceptanceCriteria",
+	"System.Tags",
+	"System.BoardColumn",
+	"System.Parent",
+	"Microsoft.VSTS.Scheduling.StoryPoints",
+	"Microsoft.VSTS.Scheduling.RemainingWork",
+	"Microsoft.VSTS.Common.StateChangeDate",
+	"Microsoft.VSTS.Common.ActivatedDate",
+	"Microsoft.VSTS.Common.ClosedDate",
+	"System.CreatedDate",
 }
 
 // GetWorkItems retrieves work items by their IDs
 // Azure DevOps supports up to 200 IDs per request
 func (c *Client) GetWorkItems(ids []int) ([]WorkItem, error) {
+	return c.getWorkItems(ids, workItemFields)
+}
+
+// getWorkItems retrieves the given fields of work items by their IDs
+func (c *Client) getWorkItems(ids []int, fields []string) ([]WorkItem, error) {
 	if len(ids) == 0 {
 		return []WorkItem{}, nil
 	}
@@ -184,27 +240,7 @@ func (c *Client) GetWorkItems(ids []int) ([]WorkItem, error) {
 	}
 	idsParam := strings.Join(idStrs, ",")
 
-	// Specify fields to retrieve
-	fields := strings.Join([]string{
-		"System.Id",
-		"System.Title",
-		"System.State",
-		"System.WorkItemType",
-		"System.AssignedTo",
-		"Microsoft.VSTS.Common.Priority",
-		"System.ChangedDate",
-		"System.IterationPath",
-		"System.Description",
-		"Microsoft.VSTS.TCM.ReproSteps",
-		"System.Tags",
-		"Microsoft.VSTS.Scheduling.StoryPoints",
-		"Microsoft.VSTS.Common.StateChangeDate",
-		"Microsoft.VSTS.Common.ActivatedDate",
-		"Microsoft.VSTS.Common.ClosedDate",
-		"System.CreatedDate",
-	}, ",")
-
-	path := fmt.Sprintf("/wit/workitems?ids=%s&fields=%s&api-version=7.1", idsParam, fields)
+	path := fmt.Sprintf("/wit/workitems?ids=%s&fields=%s&api-version=7.1", idsParam, strings.Join(fields, ","))
 
 	body, err := c.get(path)
 	if err != nil {
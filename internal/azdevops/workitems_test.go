@@ -196,6 +196,9 @@ func TestClient_GetWorkItems_RequestsReproStepsField(t *testing.T) {
 	if !strings.Contains(capturedPath, "Microsoft.VSTS.TCM.ReproSteps") {
 		t.Errorf("GetWorkItems request must include Microsoft.VSTS.TCM.ReproSteps field.\nGot path: %s", capturedPath)
 	}
+	if !strings.Contains(capturedPath, "Microsoft.VSTS.Common.AcceptanceCriteria") {
+		t.Errorf("GetWorkItems request must include Microsoft.VSTS.Common.AcceptanceCriteria field.\nGot path: %s", capturedPath)
+	}
 }
 
 func TestClient_QueryWorkItemIDs(t *testing.T) {
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/spf13/viper"
@@ -36,7 +37,10 @@ type Config struct {
 	DisabledPanes   []string          `mapstructure:"-"` // parsed from comma-separated "disabled_panes"
 	Metrics         MetricsConfig     `mapstructure:"metrics"`
 	GitHub          GitHubConfig      `mapstructure:"github"`
+	Diff            DiffConfig        `mapstructure:"diff"`
+	WorkItems       WorkItemsConfig   `mapstructure:"work_items"`
 	configPath      string            // internal field to store config path for saving
+	inMemory        bool              // derived for this session only; Save writes nothing
 }
 
 // HasAzure reports whether Azure DevOps is fully configured (org AND projects
@@ -74,6 +78,69 @@ type MetricsStates struct {
 	Closed       string `mapstructure:"closed"`
 }
 
+// DiffConfig tunes how the PR diff viewer compares file versions.
+type DiffConfig struct {
+	ContextLines     int  `mapstructure:"context_lines"`     // unchanged lines shown around each change
+	IgnoreWhitespace bool `mapstructure:"ignore_whitespace"` // treat whitespace-only line changes as unchanged
+	MaxLines         int  `mapstructure:"max_lines"`         // combined old+new lines above which the diff waits for confirmation; 0 disables
+}
+
+// WorkItemsConfig holds the named queries offered by the work items query
+// picker, the columns their results show and the team each project's
+// boards and sprints are read from.
+type WorkItemsConfig struct {
+	Queries []WorkItemQueryConfig `mapstructure:"queries"`
+	Columns []string              `mapstructure:"columns"` // field reference names; used by queries without columns of their own
+	Teams   []WorkItemTeamConfig  `mapstructure:"teams"`   // projects not listed use their default team
+}
+
+// WorkItemTeamConfig selects the team of a project whose boards, sprints
+// and capacity are shown.
+type WorkItemTeamConfig struct {
+	Project string `mapstructure:"project"`
+	Team    string `mapstructure:"team"` // team name or ID
+}
+
+// TeamFor returns the team configured for project, or "" to use the
+// project's default team.
+func (c WorkItemsConfig) TeamFor(project string) string {
+	for _, t := range c.Teams {
+		if t.Project == project {
+			return strings.TrimSpace(t.Team)
+		}
+	}
+	return ""
+}
+
+// WorkItemQueryConfig is a named work item query, given either as raw WIQL
+// or as criteria the WIQL is built from.
+type WorkItemQueryConfig struct {
+	Name     string                `mapstructure:"name"`
+	Project  string                `mapstructure:"project"` // runs in every project when empty
+	WIQL     string                `mapstructure:"wiql"`
+	Criteria WorkItemQueryCriteria `mapstructure:"criteria"`
+	Mode     string                `mapstructure:"mode"` // "flat" (default) or "tree"
+	Columns  []string              `mapstructure:"columns"`
+	Top      int                   `mapstructure:"top"` // 0 uses the default limit
+}
+
+// WorkItemQueryCriteria selects work items without writing WIQL. Values
+// starting with @ are WIQL macros, e.g. @Me or @CurrentIteration.
+type WorkItemQueryCriteria struct {
+	Types         []string `mapstructure:"types"`
+	States        []string `mapstructure:"states"`
+	Tags          []string `mapstructure:"tags"`
+	AssignedTo    string   `mapstructure:"assigned_to"`
+	AreaPath      string   `mapstructure:"area_path"`
+	IterationPath string   `mapstructure:"iteration_path"`
+}
+
+// IsZero reports whether no criterion is set.
+func (c WorkItemQueryCriteria) IsZero() bool {
+	return len(c.Types) == 0 && len(c.States) == 0 && len(c.Tags) == 0 &&
+		c.AssignedTo == "" && c.AreaPath == "" && c.IterationPath == ""
+}
+
 // validDisabledPanes lists the pane names that can be disabled.
 var validDisabledPanes = map[string]bool{
 	"pipelines": true,
@@ -173,6 +240,10 @@ const (
 	DefaultMetricsActiveState       = "Active"
 	DefaultMetricsReadyForTestState = "Ready for Test"
 	DefaultMetricsClosedState       = "Closed"
+
+	// Mirrors diff.DefaultContextLines / diff.DefaultMaxLines.
+	DefaultDiffContextLines = 5
+	DefaultDiffMaxLines     = 20000
 )
 
 // GetPath returns the path to the config file
@@ -217,18 +288,7 @@ func LoadFrom(configPath string) (*Config, error) {
 	v.SetConfigFile(configPath)
 	v.SetConfigType("yaml")
 
-	// Set defaults
-	v.SetDefault("polling_interval", DefaultPollingInterval)
-	v.SetDefault("theme", DefaultTheme)
-	v.SetDefault("metrics.enabled", false)
-	v.SetDefault("metrics.interval_days", DefaultMetricsIntervalDays)
-	v.SetDefault("metrics.active_stale_days", DefaultMetricsActiveStaleDays)
-	v.SetDefault("metrics.rft_stale_days", DefaultMetricsRFTStaleDays)
-	v.SetDefault("metrics.wip_limit", DefaultMetricsWIPLimit)
-	v.SetDefault("metrics.run_one_shot_backfill", false)
-	v.SetDefault("metrics.states.active", DefaultMetricsActiveState)
-	v.SetDefault("metrics.states.ready_for_test", DefaultMetricsReadyForTestState)
-	v.SetDefault("metrics.states.closed", DefaultMetricsClosedState)
+	setDefaults(v)
 
 	// Read config file - return error if not found
 	if err := v.ReadInConfig(); err != nil {
@@ -303,6 +363,43 @@ func LoadFrom(configPath string) (*Config, error) {
 	return &cfg, nil
 }
 
+// setDefaults registers the default value of every optional setting.
+func setDefaults(v *viper.Viper) {
+	v.SetDefault("polling_interval", DefaultPollingInterval)
+	v.SetDefault("theme", DefaultTheme)
+	v.SetDefault("metrics.enabled", false)
+	v.SetDefault("metrics.interval_days", DefaultMetricsIntervalDays)
+	v.SetDefault("metrics.active_stale_days", DefaultMetricsActiveStaleDays)
+	v.SetDefault("metrics.rft_stale_days", DefaultMetricsRFTStaleDays)
+	v.SetDefault("metrics.wip_limit", DefaultMetricsWIPLimit)
+	v.SetDefault("metrics.run_one_shot_backfill", false)
+	v.SetDefault("metrics.states.active", DefaultMetricsActiveState)
+	v.SetDefault("metrics.states.ready_for_test", DefaultMetricsReadyForTestState)
+	v.SetDefault("metrics.states.closed", DefaultMetricsClosedState)
+	v.SetDefault("diff.context_lines", DefaultDiffContextLines)
+	v.SetDefault("diff.ignore_whitespace", false)
+	v.SetDefault("diff.max_lines", DefaultDiffMaxLines)
+}
+
+// Defaults returns a Config with every optional setting at its default and
+// no backend configured. It backs running without a config file, where the
+// backend is derived from the git remote of the working directory instead.
+func Defaults() *Config {
+	v := viper.New()
+	setDefaults(v)
+	var cfg Config
+	// Defaults are plain scalars, which always decode.
+	_ = v.Unmarshal(&cfg)
+	return &cfg
+}
+
+// KeepInMemory makes Save a no-op, for a config derived for this session
+// rather than read from a file: changes such as the theme then last until
+// exit instead of writing the derived backend to the user's config file.
+func (c *Config) KeepInMemory() {
+	c.inMemory = true
+}
+
 // NewWithPath creates a Config with all fields set and the internal configPath
 // populated so that Save() writes to the correct location.
 func NewWithPath(org string, projects []string, pollingInterval int, theme string, configPath string) *Config {
@@ -401,6 +498,17 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	if c.Diff.ContextLines < 0 {
+		return fmt.Errorf("diff.context_lines must be >= 0, got %d", c.Diff.ContextLines)
+	}
+	if c.Diff.MaxLines < 0 {
+		return fmt.Errorf("diff.max_lines must be >= 0 (0 disables the limit), got %d", c.Diff.MaxLines)
+	}
+
+	if err := c.validateWorkItems(); err != nil {
+		return err
+	}
+
 	if c.Metrics.Enabled {
 		if c.Metrics.IntervalDays <= 0 {
 			return fmt.Errorf("metrics.interval_days must be > 0, got %d", c.Metrics.IntervalDays)
@@ -440,6 +548,71 @@ func (c *Config) Validate() error {
 	return nil
 }
 
+// validateWorkItems checks the work item queries: each needs a unique name,
+// exactly one of wiql and criteria, a known mode and, when it names a
+// project, one of the configured projects. Each configured project may
+// name one team.
+func (c *Config) validateWorkItems() error {
+	teams := map[string]bool{}
+	for i, t := range c.WorkItems.Teams {
+		key := fmt.Sprintf("work_items.teams[%d]", i)
+		if !slices.Contains(c.Projects, t.Project) {
+			return fmt.Errorf("%s: project %q is not one of the configured projects", key, t.Project)
+		}
+		if strings.TrimSpace(t.Team) == "" {
+			return fmt.Errorf("%s.team cannot be empty", key)
+		}
+		if teams[t.Project] {
+			return fmt.Errorf("%s: duplicate team for project %q", key, t.Project)
+		}
+		teams[t.Project] = true
+	}
+
+	names := map[string]bool{}
+	for i, q := range c.WorkItems.Queries {
+		key := fmt.Sprintf("work_items.queries[%d]", i)
+		name := strings.TrimSpace(q.Name)
+		if name == "" {
+			return fmt.Errorf("%s.name cannot be empty", key)
+		}
+		if names[strings.ToLower(name)] {
+			return fmt.Errorf("%s: duplicate query name %q", key, name)
+		}
+		names[strings.ToLower(name)] = true
+
+		hasWIQL := strings.TrimSpace(q.WIQL) != ""
+		if hasWIQL == !q.Criteria.IsZero() {
+			return fmt.Errorf("%s (%s): set exactly one of 'wiql' and 'criteria'", key, name)
+		}
+		switch q.Mode {
+		case "", "flat", "tree":
+		default:
+			return fmt.Errorf("%s (%s): invalid mode %q: must be 'flat' or 'tree'", key, name, q.Mode)
+		}
+		if q.Top < 0 {
+			return fmt.Errorf("%s (%s): top must be >= 0, got %d", key, name, q.Top)
+		}
+		if q.Project != "" && !slices.Contains(c.Projects, q.Project) {
+			return fmt.Errorf("%s (%s): project %q is not one of the configured projects", key, name, q.Project)
+		}
+		if err := validateColumns(key+".columns", q.Columns); err != nil {
+			return err
+		}
+	}
+	return validateColumns("work_items.columns", c.WorkItems.Columns)
+}
+
+// validateColumns checks that columns are field reference names such as
+// "Microsoft.VSTS.Scheduling.StoryPoints".
+func validateColumns(key string, columns []string) error {
+	for _, col := range columns {
+		if col == "" || strings.ContainsAny(col, " []'") {
+			return fmt.Errorf("%s: invalid field %q: use the field's reference name, e.g. System.Tags", key, col)
+		}
+	}
+	return nil
+}
+
 // validateStateName guards the configured names against empty values and
 // single quotes (which would break the WIQL `IN ('...','...')` literal).
 func validateStateName(key, name string) error {
@@ -464,6 +637,10 @@ func (c *Config) GetTheme() string {
 
 // Save writes the current configuration to the config file
 func (c *Config) Save() error {
+	if c.inMemory {
+		return nil
+	}
+
 	// Validate before saving
 	if err := c.Validate(); err != nil {
 		return fmt.Errorf("cannot save invalid config: %w", err)
@@ -102,6 +102,32 @@ theme: dark
 	}
 }
 
+// TestConfigUpdateThemeInMemory tests that a config derived without a config
+// file, as from the git remote, is never written to the default path.
+func TestConfigUpdateThemeInMemory(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+
+	cfg := Defaults()
+	cfg.Organization, cfg.Projects = "remote-org", []string{"remote-project"}
+	cfg.KeepInMemory()
+
+	if err := cfg.UpdateTheme("nord"); err != nil {
+		t.Fatalf("UpdateTheme() error = %v", err)
+	}
+	if cfg.Theme != "nord" {
+		t.Errorf("Theme = %q, want the change kept for the session", cfg.Theme)
+	}
+	path, err := GetPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("expected no config file at %s, stat error = %v", path, err)
+	}
+}
+
 // TestConfigSaveValidation tests that validation happens before save
 func TestConfigSaveValidation(t *testing.T) {
 	// Create a temporary config file
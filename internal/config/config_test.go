@@ -145,6 +145,27 @@ theme: dark
 	}
 }
 
+func TestDefaults_MatchLoadedDefaults(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configFile, []byte("github:\n  repos:\n    - o/r\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	loaded, err := LoadFrom(configFile)
+	if err != nil {
+		t.Fatalf("LoadFrom() failed: %v", err)
+	}
+
+	cfg := Defaults()
+	if cfg.HasAzure() || cfg.HasGitHub() {
+		t.Error("Defaults() should configure no backend")
+	}
+	cfg.GitHub.Repos = loaded.GitHub.Repos
+	if cfg.PollingInterval != loaded.PollingInterval || cfg.Theme != loaded.Theme ||
+		cfg.Metrics != loaded.Metrics || cfg.Diff != loaded.Diff {
+		t.Errorf("Defaults() = %+v, want the defaults LoadFrom applies: %+v", cfg, loaded)
+	}
+}
+
 func TestLoad_BackwardCompatSingleProject(t *testing.T) {
 	// Old config format with single "project:" field should still work
 	tempDir := t.TempDir()
@@ -1691,3 +1712,197 @@ github:
 		t.Errorf("GitHub.PriorityPrefix = %q, want priority:", cfg.GitHub.PriorityPrefix)
 	}
 }
+
+func TestLoad_DiffDefaults_WhenBlockAbsent(t *testing.T) {
+	tempDir := t.TempDir()
+	configFile := filepath.Join(tempDir, "config.yaml")
+	configContent := `organization: test-org
+projects:
+  - alpha
+`
+	if err := os.WriteFile(configFile, []byte(configContent), 0644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	cfg, err := LoadFrom(configFile)
+	if err != nil {
+		t.Fatalf("LoadFrom: %v", err)
+	}
+	if cfg.Diff.ContextLines != DefaultDiffContextLines {
+		t.Errorf("Diff.ContextLines = %d, want %d", cfg.Diff.ContextLines, DefaultDiffContextLines)
+	}
+	if cfg.Diff.MaxLines != DefaultDiffMaxLines {
+		t.Errorf("Diff.MaxLines = %d, want %d", cfg.Diff.MaxLines, DefaultDiffMaxLines)
+	}
+	if cfg.Diff.IgnoreWhitespace {
+		t.Error("Diff.IgnoreWhitespace = true by default; want false")
+	}
+}
+
+func TestLoad_DiffSection(t *testing.T) {
+	tempDir := t.TempDir()
+	configFile := filepath.Join(tempDir, "config.yaml")
+	configContent := `organization: test-org
+projects:
+  - alpha
+diff:
+  context_lines: 2
+  ignore_whitespace: true
+  max_lines: 0
+`
+	if err := os.WriteFile(configFile, []byte(configContent), 0644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	cfg, err := LoadFrom(configFile)
+	if err != nil {
+		t.Fatalf("LoadFrom: %v", err)
+	}
+	if cfg.Diff.ContextLines != 2 || !cfg.Diff.IgnoreWhitespace || cfg.Diff.MaxLines != 0 {
+		t.Errorf("Diff = %+v, want {2 true 0}", cfg.Diff)
+	}
+}
+
+func TestValidate_DiffRejectsNegativeValues(t *testing.T) {
+	base := Config{Organization: "org", Projects: []string{"p"}, PollingInterval: 60, Theme: "dark"}
+
+	cfg := base
+	cfg.Diff.ContextLines = -1
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "diff.context_lines") {
+		t.Errorf("Validate() = %v, want diff.context_lines error", err)
+	}
+
+	cfg = base
+	cfg.Diff.MaxLines = -5
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "diff.max_lines") {
+		t.Errorf("Validate() = %v, want diff.max_lines error", err)
+	}
+}
+
+func TestLoad_WorkItemsSection(t *testing.T) {
+	tempDir := t.TempDir()
+	configFile := filepath.Join(tempDir, "config.yaml")
+	configContent := `organization: test-org
+projects:
+  - alpha
+work_items:
+  teams:
+    - project: alpha
+      team: Web Team
+  columns:
+    - Microsoft.VSTS.Scheduling.StoryPoints
+  queries:
+    - name: My bugs
+      criteria:
+        types: [Bug]
+        assigned_to: "@Me"
+    - name: Epics
+      project: alpha
+      mode: tree
+      top: 100
+      wiql: SELECT [System.Id] FROM WorkItemLinks
+`
+	if err := os.WriteFile(configFile, []byte(configContent), 0644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	cfg, err := LoadFrom(configFile)
+	if err != nil {
+		t.Fatalf("LoadFrom: %v", err)
+	}
+	if len(cfg.WorkItems.Columns) != 1 || cfg.WorkItems.Columns[0] != "Microsoft.VSTS.Scheduling.StoryPoints" {
+		t.Errorf("WorkItems.Columns = %v", cfg.WorkItems.Columns)
+	}
+	if len(cfg.WorkItems.Queries) != 2 {
+		t.Fatalf("WorkItems.Queries = %+v, want 2 queries", cfg.WorkItems.Queries)
+	}
+	bugs, epics := cfg.WorkItems.Queries[0], cfg.WorkItems.Queries[1]
+	if bugs.Name != "My bugs" || len(bugs.Criteria.Types) != 1 || bugs.Criteria.AssignedTo != "@Me" {
+		t.Errorf("first query = %+v", bugs)
+	}
+	if epics.Project != "alpha" || epics.Mode != "tree" || epics.Top != 100 || epics.WIQL == "" {
+		t.Errorf("second query = %+v", epics)
+	}
+	if got := cfg.WorkItems.TeamFor("alpha"); got != "Web Team" {
+		t.Errorf("TeamFor(alpha) = %q, want Web Team", got)
+	}
+	if got := cfg.WorkItems.TeamFor("beta"); got != "" {
+		t.Errorf("TeamFor(beta) = %q, want the default team", got)
+	}
+}
+
+func TestValidate_WorkItemQueries(t *testing.T) {
+	base := Config{Organization: "org", Projects: []string{"p"}, PollingInterval: 60, Theme: "dark"}
+	wiql := "SELECT [System.Id] FROM WorkItems"
+
+	tests := []struct {
+		name    string
+		query   WorkItemQueryConfig
+		columns []string
+		wantErr string
+	}{
+		{name: "valid wiql", query: WorkItemQueryConfig{Name: "q", WIQL: wiql, Mode: "flat"}},
+		{name: "valid criteria", query: WorkItemQueryConfig{Name: "q", Project: "p", Criteria: WorkItemQueryCriteria{States: []string{"Active"}}}},
+		{name: "missing name", query: WorkItemQueryConfig{WIQL: wiql}, wantErr: "name cannot be empty"},
+		{name: "neither", query: WorkItemQueryConfig{Name: "q"}, wantErr: "exactly one of"},
+		{name: "both", query: WorkItemQueryConfig{Name: "q", WIQL: wiql, Criteria: WorkItemQueryCriteria{Tags: []string{"x"}}}, wantErr: "exactly one of"},
+		{name: "bad mode", query: WorkItemQueryConfig{Name: "q", WIQL: wiql, Mode: "oneHop"}, wantErr: "invalid mode"},
+		{name: "negative top", query: WorkItemQueryConfig{Name: "q", WIQL: wiql, Top: -1}, wantErr: "top must be >= 0"},
+		{name: "unknown project", query: WorkItemQueryConfig{Name: "q", WIQL: wiql, Project: "other"}, wantErr: "not one of the configured projects"},
+		{name: "bad column", query: WorkItemQueryConfig{Name: "q", WIQL: wiql, Columns: []string{"Story Points"}}, wantErr: "invalid field"},
+		{name: "bad default column", query: WorkItemQueryConfig{Name: "q", WIQL: wiql}, columns: []string{"[System.Tags]"}, wantErr: "work_items.columns"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := base
+			cfg.WorkItems = WorkItemsConfig{Queries: []WorkItemQueryConfig{tt.query}, Columns: tt.columns}
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	cfg := base
+	cfg.WorkItems.Queries = []WorkItemQueryConfig{{Name: "Bugs", WIQL: wiql}, {Name: "bugs", WIQL: wiql}}
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "duplicate query name") {
+		t.Errorf("Validate() = %v, want duplicate query name error", err)
+	}
+}
+
+func TestValidate_WorkItemTeams(t *testing.T) {
+	base := Config{Organization: "org", Projects: []string{"p", "q"}, PollingInterval: 60, Theme: "dark"}
+
+	tests := []struct {
+		name    string
+		teams   []WorkItemTeamConfig
+		wantErr string
+	}{
+		{name: "valid", teams: []WorkItemTeamConfig{{Project: "p", Team: "Web"}, {Project: "q", Team: "Web"}}},
+		{name: "unknown project", teams: []WorkItemTeamConfig{{Project: "other", Team: "Web"}}, wantErr: "not one of the configured projects"},
+		{name: "empty team", teams: []WorkItemTeamConfig{{Project: "p", Team: " "}}, wantErr: "team cannot be empty"},
+		{name: "duplicate project", teams: []WorkItemTeamConfig{{Project: "p", Team: "Web"}, {Project: "p", Team: "API"}}, wantErr: "duplicate team"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := base
+			cfg.WorkItems = WorkItemsConfig{Teams: tt.teams}
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -137,6 +137,7 @@ func mockWorkItems() []azdevops.WorkItem {
 				Title: "Login page crashes on mobile Safari", State: "Active", WorkItemType: "Bug",
 				AssignedTo: &team[0], Priority: 1, ChangedDate: hoursAgo(2),
 				StateChangeDate: daysAgo(6), StoryPoints: 3,
+				RemainingWork: 6,
 				IterationPath: "Nexus Platform\\Sprint 24",
 				ReproSteps:    "<ol><li>Open login page on iOS Safari 17</li><li>Enter credentials</li><li>Tap Sign In</li><li>Page crashes with white screen</li></ol>",
 				Tags:          "mobile; critical; safari",
@@ -148,8 +149,10 @@ func mockWorkItems() []azdevops.WorkItem {
 				Title: "Implement user profile avatar upload", State: "Active", WorkItemType: "User Story",
 				AssignedTo: &team[1], Priority: 2, ChangedDate: hoursAgo(5),
 				StateChangeDate: hoursAgo(20), StoryPoints: 5,
+				RemainingWork: 10,
 				IterationPath: "Nexus Platform\\Sprint 24",
-				Description:   "As a user, I want to upload a profile avatar so that other team members can identify me visually.\n\n## Acceptance Criteria\n- Support JPEG, PNG, WebP formats\n- Max file size: 5MB\n- Auto-crop to square\n- Generate thumbnails at 32px, 64px, 128px",
+				Description:   "<div>As a user, I want to upload a profile <b>avatar</b> so that other team members can identify me visually.</div><div><br></div><div>Design: <a href=\"https://www.figma.com/file/nexus-profile\">profile mockups</a></div>",
+				AcceptanceCriteria: "<ul><li>Support <code>JPEG</code>, <code>PNG</code> and <code>WebP</code> formats</li><li>Max file size: <b>5MB</b></li><li>Auto-crop to square</li><li>Generate thumbnails at:<ul><li>32px</li><li>64px</li><li>128px</li></ul></li></ul>",
 				Tags:          "frontend; ux",
 			},
 		},
@@ -158,6 +161,8 @@ func mockWorkItems() []azdevops.WorkItem {
 			Fields: azdevops.WorkItemFields{
 				Title: "Set up CI pipeline for integration tests", State: "New", WorkItemType: "Task",
 				AssignedTo: &team[2], Priority: 2, ChangedDate: hoursAgo(8),
+				Parent:        5002,
+				RemainingWork: 8,
 				IterationPath: "Nexus Platform\\Sprint 24",
 				Description:   "Configure the CI pipeline to run integration tests against the staging database after unit tests pass.",
 			},
@@ -168,6 +173,7 @@ func mockWorkItems() []azdevops.WorkItem {
 				Title: "API returns 500 when filtering by date range", State: "Ready for Test", WorkItemType: "Bug",
 				AssignedTo: &team[3], Priority: 2, ChangedDate: hoursAgo(1),
 				StateChangeDate: daysAgo(4), StoryPoints: 3,
+				RemainingWork: 2,
 				IterationPath: "Nexus Platform\\Sprint 24",
 				ReproSteps:    "<ol><li>Call GET /api/v1/events?from=2024-01-01&to=2024-12-31</li><li>Returns HTTP 500</li></ol><p>Cause: date parsing fails for timezone-aware timestamps.</p>",
 				Tags:          "api; backend",
@@ -211,6 +217,7 @@ func mockWorkItems() []azdevops.WorkItem {
 				Title: "Write unit tests for notification service", State: "Ready for Test", WorkItemType: "Task",
 				AssignedTo: &team[4], Priority: 3, ChangedDate: hoursAgo(24),
 				StateChangeDate: hoursAgo(18), StoryPoints: 2,
+				Parent:        6005,
 				IterationPath: "Horizon App\\Sprint 12",
 				Description:   "Add unit tests for the notification service. Target: 80% code coverage.",
 			},
@@ -221,6 +228,7 @@ func mockWorkItems() []azdevops.WorkItem {
 				Title: "Optimize bundle size by code splitting routes", State: "Active", WorkItemType: "Task",
 				AssignedTo: &team[3], Priority: 2, ChangedDate: hoursAgo(4),
 				StateChangeDate: daysAgo(5), StoryPoints: 5,
+				Parent:        6002,
 				IterationPath: "Horizon App\\Sprint 12",
 				Description:   "Current bundle is 2.3MB. Split routes using dynamic imports to reduce initial load to under 500KB.",
 				Tags:          "performance",
@@ -242,6 +250,8 @@ func mockWorkItems() []azdevops.WorkItem {
 				Title: "Add OpenTelemetry tracing to API gateway", State: "Active", WorkItemType: "Task",
 				AssignedTo: &team[2], Priority: 2, ChangedDate: hoursAgo(10),
 				StateChangeDate: hoursAgo(30), StoryPoints: 3,
+				Parent:        5005,
+				RemainingWork: 4,
 				IterationPath: "Nexus Platform\\Sprint 24",
 				Description:   "Instrument the API gateway with OpenTelemetry for distributed tracing. Export to Jaeger.",
 				Tags:          "observability; infrastructure",
@@ -252,6 +262,7 @@ func mockWorkItems() []azdevops.WorkItem {
 			Fields: azdevops.WorkItemFields{
 				Title: "Security audit: review dependency vulnerabilities", State: "New", WorkItemType: "Task",
 				AssignedTo: &team[1], Priority: 1, ChangedDate: hoursAgo(16),
+				RemainingWork: 12,
 				IterationPath: "Nexus Platform\\Sprint 24",
 				Description:   "Run a full dependency audit and address any critical or high severity CVEs.",
 				Tags:          "security",
@@ -263,6 +274,7 @@ func mockWorkItems() []azdevops.WorkItem {
 				Title: "Add rate limiting to public API endpoints", State: "Closed", WorkItemType: "User Story",
 				AssignedTo: &team[0], Priority: 2, ChangedDate: daysAgo(3),
 				StateChangeDate: daysAgo(3), ClosedDate: daysAgo(3), StoryPoints: 5,
+				RemainingWork: 3,
 				IterationPath: "Nexus Platform\\Sprint 24",
 				Description:   "Throttle public API endpoints to 100 requests/minute per API key to protect against abuse.",
 				Tags:          "api; security",
@@ -274,6 +286,7 @@ func mockWorkItems() []azdevops.WorkItem {
 				Title: "Fix flaky timezone test in scheduler", State: "Closed", WorkItemType: "Bug",
 				AssignedTo: &team[1], Priority: 3, ChangedDate: daysAgo(10),
 				StateChangeDate: daysAgo(10), ClosedDate: daysAgo(10), StoryPoints: 3,
+				RemainingWork: 5,
 				IterationPath: "Nexus Platform\\Sprint 24",
 				ReproSteps:    "<p>Scheduler test intermittently fails around DST boundaries due to a hardcoded UTC offset.</p>",
 				Tags:          "backend; flaky-test",
@@ -418,7 +431,8 @@ func mockThreads() []azdevops.Thread {
 					ID: 2, ParentCommentID: 1,
 					Content:     "Good point, I'll create an `AuthError` type with an error code field.",
 					CommentType: "text", PublishedDate: hoursAgo(2), LastUpdatedDate: hoursAgo(2),
-					Author: team[0],
+					Author:     team[0],
+					UsersLiked: []azdevops.Identity{team[1]},
 				},
 			},
 		},
@@ -432,7 +446,7 @@ func mockThreads() []azdevops.Thread {
 			},
 			Comments: []azdevops.Comment{
 				{
-					ID: 1, Content: "Should we validate the redirect URI against a whitelist here?",
+					ID: 1, Content: "Should we validate the redirect URI against a whitelist here? @<" + team[4].ID + "> set this up originally.",
 					CommentType: "text", PublishedDate: hoursAgo(3), LastUpdatedDate: hoursAgo(3),
 					Author: team[3],
 				},
@@ -569,6 +583,16 @@ func mockTimeline() azdevops.Timeline {
 	}
 }
 
+func mockWorkItemTypes() []azdevops.WorkItemType {
+	return []azdevops.WorkItemType{
+		{Name: "Bug"},
+		{Name: "Epic"},
+		{Name: "Feature"},
+		{Name: "Task"},
+		{Name: "User Story"},
+	}
+}
+
 func mockWorkItemTypeStates(workItemType string) []azdevops.WorkItemTypeState {
 	switch workItemType {
 	case "Bug":
@@ -600,6 +624,162 @@ func mockWorkItemTypeStates(workItemType string) []azdevops.WorkItemTypeState {
 	}
 }
 
+// demoTeamID is the ID of the projects' default team.
+const demoTeamID = "d3m0-team-0000-4000-8000-000000000001"
+
+// mockBoards returns the boards of the default team, which hold the work
+// items of every type.
+func mockBoards() []azdevops.Board {
+	states := func(state string) map[string]string {
+		return map[string]string{"Bug": state, "User Story": state, "Task": state}
+	}
+	return []azdevops.Board{{
+		ID:   "d3m0-board-0001",
+		Name: "Stories",
+		Columns: []azdevops.BoardColumn{
+			{ID: "c1", Name: "New", ColumnType: "incoming", StateMappings: states("New")},
+			{ID: "c2", Name: "Active", ColumnType: "inProgress", ItemLimit: 4, StateMappings: states("Active")},
+			{ID: "c3", Name: "Ready for Test", ColumnType: "inProgress", ItemLimit: 2, StateMappings: states("Ready for Test")},
+			{ID: "c4", Name: "Closed", ColumnType: "outgoing", StateMappings: states("Closed")},
+		},
+		Fields: azdevops.BoardFields{ColumnField: azdevops.WorkItemFieldReference{ReferenceName: "WEF_DEMO_Kanban.Column"}},
+	}}
+}
+
+// mockSprints returns the iterations the default team has selected: the
+// previous, the current and the next two-week sprint.
+func mockSprints() []azdevops.TeamIteration {
+	start := now.AddDate(0, 0, -7).UTC().Truncate(24 * time.Hour)
+	sprint := func(n, weeks int, timeFrame string) azdevops.TeamIteration {
+		from := start.AddDate(0, 0, 14*weeks)
+		to := from.AddDate(0, 0, 11)
+		return azdevops.TeamIteration{
+			ID:   fmt.Sprintf("d3m0-sprint-%04d", n),
+			Name: fmt.Sprintf("Sprint %d", n),
+			Path: fmt.Sprintf("%s\\Sprint %d", displayNexus, n),
+			Attributes: azdevops.TeamIterationAttributes{
+				StartDate: &from, FinishDate: &to, TimeFrame: timeFrame,
+			},
+		}
+	}
+	return []azdevops.TeamIteration{sprint(23, -1, "past"), sprint(24, 0, "current"), sprint(25, 1, "future")}
+}
+
+// mockCapacities returns the capacity of the team members in a sprint.
+// Everyone works the same hours every sprint; one member takes two days
+// off in the current one.
+func mockCapacities(sprint azdevops.TeamIteration) []azdevops.TeamMemberCapacity {
+	perDay := []float64{6, 6, 5, 6, 4, 0}
+	capacities := make([]azdevops.TeamMemberCapacity, len(team))
+	for i, m := range team {
+		capacities[i] = azdevops.TeamMemberCapacity{
+			TeamMember: m,
+			Activities: []azdevops.Activity{{Name: "Development", CapacityPerDay: perDay[i]}},
+			DaysOff:    []azdevops.DateRange{},
+		}
+	}
+	if sprint.Attributes.TimeFrame == "current" {
+		off := now.AddDate(0, 0, 2).UTC().Truncate(24 * time.Hour)
+		capacities[2].DaysOff = []azdevops.DateRange{{Start: off, End: off.AddDate(0, 0, 1)}}
+	}
+	return capacities
+}
+
+// mockAttachments returns the files attached to a mock work item as
+// AttachedFile relations to the mock server at host. Only the Safari crash
+// has any.
+func mockAttachments(host string, id int) []azdevops.WorkItemRelation {
+	if id != 5001 {
+		return nil
+	}
+	file := func(guid, name string, size int) azdevops.WorkItemRelation {
+		return azdevops.WorkItemRelation{
+			Rel: azdevops.LinkTypeAttachedFile,
+			URL: fmt.Sprintf("http://%s/wit/attachments/%s", host, guid),
+			Attributes: map[string]any{
+				"name":                name,
+				"resourceSize":        size,
+				"resourceCreatedDate": daysAgo(11).UTC().Format(time.RFC3339),
+			},
+		}
+	}
+	return []azdevops.WorkItemRelation{
+		file("d3m0-att-0001", "safari-white-screen.png", 48213),
+		file("d3m0-att-0002", "web-inspector-console.log", 2311),
+	}
+}
+
+// mockWorkItemUpdates returns the update history of a mock work item: it
+// was created as New, then assigned, linked to its parent and given its
+// attachments, moved to its current state and commented on. Attachment
+// URLs point to the mock server at host.
+func mockWorkItemUpdates(host string, item azdevops.WorkItem) []azdevops.WorkItemRevisionUpdate {
+	f := item.Fields
+	creator, assignee := team[(item.ID+2)%len(team)], team[(item.ID+1)%len(team)]
+	if f.AssignedTo != nil {
+		assignee = *f.AssignedTo
+	}
+	set := func(v any) azdevops.WorkItemFieldUpdate { return azdevops.WorkItemFieldUpdate{NewValue: v} }
+	date := func(t time.Time) azdevops.WorkItemFieldUpdate { return set(t.UTC().Format(time.RFC3339)) }
+
+	updates := []azdevops.WorkItemRevisionUpdate{
+		{Rev: 1, RevisedBy: creator, Fields: map[string]azdevops.WorkItemFieldUpdate{
+			"System.Title":                   set(f.Title),
+			"System.WorkItemType":            set(f.WorkItemType),
+			"System.State":                   set("New"),
+			"Microsoft.VSTS.Common.Priority": set(f.Priority),
+			"System.ChangedDate":             date(daysAgo(12)),
+		}},
+		{Rev: 2, RevisedBy: creator, Fields: map[string]azdevops.WorkItemFieldUpdate{
+			"System.AssignedTo":  set(assignee),
+			"System.ChangedDate": date(daysAgo(11)),
+		}},
+	}
+	if f.Tags != "" {
+		updates[1].Fields["System.Tags"] = set(f.Tags)
+	}
+	added := mockAttachments(host, item.ID)
+	if f.Parent != 0 {
+		added = append(added, workItemRelation(azdevops.LinkTypeParent, f.Parent))
+	}
+	if len(added) > 0 {
+		updates[1].Relations = &azdevops.WorkItemRelationUpdates{Added: added}
+	}
+	if f.State != "New" {
+		updates = append(updates, azdevops.WorkItemRevisionUpdate{Rev: 3, RevisedBy: assignee, Fields: map[string]azdevops.WorkItemFieldUpdate{
+			"System.State":       {OldValue: "New", NewValue: f.State},
+			"System.ChangedDate": date(daysAgo(6)),
+		}})
+	}
+	updates = append(updates, azdevops.WorkItemRevisionUpdate{Rev: len(updates) + 1, RevisedBy: assignee, Fields: map[string]azdevops.WorkItemFieldUpdate{
+		"System.History":     set("<div>Looked into this, notes are in the description.</div>"),
+		"System.ChangedDate": date(f.ChangedDate),
+	}})
+	for i := range updates {
+		updates[i].ID = i + 1
+	}
+	return updates
+}
+
+// mockClassificationNodes returns the iteration tree (group "Iterations") or
+// area tree (group "Areas"). Both projects share it, as the mock server
+// cannot tell them apart.
+func mockClassificationNodes(group string) azdevops.ClassificationNode {
+	if group == "Areas" {
+		return azdevops.ClassificationNode{Name: displayNexus, Children: []azdevops.ClassificationNode{
+			{Name: "Backend"},
+			{Name: "Frontend"},
+			{Name: "Infrastructure"},
+		}}
+	}
+	return azdevops.ClassificationNode{Name: displayNexus, Children: []azdevops.ClassificationNode{
+		{Name: "Backlog"},
+		{Name: "Sprint 23"},
+		{Name: "Sprint 24"},
+		{Name: "Sprint 25"},
+	}}
+}
+
 func mockPRIterations() []azdevops.Iteration {
 	return []azdevops.Iteration{
 		{ID: 1, Description: "Initial implementation"},
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/Elpulgo/azdo/internal/azdevops"
@@ -23,20 +24,47 @@ func newMockHandler() http.Handler {
 	// These all start with /git/repositories/
 	mux.HandleFunc("/git/repositories/", handleGitRepositories)
 
-	// WIQL query (POST)
+	// WIQL query (POST) and saved queries run by ID (GET /wit/wiql/{id})
 	mux.HandleFunc("/wit/wiql", handleWIQL)
+	mux.HandleFunc("/wit/wiql/", handleSavedQueryResult)
+
+	// Saved query folders
+	mux.HandleFunc("/wit/queries", handleQueries)
 
 	// Work items by IDs and state updates (PATCH to /wit/workitems/{id})
 	mux.HandleFunc("/wit/workitems", handleWorkItems)
 	mux.HandleFunc("/wit/workitems/", handleWorkItems)
 
-	// Work item type states
+	// Attachment uploads (POST) and downloads (GET /wit/attachments/{id})
+	mux.HandleFunc("/wit/attachments", handleAttachments)
+	mux.HandleFunc("/wit/attachments/", handleAttachments)
+
+	// Work item types and their states
+	mux.HandleFunc("/wit/workitemtypes", handleWorkItemTypes)
 	mux.HandleFunc("/wit/workitemtypes/", handleWorkItemTypeStates)
 
+	// Iterations and areas offered when editing work items
+	mux.HandleFunc("/wit/classificationnodes/", handleClassificationNodes)
+
+	// Project default team and its members (org-level in the real API)
+	mux.HandleFunc("/projects/", handleProjects)
+
+	// Boards of the default team
+	mux.HandleFunc("/"+demoTeamID+"/_apis/work/boards", handleBoards)
+	mux.HandleFunc("/"+demoTeamID+"/_apis/work/boards/", handleBoards)
+
+	// Settings, sprints and capacity of the default team
+	mux.HandleFunc("/"+demoTeamID+"/_apis/work/teamsettings", handleTeamSettings)
+	mux.HandleFunc("/"+demoTeamID+"/_apis/work/teamsettings/", handleTeamSettings)
+
 	// Pipeline runs, timeline, logs
 	mux.HandleFunc("/build/builds", handleBuilds)
 	mux.HandleFunc("/build/builds/", handleBuildDetail)
 
+	// People search and lookup for @mentions (org-level in the real API)
+	mux.HandleFunc("/IdentityPicker/Identities", handleIdentityPicker)
+	mux.HandleFunc("/identities", handleIdentities)
+
 	return mux
 }
 
@@ -131,10 +159,112 @@ func handleWIQL(w http.ResponseWriter, _ *http.Request) {
 	writeJSON(w, azdevops.WIQLResponse{WorkItems: refs})
 }
 
+// Saved queries of the demo project, run by handleSavedQueryResult.
+const (
+	demoQueryAssignedToMe = "a1f3c2d4-0000-4000-8000-000000000001"
+	demoQueryActiveBugs   = "a1f3c2d4-0000-4000-8000-000000000002"
+	demoQueryBacklogTree  = "a1f3c2d4-0000-4000-8000-000000000003"
+)
+
+// handleQueries answers the query folder tree: "My Queries" and "Shared
+// Queries" with their saved queries.
+func handleQueries(w http.ResponseWriter, _ *http.Request) {
+	query := func(id, folder, name, queryType string) azdevops.QueryHierarchyItem {
+		return azdevops.QueryHierarchyItem{ID: id, Name: name, Path: folder + "/" + name, QueryType: queryType}
+	}
+	folder := func(name string, children ...azdevops.QueryHierarchyItem) azdevops.QueryHierarchyItem {
+		return azdevops.QueryHierarchyItem{
+			ID: "folder-" + strings.ToLower(strings.ReplaceAll(name, " ", "-")), Name: name, Path: name,
+			IsFolder: true, HasChildren: true, Children: children,
+		}
+	}
+	folders := []azdevops.QueryHierarchyItem{
+		folder("My Queries", query(demoQueryAssignedToMe, "My Queries", "Assigned to me", "flat")),
+		folder("Shared Queries",
+			query(demoQueryActiveBugs, "Shared Queries", "Active bugs", "flat"),
+			query(demoQueryBacklogTree, "Shared Queries", "Backlog tree", "tree")),
+	}
+	writeJSON(w, azdevops.QueryHierarchyResponse{Count: len(folders), Value: folders})
+}
+
+// handleSavedQueryResult runs a saved query over the mock work items. The
+// flat queries return their columns; the tree query returns the parents
+// with their children as links.
+func handleSavedQueryResult(w http.ResponseWriter, r *http.Request) {
+	items := mockWorkItems()
+	columns := []azdevops.WorkItemFieldReference{
+		{ReferenceName: "System.Id", Name: "ID"},
+		{ReferenceName: "System.Title", Name: "Title"},
+		{ReferenceName: "System.State", Name: "State"},
+		{ReferenceName: "System.ChangedDate", Name: "Changed Date"},
+	}
+
+	var match func(azdevops.WorkItem) bool
+	switch strings.TrimPrefix(r.URL.Path, "/wit/wiql/") {
+	case demoQueryAssignedToMe:
+		match = func(wi azdevops.WorkItem) bool {
+			return wi.Fields.AssignedTo != nil && wi.Fields.AssignedTo.ID == demoUserID
+		}
+	case demoQueryActiveBugs:
+		match = func(wi azdevops.WorkItem) bool {
+			return wi.Fields.WorkItemType == "Bug" && wi.Fields.State != "Closed"
+		}
+	case demoQueryBacklogTree:
+		parents := map[int]bool{}
+		for _, wi := range items {
+			parents[wi.Fields.Parent] = true
+		}
+		var links []azdevops.WorkItemLinkReference
+		for _, parent := range items {
+			if !parents[parent.ID] {
+				continue
+			}
+			links = append(links, azdevops.WorkItemLinkReference{Target: &azdevops.WorkItemReference{ID: parent.ID}})
+			for _, child := range items {
+				if child.Fields.Parent == parent.ID {
+					links = append(links, azdevops.WorkItemLinkReference{
+						Rel:    "System.LinkTypes.Hierarchy-Forward",
+						Source: &azdevops.WorkItemReference{ID: parent.ID},
+						Target: &azdevops.WorkItemReference{ID: child.ID},
+					})
+				}
+			}
+		}
+		writeJSON(w, azdevops.WIQLResponse{QueryResultType: "workItemLink", Columns: columns, WorkItemRelations: links})
+		return
+	default:
+		http.Error(w, "query not found", http.StatusNotFound)
+		return
+	}
+
+	var refs []azdevops.WorkItemReference
+	for _, wi := range items {
+		if match(wi) {
+			refs = append(refs, azdevops.WorkItemReference{ID: wi.ID})
+		}
+	}
+	writeJSON(w, azdevops.WIQLResponse{QueryResultType: "workItem", Columns: columns, WorkItems: refs})
+}
+
 func handleWorkItems(w http.ResponseWriter, r *http.Request) {
-	// PATCH for state update — just return success
+	// PATCH to /wit/workitems/{id} updates fields or the state
 	if r.Method == http.MethodPatch {
-		writeJSON(w, map[string]any{"id": 1, "rev": 2})
+		handleUpdateWorkItem(w, r)
+		return
+	}
+	// POST to /wit/workitems/${type} creates a work item — echo it back
+	if r.Method == http.MethodPost {
+		handleCreateWorkItem(w, r)
+		return
+	}
+
+	if strings.HasSuffix(r.URL.Path, "/updates") {
+		handleWorkItemUpdates(w, r)
+		return
+	}
+	// GET /wit/workitems/{id}?$expand=relations returns the item's links
+	if strings.HasPrefix(r.URL.Path, "/wit/workitems/") {
+		handleWorkItemRelations(w, r)
 		return
 	}
 
@@ -142,6 +272,146 @@ func handleWorkItems(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, azdevops.WorkItemsResponse{Count: len(items), Value: items})
 }
 
+// handleWorkItemRelations answers a single work item with its parent and
+// children as hierarchy relations, derived from the mock items' parents,
+// and its attachments.
+func handleWorkItemRelations(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/wit/workitems/"))
+	var item azdevops.WorkItem
+	for _, wi := range mockWorkItems() {
+		switch {
+		case wi.ID == id:
+			item = wi
+		case wi.Fields.Parent == id:
+			item.Relations = append(item.Relations, workItemRelation(azdevops.LinkTypeChild, wi.ID))
+		}
+	}
+	if item.ID == 0 {
+		http.Error(w, fmt.Sprintf("work item %d not found", id), http.StatusNotFound)
+		return
+	}
+	if item.Fields.Parent != 0 {
+		item.Relations = append(item.Relations, workItemRelation(azdevops.LinkTypeParent, item.Fields.Parent))
+	}
+	item.Relations = append(item.Relations, mockAttachments(r.Host, id)...)
+	writeJSON(w, item)
+}
+
+// handleWorkItemUpdates answers /wit/workitems/{id}/updates with the
+// mock item's history, all on one page.
+func handleWorkItemUpdates(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/wit/workitems/"), "/updates"))
+	for _, wi := range mockWorkItems() {
+		if wi.ID == id {
+			updates := mockWorkItemUpdates(r.Host, wi)
+			writeJSON(w, azdevops.WorkItemRevisionUpdatesResponse{Count: len(updates), Value: updates})
+			return
+		}
+	}
+	http.Error(w, fmt.Sprintf("work item %d not found", id), http.StatusNotFound)
+}
+
+// handleAttachments answers an upload with a reference to the file, which
+// is not stored, and a download with placeholder content.
+func handleAttachments(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodPost {
+		writeJSON(w, azdevops.AttachmentReference{
+			ID:  "d3m0-att-0100",
+			URL: fmt.Sprintf("http://%s/wit/attachments/d3m0-att-0100", r.Host),
+		})
+		return
+	}
+	fmt.Fprintf(w, "Demo attachment %s\n", strings.TrimPrefix(r.URL.Path, "/wit/attachments/"))
+}
+
+func workItemRelation(rel string, target int) azdevops.WorkItemRelation {
+	return azdevops.WorkItemRelation{Rel: rel, URL: fmt.Sprintf("https://dev.azure.com/demo/_apis/wit/workItems/%d", target)}
+}
+
+// handleCreateWorkItem answers a create with the work item the JSON Patch
+// describes. Nothing is stored, so the item does not show up in the list.
+func handleCreateWorkItem(w http.ResponseWriter, r *http.Request) {
+	var ops []azdevops.PatchOperation
+	if err := json.NewDecoder(r.Body).Decode(&ops); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	fields := azdevops.WorkItemFields{
+		WorkItemType: strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, "/wit/workitems/"), "$"),
+		State:        "New",
+		ChangedDate:  hoursAgo(0),
+		CreatedDate:  hoursAgo(0),
+	}
+	for _, op := range ops {
+		if value, ok := op.Value.(string); ok && op.Path == azdevops.FieldPath(azdevops.FieldTitle) {
+			fields.Title = value
+		}
+	}
+	writeJSON(w, azdevops.WorkItem{ID: 5100, Rev: 1, Fields: fields})
+}
+
+// handleUpdateWorkItem answers an update with the mock work item the JSON
+// Patch is applied to. Nothing is stored, so a refresh shows the old values.
+func handleUpdateWorkItem(w http.ResponseWriter, r *http.Request) {
+	var ops []azdevops.PatchOperation
+	if err := json.NewDecoder(r.Body).Decode(&ops); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	id, _ := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/wit/workitems/"))
+	var item azdevops.WorkItem
+	for _, wi := range mockWorkItems() {
+		if wi.ID == id {
+			item = wi
+		}
+	}
+	if item.ID == 0 {
+		http.Error(w, fmt.Sprintf("work item %d not found", id), http.StatusNotFound)
+		return
+	}
+
+	f := &item.Fields
+	for _, op := range ops {
+		value, _ := op.Value.(string)
+		number, _ := op.Value.(float64)
+		switch op.Path {
+		case azdevops.FieldPath(azdevops.FieldTitle):
+			f.Title = value
+		case azdevops.FieldPath("System.State"):
+			f.State = value
+		case azdevops.FieldPath(azdevops.FieldIterationPath):
+			f.IterationPath = value
+		case azdevops.FieldPath(azdevops.FieldAreaPath):
+			f.AreaPath = value
+		case azdevops.FieldPath(azdevops.FieldTags):
+			f.Tags = value
+		case azdevops.FieldPath(azdevops.FieldDescription):
+			f.Description = value
+		case azdevops.FieldPath(azdevops.FieldReproSteps):
+			f.ReproSteps = value
+		case azdevops.FieldPath(azdevops.FieldPriority):
+			f.Priority = int(number)
+		case azdevops.FieldPath(azdevops.FieldStoryPoints):
+			f.StoryPoints = number
+		case azdevops.FieldPath(azdevops.FieldAssignedTo):
+			f.AssignedTo = nil
+			for _, m := range team {
+				if value != "" && strings.Contains(value, m.UniqueName) {
+					f.AssignedTo = &m
+				}
+			}
+		}
+	}
+	item.Rev++
+	f.ChangedDate = hoursAgo(0)
+	writeJSON(w, item)
+}
+
+func handleWorkItemTypes(w http.ResponseWriter, _ *http.Request) {
+	types := mockWorkItemTypes()
+	writeJSON(w, azdevops.WorkItemTypesResponse{Count: len(types), Value: types})
+}
+
 func handleWorkItemTypeStates(w http.ResponseWriter, r *http.Request) {
 	// Extract work item type from path: /wit/workitemtypes/{type}/states
 	path := r.URL.Path
@@ -156,6 +426,95 @@ func handleWorkItemTypeStates(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, azdevops.WorkItemTypeStatesResponse{Count: len(states), Value: states})
 }
 
+// handleClassificationNodes answers /wit/classificationnodes/{Iterations|Areas}
+// with the project's iteration or area tree.
+func handleClassificationNodes(w http.ResponseWriter, r *http.Request) {
+	group := strings.TrimPrefix(r.URL.Path, "/wit/classificationnodes/")
+	writeJSON(w, mockClassificationNodes(group))
+}
+
+// handleProjects answers /projects/{project} with the project's default
+// team and /projects/{project}/teams/{team}/members with the team.
+func handleProjects(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasSuffix(r.URL.Path, "/members") {
+		writeJSON(w, map[string]any{"defaultTeam": map[string]any{"id": demoTeamID}})
+		return
+	}
+	members := make([]azdevops.TeamMember, len(team))
+	for i, m := range team {
+		members[i] = azdevops.TeamMember{Identity: m}
+	}
+	writeJSON(w, azdevops.TeamMembersResponse{Count: len(members), Value: members})
+}
+
+// handleBoards answers the list of the team's boards and, under
+// /work/boards/{id}, a board with its columns.
+func handleBoards(w http.ResponseWriter, r *http.Request) {
+	boards := mockBoards()
+	_, id, found := strings.Cut(r.URL.Path, "/work/boards/")
+	if !found {
+		refs := make([]azdevops.BoardReference, len(boards))
+		for i, b := range boards {
+			refs[i] = azdevops.BoardReference{ID: b.ID, Name: b.Name}
+		}
+		writeJSON(w, azdevops.BoardReferencesResponse{Count: len(refs), Value: refs})
+		return
+	}
+	for _, b := range boards {
+		if b.ID == id {
+			writeJSON(w, b)
+			return
+		}
+	}
+	http.Error(w, fmt.Sprintf("board %s not found", id), http.StatusNotFound)
+}
+
+// handleTeamSettings answers the team's working days, its sprints (only
+// the current one for $timeframe=current) and, under
+// /iterations/{id}/..., the work items, capacity and days off of a sprint.
+func handleTeamSettings(w http.ResponseWriter, r *http.Request) {
+	sprints := mockSprints()
+	_, rest, found := strings.Cut(r.URL.Path, "/work/teamsettings/iterations")
+	if !found {
+		writeJSON(w, azdevops.TeamSettingsResponse{
+			WorkingDays: []string{"monday", "tuesday", "wednesday", "thursday", "friday"},
+		})
+		return
+	}
+	if rest == "" {
+		if r.URL.Query().Get("$timeframe") == "current" {
+			sprints = sprints[1:2]
+		}
+		writeJSON(w, azdevops.TeamIterationsResponse{Count: len(sprints), Value: sprints})
+		return
+	}
+
+	id, kind, _ := strings.Cut(strings.TrimPrefix(rest, "/"), "/")
+	for _, sprint := range sprints {
+		if sprint.ID != id {
+			continue
+		}
+		switch kind {
+		case "workitems":
+			var links []azdevops.IterationWorkItemLink
+			for _, wi := range mockWorkItems() {
+				if wi.Fields.IterationPath == sprint.Path {
+					links = append(links, azdevops.IterationWorkItemLink{Target: azdevops.WorkItemReference{ID: wi.ID}})
+				}
+			}
+			writeJSON(w, azdevops.IterationWorkItemsResponse{WorkItemRelations: links})
+		case "capacities":
+			writeJSON(w, azdevops.TeamCapacityResponse{TeamMembers: mockCapacities(sprint)})
+		case "teamdaysoff":
+			writeJSON(w, azdevops.TeamDaysOffResponse{DaysOff: []azdevops.DateRange{}})
+		default:
+			http.NotFound(w, r)
+		}
+		return
+	}
+	http.Error(w, fmt.Sprintf("iteration %s not found", id), http.StatusNotFound)
+}
+
 func handleBuilds(w http.ResponseWriter, _ *http.Request) {
 	runs := mockPipelineRuns()
 	writeJSON(w, azdevops.PipelineRunsResponse{Count: len(runs), Value: runs})
@@ -187,3 +546,41 @@ func handleBuildDetail(w http.ResponseWriter, r *http.Request) {
 		http.NotFound(w, r)
 	}
 }
+
+// handleIdentityPicker answers people searches with the team members whose
+// name or email starts with the query.
+func handleIdentityPicker(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Query string `json:"query"`
+	}
+	json.NewDecoder(r.Body).Decode(&req)
+	query := strings.ToLower(req.Query)
+
+	identities := []azdevops.PickerIdentity{}
+	for _, m := range team {
+		if strings.HasPrefix(strings.ToLower(m.DisplayName), query) || strings.HasPrefix(m.UniqueName, query) {
+			identities = append(identities, azdevops.PickerIdentity{
+				LocalID: m.ID, DisplayName: m.DisplayName, SignInAddress: m.UniqueName,
+			})
+		}
+	}
+	writeJSON(w, map[string]any{
+		"results": []any{map[string]any{"queryToken": req.Query, "identities": identities}},
+	})
+}
+
+// handleIdentities answers identity lookups by ID from the team.
+func handleIdentities(w http.ResponseWriter, r *http.Request) {
+	ids := strings.Split(r.URL.Query().Get("identityIds"), ",")
+	value := []azdevops.IdentityRecord{}
+	for _, id := range ids {
+		for _, m := range team {
+			if m.ID == id {
+				rec := azdevops.IdentityRecord{ID: m.ID, ProviderDisplayName: m.DisplayName}
+				rec.Properties.Account.Value = m.UniqueName
+				value = append(value, rec)
+			}
+		}
+	}
+	writeJSON(w, map[string]any{"count": len(value), "value": value})
+}
@@ -5,10 +5,13 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"slices"
+	"strconv"
 	"strings"
 	"testing"
 
 	"github.com/Elpulgo/azdo/internal/azdevops"
+	"github.com/Elpulgo/azdo/internal/provider"
 )
 
 func TestServerPullRequests(t *testing.T) {
@@ -403,3 +406,274 @@ func TestServerFileContent(t *testing.T) {
 		t.Error("expected non-empty file content")
 	}
 }
+
+func TestServerPeople(t *testing.T) {
+	srv := httptest.NewServer(newMockHandler())
+	defer srv.Close()
+
+	client, err := azdevops.NewClient("org", "proj", "pat")
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	client.SetBaseURL(srv.URL)
+
+	found, err := client.SearchIdentities("ma")
+	if err != nil {
+		t.Fatalf("SearchIdentities: %v", err)
+	}
+	if len(found) != 1 || found[0].DisplayName != "Maria Santos" {
+		t.Fatalf("SearchIdentities(ma) = %+v, want Maria Santos", found)
+	}
+
+	resolved, err := client.GetIdentities([]string{found[0].LocalID, "unknown"})
+	if err != nil {
+		t.Fatalf("GetIdentities: %v", err)
+	}
+	if len(resolved) != 1 || resolved[0].ProviderDisplayName != "Maria Santos" {
+		t.Errorf("GetIdentities = %+v, want Maria Santos", resolved)
+	}
+}
+
+func TestServerCreateWorkItem(t *testing.T) {
+	srv := httptest.NewServer(newMockHandler())
+	defer srv.Close()
+
+	body := strings.NewReader(`[{"op": "add", "path": "/fields/System.Title", "value": "New bug"}]`)
+	resp, err := http.Post(srv.URL+"/wit/workitems/$Bug?api-version=7.1", "application/json-patch+json", body)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var result azdevops.WorkItem
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	if result.ID == 0 || result.Fields.Title != "New bug" || result.Fields.WorkItemType != "Bug" {
+		t.Errorf("unexpected created work item: %+v", result)
+	}
+}
+
+func TestServerEditWorkItem(t *testing.T) {
+	srv := httptest.NewServer(newMockHandler())
+	defer srv.Close()
+
+	client, err := azdevops.NewClient("org", "proj", "pat")
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	client.SetBaseURL(srv.URL)
+
+	updated, err := client.UpdateWorkItem(5001, []azdevops.PatchOperation{
+		{Op: "test", Path: "/rev", Value: 3},
+		{Op: "add", Path: azdevops.FieldPath(azdevops.FieldTitle), Value: "Renamed"},
+		{Op: "add", Path: azdevops.FieldPath(azdevops.FieldAssignedTo), Value: "Maria Santos <maria.santos@contoso.com>"},
+		{Op: "add", Path: azdevops.FieldPath(azdevops.FieldDescription), Value: "<p>Edited</p>"},
+	})
+	if err != nil {
+		t.Fatalf("UpdateWorkItem: %v", err)
+	}
+	if updated.Fields.Title != "Renamed" || updated.Rev != 4 || updated.Fields.Description != "<p>Edited</p>" ||
+		updated.Fields.AssignedTo == nil || updated.Fields.AssignedTo.DisplayName != "Maria Santos" {
+		t.Errorf("unexpected updated work item: %+v", updated)
+	}
+
+	iterations, err := client.GetClassificationPaths("Iterations")
+	if err != nil {
+		t.Fatalf("GetClassificationPaths: %v", err)
+	}
+	if len(iterations) < 2 || iterations[1] != `Nexus Platform\Backlog` {
+		t.Errorf("iterations = %v", iterations)
+	}
+
+	members, err := client.GetTeamMembers()
+	if err != nil {
+		t.Fatalf("GetTeamMembers: %v", err)
+	}
+	if len(members) != len(team) {
+		t.Errorf("got %d team members, want %d", len(members), len(team))
+	}
+}
+
+func TestServerWorkItemLinks(t *testing.T) {
+	srv := httptest.NewServer(newMockHandler())
+	defer srv.Close()
+
+	mc, err := azdevops.NewMultiClient("org", []string{"proj"}, "pat", nil)
+	if err != nil {
+		t.Fatalf("NewMultiClient: %v", err)
+	}
+	mc.ClientFor("proj").SetBaseURL(srv.URL)
+	adapter := azdevops.NewAdapter(mc)
+
+	links, err := adapter.GetWorkItemLinks("proj", 5002)
+	if err != nil {
+		t.Fatalf("GetWorkItemLinks: %v", err)
+	}
+	if len(links) != 1 || links[0].Type != provider.LinkChild || links[0].Item.Identity.ID != "5003" {
+		t.Errorf("links of 5002 = %+v", links)
+	}
+
+	links, err = adapter.GetWorkItemLinks("proj", 5003)
+	if err != nil {
+		t.Fatalf("GetWorkItemLinks: %v", err)
+	}
+	if len(links) != 1 || links[0].Type != provider.LinkParent || links[0].Item.Identity.ID != "5002" {
+		t.Errorf("links of 5003 = %+v", links)
+	}
+}
+
+func TestServerWorkItemHistory(t *testing.T) {
+	srv := httptest.NewServer(newMockHandler())
+	defer srv.Close()
+
+	mc, err := azdevops.NewMultiClient("org", []string{"proj"}, "pat", nil)
+	if err != nil {
+		t.Fatalf("NewMultiClient: %v", err)
+	}
+	mc.ClientFor("proj").SetBaseURL(srv.URL)
+
+	history, err := azdevops.NewAdapter(mc).GetWorkItemHistory("proj", 5003)
+	if err != nil {
+		t.Fatalf("GetWorkItemHistory: %v", err)
+	}
+	var changes []string
+	for _, rev := range history {
+		for _, c := range rev.Changes {
+			changes = append(changes, c.Field+": "+c.Old+" → "+c.New)
+		}
+	}
+	for _, want := range []string{"State:  → New", "Links:  → Parent #5002", "Comment:  → <div>Looked into this, notes are in the description.</div>"} {
+		if !slices.Contains(changes, want) {
+			t.Errorf("history of 5003 should contain %q: %v", want, changes)
+		}
+	}
+}
+
+func TestServerWorkItemAttachments(t *testing.T) {
+	srv := httptest.NewServer(newMockHandler())
+	defer srv.Close()
+
+	mc, err := azdevops.NewMultiClient("org", []string{"proj"}, "pat", nil)
+	if err != nil {
+		t.Fatalf("NewMultiClient: %v", err)
+	}
+	mc.ClientFor("proj").SetBaseURL(srv.URL)
+	adapter := azdevops.NewAdapter(mc)
+
+	attachments, err := adapter.GetWorkItemAttachments("proj", 5001)
+	if err != nil {
+		t.Fatalf("GetWorkItemAttachments: %v", err)
+	}
+	if len(attachments) != 2 || attachments[0].Name != "safari-white-screen.png" || attachments[0].UploadedBy == "" {
+		t.Fatalf("attachments of 5001 = %+v", attachments)
+	}
+	content, err := adapter.DownloadWorkItemAttachment("proj", attachments[1])
+	if err != nil || !strings.Contains(string(content), "d3m0-att-0002") {
+		t.Errorf("DownloadWorkItemAttachment = %q, %v", content, err)
+	}
+	if _, err := adapter.AddWorkItemAttachment("proj", 5001, "notes.txt", []byte("hi")); err != nil {
+		t.Errorf("AddWorkItemAttachment: %v", err)
+	}
+}
+
+func TestServerWorkItemQueries(t *testing.T) {
+	srv := httptest.NewServer(newMockHandler())
+	defer srv.Close()
+
+	mc, err := azdevops.NewMultiClient("org", []string{"proj"}, "pat", nil)
+	if err != nil {
+		t.Fatalf("NewMultiClient: %v", err)
+	}
+	mc.ClientFor("proj").SetBaseURL(srv.URL)
+	adapter := azdevops.NewAdapter(mc)
+
+	queries, err := adapter.ListWorkItemQueries("proj")
+	if err != nil {
+		t.Fatalf("ListWorkItemQueries: %v", err)
+	}
+	if len(queries) != 3 {
+		t.Fatalf("queries = %+v, want 3", queries)
+	}
+
+	for _, q := range queries {
+		result, err := adapter.RunWorkItemQuery("proj", q)
+		if err != nil {
+			t.Fatalf("RunWorkItemQuery(%s): %v", q.Name, err)
+		}
+		if len(result.Items) == 0 {
+			t.Errorf("%s returned no work items", q.Name)
+		}
+		if result.Tree != q.Tree {
+			t.Errorf("%s: Tree = %v, want %v", q.Name, result.Tree, q.Tree)
+		}
+		if len(result.Columns) != 4 || result.Items[0].Fields["System.ChangedDate"] == "" {
+			t.Errorf("%s: columns = %+v, fields = %v", q.Name, result.Columns, result.Items[0].Fields)
+		}
+		if q.Tree && result.Items[1].ParentID != workItemID(t, result.Items[0]) {
+			t.Errorf("%s: second item's parent = %d, want the first item", q.Name, result.Items[1].ParentID)
+		}
+	}
+}
+
+func workItemID(t *testing.T, wi provider.WorkItem) int {
+	t.Helper()
+	id, err := strconv.Atoi(wi.Identity.ID)
+	if err != nil {
+		t.Fatalf("work item ID %q: %v", wi.Identity.ID, err)
+	}
+	return id
+}
+
+func TestServerBoards(t *testing.T) {
+	srv := httptest.NewServer(newMockHandler())
+	defer srv.Close()
+
+	mc, err := azdevops.NewMultiClient("org", []string{"proj"}, "pat", nil)
+	if err != nil {
+		t.Fatalf("NewMultiClient: %v", err)
+	}
+	mc.ClientFor("proj").SetBaseURL(srv.URL)
+	adapter := azdevops.NewAdapter(mc)
+
+	boards, err := adapter.GetBoards("proj")
+	if err != nil {
+		t.Fatalf("GetBoards: %v", err)
+	}
+	if len(boards) != 1 || len(boards[0].Columns) != 4 {
+		t.Fatalf("boards = %+v, want one board with four columns", boards)
+	}
+	if err := adapter.MoveWorkItemOnBoard("proj", 5001, "Bug", boards[0], "Closed"); err != nil {
+		t.Errorf("MoveWorkItemOnBoard: %v", err)
+	}
+}
+
+func TestServerSprints(t *testing.T) {
+	srv := httptest.NewServer(newMockHandler())
+	defer srv.Close()
+
+	mc, err := azdevops.NewMultiClient("org", []string{"proj"}, "pat", nil)
+	if err != nil {
+		t.Fatalf("NewMultiClient: %v", err)
+	}
+	mc.ClientFor("proj").SetBaseURL(srv.URL)
+	adapter := azdevops.NewAdapter(mc)
+
+	sprints, err := adapter.GetSprints("proj")
+	if err != nil {
+		t.Fatalf("GetSprints: %v", err)
+	}
+	if len(sprints) != 3 || !sprints[1].Current || sprints[1].Name != "Sprint 24" {
+		t.Fatalf("sprints = %+v, want Sprint 24 current between two others", sprints)
+	}
+	backlog, err := adapter.GetSprintBacklog("proj", sprints[1])
+	if err != nil {
+		t.Fatalf("GetSprintBacklog: %v", err)
+	}
+	if len(backlog.Items) == 0 || backlog.Items[0].Identity.ID != "5001" || backlog.Items[0].RemainingWork == 0 {
+		t.Errorf("items = %+v, want the Sprint 24 items with remaining work", backlog.Items)
+	}
+	if len(backlog.Capacity) != 5 || backlog.DaysLeft == 0 {
+		t.Errorf("capacity = %+v, days left = %d, want five members with capacity", backlog.Capacity, backlog.DaysLeft)
+	}
+}
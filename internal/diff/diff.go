@@ -1,6 +1,7 @@
 package diff
 
 import (
+	"fmt"
 	"strconv"
 	"strings"
 
@@ -23,6 +24,9 @@ type Line struct {
 	Content string
 	OldNum  int // line number in old file (0 if added)
 	NewNum  int // line number in new file (0 if removed)
+	// Changed holds the word-level segments of Content that differ from the
+	// paired line on the other side; nil for context and unpaired lines.
+	Changed []Segment
 }
 
 // Hunk represents a contiguous group of changes with surrounding context
@@ -42,17 +46,70 @@ type FileDiff struct {
 	Hunks      []Hunk
 }
 
+// DefaultContextLines is the number of unchanged lines shown around each
+// change when no explicit context is configured.
+const DefaultContextLines = 5
+
+// DefaultMaxLines is the combined old+new line count above which
+// ComputeDiffWithOptions refuses to diff unless the caller lifts the limit.
+// Generated files and lockfiles beyond this size are rarely worth reviewing
+// line by line, and skipping them keeps the TUI responsive.
+const DefaultMaxLines = 20000
+
+// Options controls how ComputeDiffWithOptions compares two file versions.
+type Options struct {
+	// Context is the number of unchanged lines kept around each change.
+	Context int
+	// IgnoreWhitespace treats lines that differ only in whitespace as equal.
+	IgnoreWhitespace bool
+	// MaxLines is the combined old+new line count above which a
+	// *TooLargeError is returned instead of a diff. Zero or negative
+	// disables the limit.
+	MaxLines int
+}
+
+// DefaultOptions returns the options used by the diff view when the user has
+// not configured anything.
+func DefaultOptions() Options {
+	return Options{Context: DefaultContextLines, MaxLines: DefaultMaxLines}
+}
+
+// TooLargeError is returned by ComputeDiffWithOptions when the inputs exceed
+// Options.MaxLines.
+type TooLargeError struct {
+	Lines int // combined old+new line count
+	Limit int // the MaxLines that was exceeded
+}
+
+func (e *TooLargeError) Error() string {
+	return fmt.Sprintf("diff too large: %d lines exceeds limit of %d", e.Lines, e.Limit)
+}
+
 // ComputeDiff computes the diff between old and new content with the given
-// number of context lines surrounding each change.
+// number of context lines surrounding each change. It never refuses to diff;
+// use ComputeDiffWithOptions for the size guard and whitespace handling.
 func ComputeDiff(oldContent, newContent string, contextLines int) []Hunk {
+	hunks, _ := ComputeDiffWithOptions(oldContent, newContent, Options{Context: contextLines})
+	return hunks
+}
+
+// ComputeDiffWithOptions computes the diff between old and new content using
+// a linear-space Myers algorithm. It returns a *TooLargeError without doing
+// any diff work when the inputs exceed opts.MaxLines.
+func ComputeDiffWithOptions(oldContent, newContent string, opts Options) ([]Hunk, error) {
 	oldLines := splitLines(oldContent)
 	newLines := splitLines(newContent)
 
-	// Compute LCS-based edit script
-	ops := computeEditScript(oldLines, newLines)
+	if total := len(oldLines) + len(newLines); opts.MaxLines > 0 && total > opts.MaxLines {
+		return nil, &TooLargeError{Lines: total, Limit: opts.MaxLines}
+	}
+
+	ops := computeEditScript(oldLines, newLines, opts.IgnoreWhitespace)
 
 	// Group into hunks with context
-	return buildHunks(ops, contextLines)
+	hunks := buildHunks(ops, max(opts.Context, 0))
+	AnnotateIntraLine(hunks)
+	return hunks, nil
 }
 
 // ParseUnifiedDiff parses a unified-diff patch — as returned in the per-file
@@ -111,6 +168,7 @@ func ParseUnifiedDiff(patch string) []Hunk {
 	if cur != nil {
 		hunks = append(hunks, *cur)
 	}
+	AnnotateIntraLine(hunks)
 	return hunks
 }
 
@@ -175,51 +233,13 @@ func splitLines(content string) []string {
 	return lines
 }
 
-// computeEditScript computes the edit operations using LCS
-func computeEditScript(oldLines, newLines []string) []editOp {
-	m := len(oldLines)
-	n := len(newLines)
-
-	// Build LCS table
-	lcs := make([][]int, m+1)
-	for i := range lcs {
-		lcs[i] = make([]int, n+1)
-	}
-	for i := 1; i <= m; i++ {
-		for j := 1; j <= n; j++ {
-			if oldLines[i-1] == newLines[j-1] {
-				lcs[i][j] = lcs[i-1][j-1] + 1
-			} else if lcs[i-1][j] >= lcs[i][j-1] {
-				lcs[i][j] = lcs[i-1][j]
-			} else {
-				lcs[i][j] = lcs[i][j-1]
-			}
-		}
-	}
-
-	// Backtrack to produce edit script
-	var ops []editOp
-	i, j := m, n
-	for i > 0 || j > 0 {
-		if i > 0 && j > 0 && oldLines[i-1] == newLines[j-1] {
-			ops = append(ops, editOp{Type: Context, Content: oldLines[i-1], OldNum: i, NewNum: j})
-			i--
-			j--
-		} else if j > 0 && (i == 0 || lcs[i][j-1] >= lcs[i-1][j]) {
-			ops = append(ops, editOp{Type: Added, Content: newLines[j-1], OldNum: 0, NewNum: j})
-			j--
-		} else {
-			ops = append(ops, editOp{Type: Removed, Content: oldLines[i-1], OldNum: i, NewNum: 0})
-			i--
-		}
-	}
-
-	// Reverse (we built it backwards)
-	for left, right := 0, len(ops)-1; left < right; left, right = left+1, right-1 {
-		ops[left], ops[right] = ops[right], ops[left]
-	}
-
-	return ops
+// computeEditScript computes the line-level edit operations that turn
+// oldLines into newLines.
+func computeEditScript(oldLines, newLines []string, ignoreWhitespace bool) []editOp {
+	a, b := internLines(oldLines, newLines, ignoreWhitespace)
+	s := newMyers(a, b)
+	s.compare(0, len(a), 0, len(b))
+	return s.editScript(oldLines, newLines)
 }
 
 // buildHunks groups edit operations into hunks with surrounding context lines
@@ -404,13 +424,25 @@ func FilterGeneralThreadsP(threads []provider.Thread) []provider.Thread {
 
 // MapThreadsToLinesP maps provider threads to line numbers for a specific file.
 // Returns a map from new-file line number to provider threads at that line.
+// Threads anchored to the old file are excluded; see MapLeftThreadsToLinesP.
 func MapThreadsToLinesP(threads []provider.Thread, filePath string) map[int][]provider.Thread {
+	return mapThreadsToLinesOnSide(threads, filePath, false)
+}
+
+// MapLeftThreadsToLinesP maps provider threads anchored to the old version of
+// a file (comments on deleted or unchanged lines). Returns a map from old-file
+// line number to provider threads at that line.
+func MapLeftThreadsToLinesP(threads []provider.Thread, filePath string) map[int][]provider.Thread {
+	return mapThreadsToLinesOnSide(threads, filePath, true)
+}
+
+func mapThreadsToLinesOnSide(threads []provider.Thread, filePath string, left bool) map[int][]provider.Thread {
 	result := make(map[int][]provider.Thread)
 	for _, thread := range threads {
 		if thread.FilePath != filePath {
 			continue
 		}
-		if thread.Line == 0 {
+		if thread.Line == 0 || thread.Range.IsLeft() != left {
 			continue
 		}
 		result[thread.Line] = append(result[thread.Line], thread)
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/Elpulgo/azdo/internal/azdevops"
+	"github.com/Elpulgo/azdo/internal/provider"
 )
 
 func TestSplitLines(t *testing.T) {
@@ -681,7 +682,7 @@ func TestCountGeneralComments_Empty(t *testing.T) {
 func TestCountGeneralComments_NoGeneral(t *testing.T) {
 	threads := []azdevops.Thread{
 		{
-			ID: 1,
+			ID:            1,
 			ThreadContext: &azdevops.ThreadContext{FilePath: "/src/main.go"},
 			Comments:      []azdevops.Comment{{ID: 1, Content: "Code comment"}},
 		},
@@ -692,3 +693,25 @@ func TestCountGeneralComments_NoGeneral(t *testing.T) {
 		t.Errorf("Expected 0 for no general threads, got %d", count)
 	}
 }
+
+func TestMapThreadsToLinesP_SplitsBySide(t *testing.T) {
+	threads := []provider.Thread{
+		{Identity: provider.Identity{ID: "1"}, FilePath: "a.go", Line: 4, Range: provider.LineAt(provider.SideRight, 4)},
+		{Identity: provider.Identity{ID: "2"}, FilePath: "a.go", Line: 4, Range: provider.LineAt(provider.SideLeft, 4)},
+		{Identity: provider.Identity{ID: "3"}, FilePath: "a.go", Line: 9}, // zero Side is the right side
+		{Identity: provider.Identity{ID: "4"}, FilePath: "b.go", Line: 4, Range: provider.LineAt(provider.SideLeft, 4)},
+	}
+
+	right := MapThreadsToLinesP(threads, "a.go")
+	if len(right[4]) != 1 || right[4][0].Identity.ID != "1" {
+		t.Errorf("right[4] = %+v, want only thread 1", right[4])
+	}
+	if len(right[9]) != 1 {
+		t.Errorf("right[9] = %+v, want thread 3 (zero Side defaults to right)", right[9])
+	}
+
+	left := MapLeftThreadsToLinesP(threads, "a.go")
+	if len(left) != 1 || len(left[4]) != 1 || left[4][0].Identity.ID != "2" {
+		t.Errorf("left = %+v, want only thread 2 at line 4", left)
+	}
+}
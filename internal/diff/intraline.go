@@ -0,0 +1,203 @@
+package diff
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Segment is a changed byte range [Start, End) within a Line's Content,
+// relative to the line it was paired with on the other side of the diff.
+type Segment struct {
+	Start int
+	End   int
+}
+
+// maxIntraLineTokens caps the token count per line for word-level diffing.
+// Minified or generated lines can be thousands of tokens long; the token LCS
+// is quadratic, and emphasis on such lines is noise anyway.
+const maxIntraLineTokens = 400
+
+// minIntraLineSimilarity is the fraction of non-whitespace bytes two paired
+// lines must share before their changed segments are emphasized. Below it the
+// line was effectively rewritten and highlighting "everything" adds nothing.
+const minIntraLineSimilarity = 0.25
+
+// AnnotateIntraLine pairs removed and added lines within each hunk and fills
+// Line.Changed with the word-level segments that differ between each pair.
+// A run of removed lines immediately followed by a run of added lines is a
+// change block; the i-th removed line pairs with the i-th added line, and any
+// surplus lines on either side stay unpaired (Changed nil). Hunks are updated
+// in place.
+func AnnotateIntraLine(hunks []Hunk) {
+	for h := range hunks {
+		lines := hunks[h].Lines
+		for i := 0; i < len(lines); {
+			if lines[i].Type != Removed {
+				i++
+				continue
+			}
+			delStart := i
+			for i < len(lines) && lines[i].Type == Removed {
+				i++
+			}
+			addStart := i
+			for i < len(lines) && lines[i].Type == Added {
+				i++
+			}
+			pairs := min(addStart-delStart, i-addStart)
+			for p := 0; p < pairs; p++ {
+				del, add := &lines[delStart+p], &lines[addStart+p]
+				del.Changed, add.Changed = IntraLineSegments(del.Content, add.Content)
+			}
+		}
+	}
+}
+
+// IntraLineSegments returns the changed segments of oldLine and newLine at
+// word granularity. Both results are nil when the lines are identical, too
+// long to diff, or too dissimilar for emphasis to be useful.
+func IntraLineSegments(oldLine, newLine string) (oldSegs, newSegs []Segment) {
+	if oldLine == newLine {
+		return nil, nil
+	}
+	a, b := tokenize(oldLine), tokenize(newLine)
+	if len(a) > maxIntraLineTokens || len(b) > maxIntraLineTokens {
+		return nil, nil
+	}
+
+	keepA, keepB := lcsTokens(a, b)
+
+	shared := 0
+	for i, t := range a {
+		if keepA[i] && strings.TrimSpace(t.text) != "" {
+			shared += len(t.text)
+		}
+	}
+	longest := max(nonSpaceLen(oldLine), nonSpaceLen(newLine))
+	if longest == 0 || float64(shared)/float64(longest) < minIntraLineSimilarity {
+		return nil, nil
+	}
+
+	return segmentsOf(a, keepA), segmentsOf(b, keepB)
+}
+
+// token is a word, whitespace run, or single punctuation rune with its byte
+// offset in the source line.
+type token struct {
+	text  string
+	start int
+}
+
+// tokenize splits s into identifier/number words, whitespace runs and single
+// punctuation runes, so a renamed identifier is one change rather than a run
+// of character edits.
+func tokenize(s string) []token {
+	var toks []token
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		j := i + size
+		switch {
+		case isWordRune(r):
+			for j < len(s) {
+				r2, s2 := utf8.DecodeRuneInString(s[j:])
+				if !isWordRune(r2) {
+					break
+				}
+				j += s2
+			}
+		case unicode.IsSpace(r):
+			for j < len(s) {
+				r2, s2 := utf8.DecodeRuneInString(s[j:])
+				if !unicode.IsSpace(r2) {
+					break
+				}
+				j += s2
+			}
+		}
+		toks = append(toks, token{text: s[i:j], start: i})
+		i = j
+	}
+	return toks
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func nonSpaceLen(s string) int {
+	n := 0
+	for _, r := range s {
+		if !unicode.IsSpace(r) {
+			n += utf8.RuneLen(r)
+		}
+	}
+	return n
+}
+
+// lcsTokens marks the tokens of a and b that belong to their longest common
+// subsequence. Inputs are bounded by maxIntraLineTokens.
+func lcsTokens(a, b []token) (keepA, keepB []bool) {
+	n, m := len(a), len(b)
+	table := make([][]int, n+1)
+	for i := range table {
+		table[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if a[i].text == b[j].text {
+				table[i][j] = table[i+1][j+1] + 1
+			} else {
+				table[i][j] = max(table[i+1][j], table[i][j+1])
+			}
+		}
+	}
+
+	keepA, keepB = make([]bool, n), make([]bool, m)
+	for i, j := 0, 0; i < n && j < m; {
+		switch {
+		case a[i].text == b[j].text:
+			keepA[i], keepB[j] = true, true
+			i++
+			j++
+		case table[i+1][j] >= table[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	return keepA, keepB
+}
+
+// segmentsOf merges consecutive non-kept tokens into byte segments. A kept
+// whitespace token sandwiched between two changes is absorbed so "foo bar" →
+// "baz qux" reads as one emphasized run instead of two.
+func segmentsOf(toks []token, keep []bool) []Segment {
+	var segs []Segment
+	for i := 0; i < len(toks); i++ {
+		if keep[i] {
+			continue
+		}
+		end := toks[i].start + len(toks[i].text)
+		if n := len(segs); n > 0 && gapIsSpace(toks, keep, segs[n-1].End, toks[i].start) {
+			segs[n-1].End = end
+			continue
+		}
+		segs = append(segs, Segment{Start: toks[i].start, End: end})
+	}
+	return segs
+}
+
+// gapIsSpace reports whether every token between byte offsets from and to is
+// whitespace (or the range is empty).
+func gapIsSpace(toks []token, keep []bool, from, to int) bool {
+	for i, t := range toks {
+		if t.start < from || t.start >= to {
+			continue
+		}
+		if keep[i] && strings.TrimSpace(t.text) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,163 @@
+package diff
+
+import "testing"
+
+// segText renders the segments of s as the substrings they cover.
+func segText(s string, segs []Segment) []string {
+	var out []string
+	for _, sg := range segs {
+		out = append(out, s[sg.Start:sg.End])
+	}
+	return out
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestIntraLineSegments(t *testing.T) {
+	tests := []struct {
+		name    string
+		old     string
+		new     string
+		wantOld []string
+		wantNew []string
+	}{
+		{
+			name:    "renamed identifier",
+			old:     "total := computeSum(items)",
+			new:     "total := computeTotal(items)",
+			wantOld: []string{"computeSum"},
+			wantNew: []string{"computeTotal"},
+		},
+		{
+			name:    "inserted argument",
+			old:     "call(a, b)",
+			new:     "call(a, x, b)",
+			wantOld: nil,
+			wantNew: []string{"x, "},
+		},
+		{
+			name:    "two adjacent words merge across the space",
+			old:     "return foo bar + 1",
+			new:     "return baz qux + 1",
+			wantOld: []string{"foo bar"},
+			wantNew: []string{"baz qux"},
+		},
+		{
+			name:    "separate changes stay separate",
+			old:     "a = b + c",
+			new:     "x = b + y",
+			wantOld: []string{"a", "c"},
+			wantNew: []string{"x", "y"},
+		},
+		{
+			name:    "unicode words",
+			old:     `msg := "héllo wörld"`,
+			new:     `msg := "héllo welt"`,
+			wantOld: []string{"wörld"},
+			wantNew: []string{"welt"},
+		},
+		{
+			name: "identical lines",
+			old:  "same",
+			new:  "same",
+		},
+		{
+			name: "rewritten line is not emphasized",
+			old:  "if err != nil { return err }",
+			new:  "log.Println(value)",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			oldSegs, newSegs := IntraLineSegments(tc.old, tc.new)
+			if got := segText(tc.old, oldSegs); !equalStrings(got, tc.wantOld) {
+				t.Errorf("old segments = %q, want %q", got, tc.wantOld)
+			}
+			if got := segText(tc.new, newSegs); !equalStrings(got, tc.wantNew) {
+				t.Errorf("new segments = %q, want %q", got, tc.wantNew)
+			}
+		})
+	}
+}
+
+func TestIntraLineSegments_TooLongIsSkipped(t *testing.T) {
+	long := ""
+	for i := 0; i <= maxIntraLineTokens; i++ {
+		long += "a "
+	}
+	oldSegs, newSegs := IntraLineSegments(long, long+"b")
+	if oldSegs != nil || newSegs != nil {
+		t.Errorf("got %v / %v, want nil for lines over the token cap", oldSegs, newSegs)
+	}
+}
+
+func TestAnnotateIntraLine_PairsWithinChangeBlocks(t *testing.T) {
+	hunks := []Hunk{{Lines: []Line{
+		{Type: Context, Content: "start"},
+		{Type: Removed, Content: "x := oldName"},
+		{Type: Removed, Content: "y := 2"},
+		{Type: Removed, Content: "z := 3"},
+		{Type: Added, Content: "x := newName"},
+		{Type: Added, Content: "y := 20"},
+		{Type: Context, Content: "middle"},
+		{Type: Added, Content: "lonely := true"},
+	}}}
+	AnnotateIntraLine(hunks)
+	l := hunks[0].Lines
+
+	if got := segText(l[1].Content, l[1].Changed); !equalStrings(got, []string{"oldName"}) {
+		t.Errorf("removed[0] = %q, want [oldName]", got)
+	}
+	if got := segText(l[4].Content, l[4].Changed); !equalStrings(got, []string{"newName"}) {
+		t.Errorf("added[0] = %q, want [newName]", got)
+	}
+	if got := segText(l[5].Content, l[5].Changed); !equalStrings(got, []string{"20"}) {
+		t.Errorf("added[1] = %q, want [20]", got)
+	}
+	// Surplus removed line, context lines and an unpaired addition stay nil.
+	for _, i := range []int{0, 3, 6, 7} {
+		if l[i].Changed != nil {
+			t.Errorf("line %d (%q) Changed = %v, want nil", i, l[i].Content, l[i].Changed)
+		}
+	}
+}
+
+func TestComputeDiff_AnnotatesIntraLine(t *testing.T) {
+	hunks := ComputeDiff("a\nvalue := 1\nb\n", "a\nvalue := 2\nb\n", 1)
+	var removed, added Line
+	for _, l := range hunks[0].Lines {
+		switch l.Type {
+		case Removed:
+			removed = l
+		case Added:
+			added = l
+		}
+	}
+	if got := segText(removed.Content, removed.Changed); !equalStrings(got, []string{"1"}) {
+		t.Errorf("removed segments = %q, want [1]", got)
+	}
+	if got := segText(added.Content, added.Changed); !equalStrings(got, []string{"2"}) {
+		t.Errorf("added segments = %q, want [2]", got)
+	}
+}
+
+func TestParseUnifiedDiff_AnnotatesIntraLine(t *testing.T) {
+	hunks := ParseUnifiedDiff("@@ -1,1 +1,1 @@\n-fmt.Println(name)\n+fmt.Printf(name)")
+	l := hunks[0].Lines
+	if got := segText(l[0].Content, l[0].Changed); !equalStrings(got, []string{"Println"}) {
+		t.Errorf("removed segments = %q, want [Println]", got)
+	}
+	if got := segText(l[1].Content, l[1].Changed); !equalStrings(got, []string{"Printf"}) {
+		t.Errorf("added segments = %q, want [Printf]", got)
+	}
+}
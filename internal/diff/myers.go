@@ -0,0 +1,207 @@
+package diff
+
+import (
+	"strings"
+	"unicode"
+)
+
+// myers holds the working state for a linear-space Myers diff (Myers 1986,
+// "An O(ND) Difference Algorithm and Its Variations", section 4b). Lines are
+// interned to ints up front so the inner snake loops compare integers rather
+// than strings, and the two diagonal vectors are allocated once and shared by
+// every level of the divide-and-conquer recursion.
+//
+// Memory is O(N+M) regardless of how different the inputs are; time is
+// O((N+M)·D) where D is the edit distance. Callers guard against very large
+// inputs with Options.MaxLines.
+type myers struct {
+	a, b       []int
+	removed    []bool // removed[i] is true when a[i] is not part of the LCS
+	added      []bool // added[j] is true when b[j] is not part of the LCS
+	fd, bd     []int  // furthest-reaching x per diagonal, forward and backward
+	diagOffset int    // added to a diagonal index to address fd/bd
+}
+
+// internLines maps each distinct line to a small int, returning the id
+// sequences for both sides. When ignoreWhitespace is set, lines that differ
+// only in whitespace share an id.
+func internLines(oldLines, newLines []string, ignoreWhitespace bool) ([]int, []int) {
+	ids := make(map[string]int, len(oldLines)+len(newLines))
+	intern := func(lines []string) []int {
+		out := make([]int, len(lines))
+		for i, line := range lines {
+			key := line
+			if ignoreWhitespace {
+				key = stripWhitespace(line)
+			}
+			id, ok := ids[key]
+			if !ok {
+				id = len(ids)
+				ids[key] = id
+			}
+			out[i] = id
+		}
+		return out
+	}
+	return intern(oldLines), intern(newLines)
+}
+
+// stripWhitespace removes every whitespace rune from s.
+func stripWhitespace(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// newMyers prepares the diff state for the id sequences a and b.
+func newMyers(a, b []int) *myers {
+	size := len(a) + len(b) + 3
+	return &myers{
+		a:          a,
+		b:          b,
+		removed:    make([]bool, len(a)),
+		added:      make([]bool, len(b)),
+		fd:         make([]int, size),
+		bd:         make([]int, size),
+		diagOffset: len(b) + 1,
+	}
+}
+
+// compare marks the lines of a[aLo:aHi] and b[bLo:bHi] that are not part of
+// a longest common subsequence.
+func (s *myers) compare(aLo, aHi, bLo, bHi int) {
+	// Strip the common prefix and suffix; they never need to be searched.
+	for aLo < aHi && bLo < bHi && s.a[aLo] == s.b[bLo] {
+		aLo++
+		bLo++
+	}
+	for aLo < aHi && bLo < bHi && s.a[aHi-1] == s.b[bHi-1] {
+		aHi--
+		bHi--
+	}
+
+	switch {
+	case aLo == aHi:
+		for j := bLo; j < bHi; j++ {
+			s.added[j] = true
+		}
+	case bLo == bHi:
+		for i := aLo; i < aHi; i++ {
+			s.removed[i] = true
+		}
+	default:
+		// With the prefix and suffix stripped and both sides non-empty the
+		// edit distance is at least 2, so the midpoint is strictly inside
+		// the box and both halves are smaller than the whole.
+		x, y := s.midpoint(aLo, aHi, bLo, bHi)
+		s.compare(aLo, x, bLo, y)
+		s.compare(x, aHi, y, bHi)
+	}
+}
+
+// midpoint runs the forward and backward searches simultaneously and returns
+// a point on an optimal edit path where they meet. Diagonals are numbered
+// k = x - y in absolute coordinates; out-of-range neighbours are seeded with
+// sentinels so the boundary diagonals never extend past the box.
+func (s *myers) midpoint(aLo, aHi, bLo, bHi int) (int, int) {
+	const maxInt = int(^uint(0) >> 1)
+
+	fd, bd, off := s.fd, s.bd, s.diagOffset
+	dmin, dmax := aLo-bHi, aHi-bLo
+	fmid, bmid := aLo-bLo, aHi-bHi
+	fmin, fmax := fmid, fmid
+	bmin, bmax := bmid, bmid
+	odd := (fmid-bmid)&1 != 0
+
+	fd[off+fmid] = aLo
+	bd[off+bmid] = aHi
+
+	for {
+		// Extend the forward search by one edit.
+		if fmin > dmin {
+			fmin--
+			fd[off+fmin-1] = -1
+		} else {
+			fmin++
+		}
+		if fmax < dmax {
+			fmax++
+			fd[off+fmax+1] = -1
+		} else {
+			fmax--
+		}
+		for k := fmax; k >= fmin; k -= 2 {
+			lo, hi := fd[off+k-1], fd[off+k+1]
+			x := lo + 1
+			if lo < hi {
+				x = hi
+			}
+			y := x - k
+			for x < aHi && y < bHi && s.a[x] == s.b[y] {
+				x++
+				y++
+			}
+			fd[off+k] = x
+			if odd && bmin <= k && k <= bmax && bd[off+k] <= x {
+				return x, y
+			}
+		}
+
+		// Extend the backward search by one edit.
+		if bmin > dmin {
+			bmin--
+			bd[off+bmin-1] = maxInt
+		} else {
+			bmin++
+		}
+		if bmax < dmax {
+			bmax++
+			bd[off+bmax+1] = maxInt
+		} else {
+			bmax--
+		}
+		for k := bmax; k >= bmin; k -= 2 {
+			lo, hi := bd[off+k-1], bd[off+k+1]
+			x := hi - 1
+			if lo < hi {
+				x = lo
+			}
+			y := x - k
+			for x > aLo && y > bLo && s.a[x-1] == s.b[y-1] {
+				x--
+				y--
+			}
+			bd[off+k] = x
+			if !odd && fmin <= k && k <= fmax && x <= fd[off+k] {
+				return x, y
+			}
+		}
+	}
+}
+
+// editScript walks the removed/added marks in order and emits one editOp per
+// line. Within each changed block removals precede additions, matching the
+// layout of a unified diff. Context lines take their content from the new
+// side so ignore-whitespace diffs show the current formatting.
+func (s *myers) editScript(oldLines, newLines []string) []editOp {
+	ops := make([]editOp, 0, len(oldLines)+len(newLines))
+	i, j := 0, 0
+	for i < len(oldLines) || j < len(newLines) {
+		switch {
+		case i < len(oldLines) && s.removed[i]:
+			ops = append(ops, editOp{Type: Removed, Content: oldLines[i], OldNum: i + 1})
+			i++
+		case j < len(newLines) && s.added[j]:
+			ops = append(ops, editOp{Type: Added, Content: newLines[j], NewNum: j + 1})
+			j++
+		default:
+			ops = append(ops, editOp{Type: Context, Content: newLines[j], OldNum: i + 1, NewNum: j + 1})
+			i++
+			j++
+		}
+	}
+	return ops
+}
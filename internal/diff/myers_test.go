@@ -0,0 +1,211 @@
+package diff
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// lcsLength is the textbook O(N·M) LCS length, used as an oracle for the
+// Myers implementation on small inputs.
+func lcsLength(a, b []string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			switch {
+			case a[i-1] == b[j-1]:
+				cur[j] = prev[j-1] + 1
+			case prev[j] >= cur[j-1]:
+				cur[j] = prev[j]
+			default:
+				cur[j] = cur[j-1]
+			}
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+// applyScript checks that ops is a valid edit script from oldLines to
+// newLines and returns the number of context (kept) lines.
+func applyScript(t *testing.T, ops []editOp, oldLines, newLines []string) int {
+	t.Helper()
+	var gotOld, gotNew []string
+	kept := 0
+	for _, op := range ops {
+		switch op.Type {
+		case Context:
+			if oldLines[op.OldNum-1] != newLines[op.NewNum-1] {
+				t.Fatalf("context op pairs %q with %q", oldLines[op.OldNum-1], newLines[op.NewNum-1])
+			}
+			gotOld = append(gotOld, oldLines[op.OldNum-1])
+			gotNew = append(gotNew, newLines[op.NewNum-1])
+			kept++
+		case Removed:
+			gotOld = append(gotOld, op.Content)
+		case Added:
+			gotNew = append(gotNew, op.Content)
+		}
+	}
+	if strings.Join(gotOld, "\n") != strings.Join(oldLines, "\n") {
+		t.Fatalf("script does not reproduce old side")
+	}
+	if strings.Join(gotNew, "\n") != strings.Join(newLines, "\n") {
+		t.Fatalf("script does not reproduce new side")
+	}
+	return kept
+}
+
+func TestComputeEditScript_MinimalOnRandomInputs(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	alphabet := []string{"a", "b", "c", "d"}
+	randLines := func() []string {
+		n := rng.Intn(30)
+		out := make([]string, n)
+		for i := range out {
+			out[i] = alphabet[rng.Intn(len(alphabet))]
+		}
+		return out
+	}
+
+	for iter := 0; iter < 500; iter++ {
+		oldLines, newLines := randLines(), randLines()
+		ops := computeEditScript(oldLines, newLines, false)
+		kept := applyScript(t, ops, oldLines, newLines)
+		if want := lcsLength(oldLines, newLines); kept != want {
+			t.Fatalf("iteration %d: kept %d lines, LCS is %d\nold=%v\nnew=%v", iter, kept, want, oldLines, newLines)
+		}
+	}
+}
+
+func TestComputeEditScript_RemovalsBeforeAdditions(t *testing.T) {
+	ops := computeEditScript([]string{"x", "old", "y"}, []string{"x", "new", "y"}, false)
+	var types []LineType
+	for _, op := range ops {
+		types = append(types, op.Type)
+	}
+	want := []LineType{Context, Removed, Added, Context}
+	if fmt.Sprint(types) != fmt.Sprint(want) {
+		t.Errorf("op types = %v, want %v", types, want)
+	}
+}
+
+func TestComputeDiffWithOptions_IgnoreWhitespace(t *testing.T) {
+	old := "func f() {\n\treturn 1\n}\n"
+	new := "func f() {\n    return  1\n}\n"
+
+	hunks, err := ComputeDiffWithOptions(old, new, Options{Context: 3})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(hunks) != 1 {
+		t.Fatalf("expected 1 hunk without ignore-whitespace, got %d", len(hunks))
+	}
+
+	hunks, err = ComputeDiffWithOptions(old, new, Options{Context: 3, IgnoreWhitespace: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(hunks) != 0 {
+		t.Fatalf("expected no hunks with ignore-whitespace, got %d", len(hunks))
+	}
+
+	// A real change next to a whitespace-only one: the context line shows
+	// the new formatting.
+	hunks, _ = ComputeDiffWithOptions(old, "func f() {\n    return  1\n}\nextra\n", Options{Context: 3, IgnoreWhitespace: true})
+	if len(hunks) != 1 {
+		t.Fatalf("expected 1 hunk, got %d", len(hunks))
+	}
+	if got := hunks[0].Lines[1].Content; got != "    return  1" {
+		t.Errorf("context line = %q, want the new-side formatting", got)
+	}
+}
+
+func TestComputeDiffWithOptions_TooLarge(t *testing.T) {
+	old := strings.Repeat("line\n", 60)
+	new := strings.Repeat("other\n", 50)
+
+	_, err := ComputeDiffWithOptions(old, new, Options{Context: 3, MaxLines: 100})
+	var tooLarge *TooLargeError
+	if !errors.As(err, &tooLarge) {
+		t.Fatalf("expected *TooLargeError, got %v", err)
+	}
+	if tooLarge.Lines != 110 || tooLarge.Limit != 100 {
+		t.Errorf("TooLargeError = %+v, want Lines=110 Limit=100", tooLarge)
+	}
+
+	hunks, err := ComputeDiffWithOptions(old, new, Options{Context: 3})
+	if err != nil {
+		t.Fatalf("MaxLines=0 should disable the limit, got %v", err)
+	}
+	if len(hunks) != 1 {
+		t.Errorf("expected 1 hunk, got %d", len(hunks))
+	}
+}
+
+func TestDefaultOptions(t *testing.T) {
+	opts := DefaultOptions()
+	if opts.Context != DefaultContextLines || opts.MaxLines != DefaultMaxLines || opts.IgnoreWhitespace {
+		t.Errorf("DefaultOptions() = %+v", opts)
+	}
+}
+
+// generateFile builds a deterministic n-line source-like file.
+func generateFile(n int, seed int64) []string {
+	rng := rand.New(rand.NewSource(seed))
+	lines := make([]string, n)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("\tvalue%d := compute(%d, %d)", i, rng.Intn(1000), i%17)
+	}
+	return lines
+}
+
+// scatterEdits changes roughly one line in every stride.
+func scatterEdits(lines []string, stride int) []string {
+	out := make([]string, 0, len(lines))
+	for i, line := range lines {
+		switch i % stride {
+		case 0:
+			out = append(out, line+" // edited")
+		case 1:
+			// dropped
+		default:
+			out = append(out, line)
+		}
+	}
+	return out
+}
+
+func benchmarkComputeDiff(b *testing.B, oldLines, newLines []string) {
+	old := strings.Join(oldLines, "\n") + "\n"
+	new := strings.Join(newLines, "\n") + "\n"
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := ComputeDiffWithOptions(old, new, Options{Context: DefaultContextLines}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkComputeDiff_SmallEdit_1k(b *testing.B) {
+	old := generateFile(1000, 1)
+	benchmarkComputeDiff(b, old, scatterEdits(old, 200))
+}
+
+func BenchmarkComputeDiff_SmallEdit_20k(b *testing.B) {
+	old := generateFile(20000, 1)
+	benchmarkComputeDiff(b, old, scatterEdits(old, 2000))
+}
+
+func BenchmarkComputeDiff_ScatteredEdits_20k(b *testing.B) {
+	old := generateFile(20000, 1)
+	benchmarkComputeDiff(b, old, scatterEdits(old, 25))
+}
+
+func BenchmarkComputeDiff_Rewrite_5k(b *testing.B) {
+	benchmarkComputeDiff(b, generateFile(5000, 1), generateFile(5000, 2))
+}
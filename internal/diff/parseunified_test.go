@@ -56,7 +56,7 @@ func TestParseUnifiedDiff_EditHunkLineNumbers(t *testing.T) {
 	}
 	for i, w := range want {
 		got := h.Lines[i]
-		if got != w {
+		if got.Type != w.Type || got.Content != w.Content || got.OldNum != w.OldNum || got.NewNum != w.NewNum {
 			t.Errorf("line[%d] = %+v, want %+v", i, got, w)
 		}
 	}
@@ -0,0 +1,69 @@
+package diff
+
+import (
+	"strings"
+)
+
+// suggestionInfo is the info string that marks a fenced code block as a
+// suggested change, as used by GitHub and Azure DevOps.
+const suggestionInfo = "suggestion"
+
+// FormatSuggestion renders lines as a ```suggestion block. The fence grows
+// beyond three backticks when a line already contains a fence.
+func FormatSuggestion(lines []string) string {
+	fence := "```"
+	for _, l := range lines {
+		for strings.Contains(l, fence) {
+			fence += "`"
+		}
+	}
+	var sb strings.Builder
+	sb.WriteString(fence + suggestionInfo + "\n")
+	for _, l := range lines {
+		sb.WriteString(l)
+		sb.WriteString("\n")
+	}
+	sb.WriteString(fence)
+	return sb.String()
+}
+
+// ExtractSuggestion finds the first suggestion block in a comment body. It
+// returns the body with the block removed (trimmed), the suggested lines
+// (empty when the suggestion deletes the lines) and whether a block was
+// found. An unterminated block is not a suggestion.
+func ExtractSuggestion(body string) (text string, lines []string, ok bool) {
+	src := strings.Split(strings.ReplaceAll(body, "\r\n", "\n"), "\n")
+	for i, line := range src {
+		fence, info := splitFence(line)
+		if fence == "" || info != suggestionInfo {
+			continue
+		}
+		for j := i + 1; j < len(src); j++ {
+			closing, rest := splitFence(src[j])
+			if len(closing) < len(fence) || rest != "" {
+				continue
+			}
+			remaining := append(append([]string(nil), src[:i]...), src[j+1:]...)
+			return strings.TrimSpace(strings.Join(remaining, "\n")), append([]string{}, src[i+1:j]...), true
+		}
+		return body, nil, false
+	}
+	return body, nil, false
+}
+
+// splitFence returns the backtick fence that opens line (at least three, up
+// to three spaces of indentation) and the trimmed info string after it.
+func splitFence(line string) (fence, info string) {
+	trimmed := strings.TrimLeft(line, " ")
+	if len(line)-len(trimmed) > 3 {
+		return "", ""
+	}
+	n := 0
+	for n < len(trimmed) && trimmed[n] == '`' {
+		n++
+	}
+	if n < 3 {
+		return "", ""
+	}
+	return trimmed[:n], strings.TrimSpace(trimmed[n:])
+}
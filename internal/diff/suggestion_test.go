@@ -0,0 +1,59 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFormatSuggestion_RoundTripsThroughExtract(t *testing.T) {
+	lines := []string{"\tif err != nil {", "\t\treturn err", "\t}"}
+	body := "Handle the error:\n\n" + FormatSuggestion(lines)
+
+	text, got, ok := ExtractSuggestion(body)
+	if !ok {
+		t.Fatal("ExtractSuggestion() found no suggestion")
+	}
+	if text != "Handle the error:" {
+		t.Errorf("text = %q", text)
+	}
+	if strings.Join(got, "\n") != strings.Join(lines, "\n") {
+		t.Errorf("lines = %q, want %q", got, lines)
+	}
+}
+
+func TestFormatSuggestion_LengthensFenceAroundBackticks(t *testing.T) {
+	body := FormatSuggestion([]string{"see ```go"})
+	if !strings.HasPrefix(body, "````suggestion\n") || !strings.HasSuffix(body, "\n````") {
+		t.Errorf("body = %q, want a four-backtick fence", body)
+	}
+	if _, got, ok := ExtractSuggestion(body); !ok || len(got) != 1 || got[0] != "see ```go" {
+		t.Errorf("ExtractSuggestion() = %q, %v", got, ok)
+	}
+}
+
+func TestExtractSuggestion(t *testing.T) {
+	tests := []struct {
+		name  string
+		body  string
+		ok    bool
+		lines []string
+	}{
+		{"plain comment", "looks fine", false, nil},
+		{"other language", "```go\nx := 1\n```", false, nil},
+		{"deletion", "```suggestion\n```", true, []string{}},
+		{"crlf body", "nit\r\n```suggestion\r\nfoo()\r\n```\r\n", true, []string{"foo()"}},
+		{"unterminated", "```suggestion\nfoo()", false, nil},
+		{"indented fence", "  ```suggestion\nbar\n  ```", true, []string{"bar"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, lines, ok := ExtractSuggestion(tt.body)
+			if ok != tt.ok {
+				t.Fatalf("ok = %v, want %v", ok, tt.ok)
+			}
+			if ok && strings.Join(lines, "\n") != strings.Join(tt.lines, "\n") {
+				t.Errorf("lines = %q, want %q", lines, tt.lines)
+			}
+		})
+	}
+}
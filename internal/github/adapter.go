@@ -183,7 +183,88 @@ func (a *Adapter) GetFileContent(scope, repositoryID string, filePath string, br
 	return c.GetFileContent(filePath, branchName)
 }
 
-// AddPRCodeComment creates an inline code comment on the given file and line.
+// GetFileContentAtCommit returns the raw decoded file content at the given
+// commit; the Contents API accepts a commit SHA as its ref.
+// scope routes to the correct per-repo Client.
+// repositoryID is ignored (see Adapter doc).
+func (a *Adapter) GetFileContentAtCommit(scope, repositoryID string, filePath string, commitID string) (string, error) {
+	if a.mc == nil {
+		return "", fmt.Errorf("no client configured")
+	}
+	c := a.mc.ClientFor(scope)
+	if c == nil {
+		return "", fmt.Errorf("no client for scope %q", scope)
+	}
+	return c.GetFileContent(filePath, commitID)
+}
+
+// GetPRCommits returns the commits of the given pull request, oldest first.
+// repositoryID is ignored (see Adapter doc).
+func (a *Adapter) GetPRCommits(scope, repositoryID string, pullRequestID int) ([]provider.Commit, error) {
+	if a.mc == nil {
+		return nil, fmt.Errorf("no client configured")
+	}
+	c := a.mc.ClientFor(scope)
+	if c == nil {
+		return nil, fmt.Errorf("no client for scope %q", scope)
+	}
+	commits, err := c.GetPRCommits(pullRequestID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]provider.Commit, len(commits))
+	for i, commit := range commits {
+		result[i] = MapCommit(commit)
+	}
+	return result, nil
+}
+
+// GetCommitChanges returns the files changed by a single commit, with their
+// patches, and the first parent they are relative to.
+// repositoryID is ignored (see Adapter doc).
+func (a *Adapter) GetCommitChanges(scope, repositoryID string, commitID string) (*provider.CommitChanges, error) {
+	if a.mc == nil {
+		return nil, fmt.Errorf("no client configured")
+	}
+	c := a.mc.ClientFor(scope)
+	if c == nil {
+		return nil, fmt.Errorf("no client for scope %q", scope)
+	}
+	commit, err := c.GetCommit(commitID)
+	if err != nil {
+		return nil, err
+	}
+	result := &provider.CommitChanges{}
+	if len(commit.Parents) > 0 {
+		result.ParentID = commit.Parents[0].SHA
+	}
+	for i, f := range commit.Files {
+		result.Changes = append(result.Changes, MapPRFile(f, i+1))
+	}
+	return result, nil
+}
+
+// GetPRMergeability returns the pull request's mergeable_state. GitHub's
+// REST API does not list the conflicting files, so Conflicts is always nil.
+// scope routes to the correct per-repo Client.
+// repositoryID is ignored (see Adapter doc).
+func (a *Adapter) GetPRMergeability(scope, repositoryID string, pullRequestID int) (*provider.Mergeability, error) {
+	if a.mc == nil {
+		return nil, fmt.Errorf("no client configured")
+	}
+	c := a.mc.ClientFor(scope)
+	if c == nil {
+		return nil, fmt.Errorf("no client for scope %q", scope)
+	}
+	pr, err := c.GetPullRequest(pullRequestID)
+	if err != nil {
+		return nil, err
+	}
+	return &provider.Mergeability{Status: MapMergeStatus(pr.MergeableState)}, nil
+}
+
+// AddPRCodeComment creates an inline code comment anchored to rng. A left-side
+// range maps to side "LEFT"; multi-line ranges send start_line.
 //
 // The wire ReviewComment returned by the Client is passed to MapReviewThreads
 // as a single-element slice (it is a root comment with InReplyToID==nil) to
@@ -191,7 +272,7 @@ func (a *Adapter) GetFileContent(scope, repositoryID string, filePath string, br
 // mapping logic as GetPRThreads.
 //
 // repositoryID is ignored (see Adapter doc).
-func (a *Adapter) AddPRCodeComment(scope, repositoryID string, pullRequestID int, filePath string, line int, content string) (*provider.Thread, error) {
+func (a *Adapter) AddPRCodeComment(scope, repositoryID string, pullRequestID int, filePath string, rng provider.LineRange, content string) (*provider.Thread, error) {
 	if a.mc == nil {
 		return nil, fmt.Errorf("no client configured")
 	}
@@ -199,7 +280,11 @@ func (a *Adapter) AddPRCodeComment(scope, repositoryID string, pullRequestID int
 	if c == nil {
 		return nil, fmt.Errorf("no client for scope %q", scope)
 	}
-	wire, err := c.AddPRCodeComment(pullRequestID, filePath, line, content)
+	side := "RIGHT"
+	if rng.IsLeft() {
+		side = "LEFT"
+	}
+	wire, err := c.AddPRCodeComment(pullRequestID, filePath, side, rng.StartLine, rng.EndLine, content)
 	if err != nil {
 		return nil, err
 	}
@@ -310,6 +395,162 @@ func (a *Adapter) UpdateThreadStatus(scope, repositoryID string, pullRequestID i
 	return c.UpdateThreadStatus(pullRequestID, threadID, status)
 }
 
+// EditPRComment replaces the body of a review comment. Threads on GitHub
+// are built from review comments only, so commentID is always a review
+// comment id; threadID is not needed.
+// repositoryID is ignored (see Adapter doc).
+func (a *Adapter) EditPRComment(scope, repositoryID string, pullRequestID, threadID, commentID int, content string) error {
+	if a.mc == nil {
+		return fmt.Errorf("no client configured")
+	}
+	c := a.mc.ClientFor(scope)
+	if c == nil {
+		return fmt.Errorf("no client for scope %q", scope)
+	}
+	return c.EditReviewComment(commentID, content)
+}
+
+// DeletePRComment deletes a review comment.
+// repositoryID is ignored (see Adapter doc).
+func (a *Adapter) DeletePRComment(scope, repositoryID string, pullRequestID, threadID, commentID int) error {
+	if a.mc == nil {
+		return fmt.Errorf("no client configured")
+	}
+	c := a.mc.ClientFor(scope)
+	if c == nil {
+		return fmt.Errorf("no client for scope %q", scope)
+	}
+	return c.DeleteReviewComment(commentID)
+}
+
+// ReactToPRComment adds or removes a reaction on a review comment. Neutral
+// reaction values are GitHub's own reaction names.
+// repositoryID is ignored (see Adapter doc).
+func (a *Adapter) ReactToPRComment(scope, repositoryID string, pullRequestID, threadID, commentID int, reaction provider.Reaction, remove bool) error {
+	if a.mc == nil {
+		return fmt.Errorf("no client configured")
+	}
+	c := a.mc.ClientFor(scope)
+	if c == nil {
+		return fmt.Errorf("no client for scope %q", scope)
+	}
+	return c.ReactToReviewComment(commentID, string(reaction), remove)
+}
+
+// SubmitReview publishes review as a single GitHub review: the drafted
+// comments are attached to a PENDING review, which is then submitted with
+// the verdict and summary so the author gets one notification. When the
+// submit step fails the pending review is deleted so a retry can start over.
+//
+// repositoryID is ignored (see Adapter doc).
+func (a *Adapter) SubmitReview(scope, repositoryID string, pullRequestID int, review provider.Review) error {
+	if a.mc == nil {
+		return fmt.Errorf("no client configured")
+	}
+	c := a.mc.ClientFor(scope)
+	if c == nil {
+		return fmt.Errorf("no client for scope %q", scope)
+	}
+	comments := make([]DraftReviewComment, len(review.Comments))
+	for i, rc := range review.Comments {
+		comments[i] = draftReviewComment(rc)
+	}
+	pending, err := c.CreatePendingReview(pullRequestID, comments)
+	if err != nil {
+		return err
+	}
+	if err := c.SubmitPendingReview(pullRequestID, pending.ID, reviewEvent(review.Verdict), review.Body); err != nil {
+		_ = c.DeletePendingReview(pullRequestID, pending.ID)
+		return err
+	}
+	return nil
+}
+
+// ApplyFileEdit commits edit through the Contents API: the file is read at
+// the branch head, the lines are replaced, and the result is written back
+// with the blob SHA that was read so a concurrent change yields a conflict.
+//
+// repositoryID is ignored (see Adapter doc).
+func (a *Adapter) ApplyFileEdit(scope, repositoryID string, edit provider.FileEdit) error {
+	if a.mc == nil {
+		return fmt.Errorf("no client configured")
+	}
+	c := a.mc.ClientFor(scope)
+	if c == nil {
+		return fmt.Errorf("no client for scope %q", scope)
+	}
+	file, err := c.GetFile(edit.FilePath, edit.Branch)
+	if err != nil {
+		return err
+	}
+	updated, err := edit.Apply(file.Content)
+	if err != nil {
+		return err
+	}
+	_, err = c.UpdateFile(edit.FilePath, edit.Branch, edit.Message, updated, file.SHA)
+	return err
+}
+
+// GetViewedFiles returns the files of the pull request that the user marked
+// as viewed on GitHub. GitHub clears the mark itself when a file changes.
+// scope routes to the correct per-repo Client; repositoryID is ignored.
+func (a *Adapter) GetViewedFiles(scope, repositoryID string, pullRequestID int) ([]string, error) {
+	if a.mc == nil {
+		return nil, fmt.Errorf("no client configured")
+	}
+	c := a.mc.ClientFor(scope)
+	if c == nil {
+		return nil, fmt.Errorf("no client for scope %q", scope)
+	}
+	return c.GetViewedFiles(pullRequestID)
+}
+
+// SetFileViewed marks or unmarks a pull request file as viewed on GitHub.
+// scope routes to the correct per-repo Client; repositoryID is ignored.
+func (a *Adapter) SetFileViewed(scope, repositoryID string, pullRequestID int, filePath string, viewed bool) error {
+	if a.mc == nil {
+		return fmt.Errorf("no client configured")
+	}
+	c := a.mc.ClientFor(scope)
+	if c == nil {
+		return fmt.Errorf("no client for scope %q", scope)
+	}
+	return c.SetFileViewed(pullRequestID, filePath, viewed)
+}
+
+// draftReviewComment converts a neutral drafted comment into the pending
+// review wire shape, using the same side and start_line rules as
+// AddPRCodeComment.
+func draftReviewComment(rc provider.ReviewComment) DraftReviewComment {
+	side := "RIGHT"
+	if rc.Range.IsLeft() {
+		side = "LEFT"
+	}
+	d := DraftReviewComment{
+		Path: rc.FilePath,
+		Body: rc.Content,
+		Line: rc.Range.EndLine,
+		Side: side,
+	}
+	if rc.Range.IsMultiLine() && rc.Range.StartLine > 0 {
+		d.StartLine = rc.Range.StartLine
+		d.StartSide = side
+	}
+	return d
+}
+
+// reviewEvent maps a neutral verdict to a GitHub review event.
+func reviewEvent(v provider.ReviewVerdict) string {
+	switch v {
+	case provider.VerdictApprove:
+		return "APPROVE"
+	case provider.VerdictRequestChanges:
+		return "REQUEST_CHANGES"
+	default:
+		return "COMMENT"
+	}
+}
+
 // --------------------------------------------------------------------------
 // Work-item list surface — delegates to MultiClient (already neutral)
 // --------------------------------------------------------------------------
@@ -364,6 +605,341 @@ func (a *Adapter) UpdateWorkItemState(scope string, id int, state string) error
 	return c.UpdateWorkItemState(id, state)
 }
 
+// GetWorkItemTypes returns the types an issue can be created as; every
+// type but Issue becomes a type label. scope routes to the correct per-repo
+// Client.
+func (a *Adapter) GetWorkItemTypes(scope string) ([]string, error) {
+	if a.mc == nil {
+		return nil, fmt.Errorf("no client configured")
+	}
+	c := a.mc.ClientFor(scope)
+	if c == nil {
+		return nil, fmt.Errorf("no client for scope %q", scope)
+	}
+	return c.GetWorkItemTypes(), nil
+}
+
+// CreateWorkItem opens an issue. Type and priority become labels through the
+// label convention and tags become plain labels; the iteration names a
+// milestone and the parent makes the issue a sub-issue. GitHub has no area
+// paths, so AreaPath is ignored. scope routes to the correct per-repo Client.
+func (a *Adapter) CreateWorkItem(scope string, item provider.NewWorkItem) (*provider.WorkItem, error) {
+	if a.mc == nil {
+		return nil, fmt.Errorf("no client configured")
+	}
+	c := a.mc.ClientFor(scope)
+	if c == nil {
+		return nil, fmt.Errorf("no client for scope %q", scope)
+	}
+
+	milestone := 0
+	if item.IterationPath != "" {
+		n, err := c.FindMilestone(item.IterationPath)
+		if err != nil {
+			return nil, err
+		}
+		milestone = n
+	}
+	var assignees []string
+	if item.AssignedTo != "" {
+		assignees = []string{item.AssignedTo}
+	}
+	labels := append(a.mc.conv.Labels(item.Type, item.Priority), item.Tags...)
+
+	issue, err := c.CreateIssue(item.Title, item.Description, assignees, labels, milestone)
+	if err != nil {
+		return nil, err
+	}
+	if item.ParentID > 0 {
+		if err := c.AddSubIssue(item.ParentID, issue.ID); err != nil {
+			return nil, fmt.Errorf("created issue #%d but could not link it to #%d: %w", issue.Number, item.ParentID, err)
+		}
+	}
+	mapped := MapWorkItem(issue, a.mc.conv, scope, a.mc.DisplayNameFor(scope))
+	return &mapped, nil
+}
+
+// GetWorkItem returns a single issue. scope routes to the correct per-repo
+// Client.
+func (a *Adapter) GetWorkItem(scope string, id int) (*provider.WorkItem, error) {
+	if a.mc == nil {
+		return nil, fmt.Errorf("no client configured")
+	}
+	c := a.mc.ClientFor(scope)
+	if c == nil {
+		return nil, fmt.Errorf("no client for scope %q", scope)
+	}
+	issue, err := c.GetIssue(id)
+	if err != nil {
+		return nil, err
+	}
+	mapped := MapWorkItem(issue, a.mc.conv, scope, a.mc.DisplayNameFor(scope))
+	return &mapped, nil
+}
+
+// UpdateWorkItemFields edits an issue: the title, the assignee, the
+// milestone (for the iteration), and priority and tags as labels through
+// the label convention. Story points, area paths and other fields return
+// ErrFieldUnsupported. GitHub issues have no revisions, so update.Rev is
+// ignored. scope routes to the correct per-repo Client.
+func (a *Adapter) UpdateWorkItemFields(scope string, id int, update provider.WorkItemUpdate) (*provider.WorkItem, error) {
+	if a.mc == nil {
+		return nil, fmt.Errorf("no client configured")
+	}
+	c := a.mc.ClientFor(scope)
+	if c == nil {
+		return nil, fmt.Errorf("no client for scope %q", scope)
+	}
+	changes, err := issueChanges(c, a.mc.conv, update.Fields, func() (Issue, error) { return c.GetIssue(id) })
+	if err != nil {
+		return nil, err
+	}
+	issue, err := c.UpdateIssue(id, changes)
+	if err != nil {
+		return nil, err
+	}
+	mapped := MapWorkItem(issue, a.mc.conv, scope, a.mc.DisplayNameFor(scope))
+	return &mapped, nil
+}
+
+// GetFieldOptions returns the logins of the assignable users or the titles
+// of the open milestones. Story points and area paths have no GitHub
+// equivalent. scope routes to the correct per-repo Client.
+func (a *Adapter) GetFieldOptions(scope string, field provider.WorkItemField) ([]string, error) {
+	if a.mc == nil {
+		return nil, fmt.Errorf("no client configured")
+	}
+	c := a.mc.ClientFor(scope)
+	if c == nil {
+		return nil, fmt.Errorf("no client for scope %q", scope)
+	}
+	switch field {
+	case provider.FieldAssignedTo:
+		users, err := c.ListAssignees()
+		if err != nil {
+			return nil, err
+		}
+		logins := make([]string, len(users))
+		for i, u := range users {
+			logins[i] = u.Login
+		}
+		return logins, nil
+	case provider.FieldIteration:
+		milestones, err := c.ListMilestones()
+		if err != nil {
+			return nil, err
+		}
+		titles := make([]string, len(milestones))
+		for i, m := range milestones {
+			titles[i] = m.Title
+		}
+		return titles, nil
+	case provider.FieldStoryPoints, provider.FieldArea:
+		return nil, provider.ErrFieldUnsupported
+	}
+	return nil, nil
+}
+
+// GetWorkItemLinks returns the issue's parent and sub-issues. GitHub has no
+// related links. scope routes to the correct per-repo Client.
+func (a *Adapter) GetWorkItemLinks(scope string, id int) ([]provider.WorkItemLink, error) {
+	if a.mc == nil {
+		return nil, fmt.Errorf("no client configured")
+	}
+	c := a.mc.ClientFor(scope)
+	if c == nil {
+		return nil, fmt.Errorf("no client for scope %q", scope)
+	}
+	parent, err := c.GetParentIssue(id)
+	if err != nil {
+		return nil, err
+	}
+	children, err := c.ListSubIssues(id)
+	if err != nil {
+		return nil, err
+	}
+
+	display := a.mc.DisplayNameFor(scope)
+	var links []provider.WorkItemLink
+	if parent != nil {
+		links = append(links, provider.WorkItemLink{
+			Type: provider.LinkParent,
+			Item: MapWorkItem(*parent, a.mc.conv, scope, display),
+		})
+	}
+	for _, child := range children {
+		links = append(links, provider.WorkItemLink{
+			Type: provider.LinkChild,
+			Item: MapWorkItem(child, a.mc.conv, scope, display),
+		})
+	}
+	return links, nil
+}
+
+// AddWorkItemLink makes targetID the parent or a sub-issue of the given
+// issue. Related links return ErrLinkUnsupported. scope routes to the
+// correct per-repo Client.
+func (a *Adapter) AddWorkItemLink(scope string, id int, link provider.WorkItemLinkType, targetID int) error {
+	c, parent, child, err := a.subIssueLink(scope, id, link, targetID)
+	if err != nil {
+		return err
+	}
+	return c.AddSubIssue(parent, child)
+}
+
+// RemoveWorkItemLink detaches the sub-issue link between the given issue
+// and targetID. Related links return ErrLinkUnsupported. scope routes to
+// the correct per-repo Client.
+func (a *Adapter) RemoveWorkItemLink(scope string, id int, link provider.WorkItemLinkType, targetID int) error {
+	c, parent, child, err := a.subIssueLink(scope, id, link, targetID)
+	if err != nil {
+		return err
+	}
+	return c.RemoveSubIssue(parent, child)
+}
+
+// subIssueLink resolves a parent or child link between issues id and
+// targetID to the parent's number and the child's issue ID, which the
+// sub-issue endpoints take.
+func (a *Adapter) subIssueLink(scope string, id int, link provider.WorkItemLinkType, targetID int) (*Client, int, int64, error) {
+	if a.mc == nil {
+		return nil, 0, 0, fmt.Errorf("no client configured")
+	}
+	c := a.mc.ClientFor(scope)
+	if c == nil {
+		return nil, 0, 0, fmt.Errorf("no client for scope %q", scope)
+	}
+	parent, child := id, targetID
+	switch link {
+	case provider.LinkChild:
+	case provider.LinkParent:
+		parent, child = targetID, id
+	default:
+		return nil, 0, 0, provider.ErrLinkUnsupported
+	}
+	issue, err := c.GetIssue(child)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	return c, parent, issue.ID, nil
+}
+
+// ListWorkItemQueries returns ErrQueriesUnsupported: GitHub has no saved
+// WIQL queries.
+func (a *Adapter) ListWorkItemQueries(scope string) ([]provider.WorkItemQuery, error) {
+	return nil, provider.ErrQueriesUnsupported
+}
+
+// RunWorkItemQuery returns ErrQueriesUnsupported: GitHub issues cannot be
+// queried with WIQL.
+func (a *Adapter) RunWorkItemQuery(scope string, query provider.WorkItemQuery) (*provider.WorkItemQueryResult, error) {
+	return nil, provider.ErrQueriesUnsupported
+}
+
+// GetSprints returns ErrSprintsUnsupported: GitHub issues have no
+// iterations or team capacity.
+func (a *Adapter) GetSprints(scope string) ([]provider.Sprint, error) {
+	return nil, provider.ErrSprintsUnsupported
+}
+
+// GetSprintBacklog returns ErrSprintsUnsupported: GitHub issues have no
+// iterations or team capacity.
+func (a *Adapter) GetSprintBacklog(scope string, sprint provider.Sprint) (*provider.SprintBacklog, error) {
+	return nil, provider.ErrSprintsUnsupported
+}
+
+// GetBoards returns the repository's issue board, whose columns are the
+// status labels between "Open" and "Closed". scope routes to the correct
+// per-repo Client.
+func (a *Adapter) GetBoards(scope string) ([]provider.Board, error) {
+	if a.mc == nil {
+		return nil, fmt.Errorf("no client configured")
+	}
+	c := a.mc.ClientFor(scope)
+	if c == nil {
+		return nil, fmt.Errorf("no client for scope %q", scope)
+	}
+	board, err := c.GetBoard()
+	if err != nil {
+		return nil, err
+	}
+	return []provider.Board{board}, nil
+}
+
+// MoveWorkItemOnBoard relabels the issue with the column's status label
+// and opens or closes it as the column requires. Issues have no types, so
+// workItemType is ignored. scope routes to the correct per-repo Client.
+func (a *Adapter) MoveWorkItemOnBoard(scope string, id int, workItemType string, board provider.Board, column string) error {
+	if a.mc == nil {
+		return fmt.Errorf("no client configured")
+	}
+	c := a.mc.ClientFor(scope)
+	if c == nil {
+		return fmt.Errorf("no client for scope %q", scope)
+	}
+	for _, col := range board.Columns {
+		if col.Name == column {
+			return c.MoveIssueToColumn(id, col)
+		}
+	}
+	return fmt.Errorf("github: board %q has no column %q", board.Name, column)
+}
+
+// GetWorkItemHistory returns the changes made to the given issue, oldest
+// first, read from its timeline. scope routes to the correct per-repo
+// Client.
+func (a *Adapter) GetWorkItemHistory(scope string, id int) ([]provider.WorkItemRevision, error) {
+	if a.mc == nil {
+		return nil, fmt.Errorf("no client configured")
+	}
+	c := a.mc.ClientFor(scope)
+	if c == nil {
+		return nil, fmt.Errorf("no client for scope %q", scope)
+	}
+	events, err := c.GetIssueTimeline(id)
+	if err != nil {
+		return nil, err
+	}
+	return MapTimelineEvents(events), nil
+}
+
+// GetWorkItemAttachments returns the files linked from the issue's body
+// that were uploaded to GitHub. scope routes to the correct per-repo
+// Client.
+func (a *Adapter) GetWorkItemAttachments(scope string, id int) ([]provider.WorkItemAttachment, error) {
+	if a.mc == nil {
+		return nil, fmt.Errorf("no client configured")
+	}
+	c := a.mc.ClientFor(scope)
+	if c == nil {
+		return nil, fmt.Errorf("no client for scope %q", scope)
+	}
+	issue, err := c.GetIssue(id)
+	if err != nil {
+		return nil, err
+	}
+	return MapIssueAttachments(issue), nil
+}
+
+// DownloadWorkItemAttachment returns the content of a file uploaded to an
+// issue. scope routes to the correct per-repo Client.
+func (a *Adapter) DownloadWorkItemAttachment(scope string, attachment provider.WorkItemAttachment) ([]byte, error) {
+	if a.mc == nil {
+		return nil, fmt.Errorf("no client configured")
+	}
+	c := a.mc.ClientFor(scope)
+	if c == nil {
+		return nil, fmt.Errorf("no client for scope %q", scope)
+	}
+	return c.DownloadAsset(attachment.URL)
+}
+
+// AddWorkItemAttachment returns ErrAttachmentUploadUnsupported: GitHub
+// uploads issue files through the web UI only.
+func (a *Adapter) AddWorkItemAttachment(scope string, id int, name string, content []byte) (*provider.WorkItemAttachment, error) {
+	return nil, provider.ErrAttachmentUploadUnsupported
+}
+
 // GetWorkItemComments returns the comments for the given issue, in the order
 // returned by GitHub (chronological, oldest first).
 // scope routes to the correct per-repo Client.
@@ -407,6 +983,81 @@ func (a *Adapter) AddWorkItemComment(scope string, id int, text string) (*provid
 	return &mapped, nil
 }
 
+// AddWorkItemCommentMarkdown is AddWorkItemComment: issue comments are
+// markdown already.
+func (a *Adapter) AddWorkItemCommentMarkdown(scope string, id int, markdown string) (*provider.WorkItemComment, error) {
+	return a.AddWorkItemComment(scope, id, markdown)
+}
+
+// EditWorkItemComment replaces the body of an issue comment.
+// scope routes to the correct per-repo Client.
+func (a *Adapter) EditWorkItemComment(scope string, id, commentID int, text string) error {
+	if a.mc == nil {
+		return fmt.Errorf("no client configured")
+	}
+	c := a.mc.ClientFor(scope)
+	if c == nil {
+		return fmt.Errorf("no client for scope %q", scope)
+	}
+	return c.EditWorkItemComment(commentID, text)
+}
+
+// DeleteWorkItemComment deletes an issue comment.
+// scope routes to the correct per-repo Client.
+func (a *Adapter) DeleteWorkItemComment(scope string, id, commentID int) error {
+	if a.mc == nil {
+		return fmt.Errorf("no client configured")
+	}
+	c := a.mc.ClientFor(scope)
+	if c == nil {
+		return fmt.Errorf("no client for scope %q", scope)
+	}
+	return c.DeleteWorkItemComment(commentID)
+}
+
+// ReactToWorkItemComment adds or removes a reaction on an issue comment.
+// scope routes to the correct per-repo Client.
+func (a *Adapter) ReactToWorkItemComment(scope string, id, commentID int, reaction provider.Reaction, remove bool) error {
+	if a.mc == nil {
+		return fmt.Errorf("no client configured")
+	}
+	c := a.mc.ClientFor(scope)
+	if c == nil {
+		return fmt.Errorf("no client for scope %q", scope)
+	}
+	return c.ReactToIssueComment(commentID, string(reaction), remove)
+}
+
+// --------------------------------------------------------------------------
+// People
+// --------------------------------------------------------------------------
+
+// SearchPeople returns the repository's assignable users whose login starts
+// with query. scope routes to the correct per-repo Client.
+func (a *Adapter) SearchPeople(scope, query string) ([]provider.Person, error) {
+	if a.mc == nil {
+		return nil, fmt.Errorf("no client configured")
+	}
+	c := a.mc.ClientFor(scope)
+	if c == nil {
+		return nil, fmt.Errorf("no client for scope %q", scope)
+	}
+	users, err := c.SearchAssignees(query)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]provider.Person, len(users))
+	for i, u := range users {
+		result[i] = MapUser(u)
+	}
+	return result, nil
+}
+
+// ResolvePeople returns nil: GitHub mentions are already readable logins.
+func (a *Adapter) ResolvePeople(scope string, ids []string) ([]provider.Person, error) {
+	return nil, nil
+}
+
 // --------------------------------------------------------------------------
 // Pipeline list surface — delegates to MultiClient (already neutral)
 // --------------------------------------------------------------------------
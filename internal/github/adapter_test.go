@@ -1,8 +1,13 @@
 package github
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -237,6 +242,109 @@ func TestAdapter_GetPRIterationChanges_MapsPRFiles(t *testing.T) {
 	}
 }
 
+func TestAdapter_GetPRMergeability_MapsMergeableState(t *testing.T) {
+	var capturedPath string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		capturedPath = r.URL.Path
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"number":7,"mergeable_state":"dirty"}`))
+	}))
+	defer srv.Close()
+
+	mc, _ := NewMultiClient([]string{"owner/repo"}, "tok", DefaultLabelConvention(), nil)
+	mc.ClientFor("owner/repo").SetBaseURL(srv.URL)
+	a := NewAdapter(mc)
+
+	got, err := a.GetPRMergeability("owner/repo", "", 7)
+	if err != nil {
+		t.Fatalf("GetPRMergeability: %v", err)
+	}
+	if capturedPath != "/repos/owner/repo/pulls/7" {
+		t.Errorf("path = %q, want /repos/owner/repo/pulls/7", capturedPath)
+	}
+	if got.Status != provider.MergeStatusConflicts || got.Conflicts != nil {
+		t.Errorf("GetPRMergeability = %+v, want conflicts status without files", got)
+	}
+}
+
+func TestAdapter_GetPRCommits_MapsAuthorAndMessage(t *testing.T) {
+	var capturedPath string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		capturedPath = r.URL.Path
+		w.Write([]byte(`[{"sha":"abc","commit":{"message":"Fix it","author":{"name":"Ann","email":"ann@x.io","date":"2024-03-01T10:00:00Z"}}}]`))
+	}))
+	defer srv.Close()
+
+	mc, _ := NewMultiClient([]string{"owner/repo"}, "tok", DefaultLabelConvention(), nil)
+	mc.ClientFor("owner/repo").SetBaseURL(srv.URL)
+	a := NewAdapter(mc)
+
+	got, err := a.GetPRCommits("owner/repo", "", 7)
+	if err != nil {
+		t.Fatalf("GetPRCommits: %v", err)
+	}
+	if capturedPath != "/repos/owner/repo/pulls/7/commits" {
+		t.Errorf("path = %q, want /repos/owner/repo/pulls/7/commits", capturedPath)
+	}
+	if len(got) != 1 || got[0].ID != "abc" || got[0].Message != "Fix it" || got[0].AuthorName != "Ann" || got[0].Date.IsZero() {
+		t.Errorf("GetPRCommits = %+v", got)
+	}
+}
+
+func TestClient_GetPRCommits_PagesUpToTheCap(t *testing.T) {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page == 0 {
+			page = 1
+		}
+		// GitHub lists 250 commits at most, but keeps linking pages.
+		w.Header().Set("Link", fmt.Sprintf(`<%s/repos/o/r/pulls/7/commits?per_page=100&page=%d>; rel="next"`, srv.URL, page+1))
+		commits := make([]string, 100)
+		for i := range commits {
+			commits[i] = fmt.Sprintf(`{"sha": "c%d"}`, (page-1)*100+i)
+		}
+		w.Write([]byte("[" + strings.Join(commits, ",") + "]"))
+	}))
+	defer srv.Close()
+
+	c := NewClient("o", "r", "tok")
+	c.SetBaseURL(srv.URL)
+
+	commits, err := c.GetPRCommits(7)
+	if err != nil {
+		t.Fatalf("GetPRCommits() error = %v", err)
+	}
+	if len(commits) != 250 || commits[0].SHA != "c0" || commits[249].SHA != "c249" {
+		t.Errorf("got %d commits, %s..%s; want c0..c249", len(commits), commits[0].SHA, commits[len(commits)-1].SHA)
+	}
+}
+
+func TestAdapter_GetCommitChanges_MapsFilesAndParent(t *testing.T) {
+	var capturedPath string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		capturedPath = r.URL.Path
+		w.Write([]byte(`{"sha":"abc","parents":[{"sha":"p1"}],
+			"files":[{"filename":"a.go","status":"modified","patch":"@@ -1 +1 @@\n-a\n+b"}]}`))
+	}))
+	defer srv.Close()
+
+	mc, _ := NewMultiClient([]string{"owner/repo"}, "tok", DefaultLabelConvention(), nil)
+	mc.ClientFor("owner/repo").SetBaseURL(srv.URL)
+	a := NewAdapter(mc)
+
+	got, err := a.GetCommitChanges("owner/repo", "", "abc")
+	if err != nil {
+		t.Fatalf("GetCommitChanges: %v", err)
+	}
+	if capturedPath != "/repos/owner/repo/commits/abc" {
+		t.Errorf("path = %q, want /repos/owner/repo/commits/abc", capturedPath)
+	}
+	if got.ParentID != "p1" || len(got.Changes) != 1 || got.Changes[0].ChangeType != "edit" || got.Changes[0].Patch == "" {
+		t.Errorf("GetCommitChanges = %+v, want parent p1 and a patched edit of a.go", got)
+	}
+}
+
 // ---------------------------------------------------------------------------
 // GetPRThreads — flat comments grouped into threads
 // ---------------------------------------------------------------------------
@@ -569,3 +677,160 @@ func TestAdapter_AddPRComment_SynthesizesThread(t *testing.T) {
 		t.Errorf("thread kind = %v, want KindGitHub", thread.Identity.Kind)
 	}
 }
+
+// ---------------------------------------------------------------------------
+// SubmitReview — pending review, then submit
+// ---------------------------------------------------------------------------
+
+func TestAdapter_SubmitReview_CreatesPendingReviewThenSubmits(t *testing.T) {
+	var calls []string
+	var created createReviewBody
+	var submitted submitPendingReviewBody
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		switch r.Method + " " + r.URL.Path {
+		case "GET /repos/owner/repo/pulls/7":
+			w.Write([]byte(`{"number": 7, "head": {"ref": "f", "sha": "abc123"}}`))
+		case "POST /repos/owner/repo/pulls/7/reviews":
+			_ = json.NewDecoder(r.Body).Decode(&created)
+			w.Write([]byte(`{"id": 88, "state": "PENDING"}`))
+		case "POST /repos/owner/repo/pulls/7/reviews/88/events":
+			_ = json.NewDecoder(r.Body).Decode(&submitted)
+			w.Write([]byte(`{"id": 88, "state": "CHANGES_REQUESTED"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	mc, _ := NewMultiClient([]string{"owner/repo"}, "tok", DefaultLabelConvention(), nil)
+	mc.ClientFor("owner/repo").SetBaseURL(srv.URL)
+	a := NewAdapter(mc)
+
+	review := provider.Review{
+		Verdict: provider.VerdictRequestChanges,
+		Body:    "Please address these",
+		Comments: []provider.ReviewComment{
+			{FilePath: "main.go", Range: provider.LineRange{Side: provider.SideRight, StartLine: 3, EndLine: 5}, Content: "split this"},
+			{FilePath: "old.go", Range: provider.LineAt(provider.SideLeft, 9), Content: "why removed?"},
+		},
+	}
+	if err := a.SubmitReview("owner/repo", "", 7, review); err != nil {
+		t.Fatalf("SubmitReview() error = %v", err)
+	}
+
+	if len(calls) != 3 {
+		t.Fatalf("calls = %v, want GET PR, create review, submit", calls)
+	}
+	if created.CommitID != "abc123" {
+		t.Errorf("commit_id = %q, want abc123", created.CommitID)
+	}
+	want := []DraftReviewComment{
+		{Path: "main.go", Body: "split this", Line: 5, Side: "RIGHT", StartLine: 3, StartSide: "RIGHT"},
+		{Path: "old.go", Body: "why removed?", Line: 9, Side: "LEFT"},
+	}
+	if len(created.Comments) != len(want) {
+		t.Fatalf("comments = %+v, want %+v", created.Comments, want)
+	}
+	for i := range want {
+		if created.Comments[i] != want[i] {
+			t.Errorf("comments[%d] = %+v, want %+v", i, created.Comments[i], want[i])
+		}
+	}
+	if submitted.Event != "REQUEST_CHANGES" || submitted.Body != "Please address these" {
+		t.Errorf("submitted = %+v, want REQUEST_CHANGES with the summary", submitted)
+	}
+}
+
+func TestAdapter_SubmitReview_DeletesPendingReviewWhenSubmitFails(t *testing.T) {
+	var deleted bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /repos/owner/repo/pulls/7":
+			w.Write([]byte(`{"number": 7, "head": {"ref": "f", "sha": "abc123"}}`))
+		case "POST /repos/owner/repo/pulls/7/reviews":
+			w.Write([]byte(`{"id": 88, "state": "PENDING"}`))
+		case "POST /repos/owner/repo/pulls/7/reviews/88/events":
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			w.Write([]byte(`{"message": "Validation Failed"}`))
+		case "DELETE /repos/owner/repo/pulls/7/reviews/88":
+			deleted = true
+			w.Write([]byte(`{"id": 88}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	mc, _ := NewMultiClient([]string{"owner/repo"}, "tok", DefaultLabelConvention(), nil)
+	mc.ClientFor("owner/repo").SetBaseURL(srv.URL)
+	a := NewAdapter(mc)
+
+	err := a.SubmitReview("owner/repo", "", 7, provider.Review{Verdict: provider.VerdictApprove})
+	if err == nil {
+		t.Fatal("SubmitReview() error = nil, want the submit failure")
+	}
+	if !deleted {
+		t.Error("pending review was not deleted after the failed submit")
+	}
+}
+
+// ---------------------------------------------------------------------------
+// ApplyFileEdit — read blob, commit via contents API
+// ---------------------------------------------------------------------------
+
+func TestAdapter_ApplyFileEdit_CommitsAgainstBlobSHA(t *testing.T) {
+	var put updateFileBody
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /repos/owner/repo/contents/src/main.go":
+			if ref := r.URL.Query().Get("ref"); ref != "feat" {
+				t.Errorf("ref = %q, want feat", ref)
+			}
+			content := base64.StdEncoding.EncodeToString([]byte("a\nb\nc\n"))
+			w.Write([]byte(`{"content": "` + content + `", "encoding": "base64", "sha": "blob1"}`))
+		case "PUT /repos/owner/repo/contents/src/main.go":
+			_ = json.NewDecoder(r.Body).Decode(&put)
+			w.Write([]byte(`{"commit": {"sha": "c0ffee"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	mc, _ := NewMultiClient([]string{"owner/repo"}, "tok", DefaultLabelConvention(), nil)
+	mc.ClientFor("owner/repo").SetBaseURL(srv.URL)
+	a := NewAdapter(mc)
+
+	edit := provider.FileEdit{
+		Branch: "feat", FilePath: "/src/main.go", StartLine: 2, EndLine: 2,
+		Expected: []string{"b"}, Lines: []string{"B"}, Message: "Apply suggestion",
+	}
+	if err := a.ApplyFileEdit("owner/repo", "", edit); err != nil {
+		t.Fatalf("ApplyFileEdit() error = %v", err)
+	}
+	decoded, _ := base64.StdEncoding.DecodeString(put.Content)
+	if string(decoded) != "a\nB\nc\n" || put.SHA != "blob1" || put.Branch != "feat" || put.Message != "Apply suggestion" {
+		t.Errorf("PUT body = %+v (content %q)", put, decoded)
+	}
+}
+
+func TestAdapter_ApplyFileEdit_StaleLinesDoNotCommit(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PUT" {
+			t.Error("stale edit was committed")
+		}
+		content := base64.StdEncoding.EncodeToString([]byte("a\nchanged\n"))
+		w.Write([]byte(`{"content": "` + content + `", "encoding": "base64", "sha": "blob2"}`))
+	}))
+	defer srv.Close()
+
+	mc, _ := NewMultiClient([]string{"owner/repo"}, "tok", DefaultLabelConvention(), nil)
+	mc.ClientFor("owner/repo").SetBaseURL(srv.URL)
+	a := NewAdapter(mc)
+
+	edit := provider.FileEdit{Branch: "feat", FilePath: "main.go", StartLine: 2, EndLine: 2, Expected: []string{"b"}, Lines: []string{"B"}}
+	if err := a.ApplyFileEdit("owner/repo", "", edit); !errors.Is(err, provider.ErrEditStale) {
+		t.Errorf("ApplyFileEdit() error = %v, want ErrEditStale", err)
+	}
+}
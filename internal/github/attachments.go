@@ -0,0 +1,35 @@
+package github
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// DownloadAsset returns the content of a file uploaded to an issue, such
+// as a user-attachments asset. Assets are fetched over https only, except
+// from the API base URL, which is plain http only in tests and demo mode.
+// The token is only sent to github.com and the API host; GitHub redirects
+// to signed storage URLs that need none.
+func (c *Client) DownloadAsset(assetURL string) ([]byte, error) {
+	u, err := url.Parse(assetURL)
+	base, berr := url.Parse(c.baseURL)
+	onAPI := berr == nil && err == nil && strings.EqualFold(u.Host, base.Host) && u.Scheme == base.Scheme
+	if err != nil || (u.Scheme != "https" && !onAPI) {
+		return nil, fmt.Errorf("github: invalid asset URL %q", assetURL)
+	}
+	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("github: build request: %w", err)
+	}
+	if onAPI || strings.EqualFold(u.Host, "github.com") {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	body, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("github: download asset: %w", err)
+	}
+	return body, nil
+}
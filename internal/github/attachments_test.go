@@ -0,0 +1,67 @@
+package github
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/Elpulgo/azdo/internal/provider"
+)
+
+func TestMapIssueAttachments_NamesAssetLinks(t *testing.T) {
+	issue := Issue{
+		User: User{Login: "ada"},
+		Body: "Crashes on start.\n\n" +
+			`<img width="400" alt="Login screen" src="https://github.com/user-attachments/assets/11-22">` + "\n" +
+			"![image](https://github.com/user-attachments/assets/33-44)\n" +
+			"Logs: [app.log](https://github.com/user-attachments/files/123/app.log)\n" +
+			"Old: ![](https://user-images.githubusercontent.com/1/shot.png) and again https://github.com/user-attachments/files/123/app.log\n" +
+			"Docs: [guide](https://example.com/guide.pdf)",
+	}
+
+	var got []string
+	for _, a := range MapIssueAttachments(issue) {
+		if a.UploadedBy != "ada" {
+			t.Errorf("%s should count as uploaded by the author", a.Name)
+		}
+		got = append(got, a.Name+" "+a.URL)
+	}
+	want := []string{
+		"Login screen https://github.com/user-attachments/assets/11-22",
+		"33-44 https://github.com/user-attachments/assets/33-44",
+		"app.log https://github.com/user-attachments/files/123/app.log",
+		"shot.png https://user-images.githubusercontent.com/1/shot.png",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("attachments = %q\nwant %q", got, want)
+	}
+}
+
+func TestAdapter_Attachments_DownloadAndUpload(t *testing.T) {
+	var auth []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = append(auth, r.Header.Get("Authorization"))
+		w.Write([]byte("png bytes"))
+	}))
+	defer srv.Close()
+
+	mc, _ := NewMultiClient([]string{"o/r"}, "tok", DefaultLabelConvention(), nil)
+	mc.ClientFor("o/r").SetBaseURL(srv.URL)
+	a := NewAdapter(mc)
+
+	body, err := a.DownloadWorkItemAttachment("o/r", provider.WorkItemAttachment{URL: srv.URL + "/assets/1"})
+	if err != nil || string(body) != "png bytes" {
+		t.Fatalf("DownloadWorkItemAttachment() = %q, %v", body, err)
+	}
+	if !reflect.DeepEqual(auth, []string{"Bearer tok"}) {
+		t.Errorf("Authorization = %q", auth)
+	}
+	if _, err := a.DownloadWorkItemAttachment("o/r", provider.WorkItemAttachment{URL: "http://github.com/user-attachments/assets/1"}); err == nil {
+		t.Error("an asset over plain http should be refused")
+	}
+	if _, err := a.AddWorkItemAttachment("o/r", 5, "a.txt", nil); !errors.Is(err, provider.ErrAttachmentUploadUnsupported) {
+		t.Errorf("AddWorkItemAttachment() error = %v", err)
+	}
+}
@@ -0,0 +1,92 @@
+package github
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Elpulgo/azdo/internal/provider"
+)
+
+// StatusLabelPrefix marks the labels that become columns of the issue
+// board, e.g. "status:in progress". Matched case-insensitively.
+const StatusLabelPrefix = "status:"
+
+// issueBoardName is the name of the single board a repository has.
+const issueBoardName = "Issues"
+
+// ListLabels returns the repository's labels, up to issuePerPageCap (100).
+func (c *Client) ListLabels() ([]Label, error) {
+	var labels []Label
+	path := fmt.Sprintf("/repos/%s/%s/labels?per_page=%d", c.owner, c.repo, issuePerPageCap)
+	if err := c.getJSON(path, &labels); err != nil {
+		return nil, fmt.Errorf("github: list labels: %w", err)
+	}
+	return labels, nil
+}
+
+// isStatusLabel reports whether name is a status label.
+func isStatusLabel(name string) bool {
+	return strings.HasPrefix(strings.ToLower(name), StatusLabelPrefix)
+}
+
+// GetBoard returns the repository's issue board. GitHub issues are only
+// open or closed, so the columns between "Open" and "Closed" are the
+// repository's status labels in the order GitHub lists them: open issues
+// carrying one are shown in its column instead of "Open".
+func (c *Client) GetBoard() (provider.Board, error) {
+	labels, err := c.ListLabels()
+	if err != nil {
+		return provider.Board{}, err
+	}
+	columns := []provider.BoardColumn{{Name: "Open", States: map[string]string{"": "open"}}}
+	for _, label := range labels {
+		if !isStatusLabel(label.Name) {
+			continue
+		}
+		name := strings.TrimSpace(label.Name[len(StatusLabelPrefix):])
+		if name == "" {
+			continue
+		}
+		columns = append(columns, provider.BoardColumn{
+			Name:   name,
+			States: map[string]string{"": "open"},
+			Label:  label.Name,
+		})
+	}
+	columns = append(columns, provider.BoardColumn{Name: "Closed", States: map[string]string{"": "closed"}})
+	return provider.Board{ID: issueBoardName, Name: issueBoardName, Columns: columns}, nil
+}
+
+// MoveIssueToColumn moves the issue to column of the issue board: its
+// status labels are replaced by the column's label, if any, and it is
+// closed or reopened as the column requires.
+func (c *Client) MoveIssueToColumn(number int, column provider.BoardColumn) error {
+	state, ok := column.StateFor("")
+	if !ok {
+		return fmt.Errorf("github: column %q has no state", column.Name)
+	}
+	issue, err := c.GetIssue(number)
+	if err != nil {
+		return err
+	}
+
+	labels := []string{}
+	for _, label := range issue.Labels {
+		if !isStatusLabel(label.Name) {
+			labels = append(labels, label.Name)
+		}
+	}
+	if column.Label != "" {
+		labels = append(labels, column.Label)
+	}
+	changes := map[string]any{"labels": labels}
+	if !strings.EqualFold(issue.State, state) {
+		changes["state"] = state
+		changes["state_reason"] = "completed"
+		if state == "open" {
+			changes["state_reason"] = "reopened"
+		}
+	}
+	_, err = c.UpdateIssue(number, changes)
+	return err
+}
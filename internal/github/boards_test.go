@@ -0,0 +1,82 @@
+package github
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestAdapter_GetBoards_StatusLabelColumns(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/o/r/labels" {
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+		w.Write([]byte(`[{"name": "bug"}, {"name": "Status: In progress"}, {"name": "status:review"}, {"name": "status:"}]`))
+	}))
+	defer srv.Close()
+
+	mc, _ := NewMultiClient([]string{"o/r"}, "tok", DefaultLabelConvention(), nil)
+	mc.ClientFor("o/r").SetBaseURL(srv.URL)
+
+	boards, err := NewAdapter(mc).GetBoards("o/r")
+	if err != nil {
+		t.Fatalf("GetBoards() error = %v", err)
+	}
+	if len(boards) != 1 {
+		t.Fatalf("boards = %+v, want one", boards)
+	}
+	var got []string
+	for _, col := range boards[0].Columns {
+		state, _ := col.StateFor("Bug")
+		got = append(got, col.Name+"|"+col.Label+"|"+state)
+	}
+	want := []string{"Open||open", "In progress|Status: In progress|open", "review|status:review|open", "Closed||closed"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("columns = %v\nwant %v", got, want)
+	}
+}
+
+func TestAdapter_MoveWorkItemOnBoard_SwapsStatusLabel(t *testing.T) {
+	var patches []map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/repos/o/r/labels":
+			w.Write([]byte(`[{"name": "status:doing"}, {"name": "status:review"}]`))
+		case r.Method == "GET" && r.URL.Path == "/repos/o/r/issues/5":
+			w.Write([]byte(`{"number": 5, "state": "open", "labels": [{"name": "type:bug"}, {"name": "status:doing"}]}`))
+		case r.Method == "PATCH" && r.URL.Path == "/repos/o/r/issues/5":
+			var patch map[string]any
+			json.NewDecoder(r.Body).Decode(&patch)
+			patches = append(patches, patch)
+			w.Write([]byte(`{"number": 5}`))
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	mc, _ := NewMultiClient([]string{"o/r"}, "tok", DefaultLabelConvention(), nil)
+	mc.ClientFor("o/r").SetBaseURL(srv.URL)
+	a := NewAdapter(mc)
+	boards, err := a.GetBoards("o/r")
+	if err != nil {
+		t.Fatalf("GetBoards() error = %v", err)
+	}
+
+	if err := a.MoveWorkItemOnBoard("o/r", 5, "Bug", boards[0], "review"); err != nil {
+		t.Fatalf("MoveWorkItemOnBoard(review) error = %v", err)
+	}
+	if err := a.MoveWorkItemOnBoard("o/r", 5, "Bug", boards[0], "Closed"); err != nil {
+		t.Fatalf("MoveWorkItemOnBoard(Closed) error = %v", err)
+	}
+	want := []map[string]any{
+		{"labels": []any{"type:bug", "status:review"}},
+		{"labels": []any{"type:bug"}, "state": "closed", "state_reason": "completed"},
+	}
+	if !reflect.DeepEqual(patches, want) {
+		t.Errorf("patches = %v\nwant %v", patches, want)
+	}
+}
@@ -13,6 +13,8 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"regexp"
+	"strings"
 	"time"
 )
 
@@ -38,6 +40,7 @@ type Client struct {
 	baseURL    string
 	token      string
 	httpClient *http.Client
+	login      string // cached authenticated user login
 }
 
 // NewClient creates a GitHub REST API client scoped to owner/repo.
@@ -90,21 +93,27 @@ func (c *Client) newRequest(method, path string, body io.Reader) (*http.Request,
 // body is intentionally not surfaced in the error string to avoid leaking
 // server-side details (mirrors azdevops.formatHTTPError).
 func (c *Client) do(req *http.Request) ([]byte, error) {
+	body, _, err := c.doWithHeader(req)
+	return body, err
+}
+
+// doWithHeader is do, also returning the response headers.
+func (c *Client) doWithHeader(req *http.Request) ([]byte, http.Header, error) {
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("github: request failed: %w", err)
+		return nil, nil, fmt.Errorf("github: request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("github: read response body: %w", err)
+		return nil, nil, fmt.Errorf("github: read response body: %w", err)
 	}
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, newAPIError(resp.StatusCode, resp.Header, body)
+		return nil, nil, newAPIError(resp.StatusCode, resp.Header, body)
 	}
-	return body, nil
+	return body, resp.Header, nil
 }
 
 // get performs an authenticated GET request and returns the raw response body.
@@ -129,6 +138,43 @@ func (c *Client) getJSON(path string, dst any) error {
 	return nil
 }
 
+// nextLinkPattern matches the URL of the next page in a Link header.
+var nextLinkPattern = regexp.MustCompile(`<([^>]+)>;\s*rel="next"`)
+
+// getPages GETs the list at path and the pages its Link header points to
+// after it, until the list ends or limit items (0 for no limit) are read.
+// Only links on the client's base URL are followed, so the token is never
+// sent elsewhere.
+func getPages[T any](c *Client, path string, limit int) ([]T, error) {
+	var all []T
+	for path != "" {
+		req, err := c.newRequest(http.MethodGet, path, nil)
+		if err != nil {
+			return nil, err
+		}
+		body, header, err := c.doWithHeader(req)
+		if err != nil {
+			return nil, err
+		}
+		var page []T
+		if err := json.Unmarshal(body, &page); err != nil {
+			return nil, fmt.Errorf("github: decode response: %w", err)
+		}
+		all = append(all, page...)
+		if limit > 0 && len(all) >= limit {
+			return all[:limit], nil
+		}
+
+		path = ""
+		if m := nextLinkPattern.FindStringSubmatch(header.Get("Link")); m != nil {
+			if next, ok := strings.CutPrefix(m[1], c.baseURL); ok && strings.HasPrefix(next, "/") {
+				path = next
+			}
+		}
+	}
+	return all, nil
+}
+
 // APIError is the typed error returned for every non-2xx GitHub response.
 // Callers recover it with errors.As(err, &apiErr) — mirroring how the codebase
 // already inspects provider.PartialError — to branch on the status code rather
@@ -0,0 +1,62 @@
+package github
+
+import (
+	"fmt"
+	"time"
+)
+
+// CommitAuthor is the git author or committer recorded in a commit.
+type CommitAuthor struct {
+	Name  string    `json:"name"`
+	Email string    `json:"email"`
+	Date  time.Time `json:"date"`
+}
+
+// CommitDetails holds the git-level fields of a commit.
+type CommitDetails struct {
+	Author  CommitAuthor `json:"author"`
+	Message string       `json:"message"`
+}
+
+// CommitParent identifies a parent of a commit.
+type CommitParent struct {
+	SHA string `json:"sha"`
+}
+
+// Commit is a commit as returned by the pulls/{n}/commits and commits/{sha}
+// endpoints. Files is only populated by the latter.
+type Commit struct {
+	SHA     string         `json:"sha"`
+	Commit  CommitDetails  `json:"commit"`
+	Parents []CommitParent `json:"parents"`
+	Files   []PRFile       `json:"files,omitempty"`
+}
+
+// prCommitsCap is the most commits GitHub lists for a pull request.
+const prCommitsCap = 250
+
+// GetPRCommits returns the commits of a pull request, oldest first,
+// following the pages of issuePerPageCap (100) commits up to the
+// prCommitsCap (250) GitHub lists.
+func (c *Client) GetPRCommits(number int) ([]Commit, error) {
+	path := fmt.Sprintf("/repos/%s/%s/pulls/%d/commits?per_page=%d",
+		c.owner, c.repo, number, issuePerPageCap)
+
+	commits, err := getPages[Commit](c, path, prCommitsCap)
+	if err != nil {
+		return nil, fmt.Errorf("github: get PR commits: %w", err)
+	}
+	return commits, nil
+}
+
+// GetCommit returns a single commit with its parents and changed files,
+// each carrying its patch relative to the first parent.
+func (c *Client) GetCommit(sha string) (*Commit, error) {
+	path := fmt.Sprintf("/repos/%s/%s/commits/%s", c.owner, c.repo, sha)
+
+	var commit Commit
+	if err := c.getJSON(path, &commit); err != nil {
+		return nil, fmt.Errorf("github: get commit: %w", err)
+	}
+	return &commit, nil
+}
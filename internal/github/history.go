@@ -0,0 +1,51 @@
+package github
+
+import (
+	"fmt"
+	"time"
+)
+
+// TimelineRename is the title change of a "renamed" timeline event.
+type TimelineRename struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// TimelineSource is the issue or pull request a "cross-referenced"
+// timeline event comes from.
+type TimelineSource struct {
+	Issue *Issue `json:"issue"`
+}
+
+// TimelineEvent is one event of an issue's timeline
+// (GET /repos/{owner}/{repo}/issues/{number}/timeline). Which of the
+// optional members is set depends on Event; comments ("commented") carry
+// their author in User and their text in Body, other events their author
+// in Actor.
+type TimelineEvent struct {
+	Event       string          `json:"event"`
+	Actor       *User           `json:"actor"`
+	User        *User           `json:"user"`
+	CreatedAt   time.Time       `json:"created_at"`
+	Body        string          `json:"body"`
+	Label       *Label          `json:"label"`
+	Assignee    *User           `json:"assignee"`
+	Milestone   *Milestone      `json:"milestone"`
+	Rename      *TimelineRename `json:"rename"`
+	StateReason string          `json:"state_reason"`
+	CommitID    string          `json:"commit_id"`
+	Source      *TimelineSource `json:"source"`
+}
+
+// GetIssueTimeline returns all events of an issue's timeline, oldest
+// first, following the pages of issuePerPageCap (100) events.
+func (c *Client) GetIssueTimeline(number int) ([]TimelineEvent, error) {
+	path := fmt.Sprintf("/repos/%s/%s/issues/%d/timeline?per_page=%d",
+		c.owner, c.repo, number, issuePerPageCap)
+
+	events, err := getPages[TimelineEvent](c, path, 0)
+	if err != nil {
+		return nil, fmt.Errorf("github: get issue timeline: %w", err)
+	}
+	return events, nil
+}
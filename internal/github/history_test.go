@@ -0,0 +1,99 @@
+package github
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Elpulgo/azdo/internal/provider"
+)
+
+func TestAdapter_GetWorkItemHistory_MapsTimelineEvents(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/o/r/issues/5/timeline" {
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+		w.Write([]byte(`[
+			{"event": "labeled", "actor": {"login": "ada"}, "created_at": "2026-10-01T09:00:00Z", "label": {"name": "bug"}},
+			{"event": "subscribed", "actor": {"login": "ada"}, "created_at": "2026-10-01T09:01:00Z"},
+			{"event": "commented", "user": {"login": "bob"}, "created_at": "2026-10-02T10:00:00Z", "body": "On it"},
+			{"event": "renamed", "actor": {"login": "bob"}, "created_at": "2026-10-02T10:05:00Z", "rename": {"from": "Crash", "to": "Crash on start"}},
+			{"event": "cross-referenced", "actor": {"login": "bob"}, "created_at": "2026-10-03T08:00:00Z",
+				"source": {"issue": {"number": 9, "pull_request": {"url": "https://api.github.com/repos/o/r/pulls/9"}}}},
+			{"event": "referenced", "actor": {"login": "bob"}, "created_at": "2026-10-03T08:30:00Z", "commit_id": "abc1234def"},
+			{"event": "closed", "actor": {"login": "ada"}, "created_at": "2026-10-04T12:00:00Z", "state_reason": "not_planned"}
+		]`))
+	}))
+	defer srv.Close()
+
+	mc, _ := NewMultiClient([]string{"o/r"}, "tok", DefaultLabelConvention(), nil)
+	mc.ClientFor("o/r").SetBaseURL(srv.URL)
+
+	history, err := NewAdapter(mc).GetWorkItemHistory("o/r", 5)
+	if err != nil {
+		t.Fatalf("GetWorkItemHistory() error = %v", err)
+	}
+	type entry struct {
+		author string
+		day    int
+		change provider.WorkItemChange
+	}
+	var got []entry
+	for _, rev := range history {
+		if len(rev.Changes) != 1 {
+			t.Fatalf("revision %+v should hold one change", rev)
+		}
+		got = append(got, entry{rev.AuthorLogin, rev.Date.Day(), rev.Changes[0]})
+	}
+	want := []entry{
+		{"ada", 1, provider.WorkItemChange{Kind: provider.ChangeField, Field: "Labels", New: "bug"}},
+		{"bob", 2, provider.WorkItemChange{Kind: provider.ChangeComment, Field: "Comment", New: "On it"}},
+		{"bob", 2, provider.WorkItemChange{Kind: provider.ChangeField, Field: "Title", Old: "Crash", New: "Crash on start"}},
+		{"bob", 3, provider.WorkItemChange{Kind: provider.ChangeLink, Field: "Links", New: "Mentioned in Pull request #9"}},
+		{"bob", 3, provider.WorkItemChange{Kind: provider.ChangeLink, Field: "Links", New: "Commit abc1234"}},
+		{"ada", 4, provider.WorkItemChange{Kind: provider.ChangeField, Field: "State", Old: "open", New: "closed (not planned)"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("history = %+v\nwant %+v", got, want)
+	}
+	if !history[0].Date.Equal(time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("Date = %v", history[0].Date)
+	}
+}
+
+func TestClient_GetIssueTimeline_FollowsNextLinks(t *testing.T) {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("page") {
+		case "":
+			w.Header().Set("Link", `<`+srv.URL+`/repos/o/r/issues/5/timeline?per_page=100&page=2>; rel="next", <`+srv.URL+`/repos/o/r/issues/5/timeline?per_page=100&page=3>; rel="last"`)
+			w.Write([]byte(`[{"event": "labeled"}]`))
+		case "2":
+			w.Header().Set("Link", `<`+srv.URL+`/repos/o/r/issues/5/timeline?per_page=100&page=3>; rel="next"`)
+			w.Write([]byte(`[{"event": "renamed"}]`))
+		case "3":
+			w.Header().Set("Link", `<https://elsewhere.example/page=4>; rel="next"`)
+			w.Write([]byte(`[{"event": "closed"}]`))
+		default:
+			t.Errorf("unexpected %s", r.URL)
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient("o", "r", "tok")
+	c.SetBaseURL(srv.URL)
+
+	events, err := c.GetIssueTimeline(5)
+	if err != nil {
+		t.Fatalf("GetIssueTimeline() error = %v", err)
+	}
+	var got []string
+	for _, e := range events {
+		got = append(got, e.Event)
+	}
+	if want := []string{"labeled", "renamed", "closed"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("events = %v, want %v; links off the API host are not followed", got, want)
+	}
+}
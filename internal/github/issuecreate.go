@@ -0,0 +1,100 @@
+package github
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Elpulgo/azdo/internal/provider"
+)
+
+// creatableItemTypes are the work item types offered when creating an issue,
+// in the order they are listed. Every type but Issue is expressed as a type
+// label of the LabelConvention.
+var creatableItemTypes = []provider.ItemType{
+	provider.ItemTypeIssue,
+	provider.ItemTypeBug,
+	provider.ItemTypeTask,
+	provider.ItemTypeUserStory,
+	provider.ItemTypeFeature,
+	provider.ItemTypeEpic,
+}
+
+// createIssueBody is the JSON body for POST /repos/{owner}/{repo}/issues.
+type createIssueBody struct {
+	Title     string   `json:"title"`
+	Body      string   `json:"body,omitempty"`
+	Assignees []string `json:"assignees,omitempty"`
+	Labels    []string `json:"labels,omitempty"`
+	Milestone int      `json:"milestone,omitempty"`
+}
+
+// addSubIssueBody is the JSON body for POST
+// /repos/{owner}/{repo}/issues/{number}/sub_issues. SubIssueID is the issue
+// ID, not its number.
+type addSubIssueBody struct {
+	SubIssueID int64 `json:"sub_issue_id"`
+}
+
+// GetWorkItemTypes returns the display names of the types an issue can be
+// created as. No HTTP call is made — the set is fixed by the label
+// convention.
+func (c *Client) GetWorkItemTypes() []string {
+	names := make([]string, len(creatableItemTypes))
+	for i, t := range creatableItemTypes {
+		names[i] = itemTypeDisplay(t)
+	}
+	return names
+}
+
+// CreateIssue opens a new issue and returns it as echoed back by GitHub.
+// milestone is the milestone number, or 0 for none.
+func (c *Client) CreateIssue(title, body string, assignees, labels []string, milestone int) (Issue, error) {
+	path := fmt.Sprintf("/repos/%s/%s/issues", c.owner, c.repo)
+	payload := createIssueBody{
+		Title:     title,
+		Body:      body,
+		Assignees: assignees,
+		Labels:    labels,
+		Milestone: milestone,
+	}
+	var created Issue
+	if err := c.doJSON("POST", path, payload, &created); err != nil {
+		return Issue{}, fmt.Errorf("github: create issue: %w", err)
+	}
+	return created, nil
+}
+
+// ListMilestones returns the repository's open milestones, up to
+// issuePerPageCap (100).
+func (c *Client) ListMilestones() ([]Milestone, error) {
+	var milestones []Milestone
+	path := fmt.Sprintf("/repos/%s/%s/milestones?state=open&per_page=%d", c.owner, c.repo, issuePerPageCap)
+	if err := c.getJSON(path, &milestones); err != nil {
+		return nil, fmt.Errorf("github: list milestones: %w", err)
+	}
+	return milestones, nil
+}
+
+// FindMilestone returns the number of the open milestone titled title
+// (case-insensitively), among those ListMilestones returns.
+func (c *Client) FindMilestone(title string) (int, error) {
+	milestones, err := c.ListMilestones()
+	if err != nil {
+		return 0, err
+	}
+	for _, m := range milestones {
+		if strings.EqualFold(m.Title, title) {
+			return m.Number, nil
+		}
+	}
+	return 0, fmt.Errorf("github: no open milestone named %q", title)
+}
+
+// AddSubIssue makes the issue with ID childID a sub-issue of parent.
+func (c *Client) AddSubIssue(parent int, childID int64) error {
+	path := fmt.Sprintf("/repos/%s/%s/issues/%d/sub_issues", c.owner, c.repo, parent)
+	if err := c.doJSON("POST", path, addSubIssueBody{SubIssueID: childID}, nil); err != nil {
+		return fmt.Errorf("github: add sub-issue: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package github
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/Elpulgo/azdo/internal/provider"
+)
+
+func TestAdapter_GetWorkItemTypes_StaticList(t *testing.T) {
+	mc, _ := NewMultiClient([]string{"o/r"}, "tok", DefaultLabelConvention(), nil)
+	types, err := NewAdapter(mc).GetWorkItemTypes("o/r")
+	if err != nil {
+		t.Fatalf("GetWorkItemTypes() error = %v", err)
+	}
+	want := []string{"Issue", "Bug", "Task", "User Story", "Feature", "Epic"}
+	if !reflect.DeepEqual(types, want) {
+		t.Errorf("GetWorkItemTypes() = %v, want %v", types, want)
+	}
+}
+
+func TestAdapter_CreateWorkItem_LabelsMilestoneAndParent(t *testing.T) {
+	var created createIssueBody
+	var subIssue addSubIssueBody
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/repos/o/r/milestones":
+			w.Write([]byte(`[{"title": "Sprint 1", "number": 3}, {"title": "Sprint 2", "number": 4}]`))
+		case r.Method == "POST" && r.URL.Path == "/repos/o/r/issues":
+			json.NewDecoder(r.Body).Decode(&created)
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id": 9001, "number": 42, "title": "Crash on save", "state": "open",
+				"labels": [{"name": "type:bug"}, {"name": "priority:p1"}, {"name": "ui"}]}`))
+		case r.Method == "POST" && r.URL.Path == "/repos/o/r/issues/7/sub_issues":
+			json.NewDecoder(r.Body).Decode(&subIssue)
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{}`))
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	mc, _ := NewMultiClient([]string{"o/r"}, "tok", DefaultLabelConvention(), nil)
+	mc.ClientFor("o/r").SetBaseURL(srv.URL)
+
+	got, err := NewAdapter(mc).CreateWorkItem("o/r", provider.NewWorkItem{
+		Type:          "Bug",
+		Title:         "Crash on save",
+		Description:   "Steps",
+		AssignedTo:    "alice",
+		Priority:      1,
+		IterationPath: "sprint 2",
+		AreaPath:      "ignored",
+		Tags:          []string{"ui"},
+		ParentID:      7,
+	})
+	if err != nil {
+		t.Fatalf("CreateWorkItem() error = %v", err)
+	}
+
+	want := createIssueBody{
+		Title:     "Crash on save",
+		Body:      "Steps",
+		Assignees: []string{"alice"},
+		Labels:    []string{"type:bug", "priority:p1", "ui"},
+		Milestone: 4,
+	}
+	if !reflect.DeepEqual(created, want) {
+		t.Errorf("create body = %+v, want %+v", created, want)
+	}
+	if subIssue.SubIssueID != 9001 {
+		t.Errorf("sub_issue_id = %d, want the issue ID 9001", subIssue.SubIssueID)
+	}
+	if got.Identity.ID != "42" || got.ItemKind != provider.ItemTypeBug || got.Priority != 1 {
+		t.Errorf("CreateWorkItem() = %+v", got)
+	}
+}
+
+func TestAdapter_CreateWorkItem_UnknownMilestone(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("no issue should be created, got %s %s", r.Method, r.URL.Path)
+		}
+		w.Write([]byte(`[]`))
+	}))
+	defer srv.Close()
+
+	mc, _ := NewMultiClient([]string{"o/r"}, "tok", DefaultLabelConvention(), nil)
+	mc.ClientFor("o/r").SetBaseURL(srv.URL)
+
+	_, err := NewAdapter(mc).CreateWorkItem("o/r", provider.NewWorkItem{Title: "x", IterationPath: "Someday"})
+	if err == nil {
+		t.Fatal("CreateWorkItem() should fail for an unknown milestone")
+	}
+}
@@ -0,0 +1,114 @@
+package github
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Elpulgo/azdo/internal/provider"
+)
+
+// GetIssue returns a single issue.
+func (c *Client) GetIssue(number int) (Issue, error) {
+	path := fmt.Sprintf("/repos/%s/%s/issues/%d", c.owner, c.repo, number)
+	var issue Issue
+	if err := c.getJSON(path, &issue); err != nil {
+		return Issue{}, fmt.Errorf("github: get issue: %w", err)
+	}
+	return issue, nil
+}
+
+// UpdateIssue PATCHes the given issue properties (title, assignees, labels,
+// milestone; a nil milestone clears it) and returns the issue as updated.
+func (c *Client) UpdateIssue(number int, changes map[string]any) (Issue, error) {
+	path := fmt.Sprintf("/repos/%s/%s/issues/%d", c.owner, c.repo, number)
+	var updated Issue
+	if err := c.doJSON("PATCH", path, changes, &updated); err != nil {
+		return Issue{}, fmt.Errorf("github: update issue: %w", err)
+	}
+	return updated, nil
+}
+
+// issueChanges translates a neutral field update into issue properties.
+// Priority and tags are both labels, so changing either rewrites the label
+// set: the other labels of the issue are kept from current, which is only
+// read (via getCurrent) when needed.
+func issueChanges(c *Client, conv LabelConvention, fields map[provider.WorkItemField]string, getCurrent func() (Issue, error)) (map[string]any, error) {
+	changes := map[string]any{}
+	relabelPriority, relabelTags := false, false
+	for field, value := range fields {
+		switch field {
+		case provider.FieldTitle:
+			if value == "" {
+				return nil, fmt.Errorf("the title cannot be empty")
+			}
+			changes["title"] = value
+		case provider.FieldAssignedTo:
+			assignees := []string{}
+			if value != "" {
+				assignees = append(assignees, value)
+			}
+			changes["assignees"] = assignees
+		case provider.FieldIteration:
+			if value == "" {
+				changes["milestone"] = nil
+				continue
+			}
+			n, err := c.FindMilestone(value)
+			if err != nil {
+				return nil, err
+			}
+			changes["milestone"] = n
+		case provider.FieldPriority:
+			relabelPriority = true
+		case provider.FieldTags:
+			relabelTags = true
+		case provider.FieldDescription:
+			changes["body"] = value
+		default:
+			return nil, fmt.Errorf("%s: %w", field, provider.ErrFieldUnsupported)
+		}
+	}
+	if !relabelPriority && !relabelTags {
+		return changes, nil
+	}
+
+	priority := 0
+	if value := fields[provider.FieldPriority]; relabelPriority && value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 || n > 4 {
+			return nil, fmt.Errorf("priority must be 1 to 4, got %q", value)
+		}
+		priority = n
+	}
+	current, err := getCurrent()
+	if err != nil {
+		return nil, err
+	}
+	labels := []string{}
+	for _, label := range current.Labels {
+		switch conv.role(label.Name) {
+		case labelPriority:
+			if relabelPriority {
+				continue
+			}
+		case labelTag:
+			if relabelTags {
+				continue
+			}
+		}
+		labels = append(labels, label.Name)
+	}
+	if relabelPriority {
+		labels = append(labels, conv.Labels("", priority)...)
+	}
+	if relabelTags {
+		for _, tag := range strings.Split(fields[provider.FieldTags], ";") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				labels = append(labels, tag)
+			}
+		}
+	}
+	changes["labels"] = labels
+	return changes, nil
+}
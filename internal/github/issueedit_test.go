@@ -0,0 +1,135 @@
+package github
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/Elpulgo/azdo/internal/provider"
+)
+
+func TestAdapter_UpdateWorkItemFields_RelabelsAndSetsMilestone(t *testing.T) {
+	var patch map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/repos/o/r/issues/5":
+			w.Write([]byte(`{"number": 5, "labels": [{"name": "type:bug"}, {"name": "priority:p3"},
+				{"name": "old-tag"}, {"name": "priority:high"}]}`))
+		case r.Method == "GET" && r.URL.Path == "/repos/o/r/milestones":
+			w.Write([]byte(`[{"title": "v2.0", "number": 8}]`))
+		case r.Method == "PATCH" && r.URL.Path == "/repos/o/r/issues/5":
+			json.NewDecoder(r.Body).Decode(&patch)
+			w.Write([]byte(`{"number": 5, "title": "Renamed", "assignee": {"login": "bob"},
+				"labels": [{"name": "type:bug"}, {"name": "priority:p1"}, {"name": "ui"}]}`))
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	mc, _ := NewMultiClient([]string{"o/r"}, "tok", DefaultLabelConvention(), nil)
+	mc.ClientFor("o/r").SetBaseURL(srv.URL)
+
+	got, err := NewAdapter(mc).UpdateWorkItemFields("o/r", 5, provider.WorkItemUpdate{
+		Rev: 9, // ignored: issues have no revisions
+		Fields: map[provider.WorkItemField]string{
+			provider.FieldTitle:       "Renamed",
+			provider.FieldAssignedTo:  "bob",
+			provider.FieldPriority:    "1",
+			provider.FieldTags:        "ui",
+			provider.FieldIteration:   "v2.0",
+			provider.FieldDescription: "Steps:\n\n1. **Open** it",
+		},
+	})
+	if err != nil {
+		t.Fatalf("UpdateWorkItemFields() error = %v", err)
+	}
+
+	want := map[string]any{
+		"title":     "Renamed",
+		"assignees": []any{"bob"},
+		"milestone": float64(8),
+		"body":      "Steps:\n\n1. **Open** it",
+		// The type label stays; the old priority and tag labels (including
+		// the unparseable "priority:high", which shows as a tag) go.
+		"labels": []any{"type:bug", "priority:p1", "ui"},
+	}
+	if !reflect.DeepEqual(patch, want) {
+		t.Errorf("PATCH body = %v, want %v", patch, want)
+	}
+	if got.Title != "Renamed" || got.Priority != 1 || got.AssignedTo != "bob" {
+		t.Errorf("UpdateWorkItemFields() = %+v", got)
+	}
+}
+
+func TestAdapter_UpdateWorkItemFields_ClearsWithoutReadingLabels(t *testing.T) {
+	var patch map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PATCH" {
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&patch)
+		w.Write([]byte(`{"number": 5}`))
+	}))
+	defer srv.Close()
+
+	mc, _ := NewMultiClient([]string{"o/r"}, "tok", DefaultLabelConvention(), nil)
+	mc.ClientFor("o/r").SetBaseURL(srv.URL)
+
+	_, err := NewAdapter(mc).UpdateWorkItemFields("o/r", 5, provider.WorkItemUpdate{
+		Fields: map[provider.WorkItemField]string{
+			provider.FieldAssignedTo: "",
+			provider.FieldIteration:  "",
+		},
+	})
+	if err != nil {
+		t.Fatalf("UpdateWorkItemFields() error = %v", err)
+	}
+	if want := map[string]any{"assignees": []any{}, "milestone": nil}; !reflect.DeepEqual(patch, want) {
+		t.Errorf("PATCH body = %v, want %v", patch, want)
+	}
+}
+
+func TestAdapter_UpdateWorkItemFields_UnsupportedField(t *testing.T) {
+	mc, _ := NewMultiClient([]string{"o/r"}, "tok", DefaultLabelConvention(), nil)
+	a := NewAdapter(mc)
+
+	for _, field := range []provider.WorkItemField{provider.FieldStoryPoints, provider.FieldArea, provider.FieldReproSteps, "Custom.Severity"} {
+		_, err := a.UpdateWorkItemFields("o/r", 5, provider.WorkItemUpdate{
+			Fields: map[provider.WorkItemField]string{field: "x"},
+		})
+		if !errors.Is(err, provider.ErrFieldUnsupported) {
+			t.Errorf("%s: error = %v, want ErrFieldUnsupported", field, err)
+		}
+		if _, err := a.GetFieldOptions("o/r", field); field != "Custom.Severity" && field != provider.FieldReproSteps && !errors.Is(err, provider.ErrFieldUnsupported) {
+			t.Errorf("GetFieldOptions(%s) error = %v, want ErrFieldUnsupported", field, err)
+		}
+	}
+}
+
+func TestAdapter_GetFieldOptions_AssigneesAndMilestones(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/o/r/assignees":
+			w.Write([]byte(`[{"login": "alice"}, {"login": "bob"}]`))
+		case "/repos/o/r/milestones":
+			w.Write([]byte(`[{"title": "v1.0", "number": 1}]`))
+		}
+	}))
+	defer srv.Close()
+
+	mc, _ := NewMultiClient([]string{"o/r"}, "tok", DefaultLabelConvention(), nil)
+	mc.ClientFor("o/r").SetBaseURL(srv.URL)
+	a := NewAdapter(mc)
+
+	if got, err := a.GetFieldOptions("o/r", provider.FieldAssignedTo); err != nil || !reflect.DeepEqual(got, []string{"alice", "bob"}) {
+		t.Errorf("assignee options = %v, %v", got, err)
+	}
+	if got, err := a.GetFieldOptions("o/r", provider.FieldIteration); err != nil || !reflect.DeepEqual(got, []string{"v1.0"}) {
+		t.Errorf("milestone options = %v, %v", got, err)
+	}
+}
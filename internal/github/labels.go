@@ -109,6 +109,57 @@ func (c LabelConvention) Parse(labels []Label) (itemType provider.ItemType, prio
 	return itemType, priority, tags
 }
 
+// Labels is the inverse of Parse: it returns the labels expressing
+// workItemType (a display name such as "User Story") and priority under the
+// convention. Issue, unrecognised types and priorities outside 1–4 yield no
+// label, as do empty prefixes.
+func (c LabelConvention) Labels(workItemType string, priority int) []string {
+	var labels []string
+	if t, ok := mapItemType(strings.ToLower(strings.TrimSpace(workItemType))); ok && t != provider.ItemTypeIssue && c.TypePrefix != "" {
+		labels = append(labels, c.TypePrefix+typeLabelValue(t))
+	}
+	if priority >= 1 && priority <= 4 && c.PriorityPrefix != "" {
+		labels = append(labels, c.PriorityPrefix+"p"+strconv.Itoa(priority))
+	}
+	return labels
+}
+
+// labelRole is what a single label stands for under a LabelConvention.
+type labelRole int
+
+const (
+	labelTag labelRole = iota
+	labelType
+	labelPriority
+)
+
+// role classifies a label on its own the way Parse does: a prefixed label
+// is a type or priority label only when its value maps, anything else is a
+// tag.
+func (c LabelConvention) role(name string) labelRole {
+	lower := strings.ToLower(name)
+	if typePfx := strings.ToLower(c.TypePrefix); typePfx != "" && strings.HasPrefix(lower, typePfx) {
+		if _, ok := mapItemType(strings.TrimSpace(lower[len(typePfx):])); ok {
+			return labelType
+		}
+	}
+	if priPfx := strings.ToLower(c.PriorityPrefix); priPfx != "" && strings.HasPrefix(lower, priPfx) {
+		if parsePriority(strings.TrimSpace(lower[len(priPfx):])) != 0 {
+			return labelPriority
+		}
+	}
+	return labelTag
+}
+
+// typeLabelValue returns the value written after the type prefix for t; Parse
+// maps it back to t.
+func typeLabelValue(t provider.ItemType) string {
+	if t == provider.ItemTypeUserStory {
+		return "story"
+	}
+	return strings.ToLower(itemTypeDisplay(t))
+}
+
 // mapItemType converts a lower-cased label value (after stripping the type:
 // prefix) to a provider.ItemType. The bool reports whether the value was a
 // recognised type; an unrecognised value returns (ItemTypeIssue, false) so the
@@ -393,3 +393,29 @@ func TestDefaultLabelConvention(t *testing.T) {
 		t.Errorf("PriorityPrefix: got %q, want %q", c.PriorityPrefix, "priority:")
 	}
 }
+
+func TestLabelConventionLabels_RoundTripsThroughParse(t *testing.T) {
+	def := DefaultLabelConvention()
+
+	for _, workItemType := range []string{"Bug", "Task", "User Story", "Feature", "Epic"} {
+		got := def.Labels(workItemType, 2)
+		itemType, priority, tags := def.Parse(labels(got...))
+		if itemTypeDisplay(itemType) != workItemType || priority != 2 || tags != "" {
+			t.Errorf("Labels(%q, 2) = %v, parses back to %v/%d/%q", workItemType, got, itemType, priority, tags)
+		}
+	}
+}
+
+func TestLabelConventionLabels_NoLabelForDefaults(t *testing.T) {
+	def := DefaultLabelConvention()
+
+	if got := def.Labels("Issue", 0); len(got) != 0 {
+		t.Errorf("Labels(Issue, 0) = %v, want none", got)
+	}
+	if got := def.Labels("Chore", 7); len(got) != 0 {
+		t.Errorf("Labels(Chore, 7) = %v, want none", got)
+	}
+	if got := (LabelConvention{}).Labels("Bug", 1); len(got) != 0 {
+		t.Errorf("zero convention Labels(Bug, 1) = %v, want none", got)
+	}
+}
@@ -61,6 +61,27 @@ func MapVoteKind(reviewState string) provider.VoteKind {
 	}
 }
 
+// MapMergeStatus translates a GitHub pull request mergeable_state into a
+// neutral provider.MergeStatus.
+//
+// "unstable" (failing non-required checks) and "has_hooks" still merge, so
+// they map to MergeStatusMergeable. "draft", "unknown" and the empty string
+// (list endpoints omit the field) map to MergeStatusUnknown.
+func MapMergeStatus(mergeableState string) provider.MergeStatus {
+	switch strings.ToLower(mergeableState) {
+	case "clean", "unstable", "has_hooks":
+		return provider.MergeStatusMergeable
+	case "dirty":
+		return provider.MergeStatusConflicts
+	case "blocked":
+		return provider.MergeStatusBlocked
+	case "behind":
+		return provider.MergeStatusBehind
+	default:
+		return provider.MergeStatusUnknown
+	}
+}
+
 // MapRunStatus translates a GitHub Actions status+conclusion pair into a
 // neutral provider.RunStatus. Status is checked before conclusion because
 // in-flight statuses (in_progress, queued, waiting) are authoritative
@@ -75,6 +75,33 @@ func TestMapVoteKind(t *testing.T) {
 	}
 }
 
+// --- MergeStatus mapping ---
+
+func TestMapMergeStatus(t *testing.T) {
+	tests := []struct {
+		input string
+		want  provider.MergeStatus
+	}{
+		{input: "clean", want: provider.MergeStatusMergeable},
+		{input: "unstable", want: provider.MergeStatusMergeable},
+		{input: "has_hooks", want: provider.MergeStatusMergeable},
+		{input: "dirty", want: provider.MergeStatusConflicts},
+		{input: "blocked", want: provider.MergeStatusBlocked},
+		{input: "behind", want: provider.MergeStatusBehind},
+		{input: "draft", want: provider.MergeStatusUnknown},
+		{input: "unknown", want: provider.MergeStatusUnknown},
+		{input: "", want: provider.MergeStatusUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := github.MapMergeStatus(tt.input); got != tt.want {
+				t.Errorf("MapMergeStatus(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
 // --- RunStatus mapping ---
 
 func TestMapRunStatus(t *testing.T) {
@@ -2,6 +2,9 @@ package github
 
 import (
 	"fmt"
+	"path"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/Elpulgo/azdo/internal/provider"
@@ -21,8 +24,8 @@ import (
 // When the issue is closed we approximate it with ClosedAt; for open issues it
 // is left as the zero time.Time.
 //
-// ActivatedDate, ReproSteps, and StoryPoints have no GitHub equivalent and are
-// left at their zero values.
+// ActivatedDate, ReproSteps, AcceptanceCriteria and StoryPoints have no GitHub
+// equivalent and are left at their zero values.
 func MapWorkItem(issue Issue, conv LabelConvention, scope, scopeDisplay string) provider.WorkItem {
 	assignedTo := ""
 	if issue.Assignee != nil {
@@ -62,6 +65,7 @@ func MapWorkItem(issue Issue, conv LabelConvention, scope, scopeDisplay string)
 		StateCategory:   stateCategory,
 		ItemKind:        itemKind,
 		AssignedToName:  assignedTo,
+		AssignedTo:      assignedTo,
 		Priority:        priority,
 		ChangedDate:     issue.UpdatedAt,
 		CreatedDate:     issue.CreatedAt,
@@ -96,9 +100,34 @@ func MapWorkItemComment(c IssueComment, scope, scopeDisplay string) provider.Wor
 		Text:        c.Body,
 		AuthorName:  c.User.Login,
 		CreatedDate: c.CreatedAt,
+		Reactions:   mapReactions(c.Reactions),
 	}
 }
 
+// mapReactions converts a reaction rollup into neutral counts, omitting
+// reactions nobody used. Mine is set from r.Mine, which the neutral
+// reaction names share with GitHub's reaction contents.
+func mapReactions(r ReactionRollup) []provider.ReactionCount {
+	counts := []provider.ReactionCount{
+		{Reaction: provider.ReactionLike, Count: r.PlusOne},
+		{Reaction: provider.ReactionDislike, Count: r.MinusOne},
+		{Reaction: provider.ReactionLaugh, Count: r.Laugh},
+		{Reaction: provider.ReactionHooray, Count: r.Hooray},
+		{Reaction: provider.ReactionConfused, Count: r.Confused},
+		{Reaction: provider.ReactionHeart, Count: r.Heart},
+		{Reaction: provider.ReactionRocket, Count: r.Rocket},
+		{Reaction: provider.ReactionEyes, Count: r.Eyes},
+	}
+	var result []provider.ReactionCount
+	for _, rc := range counts {
+		if rc.Count > 0 {
+			rc.Mine = r.Mine[string(rc.Reaction)]
+			result = append(result, rc)
+		}
+	}
+	return result
+}
+
 // itemTypeDisplay derives a human-readable WorkItemType string from the neutral
 // ItemType enum. This string is displayed directly in the work-item detail
 // header (detail.go: fmt.Sprintf("%s | ...", wi.WorkItemType, ...)) and is also
@@ -139,3 +168,151 @@ func derefTime(t *time.Time) time.Time {
 	}
 	return *t
 }
+
+// MapUser maps a GitHub user to a provider.Person. GitHub mentions are the
+// plain "@login" in both markdown and HTML.
+func MapUser(u User) provider.Person {
+	return provider.Person{
+		ID:          u.Login,
+		DisplayName: u.Login,
+		Login:       u.Login,
+		Mention:     "@" + u.Login,
+		HTMLMention: "@" + u.Login,
+	}
+}
+
+// MapTimelineEvents maps the timeline of an issue to its history, one
+// revision per event, oldest first. Label, assignee, milestone, title and
+// state changes become field changes, comments comment changes, and
+// references from other issues and commits link changes. Other events are
+// left out.
+func MapTimelineEvents(events []TimelineEvent) []provider.WorkItemRevision {
+	var revisions []provider.WorkItemRevision
+	for _, e := range events {
+		change, ok := mapTimelineEvent(e)
+		if !ok {
+			continue
+		}
+		author := e.Actor
+		if author == nil {
+			author = e.User
+		}
+		rev := provider.WorkItemRevision{Date: e.CreatedAt, Changes: []provider.WorkItemChange{change}}
+		if author != nil {
+			rev.AuthorName, rev.AuthorLogin = author.Login, author.Login
+		}
+		revisions = append(revisions, rev)
+	}
+	return revisions
+}
+
+// mapTimelineEvent maps a timeline event to the change it made, if it is
+// one the history shows.
+func mapTimelineEvent(e TimelineEvent) (provider.WorkItemChange, bool) {
+	field := func(name, old, new string) (provider.WorkItemChange, bool) {
+		return provider.WorkItemChange{Kind: provider.ChangeField, Field: name, Old: old, New: new}, true
+	}
+	switch e.Event {
+	case "labeled", "unlabeled":
+		if e.Label == nil {
+			return provider.WorkItemChange{}, false
+		}
+		if e.Event == "labeled" {
+			return field("Labels", "", e.Label.Name)
+		}
+		return field("Labels", e.Label.Name, "")
+	case "assigned", "unassigned":
+		if e.Assignee == nil {
+			return provider.WorkItemChange{}, false
+		}
+		if e.Event == "assigned" {
+			return field("Assignees", "", e.Assignee.Login)
+		}
+		return field("Assignees", e.Assignee.Login, "")
+	case "milestoned", "demilestoned":
+		if e.Milestone == nil {
+			return provider.WorkItemChange{}, false
+		}
+		if e.Event == "milestoned" {
+			return field("Milestone", "", e.Milestone.Title)
+		}
+		return field("Milestone", e.Milestone.Title, "")
+	case "renamed":
+		if e.Rename == nil {
+			return provider.WorkItemChange{}, false
+		}
+		return field("Title", e.Rename.From, e.Rename.To)
+	case "closed":
+		closed := "closed"
+		if e.StateReason == "not_planned" {
+			closed = "closed (not planned)"
+		}
+		return field("State", "open", closed)
+	case "reopened":
+		return field("State", "closed", "open")
+	case "commented":
+		return provider.WorkItemChange{Kind: provider.ChangeComment, Field: "Comment", New: e.Body}, true
+	case "cross-referenced":
+		if e.Source == nil || e.Source.Issue == nil {
+			return provider.WorkItemChange{}, false
+		}
+		kind := "Issue"
+		if e.Source.Issue.PullRequest != nil {
+			kind = "Pull request"
+		}
+		return provider.WorkItemChange{Kind: provider.ChangeLink, Field: "Links", New: fmt.Sprintf("Mentioned in %s #%d", kind, e.Source.Issue.Number)}, true
+	case "referenced":
+		if e.CommitID == "" {
+			return provider.WorkItemChange{}, false
+		}
+		return provider.WorkItemChange{Kind: provider.ChangeLink, Field: "Links", New: "Commit " + e.CommitID[:min(7, len(e.CommitID))]}, true
+	}
+	return provider.WorkItemChange{}, false
+}
+
+// assetURLPattern matches the URLs of files uploaded to issues: the
+// user-attachments store, the older per-repository assets and images on
+// user-images.githubusercontent.com.
+var assetURLPattern = regexp.MustCompile(`https://(?:github\.com/(?:user-attachments|[\w.-]+/[\w.-]+)/(?:assets|files)/|(?:private-)?user-images\.githubusercontent\.com/)[^\s()<>"'\]]+`)
+
+// assetNamePatterns capture the text and URL of markdown links and images
+// and the alt text and source of HTML images, which name the files.
+var assetNamePatterns = []*regexp.Regexp{
+	regexp.MustCompile(`!?\[([^\]]*)\]\(\s*([^)\s]+)`),
+	regexp.MustCompile(`<img\s[^>]*?alt="([^"]*)"[^>]*?src="([^"]+)"`),
+}
+
+// MapIssueAttachments maps the files uploaded into an issue's body to
+// attachments, in the order the body links them. A file is named by the
+// text of the link to it, or else by the last segment of its URL; GitHub
+// reports no sizes, and the files count as uploaded by the issue's author.
+func MapIssueAttachments(issue Issue) []provider.WorkItemAttachment {
+	names := map[string]string{}
+	for _, p := range assetNamePatterns {
+		for _, m := range p.FindAllStringSubmatch(issue.Body, -1) {
+			if name := strings.TrimSpace(m[1]); name != "" && !strings.EqualFold(name, "image") {
+				names[m[2]] = name
+			}
+		}
+	}
+
+	var attachments []provider.WorkItemAttachment
+	seen := map[string]bool{}
+	for _, u := range assetURLPattern.FindAllString(issue.Body, -1) {
+		if seen[u] {
+			continue
+		}
+		seen[u] = true
+		name := names[u]
+		if name == "" {
+			name = path.Base(u)
+		}
+		attachments = append(attachments, provider.WorkItemAttachment{
+			Name:       name,
+			URL:        u,
+			UploadedBy: issue.User.Login,
+			UploadedAt: issue.CreatedAt,
+		})
+	}
+	return attachments
+}
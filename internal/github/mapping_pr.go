@@ -2,6 +2,7 @@ package github
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/Elpulgo/azdo/internal/provider"
 )
@@ -36,6 +37,11 @@ func MapPullRequest(pr PullRequest, scope, scopeDisplay string) provider.PullReq
 	}
 	statusCategory := MapStateCategory(pr.State, stateReason)
 
+	var labels []string
+	for _, l := range pr.Labels {
+		labels = append(labels, l.Name)
+	}
+
 	return provider.PullRequest{
 		Identity: provider.Identity{
 			Kind:         provider.KindGitHub,
@@ -48,9 +54,11 @@ func MapPullRequest(pr PullRequest, scope, scopeDisplay string) provider.PullReq
 		Status:         pr.State, // raw "open" / "closed"
 		StatusCategory: statusCategory,
 		CreationDate:   pr.CreatedAt,
+		UpdatedDate:    pr.UpdatedAt,
 		SourceRefName:  pr.Head.Ref,
 		TargetRefName:  pr.Base.Ref,
 		IsDraft:        pr.Draft,
+		Labels:         labels,
 		CreatedByName:  pr.User.Login,
 		CreatedByID:    fmt.Sprintf("%d", pr.User.ID),
 		// GitHub PR wire carries no separate numeric repo ID.
@@ -58,6 +66,7 @@ func MapPullRequest(pr PullRequest, scope, scopeDisplay string) provider.PullReq
 		RepositoryID:   scope,
 		RepositoryName: scope,
 		WebURL:         pr.HTMLURL,
+		MergeStatus:    MapMergeStatus(pr.MergeableState),
 		// Reviewers: populated by caller via MapReviewers.
 	}
 }
@@ -225,12 +234,7 @@ func MapReviewThreads(comments []ReviewComment, scope, scopeDisplay string) []pr
 			}
 		}
 
-		// Line: prefer the current-diff anchor; fall back to OriginalLine for
-		// comments anchored to an outdated diff position.
-		line := derefInt(td.root.Line)
-		if line == 0 {
-			line = derefInt(td.root.OriginalLine)
-		}
+		rng := mapReviewCommentRange(td.root)
 
 		threads = append(threads, provider.Thread{
 			Identity: provider.Identity{
@@ -245,15 +249,39 @@ func MapReviewThreads(comments []ReviewComment, scope, scopeDisplay string) []pr
 			// Default to "active"; GraphQL-based resolve state layered in later.
 			Status:    "active",
 			FilePath:  td.root.Path,
-			Line:      line,
+			Line:      rng.EndLine,
+			Range:     rng,
 			Comments:  threadComments,
 			IsDeleted: false,
+			Outdated:  td.root.Line == nil && td.root.OriginalLine != nil,
 		})
 	}
 
 	return threads
 }
 
+// mapReviewCommentRange derives the neutral anchor of a review comment.
+// Lines prefer the current-diff anchor and fall back to the Original* fields
+// for comments on an outdated diff position. Side "LEFT" anchors to the base
+// file; anything else (including the empty side of older payloads) is the
+// head file. GitHub anchors whole lines, so offsets stay zero.
+func mapReviewCommentRange(c ReviewComment) provider.LineRange {
+	end := derefInt(c.Line)
+	start := derefInt(c.StartLine)
+	if end == 0 {
+		end = derefInt(c.OriginalLine)
+		start = derefInt(c.OriginalStartLine)
+	}
+	if start == 0 || start > end {
+		start = end
+	}
+	side := provider.SideRight
+	if strings.EqualFold(c.Side, "LEFT") {
+		side = provider.SideLeft
+	}
+	return provider.LineRange{Side: side, StartLine: start, EndLine: end}
+}
+
 // mapReviewComment maps a single ReviewComment to a provider.Comment.
 // parentCommentID is 0 for thread roots and the numeric root ID for replies.
 func mapReviewComment(c ReviewComment, parentCommentID int, scope, scopeDisplay string) provider.Comment {
@@ -271,6 +299,19 @@ func mapReviewComment(c ReviewComment, parentCommentID int, scope, scopeDisplay
 		CommentType:     "text",
 		AuthorName:      c.User.Login,
 		AuthorID:        fmt.Sprintf("%d", c.User.ID),
+		Reactions:       mapReactions(c.Reactions),
+	}
+}
+
+// MapCommit maps a GitHub Commit to a provider.Commit. Commits are
+// sub-entities of a PR and carry no Identity.
+func MapCommit(c Commit) provider.Commit {
+	return provider.Commit{
+		ID:          c.SHA,
+		Message:     c.Commit.Message,
+		AuthorName:  c.Commit.Author.Name,
+		AuthorEmail: c.Commit.Author.Email,
+		Date:        c.Commit.Author.Date,
 	}
 }
 
@@ -290,6 +331,7 @@ func MapPRFile(f PRFile, changeID int) provider.IterationChange {
 		GitObjectType: "blob",
 		ChangeType:    mapChangeType(f.Status),
 		OriginalPath:  f.PreviousFilename,
+		ObjectID:      f.SHA,
 		Patch:         f.Patch,
 	}
 }
@@ -207,6 +207,25 @@ func TestMapPullRequest_Draft(t *testing.T) {
 
 // ── MapReviewers ──────────────────────────────────────────────────────────────
 
+func TestMapPullRequest_LabelsAndUpdatedDate(t *testing.T) {
+	updated := time.Date(2026, 4, 2, 8, 0, 0, 0, time.UTC)
+	pr := github.PullRequest{
+		Number:    5,
+		State:     "open",
+		UpdatedAt: updated,
+		Labels:    []github.Label{{Name: "bug"}, {Name: "ui"}},
+	}
+
+	got := github.MapPullRequest(pr, testScope, testScopeDisplay)
+
+	if len(got.Labels) != 2 || got.Labels[0] != "bug" || got.Labels[1] != "ui" {
+		t.Errorf("Labels = %q, want [bug ui]", got.Labels)
+	}
+	if !got.UpdatedDate.Equal(updated) {
+		t.Errorf("UpdatedDate = %v, want %v", got.UpdatedDate, updated)
+	}
+}
+
 func TestMapReviewers_ApprovedAndRejected(t *testing.T) {
 	// alice approved; bob requested changes; charlie has no review (requested only).
 	reviewsJSON := `[
@@ -536,6 +555,36 @@ func TestMapReviewThreads_NullLineFallsBackToOriginalLine(t *testing.T) {
 	if threads[0].Line != 42 {
 		t.Errorf("Line = %d, want 42 (fallback from OriginalLine)", threads[0].Line)
 	}
+	if !threads[0].Outdated {
+		t.Error("Outdated = false, want true for a null line")
+	}
+}
+
+func TestMapReviewThreads_MultiLineLeftSideRange(t *testing.T) {
+	const raw = `[
+		{"id": 110, "in_reply_to_id": null, "path": "removed.go", "start_line": 10, "line": 14, "side": "LEFT", "body": "why drop this?", "user": {"login": "u", "id": 7}, "created_at": "2026-05-01T09:00:00Z", "updated_at": "2026-05-01T09:00:00Z", "html_url": ""}
+	]`
+
+	var comments []github.ReviewComment
+	if err := json.Unmarshal([]byte(raw), &comments); err != nil {
+		t.Fatalf("json.Unmarshal: %v", err)
+	}
+
+	threads := github.MapReviewThreads(comments, testScope, testScopeDisplay)
+
+	if len(threads) != 1 {
+		t.Fatalf("len(threads) = %d, want 1", len(threads))
+	}
+	want := provider.LineRange{Side: provider.SideLeft, StartLine: 10, EndLine: 14}
+	if threads[0].Range != want {
+		t.Errorf("Range = %+v, want %+v", threads[0].Range, want)
+	}
+	if threads[0].Line != 14 {
+		t.Errorf("Line = %d, want 14 (GitHub anchors on the last line)", threads[0].Line)
+	}
+	if threads[0].Outdated {
+		t.Error("Outdated = true, want false for a current anchor")
+	}
 }
 
 func TestMapReviewThreads_DefensiveNewThreadForOrphanReply(t *testing.T) {
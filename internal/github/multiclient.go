@@ -126,9 +126,11 @@ func (mc *MultiClient) ListWorkItems(top int, opts provider.ListOpts) ([]provide
 				return
 			}
 			scopeDisplay := mc.DisplayNameFor(s)
+			parents := c.SubIssueParents(wire)
 			items := make([]provider.WorkItem, len(wire))
 			for i, issue := range wire {
 				items[i] = MapWorkItem(issue, mc.conv, s, scopeDisplay)
+				items[i].ParentID = parents[issue.Number]
 			}
 			ch <- result{items: items}
 		}(scope, client)
@@ -173,9 +175,11 @@ func (mc *MultiClient) ListMyWorkItems(top int, opts provider.ListOpts) ([]provi
 				return
 			}
 			scopeDisplay := mc.DisplayNameFor(s)
+			parents := c.SubIssueParents(wire)
 			items := make([]provider.WorkItem, len(wire))
 			for i, issue := range wire {
 				items[i] = MapWorkItem(issue, mc.conv, s, scopeDisplay)
+				items[i].ParentID = parents[issue.Number]
 			}
 			ch <- result{items: items}
 		}(scope, client)
@@ -221,7 +225,7 @@ func mergeWorkItems(all []provider.WorkItem, errs []error, total int) ([]provide
 // ListPullRequests fetches pull requests from all repos concurrently, maps to
 // neutral, merges and sorts by CreationDate descending.
 func (mc *MultiClient) ListPullRequests(top int, opts provider.ListOpts) ([]provider.PullRequest, error) {
-	return mc.fanOutPRs(func(c *Client) ([]PullRequest, error) {
+	return mc.fanOutPRs(opts, func(c *Client) ([]PullRequest, error) {
 		return c.ListPullRequests(top, opts)
 	})
 }
@@ -229,7 +233,7 @@ func (mc *MultiClient) ListPullRequests(top int, opts provider.ListOpts) ([]prov
 // ListMyPullRequests fetches PRs authored by the authenticated user from all
 // repos concurrently, maps to neutral, merges and sorts by CreationDate desc.
 func (mc *MultiClient) ListMyPullRequests(top int, opts provider.ListOpts) ([]provider.PullRequest, error) {
-	return mc.fanOutPRs(func(c *Client) ([]PullRequest, error) {
+	return mc.fanOutPRs(opts, func(c *Client) ([]PullRequest, error) {
 		return c.ListMyPullRequests(top, opts)
 	})
 }
@@ -238,17 +242,18 @@ func (mc *MultiClient) ListMyPullRequests(top int, opts provider.ListOpts) ([]pr
 // requested reviewer from all repos concurrently, maps to neutral, merges and
 // sorts by CreationDate desc.
 func (mc *MultiClient) ListPullRequestsAsReviewer(top int, opts provider.ListOpts) ([]provider.PullRequest, error) {
-	return mc.fanOutPRs(func(c *Client) ([]PullRequest, error) {
+	return mc.fanOutPRs(opts, func(c *Client) ([]PullRequest, error) {
 		return c.ListPullRequestsAsReviewer(top, opts)
 	})
 }
 
 // fanOutPRs is the shared implementation for the three PR list methods. fetch is
-// called once per repo to obtain the wire slice; results are mapped and merged.
+// called once per repo to obtain the wire slice; results are mapped, filtered
+// by the PR filters of opts the endpoints do not apply, and merged.
 // Reviewers are NOT populated here — the list/search payloads don't carry review
 // data; the mapper leaves Reviewers empty, consistent with MapPullRequest's
 // documented contract.
-func (mc *MultiClient) fanOutPRs(fetch func(*Client) ([]PullRequest, error)) ([]provider.PullRequest, error) {
+func (mc *MultiClient) fanOutPRs(opts provider.ListOpts, fetch func(*Client) ([]PullRequest, error)) ([]provider.PullRequest, error) {
 	type result struct {
 		prs []provider.PullRequest
 		err error
@@ -271,7 +276,7 @@ func (mc *MultiClient) fanOutPRs(fetch func(*Client) ([]PullRequest, error)) ([]
 			for i, pr := range wire {
 				prs[i] = MapPullRequest(pr, s, scopeDisplay)
 			}
-			ch <- result{prs: prs}
+			ch <- result{prs: provider.FilterPullRequests(prs, opts)}
 		}(scope, client)
 	}
 
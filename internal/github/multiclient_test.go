@@ -395,3 +395,41 @@ func TestMultiClient_ListPullRequests_MergesAndSortsByCreationDate(t *testing.T)
 		t.Errorf("prs[1].Title = %q, want %q", prs[1].Title, "Older PR")
 	}
 }
+
+func TestMultiClient_ListPullRequests_AppliesFilters(t *testing.T) {
+	mc := newTwoRepoMultiClient(t,
+		stubServer(t, http.StatusOK, prFixture1),
+		stubServer(t, http.StatusOK, prFixture2),
+	)
+
+	prs, err := mc.ListPullRequests(10, provider.ListOpts{Author: "BO", Repository: "repo2"})
+	if err != nil {
+		t.Fatalf("ListPullRequests: %v", err)
+	}
+	if len(prs) != 1 || prs[0].Title != "Older PR" {
+		t.Fatalf("want only the PR by bob in repo2, got %+v", prs)
+	}
+}
+
+// Search items carry the draft flag, so filtering the search-backed tabs on
+// drafts keeps the drafts the query already selected.
+func TestMultiClient_ListMyPullRequests_DraftOnly(t *testing.T) {
+	search := stubServer(t, http.StatusOK, `{"total_count":1,"items":[{"number":7,"title":"Draft PR",
+		"state":"open","draft":true,"user":{"login":"a","id":1},"created_at":"2024-05-01T00:00:00Z",
+		"html_url":"https://github.com/owner1/repo1/pull/7","pull_request":{"merged_at":null}}]}`)
+	empty := stubServer(t, http.StatusOK, `{"total_count":0,"items":[]}`)
+	mc := newTwoRepoMultiClient(t, search, empty)
+
+	for name, list := range map[string]func(int, provider.ListOpts) ([]provider.PullRequest, error){
+		"mine":      mc.ListMyPullRequests,
+		"reviewing": mc.ListPullRequestsAsReviewer,
+	} {
+		prs, err := list(10, provider.ListOpts{Draft: provider.DraftOnly})
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if len(prs) != 1 || !prs[0].IsDraft {
+			t.Errorf("%s: want the draft PR, got %+v", name, prs)
+		}
+	}
+}
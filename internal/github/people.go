@@ -0,0 +1,43 @@
+package github
+
+import (
+	"fmt"
+	"strings"
+)
+
+// peopleResultCap caps how many matches SearchAssignees returns.
+const peopleResultCap = 10
+
+// ListAssignees returns the first issuePerPageCap (100) users that can be
+// assigned to the repository's issues.
+func (c *Client) ListAssignees() ([]User, error) {
+	var users []User
+	path := fmt.Sprintf("/repos/%s/%s/assignees?per_page=%d", c.owner, c.repo, issuePerPageCap)
+	if err := c.getJSON(path, &users); err != nil {
+		return nil, fmt.Errorf("github: list assignees: %w", err)
+	}
+	return users, nil
+}
+
+// SearchAssignees returns the repository's assignable users whose login
+// starts with query (case-insensitively). Unlike collaborators, assignees
+// can be listed with read access. Only the first page of issuePerPageCap
+// (100) users is searched.
+func (c *Client) SearchAssignees(query string) ([]User, error) {
+	users, err := c.ListAssignees()
+	if err != nil {
+		return nil, err
+	}
+
+	query = strings.ToLower(query)
+	var result []User
+	for _, u := range users {
+		if strings.HasPrefix(strings.ToLower(u.Login), query) {
+			result = append(result, u)
+			if len(result) == peopleResultCap {
+				break
+			}
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,41 @@
+package github
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_SearchAssignees_FiltersByLoginPrefix(t *testing.T) {
+	var path string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.Write([]byte(`[
+			{"login": "janedoe", "id": 1},
+			{"login": "JamesW", "id": 2},
+			{"login": "bob", "id": 3}
+		]`))
+	}))
+	defer srv.Close()
+
+	c := NewClient("o", "r", "tok")
+	c.SetBaseURL(srv.URL)
+
+	users, err := c.SearchAssignees("Ja")
+	if err != nil {
+		t.Fatalf("SearchAssignees() error = %v", err)
+	}
+	if path != "/repos/o/r/assignees" {
+		t.Errorf("path = %q, want /repos/o/r/assignees", path)
+	}
+	if len(users) != 2 || users[0].Login != "janedoe" || users[1].Login != "JamesW" {
+		t.Errorf("users = %+v, want janedoe and JamesW", users)
+	}
+}
+
+func TestMapUser_MentionsByLogin(t *testing.T) {
+	p := MapUser(User{Login: "octocat", ID: 1})
+	if p.ID != "octocat" || p.Mention != "@octocat" || p.HTMLMention != "@octocat" {
+		t.Errorf("MapUser() = %+v", p)
+	}
+}
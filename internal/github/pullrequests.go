@@ -26,7 +26,7 @@ const prEnrichConcurrency = 8
 // to pull requests via the "is:pr" qualifier. The top-level shape is issue-like;
 // the nested "pull_request" sub-object adds merged_at.
 //
-// Fidelity note: /search/issues items do NOT carry Draft, Head, or Base. Those
+// Fidelity note: /search/issues items do NOT carry Head or Base. Those
 // fields are zero in the PullRequest returned by toPullRequest. This partial map
 // is sufficient for list views. N+1 GET /repos/.../pulls/{n} enrichment is
 // explicitly avoided per spec task 9.
@@ -39,16 +39,18 @@ type prSearchItem struct {
 	Body        string            `json:"body"`
 	State       string            `json:"state"`
 	User        User              `json:"user"`
+	Labels      []Label           `json:"labels"`
 	CreatedAt   time.Time         `json:"created_at"`
 	UpdatedAt   time.Time         `json:"updated_at"`
 	ClosedAt    *time.Time        `json:"closed_at"`
 	HTMLURL     string            `json:"html_url"`
+	Draft       bool              `json:"draft"`
 	PullRequest *prSearchNestedPR `json:"pull_request"`
 }
 
-// toPullRequest converts a prSearchItem to the wire PullRequest type. Draft,
-// Head, Base, and RequestedReviewers are left zero — they are not available
-// from the /search/issues endpoint.
+// toPullRequest converts a prSearchItem to the wire PullRequest type. Head,
+// Base, and RequestedReviewers are left zero — they are not available from
+// the /search/issues endpoint.
 func (item prSearchItem) toPullRequest() PullRequest {
 	var mergedAt *time.Time
 	if item.PullRequest != nil {
@@ -60,12 +62,14 @@ func (item prSearchItem) toPullRequest() PullRequest {
 		Body:      item.Body,
 		State:     item.State,
 		User:      item.User,
+		Labels:    item.Labels,
 		CreatedAt: item.CreatedAt,
 		UpdatedAt: item.UpdatedAt,
 		ClosedAt:  item.ClosedAt,
 		MergedAt:  mergedAt,
 		HTMLURL:   item.HTMLURL,
-		// Draft, Head, Base: not available in /search/issues items; left zero.
+		Draft:     item.Draft,
+		// Head, Base: not available in /search/issues items; left zero.
 		// RequestedReviewers: not available in /search/issues items; left nil.
 	}
 }
@@ -79,7 +83,8 @@ type prSearchResponse struct {
 
 // ListPullRequests returns up to top pull requests for the repository sorted
 // by most recently updated. opts.States is translated to the GitHub state
-// parameter (open/closed/all) via mapStateParam.
+// parameter (open/closed/all) via mapStateParam and opts.TargetBranch to the
+// base parameter; the other PR filters are applied by the caller.
 //
 // top is capped at issuePerPageCap (100); pagination is not yet implemented.
 func (c *Client) ListPullRequests(top int, opts provider.ListOpts) ([]PullRequest, error) {
@@ -93,6 +98,9 @@ func (c *Client) ListPullRequests(top int, opts provider.ListOpts) ([]PullReques
 	state := mapStateParam(opts.States)
 	path := fmt.Sprintf("/repos/%s/%s/pulls?state=%s&per_page=%d&sort=updated&direction=desc",
 		c.owner, c.repo, state, top)
+	if opts.TargetBranch != "" {
+		path += "&base=" + url.QueryEscape(opts.TargetBranch)
+	}
 
 	var prs []PullRequest
 	if err := c.getJSON(path, &prs); err != nil {
@@ -108,8 +116,8 @@ func (c *Client) ListPullRequests(top int, opts provider.ListOpts) ([]PullReques
 // "author:@me" (GitHub resolves @me server-side to the token owner's login).
 //
 // Fidelity limitation: search items are issue-shaped. The returned PullRequest
-// values have Draft=false and zero Head/Base; merged_at is captured from the
-// nested "pull_request" sub-object. See prSearchItem for details.
+// values have zero Head/Base until enrichBranches fills them in; merged_at is
+// captured from the nested "pull_request" sub-object. See prSearchItem for details.
 //
 // top is capped at issuePerPageCap (100). The /search/issues endpoint has a
 // rate limit of 30 requests/minute (authenticated).
@@ -143,6 +151,7 @@ func (c *Client) searchPullRequests(top int, opts provider.ListOpts, qualifier s
 	if state == "open" || state == "closed" {
 		q += " state:" + state
 	}
+	q += prSearchQualifiers(opts)
 
 	params := url.Values{}
 	params.Set("q", q)
@@ -163,6 +172,37 @@ func (c *Client) searchPullRequests(top int, opts provider.ListOpts, qualifier s
 	return prs, nil
 }
 
+// prSearchQualifiers translates the PR filters of opts that search can
+// express into qualifiers, each preceded by a space. Author is left out: the
+// filter matches a substring, while author: needs the exact login.
+func prSearchQualifiers(opts provider.ListOpts) string {
+	var q string
+	if opts.TargetBranch != "" {
+		q += " base:" + searchTerm(opts.TargetBranch)
+	}
+	for _, label := range opts.Labels {
+		q += " label:" + searchTerm(label)
+	}
+	switch opts.Draft {
+	case provider.DraftOnly:
+		q += " draft:true"
+	case provider.DraftExcluded:
+		q += " draft:false"
+	}
+	if !opts.CreatedAfter.IsZero() {
+		q += " created:>=" + opts.CreatedAfter.UTC().Format("2006-01-02")
+	}
+	return q
+}
+
+// searchTerm quotes a qualifier value containing spaces.
+func searchTerm(value string) string {
+	if strings.ContainsAny(value, " \t") {
+		return `"` + strings.ReplaceAll(value, `"`, "") + `"`
+	}
+	return value
+}
+
 // enrichBranches fills in Head/Base (source/target branches) for search-sourced
 // PRs, which GET /search/issues does not return. Without this the PR list rows
 // and detail header render an empty "→" for the my-PRs and reviewer tabs.
@@ -190,6 +230,7 @@ func (c *Client) enrichBranches(prs []PullRequest) {
 			}
 			prs[idx].Head = full.Head
 			prs[idx].Base = full.Base
+			prs[idx].MergeableState = full.MergeableState
 		}(i)
 	}
 	wg.Wait()
@@ -197,7 +238,8 @@ func (c *Client) enrichBranches(prs []PullRequest) {
 
 // GetPullRequest fetches a single pull request via
 // GET /repos/{owner}/{repo}/pulls/{number}. Used to enrich search-sourced PRs
-// (which lack head/base) with their source and target branches.
+// (which lack head/base) with their source and target branches, and for the
+// mergeable_state that list endpoints do not return.
 func (c *Client) GetPullRequest(number int) (PullRequest, error) {
 	if number <= 0 {
 		return PullRequest{}, fmt.Errorf("github: get pull request: invalid number %d", number)
@@ -226,6 +268,13 @@ func (c *Client) GetPRThreads(number int) ([]ReviewComment, error) {
 	if err := c.getJSON(path, &comments); err != nil {
 		return nil, fmt.Errorf("github: get PR threads: %w", err)
 	}
+	nodeIDs := make([]string, len(comments))
+	rollups := make([]*ReactionRollup, len(comments))
+	for i := range comments {
+		nodeIDs[i] = comments[i].NodeID
+		rollups[i] = &comments[i].Reactions
+	}
+	c.markMine(nodeIDs, rollups)
 	return comments, nil
 }
 
@@ -286,11 +335,104 @@ func (c *Client) VotePullRequest(number int, vote int) error {
 	return nil
 }
 
+// DraftReviewComment is one inline comment of a pending review, in the shape
+// POST /repos/{owner}/{repo}/pulls/{number}/reviews expects in its comments
+// array. StartLine/StartSide are only sent for multi-line comments.
+type DraftReviewComment struct {
+	Path      string `json:"path"`
+	Body      string `json:"body"`
+	Line      int    `json:"line"`
+	Side      string `json:"side"`
+	StartLine int    `json:"start_line,omitempty"`
+	StartSide string `json:"start_side,omitempty"`
+}
+
+// createReviewBody is the JSON body for creating a pending review. Omitting
+// event leaves the review in the PENDING state, invisible to the author.
+type createReviewBody struct {
+	CommitID string               `json:"commit_id"`
+	Comments []DraftReviewComment `json:"comments"`
+}
+
+// submitPendingReviewBody is the JSON body for
+// POST /repos/{owner}/{repo}/pulls/{number}/reviews/{review_id}/events.
+type submitPendingReviewBody struct {
+	Event string `json:"event"`
+	Body  string `json:"body,omitempty"`
+}
+
+// CreatePendingReview creates a PENDING review holding comments on the pull
+// request's head commit. Nothing is published until SubmitPendingReview is
+// called, so the author receives no notifications for the drafts.
+//
+// Like AddPRCodeComment, this fetches the PR first to obtain head.sha.
+func (c *Client) CreatePendingReview(number int, comments []DraftReviewComment) (Review, error) {
+	prPath := fmt.Sprintf("/repos/%s/%s/pulls/%d", c.owner, c.repo, number)
+	var pr PullRequest
+	if err := c.getJSON(prPath, &pr); err != nil {
+		return Review{}, fmt.Errorf("github: create pending review (fetch PR): %w", err)
+	}
+
+	if comments == nil {
+		comments = []DraftReviewComment{}
+	}
+	path := fmt.Sprintf("/repos/%s/%s/pulls/%d/reviews", c.owner, c.repo, number)
+	payload := createReviewBody{CommitID: pr.Head.SHA, Comments: comments}
+
+	var created Review
+	if err := c.doJSON("POST", path, payload, &created); err != nil {
+		return Review{}, fmt.Errorf("github: create pending review: %w", err)
+	}
+	return created, nil
+}
+
+// SubmitPendingReview publishes a pending review with event ("APPROVE",
+// "REQUEST_CHANGES" or "COMMENT") and an optional summary body. GitHub
+// rejects REQUEST_CHANGES and COMMENT without a body unless the review
+// carries inline comments.
+func (c *Client) SubmitPendingReview(number int, reviewID int64, event, body string) error {
+	path := fmt.Sprintf("/repos/%s/%s/pulls/%d/reviews/%d/events", c.owner, c.repo, number, reviewID)
+	payload := submitPendingReviewBody{Event: event, Body: body}
+	if err := c.doJSON("POST", path, payload, nil); err != nil {
+		return fmt.Errorf("github: submit pending review: %w", err)
+	}
+	return nil
+}
+
+// DeletePendingReview discards a review that has not been submitted yet.
+// GitHub allows one pending review per user and pull request, so a review
+// left behind by a failed submit would block the next attempt.
+func (c *Client) DeletePendingReview(number int, reviewID int64) error {
+	path := fmt.Sprintf("/repos/%s/%s/pulls/%d/reviews/%d", c.owner, c.repo, number, reviewID)
+	if err := c.doJSON("DELETE", path, nil, nil); err != nil {
+		return fmt.Errorf("github: delete pending review: %w", err)
+	}
+	return nil
+}
+
 // fileContentResponse is the JSON body returned by
 // GET /repos/{owner}/{repo}/contents/{path}.
 type fileContentResponse struct {
 	Content  string `json:"content"`
 	Encoding string `json:"encoding"`
+	SHA      string `json:"sha"`
+}
+
+// FileContent is a decoded file from the Contents API together with its
+// blob SHA, which UpdateFile needs to replace it.
+type FileContent struct {
+	Content string
+	SHA     string
+}
+
+// contentsPath builds /repos/{owner}/{repo}/contents/{path}, URL-escaping
+// each path segment independently to preserve "/" as a separator.
+func (c *Client) contentsPath(filePath string) string {
+	segments := strings.Split(strings.TrimPrefix(filePath, "/"), "/")
+	for i, seg := range segments {
+		segments[i] = url.PathEscape(seg)
+	}
+	return fmt.Sprintf("/repos/%s/%s/contents/%s", c.owner, c.repo, strings.Join(segments, "/"))
 }
 
 // GetFileContent returns the raw decoded content of a file at the given ref
@@ -307,24 +449,26 @@ type fileContentResponse struct {
 //
 // filePath segments and the ref query value are individually URL-escaped.
 func (c *Client) GetFileContent(filePath string, branchName string) (string, error) {
-	// URL-escape each path segment independently to preserve "/" as a separator.
-	segments := strings.Split(strings.TrimPrefix(filePath, "/"), "/")
-	for i, seg := range segments {
-		segments[i] = url.PathEscape(seg)
+	file, err := c.GetFile(filePath, branchName)
+	if err != nil {
+		return "", err
 	}
-	escapedFilePath := strings.Join(segments, "/")
+	return file.Content, nil
+}
 
-	path := fmt.Sprintf("/repos/%s/%s/contents/%s?ref=%s",
-		c.owner, c.repo, escapedFilePath, url.QueryEscape(branchName))
+// GetFile returns the decoded content and blob SHA of a file at the given
+// ref. See GetFileContent for the size limitation.
+func (c *Client) GetFile(filePath string, ref string) (FileContent, error) {
+	path := c.contentsPath(filePath) + "?ref=" + url.QueryEscape(ref)
 
 	var resp fileContentResponse
 	if err := c.getJSON(path, &resp); err != nil {
-		return "", fmt.Errorf("github: get file content: %w", err)
+		return FileContent{}, fmt.Errorf("github: get file content: %w", err)
 	}
 
 	if resp.Encoding != "base64" {
 		// Non-base64 encoding (or empty content for >1 MB files): return as-is.
-		return resp.Content, nil
+		return FileContent{Content: resp.Content, SHA: resp.SHA}, nil
 	}
 
 	// GitHub wraps base64 at 60 characters per line. Strip all newlines before
@@ -332,31 +476,85 @@ func (c *Client) GetFileContent(filePath string, branchName string) (string, err
 	cleaned := strings.ReplaceAll(resp.Content, "\n", "")
 	decoded, err := base64.StdEncoding.DecodeString(cleaned)
 	if err != nil {
-		return "", fmt.Errorf("github: decode file content: %w", err)
+		return FileContent{}, fmt.Errorf("github: decode file content: %w", err)
+	}
+	return FileContent{Content: string(decoded), SHA: resp.SHA}, nil
+}
+
+// updateFileBody is the JSON body for
+// PUT /repos/{owner}/{repo}/contents/{path}.
+type updateFileBody struct {
+	Message string `json:"message"`
+	Content string `json:"content"`
+	SHA     string `json:"sha"`
+	Branch  string `json:"branch"`
+}
+
+// updateFileResponse is the subset of the PUT contents response we use.
+type updateFileResponse struct {
+	Commit struct {
+		SHA string `json:"sha"`
+	} `json:"commit"`
+}
+
+// UpdateFile commits new content for filePath on branch and returns the
+// commit SHA. sha is the blob SHA the change is based on (from GetFile);
+// GitHub answers 409 Conflict when the file has changed since, so a
+// concurrent push is never overwritten.
+func (c *Client) UpdateFile(filePath, branch, message, content, sha string) (string, error) {
+	payload := updateFileBody{
+		Message: message,
+		Content: base64.StdEncoding.EncodeToString([]byte(content)),
+		SHA:     sha,
+		Branch:  branch,
+	}
+	var resp updateFileResponse
+	if err := c.doJSON("PUT", c.contentsPath(filePath), payload, &resp); err != nil {
+		return "", fmt.Errorf("github: update file: %w", err)
+	}
+	return resp.Commit.SHA, nil
+}
+
+// EditReviewComment replaces the body of a pull request review comment.
+func (c *Client) EditReviewComment(commentID int, body string) error {
+	if err := c.doJSON("PATCH", c.reviewCommentPath(commentID), addCommentBody{Body: body}, nil); err != nil {
+		return fmt.Errorf("github: edit review comment: %w", err)
 	}
-	return string(decoded), nil
+	return nil
+}
+
+// DeleteReviewComment deletes a pull request review comment.
+func (c *Client) DeleteReviewComment(commentID int) error {
+	if err := c.doJSON("DELETE", c.reviewCommentPath(commentID), nil, nil); err != nil {
+		return fmt.Errorf("github: delete review comment: %w", err)
+	}
+	return nil
 }
 
 // addCodeCommentBody is the JSON body for
 // POST /repos/{owner}/{repo}/pulls/{number}/comments (inline code comment).
+// StartLine/StartSide are only sent for multi-line comments.
 type addCodeCommentBody struct {
-	Body     string `json:"body"`
-	CommitID string `json:"commit_id"`
-	Path     string `json:"path"`
-	Line     int    `json:"line"`
-	Side     string `json:"side"`
+	Body      string `json:"body"`
+	CommitID  string `json:"commit_id"`
+	Path      string `json:"path"`
+	Line      int    `json:"line"`
+	Side      string `json:"side"`
+	StartLine int    `json:"start_line,omitempty"`
+	StartSide string `json:"start_side,omitempty"`
 }
 
-// AddPRCodeComment creates an inline code comment on the given file and line
-// in the pull request.
+// AddPRCodeComment creates an inline code comment on the given file in the
+// pull request.
 //
 // GitHub's review-comment API requires the head commit SHA (commit_id). This
 // method fetches the PR via GET /repos/{o}/{r}/pulls/{number} to obtain
 // head.sha, then posts the comment. This adds one extra round-trip per call.
 //
-// Side is always "RIGHT" (new-file side). The LEFT (base-file) side is not
-// exposed via this method.
-func (c *Client) AddPRCodeComment(number int, filePath string, line int, content string) (ReviewComment, error) {
+// side is "RIGHT" (head file) or "LEFT" (base file, e.g. deleted lines).
+// line is the last line of the comment; startLine is the first line of a
+// multi-line comment, or 0 for a single-line one.
+func (c *Client) AddPRCodeComment(number int, filePath string, side string, startLine, line int, content string) (ReviewComment, error) {
 	// Fetch the PR to obtain the head SHA required by the review-comment API.
 	prPath := fmt.Sprintf("/repos/%s/%s/pulls/%d", c.owner, c.repo, number)
 	var pr PullRequest
@@ -370,7 +568,11 @@ func (c *Client) AddPRCodeComment(number int, filePath string, line int, content
 		CommitID: pr.Head.SHA,
 		Path:     filePath,
 		Line:     line,
-		Side:     "RIGHT",
+		Side:     side,
+	}
+	if startLine > 0 && startLine < line {
+		payload.StartLine = startLine
+		payload.StartSide = side
 	}
 
 	var created ReviewComment
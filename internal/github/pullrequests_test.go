@@ -100,6 +100,27 @@ func TestClient_ListPullRequests_ClosedState(t *testing.T) {
 	}
 }
 
+func TestClient_ListPullRequests_TargetBranchAsBase(t *testing.T) {
+	var capturedBase string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		capturedBase = r.URL.Query().Get("base")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`[]`))
+	}))
+	defer srv.Close()
+
+	c := NewClient("o", "r", "tok")
+	c.SetBaseURL(srv.URL)
+
+	if _, err := c.ListPullRequests(5, provider.ListOpts{TargetBranch: "release/1.0"}); err != nil {
+		t.Fatalf("ListPullRequests() error = %v", err)
+	}
+	if capturedBase != "release/1.0" {
+		t.Errorf("base param = %q, want release/1.0", capturedBase)
+	}
+}
+
 func TestClient_ListPullRequests_TopCappedAt100(t *testing.T) {
 	var capturedPerPage string
 
@@ -161,7 +182,7 @@ func TestClient_ListMyPullRequests_SearchEndpointAndQuery(t *testing.T) {
 		}
 		// enrichBranches: GET /repos/o/r/pulls/11
 		w.WriteHeader(http.StatusOK)
-		w.Write([]byte(`{"number":11,"head":{"ref":"feature-x"},"base":{"ref":"main"}}`))
+		w.Write([]byte(`{"number":11,"head":{"ref":"feature-x"},"base":{"ref":"main"},"mergeable_state":"dirty"}`))
 	}))
 	defer srv.Close()
 
@@ -206,6 +227,9 @@ func TestClient_ListMyPullRequests_SearchEndpointAndQuery(t *testing.T) {
 	if prs[0].Base.Ref != "main" {
 		t.Errorf("prs[0].Base.Ref = %q, want main (enriched)", prs[0].Base.Ref)
 	}
+	if prs[0].MergeableState != "dirty" {
+		t.Errorf("prs[0].MergeableState = %q, want dirty (enriched)", prs[0].MergeableState)
+	}
 }
 
 func TestClient_ListMyPullRequests_OpenStateQualifier(t *testing.T) {
@@ -231,6 +255,40 @@ func TestClient_ListMyPullRequests_OpenStateQualifier(t *testing.T) {
 	}
 }
 
+func TestClient_ListMyPullRequests_FilterQualifiers(t *testing.T) {
+	var capturedQ string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		capturedQ = r.URL.Query().Get("q")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"total_count":0,"items":[]}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient("o", "r", "tok")
+	c.SetBaseURL(srv.URL)
+
+	opts := provider.ListOpts{
+		TargetBranch: "main",
+		Labels:       []string{"bug", "needs review"},
+		Draft:        provider.DraftExcluded,
+		Author:       "ann",
+		CreatedAfter: time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC),
+	}
+	if _, err := c.ListMyPullRequests(10, opts); err != nil {
+		t.Fatalf("ListMyPullRequests() error = %v", err)
+	}
+	for _, want := range []string{"base:main", "label:bug", `label:"needs review"`, "draft:false", "created:>=2024-03-01"} {
+		if !strings.Contains(capturedQ, want) {
+			t.Errorf("q = %q: missing %s", capturedQ, want)
+		}
+	}
+	// Author is a substring filter and cannot become an exact author: qualifier.
+	if strings.Contains(capturedQ, "author:ann") {
+		t.Errorf("q = %q: author filter should not be sent", capturedQ)
+	}
+}
+
 func TestClient_ListMyPullRequests_NullMergedAt(t *testing.T) {
 	fixture := `{
 		"total_count": 1,
@@ -755,7 +813,7 @@ func TestClient_AddPRCodeComment_FetchesHeadSHAThenPosts(t *testing.T) {
 	c := NewClient("o", "r", "tok")
 	c.SetBaseURL(srv.URL)
 
-	created, err := c.AddPRCodeComment(9, "cmd/main.go", 42, "This looks wrong")
+	created, err := c.AddPRCodeComment(9, "cmd/main.go", "RIGHT", 0, 42, "This looks wrong")
 	if err != nil {
 		t.Fatalf("AddPRCodeComment() error = %v", err)
 	}
@@ -790,6 +848,65 @@ func TestClient_AddPRCodeComment_FetchesHeadSHAThenPosts(t *testing.T) {
 	}
 }
 
+func TestClient_AddPRCodeComment_MultiLineLeftSide(t *testing.T) {
+	var captured map[string]any
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/o/r/pulls/9":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"number": 9, "head": {"ref": "feature/x", "sha": "deadbeef"}}`))
+		case "/repos/o/r/pulls/9/comments":
+			_ = json.NewDecoder(r.Body).Decode(&captured)
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id": 501, "path": "old.go", "start_line": 3, "line": 6, "side": "LEFT"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient("o", "r", "tok")
+	c.SetBaseURL(srv.URL)
+
+	if _, err := c.AddPRCodeComment(9, "old.go", "LEFT", 3, 6, "restore this?"); err != nil {
+		t.Fatalf("AddPRCodeComment() error = %v", err)
+	}
+
+	if captured["side"] != "LEFT" || captured["start_side"] != "LEFT" {
+		t.Errorf("side/start_side = %v/%v, want LEFT/LEFT", captured["side"], captured["start_side"])
+	}
+	if captured["start_line"] != float64(3) || captured["line"] != float64(6) {
+		t.Errorf("start_line/line = %v/%v, want 3/6", captured["start_line"], captured["line"])
+	}
+}
+
+func TestClient_AddPRCodeComment_SingleLineOmitsStartLine(t *testing.T) {
+	var captured map[string]any
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/o/r/pulls/9":
+			w.Write([]byte(`{"number": 9, "head": {"sha": "deadbeef"}}`))
+		case "/repos/o/r/pulls/9/comments":
+			_ = json.NewDecoder(r.Body).Decode(&captured)
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id": 502}`))
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient("o", "r", "tok")
+	c.SetBaseURL(srv.URL)
+
+	if _, err := c.AddPRCodeComment(9, "a.go", "RIGHT", 5, 5, "nit"); err != nil {
+		t.Fatalf("AddPRCodeComment() error = %v", err)
+	}
+	if _, ok := captured["start_line"]; ok {
+		t.Errorf("start_line sent for a single-line comment: %v", captured)
+	}
+}
+
 // ---------------------------------------------------------------------------
 // AddPRComment
 // ---------------------------------------------------------------------------
@@ -0,0 +1,160 @@
+package github
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// reviewCommentPath is the REST path of a pull request review comment.
+func (c *Client) reviewCommentPath(commentID int) string {
+	return fmt.Sprintf("/repos/%s/%s/pulls/comments/%d", c.owner, c.repo, commentID)
+}
+
+// issueCommentPath is the REST path of an issue (or PR conversation) comment.
+func (c *Client) issueCommentPath(commentID int) string {
+	return fmt.Sprintf("/repos/%s/%s/issues/comments/%d", c.owner, c.repo, commentID)
+}
+
+// CurrentUserLogin returns the login of the token's user (GET /user),
+// fetching and caching it on first call.
+func (c *Client) CurrentUserLogin() (string, error) {
+	if c.login != "" {
+		return c.login, nil
+	}
+	var u User
+	if err := c.getJSON("/user", &u); err != nil {
+		return "", fmt.Errorf("github: get current user: %w", err)
+	}
+	c.login = u.Login
+	return u.Login, nil
+}
+
+// ReactToReviewComment adds a reaction (e.g. "+1", "heart") to a review
+// comment, or removes the current user's reaction when remove is true.
+func (c *Client) ReactToReviewComment(commentID int, content string, remove bool) error {
+	return c.setReaction(c.reviewCommentPath(commentID), content, remove)
+}
+
+// ReactToIssueComment adds a reaction to an issue comment, or removes the
+// current user's reaction when remove is true.
+func (c *Client) ReactToIssueComment(commentID int, content string, remove bool) error {
+	return c.setReaction(c.issueCommentPath(commentID), content, remove)
+}
+
+// total returns the number of reactions of every kind.
+func (r ReactionRollup) total() int {
+	return r.PlusOne + r.MinusOne + r.Laugh + r.Hooray + r.Confused + r.Heart + r.Rocket + r.Eyes
+}
+
+// viewerReactionsQuery asks, for each comment node, which reactions the
+// current user left.
+const viewerReactionsQuery = `query($ids: [ID!]!) {
+  nodes(ids: $ids) {
+    ... on Reactable {
+      reactionGroups { content viewerHasReacted }
+    }
+  }
+}`
+
+// viewerReactionsResponse is the GraphQL response for viewerReactionsQuery.
+// Nodes are returned in the order of the requested ids.
+type viewerReactionsResponse struct {
+	Data struct {
+		Nodes []*struct {
+			ReactionGroups []struct {
+				Content          string `json:"content"`
+				ViewerHasReacted bool   `json:"viewerHasReacted"`
+			} `json:"reactionGroups"`
+		} `json:"nodes"`
+	} `json:"data"`
+	Errors []graphqlError `json:"errors,omitempty"`
+}
+
+// graphqlReactionContents maps GraphQL ReactionContent values to the REST
+// reaction contents used by ReactionRollup.
+var graphqlReactionContents = map[string]string{
+	"THUMBS_UP":   "+1",
+	"THUMBS_DOWN": "-1",
+	"LAUGH":       "laugh",
+	"HOORAY":      "hooray",
+	"CONFUSED":    "confused",
+	"HEART":       "heart",
+	"ROCKET":      "rocket",
+	"EYES":        "eyes",
+}
+
+// markMine fills in the Mine set of each rollup for comments anyone reacted
+// to, asking GraphQL which reactions of the comments with the matching node
+// ids the current user left, issuePerPageCap comments per query. It is best
+// effort: on an error the remaining rollups are left as they are and show no
+// reaction as the user's.
+func (c *Client) markMine(nodeIDs []string, rollups []*ReactionRollup) {
+	var ids []string
+	var reacted []*ReactionRollup
+	for i, r := range rollups {
+		if r.total() > 0 && nodeIDs[i] != "" {
+			ids = append(ids, nodeIDs[i])
+			reacted = append(reacted, r)
+		}
+	}
+	for start := 0; start < len(ids); start += issuePerPageCap {
+		end := min(start+issuePerPageCap, len(ids))
+		var resp viewerReactionsResponse
+		if err := c.graphql(viewerReactionsQuery, map[string]any{"ids": ids[start:end]}, &resp); err != nil || len(resp.Errors) > 0 {
+			return
+		}
+		for i, node := range resp.Data.Nodes {
+			if node == nil || start+i >= end {
+				continue
+			}
+			r := reacted[start+i]
+			for _, g := range node.ReactionGroups {
+				content, ok := graphqlReactionContents[g.Content]
+				if !ok || !g.ViewerHasReacted {
+					continue
+				}
+				if r.Mine == nil {
+					r.Mine = map[string]bool{}
+				}
+				r.Mine[content] = true
+			}
+		}
+	}
+}
+
+// reactionBody is the JSON body for POST .../comments/{id}/reactions.
+type reactionBody struct {
+	Content string `json:"content"`
+}
+
+// setReaction adds or removes a reaction on the comment at commentPath.
+// Adding an existing reaction is a no-op on GitHub. Removing needs the
+// reaction's own id, so the comment's reactions of that kind are listed and
+// the one left by the current user is deleted.
+func (c *Client) setReaction(commentPath, content string, remove bool) error {
+	if !remove {
+		if err := c.doJSON("POST", commentPath+"/reactions", reactionBody{Content: content}, nil); err != nil {
+			return fmt.Errorf("github: add reaction: %w", err)
+		}
+		return nil
+	}
+
+	login, err := c.CurrentUserLogin()
+	if err != nil {
+		return err
+	}
+	var reactions []Reaction
+	listPath := fmt.Sprintf("%s/reactions?content=%s&per_page=%d", commentPath, url.QueryEscape(content), issuePerPageCap)
+	if err := c.getJSON(listPath, &reactions); err != nil {
+		return fmt.Errorf("github: list reactions: %w", err)
+	}
+	for _, r := range reactions {
+		if r.User.Login == login {
+			if err := c.doJSON("DELETE", fmt.Sprintf("%s/reactions/%d", commentPath, r.ID), nil, nil); err != nil {
+				return fmt.Errorf("github: remove reaction: %w", err)
+			}
+			return nil
+		}
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+package github
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/Elpulgo/azdo/internal/provider"
+)
+
+func TestClient_ReactToIssueComment_AddPostsContent(t *testing.T) {
+	var method, path string
+	var body reactionBody
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, path = r.Method, r.URL.Path
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id": 1, "content": "heart"}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient("o", "r", "tok")
+	c.SetBaseURL(srv.URL)
+
+	if err := c.ReactToIssueComment(42, "heart", false); err != nil {
+		t.Fatalf("ReactToIssueComment() error = %v", err)
+	}
+	if method != "POST" || path != "/repos/o/r/issues/comments/42/reactions" || body.Content != "heart" {
+		t.Errorf("request = %s %s %+v", method, path, body)
+	}
+}
+
+func TestClient_ReactToReviewComment_RemoveDeletesOwnReaction(t *testing.T) {
+	var deleted string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /user":
+			w.Write([]byte(`{"login": "me", "id": 9}`))
+		case "GET /repos/o/r/pulls/comments/42/reactions":
+			if got := r.URL.Query().Get("content"); got != "+1" {
+				t.Errorf("content filter = %q, want +1", got)
+			}
+			w.Write([]byte(`[
+				{"id": 1, "content": "+1", "user": {"login": "someone"}},
+				{"id": 2, "content": "+1", "user": {"login": "me"}}
+			]`))
+		default:
+			if r.Method == "DELETE" {
+				deleted = r.URL.Path
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient("o", "r", "tok")
+	c.SetBaseURL(srv.URL)
+
+	if err := c.ReactToReviewComment(42, "+1", true); err != nil {
+		t.Fatalf("ReactToReviewComment() error = %v", err)
+	}
+	if deleted != "/repos/o/r/pulls/comments/42/reactions/2" {
+		t.Errorf("deleted = %q, want the current user's reaction", deleted)
+	}
+}
+
+func TestAdapter_EditPRComment_PatchesReviewComment(t *testing.T) {
+	var method, path string
+	var body addCommentBody
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, path = r.Method, r.URL.Path
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		w.Write([]byte(`{"id": 5}`))
+	}))
+	defer srv.Close()
+
+	mc, _ := NewMultiClient([]string{"owner/repo"}, "tok", DefaultLabelConvention(), nil)
+	mc.ClientFor("owner/repo").SetBaseURL(srv.URL)
+	a := NewAdapter(mc)
+
+	if err := a.EditPRComment("owner/repo", "", 7, 1, 5, "fixed typo"); err != nil {
+		t.Fatalf("EditPRComment() error = %v", err)
+	}
+	if method != "PATCH" || path != "/repos/owner/repo/pulls/comments/5" || body.Body != "fixed typo" {
+		t.Errorf("request = %s %s %+v", method, path, body)
+	}
+}
+
+func TestMapReactions_SkipsUnusedAndKeepsOrder(t *testing.T) {
+	got := mapReactions(ReactionRollup{PlusOne: 2, Heart: 1})
+	want := []provider.ReactionCount{
+		{Reaction: provider.ReactionLike, Count: 2},
+		{Reaction: provider.ReactionHeart, Count: 1},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("mapReactions() = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestAdapter_WorkItemCommentReactions_ToggleOff(t *testing.T) {
+	var deleted string
+	var queried []any
+	userCalls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /repos/o/r/issues/5/comments":
+			w.Write([]byte(`[
+				{"id": 42, "node_id": "IC_42", "body": "hi", "reactions": {"+1": 2, "heart": 1}},
+				{"id": 43, "node_id": "IC_43", "body": "quiet"}
+			]`))
+		case "POST /graphql":
+			var req struct {
+				Variables struct {
+					IDs []any `json:"ids"`
+				} `json:"variables"`
+			}
+			json.NewDecoder(r.Body).Decode(&req)
+			queried = req.Variables.IDs
+			w.Write([]byte(`{"data": {"nodes": [{"reactionGroups": [
+				{"content": "THUMBS_UP", "viewerHasReacted": true},
+				{"content": "HEART", "viewerHasReacted": false}
+			]}]}}`))
+		case "GET /user":
+			userCalls++
+			w.Write([]byte(`{"login": "me"}`))
+		case "GET /repos/o/r/issues/comments/42/reactions":
+			w.Write([]byte(`[
+				{"id": 1, "content": "+1", "user": {"login": "someone"}},
+				{"id": 2, "content": "+1", "user": {"login": "me"}}
+			]`))
+		case "DELETE /repos/o/r/issues/comments/42/reactions/2":
+			deleted = r.URL.Path
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	mc, _ := NewMultiClient([]string{"o/r"}, "tok", DefaultLabelConvention(), nil)
+	mc.ClientFor("o/r").SetBaseURL(srv.URL)
+	a := NewAdapter(mc)
+
+	comments, err := a.GetWorkItemComments("o/r", 5)
+	if err != nil {
+		t.Fatalf("GetWorkItemComments() error = %v", err)
+	}
+	want := []provider.ReactionCount{
+		{Reaction: provider.ReactionLike, Count: 2, Mine: true},
+		{Reaction: provider.ReactionHeart, Count: 1},
+	}
+	if got := comments[0].Reactions; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Reactions = %+v, want %+v", got, want)
+	}
+	if !reflect.DeepEqual(queried, []any{"IC_42"}) {
+		t.Errorf("queried node ids = %v, want only the comment with reactions", queried)
+	}
+	if userCalls != 0 {
+		t.Errorf("GET /user called %d times on refresh, want 0", userCalls)
+	}
+
+	// Mine tells the UI to remove the like rather than add another.
+	for range 2 {
+		if err := a.ReactToWorkItemComment("o/r", 5, 42, provider.ReactionLike, true); err != nil {
+			t.Fatalf("ReactToWorkItemComment() error = %v", err)
+		}
+	}
+	if deleted != "/repos/o/r/issues/comments/42/reactions/2" {
+		t.Errorf("deleted = %q, want the current user's reaction", deleted)
+	}
+	if userCalls != 1 {
+		t.Errorf("GET /user called %d times, want 1 (cached)", userCalls)
+	}
+}
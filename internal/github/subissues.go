@@ -0,0 +1,70 @@
+package github
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// removeSubIssueBody is the JSON body for DELETE
+// /repos/{owner}/{repo}/issues/{number}/sub_issue. SubIssueID is the issue
+// ID, not its number.
+type removeSubIssueBody struct {
+	SubIssueID int64 `json:"sub_issue_id"`
+}
+
+// ListSubIssues returns the sub-issues of the given issue, up to
+// issuePerPageCap (100).
+func (c *Client) ListSubIssues(number int) ([]Issue, error) {
+	path := fmt.Sprintf("/repos/%s/%s/issues/%d/sub_issues?per_page=%d", c.owner, c.repo, number, issuePerPageCap)
+	var issues []Issue
+	if err := c.getJSON(path, &issues); err != nil {
+		return nil, fmt.Errorf("github: list sub-issues: %w", err)
+	}
+	return issues, nil
+}
+
+// GetParentIssue returns the issue the given one is a sub-issue of, or nil
+// when it has none.
+func (c *Client) GetParentIssue(number int) (*Issue, error) {
+	path := fmt.Sprintf("/repos/%s/%s/issues/%d/parent", c.owner, c.repo, number)
+	var parent Issue
+	if err := c.getJSON(path, &parent); err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("github: get parent issue: %w", err)
+	}
+	return &parent, nil
+}
+
+// RemoveSubIssue detaches the issue with ID childID from parent.
+func (c *Client) RemoveSubIssue(parent int, childID int64) error {
+	path := fmt.Sprintf("/repos/%s/%s/issues/%d/sub_issue", c.owner, c.repo, parent)
+	if err := c.doJSON("DELETE", path, removeSubIssueBody{SubIssueID: childID}, nil); err != nil {
+		return fmt.Errorf("github: remove sub-issue: %w", err)
+	}
+	return nil
+}
+
+// SubIssueParents maps the numbers of issues that are sub-issues of other
+// issues in the list to their parent's number. Issue payloads only count
+// sub-issues, so the sub-issues of each issue that has any are listed; an
+// issue whose listing fails simply shows without children.
+func (c *Client) SubIssueParents(issues []Issue) map[int]int {
+	parents := map[int]int{}
+	for _, issue := range issues {
+		if issue.SubIssuesSummary == nil || issue.SubIssuesSummary.Total == 0 {
+			continue
+		}
+		children, err := c.ListSubIssues(issue.Number)
+		if err != nil {
+			continue
+		}
+		for _, child := range children {
+			parents[child.Number] = issue.Number
+		}
+	}
+	return parents
+}
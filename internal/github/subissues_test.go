@@ -0,0 +1,126 @@
+package github
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/Elpulgo/azdo/internal/provider"
+)
+
+// newSubIssueTestAdapter returns an adapter for repo o/r talking to handler.
+func newSubIssueTestAdapter(t *testing.T, handler http.HandlerFunc) *Adapter {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	mc, _ := NewMultiClient([]string{"o/r"}, "tok", DefaultLabelConvention(), nil)
+	mc.ClientFor("o/r").SetBaseURL(srv.URL)
+	return NewAdapter(mc)
+}
+
+func TestAdapter_ListWorkItems_SetsParentsFromSubIssues(t *testing.T) {
+	a := newSubIssueTestAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/o/r/issues":
+			w.Write([]byte(`[
+				{"number": 1, "title": "Epic", "state": "open", "sub_issues_summary": {"total": 2, "completed": 0}},
+				{"number": 2, "title": "Task", "state": "open", "sub_issues_summary": {"total": 0}},
+				{"number": 3, "title": "Loose", "state": "open"}
+			]`))
+		case "/repos/o/r/issues/1/sub_issues":
+			w.Write([]byte(`[{"number": 2}, {"number": 99}]`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			http.NotFound(w, r)
+		}
+	})
+
+	items, err := a.ListWorkItems(10, provider.ListOpts{})
+	if err != nil {
+		t.Fatalf("ListWorkItems() error = %v", err)
+	}
+	parents := map[string]int{}
+	for _, wi := range items {
+		parents[wi.Identity.ID] = wi.ParentID
+	}
+	want := map[string]int{"1": 0, "2": 1, "3": 0}
+	if !reflect.DeepEqual(parents, want) {
+		t.Errorf("parents = %v, want %v", parents, want)
+	}
+}
+
+func TestAdapter_GetWorkItemLinks_ParentAndSubIssues(t *testing.T) {
+	a := newSubIssueTestAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/o/r/issues/5/parent":
+			w.Write([]byte(`{"number": 1, "title": "Epic", "state": "open"}`))
+		case "/repos/o/r/issues/5/sub_issues":
+			w.Write([]byte(`[{"number": 8, "title": "Child", "state": "closed"}]`))
+		case "/repos/o/r/issues/8/parent":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message": "Not Found"}`))
+		case "/repos/o/r/issues/8/sub_issues":
+			w.Write([]byte(`[]`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	})
+
+	links, err := a.GetWorkItemLinks("o/r", 5)
+	if err != nil {
+		t.Fatalf("GetWorkItemLinks() error = %v", err)
+	}
+	if len(links) != 2 || links[0].Type != provider.LinkParent || links[0].Item.Title != "Epic" ||
+		links[1].Type != provider.LinkChild || links[1].Item.Identity.ID != "8" {
+		t.Errorf("links = %+v", links)
+	}
+
+	links, err = a.GetWorkItemLinks("o/r", 8)
+	if err != nil || len(links) != 0 {
+		t.Errorf("an issue without parent or children: links = %+v, err = %v", links, err)
+	}
+}
+
+func TestAdapter_WorkItemLinks_AddAndRemove(t *testing.T) {
+	var added addSubIssueBody
+	var removed removeSubIssueBody
+	var paths []string
+	a := newSubIssueTestAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.Method+" "+r.URL.Path)
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/repos/o/r/issues/5":
+			w.Write([]byte(`{"id": 5005, "number": 5}`))
+		case r.Method == "GET" && r.URL.Path == "/repos/o/r/issues/8":
+			w.Write([]byte(`{"id": 8008, "number": 8}`))
+		case r.Method == "POST":
+			json.NewDecoder(r.Body).Decode(&added)
+			w.Write([]byte(`{}`))
+		case r.Method == "DELETE":
+			json.NewDecoder(r.Body).Decode(&removed)
+			w.Write([]byte(`{}`))
+		}
+	})
+
+	// 1 becomes the parent of 5: the sub-issue is added on 1 by 5's ID.
+	if err := a.AddWorkItemLink("o/r", 5, provider.LinkParent, 1); err != nil {
+		t.Fatalf("AddWorkItemLink() error = %v", err)
+	}
+	if added.SubIssueID != 5005 || paths[len(paths)-1] != "POST /repos/o/r/issues/1/sub_issues" {
+		t.Errorf("added %+v via %v", added, paths)
+	}
+
+	// 8 stops being a child of 5.
+	if err := a.RemoveWorkItemLink("o/r", 5, provider.LinkChild, 8); err != nil {
+		t.Fatalf("RemoveWorkItemLink() error = %v", err)
+	}
+	if removed.SubIssueID != 8008 || paths[len(paths)-1] != "DELETE /repos/o/r/issues/5/sub_issue" {
+		t.Errorf("removed %+v via %v", removed, paths)
+	}
+
+	if err := a.AddWorkItemLink("o/r", 5, provider.LinkRelated, 8); !errors.Is(err, provider.ErrLinkUnsupported) {
+		t.Errorf("related link error = %v, want ErrLinkUnsupported", err)
+	}
+}
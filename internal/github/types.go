@@ -20,6 +20,12 @@ type Label struct {
 	Description string `json:"description"`
 }
 
+// SubIssuesSummary is the sub-issue count embedded in issue payloads.
+type SubIssuesSummary struct {
+	Total     int `json:"total"`
+	Completed int `json:"completed"`
+}
+
 // Milestone represents a GitHub milestone wire type embedded in issue payloads.
 // Number is the milestone number; Title is the human-readable name.
 type Milestone struct {
@@ -42,6 +48,7 @@ type Milestone struct {
 // The field is decoded as a raw JSON value so that the presence/absence of the
 // "pull_request" key is detectable without importing the full PR wire type.
 type Issue struct {
+	ID          int64      `json:"id"`
 	Number      int        `json:"number"`
 	Title       string     `json:"title"`
 	Body        string     `json:"body"`
@@ -51,10 +58,13 @@ type Issue struct {
 	Assignee    *User      `json:"assignee"`
 	Labels      []Label    `json:"labels"`
 	Milestone   *Milestone `json:"milestone"`
-	CreatedAt   time.Time  `json:"created_at"`
-	UpdatedAt   time.Time  `json:"updated_at"`
-	ClosedAt    *time.Time `json:"closed_at"`
-	HTMLURL     string     `json:"html_url"`
+	// SubIssuesSummary counts the issue's sub-issues; nil on GitHub
+	// Enterprise versions without sub-issues.
+	SubIssuesSummary *SubIssuesSummary `json:"sub_issues_summary"`
+	CreatedAt        time.Time         `json:"created_at"`
+	UpdatedAt        time.Time         `json:"updated_at"`
+	ClosedAt         *time.Time        `json:"closed_at"`
+	HTMLURL          string            `json:"html_url"`
 	// PullRequest is non-nil when this object is a pull request masquerading as
 	// an issue. Filter out any Issue where PullRequest != nil.
 	PullRequest *json.RawMessage `json:"pull_request"`
@@ -66,12 +76,39 @@ type Issue struct {
 // UpdatedAt differs from CreatedAt only when the comment has been edited.
 // HTMLURL is the permalink to the comment on github.com.
 type IssueComment struct {
-	ID        int64     `json:"id"`
-	Body      string    `json:"body"`
-	User      User      `json:"user"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
-	HTMLURL   string    `json:"html_url"`
+	ID        int64          `json:"id"`
+	NodeID    string         `json:"node_id"`
+	Body      string         `json:"body"`
+	User      User           `json:"user"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	HTMLURL   string         `json:"html_url"`
+	Reactions ReactionRollup `json:"reactions"`
+}
+
+// ReactionRollup is the per-emoji reaction totals GitHub embeds in issue
+// and review comment payloads. It does not say who reacted.
+type ReactionRollup struct {
+	PlusOne  int `json:"+1"`
+	MinusOne int `json:"-1"`
+	Laugh    int `json:"laugh"`
+	Hooray   int `json:"hooray"`
+	Confused int `json:"confused"`
+	Heart    int `json:"heart"`
+	Rocket   int `json:"rocket"`
+	Eyes     int `json:"eyes"`
+
+	// Mine holds the contents ("+1", "heart", ...) of the reactions the
+	// current user left. The rollup does not say; see Client.markMine.
+	Mine map[string]bool `json:"-"`
+}
+
+// Reaction is a single reaction as listed by
+// GET /repos/{owner}/{repo}/{issues|pulls}/comments/{id}/reactions.
+type Reaction struct {
+	ID      int64  `json:"id"`
+	Content string `json:"content"`
+	User    User   `json:"user"`
 }
 
 // PullRequestBranch holds the branch reference and commit SHA within a pull request.
@@ -86,6 +123,8 @@ type PullRequestBranch struct {
 // ClosedAt and MergedAt are null while the PR is open.
 // RequestedReviewers lists reviewers who have been requested but have not yet
 // submitted a review; the reviews endpoint only returns those who already acted.
+// MergeableState is only returned by the single-PR GET, not by list endpoints;
+// it is "unknown" while GitHub computes it in the background.
 type PullRequest struct {
 	Number             int               `json:"number"`
 	Title              string            `json:"title"`
@@ -94,6 +133,7 @@ type PullRequest struct {
 	Draft              bool              `json:"draft"`
 	User               User              `json:"user"`
 	RequestedReviewers []User            `json:"requested_reviewers"`
+	Labels             []Label           `json:"labels"`
 	Head               PullRequestBranch `json:"head"`
 	Base               PullRequestBranch `json:"base"`
 	CreatedAt          time.Time         `json:"created_at"`
@@ -101,6 +141,7 @@ type PullRequest struct {
 	ClosedAt           *time.Time        `json:"closed_at"`
 	MergedAt           *time.Time        `json:"merged_at"`
 	HTMLURL            string            `json:"html_url"`
+	MergeableState     string            `json:"mergeable_state"`
 }
 
 // Review represents a GitHub REST pull request review wire type
@@ -119,18 +160,25 @@ type Review struct {
 // InReplyToID is null for the first (root) comment in a thread.
 // Line is null for some legacy comments not anchored to a specific line;
 // OriginalLine carries the anchor position when Line is null (outdated diff).
+// StartLine (OriginalStartLine) is set for multi-line comments, with Line as
+// the last line. Side is "LEFT" (base file) or "RIGHT" (head file).
 // HTMLURL is the permalink to the comment on github.com.
 type ReviewComment struct {
-	ID           int64     `json:"id"`
-	InReplyToID  *int64    `json:"in_reply_to_id"`
-	Path         string    `json:"path"`
-	Line         *int      `json:"line"`
-	OriginalLine *int      `json:"original_line"`
-	Body         string    `json:"body"`
-	User         User      `json:"user"`
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
-	HTMLURL      string    `json:"html_url"`
+	ID                int64          `json:"id"`
+	NodeID            string         `json:"node_id"`
+	InReplyToID       *int64         `json:"in_reply_to_id"`
+	Path              string         `json:"path"`
+	Line              *int           `json:"line"`
+	OriginalLine      *int           `json:"original_line"`
+	StartLine         *int           `json:"start_line"`
+	OriginalStartLine *int           `json:"original_start_line"`
+	Side              string         `json:"side"`
+	Body              string         `json:"body"`
+	User              User           `json:"user"`
+	CreatedAt         time.Time      `json:"created_at"`
+	UpdatedAt         time.Time      `json:"updated_at"`
+	HTMLURL           string         `json:"html_url"`
+	Reactions         ReactionRollup `json:"reactions"`
 }
 
 // WorkflowRun represents a GitHub Actions workflow run wire type
@@ -191,6 +239,7 @@ type Step struct {
 // PreviousFilename is non-empty only on renames.
 type PRFile struct {
 	Filename         string `json:"filename"`
+	SHA              string `json:"sha"`    // blob SHA of the file at the PR head
 	Status           string `json:"status"` // "added", "removed", "modified", "renamed", "copied", "changed", "unchanged"
 	PreviousFilename string `json:"previous_filename,omitempty"`
 	Changes          int    `json:"changes"`
@@ -0,0 +1,92 @@
+package github
+
+import "fmt"
+
+// viewedFilesResponse is the GraphQL response for one page of a pull
+// request's files with the viewer's viewed state.
+type viewedFilesResponse struct {
+	Data struct {
+		Repository struct {
+			PullRequest struct {
+				ID    string `json:"id"`
+				Files struct {
+					PageInfo struct {
+						HasNextPage bool   `json:"hasNextPage"`
+						EndCursor   string `json:"endCursor"`
+					} `json:"pageInfo"`
+					Nodes []struct {
+						Path              string `json:"path"`
+						ViewerViewedState string `json:"viewerViewedState"` // VIEWED, UNVIEWED or DISMISSED
+					} `json:"nodes"`
+				} `json:"files"`
+			} `json:"pullRequest"`
+		} `json:"repository"`
+	} `json:"data"`
+	Errors []graphqlError `json:"errors,omitempty"`
+}
+
+// GetViewedFiles returns the paths of the pull request's files that the
+// token's user marked as viewed. Files marked before their latest change
+// are DISMISSED by GitHub and not included. There is no REST equivalent, so
+// this pages through the GraphQL files connection.
+func (c *Client) GetViewedFiles(number int) ([]string, error) {
+	const query = `query($owner:String!,$repo:String!,$number:Int!,$after:String){repository(owner:$owner,name:$repo){pullRequest(number:$number){id files(first:100,after:$after){pageInfo{hasNextPage endCursor} nodes{path viewerViewedState}}}}}`
+
+	var viewed []string
+	var after any
+	for {
+		vars := map[string]any{"owner": c.owner, "repo": c.repo, "number": number, "after": after}
+		var resp viewedFilesResponse
+		if err := c.graphql(query, vars, &resp); err != nil {
+			return nil, fmt.Errorf("github: get viewed files: %w", err)
+		}
+		if len(resp.Errors) > 0 {
+			return nil, fmt.Errorf("github: get viewed files: graphql error: %s", resp.Errors[0].Message)
+		}
+		files := resp.Data.Repository.PullRequest.Files
+		for _, f := range files.Nodes {
+			if f.ViewerViewedState == "VIEWED" {
+				viewed = append(viewed, f.Path)
+			}
+		}
+		if !files.PageInfo.HasNextPage {
+			return viewed, nil
+		}
+		after = files.PageInfo.EndCursor
+	}
+}
+
+// SetFileViewed marks filePath as viewed in the pull request, or unmarks it
+// when viewed is false, via the markFileAsViewed / unmarkFileAsViewed
+// mutations. The mutations need the pull request's node ID, which costs one
+// extra query.
+func (c *Client) SetFileViewed(number int, filePath string, viewed bool) error {
+	const idQuery = `query($owner:String!,$repo:String!,$number:Int!){repository(owner:$owner,name:$repo){pullRequest(number:$number){id}}}`
+
+	var idResp viewedFilesResponse
+	vars := map[string]any{"owner": c.owner, "repo": c.repo, "number": number}
+	if err := c.graphql(idQuery, vars, &idResp); err != nil {
+		return fmt.Errorf("github: set file viewed: %w", err)
+	}
+	if len(idResp.Errors) > 0 {
+		return fmt.Errorf("github: set file viewed: graphql error: %s", idResp.Errors[0].Message)
+	}
+	prID := idResp.Data.Repository.PullRequest.ID
+	if prID == "" {
+		return fmt.Errorf("github: set file viewed: pull request #%d not found", number)
+	}
+
+	mutation := `mutation($id:ID!,$path:String!){markFileAsViewed(input:{pullRequestId:$id,path:$path}){clientMutationId}}`
+	if !viewed {
+		mutation = `mutation($id:ID!,$path:String!){unmarkFileAsViewed(input:{pullRequestId:$id,path:$path}){clientMutationId}}`
+	}
+	var mutResp resolveMutationResponse
+	mutVars := map[string]any{"id": prID, "path": filePath}
+	if err := c.graphql(mutation, mutVars, &mutResp); err != nil {
+		return fmt.Errorf("github: set file viewed (mutation): %w", err)
+	}
+	if len(mutResp.Errors) > 0 {
+		return fmt.Errorf("github: set file viewed: graphql mutation error: %s", mutResp.Errors[0].Message)
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package github
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestClient_GetViewedFiles_PagesAndKeepsOnlyViewed(t *testing.T) {
+	var afters []any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req graphqlRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		afters = append(afters, req.Variables["after"])
+		if req.Variables["after"] == nil {
+			w.Write([]byte(`{"data":{"repository":{"pullRequest":{"id":"PR_1","files":{
+				"pageInfo":{"hasNextPage":true,"endCursor":"c1"},
+				"nodes":[{"path":"a.go","viewerViewedState":"VIEWED"},{"path":"b.go","viewerViewedState":"DISMISSED"}]}}}}}`))
+			return
+		}
+		w.Write([]byte(`{"data":{"repository":{"pullRequest":{"id":"PR_1","files":{
+			"pageInfo":{"hasNextPage":false,"endCursor":"c2"},
+			"nodes":[{"path":"c.go","viewerViewedState":"VIEWED"},{"path":"d.go","viewerViewedState":"UNVIEWED"}]}}}}}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient("o", "r", "tok")
+	c.SetBaseURL(srv.URL)
+
+	got, err := c.GetViewedFiles(7)
+	if err != nil {
+		t.Fatalf("GetViewedFiles() error = %v", err)
+	}
+	if want := []string{"a.go", "c.go"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GetViewedFiles() = %v, want %v", got, want)
+	}
+	if want := []any{nil, "c1"}; !reflect.DeepEqual(afters, want) {
+		t.Errorf("page cursors = %v, want %v", afters, want)
+	}
+}
+
+func TestClient_SetFileViewed_LooksUpPRThenMutates(t *testing.T) {
+	var queries []graphqlRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req graphqlRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		queries = append(queries, req)
+		if strings.HasPrefix(req.Query, "query") {
+			w.Write([]byte(`{"data":{"repository":{"pullRequest":{"id":"PR_kw1"}}}}`))
+			return
+		}
+		w.Write([]byte(`{"data":{}}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient("o", "r", "tok")
+	c.SetBaseURL(srv.URL)
+
+	for _, viewed := range []bool{true, false} {
+		queries = nil
+		if err := c.SetFileViewed(7, "src/a.go", viewed); err != nil {
+			t.Fatalf("SetFileViewed(%v) error = %v", viewed, err)
+		}
+		if len(queries) != 2 {
+			t.Fatalf("made %d GraphQL calls, want 2", len(queries))
+		}
+		mut := queries[1]
+		wantOp := "markFileAsViewed"
+		if !viewed {
+			wantOp = "unmarkFileAsViewed"
+		}
+		if !strings.Contains(mut.Query, wantOp+"(") {
+			t.Errorf("mutation = %q, want %s", mut.Query, wantOp)
+		}
+		if mut.Variables["id"] != "PR_kw1" || mut.Variables["path"] != "src/a.go" {
+			t.Errorf("mutation variables = %v", mut.Variables)
+		}
+	}
+}
+
+func TestClient_SetFileViewed_ReportsGraphQLErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"errors":[{"message":"Could not resolve to a PullRequest"}]}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient("o", "r", "tok")
+	c.SetBaseURL(srv.URL)
+
+	err := c.SetFileViewed(7, "a.go", true)
+	if err == nil || !strings.Contains(err.Error(), "Could not resolve") {
+		t.Errorf("SetFileViewed() error = %v, want the GraphQL error", err)
+	}
+}
@@ -221,6 +221,13 @@ func (c *Client) GetWorkItemComments(number int) ([]IssueComment, error) {
 	if err := c.getJSON(path, &comments); err != nil {
 		return nil, fmt.Errorf("github: get work item comments: %w", err)
 	}
+	nodeIDs := make([]string, len(comments))
+	rollups := make([]*ReactionRollup, len(comments))
+	for i := range comments {
+		nodeIDs[i] = comments[i].NodeID
+		rollups[i] = &comments[i].Reactions
+	}
+	c.markMine(nodeIDs, rollups)
 	return comments, nil
 }
 
@@ -241,3 +248,19 @@ func (c *Client) AddWorkItemComment(number int, text string) (IssueComment, erro
 	}
 	return created, nil
 }
+
+// EditWorkItemComment replaces the body of an issue comment.
+func (c *Client) EditWorkItemComment(commentID int, text string) error {
+	if err := c.doJSON("PATCH", c.issueCommentPath(commentID), addCommentBody{Body: text}, nil); err != nil {
+		return fmt.Errorf("github: edit work item comment: %w", err)
+	}
+	return nil
+}
+
+// DeleteWorkItemComment deletes an issue comment.
+func (c *Client) DeleteWorkItemComment(commentID int) error {
+	if err := c.doJSON("DELETE", c.issueCommentPath(commentID), nil, nil); err != nil {
+		return fmt.Errorf("github: delete work item comment: %w", err)
+	}
+	return nil
+}
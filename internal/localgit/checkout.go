@@ -0,0 +1,150 @@
+package localgit
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// CheckoutMode chooses where a pull request is checked out.
+type CheckoutMode int
+
+const (
+	CheckoutBranch   CheckoutMode = iota // switch the working copy to the branch
+	CheckoutWorktree                     // add a worktree for the branch beside the working copy
+)
+
+// CheckoutRequest describes a pull request to check out.
+type CheckoutRequest struct {
+	Remote string // remote to fetch from
+	// Refs are the remote refs to try in order; the first one that can be
+	// fetched is checked out.
+	Refs   []string
+	Branch string // local branch to create, or fast-forward if it exists
+	Mode   CheckoutMode
+	// WorktreePath is where CheckoutWorktree adds the worktree; empty means
+	// a sibling of the working copy named after it and the branch.
+	WorktreePath string
+}
+
+// CheckoutResult describes a completed checkout.
+type CheckoutResult struct {
+	Branch  string
+	Ref     string // the remote ref that was fetched
+	Commit  string
+	Path    string // directory holding the checkout
+	Created bool   // whether the local branch was new
+}
+
+// DirtyTreeError is returned when switching branches would disturb
+// uncommitted changes to tracked files.
+type DirtyTreeError struct {
+	Files []string
+}
+
+func (e *DirtyTreeError) Error() string {
+	const shown = 3
+	files := e.Files
+	more := ""
+	if len(files) > shown {
+		more = fmt.Sprintf(" and %d more", len(files)-shown)
+		files = files[:shown]
+	}
+	return fmt.Sprintf("uncommitted changes in %s%s; commit or stash them, or check out into a worktree",
+		strings.Join(files, ", "), more)
+}
+
+// Checkout fetches the pull request ref and checks it out in a local
+// branch, in the working copy or in a new worktree. Switching the working
+// copy is refused with a *DirtyTreeError while tracked files have
+// uncommitted changes; untracked files are left alone. An existing branch
+// is only fast-forwarded, never reset, so local commits on it are kept.
+func (r *Repo) Checkout(req CheckoutRequest) (CheckoutResult, error) {
+	if req.Branch == "" || len(req.Refs) == 0 {
+		return CheckoutResult{}, errors.New("localgit: checkout needs a branch and a ref")
+	}
+	if req.Mode == CheckoutBranch {
+		if err := r.checkClean(); err != nil {
+			return CheckoutResult{}, err
+		}
+	}
+
+	res := CheckoutResult{Branch: req.Branch, Path: r.Root}
+	commit, ref, err := r.fetchFirst(req.Remote, req.Refs)
+	if err != nil {
+		return CheckoutResult{}, err
+	}
+	res.Commit, res.Ref = commit, ref
+	_, verifyErr := r.git("rev-parse", "--verify", "--quiet", "refs/heads/"+req.Branch)
+	res.Created = verifyErr != nil
+
+	switch req.Mode {
+	case CheckoutWorktree:
+		res.Path = req.WorktreePath
+		if res.Path == "" {
+			res.Path = r.defaultWorktreePath(req.Branch)
+		}
+		if _, err := os.Stat(res.Path); err == nil {
+			return CheckoutResult{}, fmt.Errorf("worktree path %s already exists", res.Path)
+		}
+		if res.Created {
+			_, err = r.git("worktree", "add", "-b", req.Branch, res.Path, commit)
+		} else if _, err = r.git("worktree", "add", res.Path, req.Branch); err == nil {
+			_, err = runGit(res.Path, "merge", "--ff-only", commit)
+		}
+	default:
+		if res.Created {
+			_, err = r.git("checkout", "-b", req.Branch, commit)
+		} else if _, err = r.git("checkout", req.Branch); err == nil {
+			_, err = r.git("merge", "--ff-only", commit)
+		}
+	}
+	if err != nil {
+		return CheckoutResult{}, err
+	}
+	return res, nil
+}
+
+// checkClean returns a *DirtyTreeError listing the tracked files with
+// uncommitted changes, if any.
+func (r *Repo) checkClean() error {
+	out, err := r.git("status", "--porcelain", "--untracked-files=no")
+	if err != nil {
+		return err
+	}
+	var files []string
+	for _, line := range strings.Split(out, "\n") {
+		if len(line) > 3 {
+			files = append(files, line[3:])
+		}
+	}
+	if len(files) > 0 {
+		return &DirtyTreeError{Files: files}
+	}
+	return nil
+}
+
+// fetchFirst fetches the first of refs that the remote has and returns
+// the commit it points at.
+func (r *Repo) fetchFirst(remote string, refs []string) (commit, ref string, err error) {
+	for _, ref := range refs {
+		if _, err = r.git("fetch", "--no-tags", remote, ref); err != nil {
+			continue
+		}
+		out, err := r.git("rev-parse", "FETCH_HEAD")
+		if err != nil {
+			return "", "", err
+		}
+		return strings.TrimSpace(out), ref, nil
+	}
+	return "", "", err
+}
+
+// defaultWorktreePath places a worktree beside the working copy, e.g.
+// "../azdo-pr-42" for branch "pr/42" of "azdo".
+func (r *Repo) defaultWorktreePath(branch string) string {
+	name := filepath.Base(r.Root) + "-" + strings.NewReplacer("/", "-", "\\", "-").Replace(branch)
+	return filepath.Join(filepath.Dir(r.Root), name)
+}
@@ -0,0 +1,119 @@
+package localgit
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// pushPRRef commits a change on top of main in a scratch clone of bare
+// and pushes it as ref, the way a server publishes refs/pull/N/head.
+func pushPRRef(t *testing.T, bare, ref, content string) string {
+	t.Helper()
+	scratch := filepath.Join(t.TempDir(), "pr")
+	mustGit(t, filepath.Dir(scratch), "clone", "-q", bare, scratch)
+	commit := commitFile(t, scratch, "feature.txt", content)
+	mustGit(t, scratch, "push", "-q", "origin", "HEAD:"+ref)
+	return commit
+}
+
+func TestCheckout_BranchFromPullRef(t *testing.T) {
+	bare, clone := newRemoteAndClone(t)
+	want := pushPRRef(t, bare, "refs/pull/7/head", "v1\n")
+	repo, _ := Open(clone)
+
+	res, err := repo.Checkout(CheckoutRequest{
+		Remote: "origin",
+		Refs:   []string{"refs/heads/missing", "refs/pull/7/head"},
+		Branch: "pr/7",
+	})
+	if err != nil {
+		t.Fatalf("Checkout() error = %v", err)
+	}
+	if res.Ref != "refs/pull/7/head" || res.Commit != want || !res.Created {
+		t.Errorf("result = %+v, want fallback ref at %s, created", res, want)
+	}
+	if got := mustGit(t, clone, "rev-parse", "--abbrev-ref", "HEAD"); got != "pr/7" {
+		t.Errorf("HEAD = %q, want pr/7", got)
+	}
+
+	// Checking out again after a new push fast-forwards the branch.
+	mustGit(t, clone, "checkout", "-q", "main")
+	scratch := filepath.Join(t.TempDir(), "again")
+	mustGit(t, filepath.Dir(scratch), "clone", "-q", bare, scratch)
+	mustGit(t, scratch, "fetch", "-q", "origin", "refs/pull/7/head")
+	mustGit(t, scratch, "checkout", "-q", "FETCH_HEAD")
+	next := commitFile(t, scratch, "feature.txt", "v2\n")
+	mustGit(t, scratch, "push", "-q", "origin", "HEAD:refs/pull/7/head")
+
+	res, err = repo.Checkout(CheckoutRequest{Remote: "origin", Refs: []string{"refs/pull/7/head"}, Branch: "pr/7"})
+	if err != nil {
+		t.Fatalf("second Checkout() error = %v", err)
+	}
+	if res.Created || mustGit(t, clone, "rev-parse", "HEAD") != next {
+		t.Errorf("second checkout = %+v, want pr/7 fast-forwarded to %s", res, next)
+	}
+}
+
+func TestCheckout_RefusesDirtyTree(t *testing.T) {
+	bare, clone := newRemoteAndClone(t)
+	pushPRRef(t, bare, "refs/pull/7/head", "v1\n")
+	repo, _ := Open(clone)
+	if err := os.WriteFile(filepath.Join(clone, "README.md"), []byte("edited\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := repo.Checkout(CheckoutRequest{Remote: "origin", Refs: []string{"refs/pull/7/head"}, Branch: "pr/7"})
+	var dirty *DirtyTreeError
+	if !errors.As(err, &dirty) {
+		t.Fatalf("Checkout() error = %v, want *DirtyTreeError", err)
+	}
+	if len(dirty.Files) != 1 || dirty.Files[0] != "README.md" {
+		t.Errorf("dirty files = %v, want [README.md]", dirty.Files)
+	}
+	if got := mustGit(t, clone, "rev-parse", "--abbrev-ref", "HEAD"); got != "main" {
+		t.Errorf("HEAD = %q, want main left alone", got)
+	}
+}
+
+func TestCheckout_WorktreeIgnoresDirtyTree(t *testing.T) {
+	bare, clone := newRemoteAndClone(t)
+	want := pushPRRef(t, bare, "refs/pull/7/head", "v1\n")
+	repo, _ := Open(clone)
+	if err := os.WriteFile(filepath.Join(clone, "README.md"), []byte("edited\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := repo.Checkout(CheckoutRequest{
+		Remote: "origin",
+		Refs:   []string{"refs/pull/7/head"},
+		Branch: "pr/7",
+		Mode:   CheckoutWorktree,
+	})
+	if err != nil {
+		t.Fatalf("Checkout() error = %v", err)
+	}
+	if res.Path != filepath.Join(filepath.Dir(repo.Root), "work-pr-7") {
+		t.Errorf("Path = %q, want sibling work-pr-7", res.Path)
+	}
+	if got := mustGit(t, res.Path, "rev-parse", "HEAD"); got != want {
+		t.Errorf("worktree HEAD = %s, want %s", got, want)
+	}
+	if got := mustGit(t, clone, "rev-parse", "--abbrev-ref", "HEAD"); got != "main" {
+		t.Errorf("working copy HEAD = %q, want main", got)
+	}
+
+	if _, err := repo.Checkout(CheckoutRequest{Remote: "origin", Refs: []string{"refs/pull/7/head"}, Branch: "pr/7", Mode: CheckoutWorktree}); err == nil {
+		t.Error("second worktree checkout into the same path: want error")
+	}
+}
+
+func TestCheckout_UnknownRefReportsGitError(t *testing.T) {
+	_, clone := newRemoteAndClone(t)
+	repo, _ := Open(clone)
+	_, err := repo.Checkout(CheckoutRequest{Remote: "origin", Refs: []string{"refs/pull/99/head"}, Branch: "pr/99"})
+	if err == nil {
+		t.Fatal("Checkout() of a missing ref: want error")
+	}
+}
@@ -0,0 +1,65 @@
+// Package localgit drives the git working copy azdo was started in: it
+// reads the remotes to find which repository the checkout belongs to and
+// checks out pull request branches. It shells out to the git binary, so
+// the user's credential helpers and SSH setup apply to fetches.
+package localgit
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// ErrNotRepository is returned by Open when the directory is not inside a
+// git working copy (or git is not installed).
+var ErrNotRepository = errors.New("localgit: not a git working copy")
+
+// Repo is a git working copy.
+type Repo struct {
+	// Root is the top-level directory of the working copy.
+	Root string
+}
+
+// Open returns the working copy containing dir.
+func Open(dir string) (*Repo, error) {
+	out, err := runGit(dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, ErrNotRepository
+	}
+	return &Repo{Root: strings.TrimSpace(out)}, nil
+}
+
+// git runs a git command in the working copy and returns its stdout.
+func (r *Repo) git(args ...string) (string, error) {
+	return runGit(r.Root, args...)
+}
+
+// runGit runs git with args in dir. A failure carries git's stderr, which
+// is what explains it to the user.
+func runGit(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		return "", fmt.Errorf("git %s: %s", args[0], msg)
+	}
+	return stdout.String(), nil
+}
+
+// CurrentBranch returns the short name of the checked-out branch, or ""
+// when HEAD is detached.
+func (r *Repo) CurrentBranch() string {
+	out, err := r.git("symbolic-ref", "--quiet", "--short", "HEAD")
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(out)
+}
@@ -0,0 +1,108 @@
+package localgit
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// gitEnv isolates git from the user's configuration and gives commits a
+// fixed identity.
+func gitEnv(t *testing.T) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	t.Setenv("GIT_CONFIG_GLOBAL", os.DevNull)
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	t.Setenv("GIT_AUTHOR_NAME", "Test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "Test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+}
+
+// mustGit runs git in dir and fails the test on error.
+func mustGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	out, err := runGit(dir, args...)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	return strings.TrimSpace(out)
+}
+
+// commitFile writes content to name in dir and commits it.
+func commitFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	mustGit(t, dir, "add", name)
+	mustGit(t, dir, "commit", "-q", "-m", "update "+name)
+	return mustGit(t, dir, "rev-parse", "HEAD")
+}
+
+// newRemoteAndClone creates a bare "origin" repository with one commit on
+// main and a working-copy clone of it, returning both paths.
+func newRemoteAndClone(t *testing.T) (bare, clone string) {
+	t.Helper()
+	gitEnv(t)
+	root := t.TempDir()
+	bare = filepath.Join(root, "origin.git")
+	mustGit(t, root, "init", "-q", "--bare", "-b", "main", bare)
+
+	seed := filepath.Join(root, "seed")
+	mustGit(t, root, "clone", "-q", bare, seed)
+	mustGit(t, seed, "checkout", "-q", "-b", "main")
+	commitFile(t, seed, "README.md", "hello\n")
+	mustGit(t, seed, "push", "-q", "origin", "main")
+
+	clone = filepath.Join(root, "work")
+	mustGit(t, root, "clone", "-q", bare, clone)
+	return bare, clone
+}
+
+func TestOpen_FindsTopLevelFromSubdirectory(t *testing.T) {
+	_, clone := newRemoteAndClone(t)
+	sub := filepath.Join(clone, "sub")
+	if err := os.Mkdir(sub, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	repo, err := Open(sub)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	want, _ := filepath.EvalSymlinks(clone)
+	got, _ := filepath.EvalSymlinks(repo.Root)
+	if got != want {
+		t.Errorf("Root = %q, want %q", got, want)
+	}
+}
+
+func TestRepo_CurrentBranch(t *testing.T) {
+	_, clone := newRemoteAndClone(t)
+	repo, _ := Open(clone)
+	if got := repo.CurrentBranch(); got != "main" {
+		t.Errorf("CurrentBranch() = %q, want main", got)
+	}
+	mustGit(t, clone, "checkout", "-q", "-b", "feature/x")
+	if got := repo.CurrentBranch(); got != "feature/x" {
+		t.Errorf("CurrentBranch() = %q, want feature/x", got)
+	}
+	mustGit(t, clone, "checkout", "-q", "--detach")
+	if got := repo.CurrentBranch(); got != "" {
+		t.Errorf("CurrentBranch() on detached HEAD = %q, want empty", got)
+	}
+}
+
+func TestOpen_OutsideRepository(t *testing.T) {
+	gitEnv(t)
+	t.Setenv("GIT_CEILING_DIRECTORIES", os.TempDir())
+	if _, err := Open(t.TempDir()); !errors.Is(err, ErrNotRepository) {
+		t.Errorf("Open() error = %v, want ErrNotRepository", err)
+	}
+}
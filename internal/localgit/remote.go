@@ -0,0 +1,201 @@
+package localgit
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/Elpulgo/azdo/internal/config"
+	"github.com/Elpulgo/azdo/internal/provider"
+)
+
+// Remote is a git remote whose URL points at a repository on a supported
+// host.
+type Remote struct {
+	Name string
+	URL  string
+	Kind provider.Kind
+	// Org is the Azure DevOps organization or the GitHub owner.
+	Org string
+	// Project is the Azure DevOps project; empty for GitHub.
+	Project string
+	Repo    string
+}
+
+// Scope returns the scope the remote's repository is listed under: the
+// project for Azure DevOps, the "owner/repo" slug for GitHub.
+func (r Remote) Scope() string {
+	if r.Kind == provider.KindGitHub {
+		return r.Org + "/" + r.Repo
+	}
+	return r.Project
+}
+
+// Remotes returns the remotes of the working copy that point at Azure
+// DevOps or GitHub, "origin" first and the rest by name.
+func (r *Repo) Remotes() ([]Remote, error) {
+	out, err := r.git("config", "--get-regexp", `^remote\..*\.url$`)
+	if err != nil {
+		// git config exits 1 when nothing matches: no remotes.
+		return nil, nil
+	}
+	var remotes []Remote
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		key, rawURL, ok := strings.Cut(line, " ")
+		if !ok {
+			continue
+		}
+		name := strings.TrimSuffix(strings.TrimPrefix(key, "remote."), ".url")
+		if remote, ok := ParseRemoteURL(rawURL); ok {
+			remote.Name = name
+			remotes = append(remotes, remote)
+		}
+	}
+	sort.SliceStable(remotes, func(i, j int) bool {
+		if (remotes[i].Name == "origin") != (remotes[j].Name == "origin") {
+			return remotes[i].Name == "origin"
+		}
+		return remotes[i].Name < remotes[j].Name
+	})
+	return remotes, nil
+}
+
+// ParseRemoteURL recognizes Azure DevOps and GitHub remote URLs in their
+// HTTPS and SSH forms:
+//
+//	https://dev.azure.com/org/project/_git/repo
+//	https://org.visualstudio.com/project/_git/repo
+//	git@ssh.dev.azure.com:v3/org/project/repo
+//	https://github.com/owner/repo.git
+//	git@github.com:owner/repo.git
+//
+// The Name field of the result is left empty.
+func ParseRemoteURL(rawURL string) (Remote, bool) {
+	host, path := splitRemoteURL(strings.TrimSpace(rawURL))
+	host = strings.ToLower(host)
+	segs := strings.Split(strings.Trim(path, "/"), "/")
+	for i, s := range segs {
+		if u, err := url.PathUnescape(s); err == nil {
+			segs[i] = u
+		}
+	}
+	remote := Remote{URL: rawURL}
+
+	switch {
+	case host == "github.com":
+		if len(segs) != 2 {
+			return Remote{}, false
+		}
+		remote.Kind = provider.KindGitHub
+		remote.Org, remote.Repo = segs[0], strings.TrimSuffix(segs[1], ".git")
+
+	case host == "dev.azure.com":
+		// org/project/_git/repo
+		if len(segs) != 4 || segs[2] != "_git" {
+			return Remote{}, false
+		}
+		remote.Kind = provider.KindAzure
+		remote.Org, remote.Project, remote.Repo = segs[0], segs[1], segs[3]
+
+	case host == "ssh.dev.azure.com" || host == "vs-ssh.visualstudio.com":
+		// v3/org/project/repo
+		if len(segs) != 4 || segs[0] != "v3" {
+			return Remote{}, false
+		}
+		remote.Kind = provider.KindAzure
+		remote.Org, remote.Project, remote.Repo = segs[1], segs[2], segs[3]
+
+	case strings.HasSuffix(host, ".visualstudio.com"):
+		// [DefaultCollection/]project/_git/repo
+		if len(segs) > 0 && strings.EqualFold(segs[0], "DefaultCollection") {
+			segs = segs[1:]
+		}
+		if len(segs) != 3 || segs[1] != "_git" {
+			return Remote{}, false
+		}
+		remote.Kind = provider.KindAzure
+		remote.Org = strings.TrimSuffix(host, ".visualstudio.com")
+		remote.Project, remote.Repo = segs[0], segs[2]
+
+	default:
+		return Remote{}, false
+	}
+
+	if remote.Org == "" || remote.Repo == "" || (remote.Kind == provider.KindAzure && remote.Project == "") {
+		return Remote{}, false
+	}
+	return remote, true
+}
+
+// splitRemoteURL returns the host and path of a URL-style remote
+// (https://, ssh://) or an scp-style one (user@host:path).
+func splitRemoteURL(rawURL string) (host, path string) {
+	if strings.Contains(rawURL, "://") {
+		u, err := url.Parse(rawURL)
+		if err != nil {
+			return "", ""
+		}
+		return u.Hostname(), u.EscapedPath()
+	}
+	hostPart, path, ok := strings.Cut(rawURL, ":")
+	if !ok {
+		return "", ""
+	}
+	if _, h, found := strings.Cut(hostPart, "@"); found {
+		hostPart = h
+	}
+	return hostPart, path
+}
+
+// RemoteFor returns the remote pointing at the repository of pr, if any.
+// Azure DevOps repositories match on project and repository name, GitHub
+// ones on the "owner/repo" slug; both case-insensitively.
+func RemoteFor(remotes []Remote, pr provider.PullRequest) (Remote, bool) {
+	for _, r := range remotes {
+		if r.Kind != pr.Identity.Kind {
+			continue
+		}
+		switch r.Kind {
+		case provider.KindGitHub:
+			if strings.EqualFold(r.Scope(), pr.Identity.Scope) {
+				return r, true
+			}
+		default:
+			if strings.EqualFold(r.Project, pr.Identity.Scope) && strings.EqualFold(r.Repo, pr.RepositoryName) {
+				return r, true
+			}
+		}
+	}
+	return Remote{}, false
+}
+
+// ConfiguredRemote returns the first remote whose repository is covered by
+// cfg: an Azure DevOps remote of the configured organization and one of
+// its projects, or a GitHub remote listed in the configured repos. The
+// result carries the configured spelling of the project or slug, so its
+// Scope matches the scopes the backends report.
+func ConfiguredRemote(remotes []Remote, cfg *config.Config) (Remote, bool) {
+	for _, r := range remotes {
+		switch r.Kind {
+		case provider.KindGitHub:
+			for _, slug := range cfg.GitHub.Repos {
+				owner, repo, ok := strings.Cut(slug, "/")
+				if ok && strings.EqualFold(slug, r.Scope()) {
+					r.Org, r.Repo = owner, repo
+					return r, true
+				}
+			}
+		case provider.KindAzure:
+			if !strings.EqualFold(cfg.Organization, r.Org) {
+				continue
+			}
+			for _, project := range cfg.Projects {
+				if strings.EqualFold(project, r.Project) {
+					r.Project = project
+					return r, true
+				}
+			}
+		}
+	}
+	return Remote{}, false
+}
@@ -0,0 +1,123 @@
+package localgit
+
+import (
+	"testing"
+
+	"github.com/Elpulgo/azdo/internal/config"
+	"github.com/Elpulgo/azdo/internal/provider"
+)
+
+func TestParseRemoteURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		want Remote
+	}{
+		{"https://dev.azure.com/org/proj/_git/repo", Remote{Kind: provider.KindAzure, Org: "org", Project: "proj", Repo: "repo"}},
+		{"https://org@dev.azure.com/org/My%20Project/_git/my-repo", Remote{Kind: provider.KindAzure, Org: "org", Project: "My Project", Repo: "my-repo"}},
+		{"git@ssh.dev.azure.com:v3/org/proj/repo", Remote{Kind: provider.KindAzure, Org: "org", Project: "proj", Repo: "repo"}},
+		{"org@vs-ssh.visualstudio.com:v3/org/proj/repo", Remote{Kind: provider.KindAzure, Org: "org", Project: "proj", Repo: "repo"}},
+		{"https://org.visualstudio.com/proj/_git/repo", Remote{Kind: provider.KindAzure, Org: "org", Project: "proj", Repo: "repo"}},
+		{"https://org.visualstudio.com/DefaultCollection/proj/_git/repo", Remote{Kind: provider.KindAzure, Org: "org", Project: "proj", Repo: "repo"}},
+		{"https://github.com/owner/repo.git", Remote{Kind: provider.KindGitHub, Org: "owner", Repo: "repo"}},
+		{"https://github.com/owner/repo", Remote{Kind: provider.KindGitHub, Org: "owner", Repo: "repo"}},
+		{"git@github.com:owner/repo.git", Remote{Kind: provider.KindGitHub, Org: "owner", Repo: "repo"}},
+		{"ssh://git@github.com/owner/repo.git", Remote{Kind: provider.KindGitHub, Org: "owner", Repo: "repo"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			got, ok := ParseRemoteURL(tt.url)
+			if !ok {
+				t.Fatalf("ParseRemoteURL(%q) not recognized", tt.url)
+			}
+			tt.want.URL = tt.url
+			if got != tt.want {
+				t.Errorf("ParseRemoteURL(%q) = %+v, want %+v", tt.url, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRemoteURL_RejectsOtherRemotes(t *testing.T) {
+	for _, u := range []string{
+		"https://gitlab.com/owner/repo.git",
+		"/srv/git/repo.git",
+		"https://github.com/owner",
+		"https://dev.azure.com/org/proj/repo",
+		"git@ssh.dev.azure.com:v2/org/proj/repo",
+	} {
+		if got, ok := ParseRemoteURL(u); ok {
+			t.Errorf("ParseRemoteURL(%q) = %+v, want not recognized", u, got)
+		}
+	}
+}
+
+func TestRepo_Remotes_OriginFirst(t *testing.T) {
+	_, clone := newRemoteAndClone(t)
+	repo, err := Open(clone)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mustGit(t, clone, "remote", "set-url", "origin", "git@github.com:me/fork.git")
+	mustGit(t, clone, "remote", "add", "azure", "https://dev.azure.com/org/proj/_git/repo")
+	mustGit(t, clone, "remote", "add", "local", "/srv/git/repo.git")
+
+	remotes, err := repo.Remotes()
+	if err != nil {
+		t.Fatalf("Remotes() error = %v", err)
+	}
+	if len(remotes) != 2 || remotes[0].Name != "origin" || remotes[1].Name != "azure" {
+		t.Fatalf("Remotes() = %+v, want origin then azure", remotes)
+	}
+	if remotes[0].Scope() != "me/fork" || remotes[1].Scope() != "proj" {
+		t.Errorf("scopes = %q, %q", remotes[0].Scope(), remotes[1].Scope())
+	}
+}
+
+func TestRemoteFor(t *testing.T) {
+	remotes := []Remote{
+		{Name: "origin", Kind: provider.KindGitHub, Org: "me", Repo: "fork"},
+		{Name: "upstream", Kind: provider.KindGitHub, Org: "Owner", Repo: "Repo"},
+		{Name: "azure", Kind: provider.KindAzure, Org: "org", Project: "proj", Repo: "repo"},
+	}
+
+	gh := provider.PullRequest{Identity: provider.Identity{Kind: provider.KindGitHub, Scope: "owner/repo"}}
+	if got, ok := RemoteFor(remotes, gh); !ok || got.Name != "upstream" {
+		t.Errorf("RemoteFor(github) = %+v, %v, want upstream", got, ok)
+	}
+
+	az := provider.PullRequest{Identity: provider.Identity{Kind: provider.KindAzure, Scope: "Proj"}, RepositoryName: "REPO"}
+	if got, ok := RemoteFor(remotes, az); !ok || got.Name != "azure" {
+		t.Errorf("RemoteFor(azure) = %+v, %v, want azure", got, ok)
+	}
+
+	other := provider.PullRequest{Identity: provider.Identity{Kind: provider.KindAzure, Scope: "proj"}, RepositoryName: "other"}
+	if _, ok := RemoteFor(remotes, other); ok {
+		t.Error("RemoteFor() matched a different repository")
+	}
+}
+
+func TestConfiguredRemote(t *testing.T) {
+	cfg := &config.Config{
+		Organization: "Contoso",
+		Projects:     []string{"Web Shop", "Infra"},
+		GitHub:       config.GitHubConfig{Repos: []string{"Owner/Repo"}},
+	}
+	fork := Remote{Name: "origin", Kind: provider.KindGitHub, Org: "me", Repo: "fork"}
+	upstream := Remote{Name: "upstream", Kind: provider.KindGitHub, Org: "owner", Repo: "repo"}
+	otherOrg := Remote{Name: "old", Kind: provider.KindAzure, Org: "fabrikam", Project: "infra", Repo: "tools"}
+	azure := Remote{Name: "azure", Kind: provider.KindAzure, Org: "contoso", Project: "web shop", Repo: "shop"}
+
+	got, ok := ConfiguredRemote([]Remote{fork, upstream, azure}, cfg)
+	if !ok || got.Name != "upstream" || got.Scope() != "Owner/Repo" {
+		t.Errorf("ConfiguredRemote() = %+v, %v, want upstream as Owner/Repo", got, ok)
+	}
+
+	got, ok = ConfiguredRemote([]Remote{fork, otherOrg, azure}, cfg)
+	if !ok || got.Name != "azure" || got.Scope() != "Web Shop" {
+		t.Errorf("ConfiguredRemote() = %+v, %v, want azure in Web Shop", got, ok)
+	}
+
+	if got, ok := ConfiguredRemote([]Remote{fork, otherOrg}, cfg); ok {
+		t.Errorf("ConfiguredRemote() = %+v, want no match", got)
+	}
+}
@@ -284,13 +284,49 @@ func (cp *CompositeProvider) GetFileContent(scope, repositoryID string, filePath
 	return b.GetFileContent(scope, repositoryID, filePath, branchName)
 }
 
+// GetFileContentAtCommit delegates to the backend registered for scope.
+func (cp *CompositeProvider) GetFileContentAtCommit(scope, repositoryID string, filePath string, commitID string) (string, error) {
+	b := cp.backendFor(scope)
+	if b == nil {
+		return "", routeErr(scope)
+	}
+	return b.GetFileContentAtCommit(scope, repositoryID, filePath, commitID)
+}
+
+// GetPRCommits delegates to the backend registered for scope.
+func (cp *CompositeProvider) GetPRCommits(scope, repositoryID string, pullRequestID int) ([]Commit, error) {
+	b := cp.backendFor(scope)
+	if b == nil {
+		return nil, routeErr(scope)
+	}
+	return b.GetPRCommits(scope, repositoryID, pullRequestID)
+}
+
+// GetCommitChanges delegates to the backend registered for scope.
+func (cp *CompositeProvider) GetCommitChanges(scope, repositoryID string, commitID string) (*CommitChanges, error) {
+	b := cp.backendFor(scope)
+	if b == nil {
+		return nil, routeErr(scope)
+	}
+	return b.GetCommitChanges(scope, repositoryID, commitID)
+}
+
+// GetPRMergeability delegates to the backend registered for scope.
+func (cp *CompositeProvider) GetPRMergeability(scope, repositoryID string, pullRequestID int) (*Mergeability, error) {
+	b := cp.backendFor(scope)
+	if b == nil {
+		return nil, routeErr(scope)
+	}
+	return b.GetPRMergeability(scope, repositoryID, pullRequestID)
+}
+
 // AddPRCodeComment delegates to the backend registered for scope.
-func (cp *CompositeProvider) AddPRCodeComment(scope, repositoryID string, pullRequestID int, filePath string, line int, content string) (*Thread, error) {
+func (cp *CompositeProvider) AddPRCodeComment(scope, repositoryID string, pullRequestID int, filePath string, rng LineRange, content string) (*Thread, error) {
 	b := cp.backendFor(scope)
 	if b == nil {
 		return nil, routeErr(scope)
 	}
-	return b.AddPRCodeComment(scope, repositoryID, pullRequestID, filePath, line, content)
+	return b.AddPRCodeComment(scope, repositoryID, pullRequestID, filePath, rng, content)
 }
 
 // AddPRComment delegates to the backend registered for scope.
@@ -320,6 +356,69 @@ func (cp *CompositeProvider) UpdateThreadStatus(scope, repositoryID string, pull
 	return b.UpdateThreadStatus(scope, repositoryID, pullRequestID, threadID, status)
 }
 
+// EditPRComment delegates to the backend registered for scope.
+func (cp *CompositeProvider) EditPRComment(scope, repositoryID string, pullRequestID, threadID, commentID int, content string) error {
+	b := cp.backendFor(scope)
+	if b == nil {
+		return routeErr(scope)
+	}
+	return b.EditPRComment(scope, repositoryID, pullRequestID, threadID, commentID, content)
+}
+
+// DeletePRComment delegates to the backend registered for scope.
+func (cp *CompositeProvider) DeletePRComment(scope, repositoryID string, pullRequestID, threadID, commentID int) error {
+	b := cp.backendFor(scope)
+	if b == nil {
+		return routeErr(scope)
+	}
+	return b.DeletePRComment(scope, repositoryID, pullRequestID, threadID, commentID)
+}
+
+// ReactToPRComment delegates to the backend registered for scope.
+func (cp *CompositeProvider) ReactToPRComment(scope, repositoryID string, pullRequestID, threadID, commentID int, reaction Reaction, remove bool) error {
+	b := cp.backendFor(scope)
+	if b == nil {
+		return routeErr(scope)
+	}
+	return b.ReactToPRComment(scope, repositoryID, pullRequestID, threadID, commentID, reaction, remove)
+}
+
+// SubmitReview delegates to the backend registered for scope.
+func (cp *CompositeProvider) SubmitReview(scope, repositoryID string, pullRequestID int, review Review) error {
+	b := cp.backendFor(scope)
+	if b == nil {
+		return routeErr(scope)
+	}
+	return b.SubmitReview(scope, repositoryID, pullRequestID, review)
+}
+
+// ApplyFileEdit delegates to the backend registered for scope.
+func (cp *CompositeProvider) ApplyFileEdit(scope, repositoryID string, edit FileEdit) error {
+	b := cp.backendFor(scope)
+	if b == nil {
+		return routeErr(scope)
+	}
+	return b.ApplyFileEdit(scope, repositoryID, edit)
+}
+
+// GetViewedFiles delegates to the backend registered for scope.
+func (cp *CompositeProvider) GetViewedFiles(scope, repositoryID string, pullRequestID int) ([]string, error) {
+	b := cp.backendFor(scope)
+	if b == nil {
+		return nil, routeErr(scope)
+	}
+	return b.GetViewedFiles(scope, repositoryID, pullRequestID)
+}
+
+// SetFileViewed delegates to the backend registered for scope.
+func (cp *CompositeProvider) SetFileViewed(scope, repositoryID string, pullRequestID int, filePath string, viewed bool) error {
+	b := cp.backendFor(scope)
+	if b == nil {
+		return routeErr(scope)
+	}
+	return b.SetFileViewed(scope, repositoryID, pullRequestID, filePath, viewed)
+}
+
 // --- Work-item list methods ---
 
 // ListWorkItems fans out to all backends concurrently, merges, and sorts by
@@ -432,6 +531,168 @@ func (cp *CompositeProvider) UpdateWorkItemState(scope string, id int, state str
 	return b.UpdateWorkItemState(scope, id, state)
 }
 
+// GetWorkItemTypes delegates to the backend registered for scope.
+func (cp *CompositeProvider) GetWorkItemTypes(scope string) ([]string, error) {
+	b := cp.backendFor(scope)
+	if b == nil {
+		return nil, routeErr(scope)
+	}
+	return b.GetWorkItemTypes(scope)
+}
+
+// CreateWorkItem delegates to the backend registered for scope.
+func (cp *CompositeProvider) CreateWorkItem(scope string, item NewWorkItem) (*WorkItem, error) {
+	b := cp.backendFor(scope)
+	if b == nil {
+		return nil, routeErr(scope)
+	}
+	return b.CreateWorkItem(scope, item)
+}
+
+// GetWorkItem delegates to the backend registered for scope.
+func (cp *CompositeProvider) GetWorkItem(scope string, id int) (*WorkItem, error) {
+	b := cp.backendFor(scope)
+	if b == nil {
+		return nil, routeErr(scope)
+	}
+	return b.GetWorkItem(scope, id)
+}
+
+// UpdateWorkItemFields delegates to the backend registered for scope.
+func (cp *CompositeProvider) UpdateWorkItemFields(scope string, id int, update WorkItemUpdate) (*WorkItem, error) {
+	b := cp.backendFor(scope)
+	if b == nil {
+		return nil, routeErr(scope)
+	}
+	return b.UpdateWorkItemFields(scope, id, update)
+}
+
+// GetFieldOptions delegates to the backend registered for scope.
+func (cp *CompositeProvider) GetFieldOptions(scope string, field WorkItemField) ([]string, error) {
+	b := cp.backendFor(scope)
+	if b == nil {
+		return nil, routeErr(scope)
+	}
+	return b.GetFieldOptions(scope, field)
+}
+
+// GetWorkItemLinks delegates to the backend registered for scope.
+func (cp *CompositeProvider) GetWorkItemLinks(scope string, id int) ([]WorkItemLink, error) {
+	b := cp.backendFor(scope)
+	if b == nil {
+		return nil, routeErr(scope)
+	}
+	return b.GetWorkItemLinks(scope, id)
+}
+
+// AddWorkItemLink delegates to the backend registered for scope.
+func (cp *CompositeProvider) AddWorkItemLink(scope string, id int, link WorkItemLinkType, targetID int) error {
+	b := cp.backendFor(scope)
+	if b == nil {
+		return routeErr(scope)
+	}
+	return b.AddWorkItemLink(scope, id, link, targetID)
+}
+
+// RemoveWorkItemLink delegates to the backend registered for scope.
+func (cp *CompositeProvider) RemoveWorkItemLink(scope string, id int, link WorkItemLinkType, targetID int) error {
+	b := cp.backendFor(scope)
+	if b == nil {
+		return routeErr(scope)
+	}
+	return b.RemoveWorkItemLink(scope, id, link, targetID)
+}
+
+// GetWorkItemHistory delegates to the backend registered for scope.
+func (cp *CompositeProvider) GetWorkItemHistory(scope string, id int) ([]WorkItemRevision, error) {
+	b := cp.backendFor(scope)
+	if b == nil {
+		return nil, routeErr(scope)
+	}
+	return b.GetWorkItemHistory(scope, id)
+}
+
+// GetWorkItemAttachments delegates to the backend registered for scope.
+func (cp *CompositeProvider) GetWorkItemAttachments(scope string, id int) ([]WorkItemAttachment, error) {
+	b := cp.backendFor(scope)
+	if b == nil {
+		return nil, routeErr(scope)
+	}
+	return b.GetWorkItemAttachments(scope, id)
+}
+
+// DownloadWorkItemAttachment delegates to the backend registered for scope.
+func (cp *CompositeProvider) DownloadWorkItemAttachment(scope string, attachment WorkItemAttachment) ([]byte, error) {
+	b := cp.backendFor(scope)
+	if b == nil {
+		return nil, routeErr(scope)
+	}
+	return b.DownloadWorkItemAttachment(scope, attachment)
+}
+
+// AddWorkItemAttachment delegates to the backend registered for scope.
+func (cp *CompositeProvider) AddWorkItemAttachment(scope string, id int, name string, content []byte) (*WorkItemAttachment, error) {
+	b := cp.backendFor(scope)
+	if b == nil {
+		return nil, routeErr(scope)
+	}
+	return b.AddWorkItemAttachment(scope, id, name, content)
+}
+
+// ListWorkItemQueries delegates to the backend registered for scope.
+func (cp *CompositeProvider) ListWorkItemQueries(scope string) ([]WorkItemQuery, error) {
+	b := cp.backendFor(scope)
+	if b == nil {
+		return nil, routeErr(scope)
+	}
+	return b.ListWorkItemQueries(scope)
+}
+
+// RunWorkItemQuery delegates to the backend registered for scope.
+func (cp *CompositeProvider) RunWorkItemQuery(scope string, query WorkItemQuery) (*WorkItemQueryResult, error) {
+	b := cp.backendFor(scope)
+	if b == nil {
+		return nil, routeErr(scope)
+	}
+	return b.RunWorkItemQuery(scope, query)
+}
+
+// GetBoards delegates to the backend registered for scope.
+func (cp *CompositeProvider) GetBoards(scope string) ([]Board, error) {
+	b := cp.backendFor(scope)
+	if b == nil {
+		return nil, routeErr(scope)
+	}
+	return b.GetBoards(scope)
+}
+
+// MoveWorkItemOnBoard delegates to the backend registered for scope.
+func (cp *CompositeProvider) MoveWorkItemOnBoard(scope string, id int, workItemType string, board Board, column string) error {
+	b := cp.backendFor(scope)
+	if b == nil {
+		return routeErr(scope)
+	}
+	return b.MoveWorkItemOnBoard(scope, id, workItemType, board, column)
+}
+
+// GetSprints delegates to the backend registered for scope.
+func (cp *CompositeProvider) GetSprints(scope string) ([]Sprint, error) {
+	b := cp.backendFor(scope)
+	if b == nil {
+		return nil, routeErr(scope)
+	}
+	return b.GetSprints(scope)
+}
+
+// GetSprintBacklog delegates to the backend registered for scope.
+func (cp *CompositeProvider) GetSprintBacklog(scope string, sprint Sprint) (*SprintBacklog, error) {
+	b := cp.backendFor(scope)
+	if b == nil {
+		return nil, routeErr(scope)
+	}
+	return b.GetSprintBacklog(scope, sprint)
+}
+
 // GetWorkItemComments delegates to the backend registered for scope.
 func (cp *CompositeProvider) GetWorkItemComments(scope string, id int) ([]WorkItemComment, error) {
 	b := cp.backendFor(scope)
@@ -450,6 +711,62 @@ func (cp *CompositeProvider) AddWorkItemComment(scope string, id int, text strin
 	return b.AddWorkItemComment(scope, id, text)
 }
 
+// AddWorkItemCommentMarkdown delegates to the backend registered for scope.
+func (cp *CompositeProvider) AddWorkItemCommentMarkdown(scope string, id int, markdown string) (*WorkItemComment, error) {
+	b := cp.backendFor(scope)
+	if b == nil {
+		return nil, routeErr(scope)
+	}
+	return b.AddWorkItemCommentMarkdown(scope, id, markdown)
+}
+
+// EditWorkItemComment delegates to the backend registered for scope.
+func (cp *CompositeProvider) EditWorkItemComment(scope string, id, commentID int, text string) error {
+	b := cp.backendFor(scope)
+	if b == nil {
+		return routeErr(scope)
+	}
+	return b.EditWorkItemComment(scope, id, commentID, text)
+}
+
+// DeleteWorkItemComment delegates to the backend registered for scope.
+func (cp *CompositeProvider) DeleteWorkItemComment(scope string, id, commentID int) error {
+	b := cp.backendFor(scope)
+	if b == nil {
+		return routeErr(scope)
+	}
+	return b.DeleteWorkItemComment(scope, id, commentID)
+}
+
+// ReactToWorkItemComment delegates to the backend registered for scope.
+func (cp *CompositeProvider) ReactToWorkItemComment(scope string, id, commentID int, reaction Reaction, remove bool) error {
+	b := cp.backendFor(scope)
+	if b == nil {
+		return routeErr(scope)
+	}
+	return b.ReactToWorkItemComment(scope, id, commentID, reaction, remove)
+}
+
+// --- People ---
+
+// SearchPeople delegates to the backend registered for scope.
+func (cp *CompositeProvider) SearchPeople(scope, query string) ([]Person, error) {
+	b := cp.backendFor(scope)
+	if b == nil {
+		return nil, routeErr(scope)
+	}
+	return b.SearchPeople(scope, query)
+}
+
+// ResolvePeople delegates to the backend registered for scope.
+func (cp *CompositeProvider) ResolvePeople(scope string, ids []string) ([]Person, error) {
+	b := cp.backendFor(scope)
+	if b == nil {
+		return nil, routeErr(scope)
+	}
+	return b.ResolvePeople(scope, ids)
+}
+
 // --- Pipeline list methods ---
 
 // ListPipelineRuns fans out to all backends concurrently, merges, and sorts by
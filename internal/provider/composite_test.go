@@ -79,7 +79,23 @@ func (f *fakeBackend) GetFileContent(scope, _ string, _ string, _ string) (strin
 	f.lastRouteScope = scope
 	return "content", nil
 }
-func (f *fakeBackend) AddPRCodeComment(scope, _ string, _ int, _ string, _ int, _ string) (*provider.Thread, error) {
+func (f *fakeBackend) GetFileContentAtCommit(scope, _ string, _ string, _ string) (string, error) {
+	f.lastRouteScope = scope
+	return "content", nil
+}
+func (f *fakeBackend) GetPRCommits(scope, _ string, _ int) ([]provider.Commit, error) {
+	f.lastRouteScope = scope
+	return nil, nil
+}
+func (f *fakeBackend) GetCommitChanges(scope, _ string, _ string) (*provider.CommitChanges, error) {
+	f.lastRouteScope = scope
+	return nil, nil
+}
+func (f *fakeBackend) GetPRMergeability(scope, _ string, _ int) (*provider.Mergeability, error) {
+	f.lastRouteScope = scope
+	return nil, nil
+}
+func (f *fakeBackend) AddPRCodeComment(scope, _ string, _ int, _ string, _ provider.LineRange, _ string) (*provider.Thread, error) {
 	f.lastRouteScope = scope
 	return nil, nil
 }
@@ -95,10 +111,110 @@ func (f *fakeBackend) UpdateThreadStatus(scope, _ string, _ int, _ int, _ string
 	f.lastRouteScope = scope
 	return nil
 }
+func (f *fakeBackend) SubmitReview(scope, _ string, _ int, _ provider.Review) error {
+	f.lastRouteScope = scope
+	return nil
+}
+func (f *fakeBackend) ApplyFileEdit(scope, _ string, _ provider.FileEdit) error {
+	f.lastRouteScope = scope
+	return nil
+}
+func (f *fakeBackend) GetViewedFiles(scope, _ string, _ int) ([]string, error) {
+	f.lastRouteScope = scope
+	return nil, nil
+}
+func (f *fakeBackend) SetFileViewed(scope, _ string, _ int, _ string, _ bool) error {
+	f.lastRouteScope = scope
+	return nil
+}
+func (f *fakeBackend) EditPRComment(scope, _ string, _, _, _ int, _ string) error {
+	f.lastRouteScope = scope
+	return nil
+}
+func (f *fakeBackend) DeletePRComment(scope, _ string, _, _, _ int) error {
+	f.lastRouteScope = scope
+	return nil
+}
+func (f *fakeBackend) ReactToPRComment(scope, _ string, _, _, _ int, _ provider.Reaction, _ bool) error {
+	f.lastRouteScope = scope
+	return nil
+}
 func (f *fakeBackend) GetWorkItemTypeStates(scope, _ string) ([]provider.WorkItemTypeState, error) {
 	f.lastRouteScope = scope
 	return nil, nil
 }
+func (f *fakeBackend) GetWorkItemTypes(scope string) ([]string, error) {
+	f.lastRouteScope = scope
+	return nil, nil
+}
+func (f *fakeBackend) CreateWorkItem(scope string, _ provider.NewWorkItem) (*provider.WorkItem, error) {
+	f.lastRouteScope = scope
+	return nil, nil
+}
+func (f *fakeBackend) GetWorkItem(scope string, _ int) (*provider.WorkItem, error) {
+	f.lastRouteScope = scope
+	return nil, nil
+}
+func (f *fakeBackend) UpdateWorkItemFields(scope string, _ int, _ provider.WorkItemUpdate) (*provider.WorkItem, error) {
+	f.lastRouteScope = scope
+	return nil, nil
+}
+func (f *fakeBackend) GetFieldOptions(scope string, _ provider.WorkItemField) ([]string, error) {
+	f.lastRouteScope = scope
+	return nil, nil
+}
+func (f *fakeBackend) GetWorkItemLinks(scope string, _ int) ([]provider.WorkItemLink, error) {
+	f.lastRouteScope = scope
+	return nil, nil
+}
+func (f *fakeBackend) AddWorkItemLink(scope string, _ int, _ provider.WorkItemLinkType, _ int) error {
+	f.lastRouteScope = scope
+	return nil
+}
+func (f *fakeBackend) RemoveWorkItemLink(scope string, _ int, _ provider.WorkItemLinkType, _ int) error {
+	f.lastRouteScope = scope
+	return nil
+}
+func (f *fakeBackend) GetWorkItemHistory(scope string, _ int) ([]provider.WorkItemRevision, error) {
+	f.lastRouteScope = scope
+	return nil, nil
+}
+func (f *fakeBackend) GetWorkItemAttachments(scope string, _ int) ([]provider.WorkItemAttachment, error) {
+	f.lastRouteScope = scope
+	return nil, nil
+}
+func (f *fakeBackend) DownloadWorkItemAttachment(scope string, _ provider.WorkItemAttachment) ([]byte, error) {
+	f.lastRouteScope = scope
+	return nil, nil
+}
+func (f *fakeBackend) AddWorkItemAttachment(scope string, _ int, _ string, _ []byte) (*provider.WorkItemAttachment, error) {
+	f.lastRouteScope = scope
+	return nil, nil
+}
+func (f *fakeBackend) ListWorkItemQueries(scope string) ([]provider.WorkItemQuery, error) {
+	f.lastRouteScope = scope
+	return nil, nil
+}
+func (f *fakeBackend) RunWorkItemQuery(scope string, _ provider.WorkItemQuery) (*provider.WorkItemQueryResult, error) {
+	f.lastRouteScope = scope
+	return nil, nil
+}
+func (f *fakeBackend) GetBoards(scope string) ([]provider.Board, error) {
+	f.lastRouteScope = scope
+	return nil, nil
+}
+func (f *fakeBackend) MoveWorkItemOnBoard(scope string, _ int, _ string, _ provider.Board, _ string) error {
+	f.lastRouteScope = scope
+	return nil
+}
+func (f *fakeBackend) GetSprints(scope string) ([]provider.Sprint, error) {
+	f.lastRouteScope = scope
+	return nil, nil
+}
+func (f *fakeBackend) GetSprintBacklog(scope string, _ provider.Sprint) (*provider.SprintBacklog, error) {
+	f.lastRouteScope = scope
+	return nil, nil
+}
 func (f *fakeBackend) UpdateWorkItemState(scope string, _ int, _ string) error {
 	f.lastRouteScope = scope
 	return nil
@@ -111,6 +227,31 @@ func (f *fakeBackend) AddWorkItemComment(scope string, _ int, _ string) (*provid
 	f.lastRouteScope = scope
 	return nil, nil
 }
+
+func (f *fakeBackend) AddWorkItemCommentMarkdown(scope string, _ int, _ string) (*provider.WorkItemComment, error) {
+	f.lastRouteScope = scope
+	return nil, nil
+}
+func (f *fakeBackend) EditWorkItemComment(scope string, _, _ int, _ string) error {
+	f.lastRouteScope = scope
+	return nil
+}
+func (f *fakeBackend) DeleteWorkItemComment(scope string, _, _ int) error {
+	f.lastRouteScope = scope
+	return nil
+}
+func (f *fakeBackend) ReactToWorkItemComment(scope string, _, _ int, _ provider.Reaction, _ bool) error {
+	f.lastRouteScope = scope
+	return nil
+}
+func (f *fakeBackend) SearchPeople(scope, _ string) ([]provider.Person, error) {
+	f.lastRouteScope = scope
+	return nil, nil
+}
+func (f *fakeBackend) ResolvePeople(scope string, _ []string) ([]provider.Person, error) {
+	f.lastRouteScope = scope
+	return nil, nil
+}
 func (f *fakeBackend) GetBuildTimeline(scope string, _ int) (*provider.Timeline, error) {
 	f.lastRouteScope = scope
 	return nil, nil
@@ -683,14 +824,49 @@ func TestCompositeProvider_RoutingAllMethods(t *testing.T) {
 		{"GetPRIterationChanges", func() { _, _ = cp.GetPRIterationChanges("X", "r", 1, 1) }},
 		{"VotePullRequest", func() { _ = cp.VotePullRequest("X", "r", 1, 10) }},
 		{"GetFileContent", func() { _, _ = cp.GetFileContent("X", "r", "f", "main") }},
-		{"AddPRCodeComment", func() { _, _ = cp.AddPRCodeComment("X", "r", 1, "f", 1, "c") }},
+		{"GetFileContentAtCommit", func() { _, _ = cp.GetFileContentAtCommit("X", "r", "f", "abc") }},
+		{"GetPRCommits", func() { _, _ = cp.GetPRCommits("X", "r", 1) }},
+		{"GetCommitChanges", func() { _, _ = cp.GetCommitChanges("X", "r", "abc") }},
+		{"GetPRMergeability", func() { _, _ = cp.GetPRMergeability("X", "r", 1) }},
+		{"AddPRCodeComment", func() { _, _ = cp.AddPRCodeComment("X", "r", 1, "f", provider.LineAt(provider.SideRight, 1), "c") }},
 		{"AddPRComment", func() { _, _ = cp.AddPRComment("X", "r", 1, "c") }},
 		{"ReplyToThread", func() { _, _ = cp.ReplyToThread("X", "r", 1, 1, "c") }},
 		{"UpdateThreadStatus", func() { _ = cp.UpdateThreadStatus("X", "r", 1, 1, "Fixed") }},
+		{"SubmitReview", func() { _ = cp.SubmitReview("X", "r", 1, provider.Review{}) }},
+		{"ApplyFileEdit", func() { _ = cp.ApplyFileEdit("X", "r", provider.FileEdit{}) }},
+		{"GetViewedFiles", func() { _, _ = cp.GetViewedFiles("X", "r", 1) }},
+		{"SetFileViewed", func() { _ = cp.SetFileViewed("X", "r", 1, "/a.go", true) }},
+		{"EditPRComment", func() { _ = cp.EditPRComment("X", "r", 1, 1, 1, "c") }},
+		{"DeletePRComment", func() { _ = cp.DeletePRComment("X", "r", 1, 1, 1) }},
+		{"ReactToPRComment", func() { _ = cp.ReactToPRComment("X", "r", 1, 1, 1, provider.ReactionLike, false) }},
 		{"GetWorkItemTypeStates", func() { _, _ = cp.GetWorkItemTypeStates("X", "Bug") }},
 		{"UpdateWorkItemState", func() { _ = cp.UpdateWorkItemState("X", 1, "Active") }},
+		{"GetWorkItemTypes", func() { _, _ = cp.GetWorkItemTypes("X") }},
+		{"CreateWorkItem", func() { _, _ = cp.CreateWorkItem("X", provider.NewWorkItem{}) }},
+		{"GetWorkItem", func() { _, _ = cp.GetWorkItem("X", 1) }},
+		{"UpdateWorkItemFields", func() { _, _ = cp.UpdateWorkItemFields("X", 1, provider.WorkItemUpdate{}) }},
+		{"GetFieldOptions", func() { _, _ = cp.GetFieldOptions("X", provider.FieldIteration) }},
+		{"GetWorkItemLinks", func() { _, _ = cp.GetWorkItemLinks("X", 1) }},
+		{"AddWorkItemLink", func() { _ = cp.AddWorkItemLink("X", 1, provider.LinkChild, 2) }},
+		{"RemoveWorkItemLink", func() { _ = cp.RemoveWorkItemLink("X", 1, provider.LinkChild, 2) }},
+		{"GetWorkItemHistory", func() { _, _ = cp.GetWorkItemHistory("X", 1) }},
+		{"GetWorkItemAttachments", func() { _, _ = cp.GetWorkItemAttachments("X", 1) }},
+		{"DownloadWorkItemAttachment", func() { _, _ = cp.DownloadWorkItemAttachment("X", provider.WorkItemAttachment{}) }},
+		{"AddWorkItemAttachment", func() { _, _ = cp.AddWorkItemAttachment("X", 1, "a.txt", nil) }},
+		{"ListWorkItemQueries", func() { _, _ = cp.ListWorkItemQueries("X") }},
+		{"RunWorkItemQuery", func() { _, _ = cp.RunWorkItemQuery("X", provider.WorkItemQuery{}) }},
+		{"GetBoards", func() { _, _ = cp.GetBoards("X") }},
+		{"MoveWorkItemOnBoard", func() { _ = cp.MoveWorkItemOnBoard("X", 1, "Bug", provider.Board{}, "Doing") }},
+		{"GetSprints", func() { _, _ = cp.GetSprints("X") }},
+		{"GetSprintBacklog", func() { _, _ = cp.GetSprintBacklog("X", provider.Sprint{}) }},
 		{"GetWorkItemComments", func() { _, _ = cp.GetWorkItemComments("X", 1) }},
 		{"AddWorkItemComment", func() { _, _ = cp.AddWorkItemComment("X", 1, "t") }},
+		{"AddWorkItemCommentMarkdown", func() { _, _ = cp.AddWorkItemCommentMarkdown("X", 1, "t") }},
+		{"EditWorkItemComment", func() { _ = cp.EditWorkItemComment("X", 1, 1, "t") }},
+		{"DeleteWorkItemComment", func() { _ = cp.DeleteWorkItemComment("X", 1, 1) }},
+		{"ReactToWorkItemComment", func() { _ = cp.ReactToWorkItemComment("X", 1, 1, provider.ReactionHeart, true) }},
+		{"SearchPeople", func() { _, _ = cp.SearchPeople("X", "ja") }},
+		{"ResolvePeople", func() { _, _ = cp.ResolvePeople("X", []string{"id"}) }},
 		{"GetBuildTimeline", func() { _, _ = cp.GetBuildTimeline("X", 1) }},
 		{"GetBuildLogContent", func() { _, _ = cp.GetBuildLogContent("X", 1, 1) }},
 		{"PRThreadWebURL", func() { _ = cp.PRThreadWebURL("X", "r", 1, 1) }},
@@ -102,3 +102,30 @@ const (
 	// and a half-circle glyph, distinct from RunStatusSucceeded.
 	RunStatusSucceededWithIssues
 )
+
+// MergeStatus is a neutral semantic enum for whether a pull request can be
+// merged. Views use it to decide the conflict glyph without inspecting
+// Azure mergeStatus or GitHub mergeable_state strings.
+type MergeStatus int
+
+const (
+	// MergeStatusUnknown is the zero value; the backend has not computed
+	// mergeability yet or did not report it (e.g. GitHub list endpoints).
+	MergeStatusUnknown MergeStatus = iota
+	// MergeStatusMergeable indicates the PR merges cleanly (Azure
+	// "succeeded", GitHub "clean", "unstable" or "has_hooks").
+	MergeStatusMergeable
+	// MergeStatusConflicts indicates the PR has merge conflicts (Azure
+	// "conflicts", GitHub "dirty").
+	MergeStatusConflicts
+	// MergeStatusBlocked indicates the PR merges cleanly but a policy or
+	// branch protection blocks it (Azure "rejectedByPolicy", GitHub
+	// "blocked").
+	MergeStatusBlocked
+	// MergeStatusBehind indicates the source branch is behind its target
+	// and must be updated first (GitHub "behind").
+	MergeStatusBehind
+	// MergeStatusFailed indicates the backend failed to compute the merge
+	// (Azure "failure").
+	MergeStatusFailed
+)
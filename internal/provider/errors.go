@@ -1,6 +1,43 @@
 package provider
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrEditStale is returned by FileEdit.Apply when the lines an edit replaces
+// no longer match the file, e.g. because the branch moved on after a
+// suggestion was written.
+var ErrEditStale = errors.New("the lines have changed since the suggestion was made")
+
+// ErrReactionUnsupported is returned when a backend has no equivalent of the
+// requested reaction, e.g. anything but a like on an Azure DevOps pull
+// request comment.
+var ErrReactionUnsupported = errors.New("reaction not supported by this backend")
+
+// ErrWorkItemConflict is returned when a work item edit is based on an
+// outdated revision because someone else changed the item meanwhile.
+var ErrWorkItemConflict = errors.New("the work item was changed by someone else")
+
+// ErrFieldUnsupported is returned for work item fields the backend has no
+// equivalent of, e.g. story points on GitHub.
+var ErrFieldUnsupported = errors.New("field not supported by this backend")
+
+// ErrLinkUnsupported is returned for work item link types the backend has no
+// equivalent of, e.g. related links on GitHub.
+var ErrLinkUnsupported = errors.New("link type not supported by this backend")
+
+// ErrQueriesUnsupported is returned by backends that cannot run work item
+// queries, e.g. GitHub.
+var ErrQueriesUnsupported = errors.New("work item queries not supported by this backend")
+
+// ErrSprintsUnsupported is returned by backends without team sprints,
+// e.g. GitHub.
+var ErrSprintsUnsupported = errors.New("sprints not supported by this backend")
+
+// ErrAttachmentUploadUnsupported is returned by backends whose API cannot
+// attach files to work items, e.g. GitHub.
+var ErrAttachmentUploadUnsupported = errors.New("uploading attachments not supported by this backend")
 
 // PartialError indicates that some (but not all) sources failed during a
 // multi-source fetch. The caller receives valid data from the successful
@@ -18,3 +55,22 @@ type PartialError struct {
 func (e *PartialError) Error() string {
 	return fmt.Sprintf("%d of %d sources failed to load", e.Failed, e.Total)
 }
+
+// ReviewError reports a review submission that failed part-way on a backend
+// without atomic review submission. The first Posted comments of the review,
+// and the summary when BodyPosted, were published before Err occurred;
+// callers should drop them from their drafts so a retry does not post them
+// twice.
+type ReviewError struct {
+	Posted     int   // number of leading comments that were published
+	BodyPosted bool  // whether the summary was published
+	Err        error // the failure that stopped the submission
+}
+
+func (e *ReviewError) Error() string {
+	return fmt.Sprintf("review submitted partially (%d comments posted): %v", e.Posted, e.Err)
+}
+
+func (e *ReviewError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FileEdit replaces a span of lines in a file on a branch with a single
+// commit, as when applying a suggested change. Expected holds the lines the
+// replacement was written against; the edit is refused with ErrEditStale
+// when the branch no longer has them at StartLine..EndLine.
+type FileEdit struct {
+	Branch    string // short branch name, e.g. "feature/x"
+	FilePath  string
+	StartLine int // 1-based, inclusive
+	EndLine   int
+	Expected  []string
+	Lines     []string // replacement; empty deletes the span
+	Message   string   // commit message
+}
+
+// Apply returns content with the edit's lines replaced. The file's line
+// endings and its final-newline state are preserved. Adapters call it on
+// the content read at the branch head before committing the result.
+func (e FileEdit) Apply(content string) (string, error) {
+	parts := strings.SplitAfter(content, "\n")
+	if parts[len(parts)-1] == "" {
+		parts = parts[:len(parts)-1]
+	}
+	if e.StartLine < 1 || e.EndLine < e.StartLine || e.EndLine > len(parts) {
+		return "", fmt.Errorf("lines %d-%d out of range (file has %d lines)", e.StartLine, e.EndLine, len(parts))
+	}
+	if len(e.Expected) != e.EndLine-e.StartLine+1 {
+		return "", ErrEditStale
+	}
+	for i, want := range e.Expected {
+		if strings.TrimRight(parts[e.StartLine-1+i], "\r\n") != want {
+			return "", ErrEditStale
+		}
+	}
+
+	eol := "\n"
+	if strings.HasSuffix(parts[0], "\r\n") {
+		eol = "\r\n"
+	}
+	// The last replaced line keeps its own terminator, so replacing the
+	// final line of a file without a trailing newline does not add one.
+	last := parts[e.EndLine-1]
+	lastEOL := last[len(strings.TrimRight(last, "\r\n")):]
+
+	var sb strings.Builder
+	for _, p := range parts[:e.StartLine-1] {
+		sb.WriteString(p)
+	}
+	for i, l := range e.Lines {
+		sb.WriteString(l)
+		if i < len(e.Lines)-1 {
+			sb.WriteString(eol)
+		} else {
+			sb.WriteString(lastEOL)
+		}
+	}
+	for _, p := range parts[e.EndLine:] {
+		sb.WriteString(p)
+	}
+	return sb.String(), nil
+}
@@ -0,0 +1,44 @@
+package provider_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Elpulgo/azdo/internal/provider"
+)
+
+func TestFileEdit_Apply(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		edit    provider.FileEdit
+		want    string
+	}{
+		{"middle", "a\nb\nc\n", provider.FileEdit{StartLine: 2, EndLine: 2, Expected: []string{"b"}, Lines: []string{"B1", "B2"}}, "a\nB1\nB2\nc\n"},
+		{"delete", "a\nb\nc\n", provider.FileEdit{StartLine: 2, EndLine: 3, Expected: []string{"b", "c"}}, "a\n"},
+		{"crlf", "a\r\nb\r\nc\r\n", provider.FileEdit{StartLine: 1, EndLine: 2, Expected: []string{"a", "b"}, Lines: []string{"x", "y", "z"}}, "x\r\ny\r\nz\r\nc\r\n"},
+		{"no final newline", "a\nb", provider.FileEdit{StartLine: 2, EndLine: 2, Expected: []string{"b"}, Lines: []string{"B"}}, "a\nB"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.edit.Apply(tt.content)
+			if err != nil {
+				t.Fatalf("Apply() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Apply() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFileEdit_Apply_RejectsStaleAndOutOfRange(t *testing.T) {
+	stale := provider.FileEdit{StartLine: 2, EndLine: 2, Expected: []string{"old"}, Lines: []string{"new"}}
+	if _, err := stale.Apply("a\nb\n"); !errors.Is(err, provider.ErrEditStale) {
+		t.Errorf("mismatched lines: err = %v, want ErrEditStale", err)
+	}
+	outOfRange := provider.FileEdit{StartLine: 2, EndLine: 4, Expected: []string{"b", "", ""}}
+	if _, err := outOfRange.Apply("a\nb\n"); err == nil || errors.Is(err, provider.ErrEditStale) {
+		t.Errorf("out of range: err = %v, want a range error", err)
+	}
+}
@@ -1,5 +1,10 @@
 package provider
 
+import (
+	"strings"
+	"time"
+)
+
 // ListOpts carries neutral filter intent for list methods. The adapter is
 // responsible for translating these fields into backend-specific query
 // parameters (e.g. WIQL clauses, REST query params).
@@ -32,4 +37,136 @@ type ListOpts struct {
 	// Top overrides the default result-count limit when non-zero. A zero
 	// value means use the caller-supplied top argument (backwards compatible).
 	Top int
+
+	// The fields below filter pull requests. For them, States selects the
+	// PR status (StateCategoryActive, StateCategoryClosedDone for completed
+	// or merged, StateCategoryRemoved for abandoned or closed unmerged).
+	// Adapters push what the backend can filter on into the request and
+	// apply FilterPullRequests for the rest.
+
+	// Repository restricts results to the repository with this name
+	// (case-insensitive). A GitHub "owner/repo" also matches its bare name.
+	Repository string
+
+	// TargetBranch restricts results to pull requests into this branch,
+	// given as a short name such as "main".
+	TargetBranch string
+
+	// Author restricts results to pull requests whose author's display name
+	// or login contains this string (case-insensitive).
+	Author string
+
+	// Draft restricts results by draft state; DraftAny means no filter.
+	Draft DraftFilter
+
+	// Labels restricts results to pull requests carrying every one of these
+	// labels (case-insensitive).
+	Labels []string
+
+	// CreatedAfter restricts results to pull requests created at or after
+	// this time. The zero value means no age filter.
+	CreatedAfter time.Time
+}
+
+// DraftFilter selects pull requests by draft state.
+type DraftFilter int
+
+const (
+	// DraftAny is the zero value; drafts and ready PRs are both included.
+	DraftAny DraftFilter = iota
+	// DraftOnly includes only draft pull requests.
+	DraftOnly
+	// DraftExcluded includes only pull requests that are not drafts.
+	DraftExcluded
+)
+
+// MatchesPullRequest reports whether pr passes every pull-request filter of
+// o. Mine, Statuses and Top are not checked: they are answered by the
+// backend query itself.
+func (o ListOpts) MatchesPullRequest(pr PullRequest) bool {
+	if len(o.States) > 0 && !containsState(o.States, pr.StatusCategory) {
+		return false
+	}
+	if o.Repository != "" && !matchesRepository(pr.RepositoryName, o.Repository) {
+		return false
+	}
+	if o.TargetBranch != "" && !strings.EqualFold(strings.TrimPrefix(pr.TargetRefName, "refs/heads/"), o.TargetBranch) {
+		return false
+	}
+	if o.Author != "" && !strings.Contains(strings.ToLower(pr.CreatedByName), strings.ToLower(o.Author)) {
+		return false
+	}
+	switch o.Draft {
+	case DraftOnly:
+		if !pr.IsDraft {
+			return false
+		}
+	case DraftExcluded:
+		if pr.IsDraft {
+			return false
+		}
+	}
+	for _, label := range o.Labels {
+		if !containsFold(pr.Labels, label) {
+			return false
+		}
+	}
+	if !o.CreatedAfter.IsZero() && pr.CreationDate.Before(o.CreatedAfter) {
+		return false
+	}
+	if o.Search != "" && !strings.Contains(strings.ToLower(pr.Title), strings.ToLower(o.Search)) {
+		return false
+	}
+	return true
+}
+
+// FilterPullRequests returns the pull requests that pass the filters of
+// opts. It returns prs itself when opts filters nothing.
+func FilterPullRequests(prs []PullRequest, opts ListOpts) []PullRequest {
+	if !opts.filtersPullRequests() {
+		return prs
+	}
+	filtered := make([]PullRequest, 0, len(prs))
+	for _, pr := range prs {
+		if opts.MatchesPullRequest(pr) {
+			filtered = append(filtered, pr)
+		}
+	}
+	return filtered
+}
+
+// filtersPullRequests reports whether any pull-request filter is set.
+func (o ListOpts) filtersPullRequests() bool {
+	return len(o.States) > 0 || o.Repository != "" || o.TargetBranch != "" || o.Author != "" ||
+		o.Draft != DraftAny || len(o.Labels) > 0 || !o.CreatedAfter.IsZero() || o.Search != ""
+}
+
+func containsState(states []StateCategory, state StateCategory) bool {
+	for _, s := range states {
+		if s == state {
+			return true
+		}
+	}
+	return false
+}
+
+func containsFold(values []string, want string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, want) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesRepository compares a repository name with a filter, letting a
+// GitHub "owner/repo" match on its repo part alone.
+func matchesRepository(name, filter string) bool {
+	if strings.EqualFold(name, filter) {
+		return true
+	}
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		return strings.EqualFold(name[i+1:], filter)
+	}
+	return false
 }
@@ -0,0 +1,69 @@
+package provider_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Elpulgo/azdo/internal/provider"
+)
+
+func TestListOpts_MatchesPullRequest(t *testing.T) {
+	created := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+	pr := provider.PullRequest{
+		Title:          "Fix login redirect",
+		StatusCategory: provider.StateCategoryActive,
+		RepositoryName: "octo/web",
+		TargetRefName:  "refs/heads/main",
+		CreatedByName:  "Ann Smith",
+		IsDraft:        true,
+		Labels:         []string{"bug", "Frontend"},
+		CreationDate:   created,
+	}
+
+	tests := []struct {
+		name string
+		opts provider.ListOpts
+		want bool
+	}{
+		{"zero value", provider.ListOpts{}, true},
+		{"status matches", provider.ListOpts{States: []provider.StateCategory{provider.StateCategoryActive}}, true},
+		{"status differs", provider.ListOpts{States: []provider.StateCategory{provider.StateCategoryClosedDone}}, false},
+		{"repository full name", provider.ListOpts{Repository: "OCTO/WEB"}, true},
+		{"repository bare name", provider.ListOpts{Repository: "web"}, true},
+		{"repository differs", provider.ListOpts{Repository: "api"}, false},
+		{"target branch short name", provider.ListOpts{TargetBranch: "main"}, true},
+		{"target branch differs", provider.ListOpts{TargetBranch: "develop"}, false},
+		{"author substring", provider.ListOpts{Author: "smith"}, true},
+		{"author differs", provider.ListOpts{Author: "bob"}, false},
+		{"drafts only", provider.ListOpts{Draft: provider.DraftOnly}, true},
+		{"drafts excluded", provider.ListOpts{Draft: provider.DraftExcluded}, false},
+		{"all labels present", provider.ListOpts{Labels: []string{"BUG", "frontend"}}, true},
+		{"label missing", provider.ListOpts{Labels: []string{"bug", "backend"}}, false},
+		{"created after", provider.ListOpts{CreatedAfter: created.Add(-time.Hour)}, true},
+		{"created before cutoff", provider.ListOpts{CreatedAfter: created.Add(time.Hour)}, false},
+		{"title search", provider.ListOpts{Search: "LOGIN"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.opts.MatchesPullRequest(pr); got != tt.want {
+				t.Errorf("MatchesPullRequest(%+v) = %v, want %v", tt.opts, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterPullRequests(t *testing.T) {
+	prs := []provider.PullRequest{
+		{Title: "a", IsDraft: true},
+		{Title: "b"},
+	}
+
+	if got := provider.FilterPullRequests(prs, provider.ListOpts{}); len(got) != 2 {
+		t.Errorf("FilterPullRequests(zero opts) returned %d PRs, want 2", len(got))
+	}
+	got := provider.FilterPullRequests(prs, provider.ListOpts{Draft: provider.DraftExcluded})
+	if len(got) != 1 || got[0].Title != "b" {
+		t.Errorf("FilterPullRequests(DraftExcluded) = %+v, want only b", got)
+	}
+}
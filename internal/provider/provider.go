@@ -54,9 +54,30 @@ type Provider interface {
 	// scope is the project name used to route to the correct sub-client.
 	GetFileContent(scope, repositoryID string, filePath string, branchName string) (string, error)
 
-	// AddPRCodeComment creates a new inline code comment on the given line.
+	// GetFileContentAtCommit returns the raw file content at the given commit.
 	// scope is the project name used to route to the correct sub-client.
-	AddPRCodeComment(scope, repositoryID string, pullRequestID int, filePath string, line int, content string) (*Thread, error)
+	GetFileContentAtCommit(scope, repositoryID string, filePath string, commitID string) (string, error)
+
+	// GetPRCommits returns the commits that make up the given pull request,
+	// oldest first.
+	// scope is the project name used to route to the correct sub-client.
+	GetPRCommits(scope, repositoryID string, pullRequestID int) ([]Commit, error)
+
+	// GetCommitChanges returns the files changed by a single commit relative
+	// to its first parent, together with that parent's ID.
+	// scope is the project name used to route to the correct sub-client.
+	GetCommitChanges(scope, repositoryID string, commitID string) (*CommitChanges, error)
+
+	// GetPRMergeability returns whether the pull request can be merged and,
+	// when it conflicts, the conflicting files where the backend reports
+	// them (Azure DevOps).
+	// scope is the project name used to route to the correct sub-client.
+	GetPRMergeability(scope, repositoryID string, pullRequestID int) (*Mergeability, error)
+
+	// AddPRCodeComment creates a new inline code comment anchored to rng —
+	// a single line or a range, on either side of the diff.
+	// scope is the project name used to route to the correct sub-client.
+	AddPRCodeComment(scope, repositoryID string, pullRequestID int, filePath string, rng LineRange, content string) (*Thread, error)
 
 	// AddPRComment creates a new general (non-file) comment thread on the PR.
 	// scope is the project name used to route to the correct sub-client.
@@ -70,6 +91,44 @@ type Provider interface {
 	// scope is the project name used to route to the correct sub-client.
 	UpdateThreadStatus(scope, repositoryID string, pullRequestID int, threadID int, status string) error
 
+	// EditPRComment replaces the text of a comment in a thread.
+	// scope is the project name used to route to the correct sub-client.
+	EditPRComment(scope, repositoryID string, pullRequestID, threadID, commentID int, content string) error
+
+	// DeletePRComment deletes a comment from a thread.
+	// scope is the project name used to route to the correct sub-client.
+	DeletePRComment(scope, repositoryID string, pullRequestID, threadID, commentID int) error
+
+	// ReactToPRComment adds the authenticated user's reaction to a comment,
+	// or removes it when remove is true. Returns ErrReactionUnsupported when
+	// the backend has no such reaction.
+	// scope is the project name used to route to the correct sub-client.
+	ReactToPRComment(scope, repositoryID string, pullRequestID, threadID, commentID int, reaction Reaction, remove bool) error
+
+	// SubmitReview posts every drafted comment in review together with its
+	// verdict and summary, so the author is notified once rather than per
+	// comment where the backend supports it.
+	// scope is the project name used to route to the correct sub-client.
+	SubmitReview(scope, repositoryID string, pullRequestID int, review Review) error
+
+	// ApplyFileEdit commits edit to its branch, e.g. to apply a suggested
+	// change. The commit is based on the branch head the file was read from,
+	// so a concurrent push makes it fail rather than overwrite.
+	// scope is the project name used to route to the correct sub-client.
+	ApplyFileEdit(scope, repositoryID string, edit FileEdit) error
+
+	// GetViewedFiles returns the paths of the changed files the user has
+	// marked as viewed on the backend. Backends without server-side viewed
+	// state (Azure DevOps) return nil.
+	// scope is the project name used to route to the correct sub-client.
+	GetViewedFiles(scope, repositoryID string, pullRequestID int) ([]string, error)
+
+	// SetFileViewed marks filePath as viewed on the backend, or clears the
+	// mark when viewed is false. A no-op on backends without server-side
+	// viewed state.
+	// scope is the project name used to route to the correct sub-client.
+	SetFileViewed(scope, repositoryID string, pullRequestID int, filePath string, viewed bool) error
+
 	// --- Work-item surface ---
 
 	// ListWorkItems returns up to top work items across all configured projects.
@@ -90,6 +149,105 @@ type Provider interface {
 	// scope is the project name used to route to the correct sub-client.
 	UpdateWorkItemState(scope string, id int, state string) error
 
+	// GetWorkItemTypes returns the names of the work item types that can be
+	// created in scope, e.g. "Bug" and "User Story".
+	// scope is the project name used to route to the correct sub-client.
+	GetWorkItemTypes(scope string) ([]string, error)
+
+	// CreateWorkItem creates a work item from item and returns it as created.
+	// Fields the backend has no equivalent for are ignored.
+	// scope is the project name used to route to the correct sub-client.
+	CreateWorkItem(scope string, item NewWorkItem) (*WorkItem, error)
+
+	// GetWorkItem returns a single work item as it is now.
+	// scope is the project name used to route to the correct sub-client.
+	GetWorkItem(scope string, id int) (*WorkItem, error)
+
+	// UpdateWorkItemFields applies update to the given work item and returns
+	// it as updated. It returns ErrWorkItemConflict when update.Rev is set
+	// and the item changed since, and ErrFieldUnsupported for fields the
+	// backend has no equivalent of.
+	// scope is the project name used to route to the correct sub-client.
+	UpdateWorkItemFields(scope string, id int, update WorkItemUpdate) (*WorkItem, error)
+
+	// GetFieldOptions returns the values offered when editing field in
+	// scope: iterations, areas or team members. Fields without a fixed set
+	// of values return nil; fields the backend has no equivalent of return
+	// ErrFieldUnsupported.
+	// scope is the project name used to route to the correct sub-client.
+	GetFieldOptions(scope string, field WorkItemField) ([]string, error)
+
+	// GetWorkItemLinks returns the parent, children and related work items of
+	// the given work item, in that order.
+	// scope is the project name used to route to the correct sub-client.
+	GetWorkItemLinks(scope string, id int) ([]WorkItemLink, error)
+
+	// AddWorkItemLink links targetID to the given work item as its parent,
+	// child or related item. It returns ErrLinkUnsupported for link types the
+	// backend has no equivalent of.
+	// scope is the project name used to route to the correct sub-client.
+	AddWorkItemLink(scope string, id int, link WorkItemLinkType, targetID int) error
+
+	// RemoveWorkItemLink removes the link of the given type between the work
+	// item and targetID.
+	// scope is the project name used to route to the correct sub-client.
+	RemoveWorkItemLink(scope string, id int, link WorkItemLinkType, targetID int) error
+
+	// GetWorkItemHistory returns the changes made to the given work item,
+	// oldest first: its fields, links and comments.
+	// scope is the project name used to route to the correct sub-client.
+	GetWorkItemHistory(scope string, id int) ([]WorkItemRevision, error)
+
+	// GetWorkItemAttachments returns the files attached to the given work
+	// item, in the order they were attached.
+	// scope is the project name used to route to the correct sub-client.
+	GetWorkItemAttachments(scope string, id int) ([]WorkItemAttachment, error)
+
+	// DownloadWorkItemAttachment returns the content of an attachment
+	// GetWorkItemAttachments listed.
+	// scope is the project name used to route to the correct sub-client.
+	DownloadWorkItemAttachment(scope string, attachment WorkItemAttachment) ([]byte, error)
+
+	// AddWorkItemAttachment uploads content as a file with the given name
+	// and attaches it to the work item. Backends that cannot upload files
+	// return ErrAttachmentUploadUnsupported.
+	// scope is the project name used to route to the correct sub-client.
+	AddWorkItemAttachment(scope string, id int, name string, content []byte) (*WorkItemAttachment, error)
+
+	// ListWorkItemQueries returns the saved queries of the project, both the
+	// user's own and the shared ones, without folders. Backends without
+	// queries return ErrQueriesUnsupported.
+	// scope is the project name used to route to the correct sub-client.
+	ListWorkItemQueries(scope string) ([]WorkItemQuery, error)
+
+	// RunWorkItemQuery runs query in the project and returns its work items
+	// with the columns to show. Backends without queries return
+	// ErrQueriesUnsupported.
+	// scope is the project name used to route to the correct sub-client.
+	RunWorkItemQuery(scope string, query WorkItemQuery) (*WorkItemQueryResult, error)
+
+	// GetBoards returns the Kanban boards of the project's default team,
+	// with their columns in board order. Backends without boards derive
+	// one, e.g. from labels.
+	// scope is the project name used to route to the correct sub-client.
+	GetBoards(scope string) ([]Board, error)
+
+	// MoveWorkItemOnBoard moves a work item of the given type to the named
+	// column of board, setting the state the column has for the type.
+	// scope is the project name used to route to the correct sub-client.
+	MoveWorkItemOnBoard(scope string, id int, workItemType string, board Board, column string) error
+
+	// GetSprints returns the sprints of the project's team in order, with
+	// the current one marked. Backends without sprints return
+	// ErrSprintsUnsupported.
+	// scope is the project name used to route to the correct sub-client.
+	GetSprints(scope string) ([]Sprint, error)
+
+	// GetSprintBacklog returns the work items planned into sprint and the
+	// capacity the team's members have left in it.
+	// scope is the project name used to route to the correct sub-client.
+	GetSprintBacklog(scope string, sprint Sprint) (*SprintBacklog, error)
+
 	// GetWorkItemComments returns the discussion comments for the given work item,
 	// ordered newest first.
 	// scope is the project name used to route to the correct sub-client.
@@ -99,6 +257,39 @@ type Provider interface {
 	// scope is the project name used to route to the correct sub-client.
 	AddWorkItemComment(scope string, id int, text string) (*WorkItemComment, error)
 
+	// AddWorkItemCommentMarkdown posts a new comment written in markdown.
+	// Azure DevOps stores it as markdown rather than HTML; on GitHub it is
+	// the same as AddWorkItemComment.
+	// scope is the project name used to route to the correct sub-client.
+	AddWorkItemCommentMarkdown(scope string, id int, markdown string) (*WorkItemComment, error)
+
+	// EditWorkItemComment replaces the text of a work-item comment.
+	// scope is the project name used to route to the correct sub-client.
+	EditWorkItemComment(scope string, id, commentID int, text string) error
+
+	// DeleteWorkItemComment deletes a work-item comment.
+	// scope is the project name used to route to the correct sub-client.
+	DeleteWorkItemComment(scope string, id, commentID int) error
+
+	// ReactToWorkItemComment adds or (when remove is true) removes the
+	// authenticated user's reaction on a work-item comment. Returns
+	// ErrReactionUnsupported when the backend has no such reaction.
+	// scope is the project name used to route to the correct sub-client.
+	ReactToWorkItemComment(scope string, id, commentID int, reaction Reaction, remove bool) error
+
+	// --- People ---
+
+	// SearchPeople returns users matching query (the start of a name, login
+	// or email) who can be @mentioned in scope.
+	// scope is the project name used to route to the correct sub-client.
+	SearchPeople(scope, query string) ([]Person, error)
+
+	// ResolvePeople looks up the people behind the identity IDs found in
+	// Azure DevOps "@<GUID>" mentions, so they can be shown by name. GitHub
+	// mentions are already readable logins and it returns nil there.
+	// scope is the project name used to route to the correct sub-client.
+	ResolvePeople(scope string, ids []string) ([]Person, error)
+
 	// --- Pipeline surface ---
 
 	// ListPipelineRuns returns up to top recent pipeline/build runs.
@@ -40,7 +40,19 @@ func (s stubProvider) VotePullRequest(scope, repositoryID string, pullRequestID
 func (s stubProvider) GetFileContent(scope, repositoryID string, filePath string, branchName string) (string, error) {
 	return "", nil
 }
-func (s stubProvider) AddPRCodeComment(scope, repositoryID string, pullRequestID int, filePath string, line int, content string) (*provider.Thread, error) {
+func (s stubProvider) GetFileContentAtCommit(scope, repositoryID string, filePath string, commitID string) (string, error) {
+	return "", nil
+}
+func (s stubProvider) GetPRCommits(scope, repositoryID string, pullRequestID int) ([]provider.Commit, error) {
+	return nil, nil
+}
+func (s stubProvider) GetCommitChanges(scope, repositoryID string, commitID string) (*provider.CommitChanges, error) {
+	return nil, nil
+}
+func (s stubProvider) GetPRMergeability(scope, repositoryID string, pullRequestID int) (*provider.Mergeability, error) {
+	return nil, nil
+}
+func (s stubProvider) AddPRCodeComment(scope, repositoryID string, pullRequestID int, filePath string, rng provider.LineRange, content string) (*provider.Thread, error) {
 	return nil, nil
 }
 func (s stubProvider) AddPRComment(scope, repositoryID string, pullRequestID int, content string) (*provider.Thread, error) {
@@ -53,6 +65,34 @@ func (s stubProvider) UpdateThreadStatus(scope, repositoryID string, pullRequest
 	return nil
 }
 
+func (s stubProvider) SubmitReview(scope, repositoryID string, pullRequestID int, review provider.Review) error {
+	return nil
+}
+
+func (s stubProvider) ApplyFileEdit(scope, repositoryID string, edit provider.FileEdit) error {
+	return nil
+}
+
+func (s stubProvider) GetViewedFiles(scope, repositoryID string, pullRequestID int) ([]string, error) {
+	return nil, nil
+}
+
+func (s stubProvider) SetFileViewed(scope, repositoryID string, pullRequestID int, filePath string, viewed bool) error {
+	return nil
+}
+
+func (s stubProvider) EditPRComment(scope, repositoryID string, pullRequestID, threadID, commentID int, content string) error {
+	return nil
+}
+
+func (s stubProvider) DeletePRComment(scope, repositoryID string, pullRequestID, threadID, commentID int) error {
+	return nil
+}
+
+func (s stubProvider) ReactToPRComment(scope, repositoryID string, pullRequestID, threadID, commentID int, reaction provider.Reaction, remove bool) error {
+	return nil
+}
+
 // --- Work-item surface ---
 
 func (s stubProvider) ListWorkItems(top int, opts provider.ListOpts) ([]provider.WorkItem, error) {
@@ -65,6 +105,58 @@ func (s stubProvider) GetWorkItemTypeStates(scope, workItemType string) ([]provi
 	return nil, nil
 }
 func (s stubProvider) UpdateWorkItemState(scope string, id int, state string) error { return nil }
+func (s stubProvider) GetWorkItemTypes(scope string) ([]string, error)              { return nil, nil }
+func (s stubProvider) CreateWorkItem(scope string, item provider.NewWorkItem) (*provider.WorkItem, error) {
+	return nil, nil
+}
+func (s stubProvider) GetWorkItem(scope string, id int) (*provider.WorkItem, error) {
+	return nil, nil
+}
+func (s stubProvider) UpdateWorkItemFields(scope string, id int, update provider.WorkItemUpdate) (*provider.WorkItem, error) {
+	return nil, nil
+}
+func (s stubProvider) GetFieldOptions(scope string, field provider.WorkItemField) ([]string, error) {
+	return nil, nil
+}
+func (s stubProvider) GetWorkItemLinks(scope string, id int) ([]provider.WorkItemLink, error) {
+	return nil, nil
+}
+func (s stubProvider) AddWorkItemLink(scope string, id int, link provider.WorkItemLinkType, targetID int) error {
+	return nil
+}
+func (s stubProvider) RemoveWorkItemLink(scope string, id int, link provider.WorkItemLinkType, targetID int) error {
+	return nil
+}
+func (s stubProvider) GetWorkItemHistory(scope string, id int) ([]provider.WorkItemRevision, error) {
+	return nil, nil
+}
+func (s stubProvider) GetWorkItemAttachments(scope string, id int) ([]provider.WorkItemAttachment, error) {
+	return nil, nil
+}
+func (s stubProvider) DownloadWorkItemAttachment(scope string, attachment provider.WorkItemAttachment) ([]byte, error) {
+	return nil, nil
+}
+func (s stubProvider) AddWorkItemAttachment(scope string, id int, name string, content []byte) (*provider.WorkItemAttachment, error) {
+	return nil, nil
+}
+func (s stubProvider) ListWorkItemQueries(scope string) ([]provider.WorkItemQuery, error) {
+	return nil, nil
+}
+func (s stubProvider) RunWorkItemQuery(scope string, query provider.WorkItemQuery) (*provider.WorkItemQueryResult, error) {
+	return nil, nil
+}
+func (s stubProvider) GetBoards(scope string) ([]provider.Board, error) {
+	return nil, nil
+}
+func (s stubProvider) MoveWorkItemOnBoard(scope string, id int, workItemType string, board provider.Board, column string) error {
+	return nil
+}
+func (s stubProvider) GetSprints(scope string) ([]provider.Sprint, error) {
+	return nil, nil
+}
+func (s stubProvider) GetSprintBacklog(scope string, sprint provider.Sprint) (*provider.SprintBacklog, error) {
+	return nil, nil
+}
 func (s stubProvider) GetWorkItemComments(scope string, id int) ([]provider.WorkItemComment, error) {
 	return nil, nil
 }
@@ -72,6 +164,24 @@ func (s stubProvider) AddWorkItemComment(scope string, id int, text string) (*pr
 	return nil, nil
 }
 
+func (s stubProvider) AddWorkItemCommentMarkdown(scope string, id int, markdown string) (*provider.WorkItemComment, error) {
+	return nil, nil
+}
+func (s stubProvider) EditWorkItemComment(scope string, id, commentID int, text string) error {
+	return nil
+}
+func (s stubProvider) DeleteWorkItemComment(scope string, id, commentID int) error { return nil }
+func (s stubProvider) ReactToWorkItemComment(scope string, id, commentID int, reaction provider.Reaction, remove bool) error {
+	return nil
+}
+
+// --- People ---
+
+func (s stubProvider) SearchPeople(scope, query string) ([]provider.Person, error) { return nil, nil }
+func (s stubProvider) ResolvePeople(scope string, ids []string) ([]provider.Person, error) {
+	return nil, nil
+}
+
 // --- Pipeline surface ---
 
 func (s stubProvider) ListPipelineRuns(top int, opts provider.ListOpts) ([]provider.PipelineRun, error) {
@@ -44,6 +44,7 @@ type WorkItem struct {
 	// Populated by the adapter at mapping time via azdevops.MapItemType.
 	ItemKind        ItemType
 	AssignedToName  string
+	AssignedTo      string // unique name (Azure DevOps) or login (GitHub), used for edits
 	Priority        int
 	ChangedDate     time.Time
 	CreatedDate     time.Time
@@ -51,11 +52,27 @@ type WorkItem struct {
 	ActivatedDate   time.Time
 	ClosedDate      time.Time
 	IterationPath   string
+	AreaPath        string
 	Description     string
 	ReproSteps      string
-	Tags            string
-	StoryPoints     float64
-	URL             string
+	// AcceptanceCriteria is the HTML of the item's acceptance criteria on
+	// Azure DevOps. Empty on GitHub, where they live in the body.
+	AcceptanceCriteria string
+	Tags               string
+	StoryPoints        float64
+	RemainingWork      float64 // hours; 0 when the backend has no estimates
+	URL                string
+	ParentID           int // 0 when the item has no parent
+	// BoardColumn is the item's column on its team's board, where the
+	// backend tracks columns apart from states. Empty otherwise.
+	BoardColumn string
+	// Fields holds the display values of the columns of the query the item
+	// was read by, keyed by field reference name. nil outside queries.
+	Fields map[string]string
+	// Rev is the revision the item was read at; edits based on it fail
+	// with ErrWorkItemConflict if the item changed since. 0 when the
+	// backend has no revisions.
+	Rev int
 }
 
 // PullRequest is the neutral representation of a pull request.
@@ -66,15 +83,39 @@ type PullRequest struct {
 	Status         string
 	StatusCategory StateCategory // neutral semantic bucket derived from Status
 	CreationDate   time.Time
+	UpdatedDate    time.Time // last activity; backends without one report the closed or creation date
 	SourceRefName  string
 	TargetRefName  string
 	IsDraft        bool
+	Labels         []string
 	CreatedByName  string
 	CreatedByID    string
 	RepositoryID   string
 	RepositoryName string
 	Reviewers      []Reviewer
 	WebURL         string
+	MergeStatus    MergeStatus // MergeStatusUnknown when the backend did not report it
+}
+
+// Mergeability is the neutral representation of whether a pull request can
+// be merged, with the conflicting files when it cannot. Conflicts is nil on
+// backends that only report the status (GitHub).
+type Mergeability struct {
+	Status    MergeStatus
+	Conflicts []MergeConflict
+}
+
+// MergeConflict is a file that conflicts when merging a pull request. The
+// three commits are the sides of the merge: the merge base, the source
+// branch ("theirs") and the target branch ("ours"), in git's terms for
+// merging the source into the target.
+type MergeConflict struct {
+	ID           int
+	Path         string
+	Kind         string // backend conflict type, e.g. "editEdit" or "editDelete"
+	BaseCommit   string
+	SourceCommit string
+	TargetCommit string
 }
 
 // Reviewer is the neutral representation of a pull request reviewer.
@@ -103,15 +144,82 @@ type PipelineRun struct {
 }
 
 // Thread is the neutral representation of a pull request comment thread.
+//
+// Line is the line the thread is displayed at on Range.Side — the start line
+// for Azure DevOps and the end line for GitHub, matching each web UI. It is 0
+// for general comments. Range carries the full anchor for code comments.
 type Thread struct {
 	Identity        Identity
 	PublishedDate   time.Time
 	LastUpdatedDate time.Time
 	Status          string
 	FilePath        string // non-empty when this is a code comment
-	Line            int    // new-file line number from RightFileStart.Line; 0 for general comments
+	Line            int    // display line on Range.Side; 0 for general comments
+	Range           LineRange
 	Comments        []Comment
 	IsDeleted       bool
+	// Outdated marks a code comment whose lines no longer exist in the
+	// current diff; Range then holds its lines in the commit it was made on.
+	Outdated bool
+}
+
+// Side identifies which version of a file a code comment is anchored to.
+type Side string
+
+const (
+	SideRight Side = "right" // new (source-branch) version; the default
+	SideLeft  Side = "left"  // old (target-branch) version, e.g. deleted lines
+)
+
+// LineRange anchors a code comment to a span of lines on one side of a diff.
+// Lines are 1-based and inclusive. Offsets are 1-based character columns;
+// zero means the whole line. A single-line anchor has StartLine == EndLine.
+// The zero Side is treated as SideRight.
+type LineRange struct {
+	Side        Side
+	StartLine   int
+	StartOffset int
+	EndLine     int
+	EndOffset   int
+}
+
+// LineAt returns a whole-line anchor for a single line on side.
+func LineAt(side Side, line int) LineRange {
+	return LineRange{Side: side, StartLine: line, EndLine: line}
+}
+
+// IsLeft reports whether the range is anchored to the old version of the file.
+func (r LineRange) IsLeft() bool {
+	return r.Side == SideLeft
+}
+
+// IsMultiLine reports whether the range spans more than one line.
+func (r LineRange) IsMultiLine() bool {
+	return r.EndLine > r.StartLine
+}
+
+// ReviewVerdict is the outcome a reviewer attaches to a submitted review.
+type ReviewVerdict string
+
+const (
+	VerdictComment        ReviewVerdict = "comment"         // feedback only, no vote
+	VerdictApprove        ReviewVerdict = "approve"         // approve the changes
+	VerdictRequestChanges ReviewVerdict = "request_changes" // block until addressed
+)
+
+// ReviewComment is one inline comment drafted during a review session.
+type ReviewComment struct {
+	FilePath string
+	Range    LineRange
+	Content  string
+}
+
+// Review is a batch of drafted comments submitted together with a verdict
+// and an optional summary Body, so the author is notified once.
+type Review struct {
+	Verdict  ReviewVerdict
+	Body     string
+	Comments []ReviewComment
 }
 
 // Comment is the neutral representation of a single comment within a thread.
@@ -124,6 +232,64 @@ type Comment struct {
 	CommentType     string
 	AuthorName      string
 	AuthorID        string
+	Reactions       []ReactionCount
+	IsDeleted       bool // deleted comments stay in Azure DevOps threads as tombstones
+}
+
+// Reaction is an emoji reaction on a comment. Values follow GitHub's
+// reaction names; Azure DevOps pull request comments only support
+// ReactionLike, and its work-item comments have no rocket or eyes.
+type Reaction string
+
+const (
+	ReactionLike     Reaction = "+1"
+	ReactionDislike  Reaction = "-1"
+	ReactionLaugh    Reaction = "laugh"
+	ReactionHooray   Reaction = "hooray"
+	ReactionConfused Reaction = "confused"
+	ReactionHeart    Reaction = "heart"
+	ReactionRocket   Reaction = "rocket"
+	ReactionEyes     Reaction = "eyes"
+)
+
+// PRCommentReactions returns the reactions a pull request comment on the
+// given backend accepts.
+func PRCommentReactions(kind Kind) []Reaction {
+	if kind == KindAzure {
+		return []Reaction{ReactionLike}
+	}
+	return []Reaction{ReactionLike, ReactionDislike, ReactionLaugh, ReactionHooray, ReactionConfused, ReactionHeart, ReactionRocket, ReactionEyes}
+}
+
+// WorkItemCommentReactions returns the reactions a work item comment on the
+// given backend accepts.
+func WorkItemCommentReactions(kind Kind) []Reaction {
+	if kind == KindAzure {
+		return []Reaction{ReactionLike, ReactionDislike, ReactionLaugh, ReactionHooray, ReactionConfused, ReactionHeart}
+	}
+	return PRCommentReactions(kind)
+}
+
+// ReactionCount is how many people reacted to a comment with Reaction.
+// Mine is set when the authenticated user is one of them, so the UI can
+// take the reaction back instead of adding another.
+type ReactionCount struct {
+	Reaction Reaction
+	Count    int
+	Mine     bool
+}
+
+// Person is a user who can be @mentioned in a comment. Mention and
+// HTMLMention are the backend's markup for mentioning them in markdown text
+// (pull request comments, GitHub issues) and in HTML text (Azure DevOps work
+// item comments): "@<GUID>" and a data-vss-mention link on Azure DevOps,
+// "@login" for both on GitHub.
+type Person struct {
+	ID          string // Azure DevOps identity GUID; GitHub login
+	DisplayName string
+	Login       string // Azure DevOps unique name (usually an email); GitHub login
+	Mention     string
+	HTMLMention string
 }
 
 // Timeline is the neutral representation of a pipeline build timeline, which
@@ -180,6 +346,10 @@ type IterationChange struct {
 	GitObjectType string // "blob" for files, "tree" for folders
 	ChangeType    string // "add", "edit", "delete", "rename"
 	OriginalPath  string // non-empty on renames
+	// ObjectID identifies the file's content in this iteration (the git blob
+	// SHA) so a file can be recognised as changed between iterations. Empty
+	// when the backend does not report it.
+	ObjectID string
 	// Patch holds a ready-made unified-diff for this file when the backend can
 	// supply one (GitHub's PR files API). When non-empty the diff view renders
 	// it directly instead of fetching file content at branch refs. Azure leaves
@@ -187,6 +357,223 @@ type IterationChange struct {
 	Patch string
 }
 
+// Commit is the neutral representation of a single commit in a pull request.
+type Commit struct {
+	ID          string // full commit SHA
+	Message     string
+	AuthorName  string
+	AuthorEmail string
+	Date        time.Time // author date
+}
+
+// CommitChanges is the neutral representation of the files a single commit
+// changed. ParentID is the commit the changes are relative to (its first
+// parent) and is empty for a root commit.
+type CommitChanges struct {
+	ParentID string
+	Changes  []IterationChange
+}
+
+// NewWorkItem holds the fields of a work item to create. Zero values are
+// left unset.
+type NewWorkItem struct {
+	Type          string // one of the names returned by GetWorkItemTypes
+	Title         string
+	Description   string
+	AssignedTo    string // display name, email or login
+	Priority      int
+	IterationPath string // the iteration, or the milestone on GitHub
+	AreaPath      string
+	Tags          []string
+	ParentID      int
+}
+
+// WorkItemField names an editable work item field. Besides the constants,
+// Azure DevOps accepts any field reference name, e.g. "Custom.Severity".
+type WorkItemField string
+
+const (
+	FieldTitle       WorkItemField = "title"
+	FieldAssignedTo  WorkItemField = "assignedTo" // unique name or login; see WorkItem.AssignedTo
+	FieldPriority    WorkItemField = "priority"
+	FieldStoryPoints WorkItemField = "storyPoints"
+	FieldIteration   WorkItemField = "iteration" // the milestone on GitHub
+	FieldArea        WorkItemField = "area"
+	FieldTags        WorkItemField = "tags"        // "; "-separated, as in WorkItem.Tags
+	FieldDescription WorkItemField = "description" // HTML on Azure DevOps, markdown on GitHub
+	FieldReproSteps  WorkItemField = "reproSteps"  // a Bug's description on Azure DevOps
+)
+
+// WorkItemUpdate is an edit of some fields of a work item. An empty value
+// clears the field.
+type WorkItemUpdate struct {
+	Rev    int // the revision the edit is based on; 0 skips the conflict check
+	Fields map[WorkItemField]string
+}
+
+// WorkItemLinkType names how a linked work item relates to the one it is
+// read from.
+type WorkItemLinkType string
+
+const (
+	LinkParent  WorkItemLinkType = "parent"
+	LinkChild   WorkItemLinkType = "child"
+	LinkRelated WorkItemLinkType = "related"
+)
+
+// WorkItemLink is a work item linked to another one, as seen from it.
+type WorkItemLink struct {
+	Type WorkItemLinkType
+	Item WorkItem
+}
+
+// WorkItemChangeKind tells what a WorkItemChange changed.
+type WorkItemChangeKind string
+
+const (
+	ChangeField   WorkItemChangeKind = "field"
+	ChangeLink    WorkItemChangeKind = "link"
+	ChangeComment WorkItemChangeKind = "comment"
+)
+
+// WorkItemChange is one change a revision made to a work item. Field
+// names the changed field; link and comment changes use "Links" and
+// "Comment". Old is empty for a field that was set, a link that was added
+// or a comment, and New for a field that was cleared or a link that was
+// removed. Links read as the link type and target, e.g. "Child #12".
+type WorkItemChange struct {
+	Kind  WorkItemChangeKind
+	Field string
+	Old   string
+	New   string
+}
+
+// WorkItemRevision is one entry of a work item's history: who changed
+// what and when.
+type WorkItemRevision struct {
+	Rev         int // the work item's revision; 0 on backends without revisions
+	AuthorName  string
+	AuthorLogin string
+	Date        time.Time
+	Changes     []WorkItemChange
+}
+
+// WorkItemAttachment is a file attached to a work item.
+type WorkItemAttachment struct {
+	Name       string
+	URL        string    // where the file is downloaded from; opens in a signed-in browser
+	Size       int64     // in bytes; 0 if unknown
+	UploadedBy string    // display name; empty if unknown
+	UploadedAt time.Time // zero if unknown
+}
+
+// WorkItemQuery is a named work item query: a saved query of the backend
+// or one defined in the config. Saved queries are run by ID; others by
+// their WIQL, or by WIQL built from Criteria when WIQL is empty.
+type WorkItemQuery struct {
+	ID       string // the backend's query ID; empty for queries from the config
+	Name     string
+	Path     string // folder path, e.g. "Shared Queries/Team"; empty for queries from the config
+	Scope    string // the project the query belongs to; empty runs it in every project
+	WIQL     string
+	Criteria WorkItemQueryCriteria
+	// Tree shows the results as a hierarchy. Tree and one-hop saved queries
+	// are trees; flat ones can still be shown as a tree of parents.
+	Tree bool
+	// Columns are field reference names shown instead of the default
+	// state, priority and assignee columns. Empty uses the columns the
+	// query selects.
+	Columns []string
+	Top     int // maximum number of results; 0 uses the backend's default
+}
+
+// WorkItemQueryCriteria describes a query without writing WIQL. Empty
+// fields do not restrict the results. Values are matched literally except
+// for macros starting with "@", such as @Me or @CurrentIteration.
+type WorkItemQueryCriteria struct {
+	Types         []string
+	States        []string
+	Tags          []string // items must have all of them
+	AssignedTo    string
+	AreaPath      string // the area or one under it
+	IterationPath string // the iteration or one under it
+}
+
+// WorkItemQueryColumn is a column of a query result.
+type WorkItemQueryColumn struct {
+	Field string // reference name, e.g. "System.State"
+	Name  string // display name, e.g. "State"
+}
+
+// WorkItemQueryResult is the outcome of running a query. Items are in the
+// query's order; for tree queries each item's ParentID is its parent in
+// the result.
+type WorkItemQueryResult struct {
+	Items   []WorkItem
+	Columns []WorkItemQueryColumn
+	Tree    bool
+}
+
+// Board is a Kanban board: the columns work items move through from left
+// to right.
+type Board struct {
+	ID      string
+	Name    string
+	Columns []BoardColumn
+	// ColumnField is the field holding an item's column, set along with
+	// the state when an item moves. Empty when columns follow from the
+	// state or labels alone.
+	ColumnField string
+}
+
+// BoardColumn is a column of a Board.
+type BoardColumn struct {
+	Name string
+	// States maps work item types to the state their items have in the
+	// column. The "" key applies to every type.
+	States map[string]string
+	// Label is the label items in the column carry, on boards whose
+	// columns are labels (GitHub). Empty otherwise.
+	Label    string
+	WIPLimit int // 0 when the column has no limit
+}
+
+// StateFor returns the state items of workItemType have in the column, and
+// whether they can be in it at all.
+func (c BoardColumn) StateFor(workItemType string) (string, bool) {
+	if state, ok := c.States[workItemType]; ok {
+		return state, true
+	}
+	state, ok := c.States[""]
+	return state, ok
+}
+
+// Sprint is an iteration of a project's team.
+type Sprint struct {
+	ID     string
+	Name   string
+	Path   string    // iteration path items are planned into, e.g. "Project\Sprint 24"
+	Start  time.Time // zero when the sprint has no dates
+	Finish time.Time
+	// Current marks the sprint under way today.
+	Current bool
+}
+
+// SprintCapacity is the work a team member can still do in a sprint.
+type SprintCapacity struct {
+	Name  string  // display name
+	Login string  // unique name, matching WorkItem.AssignedTo
+	Hours float64 // capacity over the working days left, net of days off
+}
+
+// SprintBacklog is the work planned into a sprint and the team's capacity
+// to do it.
+type SprintBacklog struct {
+	Items    []WorkItem
+	Capacity []SprintCapacity
+	DaysLeft int // working days left in the sprint, net of the team's days off
+}
+
 // WorkItemTypeState is the neutral representation of a state that is valid for
 // a given work item type (e.g. "Active", "Resolved", "Closed").
 type WorkItemTypeState struct {
@@ -201,6 +588,8 @@ type WorkItemComment struct {
 	Identity    Identity
 	ID          int
 	Text        string
+	Markdown    bool // Text is markdown rather than the backend's usual format
 	AuthorName  string
 	CreatedDate time.Time
+	Reactions   []ReactionCount
 }
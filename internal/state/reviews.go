@@ -0,0 +1,106 @@
+package state
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// reviewsFileName holds drafted review comments. It is kept apart from
+// state.yaml so navigation state stays small and comparable, and so drafts
+// can be written synchronously without touching the debounced Store.
+const reviewsFileName = "reviews.yaml"
+
+// DraftComment is an inline comment written during a review session but
+// not yet submitted. Side is "right" or "left"; lines are 1-based and
+// inclusive, with StartLine == EndLine for a single-line comment.
+type DraftComment struct {
+	FilePath  string    `yaml:"file_path"`
+	Side      string    `yaml:"side,omitempty"`
+	StartLine int       `yaml:"start_line"`
+	EndLine   int       `yaml:"end_line"`
+	Content   string    `yaml:"content"`
+	CreatedAt time.Time `yaml:"created_at"`
+}
+
+// reviewsFile is the on-disk shape of reviews.yaml: drafts keyed by
+// ReviewKey.
+type reviewsFile struct {
+	Version int                       `yaml:"version,omitempty"`
+	Reviews map[string][]DraftComment `yaml:"reviews,omitempty"`
+}
+
+// ReviewKey identifies the pull request a set of drafts belongs to. backend
+// is the provider kind name, scope the project or "owner/repo" slug.
+func ReviewKey(backend, scope, repositoryID string, pullRequestID int) string {
+	return fmt.Sprintf("%s/%s/%s/%d", backend, scope, repositoryID, pullRequestID)
+}
+
+// ReviewsPath returns the on-disk location of the review drafts file
+// inside Dir.
+func ReviewsPath() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, reviewsFileName), nil
+}
+
+// DraftStore persists pending-review drafts. Unlike Store, every change is
+// written through immediately: drafts are user-authored text, and losing
+// one to a crash inside a debounce window is not acceptable. It is safe for
+// concurrent use.
+type DraftStore struct {
+	path string
+
+	mu     sync.Mutex
+	drafts map[string][]DraftComment
+}
+
+// NewDraftStore creates a DraftStore seeded with the file at path. A missing
+// file is treated as having no drafts — not an error.
+func NewDraftStore(path string) (*DraftStore, error) {
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("read review drafts: %w", err)
+	}
+	var f reviewsFile
+	if len(data) > 0 {
+		if err := yaml.Unmarshal(data, &f); err != nil {
+			return nil, fmt.Errorf("parse review drafts: %w", err)
+		}
+	}
+	if f.Reviews == nil {
+		f.Reviews = make(map[string][]DraftComment)
+	}
+	return &DraftStore{path: path, drafts: f.Reviews}, nil
+}
+
+// Drafts returns a copy of the drafts stored under key, oldest first.
+func (s *DraftStore) Drafts(key string) []DraftComment {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]DraftComment(nil), s.drafts[key]...)
+}
+
+// SetDrafts replaces the drafts stored under key and writes the file before
+// returning. An empty slice removes the key.
+func (s *DraftStore) SetDrafts(key string, drafts []DraftComment) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(drafts) == 0 {
+		delete(s.drafts, key)
+	} else {
+		s.drafts[key] = append([]DraftComment(nil), drafts...)
+	}
+	data, err := yaml.Marshal(reviewsFile{Version: CurrentVersion, Reviews: s.drafts})
+	if err != nil {
+		return fmt.Errorf("marshal review drafts: %w", err)
+	}
+	return writeAtomic(s.path, data)
+}
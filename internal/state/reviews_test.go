@@ -0,0 +1,103 @@
+package state
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestReviewsPath_HonorsXDGStateHome(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv("XDG_STATE_HOME", tmp)
+
+	got, err := ReviewsPath()
+	if err != nil {
+		t.Fatalf("ReviewsPath() error = %v", err)
+	}
+	want := filepath.Join(tmp, "azdo-tui", "reviews.yaml")
+	if got != want {
+		t.Errorf("ReviewsPath() = %q, want %q", got, want)
+	}
+}
+
+func TestDraftStore_MissingFileStartsEmpty(t *testing.T) {
+	store, err := NewDraftStore(filepath.Join(t.TempDir(), "reviews.yaml"))
+	if err != nil {
+		t.Fatalf("NewDraftStore() error = %v", err)
+	}
+	if got := store.Drafts("azure/proj/repo/1"); len(got) != 0 {
+		t.Errorf("Drafts() = %+v, want none", got)
+	}
+}
+
+func TestDraftStore_SetDraftsWritesThroughAndReloads(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "reviews.yaml")
+	store, err := NewDraftStore(path)
+	if err != nil {
+		t.Fatalf("NewDraftStore() error = %v", err)
+	}
+
+	key := ReviewKey("github", "o/r", "", 7)
+	drafts := []DraftComment{
+		{FilePath: "main.go", Side: "right", StartLine: 3, EndLine: 5, Content: "split this", CreatedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)},
+		{FilePath: "old.go", Side: "left", StartLine: 9, EndLine: 9, Content: "why removed?"},
+	}
+	if err := store.SetDrafts(key, drafts); err != nil {
+		t.Fatalf("SetDrafts() error = %v", err)
+	}
+
+	// Written synchronously: a fresh store sees the drafts immediately.
+	reloaded, err := NewDraftStore(path)
+	if err != nil {
+		t.Fatalf("reload error = %v", err)
+	}
+	got := reloaded.Drafts(key)
+	if len(got) != 2 {
+		t.Fatalf("reloaded %d drafts, want 2", len(got))
+	}
+	if got[0] != drafts[0] || got[1] != drafts[1] {
+		t.Errorf("reloaded drafts = %+v, want %+v", got, drafts)
+	}
+
+	// Clearing removes the key from the file.
+	if err := store.SetDrafts(key, nil); err != nil {
+		t.Fatalf("SetDrafts(nil) error = %v", err)
+	}
+	reloaded, _ = NewDraftStore(path)
+	if got := reloaded.Drafts(key); len(got) != 0 {
+		t.Errorf("drafts after clear = %+v, want none", got)
+	}
+}
+
+func TestDraftStore_KeysAreIndependent(t *testing.T) {
+	store, err := NewDraftStore(filepath.Join(t.TempDir(), "reviews.yaml"))
+	if err != nil {
+		t.Fatalf("NewDraftStore() error = %v", err)
+	}
+	a := ReviewKey("azure", "proj", "repo", 1)
+	b := ReviewKey("azure", "proj", "repo", 2)
+	if err := store.SetDrafts(a, []DraftComment{{FilePath: "a.go", StartLine: 1, EndLine: 1, Content: "x"}}); err != nil {
+		t.Fatalf("SetDrafts(a) error = %v", err)
+	}
+	if got := store.Drafts(b); len(got) != 0 {
+		t.Errorf("Drafts(b) = %+v, want none", got)
+	}
+
+	// Mutating the returned slice must not affect the store.
+	got := store.Drafts(a)
+	got[0].Content = "changed"
+	if store.Drafts(a)[0].Content != "x" {
+		t.Error("Drafts() returned a slice aliasing the store")
+	}
+}
+
+func TestNewDraftStore_CorruptFileIsAnError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "reviews.yaml")
+	if err := os.WriteFile(path, []byte("reviews: [unclosed"), 0o644); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	if _, err := NewDraftStore(path); err == nil {
+		t.Error("NewDraftStore() on corrupt file: want error, got nil")
+	}
+}
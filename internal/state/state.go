@@ -62,17 +62,26 @@ func (s *State) Unmarshal(data []byte) error {
 	return yaml.Unmarshal(data, s)
 }
 
-// Path returns the on-disk location of the state file, honoring
+// Dir returns the directory holding azdo-tui's state files, honoring
 // $XDG_STATE_HOME when set and falling back to ~/.local/state/azdo-tui/.
-func Path() (string, error) {
+func Dir() (string, error) {
 	if base := os.Getenv("XDG_STATE_HOME"); base != "" {
-		return filepath.Join(base, dirName, fileName), nil
+		return filepath.Join(base, dirName), nil
 	}
 	home, err := os.UserHomeDir()
 	if err != nil {
 		return "", fmt.Errorf("resolve home dir: %w", err)
 	}
-	return filepath.Join(home, ".local", "state", dirName, fileName), nil
+	return filepath.Join(home, ".local", "state", dirName), nil
+}
+
+// Path returns the on-disk location of the state file inside Dir.
+func Path() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, fileName), nil
 }
 
 // Load reads and parses the state file. A missing file is not an error —
@@ -0,0 +1,103 @@
+package state
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// viewedFileName holds the files marked as viewed during reviews. Marks are
+// written through like drafts, but kept in their own file since they are
+// machine state rather than user-authored text.
+const viewedFileName = "viewed.yaml"
+
+// ViewedFile records that a file was marked as viewed. Iteration is the
+// pull request iteration it was viewed in and Hash identifies the file's
+// content at that point, so the mark can be dropped once a later iteration
+// changes the file.
+type ViewedFile struct {
+	Iteration int    `yaml:"iteration"`
+	Hash      string `yaml:"hash"`
+}
+
+// viewedFile is the on-disk shape of viewed.yaml: marks keyed by ReviewKey,
+// then by file path.
+type viewedFile struct {
+	Version int                              `yaml:"version,omitempty"`
+	Viewed  map[string]map[string]ViewedFile `yaml:"viewed,omitempty"`
+}
+
+// ViewedPath returns the on-disk location of the viewed files file inside
+// Dir.
+func ViewedPath() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, viewedFileName), nil
+}
+
+// ViewedStore persists which files of each pull request were marked as
+// viewed. Every change is written through immediately. It is safe for
+// concurrent use.
+type ViewedStore struct {
+	path string
+
+	mu     sync.Mutex
+	viewed map[string]map[string]ViewedFile
+}
+
+// NewViewedStore creates a ViewedStore seeded with the file at path. A
+// missing file is treated as nothing viewed — not an error.
+func NewViewedStore(path string) (*ViewedStore, error) {
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("read viewed files: %w", err)
+	}
+	var f viewedFile
+	if len(data) > 0 {
+		if err := yaml.Unmarshal(data, &f); err != nil {
+			return nil, fmt.Errorf("parse viewed files: %w", err)
+		}
+	}
+	if f.Viewed == nil {
+		f.Viewed = make(map[string]map[string]ViewedFile)
+	}
+	return &ViewedStore{path: path, viewed: f.Viewed}, nil
+}
+
+// Viewed returns a copy of the marks stored under key, by file path.
+func (s *ViewedStore) Viewed(key string) map[string]ViewedFile {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	files := make(map[string]ViewedFile, len(s.viewed[key]))
+	for path, v := range s.viewed[key] {
+		files[path] = v
+	}
+	return files
+}
+
+// SetViewed replaces the marks stored under key and writes the file before
+// returning. An empty map removes the key.
+func (s *ViewedStore) SetViewed(key string, files map[string]ViewedFile) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(files) == 0 {
+		delete(s.viewed, key)
+	} else {
+		cp := make(map[string]ViewedFile, len(files))
+		for path, v := range files {
+			cp[path] = v
+		}
+		s.viewed[key] = cp
+	}
+	data, err := yaml.Marshal(viewedFile{Version: CurrentVersion, Viewed: s.viewed})
+	if err != nil {
+		return fmt.Errorf("marshal viewed files: %w", err)
+	}
+	return writeAtomic(s.path, data)
+}
@@ -0,0 +1,82 @@
+package state
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestViewedPath_HonorsXDGStateHome(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv("XDG_STATE_HOME", tmp)
+
+	got, err := ViewedPath()
+	if err != nil {
+		t.Fatalf("ViewedPath() error = %v", err)
+	}
+	want := filepath.Join(tmp, "azdo-tui", "viewed.yaml")
+	if got != want {
+		t.Errorf("ViewedPath() = %q, want %q", got, want)
+	}
+}
+
+func TestViewedStore_SetViewedWritesThroughAndReloads(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "viewed.yaml")
+	store, err := NewViewedStore(path)
+	if err != nil {
+		t.Fatalf("NewViewedStore() error = %v", err)
+	}
+	if got := store.Viewed("azure/proj/repo/1"); len(got) != 0 {
+		t.Errorf("Viewed() on a fresh store = %+v, want none", got)
+	}
+
+	key := ReviewKey("azure", "proj", "repo", 1)
+	files := map[string]ViewedFile{
+		"/src/a.go": {Iteration: 2, Hash: "abc"},
+		"/src/b.go": {Iteration: 1, Hash: "def"},
+	}
+	if err := store.SetViewed(key, files); err != nil {
+		t.Fatalf("SetViewed() error = %v", err)
+	}
+	files["/src/c.go"] = ViewedFile{}
+	if len(store.Viewed(key)) != 2 {
+		t.Error("SetViewed() kept a reference to the caller's map")
+	}
+
+	reloaded, err := NewViewedStore(path)
+	if err != nil {
+		t.Fatalf("reload error = %v", err)
+	}
+	want := map[string]ViewedFile{
+		"/src/a.go": {Iteration: 2, Hash: "abc"},
+		"/src/b.go": {Iteration: 1, Hash: "def"},
+	}
+	if got := reloaded.Viewed(key); !reflect.DeepEqual(got, want) {
+		t.Errorf("reloaded marks = %+v, want %+v", got, want)
+	}
+}
+
+func TestViewedStore_EmptyMapRemovesKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "viewed.yaml")
+	store, _ := NewViewedStore(path)
+	key := ReviewKey("github", "o/r", "", 7)
+	_ = store.SetViewed(key, map[string]ViewedFile{"a.go": {Iteration: 1, Hash: "x"}})
+	if err := store.SetViewed(key, nil); err != nil {
+		t.Fatalf("SetViewed(nil) error = %v", err)
+	}
+	reloaded, _ := NewViewedStore(path)
+	if got := reloaded.Viewed(key); len(got) != 0 {
+		t.Errorf("Viewed() after clearing = %+v, want none", got)
+	}
+}
+
+func TestNewViewedStore_RejectsCorruptFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "viewed.yaml")
+	if err := os.WriteFile(path, []byte("viewed: [unclosed"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewViewedStore(path); err == nil {
+		t.Error("NewViewedStore() on a corrupt file: want error")
+	}
+}
@@ -2,7 +2,9 @@ package components
 
 import (
 	"strings"
+	"unicode"
 
+	"github.com/Elpulgo/azdo/internal/provider"
 	"github.com/Elpulgo/azdo/internal/ui/styles"
 	"github.com/charmbracelet/bubbles/textarea"
 	tea "github.com/charmbracelet/bubbletea"
@@ -20,12 +22,48 @@ type CommentFormCancelledMsg struct{}
 // commentFormHeight is the number of textarea rows shown in the form.
 const commentFormHeight = 5
 
+// mentionPopupRows is the number of people listed in the mention popup.
+const mentionPopupRows = 5
+
+// MentionSearchFunc looks up the people matching the text typed after "@".
+type MentionSearchFunc func(query string) ([]provider.Person, error)
+
+// mentionResultsMsg carries the people found for a mention query.
+type mentionResultsMsg struct {
+	query  string
+	people []provider.Person
+	err    error
+}
+
+// mentionPopup is the autocomplete state while the cursor is in an "@query"
+// token. dismissed is set by Esc and keeps the popup closed until the token
+// ends.
+type mentionPopup struct {
+	active    bool
+	dismissed bool
+	query     string
+	people    []provider.Person
+	index     int
+	loading   bool
+	err       error
+}
+
 // CommentForm is an inline multi-line text form for composing a work item comment.
-// Enter inserts a newline; Ctrl+S submits; Esc cancels.
+// Enter inserts a newline; Ctrl+S submits; Esc cancels. With mentions
+// enabled, typing "@" opens a popup of matching people.
 type CommentForm struct {
 	styles   *styles.Styles
 	textarea textarea.Model
 	visible  bool
+
+	// Mentions: search finds people for the popup, mentioned holds the ones
+	// picked so far, whose "@Display Name" is turned into mention markup on
+	// submit (HTMLMention when html is set, Mention otherwise).
+	search    MentionSearchFunc
+	html      bool
+	mention   mentionPopup
+	mentioned []provider.Person
+	cache     map[string][]provider.Person
 }
 
 // NewCommentForm creates a new inline comment form.
@@ -43,6 +81,15 @@ func NewCommentForm(s *styles.Styles) CommentForm {
 	}
 }
 
+// EnableMentions turns on @mention autocomplete, looking people up with
+// search. html selects the HTML mention markup, for comments stored as HTML
+// such as Azure DevOps work item comments.
+func (f *CommentForm) EnableMentions(search MentionSearchFunc, html bool) {
+	f.search = search
+	f.html = html
+	f.cache = make(map[string][]provider.Person)
+}
+
 // Show makes the form visible.
 func (f *CommentForm) Show() {
 	f.visible = true
@@ -67,6 +114,8 @@ func (f *CommentForm) Focus() tea.Cmd {
 // Reset clears the textarea content.
 func (f *CommentForm) Reset() {
 	f.textarea.Reset()
+	f.mention = mentionPopup{}
+	f.mentioned = nil
 }
 
 // SetValue replaces the textarea content (used to restore a draft after a failed send).
@@ -90,10 +139,11 @@ func (f *CommentForm) SetWidth(width int) {
 }
 
 // Height returns the number of terminal rows the form occupies when visible
-// (textarea rows + border + help line).
+// (textarea rows + border + mention popup + help line). It changes while the
+// mention popup opens and closes.
 func (f CommentForm) Height() int {
 	// textarea height + top/bottom border (2) + help line (1)
-	return commentFormHeight + 3
+	return commentFormHeight + 3 + len(f.popupLines())
 }
 
 // Update handles messages for the form. Ctrl+S submits, Esc cancels, everything
@@ -103,7 +153,17 @@ func (f CommentForm) Update(msg tea.Msg) (CommentForm, tea.Cmd) {
 		return f, nil
 	}
 
+	if results, ok := msg.(mentionResultsMsg); ok {
+		f.handleMentionResults(results)
+		return f, nil
+	}
+
 	if key, ok := msg.(tea.KeyMsg); ok {
+		if f.mention.active {
+			if handled := f.updateMentionPopup(key); handled {
+				return f, nil
+			}
+		}
 		switch key.Type {
 		case tea.KeyEsc:
 			// Hide synchronously so the dispatched CommentFormCancelledMsg is
@@ -116,7 +176,9 @@ func (f CommentForm) Update(msg tea.Msg) (CommentForm, tea.Cmd) {
 			if strings.TrimSpace(text) == "" {
 				return f, nil
 			}
+			text = expandMentions(text, f.mentioned, f.html)
 			f.visible = false
+			f.mention = mentionPopup{}
 			f.textarea.Blur()
 			return f, func() tea.Msg { return CommentSubmittedMsg{Text: text} }
 		}
@@ -124,6 +186,9 @@ func (f CommentForm) Update(msg tea.Msg) (CommentForm, tea.Cmd) {
 
 	var cmd tea.Cmd
 	f.textarea, cmd = f.textarea.Update(msg)
+	if _, ok := msg.(tea.KeyMsg); ok {
+		cmd = tea.Batch(cmd, f.refreshMention())
+	}
 	return f, cmd
 }
 
@@ -133,14 +198,178 @@ func (f CommentForm) View() string {
 		return ""
 	}
 
+	help := "Ctrl+S: send • Esc: cancel"
+	if f.search != nil {
+		help = "Ctrl+S: send • @: mention • Esc: cancel"
+	}
+	if len(f.mention.people) > 0 && f.mention.active {
+		help = "↑/↓: choose • Tab/Enter: mention • Esc: close"
+	}
 	helpText := lipgloss.NewStyle().
 		Foreground(f.styles.Theme.GetForegroundMuted()).
-		Render("Ctrl+S: send • Esc: cancel")
+		Render(help)
 
 	box := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
 		BorderForeground(f.styles.Theme.GetBorder()).
 		Render(f.textarea.View())
 
-	return lipgloss.JoinVertical(lipgloss.Left, box, helpText)
+	rows := append([]string{box}, f.popupLines()...)
+	return lipgloss.JoinVertical(lipgloss.Left, append(rows, helpText)...)
+}
+
+// --- Mentions ---
+
+// mentionQuery returns the text typed after "@" when the cursor is at the
+// end of a mention token: an "@" at the start of a line or after a space or
+// opening bracket, followed by name characters. An "@" inside an email
+// address does not count.
+func (f CommentForm) mentionQuery() (string, bool) {
+	lines := strings.Split(f.textarea.Value(), "\n")
+	row := f.textarea.Line()
+	if row < 0 || row >= len(lines) {
+		return "", false
+	}
+	line := []rune(lines[row])
+	info := f.textarea.LineInfo()
+	col := min(info.StartColumn+info.ColumnOffset, len(line))
+
+	start := col
+	for start > 0 && isMentionRune(line[start-1]) {
+		start--
+	}
+	if start == 0 || line[start-1] != '@' {
+		return "", false
+	}
+	at := start - 1
+	if at > 0 && !unicode.IsSpace(line[at-1]) && !strings.ContainsRune("([{", line[at-1]) {
+		return "", false
+	}
+	return string(line[start:col]), true
+}
+
+// isMentionRune reports whether r can be part of a name, login or email
+// typed after "@".
+func isMentionRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("._-", r)
+}
+
+// refreshMention opens, updates or closes the popup after the text or the
+// cursor moved, returning the search for a new query.
+func (f *CommentForm) refreshMention() tea.Cmd {
+	if f.search == nil {
+		return nil
+	}
+	query, ok := f.mentionQuery()
+	if !ok {
+		f.mention = mentionPopup{}
+		return nil
+	}
+	if f.mention.dismissed || (f.mention.active && query == f.mention.query) {
+		return nil
+	}
+	// Keep the previous matches on screen while the new query is searched so
+	// the popup does not collapse on every keystroke.
+	f.mention = mentionPopup{active: true, query: query, people: f.mention.people}
+	if query == "" {
+		return nil
+	}
+	if people, ok := f.cache[strings.ToLower(query)]; ok {
+		f.mention.people = people
+		return nil
+	}
+	f.mention.loading = true
+	search := f.search
+	return func() tea.Msg {
+		people, err := search(query)
+		return mentionResultsMsg{query: query, people: people, err: err}
+	}
+}
+
+// handleMentionResults shows search results that still match what is typed.
+func (f *CommentForm) handleMentionResults(msg mentionResultsMsg) {
+	if msg.err == nil && f.cache != nil {
+		f.cache[strings.ToLower(msg.query)] = msg.people
+	}
+	if !f.mention.active || msg.query != f.mention.query {
+		return
+	}
+	f.mention.loading = false
+	f.mention.people = msg.people
+	f.mention.err = msg.err
+	f.mention.index = 0
+}
+
+// updateMentionPopup handles the popup's keys and reports whether key was
+// consumed. Other keys go on to the textarea.
+func (f *CommentForm) updateMentionPopup(key tea.KeyMsg) bool {
+	switch key.Type {
+	case tea.KeyEsc:
+		f.mention = mentionPopup{dismissed: true}
+		return true
+	case tea.KeyUp, tea.KeyDown:
+		if len(f.mention.people) == 0 {
+			return false
+		}
+		n := min(len(f.mention.people), mentionPopupRows)
+		if key.Type == tea.KeyUp {
+			f.mention.index = (f.mention.index + n - 1) % n
+		} else {
+			f.mention.index = (f.mention.index + 1) % n
+		}
+		return true
+	case tea.KeyTab, tea.KeyEnter:
+		if len(f.mention.people) == 0 {
+			return false
+		}
+		f.insertMention(f.mention.people[f.mention.index])
+		return true
+	}
+	return false
+}
+
+// insertMention replaces the "@query" before the cursor with "@Display Name"
+// and remembers the person so the name becomes mention markup on submit.
+func (f *CommentForm) insertMention(p provider.Person) {
+	for range len([]rune(f.mention.query)) + 1 {
+		f.textarea, _ = f.textarea.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	}
+	f.textarea.InsertString("@" + p.DisplayName + " ")
+	f.mentioned = append(f.mentioned, p)
+	f.mention = mentionPopup{}
+}
+
+// popupLines renders the mention popup, or nothing when it is closed.
+func (f CommentForm) popupLines() []string {
+	if !f.mention.active {
+		return nil
+	}
+	muted := lipgloss.NewStyle().Foreground(f.styles.Theme.GetForegroundMuted())
+	switch {
+	case f.mention.query == "":
+		return []string{muted.Render("  Type a name to mention someone")}
+	case f.mention.err != nil:
+		return []string{f.styles.Error.Render("  Could not search people: " + f.mention.err.Error())}
+	case len(f.mention.people) == 0 && f.mention.loading:
+		return []string{muted.Render("  Searching…")}
+	case len(f.mention.people) == 0:
+		return []string{muted.Render("  No one matches @" + f.mention.query)}
+	}
+
+	selected := lipgloss.NewStyle().
+		Foreground(f.styles.Theme.GetSelectForeground()).
+		Background(f.styles.Theme.GetSelectBackground())
+	var lines []string
+	for i, p := range f.mention.people[:min(len(f.mention.people), mentionPopupRows)] {
+		login := ""
+		if p.Login != "" && p.Login != p.DisplayName {
+			login = "  " + p.Login
+		}
+		if i == f.mention.index {
+			lines = append(lines, selected.Render("> @"+p.DisplayName+login))
+		} else {
+			lines = append(lines, "  @"+p.DisplayName+muted.Render(login))
+		}
+	}
+	return lines
 }
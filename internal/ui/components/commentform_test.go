@@ -4,6 +4,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/Elpulgo/azdo/internal/provider"
 	"github.com/Elpulgo/azdo/internal/ui/styles"
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -106,3 +107,103 @@ func TestCommentForm_ShowHideReset(t *testing.T) {
 		t.Error("Expected form hidden after Hide()")
 	}
 }
+
+// mentionForm returns a visible, focused form whose mention search offers
+// Jane and James Wilson for any query starting with "ja".
+func mentionForm(html bool) CommentForm {
+	f := NewCommentForm(styles.DefaultStyles())
+	f.EnableMentions(func(query string) ([]provider.Person, error) {
+		if !strings.HasPrefix(strings.ToLower(query), "ja") {
+			return nil, nil
+		}
+		return []provider.Person{
+			{ID: "id-1", DisplayName: "Jane Doe", Login: "jane@x.com", Mention: "@<id-1>", HTMLMention: `<a data-vss-mention="version:2.0,id-1">@Jane Doe</a>`},
+			{ID: "id-2", DisplayName: "James Wilson", Login: "james@x.com", Mention: "@<id-2>", HTMLMention: `<a data-vss-mention="version:2.0,id-2">@James Wilson</a>`},
+		}, nil
+	}, html)
+	f.Show()
+	f.Focus()
+	return f
+}
+
+// typeMention types s and delivers the results of the mention search it
+// starts, as the search command would.
+func typeMention(f CommentForm, s string) CommentForm {
+	f = typeRunes(f, s)
+	if f.mention.loading {
+		people, err := f.search(f.mention.query)
+		f, _ = f.Update(mentionResultsMsg{query: f.mention.query, people: people, err: err})
+	}
+	return f
+}
+
+func TestCommentForm_MentionPopupInsertsMarkupOnSubmit(t *testing.T) {
+	f := mentionForm(false)
+	f = typeMention(f, "cc ")
+	f = typeMention(f, "@ja")
+
+	if !strings.Contains(f.View(), "@James Wilson") {
+		t.Fatalf("popup should list matches, got:\n%s", f.View())
+	}
+	f, _ = f.Update(tea.KeyMsg{Type: tea.KeyDown})
+	f, _ = f.Update(tea.KeyMsg{Type: tea.KeyTab})
+
+	if got := f.Value(); got != "cc @James Wilson " {
+		t.Fatalf("Value() after picking = %q, want %q", got, "cc @James Wilson ")
+	}
+	if f.mention.active {
+		t.Error("popup should close after picking")
+	}
+
+	f = typeRunes(f, "please review")
+	_, cmd := f.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	submitted := cmd().(CommentSubmittedMsg)
+	if submitted.Text != "cc @<id-2> please review" {
+		t.Errorf("submitted.Text = %q, want the mention markup", submitted.Text)
+	}
+}
+
+func TestCommentForm_MentionUsesHTMLMarkup(t *testing.T) {
+	f := mentionForm(true)
+	f = typeMention(f, "@Ja")
+	f, _ = f.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	_, cmd := f.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	submitted := cmd().(CommentSubmittedMsg)
+	want := `<a data-vss-mention="version:2.0,id-1">@Jane Doe</a> `
+	if submitted.Text != want {
+		t.Errorf("submitted.Text = %q, want %q", submitted.Text, want)
+	}
+}
+
+func TestCommentForm_MentionIgnoresEmailAddresses(t *testing.T) {
+	f := mentionForm(false)
+	f = typeMention(f, "mail jane@ja")
+
+	if f.mention.active {
+		t.Error("an @ inside a word should not open the popup")
+	}
+}
+
+func TestCommentForm_EscClosesMentionPopupOnly(t *testing.T) {
+	f := mentionForm(false)
+	f = typeMention(f, "@ja")
+	height := f.Height()
+
+	f, cmd := f.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if cmd != nil {
+		t.Error("Esc with the popup open should not cancel the form")
+	}
+	if !f.IsVisible() || f.mention.active {
+		t.Errorf("visible = %v, popup active = %v; want form open, popup closed", f.IsVisible(), f.mention.active)
+	}
+	if f.Height() >= height {
+		t.Errorf("Height() = %d, want less than %d once the popup closes", f.Height(), height)
+	}
+
+	// Enter now inserts a newline instead of picking a person.
+	f, _ = f.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if f.Value() != "@ja\n" {
+		t.Errorf("Value() = %q, want a newline after the dismissed query", f.Value())
+	}
+}
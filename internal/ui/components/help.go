@@ -64,13 +64,15 @@ func NewHelpModal(s *styles.Styles) *HelpModal {
 					{Key: "f", Description: "Search / filter"},
 					{Key: "m", Description: "Toggle my items (PRs / work items)"},
 					{Key: "A", Description: "Toggle as reviewer (PRs)"},
-					{Key: "T", Description: "Filter by tag (work items)"},
-					{Key: "s", Description: "Filter by state (work items)"},
-					{Key: "S", Description: "Filter by status (pipelines)"},
+					{Key: "g", Description: "Toggle current repository (PRs)"},
+					{Key: "F/O", Description: "Filter panel / cycle sort order (PRs)"},
+					{Key: "T/s/n/H/Q/B", Description: "Tag / state filter, new item, tree, queries, board (work items)"},
+					{Key: "S", Description: "Filter by status (pipelines), sprint view (work items)"},
 					{Key: "r", Description: "Refresh data"},
 					{Key: "v", Description: "Vote on PR (detail view)"},
-					{Key: "w", Description: "Change work item state (detail view)"},
-					{Key: "c", Description: "Add comment (work item detail)"},
+					{Key: "w/E/L/A/H", Description: "Change state / edit fields / links / attachments / history (work item detail)"},
+					{Key: "c/C/d", Description: "Comment / comment or description in $EDITOR (work item detail)"},
+					{Key: "n/N", Description: "Select comment (work item detail)"},
 					{Key: "o", Description: "Open in browser (PR / work item / pipeline detail)"},
 					{Key: "t", Description: "Select theme"},
 					{Key: "?", Description: "Toggle help"},
@@ -81,8 +83,16 @@ func NewHelpModal(s *styles.Styles) *HelpModal {
 				Title: "Code Review (PR diff)",
 				Bindings: []HelpBinding{
 					{Key: "c", Description: "Create new comment"},
+					{Key: "v", Description: "Select a line range to comment on"},
+					{Key: "S", Description: "Suggest a change to the selected lines"},
+					{Key: "A", Description: "Apply nearest suggestion (commit)"},
+					{Key: "s", Description: "Start review / open pending review"},
 					{Key: "p", Description: "Reply to nearest thread"},
 					{Key: "x", Description: "Resolve nearest thread"},
+					{Key: "e", Description: "Edit comment under cursor"},
+					{Key: "D", Description: "Delete comment under cursor"},
+					{Key: "+", Description: "Like / unlike comment"},
+					{Key: "R", Description: "React to comment"},
 					{Key: "n", Description: "Jump to next comment"},
 					{Key: "N", Description: "Jump to previous comment"},
 				},
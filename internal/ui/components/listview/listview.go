@@ -200,9 +200,7 @@ func (m Model[T]) updateList(msg tea.Msg) (Model[T], tea.Cmd) {
 
 		switch msg.String() {
 		case "r":
-			m.loading = true
-			m.spinner.SetVisible(true)
-			return m, tea.Batch(m.config.Fetch(), m.spinner.Tick())
+			return m.Reload()
 		case "enter":
 			return m.enterDetailView()
 		case "f":
@@ -319,12 +317,7 @@ func (m Model[T]) enterDetailView() (Model[T], tea.Cmd) {
 		return m, nil
 	}
 
-	selectedItem := source[idx]
-	detail, cmd := m.config.EnterDetail(selectedItem, m.styles, m.width, m.height)
-	m.detail = detail
-	m.viewMode = ViewDetail
-
-	return m, cmd
+	return m.OpenDetail(source[idx])
 }
 
 // View renders the view.
@@ -369,6 +362,14 @@ func (m Model[T]) searchBarView() string {
 	return m.searchInput.View() + matchInfo
 }
 
+// Reload shows the loading spinner and fetches the items again, as the r
+// key does.
+func (m Model[T]) Reload() (Model[T], tea.Cmd) {
+	m.loading = true
+	m.spinner.SetVisible(true)
+	return m, tea.Batch(m.config.Fetch(), m.spinner.Tick())
+}
+
 // SetItems sets the items directly (e.g. from polling), clearing loading/error state.
 func (m Model[T]) SetItems(items []T) Model[T] {
 	m.loading = false
@@ -497,6 +498,16 @@ func (m Model[T]) OpenSelectedDetail() (Model[T], tea.Cmd) {
 	return m.enterDetailView()
 }
 
+// OpenDetail enters the detail view for item, which need not be listed,
+// returning the detail's Init command (may be nil).
+func (m Model[T]) OpenDetail(item T) (Model[T], tea.Cmd) {
+	detail, cmd := m.config.EnterDetail(item, m.styles, m.width, m.height)
+	m.detail = detail
+	m.viewMode = ViewDetail
+
+	return m, cmd
+}
+
 // effectiveColumnSpecs returns the column specs for the given items.
 // When config.ToColumns is non-nil it is called with the items; otherwise the
 // static config.Columns slice is returned unchanged.
@@ -0,0 +1,104 @@
+package components
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Elpulgo/azdo/internal/provider"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// mentionIDRe matches an Azure DevOps "@<GUID>" mention.
+var mentionIDRe = regexp.MustCompile(`@<([0-9A-Fa-f]{8}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{12})>`)
+
+// MentionsResolvedMsg carries the people behind the "@<GUID>" mentions
+// looked up by ResolveMentions.
+type MentionsResolvedMsg struct {
+	People []provider.Person
+}
+
+// Mentions maps identity IDs to display names so that Azure DevOps
+// "@<GUID>" mentions can be shown as "@Display Name".
+type Mentions map[string]string
+
+// Add records the display names of people.
+func (m Mentions) Add(people []provider.Person) {
+	for _, p := range people {
+		m[strings.ToLower(p.ID)] = p.DisplayName
+	}
+}
+
+// Missing returns the IDs mentioned in texts that have no display name yet,
+// without duplicates.
+func (m Mentions) Missing(texts ...string) []string {
+	var ids []string
+	seen := make(map[string]bool)
+	for _, text := range texts {
+		for _, match := range mentionIDRe.FindAllStringSubmatch(text, -1) {
+			id := strings.ToLower(match[1])
+			if _, known := m[id]; known || seen[id] {
+				continue
+			}
+			seen[id] = true
+			ids = append(ids, match[1])
+		}
+	}
+	return ids
+}
+
+// Replace rewrites the known "@<GUID>" mentions in text as "@Display Name".
+// Unknown mentions are left as they are.
+func (m Mentions) Replace(text string) string {
+	if len(m) == 0 || !strings.Contains(text, "@<") {
+		return text
+	}
+	return mentionIDRe.ReplaceAllStringFunc(text, func(s string) string {
+		if name, ok := m[strings.ToLower(s[2:len(s)-1])]; ok {
+			return "@" + name
+		}
+		return s
+	})
+}
+
+// ResolveMentions returns a command that looks up the people behind the
+// given mention IDs in scope and reports them in a MentionsResolvedMsg.
+// It returns nil when there is nothing to look up. A failed lookup is
+// reported as no people, leaving the mentions as they are.
+func ResolveMentions(client provider.Provider, scope string, ids []string) tea.Cmd {
+	if client == nil || len(ids) == 0 {
+		return nil
+	}
+	return func() tea.Msg {
+		people, err := client.ResolvePeople(scope, ids)
+		if err != nil {
+			return MentionsResolvedMsg{}
+		}
+		return MentionsResolvedMsg{People: people}
+	}
+}
+
+// expandMentions replaces the "@Display Name" of each person mentioned
+// through the autocomplete with their mention markup, in a single pass so
+// that markup is never rewritten. Longer names go first so that "@Jane Doe"
+// is not taken for a mention of "@Jane".
+func expandMentions(text string, people []provider.Person, html bool) string {
+	if len(people) == 0 {
+		return text
+	}
+	markup := make(map[string]string, len(people))
+	names := make([]string, 0, len(people))
+	for _, p := range people {
+		key := "@" + p.DisplayName
+		if _, dup := markup[key]; !dup {
+			names = append(names, regexp.QuoteMeta(key))
+		}
+		markup[key] = p.Mention
+		if html {
+			markup[key] = p.HTMLMention
+		}
+	}
+	sort.SliceStable(names, func(i, j int) bool { return len(names[i]) > len(names[j]) })
+	re := regexp.MustCompile(strings.Join(names, "|"))
+	return re.ReplaceAllStringFunc(text, func(s string) string { return markup[s] })
+}
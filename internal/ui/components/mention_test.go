@@ -0,0 +1,46 @@
+package components
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Elpulgo/azdo/internal/provider"
+)
+
+const (
+	janeID  = "a1b2c3d4-0002-4000-8000-000000000002"
+	jamesID = "A1B2C3D4-0003-4000-8000-000000000003"
+)
+
+func TestMentions_MissingAndReplace(t *testing.T) {
+	text := "@<" + janeID + "> and @<" + jamesID + "> again @<" + janeID + ">, mail a@b.c"
+	m := Mentions{}
+
+	if got, want := m.Missing(text), []string{janeID, jamesID}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Missing() = %v, want %v", got, want)
+	}
+
+	m.Add([]provider.Person{{ID: janeID, DisplayName: "Jane Doe"}})
+	if got, want := m.Missing(text), []string{jamesID}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Missing() after Add = %v, want %v", got, want)
+	}
+
+	want := "@Jane Doe and @<" + jamesID + "> again @Jane Doe, mail a@b.c"
+	if got := m.Replace(text); got != want {
+		t.Errorf("Replace() = %q, want %q", got, want)
+	}
+}
+
+func TestExpandMentions_LongerNamesFirst(t *testing.T) {
+	people := []provider.Person{
+		{DisplayName: "Jane", Mention: "@<1>", HTMLMention: "<a>@Jane</a>"},
+		{DisplayName: "Jane Doe", Mention: "@<2>", HTMLMention: "<a>@Jane Doe</a>"},
+	}
+
+	if got := expandMentions("@Jane Doe and @Jane", people, false); got != "@<2> and @<1>" {
+		t.Errorf("markdown = %q", got)
+	}
+	if got := expandMentions("@Jane Doe and @Jane", people, true); got != "<a>@Jane Doe</a> and <a>@Jane</a>" {
+		t.Errorf("html = %q", got)
+	}
+}
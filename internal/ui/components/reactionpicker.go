@@ -0,0 +1,229 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Elpulgo/azdo/internal/provider"
+	"github.com/Elpulgo/azdo/internal/ui/styles"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ReactionSelectedMsg is sent when a reaction is picked. Remove is set when
+// the user had already reacted with it, so picking it again takes it back.
+type ReactionSelectedMsg struct {
+	Reaction provider.Reaction
+	Remove   bool
+}
+
+// reactionOption is a single reaction choice
+type reactionOption struct {
+	Label    string
+	Reaction provider.Reaction
+}
+
+// reactionIcons maps each reaction to the glyph shown in comment footers and
+// in the picker.
+var reactionIcons = map[provider.Reaction]string{
+	provider.ReactionLike:     "👍",
+	provider.ReactionDislike:  "👎",
+	provider.ReactionLaugh:    "😄",
+	provider.ReactionHooray:   "🎉",
+	provider.ReactionConfused: "😕",
+	provider.ReactionHeart:    "❤",
+	provider.ReactionRocket:   "🚀",
+	provider.ReactionEyes:     "👀",
+}
+
+// ReactionIcon returns the glyph for r, or r itself when it has none.
+func ReactionIcon(r provider.Reaction) string {
+	if icon, ok := reactionIcons[r]; ok {
+		return icon
+	}
+	return string(r)
+}
+
+// FormatReactions renders reaction counts as "👍 2  🎉 1". The user's own
+// reactions are bracketed so a second press is recognisable as an undo.
+// Returns "" when there are none.
+func FormatReactions(rs []provider.ReactionCount) string {
+	parts := make([]string, 0, len(rs))
+	for _, r := range rs {
+		if r.Count <= 0 {
+			continue
+		}
+		part := fmt.Sprintf("%s %d", ReactionIcon(r.Reaction), r.Count)
+		if r.Mine {
+			part = "[" + part + "]"
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, "  ")
+}
+
+// HasReacted reports whether the user's own reactions in rs include r.
+func HasReacted(rs []provider.ReactionCount, r provider.Reaction) bool {
+	for _, rc := range rs {
+		if rc.Reaction == r && rc.Mine {
+			return true
+		}
+	}
+	return false
+}
+
+// reactionLabels names each reaction in the picker.
+var reactionLabels = map[provider.Reaction]string{
+	provider.ReactionLike:     "Like",
+	provider.ReactionDislike:  "Dislike",
+	provider.ReactionLaugh:    "Laugh",
+	provider.ReactionHooray:   "Hooray",
+	provider.ReactionConfused: "Confused",
+	provider.ReactionHeart:    "Heart",
+	provider.ReactionRocket:   "Rocket",
+	provider.ReactionEyes:     "Eyes",
+}
+
+// ReactionPicker is a modal component for reacting to a comment
+type ReactionPicker struct {
+	styles  *styles.Styles
+	visible bool
+	width   int
+	height  int
+	options []reactionOption
+	current []provider.ReactionCount
+	cursor  int
+}
+
+// NewReactionPicker creates a new reaction picker
+func NewReactionPicker(s *styles.Styles) ReactionPicker {
+	return ReactionPicker{styles: s}
+}
+
+// Show makes the picker visible for a comment with the given reactions,
+// offering the available ones (in order), so reactions the user already
+// gave are marked and picking them removes them.
+func (p *ReactionPicker) Show(current []provider.ReactionCount, available []provider.Reaction) {
+	p.options = make([]reactionOption, 0, len(available))
+	for _, r := range available {
+		label, ok := reactionLabels[r]
+		if !ok {
+			label = string(r)
+		}
+		p.options = append(p.options, reactionOption{Label: label, Reaction: r})
+	}
+	p.current = current
+	p.cursor = 0
+	p.visible = len(p.options) > 0
+}
+
+// Hide makes the picker invisible
+func (p *ReactionPicker) Hide() {
+	p.visible = false
+}
+
+// IsVisible returns whether the picker is visible
+func (p ReactionPicker) IsVisible() bool {
+	return p.visible
+}
+
+// SetSize sets the dimensions for centering
+func (p *ReactionPicker) SetSize(width, height int) {
+	p.width = width
+	p.height = height
+}
+
+// Update handles messages
+func (p ReactionPicker) Update(msg tea.Msg) (ReactionPicker, tea.Cmd) {
+	if !p.visible {
+		return p, nil
+	}
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return p, nil
+	}
+	switch {
+	case key.Matches(keyMsg, key.NewBinding(key.WithKeys("esc", "q"))):
+		p.visible = false
+	case key.Matches(keyMsg, key.NewBinding(key.WithKeys("up", "k"))):
+		if p.cursor > 0 {
+			p.cursor--
+		}
+	case key.Matches(keyMsg, key.NewBinding(key.WithKeys("down", "j"))):
+		if p.cursor < len(p.options)-1 {
+			p.cursor++
+		}
+	case key.Matches(keyMsg, key.NewBinding(key.WithKeys("enter"))):
+		selected := p.options[p.cursor].Reaction
+		remove := HasReacted(p.current, selected)
+		p.visible = false
+		return p, func() tea.Msg {
+			return ReactionSelectedMsg{Reaction: selected, Remove: remove}
+		}
+	}
+	return p, nil
+}
+
+// View renders the reaction picker
+func (p ReactionPicker) View() string {
+	if !p.visible {
+		return ""
+	}
+
+	titleText := "React to comment"
+	helpTextStr := "↑/↓: navigate • enter: toggle • esc/q: cancel"
+
+	maxWidth := max(minModalWidth, lipgloss.Width(helpTextStr))
+
+	var optionList string
+	for i, opt := range p.options {
+		cursor := " "
+		if i == p.cursor {
+			cursor = ">"
+		}
+		mark := " "
+		if HasReacted(p.current, opt.Reaction) {
+			mark = "✓"
+		}
+		line := fmt.Sprintf("%s %s %s %s", cursor, mark, ReactionIcon(opt.Reaction), opt.Label)
+
+		style := lipgloss.NewStyle().
+			Foreground(p.styles.Theme.GetForeground()).
+			Background(p.styles.Theme.GetBackground())
+		if i == p.cursor {
+			style = lipgloss.NewStyle().
+				Foreground(p.styles.Theme.GetSelectForeground()).
+				Background(p.styles.Theme.GetSelectBackground())
+		}
+		optionList += style.Width(maxWidth).Render(line) + "\n"
+	}
+
+	title := lipgloss.NewStyle().
+		Foreground(p.styles.Theme.GetPrimary()).
+		Background(p.styles.Theme.GetBackground()).
+		Bold(true).
+		Width(maxWidth).
+		Render(titleText)
+
+	helpText := lipgloss.NewStyle().
+		Foreground(p.styles.Theme.GetForegroundMuted()).
+		Background(p.styles.Theme.GetBackground()).
+		Width(maxWidth).
+		Render(helpTextStr)
+
+	content := lipgloss.JoinVertical(lipgloss.Left, title, "", optionList, helpText)
+
+	modal := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(p.styles.Theme.GetBorder()).
+		Padding(1, 2).
+		Background(p.styles.Theme.GetBackground()).
+		Render(content)
+
+	if p.width > 0 && p.height > 0 {
+		modal = lipgloss.Place(p.width, p.height, lipgloss.Center, lipgloss.Center, modal)
+	}
+	return modal
+}
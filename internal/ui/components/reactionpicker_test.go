@@ -0,0 +1,96 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Elpulgo/azdo/internal/provider"
+	"github.com/Elpulgo/azdo/internal/ui/styles"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func newTestReactionPicker() ReactionPicker {
+	return NewReactionPicker(styles.NewStyles(styles.GetDefaultTheme()))
+}
+
+func TestReactionPicker_EnterEmitsSelection(t *testing.T) {
+	picker := newTestReactionPicker()
+	picker.Show(nil, provider.PRCommentReactions(provider.KindGitHub))
+
+	picker, _ = picker.Update(tea.KeyMsg{Type: tea.KeyDown})
+	picker, cmd := picker.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if picker.IsVisible() {
+		t.Error("Expected picker to hide after selection")
+	}
+	if cmd == nil {
+		t.Fatal("Expected a command after enter")
+	}
+	msg, ok := cmd().(ReactionSelectedMsg)
+	if !ok {
+		t.Fatalf("Expected ReactionSelectedMsg, got %T", cmd())
+	}
+	if msg.Reaction != provider.ReactionDislike || msg.Remove {
+		t.Errorf("msg = %+v, want dislike without remove", msg)
+	}
+}
+
+func TestReactionPicker_OwnReactionIsRemoved(t *testing.T) {
+	picker := newTestReactionPicker()
+	picker.Show([]provider.ReactionCount{{Reaction: provider.ReactionLike, Count: 3, Mine: true}}, provider.PRCommentReactions(provider.KindGitHub))
+
+	_, cmd := picker.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	msg := cmd().(ReactionSelectedMsg)
+	if msg.Reaction != provider.ReactionLike || !msg.Remove {
+		t.Errorf("msg = %+v, want like with remove", msg)
+	}
+}
+
+func TestReactionPicker_EscCancels(t *testing.T) {
+	picker := newTestReactionPicker()
+	picker.Show(nil, provider.PRCommentReactions(provider.KindGitHub))
+
+	picker, cmd := picker.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if picker.IsVisible() || cmd != nil {
+		t.Error("Expected esc to hide the picker without a selection")
+	}
+}
+
+func TestReactionPicker_ViewMarksOwnReactions(t *testing.T) {
+	picker := newTestReactionPicker()
+	picker.Show([]provider.ReactionCount{{Reaction: provider.ReactionHeart, Count: 1, Mine: true}}, provider.PRCommentReactions(provider.KindGitHub))
+
+	view := picker.View()
+	if !strings.Contains(view, "✓ ❤ Heart") {
+		t.Errorf("Expected the own reaction to be checked, got:\n%s", view)
+	}
+}
+
+func TestReactionPicker_OffersOnlyAvailableReactions(t *testing.T) {
+	picker := newTestReactionPicker()
+	picker.Show(nil, provider.PRCommentReactions(provider.KindAzure))
+
+	view := picker.View()
+	if !strings.Contains(view, "Like") || strings.Contains(view, "Heart") {
+		t.Errorf("Expected only Like for an Azure DevOps PR comment, got:\n%s", view)
+	}
+	picker, _ = picker.Update(tea.KeyMsg{Type: tea.KeyDown})
+	_, cmd := picker.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if msg := cmd().(ReactionSelectedMsg); msg.Reaction != provider.ReactionLike {
+		t.Errorf("msg = %+v, want like", msg)
+	}
+}
+
+func TestFormatReactions(t *testing.T) {
+	got := FormatReactions([]provider.ReactionCount{
+		{Reaction: provider.ReactionLike, Count: 2, Mine: true},
+		{Reaction: provider.ReactionRocket, Count: 0},
+		{Reaction: provider.ReactionEyes, Count: 1},
+	})
+	if want := "[👍 2]  👀 1"; got != want {
+		t.Errorf("FormatReactions() = %q, want %q", got, want)
+	}
+	if got := FormatReactions(nil); got != "" {
+		t.Errorf("FormatReactions(nil) = %q, want empty", got)
+	}
+}
@@ -0,0 +1,453 @@
+package markdown
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ToMarkdown converts an HTML fragment, such as an Azure DevOps description,
+// to markdown for editing as plain text. Lines written as divs stay lines
+// of one paragraph, as Render keeps line breaks. Markup markdown cannot
+// express, such as colors and fonts, is dropped with its text kept.
+func ToMarkdown(src string) string {
+	lines, _ := markdownBlocks(parseHTML(src).children, false)
+	for len(lines) > 0 && lines[0] == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// markdownBlocks converts nodes to markdown lines, spacing blocks as
+// htmlBlocks does.
+func markdownBlocks(nodes []*htmlNode, tight bool) (out []string, spaced bool) {
+	lastSpaced := false
+	emit := func(block []string, blockSpaced bool) {
+		if len(block) == 0 {
+			return
+		}
+		if len(out) > 0 && out[len(out)-1] != "" && !tight && (blockSpaced || lastSpaced) {
+			out = append(out, "")
+		}
+		out = append(out, block...)
+		lastSpaced = blockSpaced
+		spaced = spaced || blockSpaced
+	}
+
+	var para []span
+	flush := func() {
+		if spans := trimSpans(para); len(spans) > 0 {
+			lines := strings.Split(spansToMarkdown(spans), "\n")
+			for k, l := range lines {
+				lines[k] = escapeLineStart(strings.TrimLeft(l, " "))
+			}
+			emit(lines, false)
+		}
+		para = nil
+	}
+
+	for _, n := range nodes {
+		if !blockTags[n.tag] {
+			collectInline(n, span{}, &para)
+			continue
+		}
+		flush()
+		switch n.tag {
+		case "h1", "h2", "h3", "h4", "h5", "h6":
+			var spans []span
+			for _, c := range n.children {
+				collectInline(c, span{}, &spans)
+			}
+			text := strings.ReplaceAll(spansToMarkdown(trimSpans(spans)), "\n", " ")
+			emit([]string{strings.Repeat("#", int(n.tag[1]-'0')) + " " + text}, true)
+		case "ul", "ol":
+			emit(markdownList(n), true)
+		case "table":
+			emit(markdownTable(n), true)
+		case "pre":
+			code, lang := preCode(n)
+			if code == nil {
+				continue
+			}
+			fence := "```"
+			if strings.Contains(strings.Join(code, "\n"), fence) {
+				fence = "~~~"
+			}
+			emit(append(append([]string{fence + lang}, code...), fence), true)
+		case "blockquote":
+			quoted, _ := markdownBlocks(n.children, false)
+			for k, l := range quoted {
+				quoted[k] = strings.TrimRight("> "+l, " ")
+			}
+			emit(quoted, true)
+		case "hr":
+			emit([]string{"---"}, true)
+		case "p":
+			block, _ := markdownBlocks(n.children, tight)
+			emit(block, true)
+		default:
+			emit(markdownBlocks(n.children, tight))
+		}
+	}
+	flush()
+	return out, spaced
+}
+
+// markdownList converts a ul or ol to a list, its items' continuation
+// lines indented under their text. Check boxes become task list items.
+func markdownList(n *htmlNode) []string {
+	var out []string
+	start := listStart(n)
+	for k, item := range listItems(n) {
+		marker := "-"
+		if n.tag == "ol" {
+			marker = fmt.Sprintf("%d.", start+k)
+		}
+		body, _ := markdownBlocks(item, true)
+		if len(body) == 0 {
+			body = []string{""}
+		}
+		if rest, ok := strings.CutPrefix(body[0], "☐ "); ok {
+			body[0] = "[ ] " + rest
+		} else if rest, ok := strings.CutPrefix(body[0], "☑ "); ok {
+			body[0] = "[x] " + rest
+		}
+		out = append(out, strings.TrimRight(marker+" "+body[0], " "))
+		pad := strings.Repeat(" ", len(marker)+1)
+		for _, l := range body[1:] {
+			if l == "" {
+				out = append(out, "")
+			} else {
+				out = append(out, pad+l)
+			}
+		}
+	}
+	return out
+}
+
+// markdownTable converts a table to a pipe table. Pipe tables always have
+// a header, so a table without one gets its first row as the header.
+func markdownTable(n *htmlNode) []string {
+	grid, cols, _ := tableGrid(n)
+	if cols == 0 {
+		return nil
+	}
+	row := func(cells []string) string {
+		return "| " + strings.Join(cells, " | ") + " |"
+	}
+
+	var out []string
+	for ri, cells := range grid {
+		texts := make([]string, cols)
+		for c, cell := range cells {
+			texts[c] = strings.ReplaceAll(spansToMarkdown(cellSpans(cell)), "|", `\|`)
+		}
+		out = append(out, row(texts))
+		if ri > 0 {
+			continue
+		}
+		delims := make([]string, cols)
+		for c := range delims {
+			delims[c] = "---"
+			if c < len(cells) {
+				switch cellAlign(cells[c]) {
+				case lipgloss.Right:
+					delims[c] = "--:"
+				case lipgloss.Center:
+					delims[c] = ":-:"
+				}
+			}
+		}
+		out = append(out, row(delims))
+	}
+	return out
+}
+
+// spansToMarkdown writes spans as inline markdown. Emphasis markers open and
+// close as the formatting changes between spans, and spaces at the edges of
+// a formatted run are moved outside its markers, where markdown wants them.
+func spansToMarkdown(spans []span) string {
+	var sb strings.Builder
+	var open []string // markers currently open, outermost first
+	pending := ""     // spaces held back until the markers around them are known
+	closeTo := func(k int) {
+		for j := len(open) - 1; j >= k; j-- {
+			if url, ok := strings.CutPrefix(open[j], "link:"); ok {
+				sb.WriteString("](" + markdownURL(url) + ")")
+			} else {
+				sb.WriteString(open[j])
+			}
+		}
+		open = open[:k]
+	}
+
+	for _, sp := range spans {
+		if !sp.image && !sp.code && strings.TrimSpace(sp.text) == "" {
+			pending += sp.text
+			continue
+		}
+		var want []string
+		if sp.url != "" && !sp.image {
+			want = append(want, "link:"+sp.url)
+		}
+		if sp.bold {
+			want = append(want, "**")
+		}
+		if sp.italic {
+			want = append(want, "*")
+		}
+		if sp.strike {
+			want = append(want, "~~")
+		}
+		k := 0
+		for k < len(open) && k < len(want) && open[k] == want[k] {
+			k++
+		}
+		closeTo(k)
+
+		text := sp.text
+		lead := text[:len(text)-len(strings.TrimLeft(text, " \n"))]
+		text = text[len(lead):]
+		trail := text[len(strings.TrimRight(text, " \n")):]
+		text = text[:len(text)-len(trail)]
+		if sp.code || sp.image {
+			text, lead, trail = sp.text, "", ""
+		}
+		sb.WriteString(pending + lead)
+		pending = trail
+		for _, m := range want[k:] {
+			if strings.HasPrefix(m, "link:") {
+				sb.WriteString("[")
+			} else {
+				sb.WriteString(m)
+			}
+			open = append(open, m)
+		}
+
+		switch {
+		case sp.image:
+			sb.WriteString("![" + escapeMarkdown(text) + "](" + markdownURL(sp.url) + ")")
+		case sp.code:
+			sb.WriteString(codeSpan(text))
+		default:
+			sb.WriteString(escapeMarkdown(text))
+		}
+	}
+	closeTo(0)
+	sb.WriteString(strings.TrimRight(pending, " "))
+	return sb.String()
+}
+
+// codeSpan wraps code in enough backticks to hold the backticks in it.
+func codeSpan(code string) string {
+	n := 1
+	for i := 0; i < len(code); i++ {
+		if code[i] == '`' {
+			n = max(n, runLength(code, i)+1)
+		}
+	}
+	fence := strings.Repeat("`", n)
+	if n > 1 {
+		return fence + " " + code + " " + fence
+	}
+	return fence + code + fence
+}
+
+// escapeMarkdown escapes the characters of text that would otherwise start
+// inline markup. Underscores inside words are left alone, as they never
+// emphasize.
+func escapeMarkdown(text string) string {
+	var sb strings.Builder
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		escape := false
+		switch c {
+		case '\\', '*', '`', '[', ']':
+			escape = true
+		case '_':
+			escape = i == 0 || i == len(text)-1 || !isWordByte(text[i-1]) || !isWordByte(text[i+1])
+		case '~':
+			escape = i+1 < len(text) && text[i+1] == '~' || i > 0 && text[i-1] == '~'
+		case '<':
+			escape = i+1 < len(text) && (isWordByte(text[i+1]) || text[i+1] == '/' || text[i+1] == '!')
+		}
+		if escape {
+			sb.WriteByte('\\')
+		}
+		sb.WriteByte(c)
+	}
+	return sb.String()
+}
+
+// escapeLineStart escapes a line of text that would otherwise open a
+// block, such as a heading, list item or quote.
+func escapeLineStart(line string) string {
+	switch {
+	case line == "":
+		return line
+	case listMarkerRe.MatchString(line) && listKind(listMarkerRe.FindStringSubmatch(line)[1]) != "":
+		// "1. " stays text as "1\. ".
+		end := strings.IndexAny(line, ".)")
+		return line[:end] + `\` + line[end:]
+	case startsBlock(line) || listMarkerRe.MatchString(line) || setextLevel(line) > 0 ||
+		strings.HasPrefix(line, "|") || strings.HasPrefix(line, "#"):
+		return `\` + line
+	}
+	return line
+}
+
+// markdownURL escapes the characters that would end a link destination.
+func markdownURL(url string) string {
+	return strings.NewReplacer(" ", "%20", "(", "%28", ")", "%29").Replace(url)
+}
+
+// ToHTML converts markdown to the HTML Azure DevOps stores in rich text
+// fields. It understands the markdown Render does; line breaks inside a
+// paragraph are kept as <br>.
+func ToHTML(src string) string {
+	lines := sourceLines(src)
+	if lines == nil {
+		return ""
+	}
+	return blocksToHTML(lines, false)
+}
+
+// blocksToHTML converts markdown lines to HTML. tight leaves paragraphs
+// unwrapped, as inside the items of a tight list.
+func blocksToHTML(lines []string, tight bool) string {
+	var sb strings.Builder
+	for _, b := range scanBlocks(lines) {
+		switch b.kind {
+		case blockHeading:
+			fmt.Fprintf(&sb, "<h%d>%s</h%d>", b.level, inlineToHTML(b.text), b.level)
+		case blockCode:
+			class := ""
+			if b.lang != "" {
+				class = ` class="language-` + html.EscapeString(b.lang) + `"`
+			}
+			fmt.Fprintf(&sb, "<pre><code%s>%s</code></pre>", class, html.EscapeString(strings.Join(b.lines, "\n")))
+		case blockRule:
+			sb.WriteString("<hr>")
+		case blockQuote:
+			sb.WriteString("<blockquote>" + blocksToHTML(b.lines, false) + "</blockquote>")
+		case blockList:
+			sb.WriteString(listToHTML(b.items, b.loose))
+		case blockTable:
+			sb.WriteString(tableToHTML(b.rows, b.aligns))
+		default:
+			text := inlineToHTML(joinParagraph(b.lines))
+			if !tight {
+				text = "<p>" + text + "</p>"
+			} else if sb.Len() > 0 {
+				text = "<br>" + text
+			}
+			sb.WriteString(text)
+		}
+	}
+	return sb.String()
+}
+
+// listToHTML converts list items to a ul or ol. Task list boxes become
+// check box characters, as Azure DevOps strips form controls.
+func listToHTML(items []listItem, loose bool) string {
+	tag := "ul"
+	var sb strings.Builder
+	if first := items[0].marker; listKind(first) != "" {
+		tag = "ol"
+		if start := first[:len(first)-1]; strings.TrimLeft(start, "0") != "1" {
+			sb.WriteString(`<ol start="` + strings.TrimLeft(start, "0") + `">`)
+		}
+	}
+	if sb.Len() == 0 {
+		sb.WriteString("<" + tag + ">")
+	}
+	for _, item := range items {
+		content := item.lines
+		prefix := ""
+		if box, rest, ok := taskBox(content[0]); ok {
+			prefix = box + " "
+			content = append([]string{rest}, content[1:]...)
+		}
+		sb.WriteString("<li>" + prefix + blocksToHTML(content, !loose) + "</li>")
+	}
+	sb.WriteString("</" + tag + ">")
+	return sb.String()
+}
+
+// tableToHTML converts the rows of a pipe table; the first is its header.
+func tableToHTML(rows [][]string, aligns []lipgloss.Position) string {
+	var sb strings.Builder
+	sb.WriteString("<table>")
+	for ri, row := range rows {
+		if ri == 0 {
+			sb.WriteString("<thead>")
+		} else if ri == 1 {
+			sb.WriteString("<tbody>")
+		}
+		cell := "td"
+		if ri == 0 {
+			cell = "th"
+		}
+		sb.WriteString("<tr>")
+		for c, pos := range aligns {
+			text := ""
+			if c < len(row) {
+				text = inlineToHTML(row[c])
+			}
+			style := ""
+			switch pos {
+			case lipgloss.Right:
+				style = ` style="text-align: right"`
+			case lipgloss.Center:
+				style = ` style="text-align: center"`
+			}
+			sb.WriteString("<" + cell + style + ">" + text + "</" + cell + ">")
+		}
+		sb.WriteString("</tr>")
+		if ri == 0 {
+			sb.WriteString("</thead>")
+		}
+	}
+	if len(rows) > 1 {
+		sb.WriteString("</tbody>")
+	}
+	sb.WriteString("</table>")
+	return sb.String()
+}
+
+// inlineToHTML converts a paragraph's inline markdown to HTML.
+func inlineToHTML(s string) string {
+	var spans []span
+	parseInline(s, span{}, &spans)
+
+	var sb strings.Builder
+	for _, sp := range spans {
+		if sp.image {
+			fmt.Fprintf(&sb, `<img src="%s" alt="%s">`, html.EscapeString(sp.url), html.EscapeString(sp.text))
+			continue
+		}
+		text := strings.ReplaceAll(html.EscapeString(sp.text), "\n", "<br>")
+		if sp.code {
+			text = "<code>" + text + "</code>"
+		}
+		if sp.strike {
+			text = "<del>" + text + "</del>"
+		}
+		if sp.italic {
+			text = "<em>" + text + "</em>"
+		}
+		if sp.bold {
+			text = "<strong>" + text + "</strong>"
+		}
+		if sp.url != "" {
+			text = `<a href="` + html.EscapeString(sp.url) + `">` + text + "</a>"
+		}
+		sb.WriteString(text)
+	}
+	return sb.String()
+}
@@ -0,0 +1,86 @@
+package markdown
+
+import "testing"
+
+func TestToMarkdown(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{"divs are lines", "<div>one</div><div>two</div>", "one\ntwo"},
+		{"empty div is a blank line", "<div>one</div><div><br></div><div>two</div>", "one\n\ntwo"},
+		{"paragraphs", "<p>one</p><p>two</p>", "one\n\ntwo"},
+		{"heading", "<h2>Summary</h2><div>Body</div>", "## Summary\n\nBody"},
+		{"inline", `<b>bold</b> <i>it</i> <s>gone</s> <code>x()</code>`, "**bold** *it* ~~gone~~ `x()`"},
+		{"nested emphasis", "<b>a <i>b</i></b> c", "**a *b*** c"},
+		{"spaces move outside markers", "a<b> bold </b>b", "a **bold** b"},
+		{"link", `see <a href="https://x.io/a b">the <b>docs</b></a>`, "see [the **docs**](https://x.io/a%20b)"},
+		{"image", `<img src="https://x.io/d.png" alt="diagram">`, "![diagram](https://x.io/d.png)"},
+		{"code with backtick", "<code>a`b</code>", "`` a`b ``"},
+		{"escapes", "<div>2 * 3 [x] snake_case _a_ &lt;b&gt;</div>", `2 \* 3 \[x\] snake_case \_a\_ \<b>`},
+		{"line starts", "<div># no</div><div>- no</div><div>1. no</div><div>&gt; no</div>", `\# no` + "\n" + `\- no` + "\n" + `1\. no` + "\n" + `\> no`},
+		{"bullets", "<ul><li>one</li><li>two<ul><li>nested</li></ul></li></ul>", "- one\n- two\n  - nested"},
+		{"ordered", `<ol start="9"><li>a</li><li>b</li></ol>`, "9. a\n10. b"},
+		{"check boxes", "<ul><li><input type=checkbox checked> done</li><li><input type=checkbox> todo</li></ul>", "- [x] done\n- [ ] todo"},
+		{"text before list", "Steps:<ol><li>a</li></ol>", "Steps:\n\n1. a"},
+		{"table", `<table><tr><th>Name</th><th align="right">N</th></tr><tr><td>a|b</td><td>1</td></tr></table>`,
+			"| Name | N |\n| --- | --: |\n| a\\|b | 1 |"},
+		{"pre", "<pre><code class=\"language-go\">x := 1\n</code></pre>", "```go\nx := 1\n```"},
+		{"quote", "<blockquote><p>a</p><p>b</p></blockquote>", "> a\n>\n> b"},
+		{"rule", "<p>a</p><hr><p>b</p>", "a\n\n---\n\nb"},
+		{"empty", "<div><br></div>", ""},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := ToMarkdown(tc.src); got != tc.want {
+				t.Errorf("ToMarkdown(%q) =\n%q\nwant\n%q", tc.src, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestToHTML(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{"paragraphs", "one\ntwo\n\nthree", "<p>one<br>two</p><p>three</p>"},
+		{"heading", "## Summary", "<h2>Summary</h2>"},
+		{"inline", "**bold** *it* ~~gone~~ `a<b`", "<p><strong>bold</strong> <em>it</em> <del>gone</del> <code>a&lt;b</code></p>"},
+		{"link and image", "[docs](https://x.io/?a=1&b=2) ![d](d.png)", `<p><a href="https://x.io/?a=1&amp;b=2">docs</a> <img src="d.png" alt="d"></p>`},
+		{"escapes", `1\. \*not\* <b>`, "<p>1. *not* &lt;b&gt;</p>"},
+		{"bullets", "- one\n- two", "<ul><li>one</li><li>two</li></ul>"},
+		{"loose list", "- one\n\n- two", "<ul><li><p>one</p></li><li><p>two</p></li></ul>"},
+		{"ordered start", "3. a\n4. b", `<ol start="3"><li>a</li><li>b</li></ol>`},
+		{"tasks", "- [x] done\n- [ ] todo", "<ul><li>☑ done</li><li>☐ todo</li></ul>"},
+		{"table", "| a | b |\n| - | -: |\n| 1 | 2 |",
+			`<table><thead><tr><th>a</th><th style="text-align: right">b</th></tr></thead><tbody><tr><td>1</td><td style="text-align: right">2</td></tr></tbody></table>`},
+		{"code", "```go\nif a < b {}\n```", `<pre><code class="language-go">if a &lt; b {}</code></pre>`},
+		{"quote", "> quoted", "<blockquote><p>quoted</p></blockquote>"},
+		{"rule", "a\n\n---\n\nb", "<p>a</p><hr><p>b</p>"},
+		{"blank", " \n\n", ""},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := ToHTML(tc.src); got != tc.want {
+				t.Errorf("ToHTML(%q) =\n%q\nwant\n%q", tc.src, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestConvert_RoundTrip(t *testing.T) {
+	docs := []string{
+		"## Repro\n\nOpen the **settings** page, then *wait*.\nIt fails with `ERR_42` — see [the logs](https://x.io/logs?id=1).",
+		"1. first\n2. second\n   - nested \\[x\\] and snake_case\n\n> quoted\n\n---\n\n- [ ] todo",
+		"| Name | N |\n| --- | --: |\n| a | 1 |\n\n```sh\necho `date` *\n```",
+		`Literal \*stars\* and \[brackets\] stay text.` + "\n" + `\# and` + "\n" + `1\. too`,
+	}
+	for _, md := range docs {
+		if got := ToMarkdown(ToHTML(md)); got != md {
+			t.Errorf("round trip of\n%s\ngave\n%s", md, got)
+		}
+	}
+}
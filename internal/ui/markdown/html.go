@@ -0,0 +1,597 @@
+package markdown
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// htmlNode is an element of a parsed HTML fragment, or a run of decoded
+// text when tag is "".
+type htmlNode struct {
+	tag      string
+	attrs    map[string]string
+	text     string
+	parent   *htmlNode
+	children []*htmlNode
+}
+
+var (
+	htmlTagRe   = regexp.MustCompile(`^<(/?)([a-zA-Z][a-zA-Z0-9]*)((?:\s+[^\s/>="']+(?:\s*=\s*(?:"[^"]*"|'[^']*'|[^\s>]+))?)*)\s*(/?)>`)
+	htmlAttrRe  = regexp.MustCompile(`([^\s/>="']+)(?:\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s>]+)))?`)
+	htmlSpaceRe = regexp.MustCompile(`[ \t\r\n\f]+`)
+	codeLangRe  = regexp.MustCompile(`(?:^|\s)(?:language|lang)-(\S+)`)
+
+	// lineEndSpaceRe matches the spaces around a line break.
+	lineEndSpaceRe = regexp.MustCompile(` *\n *`)
+)
+
+// voidTags never have content or a closing tag.
+var voidTags = map[string]bool{
+	"area": true, "br": true, "col": true, "hr": true, "img": true,
+	"input": true, "link": true, "meta": true, "source": true, "wbr": true,
+}
+
+// blockTags start a block of their own; all other tags are inline.
+var blockTags = map[string]bool{
+	"address": true, "article": true, "aside": true, "blockquote": true,
+	"body": true, "center": true, "dd": true, "details": true, "div": true,
+	"dl": true, "dt": true, "figcaption": true, "figure": true, "footer": true,
+	"form": true, "h1": true, "h2": true, "h3": true, "h4": true, "h5": true,
+	"h6": true, "header": true, "hr": true, "html": true, "li": true,
+	"main": true, "nav": true, "ol": true, "p": true, "pre": true,
+	"section": true, "summary": true, "table": true, "tbody": true,
+	"td": true, "tfoot": true, "th": true, "thead": true, "tr": true, "ul": true,
+}
+
+// skippedTags are dropped with their content.
+var skippedTags = map[string]bool{"head": true, "script": true, "style": true, "title": true}
+
+// RenderHTML renders an HTML fragment, such as an Azure DevOps description,
+// wrapped to width columns like Render. It understands the markup rich text
+// editors produce (paragraphs, headings, lists, tables, preformatted code,
+// emphasis, links and images) and drops the rest, keeping its text. Images
+// become a placeholder followed by their URL.
+func (r *Renderer) RenderHTML(src string, width int) string {
+	lines, _ := r.htmlBlocks(parseHTML(src).children, width, 0, false)
+	for len(lines) > 0 && lines[0] == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// parseHTML parses src into a tree, forgiving the sloppy markup found in
+// the wild: unknown closing tags are ignored, unclosed elements end with
+// their parent and a new item, row or cell closes the previous one.
+func parseHTML(src string) *htmlNode {
+	root := &htmlNode{tag: "#root"}
+	cur := root
+	text := func(s string) {
+		if s != "" {
+			cur.children = append(cur.children, &htmlNode{text: html.UnescapeString(s), parent: cur})
+		}
+	}
+
+	for i := 0; i < len(src); {
+		rest := src[i:]
+		switch {
+		case strings.HasPrefix(rest, "<!--"):
+			end := strings.Index(rest[4:], "-->")
+			if end < 0 {
+				return root
+			}
+			i += 4 + end + 3
+			continue
+		case strings.HasPrefix(rest, "<!") || strings.HasPrefix(rest, "<?"):
+			end := strings.IndexByte(rest, '>')
+			if end < 0 {
+				return root
+			}
+			i += end + 1
+			continue
+		}
+
+		m := htmlTagRe.FindStringSubmatch(rest)
+		if m == nil {
+			next := strings.IndexByte(rest[1:], '<')
+			if next < 0 {
+				text(rest)
+				break
+			}
+			text(rest[:next+1])
+			i += next + 1
+			continue
+		}
+		i += len(m[0])
+
+		tag := strings.ToLower(m[2])
+		if m[1] == "/" {
+			for n := cur; n != root; n = n.parent {
+				if n.tag == tag {
+					cur = n.parent
+					break
+				}
+			}
+			continue
+		}
+		if skippedTags[tag] && m[4] == "" {
+			// Skip to the closing tag so script and style bodies never show.
+			end := strings.Index(strings.ToLower(src[i:]), "</"+tag)
+			if end < 0 {
+				return root
+			}
+			i += end
+			continue
+		}
+
+		cur = closeImplied(cur, tag)
+		n := &htmlNode{tag: tag, attrs: parseAttrs(m[3]), parent: cur}
+		cur.children = append(cur.children, n)
+		if !voidTags[tag] && m[4] == "" {
+			cur = n
+		}
+	}
+	return root
+}
+
+// closeImplied returns the element a new tag opens in, after closing the
+// elements the tag implicitly ends: an open paragraph before a block, and
+// the previous item, row or cell before a new one.
+func closeImplied(cur *htmlNode, tag string) *htmlNode {
+	var closes, stops []string
+	switch tag {
+	case "li":
+		closes, stops = []string{"li"}, []string{"ul", "ol"}
+	case "tr":
+		closes, stops = []string{"tr", "td", "th"}, []string{"table", "thead", "tbody", "tfoot"}
+	case "td", "th":
+		closes, stops = []string{"td", "th"}, []string{"tr", "table"}
+	default:
+		if blockTags[tag] && cur.tag == "p" {
+			return cur.parent
+		}
+		return cur
+	}
+	for n := cur; n.parent != nil; n = n.parent {
+		switch {
+		case slices.Contains(stops, n.tag):
+			return cur
+		case slices.Contains(closes, n.tag):
+			cur = n.parent
+		}
+	}
+	return cur
+}
+
+// parseAttrs reads the attributes of a start tag, keyed by lower-case name.
+func parseAttrs(s string) map[string]string {
+	attrs := map[string]string{}
+	for _, m := range htmlAttrRe.FindAllStringSubmatch(s, -1) {
+		attrs[strings.ToLower(m[1])] = html.UnescapeString(m[2] + m[3] + m[4])
+	}
+	return attrs
+}
+
+// htmlBlocks renders nodes as output lines. Runs of inline nodes form
+// paragraphs; blocks such as paragraphs, lists and tables are separated by
+// a blank line, while divs, which editors use for single lines, are not.
+// tight drops the blank lines, as inside list items. spaced reports whether
+// any block asked for blank lines around it.
+func (r *Renderer) htmlBlocks(nodes []*htmlNode, width, depth int, tight bool) (out []string, spaced bool) {
+	lastSpaced := false
+	emit := func(block []string, blockSpaced bool) {
+		if len(block) == 0 {
+			return
+		}
+		if len(out) > 0 && !tight && (blockSpaced || lastSpaced) {
+			out = append(out, "")
+		}
+		out = append(out, block...)
+		lastSpaced = blockSpaced
+		spaced = spaced || blockSpaced
+	}
+
+	var para []span
+	flush := func() {
+		emit(r.htmlParagraph(para, r.base, width), false)
+		para = nil
+	}
+
+	for _, n := range nodes {
+		if !blockTags[n.tag] {
+			collectInline(n, span{}, &para)
+			continue
+		}
+		flush()
+		switch n.tag {
+		case "h1", "h2", "h3", "h4", "h5", "h6":
+			level := int(n.tag[1] - '0')
+			var spans []span
+			for _, c := range n.children {
+				collectInline(c, span{}, &spans)
+			}
+			emit(r.htmlParagraph(spans, r.headings[min(level, len(r.headings))-1], width), true)
+		case "ul", "ol":
+			emit(r.htmlList(n, width, depth), true)
+		case "table":
+			emit(r.htmlTable(n, width), true)
+		case "pre":
+			emit(r.htmlPre(n, width), true)
+		case "blockquote":
+			quoted, _ := r.WithBase(r.quote).htmlBlocks(n.children, width-2, depth, tight)
+			emit(r.quoteBar(quoted), true)
+		case "hr":
+			rule := 40
+			if width > 0 {
+				rule = min(width, rule)
+			}
+			emit([]string{r.border.Render(strings.Repeat("─", rule))}, true)
+		case "p":
+			block, _ := r.htmlBlocks(n.children, width, depth, tight)
+			emit(block, true)
+		default:
+			emit(r.htmlBlocks(n.children, width, depth, tight))
+		}
+	}
+	flush()
+	return out, spaced
+}
+
+// collectInline appends the text of n and its descendants as spans,
+// inheriting the formatting of st. Whitespace is collapsed as browsers do.
+func collectInline(n *htmlNode, st span, out *[]span) {
+	switch n.tag {
+	case "":
+		text := htmlSpaceRe.ReplaceAllString(n.text, " ")
+		st.text = strings.ReplaceAll(text, "\u00a0", " ")
+		*out = append(*out, st)
+		return
+	case "br":
+		st.text = "\n"
+		*out = append(*out, st)
+		return
+	case "img":
+		st.url = n.attrs["src"]
+		st.text = imageName(n.attrs["alt"], st.url)
+		st.image = true
+		*out = append(*out, st)
+		return
+	case "input":
+		if n.attrs["type"] == "checkbox" {
+			st.text = "☐ "
+			if _, checked := n.attrs["checked"]; checked {
+				st.text = "☑ "
+			}
+			*out = append(*out, st)
+		}
+		return
+	case "b", "strong":
+		st.bold = true
+	case "i", "em", "cite", "var":
+		st.italic = true
+	case "s", "strike", "del":
+		st.strike = true
+	case "code", "kbd", "samp", "tt":
+		st.code = true
+	case "a":
+		if href := n.attrs["href"]; href != "" && !strings.HasPrefix(href, "#") {
+			st.url = href
+			if strings.TrimSpace(textContent(n)) == "" && !hasImage(n) {
+				st.text = href
+				*out = append(*out, st)
+				return
+			}
+		}
+	}
+
+	// A block inside inline markup, such as a div in a table cell, still
+	// starts on a line of its own.
+	block := blockTags[n.tag]
+	if block {
+		*out = append(*out, span{text: "\n"})
+	}
+	for _, c := range n.children {
+		collectInline(c, st, out)
+	}
+	if block {
+		*out = append(*out, span{text: "\n"})
+	}
+}
+
+// htmlParagraph renders collected spans in base, wrapped to width. Spaces
+// around line breaks and at the ends are dropped and a line break ending
+// the paragraph is ignored, so "<div><br></div>" renders as one blank line.
+func (r *Renderer) htmlParagraph(spans []span, base lipgloss.Style, width int) []string {
+	spans = trimSpans(spans)
+	if len(spans) == 0 {
+		return nil
+	}
+	return wrap(r.renderSpans(spans, base), width)
+}
+
+// trimSpans drops the spaces that would start or end a line and one line
+// break ending the spans. Spans holding nothing but that line break become
+// a single empty span, an empty line.
+func trimSpans(spans []span) []span {
+	var out []span
+	broken := false
+	for _, sp := range spans {
+		broken = broken || strings.Contains(sp.text, "\n")
+		text := lineEndSpaceRe.ReplaceAllString(sp.text, "\n")
+		if len(out) == 0 || strings.HasSuffix(out[len(out)-1].text, " ") || strings.HasSuffix(out[len(out)-1].text, "\n") {
+			text = strings.TrimLeft(text, " ")
+		}
+		if text == "" {
+			continue
+		}
+		if strings.HasPrefix(text, "\n") && len(out) > 0 {
+			out[len(out)-1].text = strings.TrimRight(out[len(out)-1].text, " ")
+		}
+		sp.text = text
+		out = append(out, sp)
+	}
+
+	closed := false
+	for len(out) > 0 {
+		last := &out[len(out)-1]
+		last.text = strings.TrimRight(last.text, " ")
+		if !closed && strings.HasSuffix(last.text, "\n") {
+			last.text = strings.TrimRight(strings.TrimSuffix(last.text, "\n"), " ")
+			closed = true
+		}
+		if last.text != "" {
+			break
+		}
+		out = out[:len(out)-1]
+	}
+	if len(out) == 0 && broken {
+		return []span{{}}
+	}
+	return out
+}
+
+// listItems returns the content of each item of a ul or ol. Lists nested
+// directly in a list, as some editors write them, belong to the item before
+// them.
+func listItems(n *htmlNode) [][]*htmlNode {
+	var items [][]*htmlNode
+	for _, c := range n.children {
+		switch {
+		case c.tag == "li":
+			items = append(items, c.children)
+		case (c.tag == "ul" || c.tag == "ol") && len(items) > 0:
+			items[len(items)-1] = append(items[len(items)-1], c)
+		case c.tag == "" && strings.TrimSpace(c.text) == "":
+		default:
+			items = append(items, []*htmlNode{c})
+		}
+	}
+	return items
+}
+
+// listStart returns the number of the first item of an ol.
+func listStart(n *htmlNode) int {
+	start, err := strconv.Atoi(n.attrs["start"])
+	if err != nil {
+		return 1
+	}
+	return start
+}
+
+// htmlList renders a ul or ol.
+func (r *Renderer) htmlList(n *htmlNode, width, depth int) []string {
+	items := listItems(n)
+	if len(items) == 0 {
+		return nil
+	}
+
+	start := listStart(n)
+	markers := make([]string, len(items))
+	markerWidth := 0
+	for k := range items {
+		if n.tag == "ol" {
+			markers[k] = fmt.Sprintf("%d.", start+k)
+		} else {
+			markers[k] = bullets[depth%len(bullets)]
+		}
+		markerWidth = max(markerWidth, ansi.StringWidth(markers[k]))
+	}
+
+	var out []string
+	pad := strings.Repeat(" ", markerWidth+1)
+	for k, item := range items {
+		body, _ := r.htmlBlocks(item, width-markerWidth-1, depth+1, true)
+		if len(body) == 0 {
+			body = []string{""}
+		}
+		marker := markers[k]
+		gap := strings.Repeat(" ", markerWidth-ansi.StringWidth(marker))
+		out = append(out, gap+r.styleMarker(marker)+" "+body[0])
+		for _, l := range body[1:] {
+			if l == "" {
+				out = append(out, "")
+			} else {
+				out = append(out, pad+l)
+			}
+		}
+	}
+	return out
+}
+
+// tableGrid returns the cells of a table by row, the number of columns and
+// whether the first row is a header: it sits in a thead or holds only th
+// cells.
+func tableGrid(n *htmlNode) (grid [][]*htmlNode, cols int, header bool) {
+	var rows []*htmlNode
+	var walk func(*htmlNode)
+	walk = func(n *htmlNode) {
+		for _, c := range n.children {
+			switch c.tag {
+			case "tr":
+				if len(rows) == 0 && n.tag == "thead" {
+					header = true
+				}
+				rows = append(rows, c)
+			case "thead", "tbody", "tfoot":
+				walk(c)
+			}
+		}
+	}
+	walk(n)
+
+	for _, row := range rows {
+		var cells []*htmlNode
+		for _, c := range row.children {
+			if c.tag == "td" || c.tag == "th" {
+				cells = append(cells, c)
+			}
+		}
+		grid = append(grid, cells)
+		cols = max(cols, len(cells))
+	}
+	if cols > 0 && !header {
+		header = len(grid[0]) > 0
+		for _, c := range grid[0] {
+			header = header && c.tag == "th"
+		}
+	}
+	return grid, cols, header
+}
+
+// cellSpans collects the text of a table cell on one line.
+func cellSpans(cell *htmlNode) []span {
+	var spans []span
+	for _, child := range cell.children {
+		collectInline(child, span{}, &spans)
+	}
+	for k := range spans {
+		spans[k].text = strings.ReplaceAll(spans[k].text, "\n", " ")
+	}
+	return trimSpans(spans)
+}
+
+// htmlTable renders a table's cells on one line each.
+func (r *Renderer) htmlTable(n *htmlNode, width int) []string {
+	grid, cols, header := tableGrid(n)
+	if cols == 0 {
+		return nil
+	}
+
+	aligns := make([]lipgloss.Position, cols)
+	cells := make([][]string, len(grid))
+	for ri, row := range grid {
+		cells[ri] = make([]string, cols)
+		for c, cell := range row {
+			style := r.base
+			if cell.tag == "th" || (ri == 0 && header) {
+				style = style.Bold(true)
+			}
+			cells[ri][c] = r.renderSpans(cellSpans(cell), style)
+			if ri == 0 || aligns[c] == lipgloss.Left {
+				aligns[c] = cellAlign(cell)
+			}
+		}
+	}
+	return r.layoutTable(cells, aligns, header, width)
+}
+
+// cellAlign reads a cell's align attribute or text-align style.
+func cellAlign(cell *htmlNode) lipgloss.Position {
+	a := strings.ToLower(cell.attrs["align"])
+	if style := strings.ToLower(cell.attrs["style"]); a == "" && strings.Contains(style, "text-align") {
+		_, a, _ = strings.Cut(style, "text-align")
+		a = strings.TrimLeft(a, ": ")
+	}
+	switch {
+	case strings.HasPrefix(a, "right"):
+		return lipgloss.Right
+	case strings.HasPrefix(a, "center"):
+		return lipgloss.Center
+	}
+	return lipgloss.Left
+}
+
+// htmlPre renders preformatted text as a code block.
+func (r *Renderer) htmlPre(n *htmlNode, width int) []string {
+	code, lang := preCode(n)
+	if code == nil {
+		return nil
+	}
+	return r.codeBlock(code, lang, width)
+}
+
+// preCode returns the lines of a pre element and the language named by a
+// language-* class on it or its code element; nil when it is empty.
+func preCode(n *htmlNode) ([]string, string) {
+	lang := ""
+	for _, el := range append([]*htmlNode{n}, n.children...) {
+		if m := codeLangRe.FindStringSubmatch(el.attrs["class"]); m != nil {
+			lang = m[1]
+			break
+		}
+	}
+	code := strings.ReplaceAll(textContent(n), "\t", "    ")
+	code = strings.ReplaceAll(code, "\r\n", "\n")
+	code = strings.TrimPrefix(code, "\n")
+	code = strings.TrimRight(code, "\n ")
+	if code == "" {
+		return nil, lang
+	}
+	return strings.Split(code, "\n"), lang
+}
+
+// textContent returns the text of n and its descendants as written, with
+// line breaks for br elements.
+func textContent(n *htmlNode) string {
+	switch n.tag {
+	case "":
+		return n.text
+	case "br":
+		return "\n"
+	}
+	var sb strings.Builder
+	for _, c := range n.children {
+		sb.WriteString(textContent(c))
+	}
+	return sb.String()
+}
+
+// hasImage reports whether n contains an img element.
+func hasImage(n *htmlNode) bool {
+	for _, c := range n.children {
+		if c.tag == "img" || hasImage(c) {
+			return true
+		}
+	}
+	return false
+}
+
+// imageName names an image for its placeholder: the alt text, else the
+// fileName Azure DevOps puts in attachment URLs, else the file name.
+func imageName(alt, src string) string {
+	if alt = strings.TrimSpace(alt); alt != "" {
+		return alt
+	}
+	u, err := url.Parse(src)
+	if err != nil {
+		return ""
+	}
+	if name := u.Query().Get("fileName"); name != "" {
+		return name
+	}
+	if name := path.Base(u.Path); name != "." && name != "/" {
+		return name
+	}
+	return ""
+}
@@ -0,0 +1,147 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// renderHTML renders src and strips all escape sequences, leaving the layout.
+func renderHTML(src string, width int) string {
+	return ansi.Strip(newTestRenderer().RenderHTML(src, width))
+}
+
+func TestRenderHTML_Blocks(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{"divs are lines", "<div>one</div><div>two</div>", "one\ntwo"},
+		{"empty div is a blank line", "<div>one</div><div><br></div><div>two</div>", "one\n\ntwo"},
+		{"paragraphs", "<p>one</p>\n<p>two</p>", "one\n\ntwo"},
+		{"unclosed paragraphs", "<p>one<p>two", "one\n\ntwo"},
+		{"whitespace collapses", "<div>  a \n  b&nbsp;&nbsp;c </div>", "a b  c"},
+		{"line breaks", "a<br>b<br/>c", "a\nb\nc"},
+		{"entities", "<p>a &lt;b&gt; &amp; &quot;c&quot; &#39;d&#39;</p>", `a <b> & "c" 'd'`},
+		{"heading", "<h2>Summary</h2><div>Body</div>", "Summary\n\nBody"},
+		{"rule", "<p>a</p><hr><p>b</p>", "a\n\n" + strings.Repeat("─", 20) + "\n\nb"},
+		{"quote", "<blockquote>quoted <b>text</b></blockquote>", "│ quoted text"},
+		{"comments and scripts dropped", "<!-- hint --><style>p{}</style><script>x()</script>kept", "kept"},
+		{"unknown tags keep text", "<font color=red><span>kept</span></font>", "kept"},
+		{"stray less-than", "<div>a < b</div>", "a < b"},
+		{"empty", "<div><br></div>  ", ""},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := renderHTML(tc.src, 20); got != tc.want {
+				t.Errorf("RenderHTML(%q) =\n%q\nwant\n%q", tc.src, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRenderHTML_Lists(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{"bullets", "<ul><li>one</li><li>two</li></ul>", "• one\n• two"},
+		{"unclosed items", "<ul><li>one<li>two</ul>", "• one\n• two"},
+		{"nested", "<ul><li>one<ul><li>nested<ul><li>deeper</li></ul></li></ul></li></ul>", "• one\n  ◦ nested\n    ▪ deeper"},
+		{"list nested in list", "<ul><li>one</li><ul><li>nested</li></ul></ul>", "• one\n  ◦ nested"},
+		{"ordered keeps start", "<ol start=\"9\"><li>a</li><li>b</li></ol>", " 9. a\n10. b"},
+		{"paragraphs in items", "<ol><li><p>a</p></li><li><div>b</div></li></ol>", "1. a\n2. b"},
+		{"wraps under marker", "<ul><li>alpha beta gamma</li></ul>", "• alpha beta\n  gamma"},
+		{"check boxes", "<ul><li><input type=checkbox checked> done</li><li><input type=\"checkbox\"> todo</li></ul>", "• ☑ done\n• ☐ todo"},
+		{"after text", "Steps:<ol><li>a</li></ol>", "Steps:\n\n1. a"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := renderHTML(tc.src, 14); got != tc.want {
+				t.Errorf("RenderHTML(%q) =\n%q\nwant\n%q", tc.src, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRenderHTML_Table(t *testing.T) {
+	src := `<table><thead><tr><th>Name</th><th align="right">Count</th></tr></thead>
+<tbody><tr><td>a</td><td style="text-align: right">1</td></tr><tr><td><div>long name</div></td><td>22</td></tr></tbody></table>`
+	want := "Name      │ Count\n──────────┼──────\na         │     1\nlong name │    22"
+	if got := renderHTML(src, 40); got != want {
+		t.Errorf("table =\n%s\nwant\n%s", got, want)
+	}
+
+	got := renderHTML("<table><tr><td>a</td><td>b</td></tr><tr><td>c</td></tr></table>", 40)
+	if got != "a │ b\nc │" {
+		t.Errorf("table without header =\n%q", got)
+	}
+
+	for _, line := range strings.Split(renderHTML(src, 12), "\n") {
+		if w := ansi.StringWidth(line); w > 12 {
+			t.Errorf("line %q is %d wide, want at most 12", line, w)
+		}
+	}
+}
+
+func TestRenderHTML_Pre(t *testing.T) {
+	got := renderHTML("<p>Run:</p><pre><code class=\"language-go\">func main() {\n\treturn &amp;x\n}\n</code></pre>", 40)
+	want := "Run:\n\n│ func main() {\n│     return &x\n│ }"
+	if got != want {
+		t.Errorf("pre =\n%q\nwant\n%q", got, want)
+	}
+	if got := renderHTML("<pre>a<br>b</pre>", 40); got != "│ a\n│ b" {
+		t.Errorf("pre with br = %q", got)
+	}
+}
+
+func TestRenderHTML_Inline(t *testing.T) {
+	r := newTestRenderer()
+	got := r.RenderHTML(`<div><b>bold</b> <i>it</i> <code>x()</code> see <a href="https://example.com/docs">the docs</a> or <a href="https://a.io"></a></div>`, 0)
+	if !strings.Contains(got, "\x1b]8;;https://example.com/docs\x07") || !strings.Contains(got, "\x1b]8;;https://a.io\x07") {
+		t.Errorf("links should be OSC-8 hyperlinks: %q", got)
+	}
+	if plain := ansi.Strip(got); plain != "bold it x() see the docs or https://a.io" {
+		t.Errorf("text = %q", plain)
+	}
+
+	var spans []span
+	collectInline(parseHTML("<strong>a<em>b</em></strong><del>c</del>").children[0], span{}, &spans)
+	want := []span{{text: "a", bold: true}, {text: "b", bold: true, italic: true}}
+	if len(spans) != 2 || spans[0] != want[0] || spans[1] != want[1] {
+		t.Errorf("spans = %+v, want %+v", spans, want)
+	}
+}
+
+func TestRenderHTML_Images(t *testing.T) {
+	tests := []struct {
+		src  string
+		want string
+	}{
+		{`<img src="https://x.io/d.png" alt="diagram">`, "[image: diagram] https://x.io/d.png"},
+		{`<img src="https://dev.azure.com/o/p/_apis/wit/attachments/1?fileName=crash.png">`,
+			"[image: crash.png] https://dev.azure.com/o/p/_apis/wit/attachments/1?fileName=crash.png"},
+		{`<img src="https://x.io/shots/a.png">`, "[image: a.png] https://x.io/shots/a.png"},
+	}
+	for _, tc := range tests {
+		if got := renderHTML(tc.src, 0); got != tc.want {
+			t.Errorf("RenderHTML(%q) = %q, want %q", tc.src, got, tc.want)
+		}
+	}
+}
+
+func TestRenderHTML_WrapsToWidth(t *testing.T) {
+	src := "<div>A fairly long paragraph with <b>bold words</b> and a <a href=\"https://example.com\">link to somewhere</a> that needs wrapping.</div>"
+	lines := strings.Split(newTestRenderer().RenderHTML(src, 24), "\n")
+	if len(lines) < 3 {
+		t.Fatalf("expected several lines, got %q", lines)
+	}
+	for _, line := range lines {
+		if w := ansi.StringWidth(line); w > 24 {
+			t.Errorf("line %q is %d wide, want at most 24", ansi.Strip(line), w)
+		}
+	}
+}
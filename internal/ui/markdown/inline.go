@@ -0,0 +1,411 @@
+package markdown
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// span is a run of inline text with the formatting that applies to it.
+// Nested emphasis is flattened into flags so that styling one span never
+// resets the styling of the text around it.
+type span struct {
+	text   string
+	bold   bool
+	italic bool
+	strike bool
+	code   bool
+	url    string
+	image  bool // text is the image's alt text and url its source
+}
+
+var (
+	bareURLRe = regexp.MustCompile(`^(?:https?://|www\.)[^\s<]+`)
+	brTagRe   = regexp.MustCompile(`^<br\s*/?>`)
+	autoRe    = regexp.MustCompile(`^<((?:https?|mailto):[^\s<>]+|[^\s<>@]+@[^\s<>@]+\.[a-zA-Z]+)>`)
+)
+
+// inline renders a paragraph's inline markdown on top of base.
+func (r *Renderer) inline(s string, base lipgloss.Style) string {
+	var spans []span
+	parseInline(s, span{}, &spans)
+	return r.renderSpans(spans, base)
+}
+
+// renderSpans styles spans on top of base and joins them.
+func (r *Renderer) renderSpans(spans []span, base lipgloss.Style) string {
+	var sb strings.Builder
+	for _, sp := range spans {
+		style := base
+		switch {
+		case sp.code:
+			style = r.code.Inherit(base)
+		case sp.url != "":
+			style = r.link.Inherit(base)
+		}
+		if sp.bold {
+			style = style.Bold(true)
+		}
+		if sp.italic {
+			style = style.Italic(true)
+		}
+		if sp.strike {
+			style = style.Strikethrough(true)
+		}
+		if sp.image {
+			sb.WriteString(r.image(sp, style))
+			continue
+		}
+		// Hard breaks are rendered line by line: lipgloss pads multi-line
+		// text to a block, which would leave trailing spaces on the break.
+		for k, part := range strings.Split(sp.text, "\n") {
+			if k > 0 {
+				sb.WriteByte('\n')
+			}
+			if part == "" {
+				continue
+			}
+			text := style.Render(part)
+			if sp.url != "" {
+				text = Hyperlink(text, sp.url)
+			}
+			sb.WriteString(text)
+		}
+	}
+	return sb.String()
+}
+
+// image renders an image as a placeholder naming it, followed by its URL
+// so it can be opened in terminals without hyperlinks.
+func (r *Renderer) image(sp span, style lipgloss.Style) string {
+	text := "[image]"
+	if sp.text != "" {
+		text = "[image: " + sp.text + "]"
+	}
+	if sp.url == "" {
+		return style.Render(text)
+	}
+	return Hyperlink(style.Render(text+" "+sp.url), sp.url)
+}
+
+// parseInline splits s into spans, inheriting the formatting of st.
+func parseInline(s string, st span, out *[]span) {
+	var buf strings.Builder
+	flush := func() {
+		if buf.Len() > 0 {
+			sp := st
+			sp.text = buf.String()
+			*out = append(*out, sp)
+			buf.Reset()
+		}
+	}
+	add := func(sp span) {
+		flush()
+		*out = append(*out, sp)
+	}
+
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == '\\' && i+1 < len(s) && isPunct(s[i+1]):
+			buf.WriteByte(s[i+1])
+			i += 2
+			continue
+
+		case c == '`':
+			n := runLength(s, i)
+			if end := closingBackticks(s, i+n, n); end >= 0 {
+				sp := st
+				sp.code = true
+				sp.text = trimCodeSpan(s[i+n : end])
+				add(sp)
+				i = end + n
+				continue
+			}
+			buf.WriteString(s[i : i+n])
+			i += n
+			continue
+
+		case c == '!' && i+1 < len(s) && s[i+1] == '[':
+			if text, url, end, ok := parseLink(s, i+1); ok {
+				sp := st
+				sp.url = url
+				sp.text = text
+				sp.image = true
+				add(sp)
+				i = end
+				continue
+			}
+
+		case c == '[':
+			if text, url, end, ok := parseLink(s, i); ok {
+				flush()
+				sub := st
+				sub.url = url
+				parseInline(text, sub, out)
+				i = end
+				continue
+			}
+
+		case c == '<':
+			if m := brTagRe.FindString(s[i:]); m != "" {
+				buf.WriteByte('\n')
+				i += len(m)
+				continue
+			}
+			if m := autoRe.FindStringSubmatch(s[i:]); m != nil && st.url == "" {
+				sp := st
+				sp.text, sp.url = m[1], m[1]
+				if !strings.Contains(m[1], ":") {
+					sp.url = "mailto:" + m[1]
+				}
+				add(sp)
+				i += len(m[0])
+				continue
+			}
+
+		case (c == 'h' || c == 'w') && st.url == "" && (i == 0 || !isWordByte(s[i-1])):
+			if m := bareURLRe.FindString(s[i:]); m != "" {
+				m = trimURLPunct(m)
+				sp := st
+				sp.text, sp.url = m, m
+				if strings.HasPrefix(m, "www.") {
+					sp.url = "https://" + m
+				}
+				add(sp)
+				i += len(m)
+				continue
+			}
+
+		case c == '*' || c == '_' || c == '~':
+			if inner, apply, end, ok := parseEmphasis(s, i); ok {
+				flush()
+				sub := st
+				apply(&sub)
+				parseInline(inner, sub, out)
+				i = end
+				continue
+			}
+			// Copy the whole run so its tail is not retried as an opener.
+			n := runLength(s, i)
+			buf.WriteString(s[i : i+n])
+			i += n
+			continue
+		}
+		buf.WriteByte(c)
+		i++
+	}
+	flush()
+}
+
+// parseEmphasis parses the emphasis opening with the delimiter run at s[i]:
+// *italic*, **bold**, ***both***, the same with underscores, and ~~strike~~.
+// It returns the enclosed text, how to mark it and the index after the
+// closing run.
+func parseEmphasis(s string, i int) (string, func(*span), int, bool) {
+	c := s[i]
+	n := runLength(s, i)
+	after := i + n
+	if after >= len(s) || isSpace(s[after]) {
+		return "", nil, 0, false
+	}
+	if c == '_' && i > 0 && isWordByte(s[i-1]) {
+		return "", nil, 0, false // snake_case identifiers are not emphasis
+	}
+
+	if c == '~' {
+		if n != 2 {
+			return "", nil, 0, false
+		}
+		end := findCloser(s, after, c, 2)
+		if end < 0 {
+			return "", nil, 0, false
+		}
+		return s[after:end], func(sp *span) { sp.strike = true }, end + 2, true
+	}
+
+	for k := min(n, 3); k >= 1; k-- {
+		end := findCloser(s, i+k, c, k)
+		if end < 0 {
+			continue
+		}
+		apply := func(sp *span) {
+			sp.italic = sp.italic || k != 2
+			sp.bold = sp.bold || k >= 2
+		}
+		return s[i+k : end], apply, end + k, true
+	}
+	return "", nil, 0, false
+}
+
+// findCloser returns the index of the k-character closing run of c after
+// from, or -1. A run of exactly k characters is preferred; failing that the
+// last k characters of a longer run close, so that the outer delimiter of
+// "**bold *italic***" is found. Code spans and escapes are skipped.
+func findCloser(s string, from int, c byte, k int) int {
+	for _, exact := range []bool{true, false} {
+		for j := from; j < len(s); {
+			switch s[j] {
+			case '\\':
+				j += 2
+				continue
+			case '`':
+				n := runLength(s, j)
+				if end := closingBackticks(s, j+n, n); end >= 0 {
+					j = end + n
+					continue
+				}
+				j += n
+				continue
+			case c:
+				n := runLength(s, j)
+				closeAt := j + n - k
+				fits := n == k || (!exact && n > k)
+				if fits && closeAt > from && !isSpace(s[closeAt-1]) &&
+					(c != '_' || j+n >= len(s) || !isWordByte(s[j+n])) {
+					return closeAt
+				}
+				j += n
+				continue
+			}
+			j++
+		}
+	}
+	return -1
+}
+
+// parseLink parses [text](url) with the bracket at s[i], returning the link
+// text, the destination and the index after the closing parenthesis.
+func parseLink(s string, i int) (string, string, int, bool) {
+	closeText := matching(s, i, '[', ']')
+	if closeText < 0 || closeText+1 >= len(s) || s[closeText+1] != '(' {
+		return "", "", 0, false
+	}
+	closeDest := matching(s, closeText+1, '(', ')')
+	if closeDest < 0 {
+		return "", "", 0, false
+	}
+	dest := strings.TrimSpace(s[closeText+2 : closeDest])
+	if strings.HasPrefix(dest, "<") {
+		if end := strings.IndexByte(dest, '>'); end > 0 {
+			dest = dest[1:end]
+		}
+	} else if fields := strings.Fields(dest); len(fields) > 0 {
+		dest = fields[0] // drop an optional "title"
+	}
+	if dest == "" {
+		return "", "", 0, false
+	}
+	return s[i+1 : closeText], dest, closeDest + 1, true
+}
+
+// matching returns the index of the bracket closing the one at s[i],
+// honouring nesting and backslash escapes, or -1.
+func matching(s string, i int, open, close byte) int {
+	depth := 0
+	for j := i; j < len(s); j++ {
+		switch s[j] {
+		case '\\':
+			j++
+		case open:
+			depth++
+		case close:
+			depth--
+			if depth == 0 {
+				return j
+			}
+		}
+	}
+	return -1
+}
+
+// closingBackticks returns the start of the next run of exactly n backticks
+// at or after from, or -1.
+func closingBackticks(s string, from, n int) int {
+	for j := from; j < len(s); {
+		if s[j] != '`' {
+			j++
+			continue
+		}
+		m := runLength(s, j)
+		if m == n {
+			return j
+		}
+		j += m
+	}
+	return -1
+}
+
+// trimCodeSpan strips the single space that may pad a code span on both
+// sides so a code span can start or end with a backtick.
+func trimCodeSpan(code string) string {
+	if len(code) >= 2 && code[0] == ' ' && code[len(code)-1] == ' ' && strings.TrimSpace(code) != "" {
+		return code[1 : len(code)-1]
+	}
+	return code
+}
+
+// trimURLPunct drops trailing punctuation that ends the sentence rather than
+// the URL, and a closing parenthesis without an opening one in the URL.
+func trimURLPunct(url string) string {
+	for len(url) > 0 {
+		last := url[len(url)-1]
+		switch {
+		case strings.IndexByte(".,:;!?'\"*_~", last) >= 0:
+			url = url[:len(url)-1]
+		case last == ')' && strings.Count(url, ")") > strings.Count(url, "("):
+			url = url[:len(url)-1]
+		default:
+			return url
+		}
+	}
+	return url
+}
+
+// runLength counts the repeats of the character at s[i].
+func runLength(s string, i int) int {
+	n := 1
+	for i+n < len(s) && s[i+n] == s[i] {
+		n++
+	}
+	return n
+}
+
+func isPunct(c byte) bool {
+	return strings.IndexByte("!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~", c) >= 0
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\n'
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= 0x80
+}
+
+// Hyperlink wraps text in an OSC 8 terminal hyperlink escape sequence.
+// Terminals that support OSC 8 render this as a clickable link.
+// Terminals that don't support it will display the text normally, as
+// they do text with an empty url. Control characters in url are
+// percent-encoded, so a BEL or ESC cannot end the sequence early.
+func Hyperlink(text, url string) string {
+	if url == "" {
+		return text
+	}
+	return fmt.Sprintf("\x1b]8;;%s\x07%s\x1b]8;;\x07", escapeControls(url), text)
+}
+
+// escapeControls percent-encodes the C0 control characters and DEL in s.
+func escapeControls(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; c < 0x20 || c == 0x7f {
+			fmt.Fprintf(&b, "%%%02X", c)
+		} else {
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,721 @@
+// Package markdown renders the markdown of pull request descriptions, review
+// comments and GitHub issues for the terminal. It covers the GitHub-flavored
+// subset people actually write in reviews (headings, emphasis, lists, task
+// lists, block quotes, tables, fenced code and links) rather than the full
+// CommonMark spec. Colors come from the active styles.Theme, fenced code is
+// highlighted with the syntax package and links become OSC-8 hyperlinks.
+//
+// A line break inside a paragraph is kept, as both GitHub and Azure DevOps
+// do when they display comments and descriptions.
+//
+// RenderHTML draws the HTML of Azure DevOps rich text fields, such as work
+// item descriptions, with the same styles. ToMarkdown and ToHTML convert
+// between the two, so such fields can be edited as markdown.
+package markdown
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Elpulgo/azdo/internal/ui/styles"
+	"github.com/Elpulgo/azdo/internal/ui/syntax"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Renderer renders markdown with the colors of one theme.
+type Renderer struct {
+	theme    styles.Theme
+	base     lipgloss.Style
+	link     lipgloss.Style
+	code     lipgloss.Style
+	codeText lipgloss.Style
+	border   lipgloss.Style
+	quote    lipgloss.Style
+	marker   lipgloss.Style
+	done     lipgloss.Style
+	headings [3]lipgloss.Style
+}
+
+var (
+	htmlCommentRe = regexp.MustCompile(`(?s)<!--.*?-->`)
+	fenceRe       = regexp.MustCompile("^(`{3,}|~{3,})\\s*([^`\\s]*)")
+	headingRe     = regexp.MustCompile(`^(#{1,6})(?:\s+(.*))?$`)
+	listMarkerRe  = regexp.MustCompile(`^([-*+]|\d{1,9}[.)])( +|$)`)
+	tableDelimRe  = regexp.MustCompile(`^\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?\s*$`)
+)
+
+// bullets are the unordered list markers, by nesting depth.
+var bullets = []string{"•", "◦", "▪"}
+
+// New returns a Renderer for the theme of s. Plain text is drawn in s.Value.
+func New(s *styles.Styles) *Renderer {
+	theme := s.Theme
+	fg := func(c lipgloss.Color) lipgloss.Style { return lipgloss.NewStyle().Foreground(c) }
+	return &Renderer{
+		theme:    theme,
+		base:     s.Value,
+		link:     s.Link,
+		code:     fg(theme.Accent),
+		codeText: fg(theme.Foreground),
+		border:   fg(theme.Border),
+		quote:    s.Muted.Italic(true),
+		marker:   fg(theme.Secondary),
+		done:     fg(theme.Success),
+		headings: [3]lipgloss.Style{
+			fg(theme.Primary).Bold(true).Underline(true),
+			fg(theme.Primary).Bold(true),
+			fg(theme.Secondary).Bold(true),
+		},
+	}
+}
+
+// WithBase returns a copy of r that draws plain text in base, for markdown
+// shown inside differently colored text such as review comments.
+func (r *Renderer) WithBase(base lipgloss.Style) *Renderer {
+	c := *r
+	c.base = base
+	return &c
+}
+
+// Render renders src wrapped to width columns; width <= 0 disables wrapping.
+// Blocks are separated by a blank line and the result has no trailing
+// newline, so its line count is strings.Count(out, "\n")+1.
+func (r *Renderer) Render(src string, width int) string {
+	lines := sourceLines(src)
+	if lines == nil {
+		return ""
+	}
+	return strings.Join(r.blocks(lines, width, 0, false), "\n")
+}
+
+// sourceLines splits markdown into lines with tabs expanded and HTML
+// comments dropped; nil when nothing is left.
+func sourceLines(src string) []string {
+	src = strings.ReplaceAll(src, "\r\n", "\n")
+	src = strings.ReplaceAll(src, "\t", "    ")
+	src = htmlCommentRe.ReplaceAllString(src, "")
+	src = strings.Trim(src, "\n")
+	if strings.TrimSpace(src) == "" {
+		return nil
+	}
+	return strings.Split(src, "\n")
+}
+
+// blockKind tells what a block of markdown is.
+type blockKind int
+
+const (
+	blockParagraph blockKind = iota
+	blockHeading
+	blockCode
+	blockRule
+	blockQuote
+	blockList
+	blockTable
+)
+
+// block is one block of markdown as scanned from its lines, before any
+// inline markup is parsed.
+type block struct {
+	kind   blockKind
+	lines  []string // paragraph, code or quoted lines
+	text   string   // heading text
+	level  int      // heading level
+	lang   string   // code language
+	items  []listItem
+	loose  bool // the list has blank lines between its items
+	rows   [][]string
+	aligns []lipgloss.Position
+}
+
+// scanBlocks splits markdown lines into blocks.
+func scanBlocks(lines []string) []block {
+	var out []block
+	for i := 0; i < len(lines); {
+		trimmed, indent := splitIndent(lines[i])
+		var b block
+		switch {
+		case trimmed == "":
+			i++
+			continue
+		case indent >= 4:
+			b.kind = blockCode
+			for ; i < len(lines) && (isBlank(lines[i]) || leadingSpaces(lines[i]) >= 4); i++ {
+				b.lines = append(b.lines, stripIndent(lines[i], 4))
+			}
+			for len(b.lines) > 0 && strings.TrimSpace(b.lines[len(b.lines)-1]) == "" {
+				b.lines = b.lines[:len(b.lines)-1]
+			}
+		case fenceRe.MatchString(trimmed):
+			b.kind = blockCode
+			b.lines, b.lang, i = scanFence(lines, i)
+		case headingRe.MatchString(trimmed):
+			m := headingRe.FindStringSubmatch(trimmed)
+			b = block{kind: blockHeading, level: len(m[1]), text: trimClosingHashes(m[2])}
+			i++
+		case isRule(trimmed):
+			b.kind = blockRule
+			i++
+		case strings.HasPrefix(trimmed, ">"):
+			b.kind = blockQuote
+			b.lines, i = scanQuote(lines, i)
+		case listMarkerRe.MatchString(trimmed):
+			b.kind = blockList
+			b.items, b.loose, i = scanList(lines, i)
+		case i+1 < len(lines) && strings.Contains(trimmed, "|") && tableDelimRe.MatchString(strings.TrimSpace(lines[i+1])):
+			b.kind = blockTable
+			b.rows, b.aligns, i = scanTable(lines, i)
+		default:
+			b.lines, b.level, i = scanParagraph(lines, i)
+			if b.level > 0 {
+				b = block{kind: blockHeading, level: b.level, text: joinParagraph(b.lines)}
+			}
+		}
+		out = append(out, b)
+	}
+	return out
+}
+
+// blocks renders markdown lines as output lines. depth is the list nesting
+// level; tight drops the blank line between blocks, as inside the items of a
+// tight list.
+func (r *Renderer) blocks(lines []string, width, depth int, tight bool) []string {
+	var out []string
+	for _, b := range scanBlocks(lines) {
+		rendered := r.block(b, width, depth)
+		if len(rendered) == 0 {
+			continue
+		}
+		if len(out) > 0 && !tight {
+			out = append(out, "")
+		}
+		out = append(out, rendered...)
+	}
+	return out
+}
+
+// block renders one block.
+func (r *Renderer) block(b block, width, depth int) []string {
+	switch b.kind {
+	case blockHeading:
+		return r.heading(b.level, b.text, width)
+	case blockCode:
+		return r.codeBlock(b.lines, b.lang, width)
+	case blockRule:
+		n := 40
+		if width > 0 {
+			n = min(width, n)
+		}
+		return []string{r.border.Render(strings.Repeat("─", n))}
+	case blockQuote:
+		return r.quoteBar(r.WithBase(r.quote).blocks(b.lines, width-2, depth, false))
+	case blockList:
+		return r.list(b.items, b.loose, width, depth)
+	case blockTable:
+		return r.table(b.rows, b.aligns, width)
+	}
+	return wrap(r.inline(joinParagraph(b.lines), r.base), width)
+}
+
+// scanFence returns the code of the ``` or ~~~ block opening at lines[i],
+// its language and the index after its closing fence (or the end of input
+// when unclosed).
+func scanFence(lines []string, i int) ([]string, string, int) {
+	trimmed, indent := splitIndent(lines[i])
+	m := fenceRe.FindStringSubmatch(trimmed)
+	fence, lang := m[1], m[2]
+
+	var code []string
+	j := i + 1
+	for ; j < len(lines); j++ {
+		t, ind := splitIndent(lines[j])
+		if ind < 4 && strings.HasPrefix(t, fence) && strings.Trim(t, fence[:1]) == "" {
+			j++
+			break
+		}
+		code = append(code, stripIndent(lines[j], indent))
+	}
+	return code, lang, j
+}
+
+// codeBlock highlights code for lang and draws it behind a gutter. Lines
+// wider than the view are truncated rather than wrapped, since wrapped code
+// is harder to read than clipped code.
+func (r *Renderer) codeBlock(code []string, lang string, width int) []string {
+	h := syntax.NewLanguageHighlighter(lang, r.theme)
+	spans := h.Tokenize(code)
+	gutter := r.border.Render("│ ")
+	out := make([]string, len(code))
+	for i := range code {
+		line := h.Render(spans[i], r.codeText)
+		if width > 3 && ansi.StringWidth(line) > width-2 {
+			line = ansi.Truncate(line, width-2, "…")
+		}
+		out[i] = gutter + line
+	}
+	return out
+}
+
+// heading renders an ATX or setext heading of the given level.
+func (r *Renderer) heading(level int, text string, width int) []string {
+	style := r.headings[min(level, len(r.headings))-1]
+	return wrap(r.inline(text, style), width)
+}
+
+// scanQuote returns the > lines starting at lines[i] without their markers
+// and the index after them.
+func scanQuote(lines []string, i int) ([]string, int) {
+	var inner []string
+	for ; i < len(lines); i++ {
+		trimmed, _ := splitIndent(lines[i])
+		if !strings.HasPrefix(trimmed, ">") {
+			break
+		}
+		inner = append(inner, strings.TrimPrefix(trimmed[1:], " "))
+	}
+	return inner, i
+}
+
+// quoteBar draws block quote lines behind a bar.
+func (r *Renderer) quoteBar(quoted []string) []string {
+	bar := r.border.Render("│")
+	for k, l := range quoted {
+		if l == "" {
+			quoted[k] = bar
+		} else {
+			quoted[k] = bar + " " + l
+		}
+	}
+	return quoted
+}
+
+// listItem is one item of a list and its lines with the item's indentation
+// removed.
+type listItem struct {
+	marker string
+	lines  []string
+	blank  bool // a blank line separates blocks inside the item
+}
+
+// scanList returns the items of the list starting at lines[i], whether it
+// is loose and the index after it.
+func scanList(lines []string, i int) ([]listItem, bool, int) {
+	first, _ := splitIndent(lines[i])
+	kind := listKind(listMarkerRe.FindStringSubmatch(first)[1])
+
+	var items []listItem
+	loose := false
+	for i < len(lines) {
+		trimmed, indent := splitIndent(lines[i])
+		m := listMarkerRe.FindStringSubmatch(trimmed)
+		if m == nil || indent >= 4 || isRule(trimmed) || listKind(m[1]) != kind {
+			break
+		}
+		contentIndent := indent + len(m[0])
+		item := listItem{marker: m[1], lines: []string{trimmed[len(m[0]):]}}
+
+		for i++; i < len(lines); i++ {
+			line := lines[i]
+			if isBlank(line) {
+				next := nextNonBlank(lines, i)
+				if next < len(lines) && leadingSpaces(lines[next]) >= contentIndent {
+					item.lines = append(item.lines, "")
+					item.blank = true
+					i = next - 1
+					continue
+				}
+				break
+			}
+			if leadingSpaces(line) >= contentIndent {
+				item.lines = append(item.lines, line[contentIndent:])
+				continue
+			}
+			t, _ := splitIndent(line)
+			if listMarkerRe.MatchString(t) || startsBlock(t) {
+				break
+			}
+			item.lines = append(item.lines, t) // lazy paragraph continuation
+		}
+		items = append(items, item)
+
+		if i < len(lines) && isBlank(lines[i]) {
+			next := nextNonBlank(lines, i)
+			if next >= len(lines) {
+				i = next
+				break
+			}
+			t, ind := splitIndent(lines[next])
+			nm := listMarkerRe.FindStringSubmatch(t)
+			if nm == nil || ind >= 4 || isRule(t) || listKind(nm[1]) != kind {
+				break
+			}
+			loose = true
+			i = next
+		}
+	}
+	for _, item := range items {
+		loose = loose || item.blank
+	}
+	return items, loose, i
+}
+
+// list renders the items of a list.
+func (r *Renderer) list(items []listItem, loose bool, width, depth int) []string {
+	markers := r.listMarkers(items, depth)
+	markerWidth := 0
+	for _, mk := range markers {
+		markerWidth = max(markerWidth, ansi.StringWidth(mk))
+	}
+
+	var out []string
+	pad := strings.Repeat(" ", markerWidth+1)
+	for k, item := range items {
+		marker := markers[k]
+		content := item.lines
+		if box, rest, ok := taskBox(content[0]); ok {
+			marker = box
+			content = append([]string{rest}, content[1:]...)
+		}
+		body := r.blocks(content, width-markerWidth-1, depth+1, !loose)
+		if len(body) == 0 {
+			body = []string{""}
+		}
+		gap := strings.Repeat(" ", markerWidth-ansi.StringWidth(marker))
+		out = append(out, gap+r.styleMarker(marker)+" "+body[0])
+		for _, l := range body[1:] {
+			if l == "" {
+				out = append(out, "")
+			} else {
+				out = append(out, pad+l)
+			}
+		}
+		if loose && k < len(items)-1 {
+			out = append(out, "")
+		}
+	}
+	return out
+}
+
+// listMarkers returns the rendered marker of each item: the bullet for the
+// nesting depth, or the item number counting from the first item's number.
+func (r *Renderer) listMarkers(items []listItem, depth int) []string {
+	markers := make([]string, len(items))
+	first := items[0].marker
+	if listKind(first) == "" {
+		for k := range items {
+			markers[k] = bullets[depth%len(bullets)]
+		}
+		return markers
+	}
+	start, _ := strconv.Atoi(first[:len(first)-1])
+	for k := range items {
+		markers[k] = fmt.Sprintf("%d.", start+k)
+	}
+	return markers
+}
+
+// styleMarker colors a list marker; checked task boxes use the success color.
+func (r *Renderer) styleMarker(marker string) string {
+	if marker == "☑" {
+		return r.done.Render(marker)
+	}
+	return r.marker.Render(marker)
+}
+
+// taskBox recognises a task list item ("[ ] todo", "[x] done") and returns
+// its check box and the remaining text.
+func taskBox(line string) (string, string, bool) {
+	if len(line) < 3 || line[0] != '[' || line[2] != ']' || (len(line) > 3 && line[3] != ' ') {
+		return "", "", false
+	}
+	rest := strings.TrimPrefix(line[3:], " ")
+	switch line[1] {
+	case ' ':
+		return "☐", rest, true
+	case 'x', 'X':
+		return "☑", rest, true
+	}
+	return "", "", false
+}
+
+// scanTable returns the rows and column alignments of the pipe table whose
+// header row is lines[i], and the index after it.
+func scanTable(lines []string, i int) ([][]string, []lipgloss.Position, int) {
+	header := splitRow(lines[i])
+	aligns := parseAligns(lines[i+1], len(header))
+	rows := [][]string{header}
+	for i += 2; i < len(lines) && !isBlank(lines[i]) && strings.Contains(lines[i], "|"); i++ {
+		rows = append(rows, splitRow(lines[i]))
+	}
+	return rows, aligns, i
+}
+
+// table renders a pipe table; the first row is its header.
+func (r *Renderer) table(rows [][]string, aligns []lipgloss.Position, width int) []string {
+	cols := len(aligns)
+	cells := make([][]string, len(rows))
+	for ri, row := range rows {
+		style := r.base
+		if ri == 0 {
+			style = style.Bold(true)
+		}
+		cells[ri] = make([]string, cols)
+		for c := 0; c < cols && c < len(row); c++ {
+			cells[ri][c] = r.inline(row[c], style)
+		}
+	}
+	return r.layoutTable(cells, aligns, true, width)
+}
+
+// layoutTable lays out rendered cells in columns sized to their content.
+// When the table is wider than width, the widest columns are narrowed and
+// their cells truncated. header draws a rule under the first row.
+func (r *Renderer) layoutTable(cells [][]string, aligns []lipgloss.Position, header bool, width int) []string {
+	cols := len(aligns)
+	widths := make([]int, cols)
+	for _, row := range cells {
+		for c, cell := range row {
+			widths[c] = max(widths[c], ansi.StringWidth(cell))
+		}
+	}
+
+	if width > 0 {
+		avail := width - 3*(cols-1)
+		for sum(widths) > avail {
+			widest := 0
+			for c := range widths {
+				if widths[c] > widths[widest] {
+					widest = c
+				}
+			}
+			if widths[widest] <= 3 {
+				break
+			}
+			widths[widest]--
+		}
+	}
+
+	sep := r.border.Render(" │ ")
+	out := make([]string, 0, len(cells)+1)
+	for ri, row := range cells {
+		parts := make([]string, cols)
+		for c, cell := range row {
+			if ansi.StringWidth(cell) > widths[c] {
+				cell = ansi.Truncate(cell, widths[c], "…")
+			}
+			parts[c] = align(cell, widths[c], aligns[c])
+		}
+		out = append(out, strings.TrimRight(strings.Join(parts, sep), " "))
+		if ri == 0 && header {
+			rules := make([]string, cols)
+			for c, w := range widths {
+				rules[c] = strings.Repeat("─", w)
+			}
+			out = append(out, r.border.Render(strings.Join(rules, "─┼─")))
+		}
+	}
+	return out
+}
+
+// scanParagraph returns the lines of the paragraph starting at lines[i] and
+// the index after it. A paragraph followed by a === or --- underline is a
+// setext heading instead, whose level is returned; otherwise level is 0.
+func scanParagraph(lines []string, i int) (para []string, level, next int) {
+	for ; i < len(lines) && !isBlank(lines[i]); i++ {
+		trimmed, indent := splitIndent(lines[i])
+		if len(para) > 0 && indent < 4 {
+			if level := setextLevel(trimmed); level > 0 {
+				return para, level, i + 1
+			}
+			if startsBlock(trimmed) {
+				break
+			}
+		}
+		para = append(para, lines[i])
+	}
+	return para, 0, i
+}
+
+// joinParagraph joins paragraph lines with line breaks, dropping the
+// CommonMark hard-break markers (a trailing backslash or two spaces).
+func joinParagraph(lines []string) string {
+	for k, l := range lines {
+		lines[k] = strings.TrimSuffix(strings.TrimSpace(l), `\`)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// wrap word-wraps styled text to width and splits it into lines.
+func wrap(s string, width int) []string {
+	if width > 0 {
+		s = ansi.Wrap(s, width, "")
+	}
+	lines := strings.Split(s, "\n")
+	for k, l := range lines {
+		lines[k] = strings.TrimRight(l, " ")
+	}
+	return lines
+}
+
+// startsBlock reports whether a line interrupts a paragraph by opening a
+// block of its own.
+func startsBlock(trimmed string) bool {
+	if headingRe.MatchString(trimmed) || fenceRe.MatchString(trimmed) ||
+		strings.HasPrefix(trimmed, ">") || isRule(trimmed) {
+		return true
+	}
+	m := listMarkerRe.FindStringSubmatch(trimmed)
+	if m == nil || len(trimmed) == len(m[0]) {
+		return false
+	}
+	// Only a list starting at 1 interrupts a paragraph, so a sentence that
+	// happens to wrap before "2019." stays a sentence.
+	return listKind(m[1]) == "" || m[1] == "1." || m[1] == "1)"
+}
+
+// listKind groups list markers that continue the same list: "" for bullets
+// and the delimiter for ordered lists.
+func listKind(marker string) string {
+	last := marker[len(marker)-1]
+	if last == '.' || last == ')' {
+		return string(last)
+	}
+	return ""
+}
+
+// isRule reports whether a line is a thematic break: three or more of the
+// same -, * or _ character, optionally separated by spaces.
+func isRule(trimmed string) bool {
+	if trimmed == "" || !strings.ContainsRune("-*_", rune(trimmed[0])) {
+		return false
+	}
+	n := 0
+	for _, c := range trimmed {
+		switch {
+		case byte(c) == trimmed[0]:
+			n++
+		case c != ' ':
+			return false
+		}
+	}
+	return n >= 3
+}
+
+// setextLevel returns 1 for a === underline, 2 for a --- underline and 0
+// otherwise.
+func setextLevel(trimmed string) int {
+	t := strings.TrimRight(trimmed, " ")
+	switch {
+	case t == "":
+		return 0
+	case strings.Trim(t, "=") == "":
+		return 1
+	case strings.Trim(t, "-") == "":
+		return 2
+	}
+	return 0
+}
+
+// trimClosingHashes removes the optional closing #s of an ATX heading.
+func trimClosingHashes(text string) string {
+	text = strings.TrimSpace(text)
+	if t := strings.TrimRight(text, "#"); t != text && (t == "" || strings.HasSuffix(t, " ")) {
+		return strings.TrimSpace(t)
+	}
+	return text
+}
+
+// splitRow splits a table row into its trimmed cells. Escaped pipes stay in
+// the cell text; the inline renderer unescapes them.
+func splitRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, `\|`) {
+		line = line[:len(line)-1]
+	}
+	var cells []string
+	start := 0
+	for k := 0; k < len(line); k++ {
+		switch line[k] {
+		case '\\':
+			k++
+		case '|':
+			cells = append(cells, strings.TrimSpace(line[start:k]))
+			start = k + 1
+		}
+	}
+	return append(cells, strings.TrimSpace(line[start:]))
+}
+
+// parseAligns reads the column alignments from a table delimiter row.
+func parseAligns(line string, cols int) []lipgloss.Position {
+	aligns := make([]lipgloss.Position, cols)
+	for c, cell := range splitRow(line) {
+		if c >= cols {
+			break
+		}
+		left, right := strings.HasPrefix(cell, ":"), strings.HasSuffix(cell, ":")
+		switch {
+		case left && right:
+			aligns[c] = lipgloss.Center
+		case right:
+			aligns[c] = lipgloss.Right
+		default:
+			aligns[c] = lipgloss.Left
+		}
+	}
+	return aligns
+}
+
+// align pads a styled cell to width.
+func align(cell string, width int, pos lipgloss.Position) string {
+	gap := width - ansi.StringWidth(cell)
+	if gap <= 0 {
+		return cell
+	}
+	switch pos {
+	case lipgloss.Right:
+		return strings.Repeat(" ", gap) + cell
+	case lipgloss.Center:
+		return strings.Repeat(" ", gap/2) + cell + strings.Repeat(" ", gap-gap/2)
+	}
+	return cell + strings.Repeat(" ", gap)
+}
+
+func sum(values []int) int {
+	total := 0
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
+
+func isBlank(line string) bool {
+	return strings.TrimSpace(line) == ""
+}
+
+func leadingSpaces(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+// splitIndent returns the line without its leading spaces and their count.
+func splitIndent(line string) (string, int) {
+	trimmed := strings.TrimLeft(line, " ")
+	return strings.TrimRight(trimmed, " "), len(line) - len(trimmed)
+}
+
+// stripIndent removes up to n leading spaces.
+func stripIndent(line string, n int) string {
+	return line[min(n, leadingSpaces(line)):]
+}
+
+// nextNonBlank returns the index of the first non-blank line at or after i.
+func nextNonBlank(lines []string, i int) int {
+	for i < len(lines) && isBlank(lines[i]) {
+		i++
+	}
+	return i
+}
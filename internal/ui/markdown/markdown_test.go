@@ -0,0 +1,218 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Elpulgo/azdo/internal/ui/styles"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func newTestRenderer() *Renderer {
+	return New(styles.NewStyles(styles.GetDefaultTheme()))
+}
+
+// render renders src and strips all escape sequences, leaving the layout.
+func render(src string, width int) string {
+	return ansi.Strip(newTestRenderer().Render(src, width))
+}
+
+func TestRender_Blocks(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{"heading", "## Summary ##\nBody", "Summary\n\nBody"},
+		{"setext heading", "Title\n=====\ntext", "Title\n\ntext"},
+		{"line breaks kept", "one\ntwo", "one\ntwo"},
+		{"hard break markers dropped", "one  \ntwo\\\nthree", "one\ntwo\nthree"},
+		{"rule", "a\n\n***\n\nb", "a\n\n" + strings.Repeat("─", 20) + "\n\nb"},
+		{"quote", "> quoted **text**\n> more\n>\n> next", "│ quoted text\n│ more\n│\n│ next"},
+		{"html comments dropped", "<!-- template hint -->\nkept", "kept"},
+		{"empty", "  \n\n", ""},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := render(tc.src, 20); got != tc.want {
+				t.Errorf("Render(%q) =\n%q\nwant\n%q", tc.src, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRender_Lists(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{"nested bullets", "- one\n- two\n  - nested\n    - deeper", "• one\n• two\n  ◦ nested\n    ▪ deeper"},
+		{"ordered keeps start", "3. three\n4. four", "3. three\n4. four"},
+		{"numbers align", "9. a\n10. b", " 9. a\n10. b"},
+		{"task list", "- [x] done\n- [ ] todo", "☑ done\n☐ todo"},
+		{"loose list", "- a\n\n- b", "• a\n\n• b"},
+		{"wraps under marker", "- alpha beta gamma", "• alpha beta\n  gamma"},
+		{"lazy continuation", "- first\nsame item", "• first\n  same item"},
+		{"ends at paragraph", "- a\n\nafter", "• a\n\nafter"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := render(tc.src, 14); got != tc.want {
+				t.Errorf("Render(%q) =\n%q\nwant\n%q", tc.src, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRender_CodeBlocks(t *testing.T) {
+	got := render("```go\nfunc main() {\n\treturn\n}\n```\nafter", 40)
+	want := "│ func main() {\n│     return\n│ }\n\nafter"
+	if got != want {
+		t.Errorf("fenced code =\n%q\nwant\n%q", got, want)
+	}
+
+	got = render("text\n\n    indented code\n", 40)
+	if got != "text\n\n│ indented code" {
+		t.Errorf("indented code = %q", got)
+	}
+
+	got = render("```\n"+strings.Repeat("x", 30)+"\n```", 12)
+	if got != "│ xxxxxxxxx…" {
+		t.Errorf("long code line = %q, want it truncated to the width", got)
+	}
+}
+
+func TestRender_HighlightingKeepsCodeText(t *testing.T) {
+	r := newTestRenderer()
+	spans := r.Render("```go\nfunc f() {}\n```", 40)
+	plain := r.Render("```\nfunc f() {}\n```", 40)
+	if ansi.Strip(spans) != ansi.Strip(plain) {
+		t.Fatalf("highlighting changed the text: %q vs %q", spans, plain)
+	}
+}
+
+func TestRender_Table(t *testing.T) {
+	src := "| Name | Count |\n|:-----|------:|\n| a | 1 |\n| long name | 22 |"
+	want := "Name      │ Count\n──────────┼──────\na         │     1\nlong name │    22"
+	if got := render(src, 40); got != want {
+		t.Errorf("table =\n%s\nwant\n%s", got, want)
+	}
+
+	narrow := render(src, 12)
+	for _, line := range strings.Split(narrow, "\n") {
+		if w := ansi.StringWidth(line); w > 12 {
+			t.Errorf("line %q is %d wide, want at most 12", line, w)
+		}
+	}
+	if !strings.Contains(narrow, "…") {
+		t.Errorf("narrow table = %q, want truncated cells", narrow)
+	}
+}
+
+func TestRender_WrapsToWidth(t *testing.T) {
+	src := "A fairly long paragraph with **bold words** and a [link to somewhere](https://example.com) that needs wrapping."
+	got := newTestRenderer().Render(src, 24)
+	lines := strings.Split(got, "\n")
+	if len(lines) < 3 {
+		t.Fatalf("expected several lines, got %q", got)
+	}
+	for _, line := range lines {
+		if w := ansi.StringWidth(line); w > 24 {
+			t.Errorf("line %q is %d wide, want at most 24", ansi.Strip(line), w)
+		}
+	}
+}
+
+func TestRender_LinksAreHyperlinks(t *testing.T) {
+	got := newTestRenderer().Render("see [the docs](https://example.com/docs \"Docs\") or <https://a.io>", 0)
+	if !strings.Contains(got, "\x1b]8;;https://example.com/docs\x07") {
+		t.Errorf("missing OSC-8 link for [the docs]: %q", got)
+	}
+	if !strings.Contains(got, "\x1b]8;;https://a.io\x07") {
+		t.Errorf("missing OSC-8 link for the autolink: %q", got)
+	}
+	if plain := ansi.Strip(got); plain != "see the docs or https://a.io" {
+		t.Errorf("text = %q", plain)
+	}
+}
+
+func TestParseInline(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []span
+	}{
+		{"bold and italic", "**b** and *i*", []span{
+			{text: "b", bold: true}, {text: " and "}, {text: "i", italic: true},
+		}},
+		{"nested", "**bold *both***", []span{
+			{text: "bold ", bold: true}, {text: "both", bold: true, italic: true},
+		}},
+		{"strike", "~~old~~ new", []span{{text: "old", strike: true}, {text: " new"}}},
+		{"code keeps markup", "`a *b*`", []span{{text: "a *b*", code: true}}},
+		{"snake case", "snake_case_name", []span{{text: "snake_case_name"}}},
+		{"spaced stars", "2 * 3 * 4", []span{{text: "2 * 3 * 4"}}},
+		{"escapes", `\*not\* \[x]`, []span{{text: "*not* [x]"}}},
+		{"unclosed", "**open and `tick", []span{{text: "**open and `tick"}}},
+		{"bracket without link", "[👍 2]", []span{{text: "[👍 2]"}}},
+		{"bold link", "[**go**](https://go.dev)", []span{{text: "go", bold: true, url: "https://go.dev"}}},
+		{"bare url", "at https://x.io/a_b).", []span{
+			{text: "at "}, {text: "https://x.io/a_b", url: "https://x.io/a_b"}, {text: ")."},
+		}},
+		{"www url", "www.go.dev", []span{{text: "www.go.dev", url: "https://www.go.dev"}}},
+		{"image", "![diagram](d.png)", []span{{text: "diagram", url: "d.png", image: true}}},
+		{"br tag", "a<br/>b", []span{{text: "a\nb"}}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var got []span
+			parseInline(tc.src, span{}, &got)
+			if len(got) != len(tc.want) {
+				t.Fatalf("parseInline(%q) = %+v, want %+v", tc.src, got, tc.want)
+			}
+			for k := range got {
+				if got[k] != tc.want[k] {
+					t.Errorf("span %d = %+v, want %+v", k, got[k], tc.want[k])
+				}
+			}
+		})
+	}
+}
+
+func TestHyperlink(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		url      string
+		expected string
+	}{
+		{
+			name:     "creates OSC 8 hyperlink",
+			text:     "Click me",
+			url:      "https://example.com",
+			expected: "\x1b]8;;https://example.com\x07Click me\x1b]8;;\x07",
+		},
+		{
+			name:     "falls back to plain text when URL is empty",
+			text:     "Plain text",
+			url:      "",
+			expected: "Plain text",
+		},
+		{
+			name:     "percent-encodes control characters in the URL",
+			text:     "x",
+			url:      "https://e.com/\x07\x1b]0;pwned\x07\x7f",
+			expected: "\x1b]8;;https://e.com/%07%1B]0;pwned%07%7F\x07x\x1b]8;;\x07",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Hyperlink(tt.text, tt.url)
+			if got != tt.expected {
+				t.Errorf("Hyperlink(%q, %q) = %q, want %q", tt.text, tt.url, got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package pullrequests
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/Elpulgo/azdo/internal/localgit"
+	"github.com/Elpulgo/azdo/internal/provider"
+	"github.com/Elpulgo/azdo/internal/ui/components"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// checkoutResultMsg reports the outcome of checking out a pull request in
+// the local git working copy.
+type checkoutResultMsg struct {
+	result localgit.CheckoutResult
+	mode   localgit.CheckoutMode
+	err    error
+}
+
+// SetLocalRepo attaches the git working copy azdo was started in, enabling
+// checking out the pull request. nil disables it.
+func (m *DetailModel) SetLocalRepo(repo *localgit.Repo) {
+	m.localRepo = repo
+}
+
+// checkoutRefs returns the refs to fetch for pr, preferred first: the
+// source branch and then the merge ref for Azure DevOps, the head ref for
+// GitHub (which also covers pull requests from forks).
+func checkoutRefs(pr provider.PullRequest) []string {
+	id := prNumericID(pr)
+	if pr.Identity.Kind == provider.KindGitHub {
+		return []string{fmt.Sprintf("refs/pull/%d/head", id)}
+	}
+	var refs []string
+	if pr.SourceRefName != "" {
+		refs = append(refs, pr.SourceRefName)
+	}
+	return append(refs, fmt.Sprintf("refs/pull/%d/merge", id))
+}
+
+// checkoutPR returns a command that checks the pull request out into the
+// local branch "pr/<id>", either in the working copy or in a new worktree.
+// It sets a status message and returns nil when the working copy does not
+// belong to the pull request's repository.
+func (m *DetailModel) checkoutPR(mode localgit.CheckoutMode) tea.Cmd {
+	if m.localRepo == nil {
+		m.statusMessage = "Cannot check out: azdo was not started inside a git working copy"
+		return nil
+	}
+	remotes, err := m.localRepo.Remotes()
+	if err != nil {
+		m.statusMessage = fmt.Sprintf("Cannot check out: %v", err)
+		return nil
+	}
+	remote, ok := localgit.RemoteFor(remotes, m.pr)
+	if !ok {
+		m.statusMessage = fmt.Sprintf("Cannot check out: no remote of %s points at %s",
+			m.localRepo.Root, m.pr.RepositoryName)
+		return nil
+	}
+
+	req := localgit.CheckoutRequest{
+		Remote: remote.Name,
+		Refs:   checkoutRefs(m.pr),
+		Branch: fmt.Sprintf("pr/%d", prNumericID(m.pr)),
+		Mode:   mode,
+	}
+	repo := m.localRepo
+	m.statusMessage = fmt.Sprintf("Fetching PR #%d from %s...", prNumericID(m.pr), remote.Name)
+	return func() tea.Msg {
+		res, err := repo.Checkout(req)
+		return checkoutResultMsg{result: res, mode: mode, err: err}
+	}
+}
+
+// handleCheckoutResult reports the checkout in the status bar.
+func (m *DetailModel) handleCheckoutResult(msg checkoutResultMsg) {
+	var dirty *localgit.DirtyTreeError
+	switch {
+	case errors.As(msg.err, &dirty):
+		m.statusMessage = fmt.Sprintf("Not checked out: %v", dirty)
+	case msg.err != nil:
+		m.statusMessage = fmt.Sprintf("Checkout failed: %v", msg.err)
+	case msg.mode == localgit.CheckoutWorktree:
+		m.statusMessage = fmt.Sprintf("Checked out %s in worktree %s", msg.result.Branch, filepath.Base(msg.result.Path))
+	default:
+		m.statusMessage = fmt.Sprintf("Checked out %s (%.7s)", msg.result.Branch, msg.result.Commit)
+	}
+}
+
+// checkoutContextItems are the footer hints for checking out the pull
+// request; empty outside a git working copy.
+func (m *DetailModel) checkoutContextItems() []components.ContextItem {
+	if m.localRepo == nil {
+		return nil
+	}
+	return []components.ContextItem{
+		{Key: "b", Description: "check out"},
+		{Key: "B", Description: "check out in worktree"},
+	}
+}
@@ -0,0 +1,61 @@
+package pullrequests
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Elpulgo/azdo/internal/localgit"
+	"github.com/Elpulgo/azdo/internal/provider"
+	"github.com/Elpulgo/azdo/internal/ui/styles"
+)
+
+func TestCheckoutRefs(t *testing.T) {
+	azure := provider.PullRequest{
+		Identity:      provider.Identity{Kind: provider.KindAzure, Scope: "proj", ID: "42"},
+		SourceRefName: "refs/heads/feature/x",
+	}
+	if got, want := checkoutRefs(azure), []string{"refs/heads/feature/x", "refs/pull/42/merge"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("azure refs = %v, want %v", got, want)
+	}
+
+	gh := provider.PullRequest{Identity: provider.Identity{Kind: provider.KindGitHub, Scope: "o/r", ID: "7"}, SourceRefName: "feature"}
+	if got, want := checkoutRefs(gh), []string{"refs/pull/7/head"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("github refs = %v, want %v", got, want)
+	}
+}
+
+func TestDetailModel_CheckoutOutsideWorkingCopy(t *testing.T) {
+	m := NewDetailModelWithStyles(nil, provider.PullRequest{Identity: provider.Identity{ID: "1"}}, styles.DefaultStyles())
+
+	if cmd := m.checkoutPR(localgit.CheckoutBranch); cmd != nil {
+		t.Error("checkout without a working copy should not run")
+	}
+	if !strings.Contains(m.GetStatusMessage(), "not started inside a git working copy") {
+		t.Errorf("status = %q", m.GetStatusMessage())
+	}
+	for _, item := range m.GetContextItems() {
+		if item.Key == "b" {
+			t.Error("check out hint shown outside a working copy")
+		}
+	}
+}
+
+func TestDetailModel_CheckoutResultMessages(t *testing.T) {
+	m := NewDetailModelWithStyles(nil, provider.PullRequest{Identity: provider.Identity{ID: "1"}}, styles.DefaultStyles())
+
+	m.Update(checkoutResultMsg{err: &localgit.DirtyTreeError{Files: []string{"main.go"}}})
+	if got := m.GetStatusMessage(); !strings.HasPrefix(got, "Not checked out: uncommitted changes in main.go") {
+		t.Errorf("dirty status = %q", got)
+	}
+
+	m.Update(checkoutResultMsg{result: localgit.CheckoutResult{Branch: "pr/1", Commit: "0123456789abcdef"}})
+	if got := m.GetStatusMessage(); got != "Checked out pr/1 (0123456)" {
+		t.Errorf("branch status = %q", got)
+	}
+
+	m.Update(checkoutResultMsg{mode: localgit.CheckoutWorktree, result: localgit.CheckoutResult{Branch: "pr/1", Path: "/src/azdo-pr-1"}})
+	if got := m.GetStatusMessage(); got != "Checked out pr/1 in worktree azdo-pr-1" {
+		t.Errorf("worktree status = %q", got)
+	}
+}
@@ -0,0 +1,180 @@
+package pullrequests
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Elpulgo/azdo/internal/provider"
+	"github.com/Elpulgo/azdo/internal/ui/components"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// commentRef identifies a published comment within its thread.
+type commentRef struct {
+	threadID  int
+	commentID int
+}
+
+// commentUnderCursor returns the comment the selected line belongs to: its
+// text line or, for a suggestion, one of the mini-diff lines below it.
+// Unlike reply and resolve, which act on the nearest thread, edits and
+// deletes only act on the line the cursor is on.
+func (m *DiffModel) commentUnderCursor() (commentRef, provider.Comment, bool) {
+	line := m.currentDiffLine()
+	if line == nil || line.ThreadID <= 0 {
+		return commentRef{}, provider.Comment{}, false
+	}
+	if line.Type != diffLineComment && line.Type != diffLineSuggestionOld && line.Type != diffLineSuggestionNew {
+		return commentRef{}, provider.Comment{}, false
+	}
+	thread, ok := m.threadByID(line.ThreadID)
+	if !ok || line.CommentIdx >= len(thread.Comments) {
+		return commentRef{}, provider.Comment{}, false
+	}
+	comment := thread.Comments[line.CommentIdx]
+	ref := commentRef{threadID: line.ThreadID, commentID: parseThreadID(comment.Identity.ID)}
+	if ref.commentID <= 0 {
+		return commentRef{}, provider.Comment{}, false
+	}
+	return ref, comment, true
+}
+
+// startEditComment opens the comment form pre-filled with the text of the
+// comment under the cursor.
+func (m *DiffModel) startEditComment() tea.Cmd {
+	ref, comment, ok := m.commentUnderCursor()
+	if !ok {
+		m.statusMessage = "No comment under the cursor"
+		return nil
+	}
+	m.editTarget = &ref
+	m.commentForm.Reset()
+	m.commentForm.SetValue(comment.Content)
+	m.commentForm.Show()
+	m.SetSize(m.width, m.height)
+	return m.commentForm.Focus()
+}
+
+// handleEditSubmitted saves the edited text of editTarget.
+func (m *DiffModel) handleEditSubmitted(text string) (*DiffModel, tea.Cmd) {
+	ref := *m.editTarget
+	m.editTarget = nil
+	m.SetSize(m.width, m.height)
+	return m, m.editComment(ref, text)
+}
+
+// promptDeleteComment asks for confirmation before deleting the comment
+// under the cursor.
+func (m *DiffModel) promptDeleteComment() {
+	ref, _, ok := m.commentUnderCursor()
+	if !ok {
+		m.statusMessage = "No comment under the cursor"
+		return
+	}
+	m.pendingDelete = &ref
+}
+
+// updateDeletePrompt handles the y/n confirmation for deleting a comment.
+func (m *DiffModel) updateDeletePrompt(msg tea.KeyMsg) (*DiffModel, tea.Cmd) {
+	switch msg.String() {
+	case "y", "Y":
+		ref := *m.pendingDelete
+		m.pendingDelete = nil
+		return m, m.deleteComment(ref)
+	case "n", "N", "esc":
+		m.pendingDelete = nil
+	}
+	return m, nil
+}
+
+// toggleLike likes the comment under the cursor, or removes the like when
+// the user already gave one.
+func (m *DiffModel) toggleLike() tea.Cmd {
+	ref, comment, ok := m.commentUnderCursor()
+	if !ok {
+		m.statusMessage = "No comment under the cursor"
+		return nil
+	}
+	remove := components.HasReacted(comment.Reactions, provider.ReactionLike)
+	return m.reactToComment(ref, provider.ReactionLike, remove)
+}
+
+// openReactionPicker shows the reaction picker for the comment under the
+// cursor.
+func (m *DiffModel) openReactionPicker() {
+	ref, comment, ok := m.commentUnderCursor()
+	if !ok {
+		m.statusMessage = "No comment under the cursor"
+		return
+	}
+	m.reactTarget = ref
+	m.reactionPicker.SetSize(m.width, m.height)
+	m.reactionPicker.Show(comment.Reactions, provider.PRCommentReactions(m.pr.Identity.Kind))
+}
+
+// editComment replaces the text of a comment
+func (m *DiffModel) editComment(ref commentRef, content string) tea.Cmd {
+	return func() tea.Msg {
+		if m.client == nil {
+			return commentResultMsg{err: fmt.Errorf("no client available")}
+		}
+		err := m.client.EditPRComment(m.pr.Identity.Scope, m.pr.RepositoryID, prNumericID(m.pr), ref.threadID, ref.commentID, content)
+		if err != nil {
+			return commentResultMsg{err: err}
+		}
+		return commentResultMsg{message: "Comment updated"}
+	}
+}
+
+// deleteComment deletes a comment
+func (m *DiffModel) deleteComment(ref commentRef) tea.Cmd {
+	return func() tea.Msg {
+		if m.client == nil {
+			return commentResultMsg{err: fmt.Errorf("no client available")}
+		}
+		err := m.client.DeletePRComment(m.pr.Identity.Scope, m.pr.RepositoryID, prNumericID(m.pr), ref.threadID, ref.commentID)
+		if err != nil {
+			return commentResultMsg{err: err}
+		}
+		return commentResultMsg{message: "Comment deleted"}
+	}
+}
+
+// reactToComment adds or removes the user's reaction on a comment
+func (m *DiffModel) reactToComment(ref commentRef, reaction provider.Reaction, remove bool) tea.Cmd {
+	return func() tea.Msg {
+		if m.client == nil {
+			return commentResultMsg{err: fmt.Errorf("no client available")}
+		}
+		err := m.client.ReactToPRComment(m.pr.Identity.Scope, m.pr.RepositoryID, prNumericID(m.pr), ref.threadID, ref.commentID, reaction, remove)
+		if errors.Is(err, provider.ErrReactionUnsupported) {
+			return commentResultMsg{err: fmt.Errorf("%s reactions are not supported on pull request comments here", components.ReactionIcon(reaction))}
+		}
+		if err != nil {
+			return commentResultMsg{err: err}
+		}
+		if remove {
+			return commentResultMsg{message: "Reaction removed"}
+		}
+		return commentResultMsg{message: "Reaction added"}
+	}
+}
+
+// withReactions appends the reaction summary to a comment's text.
+func withReactions(content string, reactions []provider.ReactionCount) string {
+	if summary := components.FormatReactions(reactions); summary != "" {
+		return content + "  " + summary
+	}
+	return content
+}
+
+// commentActionContextItems are the footer hints for acting on the comment
+// under the cursor.
+func commentActionContextItems() []components.ContextItem {
+	return []components.ContextItem{
+		{Key: "e", Description: "edit comment"},
+		{Key: "D", Description: "delete comment"},
+		{Key: "+", Description: "like"},
+		{Key: "R", Description: "react"},
+	}
+}
@@ -0,0 +1,199 @@
+package pullrequests
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Elpulgo/azdo/internal/provider"
+	"github.com/Elpulgo/azdo/internal/ui/components"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// commentActionProvider records comment edits, deletes and reactions; every
+// other method panics via the nil embedded interface.
+type commentActionProvider struct {
+	provider.Provider
+	calls []string
+	err   error
+}
+
+func (p *commentActionProvider) EditPRComment(scope, repositoryID string, pullRequestID, threadID, commentID int, content string) error {
+	p.calls = append(p.calls, fmt.Sprintf("edit %d/%d %s", threadID, commentID, content))
+	return p.err
+}
+
+func (p *commentActionProvider) DeletePRComment(scope, repositoryID string, pullRequestID, threadID, commentID int) error {
+	p.calls = append(p.calls, fmt.Sprintf("delete %d/%d", threadID, commentID))
+	return p.err
+}
+
+func (p *commentActionProvider) ReactToPRComment(scope, repositoryID string, pullRequestID, threadID, commentID int, reaction provider.Reaction, remove bool) error {
+	verb := "react"
+	if remove {
+		verb = "unreact"
+	}
+	p.calls = append(p.calls, fmt.Sprintf("%s %d/%d %s", verb, threadID, commentID, reaction))
+	return p.err
+}
+
+// withCommentThread attaches thread 7 on new line 2 (added "B"): a comment
+// liked by the user, a deleted reply and a live reply. The thread's lines
+// follow diff line 3.
+func withCommentThread(m *DiffModel) {
+	m.threads = []provider.Thread{{
+		Identity: provider.Identity{ID: "7"},
+		Status:   "active",
+		FilePath: "/src/main.go",
+		Line:     2,
+		Range:    provider.LineAt(provider.SideRight, 2),
+		Comments: []provider.Comment{
+			{Identity: provider.Identity{ID: "1"}, AuthorName: "Ann", Content: "rename this",
+				Reactions: []provider.ReactionCount{{Reaction: provider.ReactionLike, Count: 2, Mine: true}}},
+			{Identity: provider.Identity{ID: "2"}, AuthorName: "Bob", Content: "gone", IsDeleted: true},
+			{Identity: provider.Identity{ID: "3"}, AuthorName: "Bob", Content: "done"},
+		},
+	}}
+	m.rebuildFileDiff()
+}
+
+// runCmd executes cmd and feeds its message back into the model.
+func runCmd(m *DiffModel, cmd tea.Cmd) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+	_, next := m.Update(cmd())
+	return next
+}
+
+func TestDiffModel_CommentLines_ShowReactionsAndSkipDeleted(t *testing.T) {
+	m := newSelectionTestModel()
+	withCommentThread(m)
+
+	var got []string
+	for _, dl := range m.diffLines {
+		if dl.ThreadID == 7 {
+			got = append(got, dl.Content)
+		}
+	}
+	if len(got) != 2 {
+		t.Fatalf("thread lines = %q, want the two live comments", got)
+	}
+	if !strings.HasSuffix(got[0], "rename this  [👍 2]") {
+		t.Errorf("first comment = %q, want the reaction summary", got[0])
+	}
+	if strings.Contains(strings.Join(got, "\n"), "gone") {
+		t.Error("deleted comment is still shown")
+	}
+}
+
+func TestDiffModel_EditComment_PrefillsAndSaves(t *testing.T) {
+	m := newSelectionTestModel()
+	client := &commentActionProvider{}
+	m.client = client
+	withCommentThread(m)
+	m.selectedLine = 5 // reply "done"
+
+	m.Update(keyRunes("e"))
+	if !m.commentForm.IsVisible() || m.commentForm.Value() != "done" {
+		t.Fatalf("edit form visible=%v value=%q", m.commentForm.IsVisible(), m.commentForm.Value())
+	}
+	m.commentForm.SetValue("done in abc123")
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if cmd = runCmd(m, cmd); cmd == nil {
+		t.Fatal("saving should edit the comment")
+	}
+	cmd()
+	if len(client.calls) != 1 || client.calls[0] != "edit 7/3 done in abc123" {
+		t.Errorf("calls = %q", client.calls)
+	}
+	if m.editTarget != nil {
+		t.Error("edit target not cleared after save")
+	}
+}
+
+func TestDiffModel_EditComment_RequiresCommentUnderCursor(t *testing.T) {
+	m := newSelectionTestModel()
+	withCommentThread(m)
+	m.selectedLine = 1 // code line "a"
+
+	m.Update(keyRunes("e"))
+	if m.commentForm.IsVisible() {
+		t.Error("edit form opened on a code line")
+	}
+	if m.GetStatusMessage() != "No comment under the cursor" {
+		t.Errorf("status = %q", m.GetStatusMessage())
+	}
+}
+
+func TestDiffModel_DeleteComment_AsksFirst(t *testing.T) {
+	m := newSelectionTestModel()
+	client := &commentActionProvider{}
+	m.client = client
+	withCommentThread(m)
+	m.selectedLine = 4 // first comment
+
+	m.Update(keyRunes("D"))
+	if m.pendingDelete == nil || !m.IsInputActive() {
+		t.Fatal("D should ask for confirmation")
+	}
+	if view := m.View(); !strings.Contains(view, "Delete this comment? (y/n)") {
+		t.Errorf("view missing confirmation:\n%s", view)
+	}
+	if _, cmd := m.Update(keyRunes("n")); cmd != nil || m.pendingDelete != nil {
+		t.Fatal("n should cancel without deleting")
+	}
+
+	m.Update(keyRunes("D"))
+	_, cmd := m.Update(keyRunes("y"))
+	if cmd == nil {
+		t.Fatal("y should delete the comment")
+	}
+	if msg, ok := cmd().(commentResultMsg); !ok || msg.message != "Comment deleted" {
+		t.Errorf("result = %+v", msg)
+	}
+	if len(client.calls) != 1 || client.calls[0] != "delete 7/1" {
+		t.Errorf("calls = %q", client.calls)
+	}
+}
+
+func TestDiffModel_ToggleLike_RemovesOwnLike(t *testing.T) {
+	m := newSelectionTestModel()
+	client := &commentActionProvider{}
+	m.client = client
+	withCommentThread(m)
+
+	m.selectedLine = 4 // liked by the user
+	_, cmd := m.Update(keyRunes("+"))
+	cmd()
+	m.selectedLine = 5 // not liked yet
+	_, cmd = m.Update(keyRunes("+"))
+	cmd()
+
+	want := []string{"unreact 7/1 +1", "react 7/3 +1"}
+	if strings.Join(client.calls, "|") != strings.Join(want, "|") {
+		t.Errorf("calls = %q, want %q", client.calls, want)
+	}
+}
+
+func TestDiffModel_ReactionPicker_ReactsToTarget(t *testing.T) {
+	m := newSelectionTestModel()
+	client := &commentActionProvider{err: provider.ErrReactionUnsupported}
+	m.client = client
+	withCommentThread(m)
+	m.selectedLine = 5
+
+	m.Update(keyRunes("R"))
+	if !m.reactionPicker.IsVisible() {
+		t.Fatal("R should open the reaction picker")
+	}
+	_, cmd := m.Update(components.ReactionSelectedMsg{Reaction: provider.ReactionRocket})
+	msg, ok := cmd().(commentResultMsg)
+	if !ok || msg.err == nil || !strings.Contains(msg.err.Error(), "not supported") {
+		t.Errorf("result = %+v, want an unsupported-reaction error", msg)
+	}
+	if len(client.calls) != 1 || client.calls[0] != "react 7/3 rocket" {
+		t.Errorf("calls = %q", client.calls)
+	}
+}
@@ -0,0 +1,206 @@
+package pullrequests
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Elpulgo/azdo/internal/diff"
+	"github.com/Elpulgo/azdo/internal/provider"
+	"github.com/Elpulgo/azdo/internal/ui/components"
+	"github.com/Elpulgo/azdo/internal/ui/styles"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// commitMarker prefixes the commits in the detail view.
+const commitMarker = "● "
+
+// commitsMsg carries the commits of the PR shown in the detail view.
+type commitsMsg struct {
+	commits []provider.Commit
+	err     error
+}
+
+// openCommitDiffMsg signals that the user wants to review a single commit.
+type openCommitDiffMsg struct {
+	commit provider.Commit
+}
+
+// shortCommitID abbreviates a commit SHA the way git does.
+func shortCommitID(id string) string {
+	return truncateString(id, 7)
+}
+
+// commitSubject returns the first line of a commit message.
+func commitSubject(message string) string {
+	subject, _, _ := strings.Cut(strings.TrimSpace(message), "\n")
+	return strings.TrimSpace(subject)
+}
+
+// fetchCommits returns a command that fetches the commits of the PR.
+func (m *DetailModel) fetchCommits() tea.Cmd {
+	if m.client == nil {
+		return nil
+	}
+	client, pr := m.client, m.pr
+	return func() tea.Msg {
+		commits, err := client.GetPRCommits(pr.Identity.Scope, pr.RepositoryID, prNumericID(pr))
+		return commitsMsg{commits: commits, err: err}
+	}
+}
+
+// handleCommits records the fetched commits. Like mergeability, a failure
+// is only reported in the status bar.
+func (m *DetailModel) handleCommits(msg commitsMsg) {
+	if msg.err != nil {
+		m.statusMessage = fmt.Sprintf("Error loading commits: %v", msg.err)
+		return
+	}
+	m.commits = msg.commits
+	if m.fileIndex >= m.totalSelectableItems() {
+		m.fileIndex = 0
+	}
+	if m.ready {
+		m.updateViewportContent()
+	}
+}
+
+// commitsOffset returns the selection index of the first commit; the
+// commits follow the merge conflicts.
+func (m *DetailModel) commitsOffset() int {
+	return m.conflictsOffset() + len(m.conflicts)
+}
+
+// selectedCommit returns the selected commit, or nil.
+func (m *DetailModel) selectedCommit() *provider.Commit {
+	ci := m.fileIndex - m.commitsOffset()
+	if ci < 0 || ci >= len(m.commits) {
+		return nil
+	}
+	return &m.commits[ci]
+}
+
+// renderCommits renders the commits section; "" before any are loaded.
+func (m *DetailModel) renderCommits() string {
+	if len(m.commits) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString("\n")
+	sb.WriteString(m.styles.Label.Render(fmt.Sprintf("Commits (%d)", len(m.commits))))
+	sb.WriteString("\n")
+	for i, commit := range m.commits {
+		sb.WriteString(renderCommitEntry(commit, i+m.commitsOffset() == m.fileIndex, m.styles))
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// renderCommitEntry renders a commit as its short ID, subject, author and
+// date.
+func renderCommitEntry(commit provider.Commit, selected bool, s *styles.Styles) string {
+	meta := commit.AuthorName
+	if !commit.Date.IsZero() {
+		meta += ", " + commit.Date.Format("2006-01-02 15:04")
+	}
+	line := fmt.Sprintf("  %s%s %s", commitMarker, shortCommitID(commit.ID), commitSubject(commit.Message))
+	if selected {
+		return s.Selected.Render(line + " · " + meta)
+	}
+	return line + " " + s.Muted.Render("· "+meta)
+}
+
+// loadCommitChanges loads the changes of a single commit of pr, relative
+// to its first parent.
+func loadCommitChanges(client provider.Provider, pr provider.PullRequest, commitID string) changedFilesMsg {
+	changes, err := client.GetCommitChanges(pr.Identity.Scope, pr.RepositoryID, commitID)
+	if err != nil {
+		return changedFilesMsg{err: err}
+	}
+	return changedFilesMsg{changes: changes.Changes, parent: changes.ParentID}
+}
+
+// SetCommit scopes the diff view to a single commit of the PR: the file
+// list and diffs show only that commit's changes against its parent. Inline
+// PR comments are hidden, as their lines refer to the whole PR's diff, and
+// commenting is limited to general comments.
+func (m *DiffModel) SetCommit(commit provider.Commit) {
+	m.commit = &commit
+	m.spinner.SetMessage(fmt.Sprintf("Loading commit %s...", shortCommitID(commit.ID)))
+}
+
+// contentReaders returns the functions reading a file on the old and new
+// side of the diff: the PR's target and source branches, or the commit's
+// parent and the commit itself.
+func (m *DiffModel) contentReaders() (readOld, readNew func(path string) (string, error)) {
+	scope, repoID := m.pr.Identity.Scope, m.pr.RepositoryID
+	if m.commit != nil {
+		parent, commitID := m.commitParent, m.commit.ID
+		readOld = func(path string) (string, error) {
+			if parent == "" {
+				return "", nil // root commit: every file is new
+			}
+			return m.client.GetFileContentAtCommit(scope, repoID, path, parent)
+		}
+		readNew = func(path string) (string, error) {
+			return m.client.GetFileContentAtCommit(scope, repoID, path, commitID)
+		}
+		return readOld, readNew
+	}
+	targetBranch := branchShortName(m.pr.TargetRefName)
+	sourceBranch := branchShortName(m.pr.SourceRefName)
+	readOld = func(path string) (string, error) {
+		return m.client.GetFileContent(scope, repoID, path, targetBranch)
+	}
+	readNew = func(path string) (string, error) {
+		return m.client.GetFileContent(scope, repoID, path, sourceBranch)
+	}
+	return readOld, readNew
+}
+
+// inlineThreads maps the PR's threads on path to the new and old file
+// lines. A commit-scoped view has none.
+func (m *DiffModel) inlineThreads(path string) (right, left map[int][]provider.Thread) {
+	if m.commit != nil {
+		return map[int][]provider.Thread{}, map[int][]provider.Thread{}
+	}
+	return diff.MapThreadsToLinesP(m.threads, path), diff.MapLeftThreadsToLinesP(m.threads, path)
+}
+
+// blockedInCommit reports whether key starts a line comment, suggestion,
+// review or viewed mark, none of which apply to a single commit, and says
+// so in the status bar.
+func (m *DiffModel) blockedInCommit(key string) bool {
+	if m.commit == nil || m.viewingGeneralComments {
+		return false
+	}
+	switch key {
+	case "c", "v", "S", "A", "s":
+		m.statusMessage = "Commit diffs are read-only; comment on the full PR diff"
+		return true
+	}
+	return false
+}
+
+// commitHeader renders the file list header of a commit-scoped view.
+func (m *DiffModel) commitHeader() string {
+	header := m.styles.Header.Render(fmt.Sprintf("Commit %s (%d files)", shortCommitID(m.commit.ID), len(m.changedFiles)))
+	if subject := commitSubject(m.commit.Message); subject != "" {
+		header += m.styles.Muted.Render(" · " + subject)
+	}
+	return header
+}
+
+// commitContextItems returns the context items of a commit-scoped view.
+func commitContextItems(mode DiffViewMode) []components.ContextItem {
+	if mode == DiffFileList {
+		return []components.ContextItem{
+			{Key: "pgup/pgdn", Description: "page"},
+			{Key: "enter", Description: "open"},
+			{Key: "r", Description: "refresh"},
+		}
+	}
+	return []components.ContextItem{
+		{Key: "↑/↓", Description: "scroll"},
+		{Key: "esc", Description: "back"},
+	}
+}
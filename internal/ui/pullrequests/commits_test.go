@@ -0,0 +1,114 @@
+package pullrequests
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Elpulgo/azdo/internal/provider"
+	"github.com/Elpulgo/azdo/internal/ui/styles"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// commitProvider serves a PR's commits and one commit's changes, recording
+// the commits file content is read at.
+type commitProvider struct {
+	provider.Provider
+	commits []provider.Commit
+	changes *provider.CommitChanges
+	readAt  []string
+}
+
+func (p *commitProvider) GetPRCommits(scope, repositoryID string, pullRequestID int) ([]provider.Commit, error) {
+	return p.commits, nil
+}
+
+func (p *commitProvider) GetCommitChanges(scope, repositoryID, commitID string) (*provider.CommitChanges, error) {
+	return p.changes, nil
+}
+
+func (p *commitProvider) GetFileContentAtCommit(scope, repositoryID, filePath, commitID string) (string, error) {
+	p.readAt = append(p.readAt, commitID)
+	return "line from " + commitID + "\n", nil
+}
+
+func (p *commitProvider) PRURL(scope, repositoryID string, pullRequestID int) string {
+	return ""
+}
+
+func TestDetail_CommitsFollowFilesAndOpenOnEnter(t *testing.T) {
+	client := &commitProvider{commits: []provider.Commit{
+		{ID: "aaaaaaa111", Message: "First change\n\nBody", AuthorName: "Ann", Date: time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)},
+		{ID: "bbbbbbb222", Message: "Second change", AuthorName: "Bob"},
+	}}
+	m := NewDetailModel(client, conflictTestPR())
+	m.SetSize(100, 40)
+	m.SetChangedFiles([]provider.IterationChange{{Path: "/a.go", ChangeType: "edit"}})
+
+	m, _ = m.Update(m.fetchCommits()())
+
+	content := m.viewport.View()
+	for _, want := range []string{"Commits (2)", "aaaaaaa First change", "Ann, 2024-03-01 10:00", "bbbbbbb Second change"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("detail content missing %q:\n%s", want, content)
+		}
+	}
+	if strings.Contains(content, "Body") {
+		t.Error("only the commit subject should be shown")
+	}
+
+	m.MoveDown()
+	m.MoveDown()
+	if c := m.selectedCommit(); c == nil || c.ID != "bbbbbbb222" {
+		t.Fatalf("selectedCommit() = %v, want the second commit", c)
+	}
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("enter on a commit should open it")
+	}
+	if msg, ok := cmd().(openCommitDiffMsg); !ok || msg.commit.ID != "bbbbbbb222" {
+		t.Errorf("enter emitted %#v, want openCommitDiffMsg for the second commit", msg)
+	}
+}
+
+func TestDiffModel_CommitDiffsAgainstParent(t *testing.T) {
+	client := &commitProvider{changes: &provider.CommitChanges{
+		ParentID: "parent1",
+		Changes:  []provider.IterationChange{{Path: "/a.go", ChangeType: "edit", GitObjectType: "blob"}},
+	}}
+	threads := []provider.Thread{{
+		Identity: provider.Identity{ID: "1"},
+		Status:   "active",
+		FilePath: "/a.go",
+		Line:     1,
+		Comments: []provider.Comment{{Identity: provider.Identity{ID: "1"}, Content: "PR comment"}},
+	}}
+	m := NewDiffModel(client, conflictTestPR(), threads, styles.DefaultStyles())
+	m.SetCommit(provider.Commit{ID: "commit1234", Message: "Tweak a"})
+	m.SetSize(100, 30)
+
+	m, _ = m.Update(m.fetchChangedFiles()())
+	if !strings.Contains(m.View(), "Commit commit1 (1 files) · Tweak a") {
+		t.Errorf("file list should name the commit:\n%s", m.View())
+	}
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("enter on a file should load its diff")
+	}
+	m, _ = m.Update(cmd().(tea.BatchMsg)[0]())
+
+	if got := strings.Join(client.readAt, ","); got != "parent1,commit1234" {
+		t.Errorf("content read at %q, want the parent then the commit", got)
+	}
+	view := m.View()
+	if !strings.Contains(view, "line from commit1234") || strings.Contains(view, "PR comment") {
+		t.Errorf("commit diff should show the commit's lines without PR comments:\n%s", view)
+	}
+
+	m, cmd = m.Update(keyRunes("c"))
+	if cmd != nil || m.IsInputActive() || !strings.Contains(m.GetStatusMessage(), "read-only") {
+		t.Errorf("c should not start a comment on a commit diff (status %q)", m.GetStatusMessage())
+	}
+}
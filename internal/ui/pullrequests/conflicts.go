@@ -0,0 +1,334 @@
+package pullrequests
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Elpulgo/azdo/internal/provider"
+	"github.com/Elpulgo/azdo/internal/ui/components"
+	"github.com/Elpulgo/azdo/internal/ui/styles"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// conflictMarker prefixes the title of pull requests with merge conflicts
+// and the conflicting files in the detail view.
+const conflictMarker = "⚠ "
+
+// conflictSeparator separates the columns of the three-way view.
+const conflictSeparator = " │ "
+
+// titleCell renders pr's title for the list, marking merge conflicts.
+func titleCell(pr provider.PullRequest, s *styles.Styles) string {
+	if pr.MergeStatus == provider.MergeStatusConflicts {
+		return s.Error.Render(conflictMarker) + pr.Title
+	}
+	return pr.Title
+}
+
+// mergeStatusDisplay renders status for the detail view; "" when unknown.
+// conflicts is the number of conflicting files, 0 when not listed.
+func mergeStatusDisplay(status provider.MergeStatus, conflicts int, s *styles.Styles) string {
+	switch status {
+	case provider.MergeStatusMergeable:
+		return s.Success.Render("✓ No conflicts")
+	case provider.MergeStatusConflicts:
+		if conflicts > 0 {
+			return s.Error.Render(fmt.Sprintf("%sConflicts in %d file(s)", conflictMarker, conflicts))
+		}
+		return s.Error.Render(conflictMarker + "Conflicts")
+	case provider.MergeStatusBlocked:
+		return s.Warning.Render("● Blocked by policy")
+	case provider.MergeStatusBehind:
+		return s.Warning.Render("↓ Behind the target branch")
+	case provider.MergeStatusFailed:
+		return s.Error.Render("✗ Merge check failed")
+	default:
+		return ""
+	}
+}
+
+// mergeabilityMsg carries the freshly fetched mergeability of the PR.
+type mergeabilityMsg struct {
+	mergeability *provider.Mergeability
+	err          error
+}
+
+// fetchMergeability returns a command that fetches whether the PR can be
+// merged. Only active PRs are checked; the others keep the list's status.
+func (m *DetailModel) fetchMergeability() tea.Cmd {
+	if m.client == nil || m.pr.StatusCategory != provider.StateCategoryActive {
+		return nil
+	}
+	client, pr := m.client, m.pr
+	return func() tea.Msg {
+		mergeability, err := client.GetPRMergeability(pr.Identity.Scope, pr.RepositoryID, prNumericID(pr))
+		return mergeabilityMsg{mergeability: mergeability, err: err}
+	}
+}
+
+// handleMergeability records the fetched mergeability. A failure keeps the
+// status from the list and is only reported in the status bar, as the rest
+// of the detail view is still usable.
+func (m *DetailModel) handleMergeability(msg mergeabilityMsg) {
+	if msg.err != nil {
+		m.statusMessage = fmt.Sprintf("Error checking mergeability: %v", msg.err)
+		return
+	}
+	if msg.mergeability == nil {
+		return
+	}
+	m.pr.MergeStatus = msg.mergeability.Status
+	m.conflicts = msg.mergeability.Conflicts
+	if m.fileIndex >= m.totalSelectableItems() {
+		m.fileIndex = 0
+	}
+	if m.ready {
+		m.updateViewportContent()
+	}
+}
+
+// conflictsOffset returns the selection index of the first conflict; the
+// conflicts follow the general comments entry and the changed files.
+func (m *DetailModel) conflictsOffset() int {
+	return m.generalCommentsOffset() + len(m.changedFiles)
+}
+
+// selectedConflict returns the selected conflicting file, or nil.
+func (m *DetailModel) selectedConflict() *provider.MergeConflict {
+	ci := m.fileIndex - m.conflictsOffset()
+	if ci < 0 || ci >= len(m.conflicts) {
+		return nil
+	}
+	return &m.conflicts[ci]
+}
+
+// renderConflicts renders the merge conflicts section; "" without conflicts.
+func (m *DetailModel) renderConflicts() string {
+	if len(m.conflicts) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString("\n")
+	sb.WriteString(m.styles.Label.Render(fmt.Sprintf("Merge conflicts (%d)", len(m.conflicts))))
+	sb.WriteString("\n")
+	for i, conflict := range m.conflicts {
+		line := fmt.Sprintf("  %s%s", conflictMarker, conflict.Path)
+		if conflict.Kind != "" {
+			line += " " + m.styles.Muted.Render("("+conflict.Kind+")")
+		}
+		if i+m.conflictsOffset() == m.fileIndex {
+			sb.WriteString(m.styles.Selected.Render(line))
+		} else {
+			sb.WriteString(m.styles.Error.Render(line))
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// openConflict opens the three-way view of the selected conflict and
+// returns the command loading its content.
+func (m *DetailModel) openConflict() tea.Cmd {
+	conflict := m.selectedConflict()
+	if conflict == nil || m.client == nil {
+		return nil
+	}
+	m.conflictView = newConflictView(*conflict, m.pr, m.styles)
+	m.conflictView.SetSize(m.width, m.height)
+	return loadConflictContent(m.client, m.pr, *conflict)
+}
+
+// conflictSide is one side of a merge conflict.
+type conflictSide int
+
+const (
+	sideBase conflictSide = iota
+	sideOurs
+	sideTheirs
+)
+
+// conflictContent is the content of a conflicting file on each side of
+// the merge. A side whose content could not be loaded, e.g. because the
+// file is deleted there, has an error instead.
+type conflictContent struct {
+	text [3]string
+	errs [3]error
+}
+
+// conflictContentMsg carries the loaded content of a conflict.
+type conflictContentMsg struct {
+	path    string
+	content conflictContent
+}
+
+// loadConflictContent returns a command fetching the three sides of
+// conflict. Ours is the target branch and theirs the source branch, as in
+// git when merging the source into the target.
+func loadConflictContent(client provider.Provider, pr provider.PullRequest, conflict provider.MergeConflict) tea.Cmd {
+	commits := [3]string{conflict.BaseCommit, conflict.TargetCommit, conflict.SourceCommit}
+	return func() tea.Msg {
+		var content conflictContent
+		for side, commit := range commits {
+			if commit == "" {
+				content.errs[side] = fmt.Errorf("not present")
+				continue
+			}
+			content.text[side], content.errs[side] = client.GetFileContentAtCommit(
+				pr.Identity.Scope, pr.RepositoryID, conflict.Path, commit)
+		}
+		return conflictContentMsg{path: conflict.Path, content: content}
+	}
+}
+
+// conflictView is a read-only three-way view of a conflicting file, with
+// the base, ours and theirs content side by side. Lines of ours and theirs
+// that differ from the base line at the same position are highlighted.
+type conflictView struct {
+	conflict provider.MergeConflict
+	pr       provider.PullRequest
+	content  *conflictContent // nil while loading
+	viewport viewport.Model
+	width    int
+	height   int
+	styles   *styles.Styles
+}
+
+func newConflictView(conflict provider.MergeConflict, pr provider.PullRequest, s *styles.Styles) *conflictView {
+	return &conflictView{conflict: conflict, pr: pr, styles: s, viewport: viewport.New(0, 0)}
+}
+
+// SetSize sizes the view; the title, column headers and separator take
+// three lines.
+func (v *conflictView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+	v.viewport.Width = width
+	v.viewport.Height = max(height-3, 1)
+	v.updateContent()
+}
+
+// SetContent shows the loaded content of the conflict.
+func (v *conflictView) SetContent(content conflictContent) {
+	v.content = &content
+	v.viewport.GotoTop()
+	v.updateContent()
+}
+
+// Update scrolls the view.
+func (v *conflictView) Update(msg tea.KeyMsg) {
+	switch msg.String() {
+	case "up", "k":
+		v.viewport.LineUp(1)
+	case "down", "j":
+		v.viewport.LineDown(1)
+	case "pgup":
+		v.viewport.HalfViewUp()
+	case "pgdown":
+		v.viewport.HalfViewDown()
+	case "home", "g":
+		v.viewport.GotoTop()
+	case "end", "G":
+		v.viewport.GotoBottom()
+	}
+}
+
+// columnWidth is the width of each of the three columns.
+func (v *conflictView) columnWidth() int {
+	return max((v.width-2*lipgloss.Width(conflictSeparator))/3, 1)
+}
+
+// columnTitles names the sides, with the branches for ours and theirs.
+func (v *conflictView) columnTitles() [3]string {
+	return [3]string{
+		"base",
+		"ours · " + branchShortName(v.pr.TargetRefName),
+		"theirs · " + branchShortName(v.pr.SourceRefName),
+	}
+}
+
+func (v *conflictView) updateContent() {
+	if v.content == nil {
+		v.viewport.SetContent(v.styles.Muted.Render("Loading conflict..."))
+		return
+	}
+
+	var sides [3][]string
+	rows := 0
+	for side := range sides {
+		if err := v.content.errs[side]; err != nil {
+			sides[side] = []string{fmt.Sprintf("(%v)", err)}
+		} else {
+			sides[side] = strings.Split(strings.TrimSuffix(v.content.text[side], "\n"), "\n")
+		}
+		rows = max(rows, len(sides[side]))
+	}
+
+	width := v.columnWidth()
+	var sb strings.Builder
+	for row := 0; row < rows; row++ {
+		base := lineAt(sides[sideBase], row)
+		cells := make([]string, 3)
+		for side := range sides {
+			line := lineAt(sides[side], row)
+			cell := fitColumn(line, width)
+			switch {
+			case v.content.errs[side] != nil:
+				cell = v.styles.Muted.Render(cell)
+			case conflictSide(side) != sideBase && v.content.errs[sideBase] == nil && line != base:
+				cell = v.styles.Warning.Render(cell)
+			}
+			cells[side] = cell
+		}
+		sb.WriteString(strings.Join(cells, v.styles.Muted.Render(conflictSeparator)))
+		sb.WriteString("\n")
+	}
+	v.viewport.SetContent(sb.String())
+}
+
+// View renders the title, the column headers and the scrollable columns.
+func (v *conflictView) View() string {
+	var sb strings.Builder
+	title := "Conflict: " + v.conflict.Path
+	if v.conflict.Kind != "" {
+		title += " (" + v.conflict.Kind + ")"
+	}
+	sb.WriteString(v.styles.Header.Render(title))
+	sb.WriteString("\n")
+
+	titles := v.columnTitles()
+	headers := make([]string, 3)
+	for i, t := range titles {
+		headers[i] = v.styles.Label.Render(fitColumn(t, v.columnWidth()))
+	}
+	sb.WriteString(strings.Join(headers, v.styles.Muted.Render(conflictSeparator)))
+	sb.WriteString("\n")
+	sb.WriteString(strings.Repeat("─", max(v.width, 1)))
+	sb.WriteString("\n")
+	sb.WriteString(v.viewport.View())
+	return sb.String()
+}
+
+// contextItems are the footer hints of the three-way view.
+func (v *conflictView) contextItems() []components.ContextItem {
+	return []components.ContextItem{
+		{Key: "↑↓", Description: "scroll"},
+		{Key: "esc", Description: "back"},
+	}
+}
+
+// lineAt returns line i of lines, or "" past the end.
+func lineAt(lines []string, i int) string {
+	if i < len(lines) {
+		return lines[i]
+	}
+	return ""
+}
+
+// fitColumn truncates or pads s to exactly width cells, expanding tabs.
+func fitColumn(s string, width int) string {
+	s = strings.ReplaceAll(s, "\t", "    ")
+	s = truncateString(s, width)
+	return s + strings.Repeat(" ", max(width-lipgloss.Width(s), 0))
+}
@@ -0,0 +1,148 @@
+package pullrequests
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Elpulgo/azdo/internal/provider"
+	"github.com/Elpulgo/azdo/internal/ui/styles"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// conflictProvider serves mergeability and file content per commit.
+type conflictProvider struct {
+	provider.Provider
+	mergeability *provider.Mergeability
+	content      map[string]string // commit -> content
+}
+
+func (p *conflictProvider) GetPRMergeability(scope, repositoryID string, pullRequestID int) (*provider.Mergeability, error) {
+	return p.mergeability, nil
+}
+
+func (p *conflictProvider) GetFileContentAtCommit(scope, repositoryID, filePath, commitID string) (string, error) {
+	content, ok := p.content[commitID]
+	if !ok {
+		return "", errors.New("file not found")
+	}
+	return content, nil
+}
+
+func (p *conflictProvider) PRURL(scope, repositoryID string, pullRequestID int) string {
+	return "https://example.com/pr/101"
+}
+
+func conflictTestPR() provider.PullRequest {
+	return provider.PullRequest{
+		Identity:       provider.Identity{Kind: provider.KindAzure, Scope: "proj", ID: "101"},
+		RepositoryID:   "repo-123",
+		StatusCategory: provider.StateCategoryActive,
+		SourceRefName:  "refs/heads/feature",
+		TargetRefName:  "refs/heads/main",
+	}
+}
+
+func TestTitleCell_MarksConflicts(t *testing.T) {
+	s := styles.DefaultStyles()
+	pr := provider.PullRequest{Title: "Fix it", MergeStatus: provider.MergeStatusConflicts}
+	if got := titleCell(pr, s); !strings.Contains(got, conflictMarker) || !strings.HasSuffix(got, "Fix it") {
+		t.Errorf("titleCell() = %q, want the conflict marker before the title", got)
+	}
+	pr.MergeStatus = provider.MergeStatusMergeable
+	if got := titleCell(pr, s); got != "Fix it" {
+		t.Errorf("titleCell() = %q, want the bare title", got)
+	}
+}
+
+func TestDetail_MergeabilityListsConflictsAfterFiles(t *testing.T) {
+	client := &conflictProvider{mergeability: &provider.Mergeability{
+		Status:    provider.MergeStatusConflicts,
+		Conflicts: []provider.MergeConflict{{Path: "/a.go", Kind: "editEdit"}},
+	}}
+	m := NewDetailModel(client, conflictTestPR())
+	m.SetSize(100, 40)
+	m.SetChangedFiles([]provider.IterationChange{{Path: "/b.go", ChangeType: "edit"}})
+
+	m, _ = m.Update(m.fetchMergeability()())
+
+	content := m.viewport.View()
+	for _, want := range []string{"Conflicts in 1 file(s)", "Merge conflicts (1)", "/a.go"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("detail content missing %q:\n%s", want, content)
+		}
+	}
+	if m.totalSelectableItems() != 2 {
+		t.Fatalf("totalSelectableItems() = %d, want the file and the conflict", m.totalSelectableItems())
+	}
+	m.MoveDown()
+	if c := m.selectedConflict(); c == nil || c.Path != "/a.go" {
+		t.Fatalf("selectedConflict() = %v, want /a.go", c)
+	}
+	lines := strings.Split(content, "\n")
+	if got := m.getSelectedItemLineOffset(); got >= len(lines) || !strings.Contains(lines[got], "/a.go") {
+		t.Errorf("getSelectedItemLineOffset() = %d, which is not the conflict's line", got)
+	}
+}
+
+func TestDetail_InactivePRSkipsMergeability(t *testing.T) {
+	pr := conflictTestPR()
+	pr.StatusCategory = provider.StateCategoryClosedDone
+	m := NewDetailModel(&conflictProvider{}, pr)
+	if cmd := m.fetchMergeability(); cmd != nil {
+		t.Error("fetchMergeability() for a completed PR should not fetch")
+	}
+}
+
+func TestDetail_ConflictViewShowsThreeSides(t *testing.T) {
+	conflict := provider.MergeConflict{
+		Path: "/a.go", Kind: "editEdit",
+		BaseCommit: "base", TargetCommit: "tgt", SourceCommit: "src",
+	}
+	client := &conflictProvider{
+		mergeability: &provider.Mergeability{Status: provider.MergeStatusConflicts, Conflicts: []provider.MergeConflict{conflict}},
+		content:      map[string]string{"base": "x = 1\n", "tgt": "x = 2\n", "src": "x = 3\n"},
+	}
+	m := NewDetailModel(client, conflictTestPR())
+	m.SetSize(120, 30)
+	m, _ = m.Update(m.fetchMergeability()())
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.conflictView == nil || cmd == nil {
+		t.Fatal("enter on a conflict should open the conflict view and load it")
+	}
+	if !m.hasModal() {
+		t.Error("hasModal() = false with the conflict view open")
+	}
+	m, _ = m.Update(cmd())
+
+	view := m.View()
+	for _, want := range []string{"Conflict: /a.go (editEdit)", "ours · main", "theirs · feature", "x = 1", "x = 2", "x = 3"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("conflict view missing %q:\n%s", want, view)
+		}
+	}
+	// Ours is the target branch, theirs the source branch.
+	if strings.Index(view, "x = 2") > strings.Index(view, "x = 3") {
+		t.Errorf("ours (target) should come before theirs (source):\n%s", view)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.conflictView != nil {
+		t.Error("esc should close the conflict view")
+	}
+}
+
+func TestLoadConflictContent_MissingSideIsAnError(t *testing.T) {
+	client := &conflictProvider{content: map[string]string{"tgt": "kept\n"}}
+	conflict := provider.MergeConflict{Path: "/a.go", Kind: "editDelete", BaseCommit: "base", TargetCommit: "tgt"}
+
+	msg := loadConflictContent(client, conflictTestPR(), conflict)().(conflictContentMsg)
+
+	if msg.content.errs[sideOurs] != nil || msg.content.text[sideOurs] != "kept\n" {
+		t.Errorf("ours = %q, %v; want the target content", msg.content.text[sideOurs], msg.content.errs[sideOurs])
+	}
+	if msg.content.errs[sideBase] == nil || msg.content.errs[sideTheirs] == nil {
+		t.Errorf("errs = %v, want errors for the base and the missing source side", msg.content.errs)
+	}
+}
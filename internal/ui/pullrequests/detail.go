@@ -7,9 +7,11 @@ import (
 	"github.com/Elpulgo/azdo/internal/azdevops"
 	"github.com/Elpulgo/azdo/internal/browser"
 	"github.com/Elpulgo/azdo/internal/diff"
+	"github.com/Elpulgo/azdo/internal/localgit"
 	"github.com/Elpulgo/azdo/internal/provider"
 	"github.com/Elpulgo/azdo/internal/ui/components"
 	"github.com/Elpulgo/azdo/internal/ui/display"
+	"github.com/Elpulgo/azdo/internal/ui/markdown"
 	"github.com/Elpulgo/azdo/internal/ui/styles"
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/viewport"
@@ -45,6 +47,14 @@ type DetailModel struct {
 	spinner       *components.LoadingIndicator
 	styles        *styles.Styles
 	votePicker    components.VotePicker
+	markdown      *markdown.Renderer
+	description   string // pr.Description rendered for the current width
+	mentions      components.Mentions
+	viewed        *viewedFiles   // shared with the diff view opened from here
+	localRepo     *localgit.Repo // working copy to check the PR out in; nil when not in one
+	conflicts     []provider.MergeConflict
+	conflictView  *conflictView // three-way view of a conflict; nil when closed
+	commits       []provider.Commit
 }
 
 // NewDetailModel creates a new PR detail model with default styles
@@ -56,6 +66,7 @@ func NewDetailModel(client provider.Provider, pr provider.PullRequest) *DetailMo
 func NewDetailModelWithStyles(client provider.Provider, pr provider.PullRequest, s *styles.Styles) *DetailModel {
 	spinner := components.NewLoadingIndicator(s)
 	spinner.SetMessage(fmt.Sprintf("Loading PR #%d...", prNumericID(pr)))
+	md := markdown.New(s)
 
 	return &DetailModel{
 		client:        client,
@@ -66,6 +77,10 @@ func NewDetailModelWithStyles(client provider.Provider, pr provider.PullRequest,
 		spinner:       spinner,
 		styles:        s,
 		votePicker:    components.NewVotePicker(s),
+		markdown:      md,
+		description:   md.Render(pr.Description, 0),
+		mentions:      components.Mentions{},
+		viewed:        newViewedFiles(nil, pr),
 	}
 }
 
@@ -75,7 +90,8 @@ func (m *DetailModel) Init() tea.Cmd {
 	m.threadsLoaded = false
 	m.filesLoaded = false
 	m.spinner.SetVisible(true)
-	return tea.Batch(m.fetchThreads(), m.fetchChangedFiles(), m.spinner.Init())
+	return tea.Batch(m.fetchThreads(), m.fetchChangedFiles(), m.fetchMergeability(), m.fetchCommits(), m.spinner.Init(),
+		components.ResolveMentions(m.client, m.pr.Identity.Scope, m.mentions.Missing(m.pr.Description)))
 }
 
 // Update handles messages for the detail view
@@ -87,6 +103,16 @@ func (m *DetailModel) Update(msg tea.Msg) (*DetailModel, tea.Cmd) {
 		return m, cmd
 	}
 
+	// Route keys to the three-way conflict view when open
+	if key, ok := msg.(tea.KeyMsg); ok && m.conflictView != nil {
+		if key.String() == "esc" {
+			m.conflictView = nil
+		} else {
+			m.conflictView.Update(key)
+		}
+		return m, nil
+	}
+
 	switch msg := msg.(type) {
 	case components.VoteSelectedMsg:
 		m.loading = true
@@ -128,6 +154,15 @@ func (m *DetailModel) Update(msg tea.Msg) (*DetailModel, tea.Cmd) {
 					}
 				}
 			}
+			if m.selectedConflict() != nil {
+				return m, m.openConflict()
+			}
+			if commit := m.selectedCommit(); commit != nil {
+				c := *commit
+				return m, func() tea.Msg {
+					return openCommitDiffMsg{commit: c}
+				}
+			}
 		case "v":
 			m.votePicker.SetSize(m.width, m.height)
 			m.votePicker.Show()
@@ -137,9 +172,20 @@ func (m *DetailModel) Update(msg tea.Msg) (*DetailModel, tea.Cmd) {
 			m.threadsLoaded = false
 			m.filesLoaded = false
 			m.spinner.SetVisible(true)
-			return m, tea.Batch(m.fetchThreads(), m.fetchChangedFiles(), m.spinner.Tick())
+			return m, tea.Batch(m.fetchThreads(), m.fetchChangedFiles(), m.fetchMergeability(), m.fetchCommits(), m.spinner.Tick())
 		case "o":
 			return m, m.openInBrowser()
+		case "b":
+			return m, m.checkoutPR(localgit.CheckoutBranch)
+		case "B":
+			return m, m.checkoutPR(localgit.CheckoutWorktree)
+		}
+
+	case components.MentionsResolvedMsg:
+		m.mentions.Add(msg.People)
+		m.description = m.markdown.Render(m.mentions.Replace(m.pr.Description), m.width)
+		if m.ready {
+			m.updateViewportContent()
 		}
 
 	case threadsMsg:
@@ -158,6 +204,9 @@ func (m *DetailModel) Update(msg tea.Msg) (*DetailModel, tea.Cmd) {
 		}
 		m.changedFiles = filterFileChanges(msg.changes)
 		m.fileIndex = 0
+		if err := m.viewed.reconcile(m.changedFiles, msg.iteration, msg.viewed); err != nil {
+			m.statusMessage = fmt.Sprintf("Error saving viewed files: %v", err)
+		}
 		m.filesLoaded = true
 		m.finishLoading()
 
@@ -171,6 +220,24 @@ func (m *DetailModel) Update(msg tea.Msg) (*DetailModel, tea.Cmd) {
 		m.spinner.SetVisible(true)
 		return m, tea.Batch(m.fetchThreads(), m.spinner.Tick())
 
+	case checkoutResultMsg:
+		m.handleCheckoutResult(msg)
+		return m, nil
+
+	case mergeabilityMsg:
+		m.handleMergeability(msg)
+		return m, nil
+
+	case commitsMsg:
+		m.handleCommits(msg)
+		return m, nil
+
+	case conflictContentMsg:
+		if m.conflictView != nil && m.conflictView.conflict.Path == msg.path {
+			m.conflictView.SetContent(msg.content)
+		}
+		return m, nil
+
 	case openURLResultMsg:
 		if msg.err != nil {
 			m.statusMessage = fmt.Sprintf("Failed to open browser: %v", msg.err)
@@ -201,6 +268,9 @@ func (m *DetailModel) View() string {
 	if m.votePicker.IsVisible() {
 		return m.votePicker.View()
 	}
+	if m.conflictView != nil {
+		return m.conflictView.View()
+	}
 
 	wrapContent := func(content string) string {
 		contentStyle := lipgloss.NewStyle().
@@ -248,9 +318,8 @@ func (m *DetailModel) updateViewportContent() {
 	var sb strings.Builder
 
 	// Description
-	if m.pr.Description != "" {
-		descStyle := m.styles.Value.Width(m.width)
-		sb.WriteString(descStyle.Render(m.pr.Description))
+	if m.description != "" {
+		sb.WriteString(m.description)
 		sb.WriteString("\n\n")
 	}
 
@@ -258,7 +327,7 @@ func (m *DetailModel) updateViewportContent() {
 	if m.client != nil {
 		prURL := m.client.PRURL(m.pr.Identity.Scope, m.pr.RepositoryID, prNumericID(m.pr))
 		if prURL != "" {
-			sb.WriteString(hyperlink(m.styles.Link.Render("Go to PR"), prURL))
+			sb.WriteString(markdown.Hyperlink(m.styles.Link.Render("Go to PR"), prURL))
 			sb.WriteString("\n\n")
 		}
 	}
@@ -273,6 +342,13 @@ func (m *DetailModel) updateViewportContent() {
 		sb.WriteString("\n\n")
 	}
 
+	// Merge status
+	if status := mergeStatusDisplay(m.pr.MergeStatus, len(m.conflicts), m.styles); status != "" {
+		sb.WriteString(m.styles.Label.Render("Merge: "))
+		sb.WriteString(status)
+		sb.WriteString("\n\n")
+	}
+
 	// Reviewers section
 	if len(m.pr.Reviewers) > 0 {
 		sb.WriteString(m.styles.Label.Render("Reviewers"))
@@ -299,6 +375,9 @@ func (m *DetailModel) updateViewportContent() {
 
 	// Changed files section
 	sb.WriteString(m.styles.Label.Render(fmt.Sprintf("Changed files (%d)", len(m.changedFiles))))
+	if len(m.changedFiles) > 0 {
+		sb.WriteString(m.styles.Muted.Render(" · " + m.viewed.progress(m.changedFiles)))
+	}
 	sb.WriteString("\n")
 
 	if len(m.changedFiles) > 0 {
@@ -312,6 +391,9 @@ func (m *DetailModel) updateViewportContent() {
 		sb.WriteString("\n")
 	}
 
+	sb.WriteString(m.renderConflicts())
+	sb.WriteString(m.renderCommits())
+
 	m.viewport.SetContent(sb.String())
 }
 
@@ -331,6 +413,10 @@ func (m *DetailModel) renderFileEntry(change provider.IterationChange, selected
 	if count > 0 {
 		line += " " + m.styles.DiffCommentCount.Render(fmt.Sprintf("(%d)", count))
 	}
+	if m.viewed.isViewed(change.Path) {
+		line += " " + viewedMarker
+		style = m.styles.Muted
+	}
 
 	if selected {
 		return m.styles.Selected.Render(line)
@@ -358,6 +444,7 @@ func changeTypeDisplay(changeType string, s *styles.Styles) (string, lipgloss.St
 func (m *DetailModel) SetSize(width, height int) {
 	m.width = width
 	m.height = height
+	m.description = m.markdown.Render(m.mentions.Replace(m.pr.Description), width)
 
 	// Account for header lines rendered in View(): title (1) + branch (1) + separator (1) = 3
 	headerLines := 3
@@ -375,6 +462,9 @@ func (m *DetailModel) SetSize(width, height int) {
 	}
 
 	m.updateViewportContent()
+	if m.conflictView != nil {
+		m.conflictView.SetSize(width, height)
+	}
 }
 
 // ensureSelectedVisible scrolls the viewport to keep the selected item visible
@@ -490,8 +580,8 @@ func (m *DetailModel) updateSelectionFromViewport() {
 // getSelectedItemLineOffset returns the visual line number for the currently selected item
 func (m *DetailModel) getSelectedItemLineOffset() int {
 	lineOffset := 0
-	if m.pr.Description != "" {
-		lineOffset += strings.Count(m.pr.Description, "\n") + 2
+	if m.description != "" {
+		lineOffset += strings.Count(m.description, "\n") + 2
 	}
 	if m.client != nil && m.pr.RepositoryID != "" {
 		lineOffset += 2
@@ -499,6 +589,9 @@ func (m *DetailModel) getSelectedItemLineOffset() int {
 	if !m.pr.CreationDate.IsZero() {
 		lineOffset += 2
 	}
+	if mergeStatusDisplay(m.pr.MergeStatus, len(m.conflicts), m.styles) != "" {
+		lineOffset += 2
+	}
 	if len(m.pr.Reviewers) > 0 {
 		lineOffset += 1 + len(m.pr.Reviewers) + 1
 	}
@@ -517,6 +610,21 @@ func (m *DetailModel) getSelectedItemLineOffset() int {
 	// "Changed files (N)" header line
 	lineOffset += 1
 
+	// Commits follow the conflicts, a blank line and their header
+	if ci := m.fileIndex - m.commitsOffset(); ci >= 0 {
+		lineOffset += max(len(m.changedFiles), 1) + 2
+		if len(m.conflicts) > 0 {
+			lineOffset += len(m.conflicts) + 2
+		}
+		return lineOffset + ci
+	}
+
+	// Conflicts follow the file list, a blank line and their header
+	if ci := m.fileIndex - m.conflictsOffset(); ci >= 0 {
+		lineOffset += max(len(m.changedFiles), 1) + 2
+		return lineOffset + ci
+	}
+
 	// File index within the file list
 	fi := m.fileIndex - gcOffset
 	lineOffset += fi
@@ -537,9 +645,10 @@ func (m *DetailModel) isGeneralCommentsSelected() bool {
 	return m.generalCommentsOffset() > 0 && m.fileIndex == 0
 }
 
-// totalSelectableItems returns the total navigable items (general comments entry + files)
+// totalSelectableItems returns the total navigable items (general comments
+// entry + files + merge conflicts + commits)
 func (m *DetailModel) totalSelectableItems() int {
-	return m.generalCommentsOffset() + len(m.changedFiles)
+	return m.commitsOffset() + len(m.commits)
 }
 
 // SelectedIndex returns the current file selection index
@@ -558,13 +667,17 @@ func (m *DetailModel) SelectedFile() *provider.IterationChange {
 
 // GetContextItems returns context items for the detail view
 func (m *DetailModel) GetContextItems() []components.ContextItem {
-	return []components.ContextItem{
+	if m.conflictView != nil {
+		return m.conflictView.contextItems()
+	}
+	items := []components.ContextItem{
 		{Key: "enter", Description: "open"},
 		{Key: "↑↓", Description: "navigate"},
 		{Key: "v", Description: "vote"},
 		{Key: "o", Description: "open in browser"},
-		{Key: "r", Description: "refresh"},
 	}
+	items = append(items, m.checkoutContextItems()...)
+	return append(items, components.ContextItem{Key: "r", Description: "refresh"})
 }
 
 // openInBrowser returns a command that opens the PR overview URL in the
@@ -585,6 +698,12 @@ func (m *DetailModel) openInBrowser() tea.Cmd {
 	}
 }
 
+// hasModal reports whether an overlay (the vote picker or the three-way
+// conflict view) is open and should handle esc itself.
+func (m *DetailModel) hasModal() bool {
+	return m.votePicker.IsVisible() || m.conflictView != nil
+}
+
 // GetThreads returns the current threads (for passing to DiffModel)
 func (m *DetailModel) GetThreads() []provider.Thread {
 	return m.threads
@@ -620,14 +739,6 @@ func (m *DetailModel) GetPRID() int {
 
 // Helper functions
 
-// hyperlink creates an OSC 8 terminal hyperlink
-func hyperlink(text, url string) string {
-	if url == "" {
-		return text
-	}
-	return fmt.Sprintf("\x1b]8;;%s\x07%s\x1b]8;;\x07", url, text)
-}
-
 // truncateString truncates a string to maxRunes runes (not bytes)
 func truncateString(s string, maxRunes int) string {
 	if maxRunes <= 0 {
@@ -752,22 +863,7 @@ func (m *DetailModel) fetchChangedFiles() tea.Cmd {
 		if m.client == nil {
 			return changedFilesMsg{changes: nil, err: nil}
 		}
-
-		iterations, err := m.client.GetPRIterations(m.pr.Identity.Scope, m.pr.RepositoryID, prNumericID(m.pr))
-		if err != nil {
-			return changedFilesMsg{err: err}
-		}
-		if len(iterations) == 0 {
-			return changedFilesMsg{changes: nil, err: nil}
-		}
-
-		latestID := iterations[len(iterations)-1].ID
-		changes, err := m.client.GetPRIterationChanges(m.pr.Identity.Scope, m.pr.RepositoryID, prNumericID(m.pr), latestID)
-		if err != nil {
-			return changedFilesMsg{err: err}
-		}
-
-		return changedFilesMsg{changes: changes}
+		return loadChangedFiles(m.client, m.pr)
 	}
 }
 
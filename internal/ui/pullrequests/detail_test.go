@@ -334,6 +334,38 @@ func TestDetailModel_View_WithContent(t *testing.T) {
 	}
 }
 
+func TestDetailModel_View_RendersDescriptionMarkdown(t *testing.T) {
+	pr := provider.PullRequest{
+		Identity:     provider.Identity{Kind: provider.KindAzure, Scope: "proj", ID: "101"},
+		Title:        "Add new feature",
+		Description:  "## Summary\n\n- adds **retries**\n- see [docs](https://example.com/docs)",
+		RepositoryID: "repo-123",
+	}
+	model := NewDetailModel(nil, pr)
+	model.SetSize(80, 24)
+	model.SetChangedFiles([]provider.IterationChange{{ChangeID: 1, Path: "/a.go", ChangeType: "edit"}})
+
+	view := model.View()
+	for _, want := range []string{"Summary", "• adds retries", "\x1b]8;;https://example.com/docs\x07"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("View should contain %q", want)
+		}
+	}
+	if strings.Contains(view, "## ") || strings.Contains(view, "**") {
+		t.Error("View should not show raw markdown syntax")
+	}
+
+	// The file list moves down by the rendered description (heading, blank
+	// line, two items) plus the blank line after it, not by its source lines.
+	pr.Description = ""
+	bare := NewDetailModel(nil, pr)
+	bare.SetSize(80, 24)
+	bare.SetChangedFiles([]provider.IterationChange{{ChangeID: 1, Path: "/a.go", ChangeType: "edit"}})
+	if got := model.getSelectedItemLineOffset() - bare.getSelectedItemLineOffset(); got != 5 {
+		t.Errorf("description shifts the file list by %d lines, want 5", got)
+	}
+}
+
 func TestDetailModel_View_ShowsChangedFiles(t *testing.T) {
 	pr := provider.PullRequest{
 		Identity:     provider.Identity{Kind: provider.KindAzure, Scope: "proj", ID: "101"},
@@ -815,37 +847,6 @@ func TestThreadStatusIcon(t *testing.T) {
 	}
 }
 
-func TestHyperlink(t *testing.T) {
-	tests := []struct {
-		name     string
-		text     string
-		url      string
-		expected string
-	}{
-		{
-			name:     "creates OSC 8 hyperlink",
-			text:     "Click me",
-			url:      "https://example.com",
-			expected: "\x1b]8;;https://example.com\x07Click me\x1b]8;;\x07",
-		},
-		{
-			name:     "falls back to plain text when URL is empty",
-			text:     "Plain text",
-			url:      "",
-			expected: "Plain text",
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got := hyperlink(tt.text, tt.url)
-			if got != tt.expected {
-				t.Errorf("hyperlink(%q, %q) = %q, want %q", tt.text, tt.url, got, tt.expected)
-			}
-		})
-	}
-}
-
 func TestTruncateString(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -1,36 +1,43 @@
 package pullrequests
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
 
 	"github.com/Elpulgo/azdo/internal/diff"
 	"github.com/Elpulgo/azdo/internal/provider"
+	"github.com/Elpulgo/azdo/internal/state"
 	"github.com/Elpulgo/azdo/internal/ui/components"
+	"github.com/Elpulgo/azdo/internal/ui/markdown"
 	"github.com/Elpulgo/azdo/internal/ui/styles"
+	"github.com/Elpulgo/azdo/internal/ui/syntax"
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 )
 
 // DiffViewMode represents the current sub-view within the diff viewer
 type DiffViewMode int
 
 const (
-	DiffFileList DiffViewMode = iota // selectable list of changed files
-	DiffFileView                     // scrollable diff for single file
+	DiffFileList   DiffViewMode = iota // selectable list of changed files
+	DiffFileView                       // scrollable diff for single file
+	DiffReviewPane                     // pending-review drafts awaiting submit
 )
 
 // InputMode represents what kind of text input is active
 type InputMode int
 
 const (
-	InputNone       InputMode = iota
-	InputNewComment           // creating new code comment on a line
-	InputReply                // replying to existing thread
+	InputNone          InputMode = iota
+	InputNewComment              // creating new code comment on a line
+	InputReply                   // replying to existing thread
+	InputReviewSummary           // summary for the pending review being submitted
 )
 
 // diffLineType represents the type of a flattened diff display line
@@ -43,6 +50,9 @@ const (
 	diffLineHunkHeader
 	diffLineComment
 	diffLineFileHeader
+	diffLineDraft         // a pending-review draft comment, not yet published
+	diffLineSuggestionOld // a line a suggested change replaces
+	diffLineSuggestionNew // a line of a suggested change
 )
 
 // diffLine is a flattened rendering line in the diff view
@@ -54,6 +64,15 @@ type diffLine struct {
 	ThreadID     int // non-zero if this is a comment line
 	CommentIdx   int
 	ThreadStatus string // thread status: "active", "fixed", etc.
+	// Spans holds the syntax-highlighted tokens of Content for code lines.
+	// nil when the file type has no lexer or the line is not code.
+	Spans []syntax.Span
+	// Changed marks the word-level segments of Content that differ from the
+	// paired line on the other side of the diff; nil when unpaired.
+	Changed []diff.Segment
+	// BodyStart and BodyEnd delimit the comment's markdown text in Content
+	// for comment lines; around it are the author header and the reactions.
+	BodyStart, BodyEnd int
 }
 
 // DiffModel is the diff viewer component
@@ -74,15 +93,63 @@ type DiffModel struct {
 	currentFile *provider.IterationChange
 	currentDiff *diff.FileDiff
 	fileThreads map[int][]provider.Thread // newLineNum -> threads
+	leftThreads map[int][]provider.Thread // oldLineNum -> threads anchored to the old file
+	diffOpts    diff.Options
+	// tooLarge is set when the current file exceeded diffOpts.MaxLines; the
+	// view then asks for confirmation before diffing without the limit.
+	tooLarge *diff.TooLargeError
 
 	// Flattened rendering
 	diffLines    []diffLine
 	selectedLine int
+	highlighter  *syntax.Highlighter
+	renderCache  []string // rendered unselected diffLines, indexed like diffLines
+
+	// Visual selection: while selecting, the lines between selectAnchor and
+	// selectedLine are highlighted and "c" comments on the whole range.
+	selecting    bool
+	selectAnchor int
 
 	// Input
 	inputMode     InputMode
 	textInput     textinput.Model
 	replyThreadID int
+	commentRange  provider.LineRange // anchor captured when a code comment is started
+
+	// Suggested changes: commentForm edits a suggestion block for
+	// commentRange; pendingApply holds a suggestion awaiting y/n before it is
+	// committed, and reloadFile re-opens the file once the commit lands.
+	commentForm  components.CommentForm
+	pendingApply *provider.FileEdit
+	reloadFile   bool
+
+	// Comment actions: editTarget is the comment being rewritten in
+	// commentForm, pendingDelete awaits y/n, and reactionPicker chooses a
+	// reaction for reactTarget.
+	editTarget     *commentRef
+	pendingDelete  *commentRef
+	reactTarget    commentRef
+	reactionPicker components.ReactionPicker
+
+	// Review session: while reviewing, new code comments are kept as local
+	// drafts and published together by submitReview.
+	reviewing     bool
+	drafts        []state.DraftComment
+	reviewDrafts  *state.DraftStore // persists drafts; nil keeps them in memory only
+	reviewIndex   int               // selected draft in the pending-review pane
+	reviewVerdict provider.ReviewVerdict
+	reviewReturn  DiffViewMode // view to return to when the pane closes
+	summaryPosted bool         // the summary went out with a submission that then failed
+
+	// Viewed files: marks shared with the detail view, checked against the
+	// changes of iteration.
+	viewed    *viewedFiles
+	iteration int
+
+	// Commit scope: when commit is set the view shows only that commit's
+	// changes, relative to commitParent, and is read-only.
+	commit       *provider.Commit
+	commitParent string
 
 	// Layout
 	viewMode      DiffViewMode
@@ -94,6 +161,8 @@ type DiffModel struct {
 	statusMessage string
 	spinner       *components.LoadingIndicator
 	styles        *styles.Styles
+	markdown      *markdown.Renderer  // comment text, drawn over the Info style
+	mentions      components.Mentions // display names for "@<GUID>" mentions
 }
 
 // NewDiffModel creates a new diff viewer model
@@ -105,23 +174,41 @@ func NewDiffModel(client provider.Provider, pr provider.PullRequest, threads []p
 	ti.Prompt = "> "
 	ti.CharLimit = 500
 
-	return &DiffModel{
+	m := &DiffModel{
 		client:         client,
 		pr:             pr,
 		threads:        threads,
 		generalThreads: diff.FilterGeneralThreadsP(threads),
+		diffOpts:       diff.DefaultOptions(),
 		viewMode:       DiffFileList,
 		spinner:        sp,
 		styles:         s,
 		textInput:      ti,
+		commentForm:    components.NewCommentForm(s),
+		reactionPicker: components.NewReactionPicker(s),
+		markdown:       markdown.New(s).WithBase(s.Info),
+		mentions:       components.Mentions{},
+		viewed:         newViewedFiles(nil, pr),
+	}
+	if client != nil {
+		m.commentForm.EnableMentions(func(query string) ([]provider.Person, error) {
+			return client.SearchPeople(pr.Identity.Scope, query)
+		}, false)
 	}
+	return m
+}
+
+// SetDiffOptions sets the context, whitespace and size-limit options used
+// when computing file diffs.
+func (m *DiffModel) SetDiffOptions(opts diff.Options) {
+	m.diffOpts = opts
 }
 
 // Init initializes the diff model by fetching changed files
 func (m *DiffModel) Init() tea.Cmd {
 	m.loading = true
 	m.spinner.SetVisible(true)
-	return tea.Batch(m.fetchChangedFiles(), m.spinner.Init())
+	return tea.Batch(m.fetchChangedFiles(), m.spinner.Init(), m.resolveMentions())
 }
 
 // InitGeneralComments initializes the diff model and immediately opens the general comments view
@@ -133,7 +220,7 @@ func (m *DiffModel) InitGeneralComments() tea.Cmd {
 	if m.ready {
 		m.updateDiffViewport()
 	}
-	return m.fetchChangedFiles()
+	return tea.Batch(m.fetchChangedFiles(), m.resolveMentions())
 }
 
 // InitWithFile initializes the diff model and immediately opens a specific file's diff
@@ -142,7 +229,7 @@ func (m *DiffModel) InitWithFile(file provider.IterationChange) tea.Cmd {
 	m.loading = true
 	m.spinner.SetMessage("Loading diff...")
 	m.spinner.SetVisible(true)
-	return tea.Batch(m.fetchChangedFiles(), m.fetchFileDiff(file), m.spinner.Init())
+	return tea.Batch(m.fetchChangedFiles(), m.fetchFileDiff(file, m.diffOpts), m.spinner.Init(), m.resolveMentions())
 }
 
 // Update handles messages
@@ -160,6 +247,10 @@ func (m *DiffModel) Update(msg tea.Msg) (*DiffModel, tea.Cmd) {
 		}
 
 	case changedFilesMsg:
+		if msg.err == nil && m.reloadFile {
+			m.reconcileViewed(msg)
+			return m.reloadCurrentFile(msg.changes)
+		}
 		if msg.err != nil {
 			m.loading = false
 			m.spinner.SetVisible(false)
@@ -168,6 +259,8 @@ func (m *DiffModel) Update(msg tea.Msg) (*DiffModel, tea.Cmd) {
 		}
 		m.changedFiles = filterFileChanges(msg.changes)
 		m.fileIndex = 0
+		m.commitParent = msg.parent
+		m.reconcileViewed(msg)
 		// Only clear loading and update viewport if we're in file list mode.
 		// When InitWithFile was used, currentFile is set and we're waiting for
 		// fileDiffMsg — clearing loading here would briefly flash the file list.
@@ -182,14 +275,21 @@ func (m *DiffModel) Update(msg tea.Msg) (*DiffModel, tea.Cmd) {
 	case fileDiffMsg:
 		m.loading = false
 		m.spinner.SetVisible(false)
+		var tooLarge *diff.TooLargeError
+		if errors.As(msg.err, &tooLarge) {
+			m.tooLarge = tooLarge
+			return m, nil
+		}
 		if msg.err != nil {
 			m.err = msg.err
 			return m, nil
 		}
 		m.currentDiff = msg.diff
 		m.fileThreads = msg.fileThreads
+		m.leftThreads = msg.leftThreads
 		m.viewMode = DiffFileView
 		m.selectedLine = 0
+		m.selecting = false
 		m.buildDiffLines()
 		m.updateDiffViewport()
 
@@ -202,6 +302,35 @@ func (m *DiffModel) Update(msg tea.Msg) (*DiffModel, tea.Cmd) {
 			return m, m.refreshThreads()
 		}
 
+	case reviewSubmittedMsg:
+		return m.handleReviewSubmitted(msg)
+
+	case suggestionAppliedMsg:
+		return m.handleSuggestionApplied(msg)
+
+	case fileViewedMsg:
+		m.handleFileViewed(msg)
+
+	case components.CommentSubmittedMsg:
+		if m.editTarget != nil {
+			return m.handleEditSubmitted(msg.Text)
+		}
+		return m.handleSuggestionSubmitted(msg.Text)
+
+	case components.CommentFormCancelledMsg:
+		m.editTarget = nil
+		m.SetSize(m.width, m.height)
+
+	case components.ReactionSelectedMsg:
+		return m, m.reactToComment(m.reactTarget, msg.Reaction, msg.Remove)
+
+	case components.MentionsResolvedMsg:
+		m.mentions.Add(msg.People)
+		if len(msg.People) > 0 && m.viewMode == DiffFileView {
+			m.renderCache = nil
+			m.updateDiffViewport()
+		}
+
 	case threadsRefreshMsg:
 		if msg.err == nil {
 			m.threads = msg.threads
@@ -210,29 +339,72 @@ func (m *DiffModel) Update(msg tea.Msg) (*DiffModel, tea.Cmd) {
 				m.buildGeneralCommentLines()
 				m.updateDiffViewport()
 			} else if m.viewMode == DiffFileView && m.currentFile != nil {
-				m.fileThreads = diff.MapThreadsToLinesP(m.threads, m.currentFile.Path)
+				m.fileThreads, m.leftThreads = m.inlineThreads(m.currentFile.Path)
 				m.buildDiffLines()
 				m.updateDiffViewport()
 			}
+			return m, m.resolveMentions()
 		}
 
 	case tea.KeyMsg:
+		// The form and picker hide themselves synchronously on submit/cancel,
+		// so the resulting messages reach the handlers above.
+		if m.commentForm.IsVisible() {
+			return m.updateCommentForm(msg)
+		}
+		if m.reactionPicker.IsVisible() {
+			var cmd tea.Cmd
+			m.reactionPicker, cmd = m.reactionPicker.Update(msg)
+			return m, cmd
+		}
+		if m.pendingApply != nil {
+			return m.updateApplyPrompt(msg)
+		}
+		if m.pendingDelete != nil {
+			return m.updateDeletePrompt(msg)
+		}
 		if m.inputMode != InputNone {
 			return m.updateInput(msg)
 		}
+		if m.tooLarge != nil {
+			return m.updateTooLarge(msg)
+		}
 		switch m.viewMode {
 		case DiffFileList:
 			return m.updateFileList(msg)
 		case DiffFileView:
 			return m.updateDiffView(msg)
+		case DiffReviewPane:
+			return m.updateReviewPane(msg)
+		}
+
+	default:
+		// Cursor blinks, mention search results and other form messages.
+		if m.commentForm.IsVisible() {
+			return m.updateCommentForm(msg)
 		}
 	}
 
 	return m, nil
 }
 
+// updateCommentForm passes msg to the comment form, making room for the
+// mention popup when it opens or closes.
+func (m *DiffModel) updateCommentForm(msg tea.Msg) (*DiffModel, tea.Cmd) {
+	height := m.commentForm.Height()
+	var cmd tea.Cmd
+	m.commentForm, cmd = m.commentForm.Update(msg)
+	if m.commentForm.Height() != height {
+		m.SetSize(m.width, m.height)
+	}
+	return m, cmd
+}
+
 // updateFileList handles key events in file list mode
 func (m *DiffModel) updateFileList(msg tea.KeyMsg) (*DiffModel, tea.Cmd) {
+	if m.blockedInCommit(msg.String()) {
+		return m, nil
+	}
 	maxIndex := m.fileListItemCount() - 1
 
 	switch msg.String() {
@@ -275,7 +447,7 @@ func (m *DiffModel) updateFileList(msg tea.KeyMsg) (*DiffModel, tea.Cmd) {
 			m.loading = true
 			m.spinner.SetMessage("Loading diff...")
 			m.spinner.SetVisible(true)
-			return m, tea.Batch(m.fetchFileDiff(change), m.spinner.Tick())
+			return m, tea.Batch(m.fetchFileDiff(change, m.diffOpts), m.spinner.Tick())
 		}
 	case "r":
 		m.loading = true
@@ -283,14 +455,45 @@ func (m *DiffModel) updateFileList(msg tea.KeyMsg) (*DiffModel, tea.Cmd) {
 		m.spinner.SetVisible(true)
 		m.err = nil
 		return m, tea.Batch(m.fetchChangedFiles(), m.spinner.Tick())
+	case "s":
+		m.startOrOpenReview()
+	case "v":
+		return m, m.toggleViewed()
 	case "esc":
 		return m, func() tea.Msg { return exitDiffViewMsg{} }
 	}
 	return m, nil
 }
 
+// updateTooLarge handles key events while the oversized-diff prompt is
+// shown: x diffs the file without the size limit, esc returns to the list.
+func (m *DiffModel) updateTooLarge(msg tea.KeyMsg) (*DiffModel, tea.Cmd) {
+	switch msg.String() {
+	case "x":
+		if m.currentFile == nil {
+			return m, nil
+		}
+		m.tooLarge = nil
+		opts := m.diffOpts
+		opts.MaxLines = 0
+		m.loading = true
+		m.spinner.SetMessage("Loading diff...")
+		m.spinner.SetVisible(true)
+		return m, tea.Batch(m.fetchFileDiff(*m.currentFile, opts), m.spinner.Tick())
+	case "esc":
+		m.tooLarge = nil
+		m.currentFile = nil
+		m.viewMode = DiffFileList
+		m.updateFileListViewport()
+	}
+	return m, nil
+}
+
 // updateDiffView handles key events in file diff mode
 func (m *DiffModel) updateDiffView(msg tea.KeyMsg) (*DiffModel, tea.Cmd) {
+	if m.blockedInCommit(msg.String()) {
+		return m, nil
+	}
 	switch msg.String() {
 	case "up", "k":
 		if m.selectedLine > 0 {
@@ -327,15 +530,64 @@ func (m *DiffModel) updateDiffView(msg tea.KeyMsg) (*DiffModel, tea.Cmd) {
 			m.textInput.Placeholder = "New comment..."
 			return m, m.textInput.Focus()
 		}
-		// Create new comment on current line
-		line := m.currentDiffLine()
-		if line != nil && (line.Type == diffLineAdded || line.Type == diffLineContext || line.Type == diffLineRemoved) {
-			m.inputMode = InputNewComment
-			m.textInput.SetValue("")
-			m.textInput.Focus()
-			m.textInput.Placeholder = "New comment..."
-			return m, m.textInput.Focus()
+		// Create new comment on the selected range or the current line
+		lo, hi := m.selectionBounds()
+		rng, ok := m.commentRangeFor(lo, hi)
+		if !ok {
+			if m.selecting {
+				m.statusMessage = "Selection must contain code and stay within one hunk"
+			}
+			return m, nil
+		}
+		m.commentRange = rng
+		m.inputMode = InputNewComment
+		m.textInput.SetValue("")
+		m.textInput.Focus()
+		noun := "New comment"
+		if m.reviewing {
+			noun = "Draft comment"
+		}
+		m.textInput.Placeholder = noun + "..."
+		if label := threadRangeLabel(rng); label != "" {
+			m.textInput.Placeholder = noun + " on " + label + "..."
+		}
+		return m, m.textInput.Focus()
+	case "v":
+		// Toggle visual selection, anchored at the current code line
+		if m.viewingGeneralComments {
+			return m, nil
+		}
+		if m.selecting {
+			m.selecting = false
+		} else if line := m.currentDiffLine(); line != nil && isCodeLine(line.Type) {
+			m.selecting = true
+			m.selectAnchor = m.selectedLine
+		}
+		m.updateDiffViewport()
+	case "S":
+		// Suggest a change to the selected range or the current line
+		if m.viewingGeneralComments {
+			return m, nil
+		}
+		return m, m.startSuggestion()
+	case "A":
+		// Apply the nearest suggestion to the source branch
+		if m.viewingGeneralComments {
+			return m, nil
 		}
+		m.promptApplySuggestion()
+	case "e":
+		// Edit the comment under the cursor
+		return m, m.startEditComment()
+	case "D":
+		// Delete the comment under the cursor, after confirmation
+		m.promptDeleteComment()
+	case "+":
+		// Toggle a like on the comment under the cursor
+		return m, m.toggleLike()
+	case "R":
+		// Pick any reaction for the comment under the cursor
+		m.openReactionPicker()
 	case "p":
 		// Reply to nearest thread
 		threadID := m.findNearestThread()
@@ -363,7 +615,14 @@ func (m *DiffModel) updateDiffView(msg tea.KeyMsg) (*DiffModel, tea.Cmd) {
 		m.jumpToNextComment(-1)
 		m.updateDiffViewport()
 		m.ensureDiffLineVisible()
+	case "s":
+		m.startOrOpenReview()
 	case "esc":
+		if m.selecting {
+			m.selecting = false
+			m.updateDiffViewport()
+			return m, nil
+		}
 		if m.viewingGeneralComments {
 			// Exit back to detail view
 			m.viewingGeneralComments = false
@@ -381,9 +640,19 @@ func (m *DiffModel) updateInput(msg tea.KeyMsg) (*DiffModel, tea.Cmd) {
 	case "esc":
 		m.inputMode = InputNone
 		m.textInput.Blur()
+		if m.selecting {
+			m.selecting = false
+			m.updateDiffViewport()
+		}
 		return m, nil
 	case "enter":
 		content := strings.TrimSpace(m.textInput.Value())
+		if m.inputMode == InputReviewSummary {
+			// The summary is optional, so an empty value still submits.
+			m.textInput.Blur()
+			m.inputMode = InputNone
+			return m, m.submitReview(content)
+		}
 		if content == "" {
 			return m, nil
 		}
@@ -396,13 +665,16 @@ func (m *DiffModel) updateInput(msg tea.KeyMsg) (*DiffModel, tea.Cmd) {
 			if m.viewingGeneralComments {
 				return m, m.createGeneralComment(content)
 			}
-			line := m.currentDiffLine()
-			if line != nil && m.currentFile != nil {
-				lineNum := line.NewNum
-				if lineNum == 0 {
-					lineNum = line.OldNum
+			if m.currentFile != nil {
+				if m.selecting {
+					m.selecting = false
+					m.updateDiffViewport()
 				}
-				return m, m.createCodeComment(m.currentFile.Path, lineNum, content)
+				if m.reviewing {
+					m.addDraft(m.currentFile.Path, m.commentRange, content)
+					return m, nil
+				}
+				return m, m.createCodeComment(m.currentFile.Path, m.commentRange, content)
 			}
 		case InputReply:
 			if m.replyThreadID > 0 {
@@ -427,12 +699,26 @@ func (m *DiffModel) View() string {
 	if m.loading {
 		return contentStyle.Render(m.spinner.View())
 	}
+	if m.tooLarge != nil {
+		path := ""
+		if m.currentFile != nil {
+			path = m.currentFile.Path + "\n\n"
+		}
+		return contentStyle.Render(fmt.Sprintf("%sDiff too large (%d lines, limit %d)\n\nPress x to load anyway, Esc to go back",
+			path, m.tooLarge.Lines, m.tooLarge.Limit))
+	}
+
+	if m.reactionPicker.IsVisible() {
+		return m.reactionPicker.View()
+	}
 
 	switch m.viewMode {
 	case DiffFileList:
 		return contentStyle.Render(m.viewFileList())
 	case DiffFileView:
 		return contentStyle.Render(m.viewFileDiff())
+	case DiffReviewPane:
+		return contentStyle.Render(m.viewReviewPane())
 	}
 	return ""
 }
@@ -443,7 +729,17 @@ func (m *DiffModel) viewFileList() string {
 		return ""
 	}
 	var sb strings.Builder
+	if m.commit != nil {
+		sb.WriteString(m.commitHeader())
+		sb.WriteString("\n")
+		sb.WriteString(m.viewport.View())
+		return sb.String()
+	}
 	sb.WriteString(m.styles.Header.Render(fmt.Sprintf("Changed files (%d)", len(m.changedFiles))))
+	if len(m.changedFiles) > 0 {
+		sb.WriteString(m.styles.Muted.Render(" · " + m.viewed.progress(m.changedFiles)))
+	}
+	sb.WriteString(m.reviewBadge())
 	sb.WriteString("\n")
 	sb.WriteString(m.viewport.View())
 	return sb.String()
@@ -462,7 +758,12 @@ func (m *DiffModel) viewFileDiff() string {
 		sb.WriteString(m.styles.DiffHeader.Render(" General comments "))
 		sb.WriteString("\n")
 	} else if m.currentFile != nil {
-		sb.WriteString(m.styles.DiffHeader.Render(fmt.Sprintf(" %s ", m.currentFile.Path)))
+		label := m.currentFile.Path
+		if m.commit != nil {
+			label = shortCommitID(m.commit.ID) + " · " + label
+		}
+		sb.WriteString(m.styles.DiffHeader.Render(fmt.Sprintf(" %s ", label)))
+		sb.WriteString(m.reviewBadge())
 		sb.WriteString("\n")
 	}
 
@@ -473,12 +774,27 @@ func (m *DiffModel) viewFileDiff() string {
 		sb.WriteString("\n")
 		sb.WriteString(m.textInput.View())
 	}
+	if m.commentForm.IsVisible() {
+		sb.WriteString("\n")
+		sb.WriteString(m.commentForm.View())
+	}
+	if m.pendingApply != nil {
+		sb.WriteString("\n")
+		sb.WriteString(m.styles.Warning.Render(m.applyPromptText()))
+	}
+	if m.pendingDelete != nil {
+		sb.WriteString("\n")
+		sb.WriteString(m.styles.Warning.Render("Delete this comment? (y/n)"))
+	}
 
 	return sb.String()
 }
 
 // SetSize sets the component size
 func (m *DiffModel) SetSize(width, height int) {
+	if width != m.width {
+		m.renderCache = nil // comments are wrapped to the width
+	}
 	m.width = width
 	m.height = height
 
@@ -487,6 +803,11 @@ func (m *DiffModel) SetSize(width, height int) {
 	if m.inputMode != InputNone {
 		viewportHeight-- // input bar
 	}
+	m.commentForm.SetWidth(width)
+	m.reactionPicker.SetSize(width, height)
+	if m.commentForm.IsVisible() {
+		viewportHeight -= m.commentForm.Height()
+	}
 	if viewportHeight < 1 {
 		viewportHeight = 1
 	}
@@ -499,15 +820,46 @@ func (m *DiffModel) SetSize(width, height int) {
 		m.viewport.Height = viewportHeight
 	}
 
-	if m.viewMode == DiffFileList {
+	switch m.viewMode {
+	case DiffFileList:
 		m.updateFileListViewport()
-	} else {
+	case DiffReviewPane:
+		m.updateReviewPaneViewport()
+	default:
 		m.updateDiffViewport()
 	}
 }
 
 // GetContextItems returns context items for the current view
 func (m *DiffModel) GetContextItems() []components.ContextItem {
+	if m.commentForm.IsVisible() {
+		submit := "submit suggestion"
+		if m.editTarget != nil {
+			submit = "save comment"
+		}
+		return []components.ContextItem{
+			{Key: "ctrl+s", Description: submit},
+			{Key: "esc", Description: "cancel"},
+		}
+	}
+	if m.reactionPicker.IsVisible() {
+		return []components.ContextItem{
+			{Key: "enter", Description: "toggle reaction"},
+			{Key: "esc", Description: "cancel"},
+		}
+	}
+	if m.pendingApply != nil {
+		return []components.ContextItem{
+			{Key: "y", Description: "commit"},
+			{Key: "n", Description: "cancel"},
+		}
+	}
+	if m.pendingDelete != nil {
+		return []components.ContextItem{
+			{Key: "y", Description: "delete"},
+			{Key: "n", Description: "cancel"},
+		}
+	}
 	if m.inputMode != InputNone {
 		return []components.ContextItem{
 			{Key: "enter", Description: "submit"},
@@ -515,19 +867,55 @@ func (m *DiffModel) GetContextItems() []components.ContextItem {
 		}
 	}
 
+	if m.tooLarge != nil {
+		return []components.ContextItem{
+			{Key: "x", Description: "load anyway"},
+			{Key: "esc", Description: "back"},
+		}
+	}
+	if m.commit != nil && !m.viewingGeneralComments {
+		return commitContextItems(m.viewMode)
+	}
+
 	switch m.viewMode {
 	case DiffFileList:
 		return []components.ContextItem{
 			{Key: "pgup/pgdn", Description: "page"},
 			{Key: "enter", Description: "open"},
+			viewedContextItem(),
+			m.reviewContextItem(),
 		}
-	case DiffFileView:
+	case DiffReviewPane:
 		return []components.ContextItem{
+			{Key: "d", Description: "delete draft"},
+			{Key: "a", Description: "approve"},
+			{Key: "r", Description: "request changes"},
+			{Key: "c", Description: "comment"},
+			{Key: "X", Description: "discard review"},
+		}
+	case DiffFileView:
+		if m.selecting {
+			return []components.ContextItem{
+				{Key: "↑/↓", Description: "extend selection"},
+				{Key: "c", Description: "comment on selection"},
+				{Key: "S", Description: "suggest change"},
+				{Key: "esc", Description: "cancel selection"},
+			}
+		}
+		items := []components.ContextItem{
 			{Key: "c", Description: "comment"},
-			{Key: "p", Description: "reply"},
-			{Key: "x", Description: "resolve"},
-			{Key: "n/N", Description: "next/prev comment"},
+			{Key: "v", Description: "select range"},
 		}
+		if !m.viewingGeneralComments {
+			items = append(items, suggestionContextItems()...)
+		}
+		items = append(items, commentActionContextItems()...)
+		return append(items,
+			components.ContextItem{Key: "p", Description: "reply"},
+			components.ContextItem{Key: "x", Description: "resolve"},
+			components.ContextItem{Key: "n/N", Description: "next/prev comment"},
+			m.reviewContextItem(),
+		)
 	}
 	return nil
 }
@@ -545,10 +933,12 @@ func (m *DiffModel) GetStatusMessage() string {
 	return m.statusMessage
 }
 
-// IsInputActive returns true when a text input (comment or reply) is active,
-// so that global keyboard shortcuts can be suppressed.
+// IsInputActive returns true when a text input (comment, reply, edit or
+// suggestion), the reaction picker or a confirmation is active, so that
+// global keyboard shortcuts can be suppressed.
 func (m *DiffModel) IsInputActive() bool {
-	return m.inputMode != InputNone
+	return m.inputMode != InputNone || m.commentForm.IsVisible() || m.reactionPicker.IsVisible() ||
+		m.pendingApply != nil || m.pendingDelete != nil
 }
 
 // --- Rendering helpers ---
@@ -577,6 +967,10 @@ func (m *DiffModel) updateFileListViewport() {
 		if change.ChangeType == "rename" && change.OriginalPath != "" {
 			line = fmt.Sprintf("  %s %s -> %s", icon, change.OriginalPath, change.Path)
 		}
+		if m.viewed.isViewed(change.Path) {
+			line += " " + viewedMarker
+			style = m.styles.Muted
+		}
 		if i+1 == m.fileIndex { // +1 for the general comments entry
 			sb.WriteString(m.styles.Selected.Render(line))
 		} else {
@@ -609,9 +1003,12 @@ func (m *DiffModel) ensureFileIndexVisible() {
 // buildDiffLines flattens hunks + inline comments into diffLines slice
 func (m *DiffModel) buildDiffLines() {
 	m.diffLines = nil
+	m.renderCache = nil
 	if m.currentDiff == nil {
 		return
 	}
+	m.highlighter = syntax.NewHighlighter(m.currentDiff.Path, m.styles.Theme)
+	draftShown := make([]bool, len(m.drafts))
 
 	for _, hunk := range m.currentDiff.Hunks {
 		// Hunk header
@@ -620,6 +1017,7 @@ func (m *DiffModel) buildDiffLines() {
 			Type:    diffLineHunkHeader,
 			Content: header,
 		})
+		hunkStart := len(m.diffLines)
 
 		for _, line := range hunk.Lines {
 			var dlt diffLineType
@@ -637,32 +1035,188 @@ func (m *DiffModel) buildDiffLines() {
 				Content: line.Content,
 				OldNum:  line.OldNum,
 				NewNum:  line.NewNum,
+				Changed: line.Changed,
 			})
 
-			// Insert inline comments after the relevant line
-			lineNum := line.NewNum
-			if lineNum == 0 {
-				lineNum = line.OldNum
+			// Insert inline comments after the relevant line: new-file
+			// threads under context/added lines, old-file threads under
+			// context/removed lines.
+			if line.Type != diff.Removed {
+				m.appendThreadLines(m.fileThreads, line.NewNum)
+				m.appendDraftLines(provider.SideRight, line.NewNum, draftShown)
 			}
-			if threads, ok := m.fileThreads[lineNum]; ok && line.Type != diff.Removed {
-				for _, thread := range threads {
-					threadID := parseThreadID(thread.Identity.ID)
-					for ci, comment := range thread.Comments {
-						timestamp := comment.PublishedDate.Format("2006-01-02 15:04")
-						m.diffLines = append(m.diffLines, diffLine{
-							Type:         diffLineComment,
-							Content:      fmt.Sprintf("@[%s] (%s): %s", comment.AuthorName, timestamp, comment.Content),
-							ThreadID:     threadID,
-							CommentIdx:   ci,
-							ThreadStatus: thread.Status,
-						})
-					}
+			if line.Type != diff.Added {
+				m.appendThreadLines(m.leftThreads, line.OldNum)
+				m.appendDraftLines(provider.SideLeft, line.OldNum, draftShown)
+			}
+		}
+		m.highlightHunk(hunkStart)
+	}
+}
+
+// appendThreadLines appends the comment lines of the threads anchored at
+// lineNum in byLine, then removes the entry so a line repeated across hunks
+// does not show its threads twice.
+func (m *DiffModel) appendThreadLines(byLine map[int][]provider.Thread, lineNum int) {
+	threads, ok := byLine[lineNum]
+	if !ok {
+		return
+	}
+	for _, thread := range threads {
+		threadID := parseThreadID(thread.Identity.ID)
+		label := threadRangeLabel(thread.Range)
+		for ci, comment := range thread.Comments {
+			if comment.IsDeleted {
+				continue
+			}
+			timestamp := comment.PublishedDate.Format("2006-01-02 15:04")
+			anchor := ""
+			if ci == 0 && label != "" {
+				anchor = " on " + label
+			}
+			content := comment.Content
+			text, suggested, isSuggestion := diff.ExtractSuggestion(content)
+			isSuggestion = isSuggestion && !thread.Range.IsLeft()
+			if isSuggestion {
+				content = text
+				if content == "" {
+					content = "Suggested change"
 				}
-				// Remove from map to avoid duplicates if same line appears in multiple hunks
-				delete(m.fileThreads, lineNum)
 			}
+			header := fmt.Sprintf("@[%s] (%s)%s: ", comment.AuthorName, timestamp, anchor)
+			m.diffLines = append(m.diffLines, commentLine(header, content, comment.Reactions, diffLine{
+				ThreadID:     threadID,
+				CommentIdx:   ci,
+				ThreadStatus: thread.Status,
+			}))
+			if isSuggestion {
+				m.appendSuggestionLines(thread, threadID, ci, suggested)
+			}
+		}
+	}
+	delete(byLine, lineNum)
+}
+
+// commentLine fills in dl as the comment line for a comment's author header,
+// markdown text and reactions.
+func commentLine(header, text string, reactions []provider.ReactionCount, dl diffLine) diffLine {
+	dl.Type = diffLineComment
+	dl.Content = withReactions(header+text, reactions)
+	dl.BodyStart = len(header)
+	dl.BodyEnd = len(header) + len(text)
+	return dl
+}
+
+// threadRangeLabel describes a comment anchor that its position in the diff
+// does not make obvious: a multi-line range or a line of the old file.
+// Returns "" for a single new-file line.
+func threadRangeLabel(r provider.LineRange) string {
+	noun := "lines"
+	if r.IsLeft() {
+		noun = "old lines"
+	}
+	switch {
+	case r.IsMultiLine():
+		return fmt.Sprintf("%s %d-%d", noun, r.StartLine, r.EndLine)
+	case r.IsLeft():
+		return fmt.Sprintf("old line %d", r.StartLine)
+	}
+	return ""
+}
+
+// isCodeLine reports whether t is a line of file content (as opposed to a
+// hunk header, comment or file header).
+func isCodeLine(t diffLineType) bool {
+	return t == diffLineContext || t == diffLineAdded || t == diffLineRemoved
+}
+
+// selectionBounds returns the inclusive diffLines span the next comment
+// applies to: the visual selection while selecting, else the current line.
+func (m *DiffModel) selectionBounds() (int, int) {
+	if !m.selecting {
+		return m.selectedLine, m.selectedLine
+	}
+	return min(m.selectAnchor, m.selectedLine), max(m.selectAnchor, m.selectedLine)
+}
+
+// inSelection reports whether diffLines[i] is part of the visual selection.
+func (m *DiffModel) inSelection(i int) bool {
+	if !m.selecting {
+		return false
+	}
+	lo, hi := m.selectionBounds()
+	return i >= lo && i <= hi
+}
+
+// commentRangeFor converts diffLines[lo..hi] into a comment anchor. A span
+// made only of removed lines anchors to the old file; any other span anchors
+// to the new file using the lines that exist there, so removed lines inside a
+// mixed selection are covered by the surrounding new-file range. ok is false
+// when the span holds no code or crosses a hunk header.
+func (m *DiffModel) commentRangeFor(lo, hi int) (provider.LineRange, bool) {
+	if lo < 0 || hi >= len(m.diffLines) {
+		return provider.LineRange{}, false
+	}
+	var oldStart, oldEnd, newStart, newEnd int
+	for i := lo; i <= hi; i++ {
+		dl := m.diffLines[i]
+		switch dl.Type {
+		case diffLineHunkHeader, diffLineFileHeader:
+			return provider.LineRange{}, false
+		case diffLineRemoved:
+			if oldStart == 0 {
+				oldStart = dl.OldNum
+			}
+			oldEnd = dl.OldNum
+		case diffLineAdded, diffLineContext:
+			if newStart == 0 {
+				newStart = dl.NewNum
+			}
+			newEnd = dl.NewNum
 		}
 	}
+	switch {
+	case newStart > 0:
+		return provider.LineRange{Side: provider.SideRight, StartLine: newStart, EndLine: newEnd}, true
+	case oldStart > 0:
+		return provider.LineRange{Side: provider.SideLeft, StartLine: oldStart, EndLine: oldEnd}, true
+	}
+	return provider.LineRange{}, false
+}
+
+// highlightHunk fills Spans for the code lines from index start to the end of
+// diffLines. The old side (context + removed) and the new side (context +
+// added) are lexed as separate blocks so each reads as valid source; context
+// lines take their spans from the new side.
+func (m *DiffModel) highlightHunk(start int) {
+	if !m.highlighter.Enabled() {
+		return
+	}
+	var oldIdx, newIdx []int
+	var oldSrc, newSrc []string
+	for i := start; i < len(m.diffLines); i++ {
+		dl := m.diffLines[i]
+		switch dl.Type {
+		case diffLineRemoved:
+			oldIdx = append(oldIdx, i)
+			oldSrc = append(oldSrc, dl.Content)
+		case diffLineAdded, diffLineContext:
+			newIdx = append(newIdx, i)
+			newSrc = append(newSrc, dl.Content)
+			if dl.Type == diffLineContext {
+				oldSrc = append(oldSrc, dl.Content)
+				oldIdx = append(oldIdx, -1) // lexed for context only
+			}
+		}
+	}
+	for j, spans := range m.highlighter.Tokenize(oldSrc) {
+		if oldIdx[j] >= 0 {
+			m.diffLines[oldIdx[j]].Spans = spans
+		}
+	}
+	for j, spans := range m.highlighter.Tokenize(newSrc) {
+		m.diffLines[newIdx[j]].Spans = spans
+	}
 }
 
 // isGeneralCommentsSelected returns true if the general comments virtual entry is selected
@@ -684,6 +1238,7 @@ func (m *DiffModel) selectedFileIndex() int {
 // buildGeneralCommentLines builds diffLines from general comment threads
 func (m *DiffModel) buildGeneralCommentLines() {
 	m.diffLines = nil
+	m.renderCache = nil
 
 	for ti, thread := range m.generalThreads {
 		// Add separator between threads (blank line)
@@ -696,14 +1251,16 @@ func (m *DiffModel) buildGeneralCommentLines() {
 
 		threadID := parseThreadID(thread.Identity.ID)
 		for ci, comment := range thread.Comments {
+			if comment.IsDeleted {
+				continue
+			}
 			timestamp := comment.PublishedDate.Format("2006-01-02 15:04")
-			m.diffLines = append(m.diffLines, diffLine{
-				Type:         diffLineComment,
-				Content:      fmt.Sprintf("@[%s] (%s): %s", comment.AuthorName, timestamp, comment.Content),
+			header := fmt.Sprintf("@[%s] (%s): ", comment.AuthorName, timestamp)
+			m.diffLines = append(m.diffLines, commentLine(header, comment.Content, comment.Reactions, diffLine{
 				ThreadID:     threadID,
 				CommentIdx:   ci,
 				ThreadStatus: thread.Status,
-			})
+			}))
 		}
 	}
 }
@@ -714,9 +1271,24 @@ func (m *DiffModel) updateDiffViewport() {
 		return
 	}
 
+	// Unselected lines are cached: highlighting every line on each cursor move
+	// makes scrolling large files sluggish, and only the selected line's
+	// rendering changes between moves.
+	if len(m.renderCache) != len(m.diffLines) {
+		m.renderCache = make([]string, len(m.diffLines))
+	}
+
 	var sb strings.Builder
 	for i, line := range m.diffLines {
-		rendered := m.renderDiffLine(line, i == m.selectedLine)
+		var rendered string
+		if i == m.selectedLine || m.inSelection(i) {
+			rendered = m.renderDiffLine(line, true)
+		} else {
+			if m.renderCache[i] == "" {
+				m.renderCache[i] = m.renderDiffLine(line, false)
+			}
+			rendered = m.renderCache[i]
+		}
 		sb.WriteString(rendered)
 		if i < len(m.diffLines)-1 {
 			sb.WriteString("\n")
@@ -742,19 +1314,29 @@ func (m *DiffModel) renderDiffLine(line diffLine, selected bool) string {
 		oldNum := fmt.Sprintf("%4d", line.OldNum)
 		newNum := fmt.Sprintf("%4d", line.NewNum)
 		gutter := m.styles.DiffLineNum.Render(oldNum) + " " + m.styles.DiffLineNum.Render(newNum)
-		result = gutter + "  " + m.styles.DiffContext.Render(line.Content)
+		result = gutter + "  " + m.renderCode(line, m.styles.DiffContext, m.styles.DiffContext, selected)
 
 	case diffLineAdded:
 		oldNum := "    "
 		newNum := fmt.Sprintf("%4d", line.NewNum)
 		gutter := m.styles.DiffLineNum.Render(oldNum) + " " + m.styles.DiffLineNum.Render(newNum)
-		result = gutter + m.styles.DiffAdded.Render(" +"+line.Content)
+		base := m.styles.DiffAdded
+		if line.Spans != nil {
+			base = m.styles.DiffAddedLine
+		}
+		marker := m.styles.DiffAdded.Background(base.GetBackground()).Render(" +")
+		result = gutter + marker + m.renderCode(line, base, m.styles.DiffAddedEmph, selected)
 
 	case diffLineRemoved:
 		oldNum := fmt.Sprintf("%4d", line.OldNum)
 		newNum := "    "
 		gutter := m.styles.DiffLineNum.Render(oldNum) + " " + m.styles.DiffLineNum.Render(newNum)
-		result = gutter + m.styles.DiffRemoved.Render(" -"+line.Content)
+		base := m.styles.DiffRemoved
+		if line.Spans != nil {
+			base = m.styles.DiffRemovedLine
+		}
+		marker := m.styles.DiffRemoved.Background(base.GetBackground()).Render(" -")
+		result = gutter + marker + m.renderCode(line, base, m.styles.DiffRemovedEmph, selected)
 
 	case diffLineComment:
 		isResolved := line.ThreadStatus == "fixed" || line.ThreadStatus == "wontFix" || line.ThreadStatus == "closed"
@@ -769,23 +1351,24 @@ func (m *DiffModel) renderDiffLine(line diffLine, selected bool) string {
 			firstIndent = ""
 			contIndent = ""
 		}
-		contentLines := strings.Split(line.Content, "\n")
-		for i, l := range contentLines {
-			if i == 0 {
-				contentLines[i] = firstIndent + l
-			} else {
-				contentLines[i] = contIndent + l
-			}
-		}
-		rendered := m.styles.Info.Render(strings.Join(contentLines, "\n"))
 		if isResolved && line.CommentIdx == 0 {
-			result = m.styles.DiffCommentResolved.Render("[Resolved]") + " " + rendered
+			prefix := m.styles.DiffCommentResolved.Render("[Resolved]") + " "
+			result = prefix + m.renderComment(line, ansi.StringWidth(prefix), contIndent)
 		} else {
-			result = rendered
+			result = firstIndent + m.renderComment(line, ansi.StringWidth(firstIndent), contIndent)
 		}
 
 	case diffLineFileHeader:
 		result = m.styles.DiffHeader.Render(line.Content)
+
+	case diffLineDraft:
+		result = m.styles.Warning.Render(line.Content)
+
+	case diffLineSuggestionOld:
+		result = "    " + m.styles.DiffRemoved.Render("- "+line.Content)
+
+	case diffLineSuggestionNew:
+		result = "    " + m.styles.DiffAdded.Render("+ "+line.Content)
 	}
 
 	if selected {
@@ -795,19 +1378,148 @@ func (m *DiffModel) renderDiffLine(line diffLine, selected bool) string {
 	return result
 }
 
+// renderCode renders the code part of a context/added/removed line. Lines with
+// syntax spans are colored token by token on top of base, so the add/remove
+// background tint shows through; the selected line swaps base for the
+// selection style. Word-level changed segments render on emph instead of base,
+// so only the edited part of a modified line stands out.
+func (m *DiffModel) renderCode(line diffLine, base, emph lipgloss.Style, selected bool) string {
+	if selected {
+		base = m.styles.Selected
+	}
+	spans := line.Spans
+	if spans == nil {
+		if line.Changed == nil {
+			return base.Render(line.Content)
+		}
+		spans = []syntax.Span{{Text: line.Content, Kind: syntax.KindPlain}}
+	}
+	if line.Changed == nil {
+		return m.highlighter.Render(spans, base)
+	}
+
+	var sb strings.Builder
+	for _, run := range emphasisRuns(spans, line.Changed) {
+		style := base
+		if run.changed {
+			style = emph
+		}
+		sb.WriteString(m.highlighter.Render(run.spans, style))
+	}
+	return sb.String()
+}
+
+// emphasisRun is a stretch of spans that is either entirely inside or
+// entirely outside the changed segments of a line.
+type emphasisRun struct {
+	spans   []syntax.Span
+	changed bool
+}
+
+// emphasisRuns cuts spans at the boundaries of segs (byte offsets into the
+// concatenated span text) and groups the pieces into alternating runs.
+func emphasisRuns(spans []syntax.Span, segs []diff.Segment) []emphasisRun {
+	inChanged := func(off int) bool {
+		for _, sg := range segs {
+			if off >= sg.Start && off < sg.End {
+				return true
+			}
+		}
+		return false
+	}
+	// nextBoundary returns the first segment edge after off, or limit.
+	nextBoundary := func(off, limit int) int {
+		b := limit
+		for _, sg := range segs {
+			if sg.Start > off && sg.Start < b {
+				b = sg.Start
+			}
+			if sg.End > off && sg.End < b {
+				b = sg.End
+			}
+		}
+		return b
+	}
+
+	var runs []emphasisRun
+	off := 0
+	for _, sp := range spans {
+		end := off + len(sp.Text)
+		for pos := off; pos < end; {
+			cut := nextBoundary(pos, end)
+			piece := syntax.Span{Text: sp.Text[pos-off : cut-off], Kind: sp.Kind}
+			changed := inChanged(pos)
+			if n := len(runs); n > 0 && runs[n-1].changed == changed {
+				runs[n-1].spans = append(runs[n-1].spans, piece)
+			} else {
+				runs = append(runs, emphasisRun{spans: []syntax.Span{piece}, changed: changed})
+			}
+			pos = cut
+		}
+		off = end
+	}
+	return runs
+}
+
+// renderComment renders a comment line's author header followed by its text
+// as markdown, with lines after the first indented by contIndent. The text
+// starts beside the header (which begins prefixWidth columns in) when its
+// first line fits there, and below the header otherwise.
+func (m *DiffModel) renderComment(line diffLine, prefixWidth int, contIndent string) string {
+	start, end := line.BodyStart, line.BodyEnd
+	if end == 0 {
+		end = len(line.Content)
+	}
+	header := line.Content[:start]
+	body := m.mentions.Replace(line.Content[start:end])
+	var trailer string
+	if rest := line.Content[end:]; rest != "" {
+		trailer = m.styles.Info.Render(rest)
+	}
+
+	room := m.width - prefixWidth - ansi.StringWidth(header)
+	if short := m.markdown.Render(body, room-ansi.StringWidth(trailer)); !strings.Contains(short, "\n") {
+		return m.styles.Info.Render(header) + short + trailer
+	}
+
+	lines := strings.Split(m.markdown.Render(body, m.width-len(contIndent)), "\n")
+	lines[len(lines)-1] += trailer
+	for i, l := range lines[1:] {
+		if l != "" {
+			lines[i+1] = contIndent + l
+		}
+	}
+	if header == "" || ansi.StringWidth(lines[0]) <= room {
+		return m.styles.Info.Render(header) + strings.Join(lines, "\n")
+	}
+	return m.styles.Info.Render(strings.TrimRight(header, " ")) + "\n" + contIndent + strings.Join(lines, "\n")
+}
+
 // visualLineForDiffLine returns the visual line number for a given diffLine index.
-// Multi-line comments occupy more than one visual line, so diffLine index != visual line.
+// Multi-line comments and drafts occupy more than one visual line, so
+// diffLine index != visual line.
 func (m *DiffModel) visualLineForDiffLine(idx int) int {
 	vis := 0
 	for i := 0; i < idx && i < len(m.diffLines); i++ {
 		vis++ // the line separator between entries
-		if m.diffLines[i].Type == diffLineComment {
-			vis += strings.Count(m.diffLines[i].Content, "\n")
-		}
+		vis += m.extraLines(i)
 	}
 	return vis
 }
 
+// extraLines returns how many lines beyond its first diffLines[i] takes up
+// once rendered. Only comments span several, as their markdown wraps.
+func (m *DiffModel) extraLines(i int) int {
+	line := m.diffLines[i]
+	if line.Type != diffLineComment {
+		return strings.Count(line.Content, "\n")
+	}
+	if i < len(m.renderCache) && m.renderCache[i] != "" {
+		return strings.Count(m.renderCache[i], "\n")
+	}
+	return strings.Count(m.renderDiffLine(line, false), "\n")
+}
+
 // ensureDiffLineVisible scrolls the viewport to keep selected line visible
 func (m *DiffModel) ensureDiffLineVisible() {
 	if !m.ready || len(m.diffLines) == 0 {
@@ -894,13 +1606,17 @@ func parseThreadID(id string) int {
 // --- Messages ---
 
 type changedFilesMsg struct {
-	changes []provider.IterationChange
-	err     error
+	changes   []provider.IterationChange
+	iteration int      // the latest iteration, which changes compare against the base
+	viewed    []string // files marked as viewed on the server
+	parent    string   // for a single commit, the parent its changes are relative to
+	err       error
 }
 
 type fileDiffMsg struct {
 	diff        *diff.FileDiff
 	fileThreads map[int][]provider.Thread
+	leftThreads map[int][]provider.Thread
 	err         error
 }
 
@@ -914,6 +1630,17 @@ type threadsRefreshMsg struct {
 	err     error
 }
 
+type suggestionAppliedMsg struct {
+	branch string
+	err    error
+}
+
+type reviewSubmittedMsg struct {
+	verdict provider.ReviewVerdict
+	count   int // drafts included in the submission
+	err     error
+}
+
 // exitDiffViewMsg signals that the user wants to leave the diff view
 type exitDiffViewMsg struct{}
 
@@ -925,36 +1652,46 @@ func (m *DiffModel) fetchChangedFiles() tea.Cmd {
 		if m.client == nil {
 			return changedFilesMsg{err: fmt.Errorf("no client available")}
 		}
-
-		// Get iterations
-		iterations, err := m.client.GetPRIterations(m.pr.Identity.Scope, m.pr.RepositoryID, prNumericID(m.pr))
-		if err != nil {
-			return changedFilesMsg{err: err}
-		}
-		if len(iterations) == 0 {
-			return changedFilesMsg{changes: nil, err: nil}
+		if m.commit != nil {
+			return loadCommitChanges(m.client, m.pr, m.commit.ID)
 		}
+		return loadChangedFiles(m.client, m.pr)
+	}
+}
 
-		// Get changes from the latest iteration compared to base
-		latestID := iterations[len(iterations)-1].ID
-		changes, err := m.client.GetPRIterationChanges(m.pr.Identity.Scope, m.pr.RepositoryID, prNumericID(m.pr), latestID)
-		if err != nil {
-			return changedFilesMsg{err: err}
-		}
+// loadChangedFiles loads the changes of pr's latest iteration compared to
+// its base, along with the files marked as viewed on the server. A failure
+// to read the viewed files is not an error; local marks still apply.
+func loadChangedFiles(client provider.Provider, pr provider.PullRequest) changedFilesMsg {
+	iterations, err := client.GetPRIterations(pr.Identity.Scope, pr.RepositoryID, prNumericID(pr))
+	if err != nil {
+		return changedFilesMsg{err: err}
+	}
+	if len(iterations) == 0 {
+		return changedFilesMsg{changes: nil, err: nil}
+	}
 
-		return changedFilesMsg{changes: changes}
+	latestID := iterations[len(iterations)-1].ID
+	changes, err := client.GetPRIterationChanges(pr.Identity.Scope, pr.RepositoryID, prNumericID(pr), latestID)
+	if err != nil {
+		return changedFilesMsg{err: err}
 	}
+
+	viewed, _ := client.GetViewedFiles(pr.Identity.Scope, pr.RepositoryID, prNumericID(pr))
+	return changedFilesMsg{changes: changes, iteration: latestID, viewed: viewed}
 }
 
-// fetchFileDiff loads file content at both branches and computes the diff
-func (m *DiffModel) fetchFileDiff(change provider.IterationChange) tea.Cmd {
+// fetchFileDiff loads file content on both sides and computes the diff
+// with opts. A file over opts.MaxLines yields a *diff.TooLargeError.
+func (m *DiffModel) fetchFileDiff(change provider.IterationChange, opts diff.Options) tea.Cmd {
 	return func() tea.Msg {
 		// When the backend supplies a ready-made unified-diff patch (GitHub's PR
 		// files API), render it directly. This needs no client and avoids fetching
 		// full file content at branch refs — robust for deleted files, fork PRs,
 		// and search-sourced PRs whose source/target refs may be unavailable.
 		// Azure leaves Patch empty and falls through to the content-fetch path
-		// below (unchanged).
+		// below (unchanged). Patches are parsed in linear time and already
+		// carry their own context, so opts does not apply to them.
 		if change.Patch != "" {
 			fileDiff := &diff.FileDiff{
 				Path:       change.Path,
@@ -962,18 +1699,15 @@ func (m *DiffModel) fetchFileDiff(change provider.IterationChange) tea.Cmd {
 				OldPath:    change.OriginalPath,
 				Hunks:      diff.ParseUnifiedDiff(change.Patch),
 			}
-			fileThreads := diff.MapThreadsToLinesP(m.threads, change.Path)
-			return fileDiffMsg{diff: fileDiff, fileThreads: fileThreads}
+			fileThreads, leftThreads := m.inlineThreads(change.Path)
+			return fileDiffMsg{diff: fileDiff, fileThreads: fileThreads, leftThreads: leftThreads}
 		}
 
 		if m.client == nil {
 			return fileDiffMsg{err: fmt.Errorf("no client available")}
 		}
 
-		scope := m.pr.Identity.Scope
-		repoID := m.pr.RepositoryID
-		targetBranch := branchShortName(m.pr.TargetRefName)
-		sourceBranch := branchShortName(m.pr.SourceRefName)
+		readOld, readNew := m.contentReaders()
 
 		var oldContent, newContent string
 		var err error
@@ -981,42 +1715,45 @@ func (m *DiffModel) fetchFileDiff(change provider.IterationChange) tea.Cmd {
 		switch change.ChangeType {
 		case "add":
 			// New file: no old content
-			newContent, err = m.client.GetFileContent(scope, repoID, change.Path, sourceBranch)
+			newContent, err = readNew(change.Path)
 			if err != nil {
 				return fileDiffMsg{err: err}
 			}
 		case "delete":
 			// Deleted file: no new content
-			oldContent, err = m.client.GetFileContent(scope, repoID, change.Path, targetBranch)
+			oldContent, err = readOld(change.Path)
 			if err != nil {
 				return fileDiffMsg{err: err}
 			}
 		case "rename":
-			// Renamed: old path on target, new path on source
+			// Renamed: old path on the old side, new path on the new side
 			oldPath := change.OriginalPath
 			if oldPath == "" {
 				oldPath = change.Path
 			}
-			oldContent, err = m.client.GetFileContent(scope, repoID, oldPath, targetBranch)
+			oldContent, err = readOld(oldPath)
 			if err != nil {
 				return fileDiffMsg{err: err}
 			}
-			newContent, err = m.client.GetFileContent(scope, repoID, change.Path, sourceBranch)
+			newContent, err = readNew(change.Path)
 			if err != nil {
 				return fileDiffMsg{err: err}
 			}
 		default: // "edit"
-			oldContent, err = m.client.GetFileContent(scope, repoID, change.Path, targetBranch)
+			oldContent, err = readOld(change.Path)
 			if err != nil {
 				return fileDiffMsg{err: err}
 			}
-			newContent, err = m.client.GetFileContent(scope, repoID, change.Path, sourceBranch)
+			newContent, err = readNew(change.Path)
 			if err != nil {
 				return fileDiffMsg{err: err}
 			}
 		}
 
-		hunks := diff.ComputeDiff(oldContent, newContent, 5)
+		hunks, err := diff.ComputeDiffWithOptions(oldContent, newContent, opts)
+		if err != nil {
+			return fileDiffMsg{err: err}
+		}
 		fileDiff := &diff.FileDiff{
 			Path:       change.Path,
 			ChangeType: change.ChangeType,
@@ -1024,19 +1761,19 @@ func (m *DiffModel) fetchFileDiff(change provider.IterationChange) tea.Cmd {
 			Hunks:      hunks,
 		}
 
-		fileThreads := diff.MapThreadsToLinesP(m.threads, change.Path)
+		fileThreads, leftThreads := m.inlineThreads(change.Path)
 
-		return fileDiffMsg{diff: fileDiff, fileThreads: fileThreads}
+		return fileDiffMsg{diff: fileDiff, fileThreads: fileThreads, leftThreads: leftThreads}
 	}
 }
 
-// createCodeComment creates a new code comment
-func (m *DiffModel) createCodeComment(filePath string, line int, content string) tea.Cmd {
+// createCodeComment creates a new code comment anchored to rng
+func (m *DiffModel) createCodeComment(filePath string, rng provider.LineRange, content string) tea.Cmd {
 	return func() tea.Msg {
 		if m.client == nil {
 			return commentResultMsg{err: fmt.Errorf("no client available")}
 		}
-		_, err := m.client.AddPRCodeComment(m.pr.Identity.Scope, m.pr.RepositoryID, prNumericID(m.pr), filePath, line, content)
+		_, err := m.client.AddPRCodeComment(m.pr.Identity.Scope, m.pr.RepositoryID, prNumericID(m.pr), filePath, rng, content)
 		if err != nil {
 			return commentResultMsg{err: err}
 		}
@@ -1086,6 +1823,18 @@ func (m *DiffModel) resolveThread(threadID int) tea.Cmd {
 	}
 }
 
+// resolveMentions looks up the people behind "@<GUID>" mentions in the
+// PR's comments that have no display name yet.
+func (m *DiffModel) resolveMentions() tea.Cmd {
+	var texts []string
+	for _, t := range m.threads {
+		for _, c := range t.Comments {
+			texts = append(texts, c.Content)
+		}
+	}
+	return components.ResolveMentions(m.client, m.pr.Identity.Scope, m.mentions.Missing(texts...))
+}
+
 // refreshThreads re-fetches threads from the API
 func (m *DiffModel) refreshThreads() tea.Cmd {
 	return func() tea.Msg {
@@ -9,7 +9,9 @@ import (
 	"github.com/Elpulgo/azdo/internal/diff"
 	"github.com/Elpulgo/azdo/internal/provider"
 	"github.com/Elpulgo/azdo/internal/ui/styles"
+	"github.com/Elpulgo/azdo/internal/ui/syntax"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
 )
 
 func newTestDiffModel() *DiffModel {
@@ -49,7 +51,7 @@ func TestDiffModel_FetchFileDiff_UsesPatchWithoutClient(t *testing.T) {
 		Patch:      "@@ -1,2 +0,0 @@\n-line one\n-line two",
 	}
 
-	cmd := m.fetchFileDiff(change)
+	cmd := m.fetchFileDiff(change, m.diffOpts)
 	if cmd == nil {
 		t.Fatal("fetchFileDiff returned nil cmd")
 	}
@@ -68,6 +70,44 @@ func TestDiffModel_FetchFileDiff_UsesPatchWithoutClient(t *testing.T) {
 	}
 }
 
+// A file over the configured size limit must not be diffed silently: the
+// view shows a prompt, x re-fetches with the limit lifted, esc goes back.
+func TestDiffModel_TooLargeDiff_PromptsBeforeLoading(t *testing.T) {
+	m := newTestDiffModel()
+	m.SetSize(80, 24)
+	m.SetDiffOptions(diff.Options{Context: 3, MaxLines: 10})
+
+	change := provider.IterationChange{Path: "package-lock.json", ChangeType: "edit"}
+	m.currentFile = &change
+	m.Update(fileDiffMsg{err: &diff.TooLargeError{Lines: 40000, Limit: 10}})
+
+	if m.err != nil {
+		t.Fatalf("err = %v, want the too-large prompt instead of an error", m.err)
+	}
+	view := m.View()
+	if !strings.Contains(view, "Diff too large (40000 lines, limit 10)") || !strings.Contains(view, "Press x to load anyway") {
+		t.Errorf("view does not show the too-large prompt:\n%s", view)
+	}
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	if cmd == nil {
+		t.Fatal("x should start a fetch without the size limit")
+	}
+	if m.tooLarge != nil || !m.loading {
+		t.Errorf("after x: tooLarge=%v loading=%v, want nil/true", m.tooLarge, m.loading)
+	}
+	if m.diffOpts.MaxLines != 10 {
+		t.Errorf("diffOpts.MaxLines = %d, lifting the limit must be one-shot", m.diffOpts.MaxLines)
+	}
+
+	m.loading = false
+	m.Update(fileDiffMsg{err: &diff.TooLargeError{Lines: 40000, Limit: 10}})
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.tooLarge != nil || m.currentFile != nil || m.viewMode != DiffFileList {
+		t.Errorf("after esc: tooLarge=%v currentFile=%v viewMode=%d, want back on the file list", m.tooLarge, m.currentFile, m.viewMode)
+	}
+}
+
 func TestNewDiffModel(t *testing.T) {
 	m := newTestDiffModel()
 
@@ -254,6 +294,154 @@ func TestDiffModel_BuildDiffLines_WithComments(t *testing.T) {
 	}
 }
 
+func TestDiffModel_BuildDiffLines_LeftThreadUnderRemovedLine(t *testing.T) {
+	m := newTestDiffModel()
+	m.SetSize(80, 24)
+
+	m.currentDiff = &diff.FileDiff{
+		Path: "/src/main.go",
+		Hunks: []diff.Hunk{{
+			OldStart: 1, OldCount: 2, NewStart: 1, NewCount: 1,
+			Lines: []diff.Line{
+				{Type: diff.Context, Content: "keep", OldNum: 1, NewNum: 1},
+				{Type: diff.Removed, Content: "gone", OldNum: 2},
+			},
+		}},
+	}
+	m.fileThreads = map[int][]provider.Thread{}
+	m.leftThreads = map[int][]provider.Thread{
+		2: {{
+			Identity: provider.Identity{ID: "7"},
+			Status:   "active",
+			Range:    provider.LineAt(provider.SideLeft, 2),
+			Comments: []provider.Comment{{Identity: provider.Identity{ID: "1"}, Content: "why remove?", AuthorName: "Alice"}},
+		}},
+	}
+
+	m.buildDiffLines()
+
+	// hunk header + keep + gone + comment
+	if len(m.diffLines) != 4 {
+		t.Fatalf("Expected 4 diffLines, got %d", len(m.diffLines))
+	}
+	got := m.diffLines[3]
+	if got.Type != diffLineComment || got.ThreadID != 7 {
+		t.Fatalf("diffLines[3] = %+v, want the left-side thread under the removed line", got)
+	}
+	if !strings.Contains(got.Content, "on old line 2:") {
+		t.Errorf("comment content = %q, want the old-line anchor label", got.Content)
+	}
+}
+
+// newSelectionTestModel opens a two-hunk diff:
+//
+//	0 @@ header
+//	1 ctx   1/1
+//	2 -     2/-
+//	3 +     -/2
+//	4 +     -/3
+//	5 ctx   3/4
+//	6 @@ header
+//	7 ctx  20/21
+func newSelectionTestModel() *DiffModel {
+	m := newTestDiffModel()
+	m.SetSize(80, 24)
+	change := provider.IterationChange{Path: "/src/main.go", ChangeType: "edit"}
+	m.currentFile = &change
+	m.currentDiff = &diff.FileDiff{
+		Path: change.Path,
+		Hunks: []diff.Hunk{
+			{OldStart: 1, OldCount: 3, NewStart: 1, NewCount: 4, Lines: []diff.Line{
+				{Type: diff.Context, Content: "a", OldNum: 1, NewNum: 1},
+				{Type: diff.Removed, Content: "b", OldNum: 2},
+				{Type: diff.Added, Content: "B", NewNum: 2},
+				{Type: diff.Added, Content: "C", NewNum: 3},
+				{Type: diff.Context, Content: "d", OldNum: 3, NewNum: 4},
+			}},
+			{OldStart: 20, OldCount: 1, NewStart: 21, NewCount: 1, Lines: []diff.Line{
+				{Type: diff.Context, Content: "z", OldNum: 20, NewNum: 21},
+			}},
+		},
+	}
+	m.viewMode = DiffFileView
+	m.buildDiffLines()
+	m.updateDiffViewport()
+	return m
+}
+
+func TestDiffModel_VisualSelection_CommentsOnNewFileRange(t *testing.T) {
+	m := newSelectionTestModel()
+	m.selectedLine = 1
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+	if !m.selecting {
+		t.Fatal("v on a code line should start a selection")
+	}
+	for i := 0; i < 3; i++ {
+		m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	}
+	for i := 1; i <= 4; i++ {
+		if !m.inSelection(i) {
+			t.Errorf("line %d should be in the selection", i)
+		}
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+	if m.inputMode != InputNewComment {
+		t.Fatalf("inputMode = %d, want InputNewComment", m.inputMode)
+	}
+	want := provider.LineRange{Side: provider.SideRight, StartLine: 1, EndLine: 3}
+	if m.commentRange != want {
+		t.Errorf("commentRange = %+v, want %+v (removed line covered by the new-file range)", m.commentRange, want)
+	}
+	if !strings.Contains(m.textInput.Placeholder, "lines 1-3") {
+		t.Errorf("placeholder = %q, want it to name the range", m.textInput.Placeholder)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.selecting {
+		t.Error("cancelling the comment should clear the selection")
+	}
+}
+
+func TestDiffModel_CommentOnRemovedLine_AnchorsToOldFile(t *testing.T) {
+	m := newSelectionTestModel()
+	m.selectedLine = 2
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+
+	if m.inputMode != InputNewComment {
+		t.Fatalf("inputMode = %d, want InputNewComment", m.inputMode)
+	}
+	want := provider.LineAt(provider.SideLeft, 2)
+	if m.commentRange != want {
+		t.Errorf("commentRange = %+v, want %+v", m.commentRange, want)
+	}
+}
+
+func TestDiffModel_VisualSelection_RejectsCrossHunkRange(t *testing.T) {
+	m := newSelectionTestModel()
+	m.selectedLine = 5
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+
+	if m.inputMode != InputNone {
+		t.Errorf("inputMode = %d, want no input for a cross-hunk selection", m.inputMode)
+	}
+	if !strings.Contains(m.GetStatusMessage(), "one hunk") {
+		t.Errorf("status = %q, want a hunk-boundary message", m.GetStatusMessage())
+	}
+
+	// esc cancels the selection without leaving the diff
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.selecting || cmd != nil {
+		t.Errorf("esc: selecting=%v cmd=%v, want selection cleared and no exit", m.selecting, cmd)
+	}
+}
+
 func TestDiffModel_BuildDiffLines_CommentTimestamps(t *testing.T) {
 	m := newTestDiffModel()
 	m.SetSize(80, 24)
@@ -1063,6 +1251,83 @@ func TestDiffModel_VisualLineForDiffLine(t *testing.T) {
 	}
 }
 
+func TestDiffModel_CommentMarkdownIsRendered(t *testing.T) {
+	m := newSelectionTestModel()
+	m.threads = []provider.Thread{{
+		Identity: provider.Identity{ID: "7"},
+		Status:   "active",
+		FilePath: "/src/main.go",
+		Line:     2,
+		Range:    provider.LineAt(provider.SideRight, 2),
+		Comments: []provider.Comment{
+			{Identity: provider.Identity{ID: "1"}, AuthorName: "Ann", Content: "Use **errors.Is** here:\n\n```go\nif errors.Is(err, io.EOF) {\n```"},
+			{Identity: provider.Identity{ID: "2"}, AuthorName: "Bob", Content: "`done`"},
+		},
+	}}
+	m.rebuildFileDiff()
+
+	view := ansi.Strip(m.View())
+	for _, want := range []string{"Use errors.Is here:", "│ if errors.Is(err, io.EOF) {", ": done"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "**") || strings.Contains(view, "```") {
+		t.Errorf("markdown syntax left in the view:\n%s", view)
+	}
+
+	// Paragraph, blank line and code: the reply starts three lines further down.
+	if got, want := m.visualLineForDiffLine(5)-m.visualLineForDiffLine(4), 3; got != want {
+		t.Errorf("first comment spans %d lines, want %d", got, want)
+	}
+}
+
+// mentionProvider resolves one known identity; every other method panics
+// via the nil embedded interface.
+type mentionProvider struct {
+	provider.Provider
+	resolved []string
+}
+
+func (p *mentionProvider) ResolvePeople(scope string, ids []string) ([]provider.Person, error) {
+	p.resolved = append(p.resolved, ids...)
+	return []provider.Person{{ID: "a1b2c3d4-0002-4000-8000-000000000002", DisplayName: "Jane Doe"}}, nil
+}
+
+func TestDiffModel_MentionsRenderAsDisplayNames(t *testing.T) {
+	m := newSelectionTestModel()
+	client := &mentionProvider{}
+	m.client = client
+	m.threads = []provider.Thread{{
+		Identity: provider.Identity{ID: "7"},
+		Status:   "active",
+		FilePath: "/src/main.go",
+		Line:     2,
+		Range:    provider.LineAt(provider.SideRight, 2),
+		Comments: []provider.Comment{
+			{Identity: provider.Identity{ID: "1"}, AuthorName: "Ann", Content: "@<a1b2c3d4-0002-4000-8000-000000000002> can you check?"},
+		},
+	}}
+	m.rebuildFileDiff()
+
+	cmd := m.resolveMentions()
+	if cmd == nil {
+		t.Fatal("resolveMentions() = nil, want a lookup for the mentioned ID")
+	}
+	m, _ = m.Update(cmd())
+	if len(client.resolved) != 1 {
+		t.Errorf("resolved IDs = %v, want the one mention", client.resolved)
+	}
+
+	view := ansi.Strip(m.View())
+	if !strings.Contains(view, "@Jane Doe can you check?") || strings.Contains(view, "@<") {
+		t.Errorf("view should show the mention by name:\n%s", view)
+	}
+	if m.resolveMentions() != nil {
+		t.Error("known mentions should not be looked up again")
+	}
+}
+
 func TestDiffModel_IsInputActive(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -1550,3 +1815,162 @@ func TestDiffModel_ScrollPastMultiLineComments(t *testing.T) {
 		t.Errorf("After scrolling to bottom, scroll percent = %.0f%%, want ~100%%", pct)
 	}
 }
+
+func TestDiffModel_BuildDiffLines_SyntaxSpans(t *testing.T) {
+	m := newTestDiffModel()
+	m.SetSize(80, 24)
+	m.currentDiff = &diff.FileDiff{
+		Path: "/src/main.go",
+		Hunks: []diff.Hunk{{
+			OldStart: 1, OldCount: 2, NewStart: 1, NewCount: 2,
+			Lines: []diff.Line{
+				{Type: diff.Context, Content: "func main() {", OldNum: 1, NewNum: 1},
+				{Type: diff.Removed, Content: `	return "old"`, OldNum: 2},
+				{Type: diff.Added, Content: `	return "new"`, NewNum: 2},
+			},
+		}},
+	}
+	m.fileThreads = map[int][]provider.Thread{}
+	m.buildDiffLines()
+
+	for i := 1; i <= 3; i++ {
+		dl := m.diffLines[i]
+		if dl.Spans == nil {
+			t.Fatalf("diffLines[%d] (%q) has no spans", i, dl.Content)
+		}
+		var joined strings.Builder
+		for _, sp := range dl.Spans {
+			joined.WriteString(sp.Text)
+		}
+		if joined.String() != dl.Content {
+			t.Errorf("diffLines[%d] spans = %q, want %q", i, joined.String(), dl.Content)
+		}
+	}
+	if m.diffLines[0].Spans != nil {
+		t.Error("hunk header should not carry syntax spans")
+	}
+
+	// Highlighted lines still render their content and +/- markers.
+	added := m.renderDiffLine(m.diffLines[3], false)
+	if !strings.Contains(added, "+") || !strings.Contains(added, `return "new"`) {
+		t.Errorf("rendered added line = %q", added)
+	}
+}
+
+func TestDiffModel_BuildDiffLines_NoSpansForUnknownFileType(t *testing.T) {
+	m := newTestDiffModel()
+	m.currentDiff = &diff.FileDiff{
+		Path: "/data/blob.unknownext",
+		Hunks: []diff.Hunk{{
+			Lines: []diff.Line{{Type: diff.Added, Content: "anything", NewNum: 1}},
+		}},
+	}
+	m.fileThreads = map[int][]provider.Thread{}
+	m.buildDiffLines()
+
+	if m.diffLines[1].Spans != nil {
+		t.Errorf("spans = %+v, want nil for a file type without a lexer", m.diffLines[1].Spans)
+	}
+	if got := m.renderDiffLine(m.diffLines[1], false); !strings.Contains(got, "+anything") {
+		t.Errorf("rendered = %q, want plain +anything", got)
+	}
+}
+
+// Moving the selection reuses cached renderings for unselected lines; the
+// viewport content must still match a from-scratch render after each move.
+func TestDiffModel_RenderCache_MatchesFreshRender(t *testing.T) {
+	m := newTestDiffModel()
+	m.SetSize(80, 40)
+	m.viewMode = DiffFileView
+	var lines []diff.Line
+	for i := 1; i <= 10; i++ {
+		lines = append(lines, diff.Line{Type: diff.Context, Content: fmt.Sprintf("x := %d", i), OldNum: i, NewNum: i})
+	}
+	m.currentDiff = &diff.FileDiff{Path: "/a.go", Hunks: []diff.Hunk{{Lines: lines}}}
+	m.fileThreads = map[int][]provider.Thread{}
+	m.buildDiffLines()
+
+	for sel := 0; sel < len(m.diffLines); sel++ {
+		m.selectedLine = sel
+		m.updateDiffViewport()
+		cached := m.viewport.View()
+
+		fresh := newTestDiffModel()
+		fresh.SetSize(80, 40)
+		fresh.viewMode = DiffFileView
+		fresh.currentDiff = m.currentDiff
+		fresh.fileThreads = map[int][]provider.Thread{}
+		fresh.buildDiffLines()
+		fresh.selectedLine = sel
+		fresh.updateDiffViewport()
+
+		if cached != fresh.viewport.View() {
+			t.Fatalf("selection %d: cached render differs from fresh render", sel)
+		}
+	}
+	if len(m.renderCache) != len(m.diffLines) {
+		t.Errorf("renderCache len = %d, want %d", len(m.renderCache), len(m.diffLines))
+	}
+}
+
+func TestEmphasisRuns_SplitsSpansAtSegmentEdges(t *testing.T) {
+	spans := []syntax.Span{
+		{Text: "total", Kind: syntax.KindPlain},
+		{Text: " := ", Kind: syntax.KindOperator},
+		{Text: "computeSum", Kind: syntax.KindFunction},
+		{Text: "(x)", Kind: syntax.KindOperator},
+	}
+	// "computeSum" starts at byte 9; emphasize only "Sum".
+	runs := emphasisRuns(spans, []diff.Segment{{Start: 16, End: 19}})
+
+	var got []string
+	for _, r := range runs {
+		var sb strings.Builder
+		for _, sp := range r.spans {
+			sb.WriteString(sp.Text)
+		}
+		got = append(got, fmt.Sprintf("%v:%s", r.changed, sb.String()))
+	}
+	want := []string{"false:total := compute", "true:Sum", "false:(x)"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("runs = %q, want %q", got, want)
+	}
+	// The cut must keep the token kind of the span it came from.
+	if runs[1].spans[0].Kind != syntax.KindFunction {
+		t.Errorf("emphasized piece kind = %v, want KindFunction", runs[1].spans[0].Kind)
+	}
+}
+
+func TestDiffModel_BuildDiffLines_CarriesIntraLineSegments(t *testing.T) {
+	m := newTestDiffModel()
+	m.currentDiff = &diff.FileDiff{
+		Path:  "/notes.unknownext",
+		Hunks: diff.ComputeDiff("a\nvalue := 1\nb\n", "a\nvalue := 2\nb\n", 1),
+	}
+	m.fileThreads = map[int][]provider.Thread{}
+	m.buildDiffLines()
+
+	var removed, added *diffLine
+	for i := range m.diffLines {
+		switch m.diffLines[i].Type {
+		case diffLineRemoved:
+			removed = &m.diffLines[i]
+		case diffLineAdded:
+			added = &m.diffLines[i]
+		}
+	}
+	if removed == nil || added == nil {
+		t.Fatal("expected one removed and one added line")
+	}
+	if len(removed.Changed) != 1 || removed.Content[removed.Changed[0].Start:removed.Changed[0].End] != "1" {
+		t.Errorf("removed.Changed = %v, want the segment covering \"1\"", removed.Changed)
+	}
+	if len(added.Changed) != 1 || added.Content[added.Changed[0].Start:added.Changed[0].End] != "2" {
+		t.Errorf("added.Changed = %v, want the segment covering \"2\"", added.Changed)
+	}
+
+	// Without a lexer the emphasized line still renders its full content.
+	if got := m.renderDiffLine(*added, false); !strings.Contains(got, "+value := 2") {
+		t.Errorf("rendered = %q, want +value := 2", got)
+	}
+}
@@ -0,0 +1,391 @@
+package pullrequests
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Elpulgo/azdo/internal/provider"
+	"github.com/Elpulgo/azdo/internal/ui/styles"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// prStatusChoice is one option of the panel's status row.
+type prStatusChoice struct {
+	name   string
+	states []provider.StateCategory // nil leaves the backend's default
+}
+
+// prStatusChoices are the status options; the first is the default, which
+// lists what the backend lists without a status filter.
+var prStatusChoices = []prStatusChoice{
+	{name: "Default"},
+	{name: "Active", states: []provider.StateCategory{provider.StateCategoryActive}},
+	{name: "Completed", states: []provider.StateCategory{provider.StateCategoryClosedDone}},
+	{name: "Abandoned", states: []provider.StateCategory{provider.StateCategoryRemoved}},
+	{name: "All", states: []provider.StateCategory{
+		provider.StateCategoryActive, provider.StateCategoryClosedDone, provider.StateCategoryRemoved,
+	}},
+}
+
+// prDraftChoices name the draft options, indexed by provider.DraftFilter.
+var prDraftChoices = []string{"Any", "Drafts only", "Exclude drafts"}
+
+// prAgeChoice is one option of the panel's age row.
+type prAgeChoice struct {
+	name   string
+	maxAge time.Duration // 0 means any age
+}
+
+var prAgeChoices = []prAgeChoice{
+	{name: "Any"},
+	{name: "Last day", maxAge: 24 * time.Hour},
+	{name: "Last 7 days", maxAge: 7 * 24 * time.Hour},
+	{name: "Last 30 days", maxAge: 30 * 24 * time.Hour},
+	{name: "Last 90 days", maxAge: 90 * 24 * time.Hour},
+}
+
+// prFilters are the filters set in the filter panel. The age is kept as a
+// choice rather than a time so every fetch uses a fresh cutoff.
+type prFilters struct {
+	status       int // index into prStatusChoices
+	draft        provider.DraftFilter
+	age          int // index into prAgeChoices
+	repository   string
+	targetBranch string
+	author       string
+	labels       []string
+}
+
+// listOpts returns the filters as list options, with the age cutoff
+// relative to now.
+func (f prFilters) listOpts(now time.Time) provider.ListOpts {
+	opts := provider.ListOpts{
+		States:       prStatusChoices[f.status].states,
+		Repository:   f.repository,
+		TargetBranch: f.targetBranch,
+		Author:       f.author,
+		Draft:        f.draft,
+		Labels:       f.labels,
+	}
+	if maxAge := prAgeChoices[f.age].maxAge; maxAge > 0 {
+		opts.CreatedAfter = now.Add(-maxAge)
+	}
+	return opts
+}
+
+// isActive reports whether any filter is set.
+func (f prFilters) isActive() bool {
+	return f.summary() != ""
+}
+
+// summary describes the set filters for the status bar; "" when none is set.
+func (f prFilters) summary() string {
+	var parts []string
+	if f.status != 0 {
+		parts = append(parts, prStatusChoices[f.status].name)
+	}
+	if f.draft != provider.DraftAny {
+		parts = append(parts, prDraftChoices[f.draft])
+	}
+	if f.age != 0 {
+		parts = append(parts, prAgeChoices[f.age].name)
+	}
+	if f.repository != "" {
+		parts = append(parts, "repo "+f.repository)
+	}
+	if f.targetBranch != "" {
+		parts = append(parts, "→ "+f.targetBranch)
+	}
+	if f.author != "" {
+		parts = append(parts, "by "+f.author)
+	}
+	for _, label := range f.labels {
+		parts = append(parts, "#"+label)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// splitLabels parses the comma-separated labels typed in the panel.
+func splitLabels(s string) []string {
+	var labels []string
+	for _, label := range strings.Split(s, ",") {
+		if label = strings.TrimSpace(label); label != "" {
+			labels = append(labels, label)
+		}
+	}
+	return labels
+}
+
+// Rows of the filter panel: three choice rows followed by the text inputs.
+const (
+	filterRowStatus = iota
+	filterRowDraft
+	filterRowAge
+	filterRowRepository
+	filterRowTargetBranch
+	filterRowAuthor
+	filterRowLabels
+	filterRowCount
+)
+
+// filterInputRows is the number of text input rows.
+const filterInputRows = filterRowCount - filterRowRepository
+
+var filterRowNames = [filterRowCount]string{
+	"Status", "Draft", "Age", "Repository", "Target branch", "Author", "Labels",
+}
+
+// prFiltersAppliedMsg is emitted when the panel is confirmed with enter.
+type prFiltersAppliedMsg struct {
+	filters prFilters
+}
+
+// filterPanel is the modal editing the PR list filters. ↑/↓ and tab move
+// between rows, ←/→ change the choice rows, enter applies, ctrl+r clears
+// every filter and esc cancels.
+type filterPanel struct {
+	styles  *styles.Styles
+	visible bool
+	width   int
+	height  int
+	cursor  int
+	filters prFilters // the choice rows; the text rows live in inputs
+	inputs  [filterInputRows]textinput.Model
+}
+
+func newFilterPanel(s *styles.Styles) filterPanel {
+	p := filterPanel{styles: s}
+	placeholders := [filterInputRows]string{"name", "e.g. main", "name or login", "comma-separated, all required"}
+	for i := range p.inputs {
+		ti := textinput.New()
+		ti.Prompt = ""
+		ti.Placeholder = placeholders[i]
+		ti.CharLimit = 200
+		p.inputs[i] = ti
+	}
+	return p
+}
+
+// Show opens the panel on the given filters.
+func (p *filterPanel) Show(f prFilters) {
+	p.filters = f
+	p.inputs[0].SetValue(f.repository)
+	p.inputs[1].SetValue(f.targetBranch)
+	p.inputs[2].SetValue(f.author)
+	p.inputs[3].SetValue(strings.Join(f.labels, ", "))
+	p.cursor = 0
+	p.visible = true
+	p.focusCursor()
+}
+
+// Hide closes the panel without applying it.
+func (p *filterPanel) Hide() {
+	p.visible = false
+	for i := range p.inputs {
+		p.inputs[i].Blur()
+	}
+}
+
+// IsVisible returns whether the panel is open.
+func (p filterPanel) IsVisible() bool {
+	return p.visible
+}
+
+// SetSize sets the area the panel is centered in.
+func (p *filterPanel) SetSize(width, height int) {
+	p.width = width
+	p.height = height
+}
+
+// current returns the filters as edited so far.
+func (p filterPanel) current() prFilters {
+	f := p.filters
+	f.repository = strings.TrimSpace(p.inputs[0].Value())
+	f.targetBranch = strings.TrimSpace(p.inputs[1].Value())
+	f.author = strings.TrimSpace(p.inputs[2].Value())
+	f.labels = splitLabels(p.inputs[3].Value())
+	return f
+}
+
+// focusCursor focuses the input under the cursor, if any.
+func (p *filterPanel) focusCursor() {
+	for i := range p.inputs {
+		if filterRowRepository+i == p.cursor {
+			p.inputs[i].Focus()
+		} else {
+			p.inputs[i].Blur()
+		}
+	}
+}
+
+// cycle moves the choice row under the cursor by delta, wrapping around.
+func (p *filterPanel) cycle(delta int) {
+	wrap := func(v, n int) int { return ((v+delta)%n + n) % n }
+	switch p.cursor {
+	case filterRowStatus:
+		p.filters.status = wrap(p.filters.status, len(prStatusChoices))
+	case filterRowDraft:
+		p.filters.draft = provider.DraftFilter(wrap(int(p.filters.draft), len(prDraftChoices)))
+	case filterRowAge:
+		p.filters.age = wrap(p.filters.age, len(prAgeChoices))
+	}
+}
+
+// Update handles the panel's keys.
+func (p filterPanel) Update(msg tea.KeyMsg) (filterPanel, tea.Cmd) {
+	if !p.visible {
+		return p, nil
+	}
+	switch msg.String() {
+	case "esc":
+		p.Hide()
+		return p, nil
+	case "enter":
+		filters := p.current()
+		p.Hide()
+		return p, func() tea.Msg { return prFiltersAppliedMsg{filters: filters} }
+	case "ctrl+r":
+		p.Show(prFilters{})
+		return p, nil
+	case "up", "shift+tab":
+		p.cursor = (p.cursor + filterRowCount - 1) % filterRowCount
+		p.focusCursor()
+		return p, nil
+	case "down", "tab":
+		p.cursor = (p.cursor + 1) % filterRowCount
+		p.focusCursor()
+		return p, nil
+	}
+
+	if p.cursor < filterRowRepository {
+		switch msg.String() {
+		case "left", "h":
+			p.cycle(-1)
+		case "right", "l", " ":
+			p.cycle(1)
+		}
+		return p, nil
+	}
+	var cmd tea.Cmd
+	i := p.cursor - filterRowRepository
+	p.inputs[i], cmd = p.inputs[i].Update(msg)
+	return p, cmd
+}
+
+// View renders the panel centered in its area.
+func (p filterPanel) View() string {
+	if !p.visible {
+		return ""
+	}
+	const labelWidth = 15
+	const width = 60
+
+	choices := [filterRowRepository]string{
+		prStatusChoices[p.filters.status].name,
+		prDraftChoices[p.filters.draft],
+		prAgeChoices[p.filters.age].name,
+	}
+	var rows []string
+	for row := 0; row < filterRowCount; row++ {
+		cursor := "  "
+		if row == p.cursor {
+			cursor = "> "
+		}
+		var value string
+		if row < filterRowRepository {
+			value = "‹ " + choices[row] + " ›"
+		} else {
+			value = p.inputs[row-filterRowRepository].View()
+		}
+		label := fmt.Sprintf("%-*s", labelWidth, filterRowNames[row])
+		line := cursor + label + value
+		style := lipgloss.NewStyle().Width(width).Foreground(p.styles.Theme.GetForeground())
+		if row == p.cursor {
+			style = style.Foreground(p.styles.Theme.GetSelectForeground()).Background(p.styles.Theme.GetSelectBackground())
+		}
+		rows = append(rows, style.Render(line))
+	}
+
+	title := lipgloss.NewStyle().
+		Foreground(p.styles.Theme.GetPrimary()).
+		Bold(true).
+		Render("Filter pull requests")
+	help := lipgloss.NewStyle().
+		Foreground(p.styles.Theme.GetForegroundMuted()).
+		Render("↑/↓/tab: move • ←/→: change • enter: apply • ctrl+r: clear • esc: cancel")
+
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		title, "", strings.Join(rows, "\n"), "", help)
+	modal := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(p.styles.Theme.GetBorder()).
+		Padding(1, 2).
+		Render(content)
+
+	if p.width > 0 && p.height > 0 {
+		modal = lipgloss.Place(p.width, p.height, lipgloss.Center, lipgloss.Center, modal)
+	}
+	return modal
+}
+
+// prSort is the order of the PR list.
+type prSort int
+
+const (
+	sortCreated        prSort = iota // newest first, as fetched
+	sortUpdated                      // most recently updated first
+	sortReviewProgress               // largest share of approving reviewers first
+	sortRepository                   // by repository name
+	prSortCount
+)
+
+var prSortNames = [prSortCount]string{"created", "updated", "review progress", "repository"}
+
+// String returns the name shown in the status bar.
+func (s prSort) String() string {
+	return prSortNames[s]
+}
+
+// reviewProgress returns the share of pr's reviewers that approved, or -1
+// without reviewers so those PRs sort after PRs nobody approved yet.
+func reviewProgress(pr provider.PullRequest) float64 {
+	if len(pr.Reviewers) == 0 {
+		return -1
+	}
+	approved := 0
+	for _, r := range pr.Reviewers {
+		if r.Kind == provider.VoteKindApproved || r.Kind == provider.VoteKindApprovedWithSuggestions {
+			approved++
+		}
+	}
+	return float64(approved) / float64(len(pr.Reviewers))
+}
+
+// sortPRs returns prs in the order by; ties keep the newest first. The
+// input is not modified.
+func sortPRs(prs []provider.PullRequest, by prSort) []provider.PullRequest {
+	sorted := append([]provider.PullRequest(nil), prs...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		switch by {
+		case sortUpdated:
+			if !a.UpdatedDate.Equal(b.UpdatedDate) {
+				return a.UpdatedDate.After(b.UpdatedDate)
+			}
+		case sortReviewProgress:
+			if pa, pb := reviewProgress(a), reviewProgress(b); pa != pb {
+				return pa > pb
+			}
+		case sortRepository:
+			if ra, rb := strings.ToLower(a.RepositoryName), strings.ToLower(b.RepositoryName); ra != rb {
+				return ra < rb
+			}
+		}
+		return a.CreationDate.After(b.CreationDate)
+	})
+	return sorted
+}
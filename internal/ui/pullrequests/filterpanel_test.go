@@ -0,0 +1,159 @@
+package pullrequests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Elpulgo/azdo/internal/provider"
+	"github.com/Elpulgo/azdo/internal/ui/styles"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// filterProvider records the options of the last PR list fetch.
+type filterProvider struct {
+	provider.Provider
+	prs      []provider.PullRequest
+	lastOpts provider.ListOpts
+}
+
+func (p *filterProvider) IsMultiProject() bool { return false }
+
+func (p *filterProvider) ListPullRequests(top int, opts provider.ListOpts) ([]provider.PullRequest, error) {
+	p.lastOpts = opts
+	return p.prs, nil
+}
+
+func TestFilterPanel_EditAndApply(t *testing.T) {
+	p := newFilterPanel(styles.DefaultStyles())
+	p.Show(prFilters{})
+
+	p, _ = p.Update(tea.KeyMsg{Type: tea.KeyRight}) // status: Active
+	p, _ = p.Update(tea.KeyMsg{Type: tea.KeyRight}) // status: Completed
+	p, _ = p.Update(tea.KeyMsg{Type: tea.KeyDown})
+	p, _ = p.Update(tea.KeyMsg{Type: tea.KeyLeft}) // draft: wraps to Exclude drafts
+	p, _ = p.Update(tea.KeyMsg{Type: tea.KeyTab})
+	p, _ = p.Update(tea.KeyMsg{Type: tea.KeyTab}) // repository
+	p, _ = p.Update(keyRunes("api"))
+	p.cursor = filterRowLabels
+	p.focusCursor()
+	p, _ = p.Update(keyRunes("bug, ui ,"))
+
+	p, cmd := p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if p.IsVisible() || cmd == nil {
+		t.Fatal("enter should close the panel and apply the filters")
+	}
+	f := cmd().(prFiltersAppliedMsg).filters
+
+	now := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	opts := f.listOpts(now)
+	if len(opts.States) != 1 || opts.States[0] != provider.StateCategoryClosedDone {
+		t.Errorf("States = %v, want completed", opts.States)
+	}
+	if opts.Draft != provider.DraftExcluded || opts.Repository != "api" {
+		t.Errorf("Draft = %v, Repository = %q; want excluded, api", opts.Draft, opts.Repository)
+	}
+	if len(opts.Labels) != 2 || opts.Labels[0] != "bug" || opts.Labels[1] != "ui" {
+		t.Errorf("Labels = %q, want [bug ui]", opts.Labels)
+	}
+	if !opts.CreatedAfter.IsZero() {
+		t.Errorf("CreatedAfter = %v, want no age filter", opts.CreatedAfter)
+	}
+	if got, want := f.summary(), "Completed, Exclude drafts, repo api, #bug, #ui"; got != want {
+		t.Errorf("summary() = %q, want %q", got, want)
+	}
+}
+
+func TestFilterPanel_AgeIsRelativeToNow(t *testing.T) {
+	now := time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC)
+	opts := prFilters{age: 2}.listOpts(now)
+	if want := now.Add(-7 * 24 * time.Hour); !opts.CreatedAfter.Equal(want) {
+		t.Errorf("CreatedAfter = %v, want %v", opts.CreatedAfter, want)
+	}
+}
+
+func TestFilterPanel_EscCancelsAndCtrlRClears(t *testing.T) {
+	p := newFilterPanel(styles.DefaultStyles())
+	p.Show(prFilters{status: 2, author: "ann"})
+
+	p, _ = p.Update(tea.KeyMsg{Type: tea.KeyCtrlR})
+	if f := p.current(); f.isActive() {
+		t.Errorf("ctrl+r left filters set: %q", f.summary())
+	}
+	p, cmd := p.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if p.IsVisible() || cmd != nil {
+		t.Error("esc should close the panel without applying")
+	}
+}
+
+func TestModel_FilterPanelRefetchesWithFilters(t *testing.T) {
+	client := &filterProvider{}
+	m := NewModelWithStyles(client, styles.DefaultStyles())
+	m, _ = m.Update(SetPRsMsg{PRs: []provider.PullRequest{
+		{Identity: provider.Identity{ID: "1"}, IsDraft: true},
+		{Identity: provider.Identity{ID: "2"}},
+	}})
+
+	m, _ = m.Update(keyRunes("F"))
+	if !m.IsFilterPanelVisible() {
+		t.Fatal("F should open the filter panel")
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyLeft}) // draft: Exclude drafts
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m, cmd = m.Update(cmd())
+
+	if got := len(m.list.Items()); got != 1 {
+		t.Errorf("list has %d PRs, want the draft filtered out", got)
+	}
+	if cmd == nil {
+		t.Fatal("applying filters should refetch")
+	}
+	cmd()
+	if client.lastOpts.Draft != provider.DraftExcluded {
+		t.Errorf("fetch Draft = %v, want DraftExcluded", client.lastOpts.Draft)
+	}
+	if m.FilterSummary() != "Exclude drafts" {
+		t.Errorf("FilterSummary() = %q", m.FilterSummary())
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.FilterSummary() != "" || len(m.list.Items()) != 2 {
+		t.Errorf("esc should clear the filters, summary %q with %d PRs", m.FilterSummary(), len(m.list.Items()))
+	}
+}
+
+func TestModel_SortCycles(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+	approved := provider.Reviewer{Kind: provider.VoteKindApproved}
+	waiting := provider.Reviewer{Kind: provider.VoteKindNoVote}
+	prs := []provider.PullRequest{
+		{Identity: provider.Identity{ID: "1"}, RepositoryName: "web", CreationDate: day(3), UpdatedDate: day(3),
+			Reviewers: []provider.Reviewer{waiting}},
+		{Identity: provider.Identity{ID: "2"}, RepositoryName: "api", CreationDate: day(2), UpdatedDate: day(9),
+			Reviewers: []provider.Reviewer{approved, waiting}},
+		{Identity: provider.Identity{ID: "3"}, RepositoryName: "Core", CreationDate: day(1), UpdatedDate: day(5),
+			Reviewers: []provider.Reviewer{approved}},
+	}
+	m := NewModelWithStyles(nil, styles.DefaultStyles())
+	m, _ = m.Update(SetPRsMsg{PRs: prs})
+
+	ids := func() string {
+		var s string
+		for _, pr := range m.list.Items() {
+			s += pr.Identity.ID
+		}
+		return s
+	}
+	want := []struct{ order, ids string }{
+		{"updated", "231"},
+		{"review progress", "321"},
+		{"repository", "231"},
+		{"", "123"},
+	}
+	for _, w := range want {
+		m, _ = m.Update(keyRunes("O"))
+		if m.SortOrder() != w.order || ids() != w.ids {
+			t.Errorf("sort %q = %s, want %q = %s", m.SortOrder(), ids(), w.order, w.ids)
+		}
+	}
+}
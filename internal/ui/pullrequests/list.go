@@ -4,9 +4,13 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/Elpulgo/azdo/internal/azdevops"
+	"github.com/Elpulgo/azdo/internal/diff"
+	"github.com/Elpulgo/azdo/internal/localgit"
 	"github.com/Elpulgo/azdo/internal/provider"
+	"github.com/Elpulgo/azdo/internal/state"
 	"github.com/Elpulgo/azdo/internal/ui/components"
 	"github.com/Elpulgo/azdo/internal/ui/components/listview"
 	"github.com/Elpulgo/azdo/internal/ui/components/table"
@@ -35,9 +39,15 @@ type Model struct {
 	styles         *styles.Styles
 	myPRsOnly      bool
 	asReviewerOnly bool
+	repoOnly       bool // show only PRs of the working copy's repository
 	allPRs         []provider.PullRequest
 	myPRs          []provider.PullRequest
 	asReviewerPRs  []provider.PullRequest
+	diffOpts       diff.Options
+	reviewDrafts   *state.DraftStore
+	opts           *sharedOptions
+	filterPanel    filterPanel
+	sortBy         prSort
 
 	// pendingDetailID is the PR ID requested by startup state restore.
 	// Cleared on the first populate (whether or not the lookup succeeded)
@@ -46,6 +56,17 @@ type Model struct {
 	pendingRestoreHandled bool
 }
 
+// sharedOptions holds settings read by the list's row rendering and by
+// every detail view opened from it. The listview callbacks share it by
+// pointer, so settings made through With* after construction still reach
+// them.
+type sharedOptions struct {
+	viewedStore *state.ViewedStore
+	localRepo   *localgit.Repo
+	repo        *repoScope // nil when not started in a working copy of a configured repository
+	filters     prFilters  // set in the filter panel; read by every fetch
+}
+
 // NewModel creates a new pull request list model with default styles
 func NewModel(client provider.Provider) Model {
 	return NewModelWithStyles(client, styles.DefaultStyles())
@@ -66,6 +87,7 @@ var prBaseColumns = []listview.ColumnSpec{
 // NewModelWithStyles creates a new pull request list model with custom styles
 func NewModelWithStyles(client provider.Provider, s *styles.Styles) Model {
 	isMulti := client != nil && client.IsMultiProject()
+	opts := &sharedOptions{}
 
 	// toColumns derives column specs from the current items, mirroring the
 	// cell gating in prsToRows / prsToRowsMulti exactly:
@@ -91,9 +113,12 @@ func NewModelWithStyles(client provider.Provider, s *styles.Styles) Model {
 		return cols
 	}
 
-	toRows := prsToRows
+	baseRows := prsToRows
 	if isMulti {
-		toRows = prsToRowsMulti
+		baseRows = prsToRowsMulti
+	}
+	toRows := func(items []provider.PullRequest, st *styles.Styles) []table.Row {
+		return baseRows(opts.repo.markCurrentBranch(items), st)
 	}
 
 	filterFunc := filterPR
@@ -108,10 +133,12 @@ func NewModelWithStyles(client provider.Provider, s *styles.Styles) Model {
 		ToRows:         toRows,
 		ToColumns:      toColumns,
 		Fetch: func() tea.Cmd {
-			return fetchPullRequestsMulti(client)
+			return fetchPullRequestsMulti(client, opts.filters.listOpts(time.Now()))
 		},
 		EnterDetail: func(item provider.PullRequest, st *styles.Styles, w, h int) (listview.DetailView, tea.Cmd) {
 			d := NewDetailModelWithStyles(client, item, st)
+			d.SetViewedStore(opts.viewedStore)
+			d.SetLocalRepo(opts.localRepo)
 			d.SetSize(w, h)
 			return &detailAdapter{d}, d.Init()
 		},
@@ -122,10 +149,13 @@ func NewModelWithStyles(client provider.Provider, s *styles.Styles) Model {
 	}
 
 	return Model{
-		list:     listview.New(cfg, s),
-		client:   client,
-		viewMode: ViewList,
-		styles:   s,
+		list:        listview.New(cfg, s),
+		client:      client,
+		viewMode:    ViewList,
+		styles:      s,
+		diffOpts:    diff.DefaultOptions(),
+		opts:        opts,
+		filterPanel: newFilterPanel(s),
 	}
 }
 
@@ -154,74 +184,96 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		if errors.As(msg.err, &partialErr) {
 			m.allPRs = msg.prs
 			if m.myPRsOnly {
-				return m, fetchMyPullRequestsMulti(m.client)
+				return m, fetchMyPullRequestsMulti(m.client, m.listOpts())
 			}
 			if m.asReviewerOnly {
-				return m, fetchPullRequestsAsReviewerMulti(m.client)
+				return m, fetchPullRequestsAsReviewerMulti(m.client, m.listOpts())
 			}
-			m.list = m.list.HandleFetchResult(msg.prs, nil)
+			m.list = m.list.HandleFetchResult(m.visible(msg.prs), nil)
 			return m.withRestore(nil)
 		}
 		m.allPRs = msg.prs
 		if m.myPRsOnly {
-			return m, fetchMyPullRequestsMulti(m.client)
+			return m, fetchMyPullRequestsMulti(m.client, m.listOpts())
 		}
 		if m.asReviewerOnly {
-			return m, fetchPullRequestsAsReviewerMulti(m.client)
+			return m, fetchPullRequestsAsReviewerMulti(m.client, m.listOpts())
 		}
-		m.list = m.list.HandleFetchResult(msg.prs, msg.err)
+		m.list = m.list.HandleFetchResult(m.visible(msg.prs), msg.err)
 		return m.withRestore(nil)
 	case myPullRequestsMsg:
 		if msg.err != nil {
 			var partialErr *azdevops.PartialError
 			if errors.As(msg.err, &partialErr) {
 				m.myPRs = msg.prs
-				m.list = m.list.SetItems(msg.prs)
+				m.list = m.list.SetItems(m.visible(msg.prs))
 				return m.withRestore(nil)
 			}
 			// On error, fall back to showing all items
 			m.myPRsOnly = false
 			m.myPRs = nil
-			m.list = m.list.SetItems(m.allPRs)
+			m.list = m.list.SetItems(m.visible(m.allPRs))
 			return m.withRestore(nil)
 		}
 		m.myPRs = msg.prs
-		m.list = m.list.SetItems(msg.prs)
+		m.list = m.list.SetItems(m.visible(msg.prs))
 		return m.withRestore(nil)
 	case asReviewerPullRequestsMsg:
 		if msg.err != nil {
 			var partialErr *azdevops.PartialError
 			if errors.As(msg.err, &partialErr) {
 				m.asReviewerPRs = msg.prs
-				m.list = m.list.SetItems(msg.prs)
+				m.list = m.list.SetItems(m.visible(msg.prs))
 				return m.withRestore(nil)
 			}
 			m.asReviewerOnly = false
 			m.asReviewerPRs = nil
-			m.list = m.list.SetItems(m.allPRs)
+			m.list = m.list.SetItems(m.visible(m.allPRs))
 			return m.withRestore(nil)
 		}
 		m.asReviewerPRs = msg.prs
-		m.list = m.list.SetItems(msg.prs)
+		m.list = m.list.SetItems(m.visible(msg.prs))
 		return m.withRestore(nil)
+	case prFiltersAppliedMsg:
+		// Show the cached PRs filtered right away, then refetch: the
+		// backend may have left out PRs the new filters include. The
+		// all-PRs fetch chains to the "my PRs" or "as reviewer" one.
+		m.opts.filters = msg.filters
+		m.list = m.list.SetItems(m.visible(m.shownSource()))
+		return m, fetchPullRequestsMulti(m.client, m.listOpts())
 	case SetPRsMsg:
 		m.allPRs = msg.PRs
 		if !m.myPRsOnly && !m.asReviewerOnly {
-			m.list = m.list.SetItems(msg.PRs)
+			m.list = m.list.SetItems(m.visible(msg.PRs))
 			return m.withRestore(nil)
 		}
 		return m, nil
 	case tea.KeyMsg:
+		if m.filterPanel.IsVisible() {
+			var cmd tea.Cmd
+			m.filterPanel, cmd = m.filterPanel.Update(msg)
+			return m, cmd
+		}
+		if msg.String() == "F" && !m.list.IsSearching() && m.viewMode == ViewList {
+			m.filterPanel.SetSize(m.width, m.height)
+			m.filterPanel.Show(m.opts.filters)
+			return m, nil
+		}
+		if msg.String() == "O" && !m.list.IsSearching() && m.viewMode == ViewList {
+			m.sortBy = (m.sortBy + 1) % prSortCount
+			m.list = m.list.SetItems(m.visible(m.shownSource()))
+			return m, nil
+		}
 		if msg.String() == "m" && !m.list.IsSearching() && m.viewMode == ViewList {
 			m.myPRsOnly = !m.myPRsOnly
 			if m.myPRsOnly {
 				// Mutually exclusive with as-reviewer
 				m.asReviewerOnly = false
 				m.asReviewerPRs = nil
-				return m, fetchMyPullRequestsMulti(m.client)
+				return m, fetchMyPullRequestsMulti(m.client, m.listOpts())
 			}
 			m.myPRs = nil
-			m.list = m.list.SetItems(m.allPRs)
+			m.list = m.list.SetItems(m.visible(m.allPRs))
 			return m, nil
 		}
 		if msg.String() == "A" && !m.list.IsSearching() && m.viewMode == ViewList {
@@ -229,28 +281,39 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			if m.asReviewerOnly {
 				m.myPRsOnly = false
 				m.myPRs = nil
-				return m, fetchPullRequestsAsReviewerMulti(m.client)
+				return m, fetchPullRequestsAsReviewerMulti(m.client, m.listOpts())
 			}
 			m.asReviewerPRs = nil
-			m.list = m.list.SetItems(m.allPRs)
+			m.list = m.list.SetItems(m.visible(m.allPRs))
 			return m, nil
 		}
-		// esc clears an active "my PRs" / "as-reviewer" filter, mirroring how
-		// esc exits search. It only ever turns a filter OFF — never on — so the
-		// full list is restored. When searching, esc is left to exit search first.
+		if msg.String() == "g" && !m.list.IsSearching() && m.viewMode == ViewList && m.opts.repo != nil {
+			return m.toggleRepoFilter(), nil
+		}
+		// esc clears an active "my PRs" / "as-reviewer" filter, then the
+		// repository filter, then the filter panel's filters, mirroring how
+		// esc exits search. It only ever turns a filter OFF — never on — so
+		// the full list is restored. When searching, esc is left to exit
+		// search first.
 		if msg.String() == "esc" && !m.list.IsSearching() && m.viewMode == ViewList {
 			if m.myPRsOnly {
 				m.myPRsOnly = false
 				m.myPRs = nil
-				m.list = m.list.SetItems(m.allPRs)
+				m.list = m.list.SetItems(m.visible(m.allPRs))
 				return m, nil
 			}
 			if m.asReviewerOnly {
 				m.asReviewerOnly = false
 				m.asReviewerPRs = nil
-				m.list = m.list.SetItems(m.allPRs)
+				m.list = m.list.SetItems(m.visible(m.allPRs))
 				return m, nil
 			}
+			if m.IsRepoFilterActive() {
+				return m.toggleRepoFilter(), nil
+			}
+			if m.opts.filters.isActive() {
+				return m.Update(prFiltersAppliedMsg{})
+			}
 		}
 	}
 
@@ -283,6 +346,9 @@ func (m Model) updateDetail(msg tea.Msg) (Model, tea.Cmd) {
 			pr := detail.GetPR()
 			threads := detail.GetThreads()
 			m.diffView = NewDiffModel(m.client, pr, threads, m.styles)
+			m.diffView.SetDiffOptions(m.diffOpts)
+			m.diffView.SetReviewDrafts(m.reviewDrafts)
+			m.diffView.SetViewedFiles(detail.viewed)
 			m.diffView.SetSize(m.width, m.height)
 			m.viewMode = ViewDiff
 			// Open directly into general comments view
@@ -297,6 +363,9 @@ func (m Model) updateDetail(msg tea.Msg) (Model, tea.Cmd) {
 			pr := detail.GetPR()
 			threads := detail.GetThreads()
 			m.diffView = NewDiffModel(m.client, pr, threads, m.styles)
+			m.diffView.SetDiffOptions(m.diffOpts)
+			m.diffView.SetReviewDrafts(m.reviewDrafts)
+			m.diffView.SetViewedFiles(detail.viewed)
 			m.diffView.SetSize(m.width, m.height)
 			m.viewMode = ViewDiff
 			// Initialize and immediately open the selected file
@@ -304,12 +373,25 @@ func (m Model) updateDetail(msg tea.Msg) (Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case openCommitDiffMsg:
+		// User pressed Enter on a commit in the detail view - review it on its own
+		if adapter, ok := m.list.Detail().(*detailAdapter); ok {
+			detail := adapter.model
+			m.diffView = NewDiffModel(m.client, detail.GetPR(), detail.GetThreads(), m.styles)
+			m.diffView.SetDiffOptions(m.diffOpts)
+			m.diffView.SetCommit(msg.commit)
+			m.diffView.SetSize(m.width, m.height)
+			m.viewMode = ViewDiff
+			return m, m.diffView.Init()
+		}
+		return m, nil
+
 	case tea.KeyMsg:
 		if msg.String() == "esc" {
 			// If the detail view has a modal open (e.g. vote picker),
 			// let it handle esc first instead of navigating back
 			if adapter, ok := m.list.Detail().(*detailAdapter); ok {
-				if adapter.model.votePicker.IsVisible() {
+				if adapter.model.hasModal() {
 					var cmd tea.Cmd
 					m.list, cmd = m.list.Update(msg)
 					return m, cmd
@@ -339,6 +421,10 @@ func (m Model) updateDiffView(msg tea.Msg) (Model, tea.Cmd) {
 	case exitDiffViewMsg:
 		m.viewMode = ViewDetail
 		m.diffView = nil
+		// Show files marked as viewed in the diff view.
+		if adapter, ok := m.list.Detail().(*detailAdapter); ok && adapter.model.ready {
+			adapter.model.updateViewportContent()
+		}
 		return m, nil
 	case tea.WindowSizeMsg:
 		m.diffView.SetSize(msg.Width, msg.Height)
@@ -436,6 +522,34 @@ func (m Model) WithPendingDetailRestore(id int) Model {
 	return m
 }
 
+// WithDiffOptions sets the options used to compute file diffs in the diff
+// view (context lines, whitespace handling and the size limit).
+func (m Model) WithDiffOptions(opts diff.Options) Model {
+	m.diffOpts = opts
+	return m
+}
+
+// WithReviewDrafts sets the store that persists pending-review drafts, so a
+// review session survives leaving the diff view or a crash.
+func (m Model) WithReviewDrafts(store *state.DraftStore) Model {
+	m.reviewDrafts = store
+	return m
+}
+
+// WithViewedFiles sets the store that persists which files of each pull
+// request were marked as viewed.
+func (m Model) WithViewedFiles(store *state.ViewedStore) Model {
+	m.opts.viewedStore = store
+	return m
+}
+
+// WithLocalRepo sets the git working copy azdo was started in, so pull
+// requests of its repository can be checked out from the detail view.
+func (m Model) WithLocalRepo(repo *localgit.Repo) Model {
+	m.opts.localRepo = repo
+	return m
+}
+
 // tryRestoreDetail attempts to open detail for the pending ID, if any.
 // Returns the (possibly updated) model and the detail's Init cmd. Always
 // marks the intent as handled on the first call.
@@ -479,6 +593,40 @@ func (m Model) IsAsReviewerActive() bool {
 	return m.asReviewerOnly
 }
 
+// listOpts returns the list options of the filter panel's filters.
+func (m Model) listOpts() provider.ListOpts {
+	return m.opts.filters.listOpts(time.Now())
+}
+
+// FilterSummary describes the filter panel's active filters; "" when none.
+func (m Model) FilterSummary() string {
+	return m.opts.filters.summary()
+}
+
+// SortOrder returns the name of the list's sort order; "" for the default
+// newest-first order.
+func (m Model) SortOrder() string {
+	if m.sortBy == sortCreated {
+		return ""
+	}
+	return m.sortBy.String()
+}
+
+// IsFilterPanelVisible returns true while the filter panel is open.
+func (m Model) IsFilterPanelVisible() bool {
+	return m.filterPanel.IsVisible()
+}
+
+// FilterPanelView renders the filter panel.
+func (m Model) FilterPanelView() string {
+	return m.filterPanel.View()
+}
+
+// SetFilterPanelSize sets the area the filter panel is centered in.
+func (m *Model) SetFilterPanelSize(width, height int) {
+	m.filterPanel.SetSize(width, height)
+}
+
 // detailAdapter wraps *DetailModel to satisfy listview.DetailView
 type detailAdapter struct {
 	model *DetailModel
@@ -531,7 +679,7 @@ func prsToRows(items []provider.PullRequest, s *styles.Styles) []table.Row {
 		branchInfo := fmt.Sprintf("%s → %s", branchShortName(pr.SourceRefName), branchShortName(pr.TargetRefName))
 		cells := table.Row{
 			statusIconWithStyles(pr.StatusCategory, pr.IsDraft, s),
-			pr.Title,
+			titleCell(pr, s),
 			branchInfo,
 			pr.CreatedByName,
 			pr.RepositoryName,
@@ -635,7 +783,7 @@ func prsToRowsMulti(items []provider.PullRequest, s *styles.Styles) []table.Row
 		cells := table.Row{
 			pr.Identity.ScopeDisplay,
 			statusIconWithStyles(pr.StatusCategory, pr.IsDraft, s),
-			pr.Title,
+			titleCell(pr, s),
 			branchInfo,
 			pr.CreatedByName,
 			pr.RepositoryName,
@@ -708,34 +856,35 @@ type SetPRsMsg struct {
 }
 
 // fetchPullRequestsMulti fetches pull requests from all projects via the provider.
-func fetchPullRequestsMulti(client provider.Provider) tea.Cmd {
+func fetchPullRequestsMulti(client provider.Provider, opts provider.ListOpts) tea.Cmd {
 	return func() tea.Msg {
 		if client == nil {
 			return pullRequestsMsg{prs: nil, err: nil}
 		}
-		prs, err := client.ListPullRequests(25, provider.ListOpts{})
+		prs, err := client.ListPullRequests(25, opts)
 		return pullRequestsMsg{prs: prs, err: err}
 	}
 }
 
 // fetchMyPullRequestsMulti fetches pull requests created by the authenticated user.
-func fetchMyPullRequestsMulti(client provider.Provider) tea.Cmd {
+func fetchMyPullRequestsMulti(client provider.Provider, opts provider.ListOpts) tea.Cmd {
+	opts.Mine = true
 	return func() tea.Msg {
 		if client == nil {
 			return myPullRequestsMsg{prs: nil, err: nil}
 		}
-		prs, err := client.ListMyPullRequests(25, provider.ListOpts{Mine: true})
+		prs, err := client.ListMyPullRequests(25, opts)
 		return myPullRequestsMsg{prs: prs, err: err}
 	}
 }
 
 // fetchPullRequestsAsReviewerMulti fetches pull requests where the authenticated user is a reviewer.
-func fetchPullRequestsAsReviewerMulti(client provider.Provider) tea.Cmd {
+func fetchPullRequestsAsReviewerMulti(client provider.Provider, opts provider.ListOpts) tea.Cmd {
 	return func() tea.Msg {
 		if client == nil {
 			return asReviewerPullRequestsMsg{prs: nil, err: nil}
 		}
-		prs, err := client.ListPullRequestsAsReviewer(25, provider.ListOpts{})
+		prs, err := client.ListPullRequestsAsReviewer(25, opts)
 		return asReviewerPullRequestsMsg{prs: prs, err: err}
 	}
 }
@@ -0,0 +1,115 @@
+package pullrequests
+
+import (
+	"github.com/Elpulgo/azdo/internal/localgit"
+	"github.com/Elpulgo/azdo/internal/provider"
+)
+
+// currentBranchMarker prefixes the title of the PR whose source branch is
+// checked out in the working copy.
+const currentBranchMarker = "⎇ "
+
+// repoScope is the configured repository of the git working copy azdo
+// was started in.
+type repoScope struct {
+	remote localgit.Remote
+	branch string // checked-out branch; "" when HEAD is detached
+}
+
+// contains reports whether pr belongs to the repository.
+func (r *repoScope) contains(pr provider.PullRequest) bool {
+	_, ok := localgit.RemoteFor([]localgit.Remote{r.remote}, pr)
+	return ok
+}
+
+// isCurrentBranch reports whether pr is opened from the checked-out branch.
+// Azure DevOps reports the source as a full ref, GitHub as a bare name.
+func (r *repoScope) isCurrentBranch(pr provider.PullRequest) bool {
+	return r != nil && r.branch != "" && branchShortName(pr.SourceRefName) == r.branch && r.contains(pr)
+}
+
+// markCurrentBranch returns items with currentBranchMarker in front of the
+// title of the PRs for the checked-out branch. The input is not modified;
+// it is returned as is when nothing is marked.
+func (r *repoScope) markCurrentBranch(items []provider.PullRequest) []provider.PullRequest {
+	var marked []provider.PullRequest
+	for i, pr := range items {
+		if !r.isCurrentBranch(pr) {
+			continue
+		}
+		if marked == nil {
+			marked = append([]provider.PullRequest(nil), items...)
+		}
+		marked[i].Title = currentBranchMarker + pr.Title
+	}
+	if marked == nil {
+		return items
+	}
+	return marked
+}
+
+// WithRepoScope sets the configured repository the working copy belongs
+// to and the branch checked out in it. The list starts filtered to that
+// repository ("g" toggles it) and marks the PR for the branch.
+func (m Model) WithRepoScope(remote localgit.Remote, branch string) Model {
+	m.opts.repo = &repoScope{remote: remote, branch: branch}
+	m.repoOnly = true
+	return m
+}
+
+// IsRepoFilterActive returns true if the list is filtered to the working
+// copy's repository.
+func (m Model) IsRepoFilterActive() bool {
+	return m.repoOnly && m.opts != nil && m.opts.repo != nil
+}
+
+// ActiveRepo returns the name of the repository the list is filtered to.
+func (m Model) ActiveRepo() string {
+	if !m.IsRepoFilterActive() {
+		return ""
+	}
+	return m.opts.repo.remote.Repo
+}
+
+// visible applies the repository filter, the filter panel's filters and the
+// sort order to prs. The backend already applied most filters; applying
+// them again keeps PRs fetched before a filter change out of the list.
+func (m Model) visible(prs []provider.PullRequest) []provider.PullRequest {
+	if m.IsRepoFilterActive() {
+		filtered := make([]provider.PullRequest, 0, len(prs))
+		for _, pr := range prs {
+			if m.opts.repo.contains(pr) {
+				filtered = append(filtered, pr)
+			}
+		}
+		prs = filtered
+	}
+	prs = provider.FilterPullRequests(prs, m.listOpts())
+	if m.sortBy != sortCreated {
+		prs = sortPRs(prs, m.sortBy)
+	}
+	return prs
+}
+
+// shownSource returns the unfiltered PRs behind the list: those of the
+// active "my PRs" or "as reviewer" filter, or all PRs.
+func (m Model) shownSource() []provider.PullRequest {
+	switch {
+	case m.myPRsOnly:
+		return m.myPRs
+	case m.asReviewerOnly:
+		return m.asReviewerPRs
+	default:
+		return m.allPRs
+	}
+}
+
+// toggleRepoFilter turns the repository filter on or off.
+func (m Model) toggleRepoFilter() Model {
+	if m.opts.repo == nil {
+		return m
+	}
+	m.repoOnly = !m.repoOnly
+	m.list = m.list.SetItems(m.visible(m.shownSource()))
+	return m
+}
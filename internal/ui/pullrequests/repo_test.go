@@ -0,0 +1,92 @@
+package pullrequests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Elpulgo/azdo/internal/localgit"
+	"github.com/Elpulgo/azdo/internal/provider"
+	"github.com/Elpulgo/azdo/internal/ui/styles"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func repoTestPRs() []provider.PullRequest {
+	pr := func(id, repo, branch string) provider.PullRequest {
+		return provider.PullRequest{
+			Identity:       provider.Identity{Kind: provider.KindAzure, Scope: "proj", ID: id},
+			Title:          "PR " + id,
+			SourceRefName:  "refs/heads/" + branch,
+			TargetRefName:  "refs/heads/main",
+			RepositoryName: repo,
+		}
+	}
+	return []provider.PullRequest{
+		pr("1", "shop", "feature/cart"),
+		pr("2", "infra", "feature/cart"),
+		pr("3", "shop", "fix/login"),
+	}
+}
+
+func TestModel_RepoScopeFiltersAndToggles(t *testing.T) {
+	remote := localgit.Remote{Name: "origin", Kind: provider.KindAzure, Org: "org", Project: "proj", Repo: "shop"}
+	m := NewModelWithStyles(nil, styles.DefaultStyles()).WithRepoScope(remote, "feature/cart")
+	m, _ = m.Update(SetPRsMsg{PRs: repoTestPRs()})
+
+	if !m.IsRepoFilterActive() || m.ActiveRepo() != "shop" {
+		t.Fatalf("repo filter active = %v (%q), want on for shop", m.IsRepoFilterActive(), m.ActiveRepo())
+	}
+	if got := len(m.list.Items()); got != 2 {
+		t.Errorf("filtered list has %d PRs, want 2 from shop", got)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("g")})
+	if m.IsRepoFilterActive() || len(m.list.Items()) != 3 {
+		t.Errorf("g should show all 3 PRs, got %d (active %v)", len(m.list.Items()), m.IsRepoFilterActive())
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("g")})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.IsRepoFilterActive() {
+		t.Error("esc should clear the repo filter")
+	}
+}
+
+func TestModel_WithoutRepoScopeIgnoresG(t *testing.T) {
+	m := NewModelWithStyles(nil, styles.DefaultStyles())
+	m, _ = m.Update(SetPRsMsg{PRs: repoTestPRs()})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("g")})
+	if m.IsRepoFilterActive() || len(m.list.Items()) != 3 {
+		t.Error("g without a working copy should leave the list alone")
+	}
+}
+
+func TestRepoScope_MarksCurrentBranchPR(t *testing.T) {
+	scope := &repoScope{
+		remote: localgit.Remote{Kind: provider.KindAzure, Project: "proj", Repo: "shop"},
+		branch: "feature/cart",
+	}
+	prs := repoTestPRs()
+	marked := scope.markCurrentBranch(prs)
+
+	if !strings.HasPrefix(marked[0].Title, currentBranchMarker) {
+		t.Errorf("PR 1 title = %q, want marked", marked[0].Title)
+	}
+	// Same branch name in another repository, or another branch: unmarked.
+	if marked[1].Title != "PR 2" || marked[2].Title != "PR 3" {
+		t.Errorf("titles = %q, %q, want unmarked", marked[1].Title, marked[2].Title)
+	}
+	if prs[0].Title != "PR 1" {
+		t.Error("markCurrentBranch modified its input")
+	}
+
+	gh := &repoScope{remote: localgit.Remote{Kind: provider.KindGitHub, Org: "o", Repo: "r"}, branch: "feature/cart"}
+	ghPR := provider.PullRequest{Identity: provider.Identity{Kind: provider.KindGitHub, Scope: "o/r"}, SourceRefName: "feature/cart"}
+	if !gh.isCurrentBranch(ghPR) {
+		t.Error("GitHub PRs report bare branch names and should match too")
+	}
+
+	var none *repoScope
+	if got := none.markCurrentBranch(prs); &got[0] != &prs[0] {
+		t.Error("a nil scope should return the items as they are")
+	}
+}
@@ -0,0 +1,332 @@
+package pullrequests
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Elpulgo/azdo/internal/provider"
+	"github.com/Elpulgo/azdo/internal/state"
+	"github.com/Elpulgo/azdo/internal/ui/components"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// SetReviewDrafts attaches the store that persists pending-review drafts and
+// resumes the review session if drafts for this pull request survived an
+// earlier run.
+func (m *DiffModel) SetReviewDrafts(store *state.DraftStore) {
+	m.reviewDrafts = store
+	if store == nil {
+		return
+	}
+	m.drafts = store.Drafts(m.reviewKey())
+	if len(m.drafts) > 0 {
+		m.reviewing = true
+	}
+}
+
+// IsReviewing reports whether a review session is active, i.e. new code
+// comments are drafted locally instead of being posted.
+func (m *DiffModel) IsReviewing() bool {
+	return m.reviewing
+}
+
+// reviewKey identifies this pull request in the draft store.
+func (m *DiffModel) reviewKey() string {
+	return prReviewKey(m.pr)
+}
+
+// saveDrafts writes the current drafts through to the store, reporting a
+// failure in the status bar; the drafts stay in memory either way.
+func (m *DiffModel) saveDrafts() {
+	if m.reviewDrafts == nil {
+		return
+	}
+	if err := m.reviewDrafts.SetDrafts(m.reviewKey(), m.drafts); err != nil {
+		m.statusMessage = fmt.Sprintf("Error saving drafts: %v", err)
+	}
+}
+
+// startOrOpenReview starts a review session, or opens the pending-review
+// pane when one is already running.
+func (m *DiffModel) startOrOpenReview() {
+	if !m.reviewing {
+		m.reviewing = true
+		m.statusMessage = "Review started: comments are saved as drafts until you submit"
+		return
+	}
+	m.reviewReturn = m.viewMode
+	m.viewMode = DiffReviewPane
+	m.reviewIndex = 0
+	m.updateReviewPaneViewport()
+}
+
+// closeReviewPane returns to the view the pane was opened from.
+func (m *DiffModel) closeReviewPane() {
+	m.viewMode = m.reviewReturn
+	if m.viewMode == DiffFileList {
+		m.updateFileListViewport()
+	} else {
+		m.updateDiffViewport()
+	}
+}
+
+// addDraft records a code comment as a pending-review draft.
+func (m *DiffModel) addDraft(filePath string, rng provider.LineRange, content string) {
+	side := provider.SideRight
+	if rng.IsLeft() {
+		side = provider.SideLeft
+	}
+	m.drafts = append(m.drafts, state.DraftComment{
+		FilePath:  filePath,
+		Side:      string(side),
+		StartLine: rng.StartLine,
+		EndLine:   rng.EndLine,
+		Content:   content,
+		CreatedAt: time.Now(),
+	})
+	m.statusMessage = fmt.Sprintf("Draft saved (%d pending)", len(m.drafts))
+	m.saveDrafts()
+	m.rebuildFileDiff()
+}
+
+// rebuildFileDiff re-flattens the open file so added or removed drafts show
+// up inline. buildDiffLines consumes the thread maps, so they are re-mapped
+// first.
+func (m *DiffModel) rebuildFileDiff() {
+	if m.currentFile == nil || m.currentDiff == nil || m.viewingGeneralComments {
+		return
+	}
+	m.fileThreads, m.leftThreads = m.inlineThreads(m.currentFile.Path)
+	m.buildDiffLines()
+	if m.selectedLine >= len(m.diffLines) {
+		m.selectedLine = max(len(m.diffLines)-1, 0)
+	}
+	if m.viewMode == DiffFileView {
+		m.updateDiffViewport()
+	}
+}
+
+// draftRange converts a stored draft back into a comment anchor.
+func draftRange(d state.DraftComment) provider.LineRange {
+	return provider.LineRange{Side: provider.Side(d.Side), StartLine: d.StartLine, EndLine: d.EndLine}
+}
+
+// appendDraftLines appends the drafts for the current file that end at
+// lineNum on side. shown tracks drafts already placed, so a line repeated
+// across hunks does not show them twice.
+func (m *DiffModel) appendDraftLines(side provider.Side, lineNum int, shown []bool) {
+	for i, d := range m.drafts {
+		rng := draftRange(d)
+		if shown[i] || d.FilePath != m.currentDiff.Path || rng.IsLeft() != (side == provider.SideLeft) || rng.EndLine != lineNum {
+			continue
+		}
+		shown[i] = true
+		anchor := ""
+		if label := threadRangeLabel(rng); label != "" {
+			anchor = " on " + label
+		}
+		m.diffLines = append(m.diffLines, diffLine{
+			Type:    diffLineDraft,
+			Content: fmt.Sprintf("✎ [Draft]%s: %s", anchor, d.Content),
+		})
+	}
+}
+
+// reviewBadge renders the pending-review indicator shown next to the view
+// header while a session is active.
+func (m *DiffModel) reviewBadge() string {
+	if !m.reviewing {
+		return ""
+	}
+	return " " + m.styles.Warning.Render(fmt.Sprintf("Reviewing: %d pending", len(m.drafts)))
+}
+
+// reviewContextItem is the footer hint for the review key.
+func (m *DiffModel) reviewContextItem() components.ContextItem {
+	if m.reviewing {
+		return components.ContextItem{Key: "s", Description: fmt.Sprintf("pending review (%d)", len(m.drafts))}
+	}
+	return components.ContextItem{Key: "s", Description: "start review"}
+}
+
+// updateReviewPane handles key events in the pending-review pane.
+func (m *DiffModel) updateReviewPane(msg tea.KeyMsg) (*DiffModel, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		if m.reviewIndex > 0 {
+			m.reviewIndex--
+			m.updateReviewPaneViewport()
+		}
+	case "down", "j":
+		if m.reviewIndex < len(m.drafts)-1 {
+			m.reviewIndex++
+			m.updateReviewPaneViewport()
+		}
+	case "d":
+		if m.reviewIndex < len(m.drafts) {
+			m.drafts = append(m.drafts[:m.reviewIndex], m.drafts[m.reviewIndex+1:]...)
+			if m.reviewIndex >= len(m.drafts) && m.reviewIndex > 0 {
+				m.reviewIndex--
+			}
+			m.saveDrafts()
+			m.rebuildFileDiff()
+			m.updateReviewPaneViewport()
+		}
+	case "a":
+		return m, m.promptReviewSummary(provider.VerdictApprove)
+	case "r":
+		return m, m.promptReviewSummary(provider.VerdictRequestChanges)
+	case "c":
+		return m, m.promptReviewSummary(provider.VerdictComment)
+	case "X":
+		m.drafts = nil
+		m.reviewing = false
+		m.saveDrafts()
+		m.rebuildFileDiff()
+		m.statusMessage = "Review discarded"
+		m.closeReviewPane()
+	case "esc":
+		m.closeReviewPane()
+	}
+	return m, nil
+}
+
+// promptReviewSummary opens the summary input for a submission with verdict.
+func (m *DiffModel) promptReviewSummary(verdict provider.ReviewVerdict) tea.Cmd {
+	m.reviewVerdict = verdict
+	m.inputMode = InputReviewSummary
+	m.textInput.SetValue("")
+	m.textInput.Placeholder = fmt.Sprintf("Summary for %s (optional)...", verdictLabel(verdict))
+	if m.summaryPosted {
+		m.textInput.Placeholder = fmt.Sprintf("Summary already posted; Enter retries the %s...", verdictLabel(verdict))
+	}
+	return m.textInput.Focus()
+}
+
+// verdictLabel is the human-readable form of a review verdict.
+func verdictLabel(v provider.ReviewVerdict) string {
+	switch v {
+	case provider.VerdictApprove:
+		return "approval"
+	case provider.VerdictRequestChanges:
+		return "request changes"
+	default:
+		return "comment"
+	}
+}
+
+// updateReviewPaneViewport rebuilds the pending-review pane content.
+func (m *DiffModel) updateReviewPaneViewport() {
+	if !m.ready {
+		return
+	}
+	var sb strings.Builder
+	for i, d := range m.drafts {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		anchor := fmt.Sprintf("%s:%d", d.FilePath, d.StartLine)
+		if label := threadRangeLabel(draftRange(d)); label != "" {
+			anchor = d.FilePath + " " + label
+		}
+		// Only the first line of a multi-line draft (e.g. a suggestion)
+		// fits the one-row-per-draft layout.
+		content, _, more := strings.Cut(d.Content, "\n")
+		if more {
+			content += " …"
+		}
+		line := fmt.Sprintf("  %s  %s", anchor, content)
+		if i == m.reviewIndex {
+			sb.WriteString(m.styles.Selected.Render(line))
+		} else {
+			sb.WriteString(m.styles.Info.Render(line))
+		}
+	}
+	if len(m.drafts) == 0 {
+		sb.WriteString(m.styles.Muted.Render("  No draft comments: submit to approve or comment with a summary only"))
+	}
+	m.viewport.SetContent(sb.String())
+	if m.reviewIndex < m.viewport.YOffset {
+		m.viewport.SetYOffset(m.reviewIndex)
+	} else if m.reviewIndex >= m.viewport.YOffset+m.viewport.Height {
+		m.viewport.SetYOffset(m.reviewIndex - m.viewport.Height + 1)
+	}
+}
+
+// viewReviewPane renders the pending-review pane.
+func (m *DiffModel) viewReviewPane() string {
+	if !m.ready {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString(m.styles.Header.Render(fmt.Sprintf("Pending review (%d comments)", len(m.drafts))))
+	sb.WriteString("\n")
+	sb.WriteString(m.viewport.View())
+	if m.inputMode != InputNone {
+		sb.WriteString("\n")
+		sb.WriteString(m.textInput.View())
+	}
+	return sb.String()
+}
+
+// submitReview publishes the drafts with the chosen verdict and summary.
+func (m *DiffModel) submitReview(summary string) tea.Cmd {
+	verdict := m.reviewVerdict
+	if verdict == provider.VerdictComment && len(m.drafts) == 0 && summary == "" {
+		m.statusMessage = "Nothing to submit: add a draft or a summary"
+		return nil
+	}
+	review := provider.Review{Verdict: verdict, Body: summary}
+	for _, d := range m.drafts {
+		review.Comments = append(review.Comments, provider.ReviewComment{
+			FilePath: d.FilePath,
+			Range:    draftRange(d),
+			Content:  d.Content,
+		})
+	}
+	m.statusMessage = "Submitting review..."
+	return func() tea.Msg {
+		if m.client == nil {
+			return reviewSubmittedMsg{verdict: verdict, err: fmt.Errorf("no client available")}
+		}
+		err := m.client.SubmitReview(m.pr.Identity.Scope, m.pr.RepositoryID, prNumericID(m.pr), review)
+		return reviewSubmittedMsg{verdict: verdict, count: len(review.Comments), err: err}
+	}
+}
+
+// handleReviewSubmitted ends the session after a successful submit. On a
+// partial failure the drafts that were already published are dropped, and
+// a published summary noted, so a retry does not post them twice.
+func (m *DiffModel) handleReviewSubmitted(msg reviewSubmittedMsg) (*DiffModel, tea.Cmd) {
+	if msg.err != nil {
+		var reviewErr *provider.ReviewError
+		if errors.As(msg.err, &reviewErr) && (reviewErr.Posted > 0 || reviewErr.BodyPosted) {
+			m.summaryPosted = m.summaryPosted || reviewErr.BodyPosted
+			m.drafts = m.drafts[min(reviewErr.Posted, len(m.drafts)):]
+			m.reviewIndex = 0
+			m.saveDrafts()
+			m.rebuildFileDiff()
+			if m.viewMode == DiffReviewPane {
+				m.updateReviewPaneViewport()
+			}
+			m.statusMessage = fmt.Sprintf("Error: %v (%d drafts left)", msg.err, len(m.drafts))
+			return m, m.refreshThreads()
+		}
+		m.statusMessage = fmt.Sprintf("Error: %v", msg.err)
+		return m, nil
+	}
+
+	// Drafts added while the submit was in flight stay pending.
+	m.summaryPosted = false
+	m.drafts = m.drafts[min(msg.count, len(m.drafts)):]
+	m.reviewing = len(m.drafts) > 0
+	m.saveDrafts()
+	m.rebuildFileDiff()
+	m.statusMessage = fmt.Sprintf("Review submitted (%s)", verdictLabel(msg.verdict))
+	if m.viewMode == DiffReviewPane {
+		m.closeReviewPane()
+	}
+	return m, m.refreshThreads()
+}
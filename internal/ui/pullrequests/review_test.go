@@ -0,0 +1,222 @@
+package pullrequests
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Elpulgo/azdo/internal/provider"
+	"github.com/Elpulgo/azdo/internal/state"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// reviewProvider records SubmitReview calls; every other method panics via
+// the nil embedded interface, proving drafting never reaches the backend.
+type reviewProvider struct {
+	provider.Provider
+	submitted []provider.Review
+	err       error
+}
+
+func (p *reviewProvider) SubmitReview(scope, repositoryID string, pullRequestID int, review provider.Review) error {
+	p.submitted = append(p.submitted, review)
+	return p.err
+}
+
+func (p *reviewProvider) GetPRThreads(scope, repositoryID string, pullRequestID int) ([]provider.Thread, error) {
+	return nil, nil
+}
+
+func newDraftStore(t *testing.T) *state.DraftStore {
+	t.Helper()
+	store, err := state.NewDraftStore(filepath.Join(t.TempDir(), "reviews.yaml"))
+	if err != nil {
+		t.Fatalf("NewDraftStore() error = %v", err)
+	}
+	return store
+}
+
+func keyRunes(s string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+// draftComment types content into the comment input opened with "c".
+func draftComment(m *DiffModel, content string) tea.Cmd {
+	m.Update(keyRunes("c"))
+	m.textInput.SetValue(content)
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	return cmd
+}
+
+func TestDiffModel_ReviewSession_DraftsInsteadOfPosting(t *testing.T) {
+	m := newSelectionTestModel() // nil client: posting would error
+	store := newDraftStore(t)
+	m.SetReviewDrafts(store)
+
+	m.Update(keyRunes("s"))
+	if !m.IsReviewing() {
+		t.Fatal("s should start a review session")
+	}
+
+	m.selectedLine = 3 // added line "B", new line 2
+	if cmd := draftComment(m, "rename this"); cmd != nil {
+		t.Errorf("drafting returned a command; comments must not be posted during a review")
+	}
+	if len(m.drafts) != 1 || m.drafts[0].StartLine != 2 || m.drafts[0].Side != "right" {
+		t.Fatalf("drafts = %+v, want one right-side draft on line 2", m.drafts)
+	}
+	if got := m.diffLines[m.selectedLine+1]; got.Type != diffLineDraft || !strings.Contains(got.Content, "rename this") {
+		t.Errorf("line after the commented line = %+v, want the draft", got)
+	}
+
+	// A new diff view for the same PR resumes the session from the store.
+	resumed := newSelectionTestModel()
+	resumed.SetReviewDrafts(store)
+	if !resumed.IsReviewing() || len(resumed.drafts) != 1 {
+		t.Errorf("resumed session: reviewing=%v drafts=%d, want true/1", resumed.IsReviewing(), len(resumed.drafts))
+	}
+}
+
+func TestDiffModel_ReviewSession_SubmitsDraftsWithVerdict(t *testing.T) {
+	m := newSelectionTestModel()
+	client := &reviewProvider{}
+	m.client = client
+	store := newDraftStore(t)
+	m.SetReviewDrafts(store)
+
+	m.Update(keyRunes("s"))
+	m.selectedLine = 3
+	draftComment(m, "first")
+	m.selectedLine = 2 // removed line "b", old line 2
+	draftComment(m, "second")
+
+	m.Update(keyRunes("s"))
+	if m.viewMode != DiffReviewPane {
+		t.Fatalf("s during a review should open the pending-review pane, viewMode = %v", m.viewMode)
+	}
+	if view := m.View(); !strings.Contains(view, "Pending review (2 comments)") {
+		t.Errorf("pane view missing header:\n%s", view)
+	}
+
+	m.Update(keyRunes("a"))
+	if m.inputMode != InputReviewSummary {
+		t.Fatal("a should prompt for a review summary")
+	}
+	m.textInput.SetValue("LGTM")
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("submitting the summary should return a command")
+	}
+	m.Update(cmd())
+
+	if len(client.submitted) != 1 {
+		t.Fatalf("SubmitReview called %d times, want 1", len(client.submitted))
+	}
+	got := client.submitted[0]
+	if got.Verdict != provider.VerdictApprove || got.Body != "LGTM" || len(got.Comments) != 2 {
+		t.Errorf("submitted review = %+v", got)
+	}
+	if !got.Comments[1].Range.IsLeft() {
+		t.Errorf("second comment range = %+v, want left side", got.Comments[1].Range)
+	}
+	if m.IsReviewing() || len(m.drafts) != 0 {
+		t.Errorf("after submit: reviewing=%v drafts=%d, want false/0", m.IsReviewing(), len(m.drafts))
+	}
+	if m.viewMode != DiffFileView {
+		t.Errorf("viewMode after submit = %v, want the file view", m.viewMode)
+	}
+	if left := store.Drafts(m.reviewKey()); len(left) != 0 {
+		t.Errorf("store still holds %d drafts after submit", len(left))
+	}
+}
+
+func TestDiffModel_ReviewSession_PartialFailureKeepsUnpostedDrafts(t *testing.T) {
+	m := newSelectionTestModel()
+	m.client = &reviewProvider{err: &provider.ReviewError{Posted: 1, Err: fmt.Errorf("boom")}}
+	m.SetReviewDrafts(newDraftStore(t))
+
+	m.Update(keyRunes("s"))
+	m.selectedLine = 1
+	draftComment(m, "posted")
+	m.selectedLine = 3
+	draftComment(m, "kept")
+
+	m.Update(keyRunes("s"))
+	m.Update(keyRunes("c"))
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m.Update(cmd())
+
+	if len(m.drafts) != 1 || m.drafts[0].Content != "kept" {
+		t.Errorf("drafts = %+v, want only the unposted one", m.drafts)
+	}
+	if !m.IsReviewing() {
+		t.Error("session should stay open after a partial failure")
+	}
+	if !strings.Contains(m.GetStatusMessage(), "boom") {
+		t.Errorf("status = %q, want the error", m.GetStatusMessage())
+	}
+}
+
+func TestDiffModel_ReviewSession_VoteFailureRetryKeepsSummaryOut(t *testing.T) {
+	m := newSelectionTestModel()
+	p := &reviewProvider{err: &provider.ReviewError{Posted: 1, BodyPosted: true, Err: fmt.Errorf("vote failed")}}
+	m.client = p
+	m.SetReviewDrafts(newDraftStore(t))
+
+	m.Update(keyRunes("s"))
+	m.selectedLine = 1
+	draftComment(m, "posted")
+
+	m.Update(keyRunes("s"))
+	m.Update(keyRunes("a"))
+	m.textInput.SetValue("Looks good")
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m.Update(cmd())
+	if len(m.drafts) != 0 || !m.IsReviewing() {
+		t.Fatalf("drafts = %+v, reviewing = %v", m.drafts, m.IsReviewing())
+	}
+
+	p.err = nil
+	m.Update(keyRunes("s"))
+	m.Update(keyRunes("a"))
+	if !strings.Contains(m.textInput.Placeholder, "already posted") {
+		t.Errorf("placeholder = %q, want a note that the summary went out", m.textInput.Placeholder)
+	}
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m.Update(cmd())
+
+	retry := p.submitted[1]
+	if retry.Body != "" || len(retry.Comments) != 0 || retry.Verdict != provider.VerdictApprove {
+		t.Errorf("retry = %+v, want only the vote", retry)
+	}
+	if m.summaryPosted {
+		t.Error("a successful retry should clear the posted summary")
+	}
+}
+
+func TestDiffModel_ReviewPane_DeleteDraft(t *testing.T) {
+	m := newSelectionTestModel()
+	m.SetReviewDrafts(newDraftStore(t))
+	m.Update(keyRunes("s"))
+	m.selectedLine = 1
+	draftComment(m, "one")
+	m.selectedLine = 3
+	draftComment(m, "two")
+
+	m.Update(keyRunes("s"))
+	m.Update(keyRunes("d"))
+	if len(m.drafts) != 1 || m.drafts[0].Content != "two" {
+		t.Errorf("drafts after delete = %+v, want only \"two\"", m.drafts)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.viewMode != DiffFileView {
+		t.Errorf("esc should close the pane, viewMode = %v", m.viewMode)
+	}
+	for _, dl := range m.diffLines {
+		if dl.Type == diffLineDraft && strings.Contains(dl.Content, "one") {
+			t.Error("deleted draft still rendered in the diff")
+		}
+	}
+}
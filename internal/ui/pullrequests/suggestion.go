@@ -0,0 +1,243 @@
+package pullrequests
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Elpulgo/azdo/internal/diff"
+	"github.com/Elpulgo/azdo/internal/provider"
+	"github.com/Elpulgo/azdo/internal/ui/components"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// suggestionCommitMessage is the commit message used when a suggestion is
+// applied, matching the default of the GitHub web UI.
+const suggestionCommitMessage = "Apply suggestion from code review"
+
+// startSuggestion opens the suggestion form pre-filled with the new-file
+// lines of the selection (or the current line) as a suggestion block.
+func (m *DiffModel) startSuggestion() tea.Cmd {
+	lo, hi := m.selectionBounds()
+	rng, ok := m.commentRangeFor(lo, hi)
+	if !ok {
+		if m.selecting {
+			m.statusMessage = "Selection must contain code and stay within one hunk"
+		}
+		return nil
+	}
+	if rng.IsLeft() {
+		m.statusMessage = "Suggestions apply to lines of the new file"
+		return nil
+	}
+	lines, ok := m.newFileLines(rng.StartLine, rng.EndLine)
+	if !ok {
+		return nil
+	}
+	m.commentRange = rng
+	m.selecting = false
+	m.commentForm.Reset()
+	m.commentForm.SetValue(diff.FormatSuggestion(lines))
+	m.commentForm.Show()
+	m.SetSize(m.width, m.height)
+	return m.commentForm.Focus()
+}
+
+// handleSuggestionSubmitted posts the suggestion as a code comment, or keeps
+// it as a draft while reviewing.
+func (m *DiffModel) handleSuggestionSubmitted(text string) (*DiffModel, tea.Cmd) {
+	m.SetSize(m.width, m.height)
+	if m.currentFile == nil {
+		return m, nil
+	}
+	if m.reviewing {
+		m.addDraft(m.currentFile.Path, m.commentRange, text)
+		return m, nil
+	}
+	return m, m.createCodeComment(m.currentFile.Path, m.commentRange, text)
+}
+
+// newFileLines returns the content of new-file lines start..end as shown in
+// the diff. ok is false unless every line is visible, since lines outside the
+// hunks are not known to the view.
+func (m *DiffModel) newFileLines(start, end int) ([]string, bool) {
+	if m.currentDiff == nil || start <= 0 || end < start {
+		return nil, false
+	}
+	byNum := make(map[int]string, end-start+1)
+	for _, hunk := range m.currentDiff.Hunks {
+		for _, line := range hunk.Lines {
+			if line.Type != diff.Removed && line.NewNum >= start && line.NewNum <= end {
+				byNum[line.NewNum] = line.Content
+			}
+		}
+	}
+	lines := make([]string, 0, end-start+1)
+	for n := start; n <= end; n++ {
+		content, ok := byNum[n]
+		if !ok {
+			return nil, false
+		}
+		lines = append(lines, content)
+	}
+	return lines, true
+}
+
+// appendSuggestionLines renders a suggestion as a mini-diff under its
+// comment: the lines it replaces, when visible, then the suggested lines.
+func (m *DiffModel) appendSuggestionLines(thread provider.Thread, threadID, commentIdx int, suggested []string) {
+	base := diffLine{ThreadID: threadID, CommentIdx: commentIdx, ThreadStatus: thread.Status}
+	if original, ok := m.newFileLines(thread.Range.StartLine, thread.Range.EndLine); ok {
+		for _, l := range original {
+			dl := base
+			dl.Type = diffLineSuggestionOld
+			dl.Content = l
+			m.diffLines = append(m.diffLines, dl)
+		}
+	}
+	for _, l := range suggested {
+		dl := base
+		dl.Type = diffLineSuggestionNew
+		dl.Content = l
+		m.diffLines = append(m.diffLines, dl)
+	}
+}
+
+// threadByID returns the thread with the given numeric id.
+func (m *DiffModel) threadByID(id int) (provider.Thread, bool) {
+	for _, t := range m.threads {
+		if parseThreadID(t.Identity.ID) == id {
+			return t, true
+		}
+	}
+	return provider.Thread{}, false
+}
+
+// findNearestSuggestion returns the thread and suggested lines of the
+// suggestion closest to the selection, searching upward first like
+// findNearestThread.
+func (m *DiffModel) findNearestSuggestion() (provider.Thread, []string, bool) {
+	check := func(i int) (provider.Thread, []string, bool) {
+		dl := m.diffLines[i]
+		if dl.ThreadID <= 0 || (dl.Type != diffLineComment && dl.Type != diffLineSuggestionOld && dl.Type != diffLineSuggestionNew) {
+			return provider.Thread{}, nil, false
+		}
+		thread, ok := m.threadByID(dl.ThreadID)
+		if !ok || thread.Range.IsLeft() || dl.CommentIdx >= len(thread.Comments) {
+			return provider.Thread{}, nil, false
+		}
+		_, lines, ok := diff.ExtractSuggestion(thread.Comments[dl.CommentIdx].Content)
+		return thread, lines, ok
+	}
+	for i := min(m.selectedLine, len(m.diffLines)-1); i >= 0; i-- {
+		if t, lines, ok := check(i); ok {
+			return t, lines, true
+		}
+	}
+	for i := max(m.selectedLine, 0); i < len(m.diffLines); i++ {
+		if t, lines, ok := check(i); ok {
+			return t, lines, true
+		}
+	}
+	return provider.Thread{}, nil, false
+}
+
+// promptApplySuggestion prepares the edit for the nearest suggestion and asks
+// for confirmation before committing it to the source branch. Suggestions on
+// an outdated diff are refused, as their lines no longer match the branch.
+func (m *DiffModel) promptApplySuggestion() {
+	thread, lines, ok := m.findNearestSuggestion()
+	if !ok {
+		m.statusMessage = "No suggestion near the cursor"
+		return
+	}
+	if thread.Outdated {
+		m.statusMessage = "The suggestion is on an outdated diff and cannot be applied"
+		return
+	}
+	expected, ok := m.newFileLines(thread.Range.StartLine, thread.Range.EndLine)
+	if !ok {
+		m.statusMessage = "The suggested lines are not all shown in the diff"
+		return
+	}
+	m.pendingApply = &provider.FileEdit{
+		Branch:    branchShortName(m.pr.SourceRefName),
+		FilePath:  thread.FilePath,
+		StartLine: thread.Range.StartLine,
+		EndLine:   thread.Range.EndLine,
+		Expected:  expected,
+		Lines:     lines,
+		Message:   suggestionCommitMessage,
+	}
+}
+
+// updateApplyPrompt handles the y/n confirmation for applying a suggestion.
+func (m *DiffModel) updateApplyPrompt(msg tea.KeyMsg) (*DiffModel, tea.Cmd) {
+	switch msg.String() {
+	case "y", "Y":
+		edit := *m.pendingApply
+		m.pendingApply = nil
+		m.statusMessage = "Applying suggestion..."
+		return m, m.applySuggestion(edit)
+	case "n", "N", "esc":
+		m.pendingApply = nil
+	}
+	return m, nil
+}
+
+// applyPromptText is the confirmation shown while a suggestion awaits y/n.
+func (m *DiffModel) applyPromptText() string {
+	return fmt.Sprintf("Commit suggestion to %s? (y/n)", m.pendingApply.Branch)
+}
+
+// applySuggestion commits edit through the provider.
+func (m *DiffModel) applySuggestion(edit provider.FileEdit) tea.Cmd {
+	return func() tea.Msg {
+		if m.client == nil {
+			return suggestionAppliedMsg{branch: edit.Branch, err: fmt.Errorf("no client available")}
+		}
+		err := m.client.ApplyFileEdit(m.pr.Identity.Scope, m.pr.RepositoryID, edit)
+		return suggestionAppliedMsg{branch: edit.Branch, err: err}
+	}
+}
+
+// handleSuggestionApplied reports the outcome and, on success, reloads the
+// changed files so the open diff shows the new commit.
+func (m *DiffModel) handleSuggestionApplied(msg suggestionAppliedMsg) (*DiffModel, tea.Cmd) {
+	if msg.err != nil {
+		if errors.Is(msg.err, provider.ErrEditStale) {
+			m.statusMessage = "Suggestion not applied: the lines changed since it was made"
+		} else {
+			m.statusMessage = fmt.Sprintf("Error: %v", msg.err)
+		}
+		return m, nil
+	}
+	m.statusMessage = "Suggestion committed to " + msg.branch
+	m.reloadFile = m.currentFile != nil
+	return m, tea.Batch(m.fetchChangedFiles(), m.refreshThreads())
+}
+
+// reloadCurrentFile takes the changed files fetched after a suggestion was
+// applied and re-opens the current file from them: GitHub diffs come from the
+// per-file patch, which only the fresh file list carries.
+func (m *DiffModel) reloadCurrentFile(changes []provider.IterationChange) (*DiffModel, tea.Cmd) {
+	m.reloadFile = false
+	m.changedFiles = filterFileChanges(changes)
+	if m.currentFile == nil {
+		return m, nil
+	}
+	for _, change := range m.changedFiles {
+		if change.Path == m.currentFile.Path {
+			m.currentFile = &change
+			return m, m.fetchFileDiff(change, m.diffOpts)
+		}
+	}
+	return m, nil
+}
+
+// suggestionContextItems are the footer hints for suggestions in the file view.
+func suggestionContextItems() []components.ContextItem {
+	return []components.ContextItem{
+		{Key: "S", Description: "suggest change"},
+		{Key: "A", Description: "apply suggestion"},
+	}
+}
@@ -0,0 +1,176 @@
+package pullrequests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Elpulgo/azdo/internal/provider"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// suggestionProvider records code comments and file edits; every other
+// method panics via the nil embedded interface.
+type suggestionProvider struct {
+	provider.Provider
+	comments []string
+	edits    []provider.FileEdit
+	err      error
+}
+
+func (p *suggestionProvider) AddPRCodeComment(scope, repositoryID string, pullRequestID int, filePath string, rng provider.LineRange, content string) (*provider.Thread, error) {
+	p.comments = append(p.comments, content)
+	return &provider.Thread{}, nil
+}
+
+func (p *suggestionProvider) ApplyFileEdit(scope, repositoryID string, edit provider.FileEdit) error {
+	p.edits = append(p.edits, edit)
+	return p.err
+}
+
+// withSuggestionThread attaches a suggestion replacing new line 2 ("B").
+func withSuggestionThread(m *DiffModel) {
+	m.threads = []provider.Thread{{
+		Identity: provider.Identity{ID: "7"},
+		Status:   "active",
+		FilePath: "/src/main.go",
+		Line:     2,
+		Range:    provider.LineAt(provider.SideRight, 2),
+		Comments: []provider.Comment{{AuthorName: "Ann", Content: "nit\n```suggestion\nBee\n```"}},
+	}}
+	m.rebuildFileDiff()
+}
+
+func TestDiffModel_SuggestChange_PrefillsSelectedLines(t *testing.T) {
+	m := newSelectionTestModel()
+	client := &suggestionProvider{}
+	m.client = client
+	m.selectedLine = 3 // added "B"
+	m.Update(keyRunes("v"))
+	m.Update(keyRunes("j")) // extend to added "C"
+
+	m.Update(keyRunes("S"))
+	if !m.commentForm.IsVisible() || !m.IsInputActive() {
+		t.Fatal("S should open the suggestion form")
+	}
+	if got, want := m.commentForm.Value(), "```suggestion\nB\nC\n```"; got != want {
+		t.Errorf("prefill = %q, want %q", got, want)
+	}
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if cmd == nil {
+		t.Fatal("ctrl+s should submit the suggestion")
+	}
+	_, cmd = m.Update(cmd())
+	if cmd == nil {
+		t.Fatal("submitting should post the comment")
+	}
+	cmd()
+	if len(client.comments) != 1 || !strings.Contains(client.comments[0], "```suggestion") {
+		t.Errorf("posted comments = %q", client.comments)
+	}
+	if m.commentRange.StartLine != 2 || m.commentRange.EndLine != 3 {
+		t.Errorf("comment range = %+v, want new lines 2-3", m.commentRange)
+	}
+}
+
+func TestDiffModel_SuggestChange_RejectsRemovedLines(t *testing.T) {
+	m := newSelectionTestModel()
+	m.selectedLine = 2 // removed "b"
+	m.Update(keyRunes("S"))
+	if m.commentForm.IsVisible() {
+		t.Error("suggestion form opened on an old-file line")
+	}
+	if !strings.Contains(m.GetStatusMessage(), "new file") {
+		t.Errorf("status = %q", m.GetStatusMessage())
+	}
+}
+
+func TestDiffModel_SuggestionRendersAsMiniDiff(t *testing.T) {
+	m := newSelectionTestModel()
+	withSuggestionThread(m)
+
+	var got []diffLine
+	for _, dl := range m.diffLines {
+		if dl.ThreadID == 7 {
+			got = append(got, dl)
+		}
+	}
+	if len(got) != 3 {
+		t.Fatalf("thread lines = %+v, want comment, old, new", got)
+	}
+	if got[0].Type != diffLineComment || strings.Contains(got[0].Content, "```") || !strings.Contains(got[0].Content, "nit") {
+		t.Errorf("comment line = %+v, want the prose without the block", got[0])
+	}
+	if got[1].Type != diffLineSuggestionOld || got[1].Content != "B" {
+		t.Errorf("old line = %+v, want B", got[1])
+	}
+	if got[2].Type != diffLineSuggestionNew || got[2].Content != "Bee" {
+		t.Errorf("new line = %+v, want Bee", got[2])
+	}
+}
+
+func TestDiffModel_ApplySuggestion_CommitsAfterConfirm(t *testing.T) {
+	m := newSelectionTestModel()
+	client := &suggestionProvider{}
+	m.client = client
+	m.pr.SourceRefName = "refs/heads/feat"
+	withSuggestionThread(m)
+	m.selectedLine = 3
+
+	m.Update(keyRunes("A"))
+	if m.pendingApply == nil {
+		t.Fatal("A should ask for confirmation")
+	}
+	if view := m.View(); !strings.Contains(view, "Commit suggestion to feat? (y/n)") {
+		t.Errorf("view missing confirmation:\n%s", view)
+	}
+	_, cmd := m.Update(keyRunes("y"))
+	if cmd == nil {
+		t.Fatal("y should apply the suggestion")
+	}
+	msg := cmd()
+	if len(client.edits) != 1 {
+		t.Fatalf("ApplyFileEdit called %d times, want 1", len(client.edits))
+	}
+	edit := client.edits[0]
+	if edit.Branch != "feat" || edit.StartLine != 2 || edit.EndLine != 2 ||
+		strings.Join(edit.Expected, "|") != "B" || strings.Join(edit.Lines, "|") != "Bee" {
+		t.Errorf("edit = %+v", edit)
+	}
+	if applied, ok := msg.(suggestionAppliedMsg); !ok || applied.err != nil {
+		t.Fatalf("msg = %#v, want a successful suggestionAppliedMsg", msg)
+	}
+}
+
+func TestDiffModel_ApplySuggestion_StaleReportsStatus(t *testing.T) {
+	m := newSelectionTestModel()
+	m.client = &suggestionProvider{err: provider.ErrEditStale}
+	withSuggestionThread(m)
+
+	m.Update(keyRunes("A"))
+	_, cmd := m.Update(keyRunes("y"))
+	m.Update(cmd())
+	if !strings.Contains(m.GetStatusMessage(), "changed since") {
+		t.Errorf("status = %q, want the stale-suggestion message", m.GetStatusMessage())
+	}
+}
+
+func TestDiffModel_ApplySuggestion_RefusesOutdatedAnchor(t *testing.T) {
+	m := newSelectionTestModel()
+	client := &suggestionProvider{}
+	m.client = client
+	withSuggestionThread(m)
+	m.threads[0].Outdated = true
+	m.selectedLine = 3
+
+	m.Update(keyRunes("A"))
+	if m.pendingApply != nil {
+		t.Fatalf("pendingApply = %+v, want no edit for an outdated anchor", m.pendingApply)
+	}
+	if !strings.Contains(m.GetStatusMessage(), "outdated") {
+		t.Errorf("status = %q, want the outdated-suggestion message", m.GetStatusMessage())
+	}
+	if len(client.edits) != 0 {
+		t.Errorf("ApplyFileEdit called %d times, want 0", len(client.edits))
+	}
+}
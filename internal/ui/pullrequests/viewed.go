@@ -0,0 +1,194 @@
+package pullrequests
+
+import (
+	"fmt"
+
+	"github.com/Elpulgo/azdo/internal/provider"
+	"github.com/Elpulgo/azdo/internal/state"
+	"github.com/Elpulgo/azdo/internal/ui/components"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// viewedMarker is appended to the changed files marked as viewed.
+const viewedMarker = "✓"
+
+// viewedFiles tracks which changed files of one pull request have been
+// marked as viewed. A mark remembers the file's content hash, so a file
+// that changes in a later iteration is unmarked on the next reconcile. The
+// detail view and the diff view share one tracker, so marks made in the
+// diff view show up in the detail's progress.
+type viewedFiles struct {
+	store *state.ViewedStore // persists marks; nil keeps them in memory only
+	key   string
+	marks map[string]state.ViewedFile
+}
+
+// newViewedFiles returns the tracker for pr, seeded from store.
+func newViewedFiles(store *state.ViewedStore, pr provider.PullRequest) *viewedFiles {
+	v := &viewedFiles{store: store, key: prReviewKey(pr), marks: map[string]state.ViewedFile{}}
+	if store != nil {
+		v.marks = store.Viewed(v.key)
+	}
+	return v
+}
+
+// prReviewKey identifies pr in the review state files.
+func prReviewKey(pr provider.PullRequest) string {
+	backend := "azure"
+	if pr.Identity.Kind == provider.KindGitHub {
+		backend = "github"
+	}
+	return state.ReviewKey(backend, pr.Identity.Scope, pr.RepositoryID, prNumericID(pr))
+}
+
+// viewedHash identifies the content of change. Without an object ID from
+// the backend, the iteration stands in, so any new iteration unmarks it.
+func viewedHash(change provider.IterationChange, iteration int) string {
+	if change.ObjectID != "" {
+		return change.ObjectID
+	}
+	return fmt.Sprintf("iteration-%d", iteration)
+}
+
+// reconcile brings the marks up to date with the changes of iteration:
+// files marked viewed on the server (remote) are marked, and marks for
+// files that are gone or whose content changed since they were viewed are
+// dropped. The store is only written when something changed.
+func (v *viewedFiles) reconcile(changes []provider.IterationChange, iteration int, remote []string) error {
+	if iteration == 0 {
+		return nil // no iterations loaded; keep the marks until there are
+	}
+	remoteViewed := make(map[string]bool, len(remote))
+	for _, p := range remote {
+		remoteViewed[p] = true
+	}
+
+	marks := make(map[string]state.ViewedFile, len(v.marks))
+	for _, change := range changes {
+		hash := viewedHash(change, iteration)
+		if mark, ok := v.marks[change.Path]; ok && mark.Hash == hash {
+			marks[change.Path] = mark
+		} else if remoteViewed[change.Path] {
+			marks[change.Path] = state.ViewedFile{Iteration: iteration, Hash: hash}
+		}
+	}
+
+	changed := len(marks) != len(v.marks)
+	for path, mark := range marks {
+		if v.marks[path] != mark {
+			changed = true
+		}
+	}
+	v.marks = marks
+	if !changed {
+		return nil
+	}
+	return v.save()
+}
+
+// isViewed reports whether path is marked as viewed.
+func (v *viewedFiles) isViewed(path string) bool {
+	_, ok := v.marks[path]
+	return ok
+}
+
+// toggle flips the viewed mark of change, viewed in iteration, and returns
+// whether it is now viewed. The mark changes in memory even when saving
+// it fails.
+func (v *viewedFiles) toggle(change provider.IterationChange, iteration int) (bool, error) {
+	if v.isViewed(change.Path) {
+		delete(v.marks, change.Path)
+		return false, v.save()
+	}
+	v.marks[change.Path] = state.ViewedFile{Iteration: iteration, Hash: viewedHash(change, iteration)}
+	return true, v.save()
+}
+
+// count returns how many of changes are marked as viewed.
+func (v *viewedFiles) count(changes []provider.IterationChange) int {
+	n := 0
+	for _, change := range changes {
+		if v.isViewed(change.Path) {
+			n++
+		}
+	}
+	return n
+}
+
+// progress renders the review progress over changes, e.g. "23/60 reviewed".
+func (v *viewedFiles) progress(changes []provider.IterationChange) string {
+	return fmt.Sprintf("%d/%d reviewed", v.count(changes), len(changes))
+}
+
+func (v *viewedFiles) save() error {
+	if v.store == nil {
+		return nil
+	}
+	return v.store.SetViewed(v.key, v.marks)
+}
+
+// fileViewedMsg reports the outcome of syncing a viewed mark to the
+// backend.
+type fileViewedMsg struct {
+	path   string
+	viewed bool
+	err    error
+}
+
+// SetViewedFiles shares the detail view's viewed-file tracker, so marks
+// made here count towards the progress shown there.
+func (m *DiffModel) SetViewedFiles(v *viewedFiles) {
+	if v != nil {
+		m.viewed = v
+	}
+}
+
+// SetViewedStore attaches the store that persists viewed marks, loading
+// the marks saved for this pull request.
+func (m *DetailModel) SetViewedStore(store *state.ViewedStore) {
+	m.viewed = newViewedFiles(store, m.pr)
+}
+
+// reconcileViewed updates the viewed marks for freshly loaded changes,
+// unmarking files that changed since they were viewed.
+func (m *DiffModel) reconcileViewed(msg changedFilesMsg) {
+	m.iteration = msg.iteration
+	if err := m.viewed.reconcile(filterFileChanges(msg.changes), msg.iteration, msg.viewed); err != nil {
+		m.statusMessage = fmt.Sprintf("Error saving viewed files: %v", err)
+	}
+}
+
+// toggleViewed flips the viewed mark of the selected file and syncs it to
+// the backend where supported.
+func (m *DiffModel) toggleViewed() tea.Cmd {
+	fi := m.selectedFileIndex()
+	if fi < 0 || fi >= len(m.changedFiles) {
+		return nil
+	}
+	change := m.changedFiles[fi]
+	viewed, err := m.viewed.toggle(change, m.iteration)
+	if err != nil {
+		m.statusMessage = fmt.Sprintf("Error saving viewed files: %v", err)
+	}
+	m.updateFileListViewport()
+	if m.client == nil {
+		return nil
+	}
+	client, pr := m.client, m.pr
+	return func() tea.Msg {
+		err := client.SetFileViewed(pr.Identity.Scope, pr.RepositoryID, prNumericID(pr), change.Path, viewed)
+		return fileViewedMsg{path: change.Path, viewed: viewed, err: err}
+	}
+}
+
+// handleFileViewed reports a failed viewed sync; the local mark stays.
+func (m *DiffModel) handleFileViewed(msg fileViewedMsg) {
+	if msg.err != nil {
+		m.statusMessage = fmt.Sprintf("Error syncing viewed state of %s: %v", msg.path, msg.err)
+	}
+}
+
+// viewedContextItem is the footer hint for marking files as viewed.
+func viewedContextItem() components.ContextItem {
+	return components.ContextItem{Key: "v", Description: "toggle viewed"}
+}
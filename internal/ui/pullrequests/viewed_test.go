@@ -0,0 +1,168 @@
+package pullrequests
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Elpulgo/azdo/internal/provider"
+	"github.com/Elpulgo/azdo/internal/state"
+	"github.com/Elpulgo/azdo/internal/ui/styles"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// viewedProvider serves one iteration of changes and records viewed syncs.
+type viewedProvider struct {
+	provider.Provider
+	changes []provider.IterationChange
+	remote  []string
+	synced  map[string]bool
+}
+
+func (p *viewedProvider) GetPRIterations(scope, repositoryID string, pullRequestID int) ([]provider.Iteration, error) {
+	return []provider.Iteration{{ID: 1}, {ID: 2}}, nil
+}
+
+func (p *viewedProvider) GetPRIterationChanges(scope, repositoryID string, pullRequestID, iterationID int) ([]provider.IterationChange, error) {
+	return p.changes, nil
+}
+
+func (p *viewedProvider) GetViewedFiles(scope, repositoryID string, pullRequestID int) ([]string, error) {
+	return p.remote, nil
+}
+
+func (p *viewedProvider) SetFileViewed(scope, repositoryID string, pullRequestID int, filePath string, viewed bool) error {
+	if p.synced == nil {
+		p.synced = map[string]bool{}
+	}
+	p.synced[filePath] = viewed
+	return nil
+}
+
+func (p *viewedProvider) PRURL(scope, repositoryID string, pullRequestID int) string {
+	return ""
+}
+
+func newViewedStore(t *testing.T) *state.ViewedStore {
+	t.Helper()
+	store, err := state.NewViewedStore(filepath.Join(t.TempDir(), "viewed.yaml"))
+	if err != nil {
+		t.Fatalf("NewViewedStore() error = %v", err)
+	}
+	return store
+}
+
+func viewedTestPR() provider.PullRequest {
+	return provider.PullRequest{
+		Identity:     provider.Identity{Kind: provider.KindAzure, Scope: "proj", ID: "101"},
+		RepositoryID: "repo-123",
+	}
+}
+
+func TestViewedFiles_ReconcileUnmarksFilesChangedInNewIteration(t *testing.T) {
+	store := newViewedStore(t)
+	pr := viewedTestPR()
+	v := newViewedFiles(store, pr)
+
+	first := []provider.IterationChange{
+		{Path: "/a.go", ObjectID: "a1"},
+		{Path: "/b.go", ObjectID: "b1"},
+		{Path: "/c.go", ObjectID: "c1"},
+	}
+	_ = v.reconcile(first, 1, nil)
+	for _, c := range first {
+		if _, err := v.toggle(c, 1); err != nil {
+			t.Fatalf("toggle(%s) error = %v", c.Path, err)
+		}
+	}
+
+	// Iteration 2 changes b.go and drops c.go.
+	second := []provider.IterationChange{
+		{Path: "/a.go", ObjectID: "a1"},
+		{Path: "/b.go", ObjectID: "b2"},
+	}
+	if err := v.reconcile(second, 2, nil); err != nil {
+		t.Fatalf("reconcile() error = %v", err)
+	}
+	if !v.isViewed("/a.go") || v.isViewed("/b.go") || v.isViewed("/c.go") {
+		t.Errorf("marks after new iteration = %+v, want only /a.go", v.marks)
+	}
+
+	// The reconciled marks were persisted.
+	if got := newViewedFiles(store, pr).progress(second); got != "1/2 reviewed" {
+		t.Errorf("reloaded progress = %q, want 1/2 reviewed", got)
+	}
+}
+
+func TestViewedFiles_ReconcileAdoptsRemoteMarks(t *testing.T) {
+	v := newViewedFiles(nil, viewedTestPR())
+	changes := []provider.IterationChange{{Path: "a.go"}, {Path: "b.go"}}
+	_ = v.reconcile(changes, 3, []string{"b.go", "gone.go"})
+	if v.isViewed("a.go") || !v.isViewed("b.go") || v.isViewed("gone.go") {
+		t.Errorf("marks = %+v, want only b.go", v.marks)
+	}
+	// Without an object ID the iteration identifies the content.
+	if got := v.marks["b.go"].Hash; got != "iteration-3" {
+		t.Errorf("hash = %q, want iteration-3", got)
+	}
+}
+
+func TestDiffModel_ToggleViewedMarksFileAndSyncs(t *testing.T) {
+	p := &viewedProvider{changes: []provider.IterationChange{
+		{Path: "/a.go", ChangeType: "edit", ObjectID: "a1"},
+		{Path: "/b.go", ChangeType: "edit", ObjectID: "b1"},
+	}}
+	store := newViewedStore(t)
+	pr := viewedTestPR()
+	m := NewDiffModel(p, pr, nil, styles.DefaultStyles())
+	m.SetViewedFiles(newViewedFiles(store, pr))
+	m.SetSize(100, 20)
+	m.Update(m.fetchChangedFiles()())
+
+	if got := m.View(); !strings.Contains(got, "0/2 reviewed") {
+		t.Errorf("header should show 0/2 reviewed:\n%s", got)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyDown}) // past "General comments"
+	_, cmd := m.Update(keyRunes("v"))
+	if cmd == nil {
+		t.Fatal("v should return a sync command")
+	}
+	m.Update(cmd())
+	if !p.synced["/a.go"] {
+		t.Errorf("synced = %v, want /a.go viewed", p.synced)
+	}
+	view := m.View()
+	if !strings.Contains(view, "1/2 reviewed") || !strings.Contains(view, "/a.go "+viewedMarker) {
+		t.Errorf("view should mark /a.go and show 1/2 reviewed:\n%s", view)
+	}
+	if got := store.Viewed(prReviewKey(pr)); got["/a.go"] != (state.ViewedFile{Iteration: 2, Hash: "a1"}) {
+		t.Errorf("stored marks = %+v", got)
+	}
+
+	_, cmd = m.Update(keyRunes("v"))
+	m.Update(cmd())
+	if p.synced["/a.go"] || m.viewed.isViewed("/a.go") {
+		t.Error("second v should unmark /a.go")
+	}
+}
+
+func TestDetailModel_ShowsReviewProgress(t *testing.T) {
+	p := &viewedProvider{
+		changes: []provider.IterationChange{{Path: "/a.go", ObjectID: "a1"}, {Path: "/b.go", ObjectID: "b1"}},
+		remote:  []string{"/b.go"},
+	}
+	m := NewDetailModelWithStyles(p, viewedTestPR(), styles.DefaultStyles())
+	m.SetViewedStore(newViewedStore(t))
+	m.SetSize(100, 30)
+	m.Update(m.fetchChangedFiles()())
+	m.Update(threadsMsg{})
+
+	view := m.View()
+	if !strings.Contains(view, "Changed files (2) · 1/2 reviewed") {
+		t.Errorf("detail should show review progress:\n%s", view)
+	}
+	if !strings.Contains(view, "/b.go "+viewedMarker) {
+		t.Errorf("detail should mark /b.go as viewed:\n%s", view)
+	}
+}
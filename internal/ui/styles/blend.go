@@ -0,0 +1,23 @@
+package styles
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/lucasb-eyer/go-colorful"
+)
+
+// BlendColors mixes from towards to by t (0 = from, 1 = to) in Lab space.
+// Both colors must be hex ("#rrggbb") for a blend to be possible; ANSI
+// palette indices (e.g. "236") have no portable RGB value, so fallback is
+// returned instead. This lets themes derive tinted backgrounds (such as the
+// diff add/remove line colors) without adding extra palette entries.
+func BlendColors(from, to lipgloss.Color, t float64, fallback lipgloss.Color) lipgloss.Color {
+	a, err := colorful.Hex(string(from))
+	if err != nil {
+		return fallback
+	}
+	b, err := colorful.Hex(string(to))
+	if err != nil {
+		return fallback
+	}
+	return lipgloss.Color(a.BlendLab(b, t).Clamped().Hex())
+}
@@ -21,11 +21,11 @@ type Styles struct {
 	ModalBox   lipgloss.Style
 
 	// Text styles
-	Header   lipgloss.Style
-	Title    lipgloss.Style
-	Label lipgloss.Style
-	Value lipgloss.Style
-	Muted lipgloss.Style
+	Header lipgloss.Style
+	Title  lipgloss.Style
+	Label  lipgloss.Style
+	Value  lipgloss.Style
+	Muted  lipgloss.Style
 
 	// Status styles
 	Success lipgloss.Style
@@ -58,14 +58,18 @@ type Styles struct {
 	TableSelected lipgloss.Style
 
 	// Diff styles
-	DiffAdded        lipgloss.Style // Success color (green) — added lines
-	DiffRemoved      lipgloss.Style // Error color (red) — removed lines
-	DiffContext      lipgloss.Style // ForegroundMuted — unchanged context lines
-	DiffHeader       lipgloss.Style // Primary + BackgroundAlt + Bold — file path header
-	DiffHunkHeader   lipgloss.Style // Info color — @@ hunk markers
-	DiffLineNum      lipgloss.Style // ForegroundMuted, right-aligned — line number gutter
+	DiffAdded           lipgloss.Style // Success color (green) — added lines
+	DiffRemoved         lipgloss.Style // Error color (red) — removed lines
+	DiffContext         lipgloss.Style // ForegroundMuted — unchanged context lines
+	DiffHeader          lipgloss.Style // Primary + BackgroundAlt + Bold — file path header
+	DiffHunkHeader      lipgloss.Style // Info color — @@ hunk markers
+	DiffLineNum         lipgloss.Style // ForegroundMuted, right-aligned — line number gutter
 	DiffCommentCount    lipgloss.Style // Accent color — comment count badges
 	DiffCommentResolved lipgloss.Style // Success color — resolved comment text
+	DiffAddedLine       lipgloss.Style // Success blended into Background — added-line background under syntax colors
+	DiffRemovedLine     lipgloss.Style // Error blended into Background — removed-line background under syntax colors
+	DiffAddedEmph       lipgloss.Style // Stronger Success blend + Bold — changed words within an added line
+	DiffRemovedEmph     lipgloss.Style // Stronger Error blend + Bold — changed words within a removed line
 }
 
 // NewStyles creates a new Styles instance from the given theme.
@@ -231,6 +235,24 @@ func NewStyles(theme Theme) *Styles {
 		Foreground(lipgloss.Color(theme.Success)).
 		Bold(true)
 
+	s.DiffAddedLine = lipgloss.NewStyle().
+		Foreground(lipgloss.Color(theme.Foreground)).
+		Background(BlendColors(theme.Background, theme.Success, 0.25, "22"))
+
+	s.DiffRemovedLine = lipgloss.NewStyle().
+		Foreground(lipgloss.Color(theme.Foreground)).
+		Background(BlendColors(theme.Background, theme.Error, 0.25, "52"))
+
+	s.DiffAddedEmph = lipgloss.NewStyle().
+		Foreground(lipgloss.Color(theme.ForegroundBold)).
+		Background(BlendColors(theme.Background, theme.Success, 0.5, "28")).
+		Bold(true)
+
+	s.DiffRemovedEmph = lipgloss.NewStyle().
+		Foreground(lipgloss.Color(theme.ForegroundBold)).
+		Background(BlendColors(theme.Background, theme.Error, 0.5, "88")).
+		Bold(true)
+
 	return s
 }
 
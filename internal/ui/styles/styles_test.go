@@ -131,3 +131,35 @@ func TestStylesAllThemes(t *testing.T) {
 		})
 	}
 }
+
+// TestBlendColors tests hex blending and the ANSI fallback
+func TestBlendColors(t *testing.T) {
+	if got := BlendColors("#000000", "#ffffff", 0, "1"); got != "#000000" {
+		t.Errorf("t=0 blend = %q, want #000000", got)
+	}
+	if got := BlendColors("#000000", "#ffffff", 1, "1"); got != "#ffffff" {
+		t.Errorf("t=1 blend = %q, want #ffffff", got)
+	}
+	mid := BlendColors("#282a36", "#50fa7b", 0.25, "22")
+	if mid == "#282a36" || mid == "#50fa7b" || mid == "22" {
+		t.Errorf("partial blend = %q, want a color between the inputs", mid)
+	}
+	if got := BlendColors("236", "42", 0.25, "22"); got != "22" {
+		t.Errorf("ANSI blend = %q, want fallback 22", got)
+	}
+}
+
+// TestStylesDiffLineBackgrounds tests that add/remove line tints are derived from the theme
+func TestStylesDiffLineBackgrounds(t *testing.T) {
+	theme := GetDefaultTheme()
+	s := NewStyles(theme)
+	if s.DiffAddedLine.GetBackground() != BlendColors(theme.Background, theme.Success, 0.25, "22") {
+		t.Error("DiffAddedLine background not derived from Background+Success")
+	}
+	if s.DiffRemovedLine.GetBackground() != BlendColors(theme.Background, theme.Error, 0.25, "52") {
+		t.Error("DiffRemovedLine background not derived from Background+Error")
+	}
+	if s.DiffAddedLine.GetBackground() == s.DiffRemovedLine.GetBackground() {
+		t.Error("added and removed line backgrounds should differ")
+	}
+}
@@ -0,0 +1,192 @@
+// Package syntax tokenises source code with chroma and renders it using token
+// colors derived from the active styles.Theme, so highlighted code follows the
+// same palette as the rest of the UI regardless of which theme is selected.
+package syntax
+
+import (
+	"path"
+	"strings"
+
+	"github.com/Elpulgo/azdo/internal/ui/styles"
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// TokenKind is the coarse token class used for coloring. Chroma's token
+// taxonomy is much finer than a terminal palette can express, so every chroma
+// token type is folded into one of these kinds.
+type TokenKind int
+
+const (
+	// KindPlain is text without a dedicated color (identifiers, whitespace).
+	KindPlain TokenKind = iota
+	// KindKeyword covers language keywords and builtins.
+	KindKeyword
+	// KindType covers type names, classes and type keywords.
+	KindType
+	// KindFunction covers function and method names.
+	KindFunction
+	// KindString covers string and character literals.
+	KindString
+	// KindNumber covers numeric and other non-string literals.
+	KindNumber
+	// KindComment covers comments and preprocessor directives.
+	KindComment
+	// KindOperator covers operators and punctuation.
+	KindOperator
+)
+
+// Span is a run of text on a single line that shares one TokenKind.
+type Span struct {
+	Text string
+	Kind TokenKind
+}
+
+// Highlighter tokenises code for a single file. The lexer is chosen once from
+// the file name; a Highlighter for an unknown file type is still usable and
+// returns every line as a single KindPlain span.
+type Highlighter struct {
+	lexer   chroma.Lexer
+	palette map[TokenKind]lipgloss.Style
+}
+
+// NewHighlighter returns a Highlighter for the file at filePath, with token
+// colors derived from theme. Only the base name is used for lexer matching,
+// so both "/src/main.go" and "main.go" select the Go lexer.
+func NewHighlighter(filePath string, theme styles.Theme) *Highlighter {
+	h := &Highlighter{palette: Palette(theme)}
+	if name := path.Base(filePath); name != "" && name != "." && name != "/" {
+		if l := lexers.Match(name); l != nil {
+			h.lexer = chroma.Coalesce(l)
+		}
+	}
+	return h
+}
+
+// NewLanguageHighlighter returns a Highlighter for a language name or alias,
+// as written after a markdown code fence ("go", "js", "yaml"). An empty or
+// unknown name gives a Highlighter that returns plain spans.
+func NewLanguageHighlighter(lang string, theme styles.Theme) *Highlighter {
+	h := &Highlighter{palette: Palette(theme)}
+	if lang = strings.TrimSpace(lang); lang != "" {
+		if l := lexers.Get(lang); l != nil {
+			h.lexer = chroma.Coalesce(l)
+		}
+	}
+	return h
+}
+
+// Enabled reports whether a lexer was found for the file.
+func (h *Highlighter) Enabled() bool {
+	return h != nil && h.lexer != nil
+}
+
+// Palette returns the per-kind foreground styles for the given theme.
+// KindPlain has no foreground so the caller's base style shows through.
+func Palette(theme styles.Theme) map[TokenKind]lipgloss.Style {
+	fg := func(c lipgloss.Color) lipgloss.Style { return lipgloss.NewStyle().Foreground(c) }
+	return map[TokenKind]lipgloss.Style{
+		KindPlain:    lipgloss.NewStyle(),
+		KindKeyword:  fg(theme.Primary).Bold(true),
+		KindType:     fg(theme.Secondary),
+		KindFunction: fg(theme.Info),
+		KindString:   fg(theme.Warning),
+		KindNumber:   fg(theme.Accent),
+		KindComment:  fg(theme.ForegroundMuted).Italic(true),
+		KindOperator: fg(theme.ForegroundBold),
+	}
+}
+
+// Tokenize splits lines into spans. The lines are lexed as one contiguous
+// block so constructs that span lines (block comments, raw strings) color
+// correctly; the result has exactly one entry per input line. When no lexer
+// is available, or lexing fails, each line becomes a single plain span.
+func (h *Highlighter) Tokenize(lines []string) [][]Span {
+	out := make([][]Span, len(lines))
+	if !h.Enabled() || len(lines) == 0 {
+		return plainSpans(lines, out)
+	}
+
+	it, err := h.lexer.Tokenise(nil, strings.Join(lines, "\n")+"\n")
+	if err != nil {
+		return plainSpans(lines, out)
+	}
+
+	row := 0
+	for tok := it(); tok != chroma.EOF; tok = it() {
+		kind := kindOf(tok.Type)
+		parts := strings.Split(tok.Value, "\n")
+		for i, part := range parts {
+			if i > 0 {
+				row++
+			}
+			if row >= len(out) {
+				break
+			}
+			if part != "" {
+				out[row] = appendSpan(out[row], Span{Text: part, Kind: kind})
+			}
+		}
+	}
+	return out
+}
+
+// Render renders spans on top of base. Token foregrounds override base's
+// foreground; every other property of base (notably its background) is kept,
+// which is how the diff view layers syntax colors over its add/remove tint.
+func (h *Highlighter) Render(spans []Span, base lipgloss.Style) string {
+	var sb strings.Builder
+	for _, sp := range spans {
+		style := base
+		if h != nil {
+			if tok, ok := h.palette[sp.Kind]; ok && sp.Kind != KindPlain {
+				style = tok.Inherit(base)
+			}
+		}
+		sb.WriteString(style.Render(sp.Text))
+	}
+	return sb.String()
+}
+
+// plainSpans fills out with one KindPlain span per non-empty line.
+func plainSpans(lines []string, out [][]Span) [][]Span {
+	for i, l := range lines {
+		if l != "" {
+			out[i] = []Span{{Text: l, Kind: KindPlain}}
+		}
+	}
+	return out
+}
+
+// appendSpan appends sp, merging it into the previous span when both share a
+// kind so rendering emits fewer escape sequences.
+func appendSpan(spans []Span, sp Span) []Span {
+	if n := len(spans); n > 0 && spans[n-1].Kind == sp.Kind {
+		spans[n-1].Text += sp.Text
+		return spans
+	}
+	return append(spans, sp)
+}
+
+// kindOf folds a chroma token type into a TokenKind.
+func kindOf(t chroma.TokenType) TokenKind {
+	switch {
+	case t == chroma.KeywordType || t == chroma.NameClass || t == chroma.NameBuiltinPseudo:
+		return KindType
+	case t.InCategory(chroma.Keyword) || t == chroma.NameBuiltin:
+		return KindKeyword
+	case t == chroma.NameFunction || t == chroma.NameFunctionMagic:
+		return KindFunction
+	case t.InCategory(chroma.Comment) || t.InSubCategory(chroma.CommentPreproc):
+		return KindComment
+	case t.InSubCategory(chroma.LiteralString):
+		return KindString
+	case t.InCategory(chroma.Literal):
+		return KindNumber
+	case t.InCategory(chroma.Operator) || t.InCategory(chroma.Punctuation):
+		return KindOperator
+	default:
+		return KindPlain
+	}
+}
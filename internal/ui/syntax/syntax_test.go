@@ -0,0 +1,155 @@
+package syntax
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Elpulgo/azdo/internal/ui/styles"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func joinSpans(spans []Span) string {
+	var sb strings.Builder
+	for _, sp := range spans {
+		sb.WriteString(sp.Text)
+	}
+	return sb.String()
+}
+
+func TestNewHighlighter_LexerFromExtension(t *testing.T) {
+	theme := styles.GetDefaultTheme()
+	tests := []struct {
+		path    string
+		enabled bool
+	}{
+		{"/src/main.go", true},
+		{"main.go", true},
+		{"/web/app.ts", true},
+		{"/scripts/build.py", true},
+		{"/Dockerfile", true},
+		{"/data/blob.unknownext", false},
+		{"", false},
+		{"/", false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.path, func(t *testing.T) {
+			h := NewHighlighter(tc.path, theme)
+			if h.Enabled() != tc.enabled {
+				t.Errorf("Enabled() = %v, want %v", h.Enabled(), tc.enabled)
+			}
+		})
+	}
+}
+
+func TestNewLanguageHighlighter_LexerFromFenceName(t *testing.T) {
+	theme := styles.GetDefaultTheme()
+	for lang, enabled := range map[string]bool{
+		"go": true, "js": true, "yaml": true, " python ": true,
+		"": false, "suggestion": false,
+	} {
+		if got := NewLanguageHighlighter(lang, theme).Enabled(); got != enabled {
+			t.Errorf("NewLanguageHighlighter(%q).Enabled() = %v, want %v", lang, got, enabled)
+		}
+	}
+}
+
+func TestTokenize_PreservesLineContent(t *testing.T) {
+	h := NewHighlighter("main.go", styles.GetDefaultTheme())
+	lines := []string{
+		"package main",
+		"",
+		"func main() {",
+		"\tfmt.Println(\"hi\") // greet",
+		"}",
+	}
+	got := h.Tokenize(lines)
+	if len(got) != len(lines) {
+		t.Fatalf("len = %d, want %d", len(got), len(lines))
+	}
+	for i, l := range lines {
+		if joined := joinSpans(got[i]); joined != l {
+			t.Errorf("line %d = %q, want %q", i, joined, l)
+		}
+	}
+}
+
+func TestTokenize_ClassifiesGoTokens(t *testing.T) {
+	h := NewHighlighter("main.go", styles.GetDefaultTheme())
+	got := h.Tokenize([]string{`func f() string { return "x" } // c`})
+
+	kinds := map[string]TokenKind{}
+	for _, sp := range got[0] {
+		kinds[strings.TrimSpace(sp.Text)] = sp.Kind
+	}
+	checks := map[string]TokenKind{
+		"func":   KindKeyword,
+		"f":      KindFunction,
+		"string": KindType,
+		`"x"`:    KindString,
+		"// c":   KindComment,
+	}
+	for text, want := range checks {
+		if kinds[text] != want {
+			t.Errorf("kind of %q = %v, want %v (spans: %+v)", text, kinds[text], want, got[0])
+		}
+	}
+}
+
+// A block comment opened on one line must keep coloring the following lines,
+// which only works because the lines are lexed as one block.
+func TestTokenize_MultiLineCommentCarriesAcrossLines(t *testing.T) {
+	h := NewHighlighter("main.go", styles.GetDefaultTheme())
+	got := h.Tokenize([]string{"/* start", "middle", "end */", "x := 1"})
+
+	for i := 0; i < 3; i++ {
+		if len(got[i]) != 1 || got[i][0].Kind != KindComment {
+			t.Errorf("line %d spans = %+v, want a single comment span", i, got[i])
+		}
+	}
+	if got[3][0].Kind == KindComment {
+		t.Errorf("line after comment should not be a comment: %+v", got[3])
+	}
+}
+
+func TestTokenize_UnknownTypeIsPlain(t *testing.T) {
+	h := NewHighlighter("notes.unknownext", styles.GetDefaultTheme())
+	got := h.Tokenize([]string{"func main() {", ""})
+	if len(got[0]) != 1 || got[0][0].Kind != KindPlain || got[0][0].Text != "func main() {" {
+		t.Errorf("spans = %+v, want one plain span", got[0])
+	}
+	if len(got[1]) != 0 {
+		t.Errorf("empty line spans = %+v, want none", got[1])
+	}
+}
+
+func TestRender_KeepsBaseBackground(t *testing.T) {
+	theme := styles.GetDefaultTheme()
+	h := NewHighlighter("main.go", theme)
+	base := lipgloss.NewStyle().Background(lipgloss.Color("#112233"))
+
+	for kind, style := range h.palette {
+		merged := style.Inherit(base)
+		if merged.GetBackground() != lipgloss.Color("#112233") {
+			t.Errorf("kind %v lost base background", kind)
+		}
+	}
+
+	spans := []Span{{Text: "return", Kind: KindKeyword}, {Text: " x", Kind: KindPlain}}
+	if got := h.Render(spans, base); !strings.Contains(got, "return") || !strings.Contains(got, " x") {
+		t.Errorf("Render() = %q, want both span texts", got)
+	}
+}
+
+func TestPalette_DerivedFromTheme(t *testing.T) {
+	theme := styles.GetDefaultTheme()
+	p := Palette(theme)
+	if p[KindKeyword].GetForeground() != theme.Primary {
+		t.Errorf("keyword fg = %v, want theme Primary %v", p[KindKeyword].GetForeground(), theme.Primary)
+	}
+	if p[KindString].GetForeground() != theme.Warning {
+		t.Errorf("string fg = %v, want theme Warning %v", p[KindString].GetForeground(), theme.Warning)
+	}
+	if p[KindComment].GetForeground() != theme.ForegroundMuted {
+		t.Errorf("comment fg = %v, want theme ForegroundMuted %v", p[KindComment].GetForeground(), theme.ForegroundMuted)
+	}
+}
@@ -0,0 +1,507 @@
+package workitems
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/Elpulgo/azdo/internal/provider"
+	"github.com/Elpulgo/azdo/internal/ui/markdown"
+	"github.com/Elpulgo/azdo/internal/ui/styles"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// attachmentsLoadedMsg is sent when the attachments of the work item have
+// been fetched
+type attachmentsLoadedMsg struct {
+	attachments []provider.WorkItemAttachment
+	err         error
+}
+
+// attachmentDoneMsg is sent when an attachment was downloaded, opened or
+// uploaded. uploaded asks for the attachments to be fetched again.
+type attachmentDoneMsg struct {
+	message  string
+	uploaded bool
+	err      error
+}
+
+// fetchAttachments fetches the files attached to the work item.
+func (m *DetailModel) fetchAttachments() tea.Cmd {
+	client := m.client
+	wi := m.workItem
+	return func() tea.Msg {
+		if client == nil {
+			return attachmentsLoadedMsg{err: fmt.Errorf("no client available")}
+		}
+		attachments, err := client.GetWorkItemAttachments(wi.Identity.Scope, workItemNumericID(wi))
+		return attachmentsLoadedMsg{attachments: attachments, err: err}
+	}
+}
+
+// writeAttachments appends the Attachments section to the viewport
+// content. Nothing is written for an item without attachments.
+func (m *DetailModel) writeAttachments(sb *strings.Builder) {
+	if m.attachmentsErr != nil {
+		sb.WriteString(m.styles.Muted.Render(fmt.Sprintf("Could not load attachments: %v", m.attachmentsErr)))
+		sb.WriteString("\n\n")
+		return
+	}
+	if len(m.attachments) == 0 {
+		return
+	}
+	sb.WriteString(m.styles.Label.Render(fmt.Sprintf("Attachments (%d)", len(m.attachments))))
+	sb.WriteString("\n")
+	for _, a := range m.attachments {
+		sb.WriteString("  ")
+		sb.WriteString(markdown.Hyperlink(a.Name, a.URL))
+		if details := attachmentDetails(a); details != "" {
+			sb.WriteString(m.styles.Muted.Render(" · " + details))
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n")
+}
+
+// attachmentDetails joins what is known of an attachment's size, uploader
+// and upload date.
+func attachmentDetails(a provider.WorkItemAttachment) string {
+	var parts []string
+	if a.Size > 0 {
+		parts = append(parts, formatSize(a.Size))
+	}
+	if a.UploadedBy != "" {
+		parts = append(parts, a.UploadedBy)
+	}
+	if !a.UploadedAt.IsZero() {
+		parts = append(parts, a.UploadedAt.Local().Format("2006-01-02"))
+	}
+	return strings.Join(parts, " · ")
+}
+
+// formatSize renders a size in bytes with a binary unit, e.g. "1.5 MB".
+func formatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	value, exp := float64(size)/unit, 0
+	for value >= unit && exp < 3 {
+		value /= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", value, "KMGT"[exp])
+}
+
+// defaultDownloadDir returns ~/Downloads if it exists, else the working
+// directory.
+func defaultDownloadDir() string {
+	if home, err := os.UserHomeDir(); err == nil {
+		dir := filepath.Join(home, "Downloads")
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			return dir
+		}
+	}
+	if wd, err := os.Getwd(); err == nil {
+		return wd
+	}
+	return "."
+}
+
+// expandHome replaces a leading ~ of path with the home directory.
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, path[1:])
+}
+
+// attachmentFileName returns the name to save a under: its own name, else
+// the last segment of its URL, else attachment-<id> after the work item.
+// Names that could leave the chosen directory are skipped.
+func attachmentFileName(a provider.WorkItemAttachment, id int) string {
+	candidates := []string{a.Name}
+	if u, err := url.Parse(a.URL); err == nil {
+		candidates = append(candidates, path.Base(u.Path))
+	}
+	for _, name := range candidates {
+		name = strings.TrimSpace(name)
+		if name != "" && name != "." && name != ".." && !strings.ContainsAny(name, `/\`) {
+			return name
+		}
+	}
+	return fmt.Sprintf("attachment-%d", id)
+}
+
+// createUnique creates the file path, or path with " (n)" before its
+// extension if a file of that name already exists, so downloads never
+// overwrite files. It returns the open file and the path it was created at.
+func createUnique(path string) (*os.File, string, error) {
+	ext := filepath.Ext(path)
+	base := strings.TrimSuffix(path, ext)
+	for n := 1; ; n++ {
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if !errors.Is(err, os.ErrExist) {
+			return f, path, err
+		}
+		path = fmt.Sprintf("%s (%d)%s", base, n, ext)
+	}
+}
+
+// attachmentForm is the modal managing the attachments of a work item.
+// ↑/↓ move between the attachments and the upload row at the bottom. On an
+// attachment, o opens it in the browser and enter or s asks for the
+// directory to save it to. On the upload row the path of a local file is
+// typed and enter uploads it. Backends that cannot upload have no upload
+// row.
+type attachmentForm struct {
+	styles      *styles.Styles
+	client      provider.Provider
+	visible     bool
+	width       int
+	height      int
+	item        provider.WorkItem
+	attachments []provider.WorkItemAttachment
+	canUpload   bool
+	cursor      int  // index into attachments; len(attachments) is the upload row
+	saving      bool // the input holds the directory to save attachments[cursor] to
+	dir         string
+	input       textinput.Model
+	status      string
+	err         string
+	busy        bool
+}
+
+func newAttachmentForm(client provider.Provider, s *styles.Styles) attachmentForm {
+	ti := textinput.New()
+	ti.CharLimit = 1024
+	ti.Width = 56
+	return attachmentForm{styles: s, client: client, input: ti}
+}
+
+// Show opens the form on item and its attachments, with the first
+// attachment selected.
+func (f *attachmentForm) Show(item provider.WorkItem, attachments []provider.WorkItemAttachment) tea.Cmd {
+	f.item = item
+	f.attachments = attachments
+	f.canUpload = item.Identity.Kind != provider.KindGitHub
+	f.cursor = 0
+	f.saving = false
+	f.status, f.err = "", ""
+	f.busy = false
+	f.visible = true
+	return f.moved()
+}
+
+// Hide closes the form.
+func (f *attachmentForm) Hide() {
+	f.visible = false
+	f.input.Blur()
+}
+
+// IsVisible returns whether the form is open.
+func (f attachmentForm) IsVisible() bool {
+	return f.visible
+}
+
+// SetSize sets the area the form is centered in.
+func (f *attachmentForm) SetSize(width, height int) {
+	f.width = width
+	f.height = height
+}
+
+// setAttachments replaces the listed attachments, keeping the cursor in
+// range.
+func (f *attachmentForm) setAttachments(attachments []provider.WorkItemAttachment) {
+	onUpload := f.onUpload()
+	f.attachments = attachments
+	f.cursor = min(f.cursor, f.rows()-1)
+	if onUpload {
+		f.cursor = f.rows() - 1
+	}
+	f.cursor = max(f.cursor, 0)
+}
+
+// handleDone records the outcome of a download, open or upload.
+func (f *attachmentForm) handleDone(msg attachmentDoneMsg) {
+	f.busy = false
+	if msg.err != nil {
+		f.err = attachmentErrorText(msg.err)
+		return
+	}
+	f.err = ""
+	f.status = msg.message
+	if msg.uploaded {
+		f.input.SetValue("")
+	}
+}
+
+// attachmentErrorText describes a failed attachment action.
+func attachmentErrorText(err error) string {
+	if errors.Is(err, provider.ErrAttachmentUploadUnsupported) {
+		return "Files cannot be uploaded here"
+	}
+	return fmt.Sprintf("Error: %v", err)
+}
+
+// rows returns the number of selectable rows.
+func (f attachmentForm) rows() int {
+	if f.canUpload {
+		return len(f.attachments) + 1
+	}
+	return len(f.attachments)
+}
+
+// onUpload reports whether the upload row is selected.
+func (f attachmentForm) onUpload() bool {
+	return f.canUpload && f.cursor == len(f.attachments)
+}
+
+// moved sets up the input for the selected row: the path of the file to
+// upload on the upload row, nothing on an attachment.
+func (f *attachmentForm) moved() tea.Cmd {
+	f.saving = false
+	if f.onUpload() {
+		f.input.Prompt = "Upload: "
+		f.input.Placeholder = "path of a local file"
+		f.input.SetValue("")
+		return f.input.Focus()
+	}
+	f.input.Blur()
+	return nil
+}
+
+// startSave asks for the directory to save the selected attachment to,
+// proposing the one used last.
+func (f *attachmentForm) startSave() tea.Cmd {
+	if f.dir == "" {
+		f.dir = defaultDownloadDir()
+	}
+	f.saving = true
+	f.input.Prompt = "Save to: "
+	f.input.Placeholder = "directory"
+	f.input.SetValue(f.dir)
+	f.input.CursorEnd()
+	return f.input.Focus()
+}
+
+// save returns the command downloading the selected attachment into the
+// typed directory.
+func (f *attachmentForm) save() tea.Cmd {
+	dir := strings.TrimSpace(f.input.Value())
+	if dir == "" {
+		f.err = "Enter the directory to save to"
+		return nil
+	}
+	if f.client == nil || f.busy {
+		return nil
+	}
+	f.dir = dir
+	f.saving = false
+	f.input.Blur()
+	f.busy = true
+	f.err, f.status = "", ""
+	client, scope, a := f.client, f.item.Identity.Scope, f.attachments[f.cursor]
+	id := workItemNumericID(f.item)
+	return func() tea.Msg {
+		dir := expandHome(dir)
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			return attachmentDoneMsg{err: fmt.Errorf("%s is not a directory", dir)}
+		}
+		content, err := client.DownloadWorkItemAttachment(scope, a)
+		if err != nil {
+			return attachmentDoneMsg{err: err}
+		}
+		file, path, err := createUnique(filepath.Join(dir, attachmentFileName(a, id)))
+		if err != nil {
+			return attachmentDoneMsg{err: err}
+		}
+		_, err = file.Write(content)
+		if cerr := file.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			os.Remove(path)
+			return attachmentDoneMsg{err: err}
+		}
+		return attachmentDoneMsg{message: "Saved to " + path}
+	}
+}
+
+// open returns the command opening the selected attachment in the browser,
+// which is signed in to the backend.
+func (f *attachmentForm) open() tea.Cmd {
+	a := f.attachments[f.cursor]
+	return func() tea.Msg {
+		if err := openURL(a.URL); err != nil {
+			return attachmentDoneMsg{err: err}
+		}
+		return attachmentDoneMsg{message: "Opened " + a.Name + " in browser"}
+	}
+}
+
+// upload returns the command uploading the typed file and attaching it.
+func (f *attachmentForm) upload() tea.Cmd {
+	path := strings.TrimSpace(f.input.Value())
+	if path == "" {
+		f.err = "Enter the path of the file to upload"
+		return nil
+	}
+	if f.client == nil || f.busy {
+		return nil
+	}
+	f.busy = true
+	f.err, f.status = "", ""
+	client, wi := f.client, f.item
+	return func() tea.Msg {
+		path := expandHome(path)
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return attachmentDoneMsg{err: err}
+		}
+		name := filepath.Base(path)
+		if _, err := client.AddWorkItemAttachment(wi.Identity.Scope, workItemNumericID(wi), name, content); err != nil {
+			return attachmentDoneMsg{err: err}
+		}
+		return attachmentDoneMsg{message: "Attached " + name, uploaded: true}
+	}
+}
+
+// Update handles the form's keys.
+func (f attachmentForm) Update(msg tea.KeyMsg) (attachmentForm, tea.Cmd) {
+	if !f.visible {
+		return f, nil
+	}
+	if f.saving {
+		switch msg.String() {
+		case "esc":
+			f.saving = false
+			f.input.Blur()
+			return f, nil
+		case "enter":
+			return f, f.save()
+		}
+		var cmd tea.Cmd
+		f.input, cmd = f.input.Update(msg)
+		return f, cmd
+	}
+
+	switch msg.String() {
+	case "esc":
+		f.Hide()
+		return f, nil
+	case "up", "shift+tab":
+		if f.rows() > 0 {
+			f.cursor = (f.cursor + f.rows() - 1) % f.rows()
+		}
+		return f, f.moved()
+	case "down", "tab":
+		if f.rows() > 0 {
+			f.cursor = (f.cursor + 1) % f.rows()
+		}
+		return f, f.moved()
+	}
+
+	if f.onUpload() {
+		if msg.String() == "enter" {
+			return f, f.upload()
+		}
+		var cmd tea.Cmd
+		f.input, cmd = f.input.Update(msg)
+		return f, cmd
+	}
+	if f.cursor >= len(f.attachments) {
+		return f, nil
+	}
+	switch msg.String() {
+	case "enter", "s":
+		return f, f.startSave()
+	case "o":
+		return f, f.open()
+	}
+	return f, nil
+}
+
+// View renders the form centered in its area.
+func (f attachmentForm) View() string {
+	if !f.visible {
+		return ""
+	}
+	const width = 72
+
+	var rows []string
+	if len(f.attachments) == 0 {
+		rows = append(rows, f.styles.Muted.Render("  No attachments"))
+	}
+	for i, a := range f.attachments {
+		cursor := "  "
+		if i == f.cursor {
+			cursor = "> "
+		}
+		line := cursor + a.Name
+		if details := attachmentDetails(a); details != "" {
+			line += " · " + details
+		}
+		style := lipgloss.NewStyle().Width(width).Foreground(f.styles.Theme.GetForeground())
+		if i == f.cursor {
+			style = style.Foreground(f.styles.Theme.GetSelectForeground()).Background(f.styles.Theme.GetSelectBackground())
+		}
+		rows = append(rows, style.MaxWidth(width).Render(line))
+	}
+	switch {
+	case f.saving:
+		rows = append(rows, "", "  "+f.input.View())
+	case f.canUpload:
+		cursor := "  "
+		if f.onUpload() {
+			cursor = "> "
+		}
+		rows = append(rows, "", cursor+f.input.View())
+	}
+
+	title := lipgloss.NewStyle().
+		Foreground(f.styles.Theme.GetPrimary()).
+		Bold(true).
+		Render(fmt.Sprintf("Attachments of #%s", f.item.Identity.ID))
+	status := ""
+	switch {
+	case f.busy:
+		status = f.styles.Muted.Render("Working...")
+	case f.err != "":
+		status = f.styles.Error.Render(f.err)
+	case f.status != "":
+		status = f.styles.Muted.Render(f.status)
+	}
+	keys := "↑/↓: move • enter: save • o: open in browser • esc: close"
+	if f.saving {
+		keys = "enter: save here • esc: cancel"
+	} else if f.onUpload() {
+		keys = "↑/↓: move • enter: upload • esc: close"
+	}
+	help := lipgloss.NewStyle().
+		Foreground(f.styles.Theme.GetForegroundMuted()).
+		Render(keys)
+
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		title, "", strings.Join(rows, "\n"), "", status, help)
+	modal := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(f.styles.Theme.GetBorder()).
+		Padding(1, 2).
+		Render(content)
+
+	if f.width > 0 && f.height > 0 {
+		modal = lipgloss.Place(f.width, f.height, lipgloss.Center, lipgloss.Center, modal)
+	}
+	return modal
+}
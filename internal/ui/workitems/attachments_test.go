@@ -0,0 +1,212 @@
+package workitems
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Elpulgo/azdo/internal/provider"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// attachmentProvider keeps the attachments of one work item in memory;
+// every other method panics via the nil embedded interface.
+type attachmentProvider struct {
+	provider.Provider
+	attachments []provider.WorkItemAttachment
+	uploads     []string
+}
+
+func (p *attachmentProvider) SearchPeople(scope, query string) ([]provider.Person, error) {
+	return nil, nil
+}
+
+func (p *attachmentProvider) WorkItemURL(scope string, id int) string { return "" }
+
+func (p *attachmentProvider) GetWorkItemComments(scope string, id int) ([]provider.WorkItemComment, error) {
+	return nil, nil
+}
+
+func (p *attachmentProvider) GetWorkItemLinks(scope string, id int) ([]provider.WorkItemLink, error) {
+	return nil, nil
+}
+
+func (p *attachmentProvider) GetWorkItemAttachments(scope string, id int) ([]provider.WorkItemAttachment, error) {
+	return p.attachments, nil
+}
+
+func (p *attachmentProvider) DownloadWorkItemAttachment(scope string, a provider.WorkItemAttachment) ([]byte, error) {
+	return []byte("content of " + a.Name), nil
+}
+
+func (p *attachmentProvider) AddWorkItemAttachment(scope string, id int, name string, content []byte) (*provider.WorkItemAttachment, error) {
+	p.uploads = append(p.uploads, name+": "+string(content))
+	a := provider.WorkItemAttachment{Name: name, URL: "https://x/" + name, Size: int64(len(content))}
+	p.attachments = append(p.attachments, a)
+	return &a, nil
+}
+
+// newAttachmentModel opens work item 7 with its attachments loaded.
+func newAttachmentModel(p *attachmentProvider) *DetailModel {
+	m := NewDetailModel(p, newWI(7, "Crash", "Active", "Bug"))
+	m.SetSize(100, 40)
+	return runBatch(m, m.Init())
+}
+
+func TestFormatSize(t *testing.T) {
+	for size, want := range map[int64]string{512: "512 B", 2048: "2.0 KB", 1536 * 1024: "1.5 MB", 3 << 30: "3.0 GB"} {
+		if got := formatSize(size); got != want {
+			t.Errorf("formatSize(%d) = %q, want %q", size, got, want)
+		}
+	}
+}
+
+func TestDetail_ListsAttachments(t *testing.T) {
+	uploaded := time.Date(2026, 10, 2, 12, 0, 0, 0, time.Local)
+	m := newAttachmentModel(&attachmentProvider{attachments: []provider.WorkItemAttachment{
+		{Name: "crash.png", URL: "https://x/crash.png", Size: 2048, UploadedBy: "Ada", UploadedAt: uploaded},
+		{Name: "app.log", URL: "https://x/app.log"},
+	}})
+
+	view := m.View()
+	for _, want := range []string{"Attachments (2)", "crash.png", "2.0 KB · Ada · 2026-10-02", "app.log"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("detail should show %q:\n%s", want, view)
+		}
+	}
+}
+
+func TestAttachmentForm_SavesAndOpens(t *testing.T) {
+	origOpen := openURL
+	defer func() { openURL = origOpen }()
+	var opened []string
+	openURL = func(url string) error {
+		opened = append(opened, url)
+		return nil
+	}
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "app.log"), []byte("older"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	m := newAttachmentModel(&attachmentProvider{attachments: []provider.WorkItemAttachment{
+		{Name: "crash.png", URL: "https://x/crash.png"},
+		{Name: "app.log", URL: "https://x/app.log"},
+	}})
+
+	m, _ = m.Update(keyRunes("A"))
+	m, _ = m.Update(keyRunes("o"))
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if cmd != nil {
+		m, _ = m.Update(cmd())
+	}
+	m, cmd = m.Update(keyRunes("o"))
+	m, _ = m.Update(cmd())
+	if len(opened) != 1 || opened[0] != "https://x/app.log" {
+		t.Errorf("opened = %v, want app.log only", opened)
+	}
+	if !strings.Contains(m.View(), "Opened app.log in browser") {
+		t.Errorf("the form should confirm the open:\n%s", m.View())
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m.attachmentForm.input.SetValue(dir)
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("enter should save the attachment")
+	}
+	m, _ = m.Update(cmd())
+
+	saved := filepath.Join(dir, "app (1).log")
+	if content, err := os.ReadFile(saved); err != nil || string(content) != "content of app.log" {
+		t.Errorf("saved file = %q, %v; want a copy next to the existing app.log", content, err)
+	}
+	if !strings.Contains(m.View(), "Saved to "+saved) {
+		t.Errorf("the form should say where the file went:\n%s", m.View())
+	}
+}
+
+func TestAttachmentForm_SaveStaysInDirectory(t *testing.T) {
+	parent := t.TempDir()
+	dir := filepath.Join(parent, "downloads")
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	m := newAttachmentModel(&attachmentProvider{attachments: []provider.WorkItemAttachment{
+		{Name: "..", URL: "https://x/_apis/wit/attachments/a1?fileName=.."},
+	}})
+
+	m, _ = m.Update(keyRunes("A"))
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m.attachmentForm.input.SetValue(dir)
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m, _ = m.Update(cmd())
+
+	saved := filepath.Join(dir, "a1")
+	if content, err := os.ReadFile(saved); err != nil || string(content) != "content of .." {
+		t.Errorf("saved file = %q, %v; want the URL's name inside the directory", content, err)
+	}
+	if entries, _ := os.ReadDir(parent); len(entries) != 1 {
+		t.Errorf("nothing should be written outside the directory, found %d entries", len(entries))
+	}
+
+	for _, tc := range []struct {
+		a    provider.WorkItemAttachment
+		want string
+	}{
+		{provider.WorkItemAttachment{Name: "../../etc/passwd", URL: "https://x/"}, "attachment-7"},
+		{provider.WorkItemAttachment{Name: ".", URL: "https://x/files/.."}, "attachment-7"},
+		{provider.WorkItemAttachment{Name: "log.txt"}, "log.txt"},
+	} {
+		if got := attachmentFileName(tc.a, 7); got != tc.want {
+			t.Errorf("attachmentFileName(%+v) = %q, want %q", tc.a, got, tc.want)
+		}
+	}
+}
+
+func TestAttachmentForm_UploadsLocalFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notes.txt")
+	if err := os.WriteFile(path, []byte("hello"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	p := &attachmentProvider{}
+	m := newAttachmentModel(p)
+
+	m, _ = m.Update(keyRunes("A"))
+	m, _ = m.Update(keyRunes(path))
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("enter on the upload row should upload the file")
+	}
+	m, cmd = m.Update(cmd())
+	if cmd == nil {
+		t.Fatal("an upload should fetch the attachments again")
+	}
+	m, _ = m.Update(cmd())
+
+	if len(p.uploads) != 1 || p.uploads[0] != "notes.txt: hello" {
+		t.Errorf("uploads = %v", p.uploads)
+	}
+	if view := m.View(); !strings.Contains(view, "Attached notes.txt") || !strings.Contains(view, "notes.txt · 5 B") {
+		t.Errorf("the form should list the new attachment:\n%s", view)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if view := m.View(); !strings.Contains(view, "Attachments (1)") {
+		t.Errorf("the detail should list the new attachment:\n%s", view)
+	}
+}
+
+func TestAttachmentForm_NoUploadOnGitHub(t *testing.T) {
+	wi := newWI(7, "Crash", "open", "Bug")
+	wi.Identity.Kind = provider.KindGitHub
+	m := NewDetailModel(&attachmentProvider{}, wi)
+	m.SetSize(100, 40)
+	m = runBatch(m, m.Init())
+
+	m, _ = m.Update(keyRunes("A"))
+	if view := m.View(); strings.Contains(view, "Upload:") || !strings.Contains(view, "No attachments") {
+		t.Errorf("GitHub items have no upload row:\n%s", view)
+	}
+}
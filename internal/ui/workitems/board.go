@@ -0,0 +1,565 @@
+package workitems
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Elpulgo/azdo/internal/provider"
+	"github.com/Elpulgo/azdo/internal/ui/styles"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// boardColumnMinWidth is the narrowest a board column is drawn; columns
+// that do not fit are scrolled to horizontally.
+const boardColumnMinWidth = 26
+
+// boardCardHeight is the number of lines a card takes, including the gap
+// below it.
+const boardCardHeight = 3
+
+// boardView is the Kanban mode of the work items tab: the listed items of
+// one project laid out in the columns of one of its boards. Boards are
+// fetched per project the first time the project is shown.
+type boardView struct {
+	styles  *styles.Styles
+	enabled bool
+	width   int
+	height  int
+	scope   string                      // the project shown
+	boards  map[string][]provider.Board // by project, once fetched
+	loading map[string]bool
+	errs    map[string]error
+	index   map[string]int // the board shown, by project
+	col     int            // selected column
+	row     int            // selected card in the column
+	// focus is the nodeKey of a moved card, selected once it shows up in
+	// the selected column after the refresh.
+	focus string
+}
+
+func newBoardView(s *styles.Styles) boardView {
+	return boardView{
+		styles:  s,
+		boards:  map[string][]provider.Board{},
+		loading: map[string]bool{},
+		errs:    map[string]error{},
+		index:   map[string]int{},
+	}
+}
+
+// board returns the board shown, or nil while the project's boards are
+// not fetched.
+func (b *boardView) board() *provider.Board {
+	boards := b.boards[b.scope]
+	if len(boards) == 0 {
+		return nil
+	}
+	return &boards[min(b.index[b.scope], len(boards)-1)]
+}
+
+// show switches to the board of scope, fetching its boards the first time.
+// items are the listed items, used to derive a board from states when the
+// project has none.
+func (b *boardView) show(client provider.Provider, scope string, items []provider.WorkItem) tea.Cmd {
+	b.scope = scope
+	b.col, b.row = 0, 0
+	if client == nil || b.loading[scope] || b.boards[scope] != nil {
+		return nil
+	}
+	b.loading[scope] = true
+	delete(b.errs, scope)
+	return fetchBoards(client, scope, workItemTypes(items))
+}
+
+// handleBoards records the fetched boards of a project and shows the one
+// placing the most of items.
+func (b *boardView) handleBoards(msg boardsMsg, items []provider.WorkItem) {
+	b.loading[msg.scope] = false
+	if msg.err != nil {
+		b.errs[msg.scope] = msg.err
+		return
+	}
+	b.boards[msg.scope] = msg.boards
+	best, placed := 0, -1
+	for i, board := range msg.boards {
+		n := 0
+		for _, cards := range boardCards(board, items) {
+			n += len(cards)
+		}
+		if n > placed {
+			best, placed = i, n
+		}
+	}
+	b.index[msg.scope] = best
+}
+
+// nextBoard shows the project's next board.
+func (b *boardView) nextBoard() {
+	if n := len(b.boards[b.scope]); n > 1 {
+		b.index[b.scope] = (b.index[b.scope] + 1) % n
+		b.col, b.row = 0, 0
+	}
+}
+
+// follow selects the column a card moved to, and the card once the
+// refreshed items show it there.
+func (b *boardView) follow(wi provider.WorkItem, column string) {
+	board := b.board()
+	if board == nil {
+		return
+	}
+	for i, col := range board.Columns {
+		if col.Name == column {
+			b.col, b.row = i, 0
+			b.focus = nodeKey(wi)
+		}
+	}
+}
+
+// clamp keeps the cursor on a card of cards, selecting the focused card
+// when it is in the selected column.
+func (b *boardView) clamp(cards [][]provider.WorkItem) {
+	b.col = max(0, min(b.col, len(cards)-1))
+	if len(cards) == 0 {
+		b.row = 0
+		return
+	}
+	if b.focus != "" {
+		for i, wi := range cards[b.col] {
+			if nodeKey(wi) == b.focus {
+				b.row, b.focus = i, ""
+			}
+		}
+	}
+	b.row = max(0, min(b.row, len(cards[b.col])-1))
+}
+
+// selected returns the selected card, if any.
+func (b *boardView) selected(cards [][]provider.WorkItem) (provider.WorkItem, bool) {
+	b.clamp(cards)
+	if b.col >= len(cards) || b.row >= len(cards[b.col]) {
+		return provider.WorkItem{}, false
+	}
+	return cards[b.col][b.row], true
+}
+
+// moveTarget returns the nearest column in direction dir (-1 or 1) from
+// the selected one that has a state for wi's type.
+func (b *boardView) moveTarget(board provider.Board, wi provider.WorkItem, dir int) (provider.BoardColumn, bool) {
+	for i := b.col + dir; i >= 0 && i < len(board.Columns); i += dir {
+		if _, ok := board.Columns[i].StateFor(wi.WorkItemType); ok {
+			return board.Columns[i], true
+		}
+	}
+	return provider.BoardColumn{}, false
+}
+
+// boardCards returns the cards of each column of board. Items are placed
+// by columnOf; those matching no column are left out.
+func boardCards(board provider.Board, items []provider.WorkItem) [][]provider.WorkItem {
+	cards := make([][]provider.WorkItem, len(board.Columns))
+	for _, wi := range items {
+		if i := columnOf(board, wi); i >= 0 {
+			cards[i] = append(cards[i], wi)
+		}
+	}
+	return cards
+}
+
+// columnOf returns the index of the column of board wi is shown in, or -1.
+// A column must have wi's state for its type. Columns of a label the item
+// carries come first, then the column the backend tracks for the item,
+// then the first column of its state.
+func columnOf(board provider.Board, wi provider.WorkItem) int {
+	inState := func(col provider.BoardColumn) bool {
+		state, ok := col.StateFor(wi.WorkItemType)
+		return ok && strings.EqualFold(state, wi.State)
+	}
+	tags := tagList(wi.Tags)
+	for i, col := range board.Columns {
+		if col.Label == "" || !inState(col) {
+			continue
+		}
+		for _, tag := range tags {
+			if strings.EqualFold(tag, col.Label) {
+				return i
+			}
+		}
+	}
+	if wi.BoardColumn != "" {
+		for i, col := range board.Columns {
+			if col.Label == "" && col.Name == wi.BoardColumn && inState(col) {
+				return i
+			}
+		}
+	}
+	for i, col := range board.Columns {
+		if col.Label == "" && inState(col) {
+			return i
+		}
+	}
+	return -1
+}
+
+// View renders the board of the project's items.
+func (b *boardView) View(items []provider.WorkItem) string {
+	s := b.styles
+	if err := b.errs[b.scope]; err != nil {
+		return s.Error.Render(fmt.Sprintf("Could not load boards of %s: %v", b.scope, err))
+	}
+	board := b.board()
+	if board == nil {
+		return s.Muted.Render("Loading boards...")
+	}
+	if len(board.Columns) == 0 {
+		return s.Muted.Render(fmt.Sprintf("Board %s has no columns", board.Name))
+	}
+	cards := boardCards(*board, items)
+	b.clamp(cards)
+
+	header := s.Title.Render(board.Name+" board") + s.Muted.Render(" · "+b.scope)
+	if n := len(b.boards[b.scope]); n > 1 {
+		header += s.Muted.Render(fmt.Sprintf(" (%d/%d)", b.index[b.scope]+1, n))
+	}
+
+	// Show as many columns as fit, scrolled to keep the selected one.
+	width := max(b.width, boardColumnMinWidth)
+	visible := max(1, min(len(board.Columns), width/boardColumnMinWidth))
+	start := max(0, min(b.col-visible/2, len(board.Columns)-visible))
+	colWidth := width/visible - 1
+
+	// The header, the column headers and the scroll hints take six lines.
+	maxCards := max(1, (b.height-6)/boardCardHeight)
+	var columns []string
+	for i := start; i < start+visible; i++ {
+		columns = append(columns, b.columnView(board.Columns[i], cards[i], i == b.col, colWidth, maxCards))
+	}
+	body := lipgloss.JoinHorizontal(lipgloss.Top, columns...)
+
+	scroll := ""
+	if start > 0 {
+		scroll += "◀ "
+	}
+	if start+visible < len(board.Columns) {
+		scroll += "▶"
+	}
+	if scroll != "" {
+		header += s.Muted.Render("  " + strings.TrimSpace(scroll))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, header, "", body)
+}
+
+// columnView renders a column: its name with the number of cards against
+// the WIP limit, and the cards in a window around the selected one.
+func (b *boardView) columnView(col provider.BoardColumn, cards []provider.WorkItem, selected bool, width, maxCards int) string {
+	s := b.styles
+	count := strconv.Itoa(len(cards))
+	countStyle := s.Muted
+	if col.WIPLimit > 0 {
+		count += "/" + strconv.Itoa(col.WIPLimit)
+		if len(cards) > col.WIPLimit {
+			countStyle = s.Error
+		}
+	}
+	nameStyle := s.Value.Bold(true)
+	if selected {
+		nameStyle = s.Title.Underline(true)
+	}
+	name := ansi.Truncate(col.Name, max(1, width-len(count)-1), "…")
+	lines := []string{nameStyle.Render(name) + " " + countStyle.Render(count), s.Muted.Render(strings.Repeat("─", width))}
+
+	first := 0
+	if selected {
+		first = max(0, min(b.row-maxCards/2, len(cards)-maxCards))
+	}
+	if first > 0 {
+		lines = append(lines, s.Muted.Render(fmt.Sprintf("↑ %d more", first)))
+	}
+	for i := first; i < len(cards) && i < first+maxCards; i++ {
+		lines = append(lines, b.cardView(cards[i], selected && i == b.row, width)...)
+	}
+	if rest := len(cards) - first - maxCards; rest > 0 {
+		lines = append(lines, s.Muted.Render(fmt.Sprintf("↓ %d more", rest)))
+	}
+	return lipgloss.NewStyle().Width(width + 1).Render(strings.Join(lines, "\n"))
+}
+
+// cardView renders a card: the type icon, ID and title, then the
+// assignee and story points.
+func (b *boardView) cardView(wi provider.WorkItem, selected bool, width int) []string {
+	s := b.styles
+	title := ansi.Truncate(fmt.Sprintf("#%s %s", wi.Identity.ID, wi.Title), max(1, width-3), "…")
+	assignee := wi.AssignedToName
+	if assignee == "" {
+		assignee = "Unassigned"
+	}
+	if wi.StoryPoints > 0 {
+		assignee += " · " + strconv.FormatFloat(wi.StoryPoints, 'f', -1, 64) + " pts"
+	}
+	details := ansi.Truncate(assignee, max(1, width-3), "…")
+
+	style := lipgloss.NewStyle().Width(width)
+	if selected {
+		style = style.Foreground(s.Theme.GetSelectForeground()).Background(s.Theme.GetSelectBackground())
+	} else {
+		details = s.Muted.Render(details)
+	}
+	return []string{
+		style.Render(typeIconWithStyles(wi.ItemKind, s) + " " + title),
+		style.Render("  " + details),
+		"",
+	}
+}
+
+// workItemTypes returns the distinct types of items in order.
+func workItemTypes(items []provider.WorkItem) []string {
+	var types []string
+	seen := map[string]bool{}
+	for _, wi := range items {
+		if !seen[wi.WorkItemType] {
+			seen[wi.WorkItemType] = true
+			types = append(types, wi.WorkItemType)
+		}
+	}
+	return types
+}
+
+// boardsMsg is sent when the boards of a project have been fetched
+type boardsMsg struct {
+	scope  string
+	boards []provider.Board
+	err    error
+}
+
+// fetchBoards fetches the boards of scope. A project without boards gets
+// one derived from the states of types.
+func fetchBoards(client provider.Provider, scope string, types []string) tea.Cmd {
+	return func() tea.Msg {
+		boards, err := client.GetBoards(scope)
+		if err != nil {
+			return boardsMsg{scope: scope, err: err}
+		}
+		if len(boards) == 0 {
+			board, err := stateBoard(client, scope, types)
+			if err != nil {
+				return boardsMsg{scope: scope, err: err}
+			}
+			boards = []provider.Board{board}
+		}
+		return boardsMsg{scope: scope, boards: boards}
+	}
+}
+
+// stateCategoryOrder orders the columns of a board derived from states.
+var stateCategoryOrder = map[string]int{"Proposed": 0, "InProgress": 1, "Resolved": 2, "Completed": 3}
+
+// stateBoard derives a board with a column per state of types, ordered by
+// state category and then as the types list them.
+func stateBoard(client provider.Provider, scope string, types []string) (provider.Board, error) {
+	type rankedColumn struct {
+		column provider.BoardColumn
+		rank   int
+	}
+	var columns []rankedColumn
+	index := map[string]int{}
+	for _, t := range types {
+		states, err := client.GetWorkItemTypeStates(scope, t)
+		if err != nil {
+			return provider.Board{}, err
+		}
+		for _, st := range states {
+			i, ok := index[st.Name]
+			if !ok {
+				rank, known := stateCategoryOrder[st.Category]
+				if !known {
+					rank = len(stateCategoryOrder)
+				}
+				i = len(columns)
+				index[st.Name] = i
+				columns = append(columns, rankedColumn{provider.BoardColumn{Name: st.Name, States: map[string]string{}}, rank})
+			}
+			columns[i].column.States[t] = st.Name
+		}
+	}
+	sort.SliceStable(columns, func(i, j int) bool { return columns[i].rank < columns[j].rank })
+
+	board := provider.Board{Name: "States"}
+	for _, c := range columns {
+		board.Columns = append(board.Columns, c.column)
+	}
+	return board, nil
+}
+
+// boardMovedMsg is sent when a card has been moved to another column
+type boardMovedMsg struct {
+	item   provider.WorkItem
+	column string
+	err    error
+}
+
+// moveOnBoard moves wi to column of board.
+func moveOnBoard(client provider.Provider, wi provider.WorkItem, board provider.Board, column string) tea.Cmd {
+	return func() tea.Msg {
+		id, err := strconv.Atoi(wi.Identity.ID)
+		if err != nil {
+			return boardMovedMsg{item: wi, column: column, err: errors.New("invalid work item ID")}
+		}
+		err = client.MoveWorkItemOnBoard(wi.Identity.Scope, id, wi.WorkItemType, board, column)
+		return boardMovedMsg{item: wi, column: column, err: err}
+	}
+}
+
+// IsBoardMode returns true while the list is shown as a board.
+func (m Model) IsBoardMode() bool {
+	return m.board.enabled && m.GetViewMode() == ViewList
+}
+
+// boardItems returns the listed items of the project the board shows,
+// with the tag and state filters applied.
+func (m Model) boardItems() []provider.WorkItem {
+	var items []provider.WorkItem
+	for _, wi := range m.applyAllFilters(m.getBaseItems()) {
+		if wi.Identity.Scope == m.board.scope {
+			items = append(items, wi)
+		}
+	}
+	return items
+}
+
+// boardScopes returns the projects the board can show: those of the
+// listed items, then the other configured ones.
+func (m Model) boardScopes() []string {
+	var scopes []string
+	seen := map[string]bool{}
+	add := func(scope string) {
+		if scope != "" && !seen[scope] {
+			seen[scope] = true
+			scopes = append(scopes, scope)
+		}
+	}
+	for _, wi := range m.getBaseItems() {
+		add(wi.Identity.Scope)
+	}
+	if m.client != nil {
+		for _, scope := range m.client.Scopes() {
+			add(scope)
+		}
+	}
+	return scopes
+}
+
+// showBoardOf switches the board to scope.
+func (m Model) showBoardOf(scope string) (Model, tea.Cmd) {
+	m.board.scope = scope
+	cmd := m.board.show(m.client, scope, m.boardItems())
+	return m, cmd
+}
+
+// toggleBoard switches between the list and the board. The board opens on
+// the project it showed last, or the first one with listed items.
+func (m Model) toggleBoard() (Model, tea.Cmd) {
+	m.board.enabled = !m.board.enabled
+	if !m.board.enabled {
+		return m, nil
+	}
+	m.sprint.enabled = false
+	scopes := m.boardScopes()
+	if len(scopes) == 0 {
+		return m, nil
+	}
+	scope := scopes[0]
+	for _, s := range scopes {
+		if s == m.board.scope {
+			scope = s
+		}
+	}
+	return m.showBoardOf(scope)
+}
+
+// updateBoard handles the keys of the board: the arrows select a card,
+// shift+arrows or < and > move it to the next column its type can be in,
+// b and p switch the board and the project, and enter opens the card.
+func (m Model) updateBoard(msg tea.KeyMsg) (Model, tea.Cmd) {
+	board := m.board.board()
+	var cards [][]provider.WorkItem
+	if board != nil {
+		cards = boardCards(*board, m.boardItems())
+	}
+	m.board.clamp(cards)
+
+	switch msg.String() {
+	case "esc":
+		m.board.enabled = false
+	case "left", "h":
+		if m.board.col > 0 {
+			m.board.col--
+			m.board.row = 0
+		}
+	case "right", "l":
+		if m.board.col < len(cards)-1 {
+			m.board.col++
+			m.board.row = 0
+		}
+	case "up", "k":
+		if m.board.row > 0 {
+			m.board.row--
+		}
+	case "down", "j":
+		m.board.row++
+		m.board.clamp(cards)
+	case "shift+left", "<", "shift+right", ">":
+		wi, ok := m.board.selected(cards)
+		if !ok {
+			return m, nil
+		}
+		dir := 1
+		if msg.String() == "shift+left" || msg.String() == "<" {
+			dir = -1
+		}
+		col, ok := m.board.moveTarget(*board, wi, dir)
+		if !ok {
+			m.statusMessage = fmt.Sprintf("%s #%s cannot move further", wi.WorkItemType, wi.Identity.ID)
+			return m, nil
+		}
+		m.statusMessage = fmt.Sprintf("Moving #%s to %s...", wi.Identity.ID, col.Name)
+		return m, moveOnBoard(m.client, wi, *board, col.Name)
+	case "b":
+		m.board.nextBoard()
+	case "p":
+		scopes := m.boardScopes()
+		for i, scope := range scopes {
+			if scope == m.board.scope {
+				return m.showBoardOf(scopes[(i+1)%len(scopes)])
+			}
+		}
+	case "enter":
+		wi, ok := m.board.selected(cards)
+		if !ok {
+			return m, nil
+		}
+		key := nodeKey(wi)
+		idx := m.list.FindIndex(func(item provider.WorkItem) bool { return nodeKey(item) == key })
+		if idx < 0 {
+			m.statusMessage = fmt.Sprintf("#%s is hidden in the list", wi.Identity.ID)
+			return m, nil
+		}
+		m.list.SetCursor(idx)
+		var cmd tea.Cmd
+		m.list, cmd = m.list.OpenSelectedDetail()
+		return m, cmd
+	case "r":
+		var cmd tea.Cmd
+		m.list, cmd = m.list.Reload()
+		if m.board.errs[m.board.scope] != nil {
+			// Retry the boards that failed to load along with the items
+			cmd = tea.Batch(cmd, m.board.show(m.client, m.board.scope, m.boardItems()))
+		}
+		return m, cmd
+	}
+	return m, nil
+}
@@ -0,0 +1,187 @@
+package workitems
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Elpulgo/azdo/internal/provider"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// boardProvider serves one board with a WIP limited "Doing" column in the
+// scope "testproject" and records the moves. Every other method panics
+// via the nil embedded interface.
+type boardProvider struct {
+	provider.Provider
+	boards []provider.Board
+	moves  []string
+}
+
+func (p *boardProvider) Scopes() []string     { return []string{"testproject"} }
+func (p *boardProvider) IsMultiProject() bool { return false }
+
+func (p *boardProvider) GetBoards(scope string) ([]provider.Board, error) {
+	return p.boards, nil
+}
+
+func (p *boardProvider) MoveWorkItemOnBoard(scope string, id int, workItemType string, board provider.Board, column string) error {
+	p.moves = append(p.moves, workItemType+" "+column)
+	return nil
+}
+
+func (p *boardProvider) GetWorkItemTypeStates(scope, workItemType string) ([]provider.WorkItemTypeState, error) {
+	if workItemType == "Bug" {
+		return []provider.WorkItemTypeState{{Name: "Closed", Category: "Completed"}, {Name: "New", Category: "Proposed"}}, nil
+	}
+	return []provider.WorkItemTypeState{{Name: "New", Category: "Proposed"}, {Name: "Active", Category: "InProgress"}}, nil
+}
+
+func storyBoard() provider.Board {
+	states := func(state string) map[string]string {
+		return map[string]string{"User Story": state, "Bug": state}
+	}
+	return provider.Board{Name: "Stories", Columns: []provider.BoardColumn{
+		{Name: "New", States: states("New")},
+		{Name: "Doing", States: states("Active"), WIPLimit: 1},
+		{Name: "Review", States: map[string]string{"User Story": "Active"}},
+		{Name: "Done", States: states("Closed")},
+	}}
+}
+
+// newBoardModel lists items and opens the board with the boards of p
+// loaded.
+func newBoardModel(t *testing.T, p *boardProvider, items ...provider.WorkItem) Model {
+	t.Helper()
+	m := NewModel(p)
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 120, Height: 30})
+	m, _ = m.Update(workItemsMsg{workItems: items})
+	m, cmd := m.Update(keyRunes("B"))
+	if !m.IsBoardMode() {
+		t.Fatal("B should show the board")
+	}
+	if cmd == nil {
+		t.Fatal("the board should fetch the project's boards")
+	}
+	m, _ = m.Update(cmd())
+	return m
+}
+
+func TestColumnOf_PlacesByLabelThenBoardColumnThenState(t *testing.T) {
+	board := provider.Board{Columns: []provider.BoardColumn{
+		{Name: "Open", States: map[string]string{"": "open"}},
+		{Name: "review", States: map[string]string{"": "open"}, Label: "status:review"},
+		{Name: "Closed", States: map[string]string{"": "closed"}},
+	}}
+	labeled := newWI(1, "Labeled", "open", "Issue")
+	labeled.Tags = "ui; status:review"
+	closed := newWI(2, "Closed", "closed", "Issue")
+	closed.Tags = "status:review"
+	stories := storyBoard()
+	inReview := newWI(4, "In review", "Active", "User Story")
+	inReview.BoardColumn = "Review"
+	movedBack := newWI(5, "Reopened", "New", "User Story")
+	movedBack.BoardColumn = "Review"
+
+	tests := []struct {
+		board provider.Board
+		wi    provider.WorkItem
+		want  int
+	}{
+		{board, newWI(3, "Plain", "open", "Issue"), 0},
+		{board, labeled, 1},
+		{board, closed, 2},
+		{stories, inReview, 2},
+		{stories, movedBack, 0},
+		{stories, newWI(6, "Epic", "New", "Epic"), -1},
+	}
+	for _, tt := range tests {
+		if got := columnOf(tt.board, tt.wi); got != tt.want {
+			t.Errorf("columnOf(#%s) = %d, want %d", tt.wi.Identity.ID, got, tt.want)
+		}
+	}
+}
+
+func TestBoard_ShowsColumnsWithWIPCounts(t *testing.T) {
+	p := &boardProvider{boards: []provider.Board{storyBoard()}}
+	story := newWI(1, "Write docs", "Active", "User Story")
+	story.AssignedToName = "Ada"
+	story.StoryPoints = 3
+	m := newBoardModel(t, p, story, newWI(2, "Crash", "Active", "Bug"), newWI(3, "Idea", "New", "User Story"))
+
+	view := m.View()
+	for _, want := range []string{"Stories board", "New 1", "Doing 2/1", "Review 0", "#1 Write docs", "Ada · 3 pts", "Unassigned"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view should contain %q:\n%s", want, view)
+		}
+	}
+}
+
+func TestBoard_MovesCardToNextColumnOfItsType(t *testing.T) {
+	p := &boardProvider{boards: []provider.Board{storyBoard()}}
+	m := newBoardModel(t, p, newWI(1, "Crash", "Active", "Bug"))
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	m, cmd := m.Update(keyRunes(">"))
+	if cmd == nil {
+		t.Fatal("> should move the selected card")
+	}
+	msg := cmd()
+	m, cmd = m.Update(msg)
+	if !reflect.DeepEqual(p.moves, []string{"Bug Done"}) {
+		t.Errorf("moves = %v, want the bug moved past Review, which has no state for bugs", p.moves)
+	}
+	if cmd == nil {
+		t.Error("a move should refresh the items")
+	}
+	if got := m.GetStatusMessage(); got != "Moved #1 to Done" {
+		t.Errorf("status = %q", got)
+	}
+	m, _ = m.Update(workItemsMsg{workItems: []provider.WorkItem{newWI(1, "Crash", "Closed", "Bug")}})
+	if wi, ok := m.board.selected(boardCards(*m.board.board(), m.boardItems())); !ok || wi.State != "Closed" {
+		t.Errorf("the moved card should stay selected, got %+v", wi)
+	}
+
+	m, cmd = m.Update(keyRunes(">"))
+	if cmd != nil || !strings.Contains(m.GetStatusMessage(), "cannot move further") {
+		t.Errorf("moving past the last column: status = %q", m.GetStatusMessage())
+	}
+}
+
+func TestBoard_DerivesStateBoardWithoutBoards(t *testing.T) {
+	p := &boardProvider{}
+	m := newBoardModel(t, p, newWI(1, "Story", "Active", "User Story"), newWI(2, "Bug", "Closed", "Bug"))
+
+	board := m.board.board()
+	if board == nil {
+		t.Fatal("a board should be derived from the states")
+	}
+	var names []string
+	for _, col := range board.Columns {
+		names = append(names, col.Name)
+	}
+	if !reflect.DeepEqual(names, []string{"New", "Active", "Closed"}) {
+		t.Errorf("columns = %v, want the states ordered by category", names)
+	}
+	if _, ok := board.Columns[1].StateFor("Bug"); ok {
+		t.Error("bugs have no Active state")
+	}
+}
+
+func TestBoard_EscAndArrowsStayInBoard(t *testing.T) {
+	p := &boardProvider{boards: []provider.Board{storyBoard()}}
+	m := newBoardModel(t, p, newWI(1, "Story", "New", "User Story"))
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	if m.board.col != 1 {
+		t.Errorf("right should select the next column, got %d", m.board.col)
+	}
+	m, _ = m.Update(keyRunes("f"))
+	if m.IsSearching() {
+		t.Error("search is not available on the board")
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.IsBoardMode() {
+		t.Error("esc should return to the list")
+	}
+}
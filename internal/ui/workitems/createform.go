@@ -0,0 +1,379 @@
+package workitems
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Elpulgo/azdo/internal/provider"
+	"github.com/Elpulgo/azdo/internal/ui/styles"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// createPriorityChoices name the priority options, indexed by priority; 0
+// leaves the priority to the backend's default.
+var createPriorityChoices = []string{"None", "1", "2", "3", "4"}
+
+// Rows of the create form: three choice rows followed by the text inputs.
+const (
+	createRowProject = iota
+	createRowType
+	createRowPriority
+	createRowTitle
+	createRowDescription
+	createRowAssignee
+	createRowIteration
+	createRowArea
+	createRowTags
+	createRowParent
+	createRowCount
+)
+
+// createInputRows is the number of text input rows.
+const createInputRows = createRowCount - createRowTitle
+
+var createRowNames = [createRowCount]string{
+	"Project", "Type", "Priority", "Title", "Description", "Assignee",
+	"Iteration", "Area path", "Tags", "Parent ID",
+}
+
+// workItemTypesMsg carries the work item types of a scope.
+type workItemTypesMsg struct {
+	scope string
+	types []string
+	err   error
+}
+
+// workItemCreatedMsg is emitted when a create request finishes.
+type workItemCreatedMsg struct {
+	item *provider.WorkItem
+	err  error
+}
+
+// createForm is the modal creating a work item. ↑/↓ and tab move between
+// rows, ←/→ change the choice rows, enter creates the item and esc cancels.
+// The types offered are fetched per project as the project changes. On
+// failure the form stays open with the error so nothing typed is lost.
+type createForm struct {
+	styles     *styles.Styles
+	client     provider.Provider
+	visible    bool
+	width      int
+	height     int
+	cursor     int
+	scopes     []string
+	scope      int                 // index into scopes
+	types      map[string][]string // fetched types per scope
+	typeIndex  int
+	priority   int // index into createPriorityChoices
+	inputs     [createInputRows]textinput.Model
+	err        string
+	submitting bool
+}
+
+func newCreateForm(client provider.Provider, s *styles.Styles) createForm {
+	f := createForm{styles: s, client: client, types: map[string][]string{}}
+	placeholders := [createInputRows]string{
+		"required", "", "email or login", "e.g. Project\\Sprint 1 or a milestone",
+		"e.g. Project\\Team", "comma-separated", "work item ID",
+	}
+	for i := range f.inputs {
+		ti := textinput.New()
+		ti.Prompt = ""
+		ti.Placeholder = placeholders[i]
+		ti.CharLimit = 200
+		f.inputs[i] = ti
+	}
+	f.inputs[createRowDescription-createRowTitle].CharLimit = 4000
+	return f
+}
+
+// Show opens an empty form and returns the command fetching the types of
+// the first project, if they are not known yet.
+func (f *createForm) Show() tea.Cmd {
+	if f.client != nil {
+		f.scopes = f.client.Scopes()
+	}
+	if f.scope >= len(f.scopes) {
+		f.scope = 0
+	}
+	for i := range f.inputs {
+		f.inputs[i].SetValue("")
+	}
+	f.typeIndex = 0
+	f.priority = 0
+	f.err = ""
+	f.submitting = false
+	f.cursor = createRowTitle
+	f.visible = true
+	f.focusCursor()
+	return f.fetchTypes()
+}
+
+// Hide closes the form.
+func (f *createForm) Hide() {
+	f.visible = false
+	for i := range f.inputs {
+		f.inputs[i].Blur()
+	}
+}
+
+// IsVisible returns whether the form is open.
+func (f createForm) IsVisible() bool {
+	return f.visible
+}
+
+// SetSize sets the area the form is centered in.
+func (f *createForm) SetSize(width, height int) {
+	f.width = width
+	f.height = height
+}
+
+// currentScope returns the selected project, or "" without any.
+func (f createForm) currentScope() string {
+	if f.scope < len(f.scopes) {
+		return f.scopes[f.scope]
+	}
+	return ""
+}
+
+// currentTypes returns the types of the selected project; nil until they
+// are fetched.
+func (f createForm) currentTypes() []string {
+	return f.types[f.currentScope()]
+}
+
+// fetchTypes returns a command fetching the types of the selected project,
+// or nil when they are already known.
+func (f createForm) fetchTypes() tea.Cmd {
+	scope := f.currentScope()
+	if f.client == nil || scope == "" || f.types[scope] != nil {
+		return nil
+	}
+	client := f.client
+	return func() tea.Msg {
+		types, err := client.GetWorkItemTypes(scope)
+		return workItemTypesMsg{scope: scope, types: types, err: err}
+	}
+}
+
+// handleTypes records the fetched types of a project.
+func (f *createForm) handleTypes(msg workItemTypesMsg) {
+	if msg.err != nil {
+		f.err = fmt.Sprintf("Error loading work item types: %v", msg.err)
+		return
+	}
+	if msg.types == nil {
+		msg.types = []string{}
+	}
+	f.types[msg.scope] = msg.types
+}
+
+// handleCreated closes the form after a successful create, or shows the
+// error and leaves it open.
+func (f *createForm) handleCreated(msg workItemCreatedMsg) {
+	f.submitting = false
+	if msg.err != nil {
+		f.err = fmt.Sprintf("Error creating work item: %v", msg.err)
+		return
+	}
+	f.Hide()
+}
+
+// focusCursor focuses the input under the cursor, if any.
+func (f *createForm) focusCursor() {
+	for i := range f.inputs {
+		if createRowTitle+i == f.cursor {
+			f.inputs[i].Focus()
+		} else {
+			f.inputs[i].Blur()
+		}
+	}
+}
+
+// cycle moves the choice row under the cursor by delta, wrapping around.
+// Changing the project resets the type and returns the command fetching
+// the new project's types.
+func (f *createForm) cycle(delta int) tea.Cmd {
+	wrap := func(v, n int) int {
+		if n == 0 {
+			return 0
+		}
+		return ((v+delta)%n + n) % n
+	}
+	switch f.cursor {
+	case createRowProject:
+		f.scope = wrap(f.scope, len(f.scopes))
+		f.typeIndex = 0
+		return f.fetchTypes()
+	case createRowType:
+		f.typeIndex = wrap(f.typeIndex, len(f.currentTypes()))
+	case createRowPriority:
+		f.priority = wrap(f.priority, len(createPriorityChoices))
+	}
+	return nil
+}
+
+// value returns the trimmed text of an input row.
+func (f createForm) value(row int) string {
+	return strings.TrimSpace(f.inputs[row-createRowTitle].Value())
+}
+
+// newWorkItem validates the form and returns the work item to create.
+func (f createForm) newWorkItem() (provider.NewWorkItem, error) {
+	types := f.currentTypes()
+	if f.typeIndex >= len(types) {
+		return provider.NewWorkItem{}, fmt.Errorf("work item types are not loaded yet")
+	}
+	item := provider.NewWorkItem{
+		Type:          types[f.typeIndex],
+		Title:         f.value(createRowTitle),
+		Description:   f.value(createRowDescription),
+		AssignedTo:    f.value(createRowAssignee),
+		Priority:      f.priority,
+		IterationPath: f.value(createRowIteration),
+		AreaPath:      f.value(createRowArea),
+	}
+	if item.Title == "" {
+		return provider.NewWorkItem{}, fmt.Errorf("a title is required")
+	}
+	for _, tag := range strings.Split(f.value(createRowTags), ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			item.Tags = append(item.Tags, tag)
+		}
+	}
+	if parent := strings.TrimPrefix(f.value(createRowParent), "#"); parent != "" {
+		id, err := strconv.Atoi(parent)
+		if err != nil || id <= 0 {
+			return provider.NewWorkItem{}, fmt.Errorf("parent ID must be a work item number")
+		}
+		item.ParentID = id
+	}
+	return item, nil
+}
+
+// submit validates the form and returns the command creating the item.
+func (f *createForm) submit() tea.Cmd {
+	if f.submitting || f.client == nil {
+		return nil
+	}
+	item, err := f.newWorkItem()
+	if err != nil {
+		f.err = err.Error()
+		return nil
+	}
+	f.err = ""
+	f.submitting = true
+	client, scope := f.client, f.currentScope()
+	return func() tea.Msg {
+		created, err := client.CreateWorkItem(scope, item)
+		return workItemCreatedMsg{item: created, err: err}
+	}
+}
+
+// Update handles the form's keys.
+func (f createForm) Update(msg tea.KeyMsg) (createForm, tea.Cmd) {
+	if !f.visible {
+		return f, nil
+	}
+	switch msg.String() {
+	case "esc":
+		f.Hide()
+		return f, nil
+	case "enter":
+		return f, f.submit()
+	case "up", "shift+tab":
+		f.cursor = (f.cursor + createRowCount - 1) % createRowCount
+		f.focusCursor()
+		return f, nil
+	case "down", "tab":
+		f.cursor = (f.cursor + 1) % createRowCount
+		f.focusCursor()
+		return f, nil
+	}
+
+	if f.cursor < createRowTitle {
+		switch msg.String() {
+		case "left", "h":
+			return f, f.cycle(-1)
+		case "right", "l", " ":
+			return f, f.cycle(1)
+		}
+		return f, nil
+	}
+	var cmd tea.Cmd
+	i := f.cursor - createRowTitle
+	f.inputs[i], cmd = f.inputs[i].Update(msg)
+	return f, cmd
+}
+
+// View renders the form centered in its area.
+func (f createForm) View() string {
+	if !f.visible {
+		return ""
+	}
+	const labelWidth = 13
+	const width = 64
+
+	typeName := "loading..."
+	if types := f.currentTypes(); types != nil {
+		typeName = "none available"
+		if f.typeIndex < len(types) {
+			typeName = types[f.typeIndex]
+		}
+	}
+	choices := [createRowTitle]string{
+		f.currentScope(),
+		typeName,
+		createPriorityChoices[f.priority],
+	}
+	var rows []string
+	for row := 0; row < createRowCount; row++ {
+		cursor := "  "
+		if row == f.cursor {
+			cursor = "> "
+		}
+		var value string
+		if row < createRowTitle {
+			value = "‹ " + choices[row] + " ›"
+		} else {
+			value = f.inputs[row-createRowTitle].View()
+		}
+		label := fmt.Sprintf("%-*s", labelWidth, createRowNames[row])
+		style := lipgloss.NewStyle().Width(width).Foreground(f.styles.Theme.GetForeground())
+		if row == f.cursor {
+			style = style.Foreground(f.styles.Theme.GetSelectForeground()).Background(f.styles.Theme.GetSelectBackground())
+		}
+		rows = append(rows, style.Render(cursor+label+value))
+	}
+
+	title := lipgloss.NewStyle().
+		Foreground(f.styles.Theme.GetPrimary()).
+		Bold(true).
+		Render("New work item")
+	status := ""
+	switch {
+	case f.submitting:
+		status = f.styles.Muted.Render("Creating...")
+	case f.err != "":
+		status = f.styles.Error.Render(f.err)
+	}
+	help := lipgloss.NewStyle().
+		Foreground(f.styles.Theme.GetForegroundMuted()).
+		Render("↑/↓/tab: move • ←/→: change • enter: create • esc: cancel")
+
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		title, "", strings.Join(rows, "\n"), "", status, help)
+	modal := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(f.styles.Theme.GetBorder()).
+		Padding(1, 2).
+		Render(content)
+
+	if f.width > 0 && f.height > 0 {
+		modal = lipgloss.Place(f.width, f.height, lipgloss.Center, lipgloss.Center, modal)
+	}
+	return modal
+}
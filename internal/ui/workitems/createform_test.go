@@ -0,0 +1,159 @@
+package workitems
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Elpulgo/azdo/internal/provider"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// createProvider serves work item types per scope and records created work
+// items; every other method panics via the nil embedded interface.
+type createProvider struct {
+	provider.Provider
+	createErr error
+	created   []provider.NewWorkItem
+	scopes    []string
+}
+
+func (p *createProvider) IsMultiProject() bool { return len(p.scopes) > 1 }
+
+func (p *createProvider) Scopes() []string { return p.scopes }
+
+func (p *createProvider) GetWorkItemTypes(scope string) ([]string, error) {
+	return []string{"Bug", scope + " Story"}, nil
+}
+
+func (p *createProvider) CreateWorkItem(scope string, item provider.NewWorkItem) (*provider.WorkItem, error) {
+	if p.createErr != nil {
+		return nil, p.createErr
+	}
+	p.created = append(p.created, item)
+	return &provider.WorkItem{
+		Identity:     provider.Identity{ID: "101", Scope: scope},
+		WorkItemType: item.Type,
+		Title:        item.Title,
+	}, nil
+}
+
+// runCmd runs cmd and feeds its message back to m.
+func runCmd(t *testing.T, m Model, cmd tea.Cmd) Model {
+	t.Helper()
+	if cmd == nil {
+		t.Fatal("expected a command")
+	}
+	m, _ = m.Update(cmd())
+	return m
+}
+
+func typeText(m Model, s string) Model {
+	for _, r := range s {
+		m, _ = m.Update(keyRunes(string(r)))
+	}
+	return m
+}
+
+func TestCreateForm_CreatesWorkItem(t *testing.T) {
+	client := &createProvider{scopes: []string{"alpha", "beta"}}
+	m := NewModel(client)
+
+	m, cmd := m.Update(keyRunes("n"))
+	if !m.IsCreateFormVisible() {
+		t.Fatal("n should open the create form")
+	}
+	m = runCmd(t, m, cmd)
+
+	// Switch to the second project, which fetches its own types, and pick
+	// its second type.
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyUp})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyUp})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyUp})
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	m = runCmd(t, m, cmd)
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	if view := m.CreateFormView(); !strings.Contains(view, "beta Story") {
+		t.Fatalf("form should offer the second project's types:\n%s", view)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m = typeText(m, "Crash on save")
+	for range createRowTags - createRowTitle {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	}
+	m = typeText(m, "ui, crash")
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m = typeText(m, "#12")
+
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = runCmd(t, m, cmd)
+
+	if len(client.created) != 1 {
+		t.Fatalf("created %d work items, want 1", len(client.created))
+	}
+	got := client.created[0]
+	if got.Type != "beta Story" || got.Title != "Crash on save" || got.Priority != 2 ||
+		got.ParentID != 12 || strings.Join(got.Tags, "|") != "ui|crash" {
+		t.Errorf("created %+v", got)
+	}
+	if m.IsCreateFormVisible() {
+		t.Error("form should close after a successful create")
+	}
+	if msg := m.GetStatusMessage(); msg != "Created beta Story #101" {
+		t.Errorf("status message = %q", msg)
+	}
+}
+
+func TestCreateForm_RequiresTitleAndNumericParent(t *testing.T) {
+	client := &createProvider{scopes: []string{"alpha"}}
+	m := NewModel(client)
+	m, cmd := m.Update(keyRunes("n"))
+	m = runCmd(t, m, cmd)
+
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd != nil || !strings.Contains(m.CreateFormView(), "a title is required") {
+		t.Errorf("enter without a title should be refused:\n%s", m.CreateFormView())
+	}
+
+	m = typeText(m, "T")
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyShiftTab})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyShiftTab})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyShiftTab})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyShiftTab})
+	m = typeText(m, "abc")
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd != nil || !strings.Contains(m.CreateFormView(), "parent ID must be a work item number") {
+		t.Errorf("a non-numeric parent should be refused:\n%s", m.CreateFormView())
+	}
+	if len(client.created) != 0 {
+		t.Errorf("nothing should be created, got %+v", client.created)
+	}
+}
+
+func TestCreateForm_ErrorKeepsFormOpen(t *testing.T) {
+	client := &createProvider{scopes: []string{"alpha"}, createErr: errors.New("field rejected")}
+	m := NewModel(client)
+	m, cmd := m.Update(keyRunes("n"))
+	m = runCmd(t, m, cmd)
+	m = typeText(m, "Title")
+
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = runCmd(t, m, cmd)
+
+	if !m.IsCreateFormVisible() {
+		t.Fatal("form should stay open after a failed create")
+	}
+	if view := m.CreateFormView(); !strings.Contains(view, "field rejected") || !strings.Contains(view, "Title") {
+		t.Errorf("form should show the error and keep the input:\n%s", view)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.IsCreateFormVisible() {
+		t.Error("esc should close the form")
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/Elpulgo/azdo/internal/provider"
 	"github.com/Elpulgo/azdo/internal/ui/components"
 	"github.com/Elpulgo/azdo/internal/ui/display"
+	"github.com/Elpulgo/azdo/internal/ui/markdown"
 	"github.com/Elpulgo/azdo/internal/ui/styles"
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/viewport"
@@ -44,8 +45,9 @@ type commentsLoadedMsg struct {
 
 // commentPostedMsg is sent when a new comment has been posted
 type commentPostedMsg struct {
-	comment *provider.WorkItemComment
-	err     error
+	comment  *provider.WorkItemComment
+	err      error
+	markdown bool // written in the external editor
 }
 
 // DetailModel represents the work item detail view
@@ -57,6 +59,7 @@ type DetailModel struct {
 	viewport      viewport.Model
 	ready         bool
 	styles        *styles.Styles
+	markdown      *markdown.Renderer
 	statePicker   components.StatePicker
 	loading       bool
 	spinner       *components.LoadingIndicator
@@ -68,6 +71,40 @@ type DetailModel struct {
 	commentForm     components.CommentForm
 	posting         bool   // a comment POST is in flight
 	pendingComment  string // draft text retained across an in-flight post
+
+	// Discussion actions: selectedComment indexes comments (-1 until n/N
+	// picks one) and commentOffsets holds each comment's first viewport line.
+	// editCommentID is the comment being rewritten in commentForm, as
+	// markdown converted from HTML when editCommentHTML is set, and
+	// pendingDeleteID the one awaiting y/n.
+	selectedComment int
+	commentOffsets  []int
+	editCommentID   int
+	editCommentHTML bool
+	pendingDeleteID int
+	reactionPicker  components.ReactionPicker
+
+	editForm editForm
+
+	links    []provider.WorkItemLink
+	linksErr error
+	linkForm linkForm
+
+	history historyView
+
+	attachments    []provider.WorkItemAttachment
+	attachmentsErr error
+	attachmentForm attachmentForm
+
+	// External editor: descriptionBase is the description an edit started
+	// from and editorOriginal the text it opened with. A description or
+	// comment that could not be saved is kept as a draft to reopen;
+	// descriptionConflict marks a draft to merge with a newer version.
+	descriptionBase     string
+	editorOriginal      string
+	descriptionDraft    string
+	descriptionConflict bool
+	commentDraft        string
 }
 
 // NewDetailModel creates a new work item detail model with default styles
@@ -78,24 +115,41 @@ func NewDetailModel(client provider.Provider, wi provider.WorkItem) *DetailModel
 // NewDetailModelWithStyles creates a new work item detail model with custom styles
 func NewDetailModelWithStyles(client provider.Provider, wi provider.WorkItem, s *styles.Styles) *DetailModel {
 	spinner := components.NewLoadingIndicator(s)
-	return &DetailModel{
+	m := &DetailModel{
 		client:      client,
 		workItem:    wi,
 		styles:      s,
+		markdown:    markdown.New(s),
 		statePicker: components.NewStatePicker(s),
 		spinner:     spinner,
 		commentForm: components.NewCommentForm(s),
-	}
+
+		selectedComment: -1,
+		reactionPicker:  components.NewReactionPicker(s),
+		editForm:        newEditForm(client, s),
+		linkForm:        newLinkForm(client, s),
+		history:         newHistoryView(client, s),
+		attachmentForm:  newAttachmentForm(client, s),
+	}
+	if client != nil {
+		// Work item comments are HTML on Azure DevOps, so mentions are
+		// inserted as mention links there.
+		m.commentForm.EnableMentions(func(query string) ([]provider.Person, error) {
+			return client.SearchPeople(wi.Identity.Scope, query)
+		}, true)
+	}
+	return m
 }
 
-// Init initializes the detail model, kicking off the comment fetch so the
-// Discussion section is populated as soon as the detail view opens.
+// Init initializes the detail model, kicking off the comment, link and
+// attachment fetches so their sections are populated as soon as the detail
+// view opens.
 func (m *DetailModel) Init() tea.Cmd {
 	m.commentsLoading = true
 	if m.ready {
 		m.updateViewportContent()
 	}
-	return m.fetchComments()
+	return tea.Batch(m.fetchComments(), m.fetchLinks(), m.fetchAttachments())
 }
 
 // Update handles messages for the detail view
@@ -112,14 +166,50 @@ func (m *DetailModel) Update(msg tea.Msg) (*DetailModel, tea.Cmd) {
 	// CommentFormCancelledMsg fall through to the handlers below instead of
 	// being re-captured here.
 	if m.commentForm.IsVisible() {
+		height := m.commentForm.Height()
 		var cmd tea.Cmd
 		m.commentForm, cmd = m.commentForm.Update(msg)
+		if m.commentForm.Height() != height {
+			// The mention popup opened or closed.
+			m.resizeViewport()
+		}
+		return m, cmd
+	}
+	if m.reactionPicker.IsVisible() {
+		var cmd tea.Cmd
+		m.reactionPicker, cmd = m.reactionPicker.Update(msg)
+		return m, cmd
+	}
+	if key, ok := msg.(tea.KeyMsg); ok && m.editForm.IsVisible() {
+		var cmd tea.Cmd
+		m.editForm, cmd = m.editForm.Update(key)
 		return m, cmd
 	}
+	if key, ok := msg.(tea.KeyMsg); ok && m.linkForm.IsVisible() {
+		var cmd tea.Cmd
+		m.linkForm, cmd = m.linkForm.Update(key)
+		return m, cmd
+	}
+	if key, ok := msg.(tea.KeyMsg); ok && m.attachmentForm.IsVisible() {
+		var cmd tea.Cmd
+		m.attachmentForm, cmd = m.attachmentForm.Update(key)
+		return m, cmd
+	}
+	if key, ok := msg.(tea.KeyMsg); ok && m.history.IsVisible() {
+		var cmd tea.Cmd
+		m.history, cmd = m.history.Update(key)
+		return m, cmd
+	}
+	if key, ok := msg.(tea.KeyMsg); ok && m.pendingDeleteID > 0 {
+		return m.updateDeletePrompt(key)
+	}
 
 	switch msg := msg.(type) {
 
 	case components.CommentSubmittedMsg:
+		if m.editCommentID > 0 {
+			return m.handleEditSubmitted(msg.Text)
+		}
 		m.pendingComment = msg.Text
 		m.posting = true
 		m.spinner.SetVisible(true)
@@ -130,19 +220,102 @@ func (m *DetailModel) Update(msg tea.Msg) (*DetailModel, tea.Cmd) {
 
 	case components.CommentFormCancelledMsg:
 		m.pendingComment = ""
+		m.editCommentID = 0
 		m.resizeViewport()
 		return m, nil
 
+	case components.ReactionSelectedMsg:
+		if c, ok := m.currentComment(); ok {
+			return m, m.reactToComment(c.ID, msg.Reaction, msg.Remove)
+		}
+		return m, nil
+
+	case commentActionMsg:
+		return m.handleCommentAction(msg)
+
+	case fieldOptionsMsg:
+		m.editForm.handleOptions(msg)
+		return m, nil
+
+	case workItemUpdatedMsg:
+		cmd := m.editForm.handleUpdated(msg)
+		if msg.err != nil {
+			return m, cmd
+		}
+		m.workItem = *msg.item
+		m.statusMessage = "Work item updated"
+		m.updateViewportContent()
+		return m, func() tea.Msg { return WorkItemUpdatedMsg{} }
+
+	case workItemReloadedMsg:
+		m.editForm.handleReloaded(msg)
+		if msg.err == nil {
+			m.workItem = *msg.item
+			m.updateViewportContent()
+		}
+		return m, nil
+
+	case linksLoadedMsg:
+		m.linksErr = msg.err
+		if msg.err == nil {
+			m.links = msg.links
+			m.linkForm.setLinks(msg.links)
+		}
+		m.updateViewportContent()
+		return m, nil
+
+	case linkChangedMsg:
+		m.linkForm.handleChanged(msg)
+		if msg.err != nil {
+			return m, nil
+		}
+		m.statusMessage = msg.message
+		// The list refetches so the tree shows the new hierarchy.
+		return m, tea.Batch(m.fetchLinks(), func() tea.Msg { return WorkItemUpdatedMsg{} })
+
+	case attachmentsLoadedMsg:
+		m.attachmentsErr = msg.err
+		if msg.err == nil {
+			m.attachments = msg.attachments
+			m.attachmentForm.setAttachments(msg.attachments)
+		}
+		m.updateViewportContent()
+		return m, nil
+
+	case attachmentDoneMsg:
+		m.attachmentForm.handleDone(msg)
+		if msg.err != nil || !msg.uploaded {
+			return m, nil
+		}
+		m.statusMessage = msg.message
+		return m, m.fetchAttachments()
+
+	case historyLoadedMsg:
+		m.history.handleLoaded(msg)
+		return m, nil
+
 	case commentsLoadedMsg:
 		m.commentsLoading = false
 		m.commentsErr = msg.err
 		m.comments = msg.comments
+		m.selectedComment = min(m.selectedComment, len(m.comments)-1)
 		m.updateViewportContent()
 		return m, nil
 
+	case editorDoneMsg:
+		return m.handleEditorDone(msg)
+
+	case descriptionSavedMsg:
+		return m.handleDescriptionSaved(msg)
+
 	case commentPostedMsg:
 		m.posting = false
 		m.spinner.SetVisible(false)
+		if msg.err != nil && msg.markdown {
+			m.pendingComment = ""
+			m.statusMessage = fmt.Sprintf("Error posting comment: %v — press C to reopen your draft", msg.err)
+			return m, nil
+		}
 		if msg.err != nil {
 			m.statusMessage = fmt.Sprintf("Error posting comment: %v", msg.err)
 			// Restore the draft so the user doesn't lose their text.
@@ -154,6 +327,9 @@ func (m *DetailModel) Update(msg tea.Msg) (*DetailModel, tea.Cmd) {
 			return m, m.commentForm.Focus()
 		}
 		m.pendingComment = ""
+		if msg.markdown {
+			m.commentDraft = ""
+		}
 		m.statusMessage = "Comment added"
 		// Re-fetch so the new comment appears in the correct (newest-first) position.
 		m.commentsLoading = true
@@ -218,6 +394,18 @@ func (m *DetailModel) Update(msg tea.Msg) (*DetailModel, tea.Cmd) {
 			return m, tea.Batch(m.fetchStates(), m.spinner.Tick())
 		case "o":
 			return m, m.openInBrowser()
+		case "E":
+			m.editForm.SetSize(m.width, m.height)
+			return m, m.editForm.Show(m.workItem)
+		case "L":
+			m.linkForm.SetSize(m.width, m.height)
+			return m, m.linkForm.Show(m.workItem, m.links)
+		case "A":
+			m.attachmentForm.SetSize(m.width, m.height)
+			return m, m.attachmentForm.Show(m.workItem, m.attachments)
+		case "H":
+			m.history.SetSize(m.width, m.height)
+			return m, m.history.Show(m.workItem)
 		case "c":
 			// Don't allow opening a new form while a post is in flight.
 			if m.posting {
@@ -228,6 +416,22 @@ func (m *DetailModel) Update(msg tea.Msg) (*DetailModel, tea.Cmd) {
 			m.commentForm.Show()
 			m.resizeViewport()
 			return m, m.commentForm.Focus()
+		case "C":
+			return m, m.editCommentInEditor()
+		case "d":
+			return m, m.editDescription()
+		case "n":
+			m.selectComment(1)
+		case "N":
+			m.selectComment(-1)
+		case "e":
+			return m, m.startEditComment()
+		case "D":
+			m.promptDeleteComment()
+		case "+":
+			return m, m.toggleLike()
+		case "R":
+			m.openReactionPicker()
 		case "up", "k":
 			m.viewport.LineUp(1)
 		case "down", "j":
@@ -323,6 +527,21 @@ func (m *DetailModel) View() string {
 	if m.statePicker.IsVisible() {
 		return m.statePicker.View()
 	}
+	if m.reactionPicker.IsVisible() {
+		return m.reactionPicker.View()
+	}
+	if m.editForm.IsVisible() {
+		return m.editForm.View()
+	}
+	if m.linkForm.IsVisible() {
+		return m.linkForm.View()
+	}
+	if m.history.IsVisible() {
+		return m.history.View()
+	}
+	if m.attachmentForm.IsVisible() {
+		return m.attachmentForm.View()
+	}
 
 	var sb strings.Builder
 
@@ -361,6 +580,10 @@ func (m *DetailModel) View() string {
 		sb.WriteString("\n")
 		sb.WriteString(m.commentForm.View())
 	}
+	if m.pendingDeleteID > 0 {
+		sb.WriteString("\n")
+		sb.WriteString(m.styles.Warning.Render("Delete this comment? (y/n)"))
+	}
 
 	contentStyle := lipgloss.NewStyle().
 		Width(m.width)
@@ -405,30 +628,40 @@ func (m *DetailModel) updateViewportContent() {
 		sb.WriteString("\n\n")
 	}
 
+	// Parent, children and related work items
+	m.writeLinks(&sb)
+	m.writeAttachments(&sb)
+
 	// Link to work item (shown before description for quick access)
 	if m.client != nil {
 		url := m.client.WorkItemURL(wi.Identity.Scope, workItemNumericID(wi))
 		if url != "" {
-			sb.WriteString(hyperlink(m.styles.Link.Render("Open in browser"), url))
+			sb.WriteString(markdown.Hyperlink(m.styles.Link.Render("Open in browser"), url))
 			sb.WriteString("\n\n")
 		}
 	}
 
-	// Description (with HTML stripped)
+	// Description
 	// Bugs use ReproSteps field; other types use Description
 	effectiveDesc := wiEffectiveDescription(wi)
 	if effectiveDesc != "" {
 		sb.WriteString(m.styles.Label.Render("Description"))
 		sb.WriteString("\n")
-		cleanDesc := stripHTMLTags(effectiveDesc)
-		descStyle := m.styles.Value.Width(m.width)
-		sb.WriteString(descStyle.Render(cleanDesc))
+		sb.WriteString(m.renderText(effectiveDesc))
 		sb.WriteString("\n")
 	} else {
 		sb.WriteString(m.styles.Muted.Render("No description"))
 		sb.WriteString("\n")
 	}
 
+	if wi.AcceptanceCriteria != "" {
+		sb.WriteString("\n")
+		sb.WriteString(m.styles.Label.Render("Acceptance Criteria"))
+		sb.WriteString("\n")
+		sb.WriteString(m.renderText(wi.AcceptanceCriteria))
+		sb.WriteString("\n")
+	}
+
 	// Discussion (comments), newest first
 	m.writeDiscussion(&sb)
 
@@ -457,8 +690,8 @@ func (m *DetailModel) writeDiscussion(sb *strings.Builder) {
 	}
 
 	metaStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(m.styles.Theme.Secondary))
-	bodyStyle := m.styles.Value.Width(m.width)
-	for _, c := range m.comments {
+	m.commentOffsets = m.commentOffsets[:0]
+	for i, c := range m.comments {
 		author := c.AuthorName
 		if author == "" {
 			author = "Unknown"
@@ -467,10 +700,24 @@ func (m *DetailModel) writeDiscussion(sb *strings.Builder) {
 		if !c.CreatedDate.IsZero() {
 			header = fmt.Sprintf("%s  ·  %s", author, c.CreatedDate.Format("2006-01-02 15:04"))
 		}
-		sb.WriteString(metaStyle.Render(header))
+		m.commentOffsets = append(m.commentOffsets, strings.Count(sb.String(), "\n"))
+		if i == m.selectedComment {
+			sb.WriteString(m.styles.Selected.Render("▸ " + header))
+		} else {
+			sb.WriteString(metaStyle.Render(header))
+		}
+		sb.WriteString("\n")
+		if c.Markdown {
+			sb.WriteString(m.markdown.Render(c.Text, m.width))
+		} else {
+			sb.WriteString(m.renderText(c.Text))
+		}
+		sb.WriteString("\n")
+		if reactions := components.FormatReactions(c.Reactions); reactions != "" {
+			sb.WriteString(metaStyle.Render(reactions))
+			sb.WriteString("\n")
+		}
 		sb.WriteString("\n")
-		sb.WriteString(bodyStyle.Render(stripHTMLTags(c.Text)))
-		sb.WriteString("\n\n")
 	}
 }
 
@@ -479,6 +726,11 @@ func (m *DetailModel) SetSize(width, height int) {
 	m.width = width
 	m.height = height
 	m.commentForm.SetWidth(width)
+	m.reactionPicker.SetSize(width, height)
+	m.editForm.SetSize(width, height)
+	m.linkForm.SetSize(width, height)
+	m.history.SetSize(width, height)
+	m.attachmentForm.SetSize(width, height)
 
 	if !m.ready {
 		m.viewport = viewport.New(width, 1)
@@ -491,12 +743,15 @@ func (m *DetailModel) SetSize(width, height int) {
 
 // reservedLines returns the number of non-viewport rows the detail view renders:
 // the fixed header (title + type/state + separator = 3), plus the inline comment
-// form (a blank spacer + the form itself) when it is open.
+// form (a blank spacer + the form itself) or the delete confirmation when open.
 func (m *DetailModel) reservedLines() int {
 	lines := 3
 	if m.commentForm.IsVisible() {
 		lines += 1 + m.commentForm.Height()
 	}
+	if m.pendingDeleteID > 0 {
+		lines++
+	}
 	return lines
 }
 
@@ -517,13 +772,30 @@ func (m *DetailModel) resizeViewport() {
 
 // GetContextItems returns context items for the detail view
 func (m *DetailModel) GetContextItems() []components.ContextItem {
-	return []components.ContextItem{
+	if m.pendingDeleteID > 0 {
+		return []components.ContextItem{
+			{Key: "y", Description: "delete"},
+			{Key: "n", Description: "cancel"},
+		}
+	}
+	items := []components.ContextItem{
 		{Key: "w", Description: "Change state"},
+		{Key: "E", Description: "edit fields"},
+		{Key: "L", Description: "links"},
+		{Key: "A", Description: "attachments"},
+		{Key: "H", Description: "history"},
+		{Key: "d", Description: "edit description"},
 		{Key: "c", Description: "comment"},
-		{Key: "o", Description: "open in browser"},
-		{Key: "↑↓", Description: "scroll"},
-		{Key: "esc", Description: "back"},
+		{Key: "C", Description: "comment in editor"},
 	}
+	if len(m.comments) > 0 {
+		items = append(items, discussionContextItems(m.selectedComment >= 0)...)
+	}
+	return append(items,
+		components.ContextItem{Key: "o", Description: "open in browser"},
+		components.ContextItem{Key: "↑↓", Description: "scroll"},
+		components.ContextItem{Key: "esc", Description: "back"},
+	)
 }
 
 // GetScrollPercent returns the scroll percentage
@@ -571,6 +843,15 @@ func wiEffectiveDescription(wi provider.WorkItem) string {
 	return wi.Description
 }
 
+// renderText renders a description or comment body for the view width.
+// GitHub issues are written in markdown; Azure DevOps stores HTML.
+func (m *DetailModel) renderText(text string) string {
+	if m.workItem.Identity.Kind == provider.KindGitHub {
+		return m.markdown.Render(text, m.width)
+	}
+	return m.markdown.RenderHTML(text, m.width)
+}
+
 // stripHTMLTags removes HTML tags from a string and converts to plain text
 func stripHTMLTags(s string) string {
 	// Convert block elements to newlines before stripping
@@ -618,11 +899,3 @@ func shortenIterationPath(path string) string {
 	return strings.Join(parts[len(parts)-2:], "\\")
 }
 
-// hyperlink creates an OSC 8 terminal hyperlink
-func hyperlink(text, url string) string {
-	if url == "" {
-		return text
-	}
-	return fmt.Sprintf("\x1b]8;;%s\x07%s\x1b]8;;\x07", url, text)
-}
-
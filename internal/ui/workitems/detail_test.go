@@ -11,6 +11,7 @@ import (
 	"github.com/Elpulgo/azdo/internal/ui/components"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 )
 
 // newTestProvider creates a provider.Provider backed by a real azdevops adapter
@@ -359,6 +360,61 @@ func TestDetailView_TaskShowsDescription(t *testing.T) {
 	}
 }
 
+func TestDetailView_GitHubIssueRendersMarkdown(t *testing.T) {
+	wi := provider.WorkItem{
+		Identity:     provider.Identity{Kind: provider.KindGitHub, ID: "12", Scope: "owner/repo"},
+		Title:        "Crash on start",
+		State:        "open",
+		WorkItemType: "Issue",
+		Description:  "### Steps\n\n1. run `azdo`\n2. press **q**\n\n<details>",
+	}
+
+	m := NewDetailModel(nil, wi)
+	m.SetSize(100, 30)
+	m, _ = m.Update(commentsLoadedMsg{comments: []provider.WorkItemComment{{ID: 1, Text: "Fixed in [#13](https://github.com/owner/repo/pull/13)"}}})
+
+	view := m.View()
+	for _, want := range []string{"Steps", "1. run azdo", "2. press q", "<details>", "\x1b]8;;https://github.com/owner/repo/pull/13\x07"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view should contain %q", want)
+		}
+	}
+	if strings.Contains(view, "###") || strings.Contains(view, "**") {
+		t.Error("view should not show raw markdown syntax")
+	}
+}
+
+func TestDetailView_AzureRendersHTML(t *testing.T) {
+	wi := provider.WorkItem{
+		Identity:           provider.Identity{ID: "103"},
+		Title:              "Crash on login",
+		State:              "Active",
+		WorkItemType:       "Bug",
+		ReproSteps:         `<ol><li>Open <b>login</b></li><li>Tap <a href="https://x.io/help">help</a></li></ol><img src="https://x.io/shot.png" alt="crash">`,
+		AcceptanceCriteria: "<ul><li>No crash</li></ul>",
+	}
+
+	m := NewDetailModel(nil, wi)
+	m.SetSize(100, 30)
+
+	view := m.View()
+	if !strings.Contains(view, "\x1b]8;;https://x.io/help\x07") {
+		t.Error("links should be hyperlinks")
+	}
+	view = ansi.Strip(view)
+	for _, want := range []string{"1. Open login", "2. Tap help", "[image: crash] https://x.io/shot.png", "Acceptance Criteria", "• No crash"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view should contain %q:\n%s", want, view)
+		}
+	}
+	if strings.Index(view, "Acceptance Criteria") < strings.Index(view, "Open login") {
+		t.Error("acceptance criteria should follow the repro steps")
+	}
+	if strings.Contains(view, "<li>") || strings.Contains(view, "<b>") {
+		t.Error("view should not show raw HTML")
+	}
+}
+
 func TestDetailView_NoTypeIconInTitle(t *testing.T) {
 	wi := provider.WorkItem{
 		Identity:     provider.Identity{ID: "789"},
@@ -0,0 +1,226 @@
+package workitems
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Elpulgo/azdo/internal/provider"
+	"github.com/Elpulgo/azdo/internal/ui/components"
+	"github.com/Elpulgo/azdo/internal/ui/markdown"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// commentActionMsg is sent when a comment edit, delete or reaction completes
+type commentActionMsg struct {
+	message string
+	err     error
+}
+
+// currentComment returns the selected discussion comment.
+func (m *DetailModel) currentComment() (provider.WorkItemComment, bool) {
+	if m.selectedComment < 0 || m.selectedComment >= len(m.comments) {
+		return provider.WorkItemComment{}, false
+	}
+	return m.comments[m.selectedComment], true
+}
+
+// selectComment moves the comment selection by delta and scrolls the
+// selected comment to the top of the viewport.
+func (m *DetailModel) selectComment(delta int) {
+	if len(m.comments) == 0 {
+		return
+	}
+	next := m.selectedComment + delta
+	if m.selectedComment < 0 {
+		next = 0
+	}
+	m.selectedComment = max(0, min(next, len(m.comments)-1))
+	m.updateViewportContent()
+	if m.selectedComment < len(m.commentOffsets) {
+		m.viewport.SetYOffset(m.commentOffsets[m.selectedComment])
+	}
+}
+
+// selectedCommentOrHint returns the selected comment, or sets a status hint
+// when none is selected.
+func (m *DetailModel) selectedCommentOrHint() (provider.WorkItemComment, bool) {
+	c, ok := m.currentComment()
+	if !ok {
+		if len(m.comments) > 0 {
+			m.statusMessage = "Select a comment with n/N first"
+		}
+		return provider.WorkItemComment{}, false
+	}
+	return c, true
+}
+
+// startEditComment opens the comment form pre-filled with the selected
+// comment's text. An Azure DevOps HTML comment is edited as markdown and
+// saved back as HTML, so its formatting, links and mentions survive.
+func (m *DetailModel) startEditComment() tea.Cmd {
+	if m.posting {
+		return nil
+	}
+	c, ok := m.selectedCommentOrHint()
+	if !ok {
+		return nil
+	}
+	m.editCommentID = c.ID
+	m.editCommentHTML = !c.Markdown && m.workItem.Identity.Kind != provider.KindGitHub
+	m.commentForm.Reset()
+	text := c.Text
+	if m.editCommentHTML {
+		text = markdown.ToMarkdown(text)
+	}
+	m.commentForm.SetValue(text)
+	m.commentForm.SetWidth(m.width)
+	m.commentForm.Show()
+	m.resizeViewport()
+	return m.commentForm.Focus()
+}
+
+// handleEditSubmitted saves the edited text of the comment being edited.
+func (m *DetailModel) handleEditSubmitted(text string) (*DetailModel, tea.Cmd) {
+	id := m.editCommentID
+	m.editCommentID = 0
+	if m.editCommentHTML {
+		text = markdown.ToHTML(text)
+	}
+	m.resizeViewport()
+	return m, m.editComment(id, text)
+}
+
+// promptDeleteComment asks for confirmation before deleting the selected
+// comment.
+func (m *DetailModel) promptDeleteComment() {
+	c, ok := m.selectedCommentOrHint()
+	if !ok {
+		return
+	}
+	m.pendingDeleteID = c.ID
+	m.resizeViewport()
+}
+
+// updateDeletePrompt handles the y/n confirmation for deleting a comment.
+func (m *DetailModel) updateDeletePrompt(msg tea.KeyMsg) (*DetailModel, tea.Cmd) {
+	var cmd tea.Cmd
+	switch msg.String() {
+	case "y", "Y":
+		cmd = m.deleteComment(m.pendingDeleteID)
+	case "n", "N", "esc":
+	default:
+		return m, nil
+	}
+	m.pendingDeleteID = 0
+	m.resizeViewport()
+	return m, cmd
+}
+
+// toggleLike likes the selected comment, or removes the like when the user
+// already gave one.
+func (m *DetailModel) toggleLike() tea.Cmd {
+	c, ok := m.selectedCommentOrHint()
+	if !ok {
+		return nil
+	}
+	remove := components.HasReacted(c.Reactions, provider.ReactionLike)
+	return m.reactToComment(c.ID, provider.ReactionLike, remove)
+}
+
+// openReactionPicker shows the reaction picker for the selected comment.
+func (m *DetailModel) openReactionPicker() {
+	c, ok := m.selectedCommentOrHint()
+	if !ok {
+		return
+	}
+	m.reactionPicker.SetSize(m.width, m.height)
+	m.reactionPicker.Show(c.Reactions, provider.WorkItemCommentReactions(m.workItem.Identity.Kind))
+}
+
+// handleCommentAction reports the outcome of a comment action and re-fetches
+// the discussion so it reflects the change.
+func (m *DetailModel) handleCommentAction(msg commentActionMsg) (*DetailModel, tea.Cmd) {
+	if msg.err != nil {
+		m.statusMessage = fmt.Sprintf("Error: %v", msg.err)
+		return m, nil
+	}
+	m.statusMessage = msg.message
+	m.commentsLoading = true
+	m.updateViewportContent()
+	return m, m.fetchComments()
+}
+
+// editComment sends the new text of a comment to the API.
+func (m *DetailModel) editComment(commentID int, text string) tea.Cmd {
+	client := m.client
+	wi := m.workItem
+	return func() tea.Msg {
+		if client == nil {
+			return commentActionMsg{err: fmt.Errorf("no client available")}
+		}
+		if err := client.EditWorkItemComment(wi.Identity.Scope, workItemNumericID(wi), commentID, text); err != nil {
+			return commentActionMsg{err: err}
+		}
+		return commentActionMsg{message: "Comment updated"}
+	}
+}
+
+// deleteComment deletes a comment through the API.
+func (m *DetailModel) deleteComment(commentID int) tea.Cmd {
+	client := m.client
+	wi := m.workItem
+	return func() tea.Msg {
+		if client == nil {
+			return commentActionMsg{err: fmt.Errorf("no client available")}
+		}
+		if err := client.DeleteWorkItemComment(wi.Identity.Scope, workItemNumericID(wi), commentID); err != nil {
+			return commentActionMsg{err: err}
+		}
+		return commentActionMsg{message: "Comment deleted"}
+	}
+}
+
+// reactToComment adds or removes the user's reaction on a comment.
+func (m *DetailModel) reactToComment(commentID int, reaction provider.Reaction, remove bool) tea.Cmd {
+	client := m.client
+	wi := m.workItem
+	return func() tea.Msg {
+		if client == nil {
+			return commentActionMsg{err: fmt.Errorf("no client available")}
+		}
+		err := client.ReactToWorkItemComment(wi.Identity.Scope, workItemNumericID(wi), commentID, reaction, remove)
+		if errors.Is(err, provider.ErrReactionUnsupported) {
+			return commentActionMsg{err: fmt.Errorf("%s reactions are not supported on work item comments here", components.ReactionIcon(reaction))}
+		}
+		if err != nil {
+			return commentActionMsg{err: err}
+		}
+		if remove {
+			return commentActionMsg{message: "Reaction removed"}
+		}
+		return commentActionMsg{message: "Reaction added"}
+	}
+}
+
+// capturesInput reports whether a form, picker or confirmation in the detail
+// view is consuming keystrokes, so esc and global shortcuts must reach it.
+func (m *DetailModel) capturesInput() bool {
+	return m.statePicker.IsVisible() || m.commentForm.IsVisible() ||
+		m.reactionPicker.IsVisible() || m.pendingDeleteID > 0 || m.editForm.IsVisible() ||
+		m.linkForm.IsVisible()
+}
+
+// discussionContextItems are the footer hints for the discussion; the
+// actions are listed once a comment is selected.
+func discussionContextItems(selected bool) []components.ContextItem {
+	items := []components.ContextItem{{Key: "n/N", Description: "select comment"}}
+	if !selected {
+		return items
+	}
+	return append(items,
+		components.ContextItem{Key: "e", Description: "edit"},
+		components.ContextItem{Key: "D", Description: "delete"},
+		components.ContextItem{Key: "+", Description: "like"},
+		components.ContextItem{Key: "R", Description: "react"},
+	)
+}
@@ -0,0 +1,185 @@
+package workitems
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Elpulgo/azdo/internal/provider"
+	"github.com/Elpulgo/azdo/internal/ui/components"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// discussionProvider records work-item comment edits, deletes and reactions;
+// every other method panics via the nil embedded interface.
+type discussionProvider struct {
+	provider.Provider
+	calls []string
+}
+
+func (p *discussionProvider) EditWorkItemComment(scope string, id, commentID int, text string) error {
+	p.calls = append(p.calls, fmt.Sprintf("edit %d/%d %s", id, commentID, text))
+	return nil
+}
+
+func (p *discussionProvider) DeleteWorkItemComment(scope string, id, commentID int) error {
+	p.calls = append(p.calls, fmt.Sprintf("delete %d/%d", id, commentID))
+	return nil
+}
+
+func (p *discussionProvider) ReactToWorkItemComment(scope string, id, commentID int, reaction provider.Reaction, remove bool) error {
+	verb := "react"
+	if remove {
+		verb = "unreact"
+	}
+	p.calls = append(p.calls, fmt.Sprintf("%s %d/%d %s", verb, id, commentID, reaction))
+	return nil
+}
+
+func (p *discussionProvider) WorkItemURL(scope string, id int) string {
+	return ""
+}
+
+func keyRunes(s string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+// newDiscussionModel opens work item 1 with the sample comments loaded.
+func newDiscussionModel() (*DetailModel, *discussionProvider) {
+	client := &discussionProvider{}
+	m := NewDetailModel(client, newTestWI(1, "T", "", ""))
+	m.SetSize(100, 40)
+	m, _ = m.Update(commentsLoadedMsg{comments: sampleComments()})
+	return m, client
+}
+
+func TestDetailModel_CommentActionsNeedSelection(t *testing.T) {
+	m, client := newDiscussionModel()
+
+	m.Update(keyRunes("+"))
+	if len(client.calls) != 0 {
+		t.Errorf("calls = %q, want none without a selection", client.calls)
+	}
+	if !strings.Contains(m.GetStatusMessage(), "n/N") {
+		t.Errorf("status = %q, want a hint to select a comment", m.GetStatusMessage())
+	}
+}
+
+func TestDetailModel_SelectCommentMarksHeader(t *testing.T) {
+	m, _ := newDiscussionModel()
+
+	m.Update(keyRunes("n"))
+	m.Update(keyRunes("n"))
+	if m.selectedComment != 1 {
+		t.Fatalf("selectedComment = %d, want 1", m.selectedComment)
+	}
+	if !strings.Contains(m.View(), "▸ John Roe") {
+		t.Error("Expected the selected comment's header to be marked")
+	}
+	m.Update(keyRunes("n"))
+	if m.selectedComment != 1 {
+		t.Errorf("selection moved past the last comment: %d", m.selectedComment)
+	}
+}
+
+func TestDetailModel_EditSelectedComment(t *testing.T) {
+	m, client := newDiscussionModel()
+	m.Update(keyRunes("n"))
+
+	m.Update(keyRunes("e"))
+	if !m.commentForm.IsVisible() || m.commentForm.Value() != "Newest discussion point" {
+		t.Fatalf("edit form visible=%v value=%q", m.commentForm.IsVisible(), m.commentForm.Value())
+	}
+	m.commentForm.SetValue("Reworded point")
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	_, cmd = m.Update(cmd())
+	if cmd == nil {
+		t.Fatal("Expected saving to edit the comment")
+	}
+	_, refetch := m.Update(cmd())
+
+	if len(client.calls) != 1 || client.calls[0] != "edit 1/45 <p>Reworded point</p>" {
+		t.Errorf("calls = %q", client.calls)
+	}
+	if m.GetStatusMessage() != "Comment updated" || refetch == nil {
+		t.Errorf("status = %q, refetch = %v; want an update message and a refetch", m.GetStatusMessage(), refetch != nil)
+	}
+}
+
+func TestDetailModel_EditCommentKeepsAzureFormatting(t *testing.T) {
+	m, client := newDiscussionModel()
+	m.comments[0].Text = `<div>See <a href="https://example.com/spec">the spec</a></div>`
+	m.Update(keyRunes("n"))
+
+	m.Update(keyRunes("e"))
+	if got, want := m.commentForm.Value(), "See [the spec](https://example.com/spec)"; got != want {
+		t.Fatalf("edit form value = %q, want %q", got, want)
+	}
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	_, cmd = m.Update(cmd())
+	cmd()
+
+	if len(client.calls) != 1 || !strings.Contains(client.calls[0], `<a href="https://example.com/spec">the spec</a>`) {
+		t.Errorf("calls = %q, want the link saved back as HTML", client.calls)
+	}
+}
+
+func TestDetailModel_DeleteSelectedCommentAfterConfirm(t *testing.T) {
+	m, client := newDiscussionModel()
+	m.Update(keyRunes("N")) // from no selection, N also starts at the newest
+
+	m.Update(keyRunes("D"))
+	if !strings.Contains(m.View(), "Delete this comment? (y/n)") {
+		t.Fatal("Expected a delete confirmation")
+	}
+	_, cmd := m.Update(keyRunes("y"))
+	if cmd == nil {
+		t.Fatal("Expected y to delete the comment")
+	}
+	cmd()
+	if len(client.calls) != 1 || client.calls[0] != "delete 1/45" {
+		t.Errorf("calls = %q", client.calls)
+	}
+	if m.pendingDeleteID != 0 {
+		t.Error("Expected the confirmation to close")
+	}
+}
+
+func TestDetailModel_ReactionsRenderAndToggle(t *testing.T) {
+	client := &discussionProvider{}
+	m := NewDetailModel(client, newTestWI(1, "T", "", ""))
+	m.SetSize(100, 40)
+	comments := sampleComments()
+	comments[0].Reactions = []provider.ReactionCount{
+		{Reaction: provider.ReactionLike, Count: 1, Mine: true},
+		{Reaction: provider.ReactionHeart, Count: 2},
+	}
+	m, _ = m.Update(commentsLoadedMsg{comments: comments})
+
+	if !strings.Contains(m.View(), "[👍 1]  ❤ 2") {
+		t.Error("Expected the reaction summary under the comment")
+	}
+
+	m.Update(keyRunes("n"))
+	_, cmd := m.Update(keyRunes("+"))
+	cmd()
+	m.Update(keyRunes("R"))
+	if !m.reactionPicker.IsVisible() {
+		t.Fatal("Expected R to open the reaction picker")
+	}
+	for range 3 { // like, dislike, laugh, hooray
+		m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	}
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	selected, ok := cmd().(components.ReactionSelectedMsg)
+	if !ok {
+		t.Fatal("Expected enter to pick a reaction")
+	}
+	_, cmd = m.Update(selected)
+	cmd()
+
+	want := []string{"unreact 1/45 +1", "react 1/45 hooray"}
+	if strings.Join(client.calls, "|") != strings.Join(want, "|") {
+		t.Errorf("calls = %q, want %q", client.calls, want)
+	}
+}
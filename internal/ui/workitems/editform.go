@@ -0,0 +1,429 @@
+package workitems
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Elpulgo/azdo/internal/provider"
+	"github.com/Elpulgo/azdo/internal/ui/styles"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// editSuggestionLimit caps the suggestions listed under a field.
+const editSuggestionLimit = 5
+
+// editRow is one field of the edit form. options are the values suggested
+// while typing, fetched from the backend; hidden rows are fields the
+// backend has no equivalent of.
+type editRow struct {
+	field   provider.WorkItemField
+	label   string
+	input   textinput.Model
+	initial string
+	options []string
+	hidden  bool
+}
+
+// fieldOptionsMsg carries the values offered for a field.
+type fieldOptionsMsg struct {
+	field   provider.WorkItemField
+	options []string
+	err     error
+}
+
+// workItemUpdatedMsg is emitted when a field update finishes.
+type workItemUpdatedMsg struct {
+	item *provider.WorkItem
+	err  error
+}
+
+// workItemReloadedMsg carries the work item as it is now, read after an
+// update was rejected because someone else changed it.
+type workItemReloadedMsg struct {
+	item *provider.WorkItem
+	err  error
+}
+
+// WorkItemUpdatedMsg is emitted after work item fields are successfully
+// edited. The list model uses it to trigger a data refresh.
+type WorkItemUpdatedMsg struct{}
+
+// editForm is the modal editing the fields of a work item. ↑/↓ and tab move
+// between fields, enter or ctrl+s saves and esc cancels. While typing in a
+// field with known values (iterations, areas, team members) the matching
+// ones are listed below it: ↑/↓ choose and tab or enter accepts. Only the
+// fields that changed are sent, based on the revision the item was read at,
+// so an edit made meanwhile by someone else is detected rather than
+// overwritten.
+type editForm struct {
+	styles     *styles.Styles
+	client     provider.Provider
+	visible    bool
+	width      int
+	height     int
+	item       provider.WorkItem
+	rows       []editRow
+	cursor     int
+	typing     bool // the field under the cursor was typed in; shows suggestions
+	suggestion int  // index into suggestions()
+	err        string
+	notice     string
+	saving     bool
+}
+
+func newEditForm(client provider.Provider, s *styles.Styles) editForm {
+	return editForm{styles: s, client: client}
+}
+
+// Show opens the form prefilled from item and returns the command fetching
+// the values offered for its fields.
+func (f *editForm) Show(item provider.WorkItem) tea.Cmd {
+	f.item = item
+	points := ""
+	if item.StoryPoints != 0 {
+		points = strconv.FormatFloat(item.StoryPoints, 'f', -1, 64)
+	}
+	priority := ""
+	if item.Priority != 0 {
+		priority = strconv.Itoa(item.Priority)
+	}
+	iterationLabel := "Iteration"
+	if item.Identity.Kind == provider.KindGitHub {
+		iterationLabel = "Milestone"
+	}
+	fields := []struct {
+		field provider.WorkItemField
+		label string
+		value string
+	}{
+		{provider.FieldTitle, "Title", item.Title},
+		{provider.FieldAssignedTo, "Assignee", item.AssignedTo},
+		{provider.FieldPriority, "Priority", priority},
+		{provider.FieldStoryPoints, "Story points", points},
+		{provider.FieldIteration, iterationLabel, item.IterationPath},
+		{provider.FieldArea, "Area path", item.AreaPath},
+		{provider.FieldTags, "Tags", item.Tags},
+	}
+	f.rows = make([]editRow, len(fields))
+	for i, fd := range fields {
+		ti := textinput.New()
+		ti.Prompt = ""
+		ti.CharLimit = 400
+		ti.SetValue(fd.value)
+		f.rows[i] = editRow{field: fd.field, label: fd.label, input: ti, initial: fd.value}
+	}
+	f.rows[len(f.rows)-1].input.Placeholder = "semicolon-separated"
+	f.cursor = 0
+	f.typing = false
+	f.suggestion = 0
+	f.err = ""
+	f.notice = ""
+	f.saving = false
+	f.visible = true
+	f.focusCursor()
+	return f.fetchOptions()
+}
+
+// Hide closes the form.
+func (f *editForm) Hide() {
+	f.visible = false
+	for i := range f.rows {
+		f.rows[i].input.Blur()
+	}
+}
+
+// IsVisible returns whether the form is open.
+func (f editForm) IsVisible() bool {
+	return f.visible
+}
+
+// SetSize sets the area the form is centered in.
+func (f *editForm) SetSize(width, height int) {
+	f.width = width
+	f.height = height
+}
+
+// fetchOptions returns the commands fetching the offered values of each
+// field, one per field so a slow or failing lookup only affects its row.
+func (f editForm) fetchOptions() tea.Cmd {
+	if f.client == nil {
+		return nil
+	}
+	client, scope := f.client, f.item.Identity.Scope
+	cmds := make([]tea.Cmd, len(f.rows))
+	for i, row := range f.rows {
+		field := row.field
+		cmds[i] = func() tea.Msg {
+			options, err := client.GetFieldOptions(scope, field)
+			return fieldOptionsMsg{field: field, options: options, err: err}
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// handleOptions records the values offered for a field, hiding it when the
+// backend does not support it. A failed lookup only loses the suggestions.
+func (f *editForm) handleOptions(msg fieldOptionsMsg) {
+	for i := range f.rows {
+		if f.rows[i].field != msg.field {
+			continue
+		}
+		switch {
+		case errors.Is(msg.err, provider.ErrFieldUnsupported):
+			f.rows[i].hidden = true
+			if i == f.cursor {
+				f.move(1)
+			}
+		case msg.err == nil:
+			f.rows[i].options = msg.options
+		}
+	}
+}
+
+// focusCursor focuses the input under the cursor.
+func (f *editForm) focusCursor() {
+	for i := range f.rows {
+		if i == f.cursor {
+			f.rows[i].input.Focus()
+		} else {
+			f.rows[i].input.Blur()
+		}
+	}
+}
+
+// move moves the cursor by delta to the next visible row, wrapping around.
+func (f *editForm) move(delta int) {
+	n := len(f.rows)
+	for step := 0; step < n; step++ {
+		f.cursor = ((f.cursor+delta)%n + n) % n
+		if !f.rows[f.cursor].hidden {
+			break
+		}
+	}
+	f.typing = false
+	f.suggestion = 0
+	f.focusCursor()
+}
+
+// suggestions returns the offered values of the field under the cursor
+// that contain its text, ignoring case. Nothing is suggested until the
+// field is typed in.
+func (f editForm) suggestions() []string {
+	if !f.typing || f.cursor >= len(f.rows) {
+		return nil
+	}
+	row := f.rows[f.cursor]
+	value := strings.TrimSpace(row.input.Value())
+	query := strings.ToLower(value)
+	var matches []string
+	for _, option := range row.options {
+		if option == value || !strings.Contains(strings.ToLower(option), query) {
+			continue
+		}
+		matches = append(matches, option)
+		if len(matches) == editSuggestionLimit {
+			break
+		}
+	}
+	return matches
+}
+
+// accept replaces the text of the field under the cursor with the chosen
+// suggestion.
+func (f *editForm) accept(value string) {
+	f.rows[f.cursor].input.SetValue(value)
+	f.rows[f.cursor].input.CursorEnd()
+	f.typing = false
+	f.suggestion = 0
+}
+
+// changes returns the fields whose text differs from the item's.
+func (f editForm) changes() map[provider.WorkItemField]string {
+	fields := map[provider.WorkItemField]string{}
+	for _, row := range f.rows {
+		value := strings.TrimSpace(row.input.Value())
+		if !row.hidden && value != strings.TrimSpace(row.initial) {
+			fields[row.field] = value
+		}
+	}
+	return fields
+}
+
+// submit returns the command sending the changed fields, or nil when
+// nothing changed.
+func (f *editForm) submit() tea.Cmd {
+	if f.saving || f.client == nil {
+		return nil
+	}
+	fields := f.changes()
+	if len(fields) == 0 {
+		f.err = "Nothing changed"
+		return nil
+	}
+	if title, ok := fields[provider.FieldTitle]; ok && title == "" {
+		f.err = "a title is required"
+		return nil
+	}
+	f.err = ""
+	f.saving = true
+	client, wi := f.client, f.item
+	update := provider.WorkItemUpdate{Rev: wi.Rev, Fields: fields}
+	return func() tea.Msg {
+		item, err := client.UpdateWorkItemFields(wi.Identity.Scope, workItemNumericID(wi), update)
+		return workItemUpdatedMsg{item: item, err: err}
+	}
+}
+
+// handleUpdated closes the form after a successful update. On a conflict
+// it returns the command reloading the item; other errors are shown and
+// the form stays open so nothing typed is lost.
+func (f *editForm) handleUpdated(msg workItemUpdatedMsg) tea.Cmd {
+	if msg.err == nil {
+		f.saving = false
+		f.Hide()
+		return nil
+	}
+	if !errors.Is(msg.err, provider.ErrWorkItemConflict) || f.client == nil {
+		f.saving = false
+		f.err = fmt.Sprintf("Error saving: %v", msg.err)
+		return nil
+	}
+	client, wi := f.client, f.item
+	return func() tea.Msg {
+		item, err := client.GetWorkItem(wi.Identity.Scope, workItemNumericID(wi))
+		return workItemReloadedMsg{item: item, err: err}
+	}
+}
+
+// handleReloaded rebases the form on the item as it is now after a
+// conflict. What the user typed is kept; saving again overwrites the other
+// change.
+func (f *editForm) handleReloaded(msg workItemReloadedMsg) {
+	f.saving = false
+	if msg.err != nil {
+		f.err = fmt.Sprintf("Changed by someone else; reloading failed: %v", msg.err)
+		return
+	}
+	f.item = *msg.item
+	f.err = ""
+	f.notice = "Changed by someone else since you opened it; reloaded — save again to overwrite"
+}
+
+// Update handles the form's keys.
+func (f editForm) Update(msg tea.KeyMsg) (editForm, tea.Cmd) {
+	if !f.visible {
+		return f, nil
+	}
+	matches := f.suggestions()
+	if len(matches) > 0 {
+		switch msg.String() {
+		case "up":
+			f.suggestion = (f.suggestion + len(matches) - 1) % len(matches)
+			return f, nil
+		case "down":
+			f.suggestion = (f.suggestion + 1) % len(matches)
+			return f, nil
+		case "tab", "enter":
+			f.accept(matches[min(f.suggestion, len(matches)-1)])
+			return f, nil
+		case "esc":
+			f.typing = false
+			return f, nil
+		}
+	}
+	switch msg.String() {
+	case "esc":
+		f.Hide()
+		return f, nil
+	case "enter", "ctrl+s":
+		return f, f.submit()
+	case "up", "shift+tab":
+		f.move(-1)
+		return f, nil
+	case "down", "tab":
+		f.move(1)
+		return f, nil
+	}
+
+	var cmd tea.Cmd
+	f.rows[f.cursor].input, cmd = f.rows[f.cursor].input.Update(msg)
+	f.typing = true
+	f.suggestion = 0
+	return f, cmd
+}
+
+// View renders the form centered in its area.
+func (f editForm) View() string {
+	if !f.visible {
+		return ""
+	}
+	const labelWidth = 14
+	const width = 72
+
+	matches := f.suggestions()
+	var rows []string
+	for i, row := range f.rows {
+		if row.hidden {
+			continue
+		}
+		cursor := "  "
+		if i == f.cursor {
+			cursor = "> "
+		}
+		label := fmt.Sprintf("%-*s", labelWidth, row.label)
+		style := lipgloss.NewStyle().Width(width).Foreground(f.styles.Theme.GetForeground())
+		if i == f.cursor {
+			style = style.Foreground(f.styles.Theme.GetSelectForeground()).Background(f.styles.Theme.GetSelectBackground())
+		}
+		rows = append(rows, style.Render(cursor+label+row.input.View()))
+		if i != f.cursor {
+			continue
+		}
+		for j, match := range matches {
+			line := strings.Repeat(" ", 2+labelWidth) + match
+			if j == f.suggestion {
+				rows = append(rows, f.styles.Selected.Render(line))
+			} else {
+				rows = append(rows, f.styles.Muted.Render(line))
+			}
+		}
+	}
+
+	title := lipgloss.NewStyle().
+		Foreground(f.styles.Theme.GetPrimary()).
+		Bold(true).
+		Render(fmt.Sprintf("Edit #%s", f.item.Identity.ID))
+	status := ""
+	switch {
+	case f.saving:
+		status = f.styles.Muted.Render("Saving...")
+	case f.err != "":
+		status = f.styles.Error.Render(f.err)
+	case f.notice != "":
+		status = f.styles.Warning.Render(f.notice)
+	}
+	hint := "↑/↓/tab: move • enter/ctrl+s: save • esc: cancel"
+	if len(matches) > 0 {
+		hint = "↑/↓: choose • tab/enter: accept • esc: dismiss"
+	}
+	help := lipgloss.NewStyle().
+		Foreground(f.styles.Theme.GetForegroundMuted()).
+		Render(hint)
+
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		title, "", strings.Join(rows, "\n"), "", status, help)
+	modal := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(f.styles.Theme.GetBorder()).
+		Padding(1, 2).
+		Render(content)
+
+	if f.width > 0 && f.height > 0 {
+		modal = lipgloss.Place(f.width, f.height, lipgloss.Center, lipgloss.Center, modal)
+	}
+	return modal
+}
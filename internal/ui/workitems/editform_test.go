@@ -0,0 +1,233 @@
+package workitems
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Elpulgo/azdo/internal/provider"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// editProvider serves field options and applies field updates, rejecting
+// updates based on a stale revision; every other method panics via the nil
+// embedded interface.
+type editProvider struct {
+	provider.Provider
+	item        provider.WorkItem
+	options     map[provider.WorkItemField][]string
+	unsupported map[provider.WorkItemField]bool
+	updates     []provider.WorkItemUpdate
+}
+
+func (p *editProvider) SearchPeople(scope, query string) ([]provider.Person, error) {
+	return nil, nil
+}
+
+func (p *editProvider) WorkItemURL(scope string, id int) string { return "" }
+
+func (p *editProvider) GetFieldOptions(scope string, field provider.WorkItemField) ([]string, error) {
+	if p.unsupported[field] {
+		return nil, provider.ErrFieldUnsupported
+	}
+	return p.options[field], nil
+}
+
+func (p *editProvider) GetWorkItem(scope string, id int) (*provider.WorkItem, error) {
+	item := p.item
+	return &item, nil
+}
+
+func (p *editProvider) UpdateWorkItemFields(scope string, id int, update provider.WorkItemUpdate) (*provider.WorkItem, error) {
+	p.updates = append(p.updates, update)
+	if update.Rev != 0 && update.Rev != p.item.Rev {
+		return nil, fmt.Errorf("work item %d: %w", id, provider.ErrWorkItemConflict)
+	}
+	for field, value := range update.Fields {
+		switch field {
+		case provider.FieldTitle:
+			p.item.Title = value
+		case provider.FieldIteration:
+			p.item.IterationPath = value
+		}
+	}
+	p.item.Rev++
+	item := p.item
+	return &item, nil
+}
+
+// runBatch runs cmd, including each command of a batch, and feeds the
+// messages back to m.
+func runBatch(m *DetailModel, cmd tea.Cmd) *DetailModel {
+	if cmd == nil {
+		return m
+	}
+	msg := cmd()
+	if batch, ok := msg.(tea.BatchMsg); ok {
+		for _, c := range batch {
+			m = runBatch(m, c)
+		}
+		return m
+	}
+	m, _ = m.Update(msg)
+	return m
+}
+
+func openEditForm(t *testing.T, p *editProvider) *DetailModel {
+	t.Helper()
+	m := NewDetailModel(p, p.item)
+	m.SetSize(100, 40)
+	m, cmd := m.Update(keyRunes("E"))
+	if !m.editForm.IsVisible() {
+		t.Fatal("E should open the edit form")
+	}
+	return runBatch(m, cmd)
+}
+
+func editItem() provider.WorkItem {
+	return provider.WorkItem{
+		Identity:      provider.Identity{ID: "42", Scope: "proj"},
+		Title:         "Old title",
+		IterationPath: `proj\Sprint 1`,
+		Rev:           3,
+	}
+}
+
+func TestEditForm_SendsOnlyChangedFields(t *testing.T) {
+	p := &editProvider{item: editItem()}
+	m := openEditForm(t, p)
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlU})
+	for _, r := range "New title" {
+		m, _ = m.Update(keyRunes(string(r)))
+	}
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if cmd == nil {
+		t.Fatal("ctrl+s should save")
+	}
+	m, cmd = m.Update(cmd())
+
+	if len(p.updates) != 1 {
+		t.Fatalf("got %d updates, want 1", len(p.updates))
+	}
+	got := p.updates[0]
+	if got.Rev != 3 || len(got.Fields) != 1 || got.Fields[provider.FieldTitle] != "New title" {
+		t.Errorf("update = %+v, want only the title at rev 3", got)
+	}
+	if m.editForm.IsVisible() {
+		t.Error("the form should close after saving")
+	}
+	if m.workItem.Title != "New title" || m.workItem.Rev != 4 {
+		t.Errorf("detail item = %q rev %d, want the updated item", m.workItem.Title, m.workItem.Rev)
+	}
+	if cmd == nil {
+		t.Fatal("expected a command signalling the list")
+	}
+	if _, ok := cmd().(WorkItemUpdatedMsg); !ok {
+		t.Error("a successful edit should emit WorkItemUpdatedMsg")
+	}
+}
+
+func TestEditForm_AcceptsSuggestion(t *testing.T) {
+	p := &editProvider{
+		item: editItem(),
+		options: map[provider.WorkItemField][]string{
+			provider.FieldIteration: {`proj\Sprint 1`, `proj\Sprint 2`, `proj\Sprint 3`},
+		},
+	}
+	m := openEditForm(t, p)
+
+	for i := 0; i < 4; i++ { // Title, Assignee, Priority, Story points
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlU})
+	for _, r := range "sprint" {
+		m, _ = m.Update(keyRunes(string(r)))
+	}
+	if view := m.View(); !strings.Contains(view, `proj\Sprint 3`) {
+		t.Fatalf("matching iterations should be suggested:\n%s", view)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+
+	if got := m.editForm.changes()[provider.FieldIteration]; got != `proj\Sprint 2` {
+		t.Errorf("iteration = %q, want the second suggestion", got)
+	}
+	if m.editForm.cursor != 4 {
+		t.Errorf("accepting should keep the cursor on the field, got row %d", m.editForm.cursor)
+	}
+}
+
+func TestEditForm_ConflictReloadsAndKeepsInput(t *testing.T) {
+	p := &editProvider{item: editItem()}
+	m := openEditForm(t, p)
+	p.item.Rev = 5 // someone else edited the item
+	p.item.IterationPath = `proj\Sprint 9`
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlU})
+	for _, r := range "Mine" {
+		m, _ = m.Update(keyRunes(string(r)))
+	}
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	m, cmd = m.Update(cmd())
+	if cmd == nil {
+		t.Fatal("a conflict should reload the item")
+	}
+	m, _ = m.Update(cmd())
+
+	if !m.editForm.IsVisible() {
+		t.Fatal("the form should stay open after a conflict")
+	}
+	if !strings.Contains(m.View(), "Changed by someone else") {
+		t.Error("the conflict should be reported")
+	}
+	if m.workItem.IterationPath != `proj\Sprint 9` {
+		t.Errorf("detail should show the reloaded item, got %q", m.workItem.IterationPath)
+	}
+
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	m, _ = m.Update(cmd())
+	if len(p.updates) != 2 || p.updates[1].Rev != 5 {
+		t.Fatalf("saving again should be based on the reloaded rev, got %+v", p.updates)
+	}
+	if p.item.Title != "Mine" || m.editForm.IsVisible() {
+		t.Errorf("second save should apply the typed title and close, got %q", p.item.Title)
+	}
+}
+
+func TestEditForm_HidesUnsupportedFields(t *testing.T) {
+	p := &editProvider{
+		item:        editItem(),
+		unsupported: map[provider.WorkItemField]bool{provider.FieldStoryPoints: true, provider.FieldArea: true},
+	}
+	m := openEditForm(t, p)
+
+	view := m.View()
+	if strings.Contains(view, "Story points") || strings.Contains(view, "Area path") {
+		t.Errorf("unsupported fields should be hidden:\n%s", view)
+	}
+	if _, ok := m.editForm.changes()[provider.FieldStoryPoints]; ok {
+		t.Error("hidden fields must not be sent")
+	}
+
+	// Moving down from Priority skips the hidden Story points row.
+	for i := 0; i < 3; i++ {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	}
+	if m.editForm.rows[m.editForm.cursor].field != provider.FieldIteration {
+		t.Errorf("cursor on %q, want the iteration", m.editForm.rows[m.editForm.cursor].field)
+	}
+}
+
+func TestEditForm_EscClosesWithoutSaving(t *testing.T) {
+	p := &editProvider{item: editItem()}
+	m := openEditForm(t, p)
+	m, _ = m.Update(keyRunes("x"))
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.editForm.IsVisible() {
+		t.Error("esc should close the form")
+	}
+	if len(p.updates) != 0 {
+		t.Error("esc must not save")
+	}
+}
@@ -0,0 +1,267 @@
+package workitems
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/Elpulgo/azdo/internal/provider"
+	"github.com/Elpulgo/azdo/internal/ui/markdown"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// execEditor is a package-level seam so tests can run the editor without
+// suspending a terminal.
+var execEditor = tea.ExecProcess
+
+// editorTarget names what the text written in the external editor is for.
+type editorTarget int
+
+const (
+	editorDescription editorTarget = iota
+	editorComment
+)
+
+// editorDoneMsg carries the text saved in the external editor.
+type editorDoneMsg struct {
+	target editorTarget
+	text   string
+	err    error
+}
+
+// descriptionSavedMsg is sent when a description edit completes. When the
+// description changed meanwhile, err is ErrWorkItemConflict and current is
+// the item as it is now.
+type descriptionSavedMsg struct {
+	item    *provider.WorkItem
+	current *provider.WorkItem
+	err     error
+}
+
+// Notes framing the two versions of a description that changed while it
+// was being edited. They are HTML comments, which markdown hides, and are
+// removed before saving.
+const (
+	mergeNote  = "<!-- Changed by someone else while you were editing. Your draft is first, their version last: merge them and save. -->"
+	theirsNote = "<!-- Their version: -->"
+)
+
+// editorCommand returns the command opening path in the user's editor:
+// $VISUAL, then $EDITOR, then vi (notepad on Windows).
+func editorCommand(path string) *exec.Cmd {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	args := strings.Fields(editor)
+	if len(args) == 0 {
+		args = []string{"vi"}
+		if runtime.GOOS == "windows" {
+			args = []string{"notepad"}
+		}
+	}
+	return exec.Command(args[0], append(args[1:], path)...)
+}
+
+// openEditor suspends the UI and opens text in the external editor. The
+// text is written to a temporary markdown file, read back once the editor
+// exits and then removed.
+func (m *DetailModel) openEditor(target editorTarget, text string) tea.Cmd {
+	f, err := os.CreateTemp("", "azdo-*.md")
+	if err != nil {
+		m.statusMessage = fmt.Sprintf("Cannot open editor: %v", err)
+		return nil
+	}
+	path := f.Name()
+	if text != "" {
+		text += "\n"
+	}
+	_, err = f.WriteString(text)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(path)
+		m.statusMessage = fmt.Sprintf("Cannot open editor: %v", err)
+		return nil
+	}
+	return execEditor(editorCommand(path), func(err error) tea.Msg {
+		defer os.Remove(path)
+		if err != nil {
+			return editorDoneMsg{target: target, err: err}
+		}
+		b, err := os.ReadFile(path)
+		return editorDoneMsg{target: target, text: strings.TrimRight(string(b), "\r\n"), err: err}
+	})
+}
+
+// editableText returns a description or comment as markdown for editing.
+// GitHub text is markdown already; Azure DevOps HTML is converted.
+func (m *DetailModel) editableText(text string) string {
+	if m.workItem.Identity.Kind == provider.KindGitHub {
+		return text
+	}
+	return markdown.ToMarkdown(text)
+}
+
+// descriptionField returns the field d edits: the repro steps of an Azure
+// DevOps bug, which has them in place of a description, else the
+// description.
+func descriptionField(wi provider.WorkItem) provider.WorkItemField {
+	if wi.WorkItemType == "Bug" && wi.Identity.Kind != provider.KindGitHub {
+		return provider.FieldReproSteps
+	}
+	return provider.FieldDescription
+}
+
+// descriptionText returns the stored text of the field d edits.
+func descriptionText(wi provider.WorkItem) string {
+	if descriptionField(wi) == provider.FieldReproSteps {
+		return wi.ReproSteps
+	}
+	return wi.Description
+}
+
+// editDescription opens the description in the external editor. A draft
+// that could not be saved is reopened; after a conflict, together with the
+// other version to be merged.
+func (m *DetailModel) editDescription() tea.Cmd {
+	if m.client == nil {
+		m.statusMessage = "Cannot edit: no client available"
+		return nil
+	}
+	if m.loading {
+		return nil
+	}
+	m.descriptionBase = descriptionText(m.workItem)
+	text := m.editableText(m.descriptionBase)
+	m.editorOriginal = text
+	switch {
+	case m.descriptionConflict:
+		text = mergeNote + "\n\n" + m.descriptionDraft + "\n\n" + theirsNote + "\n\n" + text
+	case m.descriptionDraft != "":
+		text = m.descriptionDraft
+	}
+	return m.openEditor(editorDescription, text)
+}
+
+// editCommentInEditor opens a new comment, or the draft of one that could
+// not be posted, in the external editor.
+func (m *DetailModel) editCommentInEditor() tea.Cmd {
+	if m.client == nil {
+		m.statusMessage = "Cannot comment: no client available"
+		return nil
+	}
+	if m.posting {
+		return nil
+	}
+	return m.openEditor(editorComment, m.commentDraft)
+}
+
+// handleEditorDone saves what was written in the external editor.
+func (m *DetailModel) handleEditorDone(msg editorDoneMsg) (*DetailModel, tea.Cmd) {
+	if msg.err != nil {
+		m.statusMessage = fmt.Sprintf("Editor failed: %v", msg.err)
+		return m, nil
+	}
+	if msg.target == editorComment {
+		text := strings.TrimSpace(msg.text)
+		if text == "" {
+			m.commentDraft = ""
+			m.statusMessage = "Empty comment discarded"
+			return m, nil
+		}
+		m.commentDraft = text
+		m.pendingComment = text
+		m.posting = true
+		m.spinner.SetVisible(true)
+		m.spinner.SetMessage("Posting comment...")
+		return m, tea.Batch(m.postMarkdownComment(text), m.spinner.Tick())
+	}
+
+	// A reopened draft is saved even if untouched: it was never stored.
+	if m.descriptionDraft == "" && strings.TrimSpace(msg.text) == strings.TrimSpace(m.editorOriginal) {
+		m.statusMessage = "Description unchanged"
+		return m, nil
+	}
+	text := strings.ReplaceAll(msg.text, mergeNote, "")
+	text = strings.TrimSpace(strings.ReplaceAll(text, theirsNote, ""))
+	m.descriptionDraft = text
+	m.descriptionConflict = false
+	m.loading = true
+	m.spinner.SetVisible(true)
+	m.spinner.SetMessage("Saving description...")
+	return m, tea.Batch(m.saveDescription(text), m.spinner.Tick())
+}
+
+// saveDescription writes the edited markdown back, as HTML on Azure DevOps.
+// The item is read first so a change made while the editor was open is
+// detected even on GitHub, which has no revisions; on Azure DevOps the
+// revision read also guards the write itself.
+func (m *DetailModel) saveDescription(text string) tea.Cmd {
+	client, wi, base := m.client, m.workItem, m.descriptionBase
+	return func() tea.Msg {
+		scope, id := wi.Identity.Scope, workItemNumericID(wi)
+		current, err := client.GetWorkItem(scope, id)
+		if err != nil {
+			return descriptionSavedMsg{err: err}
+		}
+		if descriptionText(*current) != base {
+			return descriptionSavedMsg{current: current, err: provider.ErrWorkItemConflict}
+		}
+
+		value := text
+		if wi.Identity.Kind != provider.KindGitHub {
+			value = markdown.ToHTML(text)
+		}
+		item, err := client.UpdateWorkItemFields(scope, id, provider.WorkItemUpdate{
+			Rev:    current.Rev,
+			Fields: map[provider.WorkItemField]string{descriptionField(*current): value},
+		})
+		if errors.Is(err, provider.ErrWorkItemConflict) {
+			current, rerr := client.GetWorkItem(scope, id)
+			if rerr != nil {
+				return descriptionSavedMsg{err: fmt.Errorf("changed by someone else; reloading failed: %w", rerr)}
+			}
+			return descriptionSavedMsg{current: current, err: err}
+		}
+		return descriptionSavedMsg{item: item, err: err}
+	}
+}
+
+// handleDescriptionSaved shows the saved description. After a conflict the
+// item is replaced by the version now stored and the draft kept for the
+// next edit to merge.
+func (m *DetailModel) handleDescriptionSaved(msg descriptionSavedMsg) (*DetailModel, tea.Cmd) {
+	m.loading = false
+	m.spinner.SetVisible(false)
+	switch {
+	case errors.Is(msg.err, provider.ErrWorkItemConflict) && msg.current != nil:
+		m.workItem = *msg.current
+		m.descriptionConflict = true
+		m.updateViewportContent()
+		m.statusMessage = "Description changed by someone else meanwhile; reloaded — press d to merge your draft"
+		return m, nil
+	case msg.err != nil:
+		m.statusMessage = fmt.Sprintf("Error saving description: %v — press d to reopen your draft", msg.err)
+		return m, nil
+	}
+	m.descriptionDraft = ""
+	m.workItem = *msg.item
+	m.statusMessage = "Description updated"
+	m.updateViewportContent()
+	return m, func() tea.Msg { return WorkItemUpdatedMsg{} }
+}
+
+// postMarkdownComment posts a comment written in markdown.
+func (m *DetailModel) postMarkdownComment(text string) tea.Cmd {
+	client := m.client
+	wi := m.workItem
+	return func() tea.Msg {
+		comment, err := client.AddWorkItemCommentMarkdown(wi.Identity.Scope, workItemNumericID(wi), text)
+		return commentPostedMsg{comment: comment, err: err, markdown: true}
+	}
+}
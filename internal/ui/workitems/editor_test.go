@@ -0,0 +1,228 @@
+package workitems
+
+import (
+	"os"
+	"os/exec"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Elpulgo/azdo/internal/provider"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// editorProvider reads and updates one work item and records markdown
+// comments; other methods panic via the nil embedded interface.
+type editorProvider struct {
+	editProvider
+	comments []string
+}
+
+func (p *editorProvider) UpdateWorkItemFields(scope string, id int, update provider.WorkItemUpdate) (*provider.WorkItem, error) {
+	for field, value := range update.Fields {
+		switch field {
+		case provider.FieldDescription:
+			p.item.Description = value
+		case provider.FieldReproSteps:
+			p.item.ReproSteps = value
+		}
+	}
+	return p.editProvider.UpdateWorkItemFields(scope, id, update)
+}
+
+func (p *editorProvider) AddWorkItemCommentMarkdown(scope string, id int, markdown string) (*provider.WorkItemComment, error) {
+	p.comments = append(p.comments, markdown)
+	return &provider.WorkItemComment{Text: markdown, Markdown: true}, nil
+}
+
+// fakeEditor stands in for the external editor: it records the text it
+// was opened with and saves reply instead, after running meanwhile.
+type fakeEditor struct {
+	opened    string
+	reply     string
+	meanwhile func()
+}
+
+func stubEditor(t *testing.T) *fakeEditor {
+	t.Helper()
+	e := &fakeEditor{}
+	orig := execEditor
+	t.Cleanup(func() { execEditor = orig })
+	execEditor = func(c *exec.Cmd, fn tea.ExecCallback) tea.Cmd {
+		path := c.Args[len(c.Args)-1]
+		b, _ := os.ReadFile(path)
+		e.opened = string(b)
+		if err := os.WriteFile(path, []byte(e.reply), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if e.meanwhile != nil {
+			e.meanwhile()
+		}
+		return func() tea.Msg { return fn(nil) }
+	}
+	return e
+}
+
+// editInEditor presses key, runs the editor and then the save it starts.
+func editInEditor(m *DetailModel, key string) *DetailModel {
+	m, cmd := m.Update(keyRunes(key))
+	if cmd == nil {
+		return m
+	}
+	m, cmd = m.Update(cmd())
+	return runBatch(m, cmd)
+}
+
+func newEditorModel(item provider.WorkItem) (*DetailModel, *editorProvider) {
+	p := &editorProvider{editProvider: editProvider{item: item}}
+	m := NewDetailModel(p, item)
+	m.SetSize(100, 40)
+	return m, p
+}
+
+func TestEditorCommand(t *testing.T) {
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "code --wait")
+	if got := editorCommand("/tmp/d.md").Args; !reflect.DeepEqual(got, []string{"code", "--wait", "/tmp/d.md"}) {
+		t.Errorf("Args = %q", got)
+	}
+	t.Setenv("VISUAL", "nano")
+	if got := editorCommand("/tmp/d.md").Args; !reflect.DeepEqual(got, []string{"nano", "/tmp/d.md"}) {
+		t.Errorf("$VISUAL should win, got %q", got)
+	}
+}
+
+func TestDetailModel_EditDescriptionConvertsToHTML(t *testing.T) {
+	e := stubEditor(t)
+	item := newTestWI(7, "T", "Active", "User Story")
+	item.Rev = 3
+	item.Description = "<div>Old <b>text</b></div>"
+	m, p := newEditorModel(item)
+
+	e.reply = "New *text*\n\n- a\n"
+	m = editInEditor(m, "d")
+
+	if e.opened != "Old **text**\n" {
+		t.Errorf("editor opened %q, want the description as markdown", e.opened)
+	}
+	want := provider.WorkItemUpdate{Rev: 3, Fields: map[provider.WorkItemField]string{
+		provider.FieldDescription: "<p>New <em>text</em></p><ul><li>a</li></ul>",
+	}}
+	if len(p.updates) != 1 || !reflect.DeepEqual(p.updates[0], want) {
+		t.Fatalf("updates = %+v, want %+v", p.updates, want)
+	}
+	if m.GetStatusMessage() != "Description updated" || m.workItem.Description != want.Fields[provider.FieldDescription] {
+		t.Errorf("status %q, description %q", m.GetStatusMessage(), m.workItem.Description)
+	}
+}
+
+func TestDetailModel_EditDescriptionFields(t *testing.T) {
+	e := stubEditor(t)
+	e.reply = "Steps **here**"
+
+	bug := newTestWI(7, "T", "Active", "Bug")
+	bug.ReproSteps = "<div>Crash</div>"
+	m, p := newEditorModel(bug)
+	editInEditor(m, "d")
+	if got := p.updates[0].Fields; got[provider.FieldReproSteps] != "<p>Steps <strong>here</strong></p>" {
+		t.Errorf("a bug's repro steps should be edited, got %v", got)
+	}
+
+	empty := newTestWI(9, "T", "New", "Bug")
+	empty.Description = "<p>Filed as a description</p>"
+	m, p = newEditorModel(empty)
+	editInEditor(m, "d")
+	if e.opened != "" {
+		t.Errorf("a bug without repro steps should open them empty, got %q", e.opened)
+	}
+	if got := p.updates[0].Fields; len(got) != 1 || got[provider.FieldReproSteps] == "" {
+		t.Errorf("a bug's repro steps should be edited even when empty, got %v", got)
+	}
+
+	issue := newTestWI(8, "T", "open", "Bug")
+	issue.Identity.Kind = provider.KindGitHub
+	issue.Description = "Old *body*"
+	m, p = newEditorModel(issue)
+	editInEditor(m, "d")
+	if e.opened != "Old *body*\n" || p.updates[0].Fields[provider.FieldDescription] != "Steps **here**" {
+		t.Errorf("GitHub markdown should round-trip as is: opened %q, sent %v", e.opened, p.updates[0].Fields)
+	}
+}
+
+func TestDetailModel_EditDescriptionUnchanged(t *testing.T) {
+	e := stubEditor(t)
+	item := newTestWI(7, "T", "Active", "Task")
+	item.Description = "<p>Same</p>"
+	m, p := newEditorModel(item)
+
+	e.reply = "Same\n"
+	m = editInEditor(m, "d")
+	if len(p.updates) != 0 || m.GetStatusMessage() != "Description unchanged" {
+		t.Errorf("updates %+v, status %q", p.updates, m.GetStatusMessage())
+	}
+}
+
+func TestDetailModel_EditDescriptionConflictMerges(t *testing.T) {
+	e := stubEditor(t)
+	item := newTestWI(7, "T", "Active", "Task")
+	item.Rev = 3
+	item.Description = "<p>Base</p>"
+	m, p := newEditorModel(item)
+
+	e.reply = "Mine"
+	e.meanwhile = func() {
+		p.item.Rev = 4
+		p.item.Description = "<p>Theirs</p>"
+	}
+	m = editInEditor(m, "d")
+	if len(p.updates) != 0 {
+		t.Fatalf("a changed description must not be overwritten, got %+v", p.updates)
+	}
+	if !strings.Contains(m.GetStatusMessage(), "press d to merge") || m.workItem.Description != "<p>Theirs</p>" {
+		t.Fatalf("status %q, description %q", m.GetStatusMessage(), m.workItem.Description)
+	}
+
+	e.meanwhile = nil
+	e.reply = mergeNote + "\n\nMine and theirs\n\n" + theirsNote + "\n"
+	m = editInEditor(m, "d")
+	if want := mergeNote + "\n\nMine\n\n" + theirsNote + "\n\nTheirs\n"; e.opened != want {
+		t.Errorf("merge opened\n%q\nwant\n%q", e.opened, want)
+	}
+	if len(p.updates) != 1 || p.updates[0].Rev != 4 || p.updates[0].Fields[provider.FieldDescription] != "<p>Mine and theirs</p>" {
+		t.Fatalf("updates = %+v", p.updates)
+	}
+	if m.GetStatusMessage() != "Description updated" || m.descriptionDraft != "" {
+		t.Errorf("status %q, draft %q", m.GetStatusMessage(), m.descriptionDraft)
+	}
+}
+
+func TestDetailModel_CommentInEditor(t *testing.T) {
+	e := stubEditor(t)
+	m, p := newEditorModel(newTestWI(7, "T", "Active", "Task"))
+
+	e.reply = "\n\n"
+	m = editInEditor(m, "C")
+	if len(p.comments) != 0 || m.GetStatusMessage() != "Empty comment discarded" {
+		t.Fatalf("comments %q, status %q", p.comments, m.GetStatusMessage())
+	}
+
+	e.reply = "**Done**, see `log`\n"
+	m = editInEditor(m, "C")
+	if !reflect.DeepEqual(p.comments, []string{"**Done**, see `log`"}) {
+		t.Fatalf("comments = %q, want the markdown as written", p.comments)
+	}
+	if m.GetStatusMessage() != "Comment added" || m.commentDraft != "" {
+		t.Errorf("status %q, draft %q", m.GetStatusMessage(), m.commentDraft)
+	}
+}
+
+func TestDetailModel_MarkdownCommentsRenderAsMarkdown(t *testing.T) {
+	m, _ := newEditorModel(newTestWI(7, "T", "Active", "Task"))
+	m, _ = m.Update(commentsLoadedMsg{comments: []provider.WorkItemComment{
+		{ID: 1, Text: "# Heading\n\n**bold**", Markdown: true, AuthorName: "Ann"},
+	}})
+	view := m.View()
+	if strings.Contains(view, "# Heading") || strings.Contains(view, "**bold**") || !strings.Contains(view, "Heading") {
+		t.Errorf("markdown comment should be rendered:\n%s", view)
+	}
+}
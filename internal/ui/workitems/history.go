@@ -0,0 +1,292 @@
+package workitems
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Elpulgo/azdo/internal/provider"
+	"github.com/Elpulgo/azdo/internal/ui/styles"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// historyLoadedMsg is sent when the revision history of the work item has
+// been fetched
+type historyLoadedMsg struct {
+	revisions []provider.WorkItemRevision
+	err       error
+}
+
+// historyView is the overlay listing the revisions of a work item, newest
+// first. f and a cycle through the changed fields and the authors to show
+// only their changes; x clears both filters.
+type historyView struct {
+	styles    *styles.Styles
+	client    provider.Provider
+	visible   bool
+	width     int
+	height    int
+	item      provider.WorkItem
+	revisions []provider.WorkItemRevision // newest first
+	loading   bool
+	err       error
+	field     string // only changes of this field are shown, if set
+	author    string // only revisions by this author are shown, if set
+	offset    int    // first line shown
+}
+
+func newHistoryView(client provider.Provider, s *styles.Styles) historyView {
+	return historyView{styles: s, client: client}
+}
+
+// Show opens the overlay on item and returns the command fetching its
+// history. The history is fetched anew each time so it includes changes
+// made since the detail view opened.
+func (h *historyView) Show(item provider.WorkItem) tea.Cmd {
+	h.item = item
+	h.revisions = nil
+	h.err = nil
+	h.field, h.author = "", ""
+	h.offset = 0
+	h.loading = true
+	h.visible = true
+
+	client := h.client
+	return func() tea.Msg {
+		if client == nil {
+			return historyLoadedMsg{err: fmt.Errorf("no client available")}
+		}
+		revisions, err := client.GetWorkItemHistory(item.Identity.Scope, workItemNumericID(item))
+		return historyLoadedMsg{revisions: revisions, err: err}
+	}
+}
+
+// Hide closes the overlay.
+func (h *historyView) Hide() {
+	h.visible = false
+}
+
+// IsVisible returns whether the overlay is open.
+func (h historyView) IsVisible() bool {
+	return h.visible
+}
+
+// SetSize sets the area the overlay fills.
+func (h *historyView) SetSize(width, height int) {
+	h.width = width
+	h.height = height
+}
+
+// handleLoaded stores the fetched history, newest revision first.
+func (h *historyView) handleLoaded(msg historyLoadedMsg) {
+	h.loading = false
+	h.err = msg.err
+	h.revisions = slices.Clone(msg.revisions)
+	slices.Reverse(h.revisions)
+}
+
+// fields returns the names of the fields the history changes, sorted.
+func (h historyView) fields() []string {
+	var fields []string
+	for _, rev := range h.revisions {
+		for _, c := range rev.Changes {
+			if !slices.Contains(fields, c.Field) {
+				fields = append(fields, c.Field)
+			}
+		}
+	}
+	slices.Sort(fields)
+	return fields
+}
+
+// authors returns the names of the authors of the history, sorted.
+func (h historyView) authors() []string {
+	var authors []string
+	for _, rev := range h.revisions {
+		if rev.AuthorName != "" && !slices.Contains(authors, rev.AuthorName) {
+			authors = append(authors, rev.AuthorName)
+		}
+	}
+	slices.Sort(authors)
+	return authors
+}
+
+// filtered returns the revisions matching the filters, holding only the
+// changes of the filtered field. Revisions left without changes are
+// dropped.
+func (h historyView) filtered() []provider.WorkItemRevision {
+	var revisions []provider.WorkItemRevision
+	for _, rev := range h.revisions {
+		if h.author != "" && rev.AuthorName != h.author {
+			continue
+		}
+		if h.field != "" {
+			var changes []provider.WorkItemChange
+			for _, c := range rev.Changes {
+				if c.Field == h.field {
+					changes = append(changes, c)
+				}
+			}
+			if len(changes) == 0 {
+				continue
+			}
+			rev.Changes = changes
+		}
+		revisions = append(revisions, rev)
+	}
+	return revisions
+}
+
+// nextOption returns the option after current, cycling from "" (no
+// filter) through options and back to "".
+func nextOption(options []string, current string) string {
+	i := slices.Index(options, current)
+	if i+1 >= len(options) {
+		return ""
+	}
+	return options[i+1]
+}
+
+// Update handles the overlay's keys.
+func (h historyView) Update(msg tea.KeyMsg) (historyView, tea.Cmd) {
+	if !h.visible {
+		return h, nil
+	}
+	switch msg.String() {
+	case "esc", "H":
+		h.Hide()
+	case "f":
+		h.field = nextOption(h.fields(), h.field)
+		h.offset = 0
+	case "a":
+		h.author = nextOption(h.authors(), h.author)
+		h.offset = 0
+	case "x":
+		h.field, h.author = "", ""
+		h.offset = 0
+	case "up", "k":
+		h.scroll(-1)
+	case "down", "j":
+		h.scroll(1)
+	case "pgup":
+		h.scroll(-h.bodyHeight() / 2)
+	case "pgdown":
+		h.scroll(h.bodyHeight() / 2)
+	}
+	return h, nil
+}
+
+// scroll moves the first shown line by delta, keeping the last page full.
+func (h *historyView) scroll(delta int) {
+	h.offset = max(0, min(h.offset+delta, len(h.lines())-h.bodyHeight()))
+}
+
+// bodyHeight is the number of history lines that fit: the area less the
+// title, filter and help lines and the blank lines between them.
+func (h historyView) bodyHeight() int {
+	return max(1, h.height-5)
+}
+
+// lines renders the filtered history, a header line per revision followed
+// by a line per change.
+func (h historyView) lines() []string {
+	width := max(20, h.width)
+	var lines []string
+	for _, rev := range h.filtered() {
+		header := rev.AuthorName
+		if header == "" {
+			header = "Unknown"
+		}
+		if !rev.Date.IsZero() {
+			header += " · " + rev.Date.Local().Format("2006-01-02 15:04")
+		}
+		if rev.Rev > 0 {
+			header += fmt.Sprintf(" (rev %d)", rev.Rev)
+		}
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, h.styles.Label.Render(ansi.Truncate(header, width, "…")))
+		for _, c := range rev.Changes {
+			lines = append(lines, ansi.Truncate("  "+h.changeText(c), width, "…"))
+		}
+	}
+	return lines
+}
+
+// changeText renders a change on one line. Comments show their first
+// line; values set or cleared read "+ value" and "− value".
+func (h historyView) changeText(c provider.WorkItemChange) string {
+	old, new := h.valueText(c.Old), h.valueText(c.New)
+	switch {
+	case c.Kind == provider.ChangeComment:
+		text, _, _ := strings.Cut(new, "\n")
+		return "Comment: " + text
+	case old == "":
+		return c.Field + ": " + h.styles.DiffAdded.Render("+ "+new)
+	case new == "":
+		return c.Field + ": " + h.styles.DiffRemoved.Render("− "+old)
+	}
+	return fmt.Sprintf("%s: %s → %s", c.Field, old, new)
+}
+
+// valueText reduces a field value to a line of plain text. Azure DevOps
+// stores rich text fields and comments as HTML.
+func (h historyView) valueText(value string) string {
+	if h.item.Identity.Kind != provider.KindGitHub {
+		value = stripHTMLTags(value)
+	}
+	lines := strings.Split(strings.TrimSpace(value), "\n")
+	for i, line := range lines {
+		lines[i] = strings.Join(strings.Fields(line), " ")
+	}
+	return strings.Join(slices.DeleteFunc(lines, func(l string) bool { return l == "" }), "\n")
+}
+
+// View renders the overlay.
+func (h historyView) View() string {
+	if !h.visible {
+		return ""
+	}
+	title := lipgloss.NewStyle().
+		Foreground(h.styles.Theme.GetPrimary()).
+		Bold(true).
+		Render(fmt.Sprintf("History of #%s", h.item.Identity.ID))
+
+	filter := "All changes"
+	if h.field != "" || h.author != "" {
+		var parts []string
+		if h.field != "" {
+			parts = append(parts, "field: "+h.field)
+		}
+		if h.author != "" {
+			parts = append(parts, "author: "+h.author)
+		}
+		filter = "Showing " + strings.Join(parts, ", ")
+	}
+
+	var body string
+	switch {
+	case h.loading:
+		body = h.styles.Muted.Render("Loading history...")
+	case h.err != nil:
+		body = h.styles.Error.Render(fmt.Sprintf("Could not load history: %v", h.err))
+	default:
+		lines := h.lines()
+		if len(lines) == 0 {
+			body = h.styles.Muted.Render("No changes")
+			break
+		}
+		start := min(h.offset, len(lines))
+		body = strings.Join(lines[start:min(start+h.bodyHeight(), len(lines))], "\n")
+	}
+
+	help := lipgloss.NewStyle().
+		Foreground(h.styles.Theme.GetForegroundMuted()).
+		Render("↑/↓: scroll • f: field • a: author • x: clear filters • esc: close")
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		title, h.styles.Muted.Render(filter), "", body, "", help)
+}
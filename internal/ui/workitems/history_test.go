@@ -0,0 +1,140 @@
+package workitems
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Elpulgo/azdo/internal/provider"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// historyProvider serves a fixed history for every work item; every other
+// method panics via the nil embedded interface.
+type historyProvider struct {
+	provider.Provider
+	history []provider.WorkItemRevision
+	err     error
+	fetches int
+}
+
+func (p *historyProvider) SearchPeople(scope, query string) ([]provider.Person, error) {
+	return nil, nil
+}
+
+func (p *historyProvider) WorkItemURL(scope string, id int) string { return "" }
+
+func (p *historyProvider) GetWorkItemHistory(scope string, id int) ([]provider.WorkItemRevision, error) {
+	p.fetches++
+	return p.history, p.err
+}
+
+// sampleHistory is an item created by Ada, then moved and commented on by
+// Bob, who also linked a parent.
+func sampleHistory() []provider.WorkItemRevision {
+	day := func(d int) time.Time { return time.Date(2026, 10, d, 9, 0, 0, 0, time.Local) }
+	return []provider.WorkItemRevision{
+		{Rev: 1, AuthorName: "Ada", Date: day(1), Changes: []provider.WorkItemChange{
+			{Kind: provider.ChangeField, Field: "State", New: "New"},
+			{Kind: provider.ChangeField, Field: "Title", New: "Crash"},
+		}},
+		{Rev: 2, AuthorName: "Bob", Date: day(2), Changes: []provider.WorkItemChange{
+			{Kind: provider.ChangeField, Field: "State", Old: "New", New: "Active"},
+			{Kind: provider.ChangeComment, Field: "Comment", New: "<div>On it</div><div>More tomorrow</div>"},
+		}},
+		{Rev: 3, AuthorName: "Bob", Date: day(3), Changes: []provider.WorkItemChange{
+			{Kind: provider.ChangeLink, Field: "Links", New: "Parent #3"},
+		}},
+	}
+}
+
+// openHistory opens the history of a work item and feeds back the fetch.
+func openHistory(t *testing.T, p *historyProvider) *DetailModel {
+	t.Helper()
+	m := NewDetailModel(p, newWI(7, "Crash", "Active", "Bug"))
+	m.SetSize(100, 40)
+	m, cmd := m.Update(keyRunes("H"))
+	if cmd == nil {
+		t.Fatal("H should fetch the history")
+	}
+	m, _ = m.Update(cmd())
+	return m
+}
+
+func TestHistory_ShowsRevisionsNewestFirst(t *testing.T) {
+	m := openHistory(t, &historyProvider{history: sampleHistory()})
+
+	view := m.View()
+	wants := []string{
+		"History of #7",
+		"Bob · 2026-10-03 09:00 (rev 3)", "Links: + Parent #3",
+		"Bob · 2026-10-02 09:00 (rev 2)", "State: New → Active", "Comment: On it",
+		"Ada · 2026-10-01 09:00 (rev 1)", "Title: + Crash",
+	}
+	last := -1
+	for _, want := range wants {
+		i := strings.Index(view, want)
+		if i < 0 {
+			t.Fatalf("history should show %q:\n%s", want, view)
+		}
+		if i < last {
+			t.Errorf("%q is out of order:\n%s", want, view)
+		}
+		last = i
+	}
+	if strings.Contains(view, "More tomorrow") {
+		t.Errorf("a comment should show its first line only:\n%s", view)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if strings.Contains(m.View(), "History of #7") {
+		t.Error("esc should close the history")
+	}
+}
+
+func TestHistory_FiltersByFieldAndAuthor(t *testing.T) {
+	m := openHistory(t, &historyProvider{history: sampleHistory()})
+
+	// Fields cycle in order: Comment, Links, State.
+	m, _ = m.Update(keyRunes("f"))
+	m, _ = m.Update(keyRunes("f"))
+	m, _ = m.Update(keyRunes("f"))
+	view := m.View()
+	if !strings.Contains(view, "Showing field: State") || !strings.Contains(view, "State: New → Active") || !strings.Contains(view, "State: + New") {
+		t.Errorf("the State filter should show both state changes:\n%s", view)
+	}
+	if strings.Contains(view, "Title") || strings.Contains(view, "Comment:") || strings.Contains(view, "(rev 3)") {
+		t.Errorf("the State filter should hide other changes and revisions without state changes:\n%s", view)
+	}
+
+	m, _ = m.Update(keyRunes("a"))
+	view = m.View()
+	if !strings.Contains(view, "field: State, author: Ada") || strings.Contains(view, "Active") {
+		t.Errorf("filtering by Ada should leave her state change only:\n%s", view)
+	}
+
+	m, _ = m.Update(keyRunes("x"))
+	if view = m.View(); !strings.Contains(view, "All changes") || !strings.Contains(view, "Parent #3") {
+		t.Errorf("x should clear the filters:\n%s", view)
+	}
+}
+
+func TestHistory_RefetchesAndShowsErrors(t *testing.T) {
+	p := &historyProvider{err: errors.New("boom")}
+	m := openHistory(t, p)
+	if view := m.View(); !strings.Contains(view, "Could not load history: boom") {
+		t.Errorf("view = %q", view)
+	}
+
+	p.err = nil
+	m, _ = m.Update(keyRunes("H"))
+	m, cmd := m.Update(keyRunes("H"))
+	m, _ = m.Update(cmd())
+	if p.fetches != 2 {
+		t.Errorf("fetches = %d, want the history fetched on every open", p.fetches)
+	}
+	if view := m.View(); !strings.Contains(view, "No changes") {
+		t.Errorf("view = %q", view)
+	}
+}
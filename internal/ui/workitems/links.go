@@ -0,0 +1,343 @@
+package workitems
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Elpulgo/azdo/internal/provider"
+	"github.com/Elpulgo/azdo/internal/ui/styles"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// linkSections are the link types in the order the detail lists them.
+var linkSections = []struct {
+	link  provider.WorkItemLinkType
+	title string
+}{
+	{provider.LinkParent, "Parent"},
+	{provider.LinkChild, "Children"},
+	{provider.LinkRelated, "Related"},
+}
+
+// linksLoadedMsg is sent when the links of the work item have been fetched
+type linksLoadedMsg struct {
+	links []provider.WorkItemLink
+	err   error
+}
+
+// linkChangedMsg is sent when a link was added or removed
+type linkChangedMsg struct {
+	message string
+	err     error
+}
+
+// fetchLinks fetches the parent, children and related work items.
+func (m *DetailModel) fetchLinks() tea.Cmd {
+	client := m.client
+	wi := m.workItem
+	return func() tea.Msg {
+		if client == nil {
+			return linksLoadedMsg{err: fmt.Errorf("no client available")}
+		}
+		links, err := client.GetWorkItemLinks(wi.Identity.Scope, workItemNumericID(wi))
+		return linksLoadedMsg{links: links, err: err}
+	}
+}
+
+// writeLinks appends the parent, children and related sections to the
+// viewport content. Nothing is written for an item without links.
+func (m *DetailModel) writeLinks(sb *strings.Builder) {
+	if m.linksErr != nil {
+		sb.WriteString(m.styles.Muted.Render(fmt.Sprintf("Could not load links: %v", m.linksErr)))
+		sb.WriteString("\n\n")
+		return
+	}
+	for _, section := range linkSections {
+		var items []provider.WorkItem
+		for _, l := range m.links {
+			if l.Type == section.link {
+				items = append(items, l.Item)
+			}
+		}
+		if len(items) == 0 {
+			continue
+		}
+		title := section.title
+		if section.link != provider.LinkParent {
+			title = fmt.Sprintf("%s (%d)", title, len(items))
+		}
+		sb.WriteString(m.styles.Label.Render(title))
+		sb.WriteString("\n")
+		for _, wi := range items {
+			sb.WriteString("  ")
+			sb.WriteString(m.linkLine(wi))
+			sb.WriteString("\n")
+		}
+		sb.WriteString("\n")
+	}
+}
+
+// linkLine renders a linked work item on one line: type, ID, title and
+// state.
+func (m *DetailModel) linkLine(wi provider.WorkItem) string {
+	return fmt.Sprintf("%s #%s %s · %s", typeIconWithStyles(wi.ItemKind, m.styles), wi.Identity.ID, wi.Title,
+		stateTextWithStyles(wi.StateCategory, wi.State, m.styles))
+}
+
+// linkForm is the modal managing the links of a work item. ↑/↓ move
+// between the links and the add row at the bottom; d removes the selected
+// link after a y/n confirmation. On the add row ←/→ choose the link type,
+// the target's ID is typed and enter adds the link.
+type linkForm struct {
+	styles  *styles.Styles
+	client  provider.Provider
+	visible bool
+	width   int
+	height  int
+	item    provider.WorkItem
+	links   []provider.WorkItemLink
+	types   []provider.WorkItemLinkType // the link types that can be added
+	cursor  int                         // index into links; len(links) is the add row
+	addType int                         // index into types
+	input   textinput.Model
+	confirm bool // removal of links[cursor] awaits y/n
+	err     string
+	busy    bool
+}
+
+func newLinkForm(client provider.Provider, s *styles.Styles) linkForm {
+	ti := textinput.New()
+	ti.Prompt = "#"
+	ti.Placeholder = "work item ID"
+	ti.CharLimit = 12
+	return linkForm{styles: s, client: client, input: ti}
+}
+
+// Show opens the form on item and its links, with the add row selected.
+// GitHub has no related links, so only parents and children are offered
+// there.
+func (f *linkForm) Show(item provider.WorkItem, links []provider.WorkItemLink) tea.Cmd {
+	f.item = item
+	f.types = []provider.WorkItemLinkType{provider.LinkChild, provider.LinkParent, provider.LinkRelated}
+	if item.Identity.Kind == provider.KindGitHub {
+		f.types = f.types[:2]
+	}
+	f.addType = 0
+	f.input.SetValue("")
+	f.confirm = false
+	f.err = ""
+	f.busy = false
+	f.visible = true
+	f.setLinks(links)
+	f.cursor = len(f.links)
+	return f.input.Focus()
+}
+
+// Hide closes the form.
+func (f *linkForm) Hide() {
+	f.visible = false
+	f.input.Blur()
+}
+
+// IsVisible returns whether the form is open.
+func (f linkForm) IsVisible() bool {
+	return f.visible
+}
+
+// SetSize sets the area the form is centered in.
+func (f *linkForm) SetSize(width, height int) {
+	f.width = width
+	f.height = height
+}
+
+// setLinks replaces the listed links, keeping the cursor in range and on
+// the add row when it was there.
+func (f *linkForm) setLinks(links []provider.WorkItemLink) {
+	onAdd := f.cursor == len(f.links)
+	f.links = links
+	f.cursor = min(f.cursor, len(f.links))
+	if onAdd {
+		f.cursor = len(f.links)
+	}
+}
+
+// handleChanged records the outcome of an add or remove.
+func (f *linkForm) handleChanged(msg linkChangedMsg) {
+	f.busy = false
+	if msg.err != nil {
+		f.err = linkErrorText(msg.err)
+		return
+	}
+	f.err = ""
+	f.input.SetValue("")
+}
+
+// linkErrorText describes a failed link change.
+func linkErrorText(err error) string {
+	switch {
+	case errors.Is(err, provider.ErrLinkUnsupported):
+		return "This link type is not supported here"
+	case errors.Is(err, provider.ErrWorkItemConflict):
+		return "The links changed meanwhile; try again"
+	}
+	return fmt.Sprintf("Error: %v", err)
+}
+
+// change returns the command adding or removing a link to targetID.
+func (f *linkForm) change(add bool, link provider.WorkItemLinkType, targetID int) tea.Cmd {
+	if f.client == nil || f.busy {
+		return nil
+	}
+	f.busy = true
+	f.err = ""
+	client, wi := f.client, f.item
+	return func() tea.Msg {
+		scope, id := wi.Identity.Scope, workItemNumericID(wi)
+		if add {
+			if err := client.AddWorkItemLink(scope, id, link, targetID); err != nil {
+				return linkChangedMsg{err: err}
+			}
+			return linkChangedMsg{message: fmt.Sprintf("Linked #%d as %s", targetID, link)}
+		}
+		if err := client.RemoveWorkItemLink(scope, id, link, targetID); err != nil {
+			return linkChangedMsg{err: err}
+		}
+		return linkChangedMsg{message: fmt.Sprintf("Removed %s link to #%d", link, targetID)}
+	}
+}
+
+// submitAdd validates the add row and returns the command adding the link.
+func (f *linkForm) submitAdd() tea.Cmd {
+	id, err := strconv.Atoi(strings.TrimPrefix(strings.TrimSpace(f.input.Value()), "#"))
+	if err != nil || id <= 0 {
+		f.err = "Enter the ID of the work item to link"
+		return nil
+	}
+	if id == workItemNumericID(f.item) {
+		f.err = "A work item cannot link to itself"
+		return nil
+	}
+	return f.change(true, f.types[f.addType], id)
+}
+
+// Update handles the form's keys.
+func (f linkForm) Update(msg tea.KeyMsg) (linkForm, tea.Cmd) {
+	if !f.visible {
+		return f, nil
+	}
+	if f.confirm {
+		f.confirm = false
+		if msg.String() == "y" && f.cursor < len(f.links) {
+			l := f.links[f.cursor]
+			id, _ := strconv.Atoi(l.Item.Identity.ID)
+			return f, f.change(false, l.Type, id)
+		}
+		return f, nil
+	}
+
+	onAdd := f.cursor == len(f.links)
+	switch msg.String() {
+	case "esc":
+		f.Hide()
+		return f, nil
+	case "up", "shift+tab":
+		f.cursor = (f.cursor + len(f.links)) % (len(f.links) + 1)
+	case "down", "tab":
+		f.cursor = (f.cursor + 1) % (len(f.links) + 1)
+	case "left":
+		if onAdd {
+			f.addType = (f.addType + len(f.types) - 1) % len(f.types)
+		}
+		return f, nil
+	case "right":
+		if onAdd {
+			f.addType = (f.addType + 1) % len(f.types)
+		}
+		return f, nil
+	case "enter":
+		if onAdd {
+			return f, f.submitAdd()
+		}
+		return f, nil
+	case "d", "delete":
+		if !onAdd {
+			f.confirm = true
+			return f, nil
+		}
+	}
+
+	if f.cursor == len(f.links) {
+		if !onAdd {
+			return f, f.input.Focus()
+		}
+		var cmd tea.Cmd
+		f.input, cmd = f.input.Update(msg)
+		return f, cmd
+	}
+	f.input.Blur()
+	return f, nil
+}
+
+// View renders the form centered in its area.
+func (f linkForm) View() string {
+	if !f.visible {
+		return ""
+	}
+	const width = 72
+
+	var rows []string
+	if len(f.links) == 0 {
+		rows = append(rows, f.styles.Muted.Render("  No links yet"))
+	}
+	for i, l := range f.links {
+		cursor := "  "
+		if i == f.cursor {
+			cursor = "> "
+		}
+		line := fmt.Sprintf("%s%-9s #%s %s · %s", cursor, l.Type, l.Item.Identity.ID, l.Item.Title, l.Item.State)
+		style := lipgloss.NewStyle().Width(width).Foreground(f.styles.Theme.GetForeground())
+		if i == f.cursor {
+			style = style.Foreground(f.styles.Theme.GetSelectForeground()).Background(f.styles.Theme.GetSelectBackground())
+		}
+		rows = append(rows, style.MaxWidth(width).Render(line))
+	}
+	cursor := "  "
+	if f.cursor == len(f.links) {
+		cursor = "> "
+	}
+	rows = append(rows, "", cursor+"Add ‹ "+string(f.types[f.addType])+" › "+f.input.View())
+
+	title := lipgloss.NewStyle().
+		Foreground(f.styles.Theme.GetPrimary()).
+		Bold(true).
+		Render(fmt.Sprintf("Links of #%s", f.item.Identity.ID))
+	status := ""
+	switch {
+	case f.busy:
+		status = f.styles.Muted.Render("Saving...")
+	case f.confirm:
+		status = f.styles.Warning.Render("Remove this link? (y/n)")
+	case f.err != "":
+		status = f.styles.Error.Render(f.err)
+	}
+	help := lipgloss.NewStyle().
+		Foreground(f.styles.Theme.GetForegroundMuted()).
+		Render("↑/↓: move • d: remove • ←/→: link type • enter: add • esc: close")
+
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		title, "", strings.Join(rows, "\n"), "", status, help)
+	modal := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(f.styles.Theme.GetBorder()).
+		Padding(1, 2).
+		Render(content)
+
+	if f.width > 0 && f.height > 0 {
+		modal = lipgloss.Place(f.width, f.height, lipgloss.Center, lipgloss.Center, modal)
+	}
+	return modal
+}
@@ -0,0 +1,143 @@
+package workitems
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Elpulgo/azdo/internal/provider"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// linkProvider keeps the links of one work item in memory; every other
+// method panics via the nil embedded interface.
+type linkProvider struct {
+	provider.Provider
+	links []provider.WorkItemLink
+	calls []string
+}
+
+func (p *linkProvider) SearchPeople(scope, query string) ([]provider.Person, error) {
+	return nil, nil
+}
+
+func (p *linkProvider) WorkItemURL(scope string, id int) string { return "" }
+
+func (p *linkProvider) GetWorkItemComments(scope string, id int) ([]provider.WorkItemComment, error) {
+	return nil, nil
+}
+
+func (p *linkProvider) GetWorkItemLinks(scope string, id int) ([]provider.WorkItemLink, error) {
+	return p.links, nil
+}
+
+func (p *linkProvider) GetWorkItemAttachments(scope string, id int) ([]provider.WorkItemAttachment, error) {
+	return nil, nil
+}
+
+func (p *linkProvider) AddWorkItemLink(scope string, id int, link provider.WorkItemLinkType, targetID int) error {
+	p.calls = append(p.calls, fmt.Sprintf("add %s %d", link, targetID))
+	if link == provider.LinkRelated {
+		return provider.ErrLinkUnsupported
+	}
+	target := newWI(targetID, fmt.Sprintf("Item %d", targetID), "New", "Task")
+	p.links = append(p.links, provider.WorkItemLink{Type: link, Item: target})
+	return nil
+}
+
+func (p *linkProvider) RemoveWorkItemLink(scope string, id int, link provider.WorkItemLinkType, targetID int) error {
+	p.calls = append(p.calls, fmt.Sprintf("remove %s %d", link, targetID))
+	for i, l := range p.links {
+		if l.Type == link && l.Item.Identity.ID == fmt.Sprint(targetID) {
+			p.links = append(p.links[:i], p.links[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func TestDetail_ShowsLinkSections(t *testing.T) {
+	p := &linkProvider{links: []provider.WorkItemLink{
+		{Type: provider.LinkParent, Item: newWI(1, "The epic", "Active", "Epic")},
+		{Type: provider.LinkChild, Item: newWI(21, "First task", "New", "Task")},
+		{Type: provider.LinkChild, Item: newWI(22, "Second task", "Closed", "Task")},
+	}}
+	m := NewDetailModel(p, newWI(10, "Story", "Active", "User Story"))
+	m.SetSize(100, 40)
+	m = runBatch(m, m.Init())
+
+	content := m.View()
+	for _, want := range []string{"Parent", "#1 The epic", "Children (2)", "#21 First task", "#22 Second task"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("detail should show %q:\n%s", want, content)
+		}
+	}
+	if strings.Contains(content, "Related") {
+		t.Errorf("an item without related links should not show the section:\n%s", content)
+	}
+}
+
+func TestLinkForm_AddAndRemove(t *testing.T) {
+	p := &linkProvider{links: []provider.WorkItemLink{
+		{Type: provider.LinkParent, Item: newWI(1, "The epic", "Active", "Epic")},
+	}}
+	m := NewDetailModel(p, newWI(10, "Story", "Active", "User Story"))
+	m.SetSize(100, 40)
+	m = runBatch(m, m.Init())
+
+	m, _ = m.Update(keyRunes("L"))
+	if !m.linkForm.IsVisible() || !m.capturesInput() {
+		t.Fatal("L should open the link form")
+	}
+
+	// The add row is selected with "child" as the link type.
+	for _, r := range "#22" {
+		m, _ = m.Update(keyRunes(string(r)))
+	}
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m, cmd = m.Update(cmd())
+	m = runBatch(m, cmd) // refetch the links
+	if len(p.calls) != 1 || p.calls[0] != "add child 22" {
+		t.Fatalf("calls = %v, want [add child 22]", p.calls)
+	}
+	if m.statusMessage != "Linked #22 as child" || len(m.links) != 2 || m.linkForm.input.Value() != "" {
+		t.Errorf("after add: status %q, links %d, input %q", m.statusMessage, len(m.links), m.linkForm.input.Value())
+	}
+
+	// Remove the parent: move up to it, press d and confirm.
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyUp})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyUp})
+	m, _ = m.Update(keyRunes("d"))
+	if !strings.Contains(m.View(), "Remove this link? (y/n)") {
+		t.Fatalf("d should ask for confirmation:\n%s", m.View())
+	}
+	m, cmd = m.Update(keyRunes("y"))
+	m, cmd = m.Update(cmd())
+	m = runBatch(m, cmd)
+	if p.calls[len(p.calls)-1] != "remove parent 1" || len(m.links) != 1 {
+		t.Errorf("calls = %v, links = %+v", p.calls, m.links)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.linkForm.IsVisible() {
+		t.Error("esc should close the link form")
+	}
+}
+
+func TestLinkForm_ShowsUnsupportedLinkType(t *testing.T) {
+	p := &linkProvider{}
+	m := NewDetailModel(p, newWI(10, "Story", "Active", "User Story"))
+	m.SetSize(100, 40)
+	m, _ = m.Update(keyRunes("L"))
+
+	// child → parent → related
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	m, _ = m.Update(keyRunes("5"))
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = runBatch(m, cmd)
+
+	if !strings.Contains(m.View(), "not supported") || !m.linkForm.IsVisible() {
+		t.Errorf("an unsupported link should be reported in the open form:\n%s", m.View())
+	}
+}
@@ -41,6 +41,15 @@ type Model struct {
 	activeState string
 	tagPicker   components.TagPicker
 	statePicker components.ListPicker
+	createForm  createForm
+	queryPicker queryPicker
+	tree        *hierarchy      // tree mode; shared with the row renderer
+	query       *querySelection // the listed query; shared with the fetch and the row renderer
+	board       boardView
+	sprint      sprintView
+
+	// statusMessage reports the outcome of a create on the list.
+	statusMessage string
 
 	// pendingDetailID is the work-item ID requested by startup state
 	// restore. Cleared on first populate so polling can't re-trigger it.
@@ -68,6 +77,10 @@ var wiBaseColumns = []listview.ColumnSpec{
 func NewModelWithStyles(client provider.Provider, s *styles.Styles) Model {
 	isMulti := client != nil && client.IsMultiProject()
 
+	// A query with columns of its own shows them in place of the state,
+	// prio and assigned columns.
+	query := &querySelection{}
+
 	// toColumns derives column specs from the current items, mirroring the
 	// cell gating in workItemsToRows / workItemsToRowsMulti exactly:
 	//   [glyph?] [project?] [type] [id] [title] [state] [prio] [assigned]
@@ -78,8 +91,7 @@ func NewModelWithStyles(client provider.Provider, s *styles.Styles) Model {
 		}
 		mixed := display.MixedKinds(kinds)
 
-		cols := make([]listview.ColumnSpec, len(wiBaseColumns))
-		copy(cols, wiBaseColumns)
+		cols := query.columnSpecs(wiBaseColumns)
 
 		if isMulti {
 			cols = append([]listview.ColumnSpec{{Title: "Project", WidthPct: 10, MinWidth: 8}}, cols...)
@@ -92,9 +104,22 @@ func NewModelWithStyles(client provider.Provider, s *styles.Styles) Model {
 		return cols
 	}
 
-	toRows := workItemsToRows
+	baseRows := workItemsToRows
 	if isMulti {
-		toRows = workItemsToRowsMulti
+		baseRows = workItemsToRowsMulti
+	}
+	// In tree mode titles are indented by their depth in the hierarchy.
+	tree := newHierarchy()
+	toRows := func(items []provider.WorkItem, s *styles.Styles) []table.Row {
+		rows := baseRows(items, s)
+		for i, wi := range items {
+			if prefix := tree.titlePrefix(wi); prefix != "" {
+				title := len(rows[i]) - 4 // [title] [state] [prio] [assigned]
+				rows[i][title] = prefix + rows[i][title]
+			}
+			rows[i] = query.replaceCells(rows[i], wi, s)
+		}
+		return rows
 	}
 
 	filterFunc := filterWorkItem
@@ -109,7 +134,7 @@ func NewModelWithStyles(client provider.Provider, s *styles.Styles) Model {
 		ToRows:         toRows,
 		ToColumns:      toColumns,
 		Fetch: func() tea.Cmd {
-			return fetchWorkItems(client)
+			return query.fetch(client)
 		},
 		EnterDetail: func(item provider.WorkItem, st *styles.Styles, w, h int) (listview.DetailView, tea.Cmd) {
 			d := NewDetailModelWithStyles(client, item, st)
@@ -130,9 +155,22 @@ func NewModelWithStyles(client provider.Provider, s *styles.Styles) Model {
 		styles:      s,
 		tagPicker:   components.NewTagPicker(s),
 		statePicker: components.NewListPicker(s),
+		createForm:  newCreateForm(client, s),
+		queryPicker: newQueryPicker(client, s),
+		tree:        tree,
+		query:       query,
+		board:       newBoardView(s),
+		sprint:      newSprintView(s),
 	}
 }
 
+// WithQueries sets the queries from the config offered by the query
+// picker next to the saved queries of each project.
+func (m Model) WithQueries(queries []provider.WorkItemQuery) Model {
+	m.queryPicker.configured = queries
+	return m
+}
+
 // Init initializes the model
 func (m Model) Init() tea.Cmd {
 	return m.list.Init()
@@ -142,15 +180,25 @@ func (m Model) Init() tea.Cmd {
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case workItemsMsg:
+		if msg.query != m.query.key() {
+			// The result of a query that is no longer selected
+			return m, nil
+		}
+		if msg.fromQuery {
+			m.query.columns = msg.columns
+		}
+		if m.query.applyMode {
+			m.query.applyMode = false
+			m.tree.enabled = msg.tree
+		}
 		if msg.err != nil {
 			// For partial errors, treat data as valid (some projects succeeded)
-			var partialErr *azdevops.PartialError
-			if errors.As(msg.err, &partialErr) {
+			if isPartialError(msg.err) {
 				m.allItems = msg.workItems
 				if m.myItemsOnly {
 					return m, fetchMyWorkItems(m.client)
 				}
-				m.list = m.list.HandleFetchResult(msg.workItems, nil)
+				m.list = m.list.HandleFetchResult(m.tree.arrange(msg.workItems), nil)
 				return m.withRestore(nil)
 			}
 
@@ -160,7 +208,7 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 				m.list = m.list.HandleFetchResult(nil, nil)
 				return m, criticalCmd
 			}
-			m.list = m.list.HandleFetchResult(msg.workItems, msg.err)
+			m.list = m.list.HandleFetchResult(m.tree.arrange(msg.workItems), msg.err)
 			return m.withRestore(nil)
 		}
 		m.allItems = msg.workItems
@@ -168,33 +216,73 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			// Chain to my-items fetch so loading state is eventually cleared
 			return m, fetchMyWorkItems(m.client)
 		}
-		m.list = m.list.HandleFetchResult(msg.workItems, nil)
+		m.list = m.list.HandleFetchResult(m.tree.arrange(msg.workItems), nil)
 		return m.withRestore(nil)
 	case myWorkItemsMsg:
 		if msg.err != nil {
 			// For partial errors, use partial data as valid
-			var partialErr *azdevops.PartialError
-			if errors.As(msg.err, &partialErr) {
+			if isPartialError(msg.err) {
 				m.myItems = msg.workItems
-				m.list = m.list.SetItems(m.applyAllFilters(msg.workItems))
+				m.list = m.list.SetItems(m.display(msg.workItems))
 				return m.withRestore(nil)
 			}
 			// On error, fall back to showing all items and clear loading state
 			m.myItemsOnly = false
 			m.myItems = nil
-			m.list = m.list.SetItems(m.applyAllFilters(m.allItems))
+			m.list = m.list.SetItems(m.display(m.allItems))
 			return m.withRestore(nil)
 		}
 		m.myItems = msg.workItems
-		m.list = m.list.SetItems(m.applyAllFilters(msg.workItems))
+		m.list = m.list.SetItems(m.display(msg.workItems))
 		return m.withRestore(nil)
-	case WorkItemStateChangedMsg:
-		// Re-fetch work items so the list reflects the updated state
-		return m, fetchWorkItems(m.client)
+	case workItemTypesMsg:
+		m.createForm.handleTypes(msg)
+		return m, nil
+	case workItemCreatedMsg:
+		m.createForm.handleCreated(msg)
+		if msg.err != nil {
+			return m, nil
+		}
+		m.statusMessage = fmt.Sprintf("Created %s #%s", msg.item.WorkItemType, msg.item.Identity.ID)
+		return m, m.query.fetch(m.client)
+	case WorkItemStateChangedMsg, WorkItemUpdatedMsg:
+		// Re-fetch work items so the list reflects the updated state or fields
+		if m.sprint.enabled {
+			return m, tea.Batch(m.query.fetch(m.client), m.sprint.reload(m.client))
+		}
+		return m, m.query.fetch(m.client)
+	case savedQueriesMsg:
+		m.queryPicker.handleSaved(msg)
+		return m, nil
+	case boardsMsg:
+		m.board.handleBoards(msg, m.boardItems())
+		return m, nil
+	case boardMovedMsg:
+		if msg.err != nil {
+			m.statusMessage = fmt.Sprintf("Could not move #%s: %v", msg.item.Identity.ID, msg.err)
+			return m, nil
+		}
+		m.statusMessage = fmt.Sprintf("Moved #%s to %s", msg.item.Identity.ID, msg.column)
+		m.board.follow(msg.item, msg.column)
+		return m, m.query.fetch(m.client)
+	case sprintsMsg:
+		return m, m.sprint.handleSprints(m.client, msg)
+	case sprintBacklogMsg:
+		m.sprint.handleBacklog(msg)
+		return m, nil
+	case tea.WindowSizeMsg:
+		m.board.width, m.board.height = msg.Width, msg.Height
+		m.sprint.width, m.sprint.height = msg.Width, msg.Height
+	case querySelectedMsg:
+		return m.selectQuery(msg.query)
 	case SetWorkItemsMsg:
+		if m.query.query != nil {
+			// Polled items are the default list, not the query's results
+			return m, nil
+		}
 		m.allItems = msg.WorkItems
 		if !m.myItemsOnly {
-			m.list = m.list.SetItems(m.applyAllFilters(msg.WorkItems))
+			m.list = m.list.SetItems(m.display(msg.WorkItems))
 			return m.withRestore(nil)
 		}
 		return m, nil
@@ -202,15 +290,30 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		m.activeTag = msg.Tag
 		m.tagPicker.Hide()
 		// Re-apply filters on the appropriate base set
-		m.list = m.list.SetItems(m.applyAllFilters(m.getBaseItems()))
+		m.list = m.list.SetItems(m.display(m.getBaseItems()))
 		return m, nil
 	case components.ListPickerSelectedMsg:
 		m.activeState = msg.Value
 		m.statePicker.Hide()
 		// Re-apply filters on the appropriate base set
-		m.list = m.list.SetItems(m.applyAllFilters(m.getBaseItems()))
+		m.list = m.list.SetItems(m.display(m.getBaseItems()))
 		return m, nil
 	case tea.KeyMsg:
+		if m.createForm.IsVisible() {
+			var cmd tea.Cmd
+			m.createForm, cmd = m.createForm.Update(msg)
+			return m, cmd
+		}
+		if m.queryPicker.IsVisible() {
+			var cmd tea.Cmd
+			m.queryPicker, cmd = m.queryPicker.Update(msg)
+			return m, cmd
+		}
+		m.statusMessage = ""
+		if msg.String() == "n" && !m.list.IsSearching() && m.GetViewMode() == ViewList && m.client != nil &&
+			!m.tagPicker.IsVisible() && !m.statePicker.IsVisible() {
+			return m, m.createForm.Show()
+		}
 		// When a picker modal is open, forward all keystrokes to it below so
 		// characters like T/m/s can be typed into the picker's search input.
 		pickerOpen := m.tagPicker.IsVisible() || m.statePicker.IsVisible()
@@ -221,6 +324,19 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 				m.tagPicker.Show()
 				return m, nil
 			}
+			if msg.String() == "H" && !m.list.IsSearching() && m.GetViewMode() == ViewList && m.tree != nil {
+				return m.toggleTree(), nil
+			}
+			if msg.String() == " " && m.IsTreeMode() && !m.list.IsSearching() && m.GetViewMode() == ViewList {
+				return m.toggleSelectedNode(), nil
+			}
+			if msg.String() == "Q" && !m.list.IsSearching() && m.GetViewMode() == ViewList {
+				return m, m.queryPicker.Show(m.query.key())
+			}
+			if msg.String() == "m" && !m.list.IsSearching() && m.GetViewMode() == ViewList && m.query.query != nil {
+				m.statusMessage = "My items filters the default list; use @Me in a query instead"
+				return m, nil
+			}
 			if msg.String() == "m" && !m.list.IsSearching() && m.GetViewMode() == ViewList {
 				m.myItemsOnly = !m.myItemsOnly
 				if m.myItemsOnly {
@@ -228,7 +344,7 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 				}
 				// Toggle OFF: restore all items (with filters if active)
 				m.myItems = nil
-				m.list = m.list.SetItems(m.applyAllFilters(m.allItems))
+				m.list = m.list.SetItems(m.display(m.allItems))
 				return m, nil
 			}
 			// esc clears an active "my items" filter, mirroring how esc exits
@@ -237,7 +353,7 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			if msg.String() == "esc" && !m.list.IsSearching() && m.GetViewMode() == ViewList && m.myItemsOnly {
 				m.myItemsOnly = false
 				m.myItems = nil
-				m.list = m.list.SetItems(m.applyAllFilters(m.allItems))
+				m.list = m.list.SetItems(m.display(m.allItems))
 				return m, nil
 			}
 			if msg.String() == "s" && !m.list.IsSearching() && m.GetViewMode() == ViewList {
@@ -250,6 +366,18 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 				m.statePicker.Show()
 				return m, nil
 			}
+			if msg.String() == "B" && !m.list.IsSearching() && m.GetViewMode() == ViewList {
+				return m.toggleBoard()
+			}
+			if m.IsBoardMode() {
+				return m.updateBoard(msg)
+			}
+			if msg.String() == "S" && !m.list.IsSearching() && m.GetViewMode() == ViewList && !m.sprint.enabled {
+				return m.toggleSprint()
+			}
+			if m.IsSprintMode() {
+				return m.updateSprint(msg)
+			}
 		}
 	}
 
@@ -276,11 +404,12 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	// When in detail view, intercept esc to check for modals first
 	if m.GetViewMode() == ViewDetail {
 		if kmsg, ok := msg.(tea.KeyMsg); ok && kmsg.String() == "esc" {
-			// If the detail view has a modal/form open (state picker or comment
-			// form), route esc directly to the detail model to close it, bypassing
-			// the listview which would otherwise close the entire detail view.
+			// If the detail view has a modal/form open (state picker, comment
+			// form, reaction picker or delete confirmation), route esc directly
+			// to the detail model to close it, bypassing the listview which
+			// would otherwise close the entire detail view.
 			if adapter, ok := m.list.Detail().(*detailAdapter); ok {
-				if adapter.model.statePicker.IsVisible() || adapter.model.commentForm.IsVisible() {
+				if adapter.model.capturesInput() {
 					var cmd tea.Cmd
 					adapter.model, cmd = adapter.model.Update(msg)
 					return m, cmd
@@ -296,6 +425,12 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 
 // View renders the view
 func (m Model) View() string {
+	if m.IsBoardMode() {
+		return m.board.View(m.boardItems())
+	}
+	if m.IsSprintMode() {
+		return m.sprint.View()
+	}
 	return m.list.View()
 }
 
@@ -316,7 +451,10 @@ func (m Model) GetScrollPercent() float64 {
 
 // GetStatusMessage returns the status message for the current view
 func (m Model) GetStatusMessage() string {
-	return m.list.GetStatusMessage()
+	if msg := m.list.GetStatusMessage(); msg != "" || m.GetViewMode() != ViewList {
+		return msg
+	}
+	return m.statusMessage
 }
 
 // HasContextBar returns true if the current view should show a context bar
@@ -335,14 +473,16 @@ func (m Model) IsMyItemsActive() bool {
 }
 
 // IsCommentFormVisible reports whether the work item detail view currently has
-// its comment form open. Used by the app to suppress global shortcuts so
-// keystrokes reach the form's textarea.
+// its comment form, field edit form, reaction picker or delete confirmation
+// open. Used by the app to suppress global shortcuts so keystrokes reach them.
 func (m Model) IsCommentFormVisible() bool {
 	if m.GetViewMode() != ViewDetail {
 		return false
 	}
 	if adapter, ok := m.list.Detail().(*detailAdapter); ok {
-		return adapter.model.commentForm.IsVisible()
+		return adapter.model.commentForm.IsVisible() || adapter.model.reactionPicker.IsVisible() ||
+			adapter.model.pendingDeleteID > 0 || adapter.model.editForm.IsVisible() ||
+			adapter.model.linkForm.IsVisible()
 	}
 	return false
 }
@@ -460,6 +600,62 @@ func (m *Model) SetStatePickerSize(width, height int) {
 	m.statePicker.SetSize(width, height)
 }
 
+// IsQueryPickerVisible returns true while the query picker is open.
+func (m Model) IsQueryPickerVisible() bool {
+	return m.queryPicker.IsVisible()
+}
+
+// QueryPickerView renders the query picker.
+func (m Model) QueryPickerView() string {
+	return m.queryPicker.View()
+}
+
+// SetQueryPickerSize sets the area the query picker is centered in.
+func (m *Model) SetQueryPickerSize(width, height int) {
+	m.queryPicker.SetSize(width, height)
+}
+
+// ActiveQuery returns the name of the query the list shows, or "" for the
+// default list.
+func (m Model) ActiveQuery() string {
+	if m.query.query == nil {
+		return ""
+	}
+	return m.query.query.Name
+}
+
+// selectQuery makes the list show query, or the default list when query is
+// nil. Filters of the previous list are cleared.
+func (m Model) selectQuery(query *provider.WorkItemQuery) (Model, tea.Cmd) {
+	m.query.query = query
+	m.query.columns = nil
+	m.query.applyMode = true
+	m.myItemsOnly = false
+	m.myItems = nil
+	m.activeTag = ""
+	m.activeState = ""
+	m.allItems = nil
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Reload()
+	m.list.SetCursor(0)
+	return m, cmd
+}
+
+// IsCreateFormVisible returns true while the create form is open.
+func (m Model) IsCreateFormVisible() bool {
+	return m.createForm.IsVisible()
+}
+
+// CreateFormView renders the create form.
+func (m Model) CreateFormView() string {
+	return m.createForm.View()
+}
+
+// SetCreateFormSize sets the area the create form is centered in.
+func (m *Model) SetCreateFormSize(width, height int) {
+	m.createForm.SetSize(width, height)
+}
+
 // getBaseItems returns the appropriate base items (allItems or myItems)
 func (m Model) getBaseItems() []provider.WorkItem {
 	if m.myItemsOnly {
@@ -468,6 +664,39 @@ func (m Model) getBaseItems() []provider.WorkItem {
 	return m.allItems
 }
 
+// display returns the given items as the list shows them: filtered, and
+// in tree order in tree mode.
+func (m Model) display(items []provider.WorkItem) []provider.WorkItem {
+	return m.tree.arrange(m.applyAllFilters(items))
+}
+
+// toggleTree switches between the flat list and the hierarchy tree.
+func (m Model) toggleTree() Model {
+	m.tree.enabled = !m.tree.enabled
+	m.list = m.list.SetItems(m.display(m.getBaseItems()))
+	m.list.SetCursor(0)
+	return m
+}
+
+// toggleSelectedNode expands or collapses the selected item in tree mode,
+// keeping it selected.
+func (m Model) toggleSelectedNode() Model {
+	items := m.list.Items()
+	idx := m.list.SelectedIndex()
+	if idx < 0 || idx >= len(items) || !m.tree.toggle(items[idx]) {
+		return m
+	}
+	key := nodeKey(items[idx])
+	m.list = m.list.SetItems(m.display(m.getBaseItems()))
+	m.list.SetCursor(m.list.FindIndex(func(wi provider.WorkItem) bool { return nodeKey(wi) == key }))
+	return m
+}
+
+// IsTreeMode returns true while the list shows the hierarchy tree.
+func (m Model) IsTreeMode() bool {
+	return m.tree != nil && m.tree.enabled
+}
+
 // applyAllFilters applies tag and state filters to the given items.
 func (m Model) applyAllFilters(items []provider.WorkItem) []provider.WorkItem {
 	result := applyTagFilter(items, m.activeTag)
@@ -616,6 +845,13 @@ type WorkItemStateChangedMsg struct{}
 type workItemsMsg struct {
 	workItems []provider.WorkItem
 	err       error
+
+	// Set for query results: the key of the query, its columns and
+	// whether it is a tree query.
+	query     string
+	fromQuery bool
+	columns   []provider.WorkItemQueryColumn
+	tree      bool
 }
 
 type myWorkItemsMsg struct {
@@ -628,6 +864,14 @@ type SetWorkItemsMsg struct {
 	WorkItems []provider.WorkItem
 }
 
+// isPartialError reports whether err is a partial failure of a fetch over
+// several projects, whose data is still valid.
+func isPartialError(err error) bool {
+	var azPartial *azdevops.PartialError
+	var partial *provider.PartialError
+	return errors.As(err, &azPartial) || errors.As(err, &partial)
+}
+
 // fetchWorkItems fetches work items from all projects via the provider.
 func fetchWorkItems(client provider.Provider) tea.Cmd {
 	return func() tea.Msg {
@@ -0,0 +1,201 @@
+package workitems
+
+import (
+	"errors"
+
+	"github.com/Elpulgo/azdo/internal/provider"
+	"github.com/Elpulgo/azdo/internal/ui/components/listview"
+	"github.com/Elpulgo/azdo/internal/ui/components/table"
+	"github.com/Elpulgo/azdo/internal/ui/styles"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// querySelection is the query the list shows. It is shared by pointer with
+// the fetch and the row renderer, which show the query's columns.
+type querySelection struct {
+	query   *provider.WorkItemQuery // nil shows the default list of open work items
+	columns []provider.WorkItemQueryColumn
+	// applyMode makes the next result switch the list to the query's
+	// flat or tree mode; later refreshes keep the user's choice.
+	applyMode bool
+}
+
+// key identifies the selected query, so results of a previous selection
+// can be told apart.
+func (q *querySelection) key() string {
+	if q == nil || q.query == nil {
+		return ""
+	}
+	return queryKey(*q.query)
+}
+
+// queryKey identifies a query: saved queries by ID, configured ones by
+// name.
+func queryKey(q provider.WorkItemQuery) string {
+	if q.ID != "" {
+		return q.Scope + "/" + q.ID
+	}
+	return "config/" + q.Name
+}
+
+// fixedQueryFields are shown by the Type, ID and Title columns the list
+// always has, so query columns on them are skipped.
+var fixedQueryFields = map[string]bool{
+	"System.Id":           true,
+	"System.Title":        true,
+	"System.WorkItemType": true,
+}
+
+// extraColumns returns the query's columns the list shows in place of the
+// State, Prio and Assigned columns.
+func (q *querySelection) extraColumns() []provider.WorkItemQueryColumn {
+	if q == nil || q.query == nil {
+		return nil
+	}
+	var cols []provider.WorkItemQueryColumn
+	for _, c := range q.columns {
+		if !fixedQueryFields[c.Field] {
+			cols = append(cols, c)
+		}
+	}
+	return cols
+}
+
+// columnSpecs replaces the last three of cols, State, Prio and Assigned,
+// with the query's columns. cols is returned as it is when the query has
+// no columns of its own.
+func (q *querySelection) columnSpecs(cols []listview.ColumnSpec) []listview.ColumnSpec {
+	extra := q.extraColumns()
+	if len(extra) == 0 {
+		return cols
+	}
+	pct := max(42/len(extra), 6)
+	cols = append([]listview.ColumnSpec{}, cols[:len(cols)-3]...)
+	for _, c := range extra {
+		cols = append(cols, listview.ColumnSpec{Title: c.Name, WidthPct: pct, MinWidth: 8})
+	}
+	return cols
+}
+
+// replaceCells replaces the State, Prio and Assigned cells of row with the
+// values of the query's columns for wi.
+func (q *querySelection) replaceCells(row table.Row, wi provider.WorkItem, s *styles.Styles) table.Row {
+	extra := q.extraColumns()
+	if len(extra) == 0 {
+		return row
+	}
+	row = append(table.Row{}, row[:len(row)-3]...)
+	for _, c := range extra {
+		row = append(row, queryCell(wi, c.Field, s))
+	}
+	return row
+}
+
+// queryCell renders the value of field for wi. The fields the list knows
+// are styled as in the default columns.
+func queryCell(wi provider.WorkItem, field string, s *styles.Styles) string {
+	switch field {
+	case "System.State":
+		return stateTextWithStyles(wi.StateCategory, wi.State, s)
+	case "Microsoft.VSTS.Common.Priority":
+		return priorityTextWithStyles(wi.Priority, s)
+	case "System.AssignedTo":
+		if wi.AssignedToName != "" {
+			return wi.AssignedToName
+		}
+	default:
+		if v := wi.Fields[field]; v != "" {
+			return v
+		}
+	}
+	return "-"
+}
+
+// fetch returns the command fetching the list: the selected query's
+// results, or the default list when no query is selected.
+func (q *querySelection) fetch(client provider.Provider) tea.Cmd {
+	if q == nil || q.query == nil {
+		return fetchWorkItems(client)
+	}
+	return runQuery(client, *q.query)
+}
+
+// runQuery runs query in its project, or in every project when it names
+// none. Scopes whose backend cannot run queries are skipped.
+func runQuery(client provider.Provider, query provider.WorkItemQuery) tea.Cmd {
+	key := queryKey(query)
+	return func() tea.Msg {
+		if client == nil {
+			return workItemsMsg{query: key}
+		}
+		scopes := client.Scopes()
+		if query.Scope != "" {
+			scopes = []string{query.Scope}
+		}
+
+		msg := workItemsMsg{query: key, fromQuery: true}
+		var errs []error
+		ran := 0
+		for _, scope := range scopes {
+			result, err := client.RunWorkItemQuery(scope, query)
+			if errors.Is(err, provider.ErrQueriesUnsupported) {
+				continue
+			}
+			ran++
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			msg.workItems = append(msg.workItems, result.Items...)
+			if msg.columns == nil {
+				msg.columns = result.Columns
+			}
+			msg.tree = msg.tree || result.Tree
+		}
+		switch {
+		case ran == 0:
+			msg.err = provider.ErrQueriesUnsupported
+		case len(errs) == ran:
+			msg.err = errs[0]
+		case len(errs) > 0:
+			msg.err = &provider.PartialError{Failed: len(errs), Total: ran, Errors: errs}
+		}
+		return msg
+	}
+}
+
+// savedQueriesMsg is sent when the saved queries of every project have
+// been listed
+type savedQueriesMsg struct {
+	queries []provider.WorkItemQuery
+	err     error
+}
+
+// fetchSavedQueries lists the saved queries of every scope whose backend
+// has them.
+func fetchSavedQueries(client provider.Provider) tea.Cmd {
+	return func() tea.Msg {
+		if client == nil {
+			return savedQueriesMsg{}
+		}
+		var msg savedQueriesMsg
+		for _, scope := range client.Scopes() {
+			queries, err := client.ListWorkItemQueries(scope)
+			if errors.Is(err, provider.ErrQueriesUnsupported) {
+				continue
+			}
+			if err != nil {
+				msg.err = err
+				continue
+			}
+			msg.queries = append(msg.queries, queries...)
+		}
+		return msg
+	}
+}
+
+// querySelectedMsg is sent when a query is picked. A nil query selects the
+// default list.
+type querySelectedMsg struct {
+	query *provider.WorkItemQuery
+}
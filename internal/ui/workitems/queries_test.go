@@ -0,0 +1,152 @@
+package workitems
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Elpulgo/azdo/internal/provider"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// queryProvider serves one saved query in the Azure scope "proj"; the
+// GitHub scope "o/r" has no queries. Every other method panics via the nil
+// embedded interface.
+type queryProvider struct {
+	provider.Provider
+	ran []string
+}
+
+func (p *queryProvider) Scopes() []string     { return []string{"proj", "o/r"} }
+func (p *queryProvider) IsMultiProject() bool { return false }
+
+func (p *queryProvider) ListWorkItemQueries(scope string) ([]provider.WorkItemQuery, error) {
+	if scope != "proj" {
+		return nil, provider.ErrQueriesUnsupported
+	}
+	return []provider.WorkItemQuery{
+		{ID: "q1", Name: "Epics", Path: "Shared Queries/Epics", Scope: "proj", Tree: true},
+	}, nil
+}
+
+func (p *queryProvider) RunWorkItemQuery(scope string, query provider.WorkItemQuery) (*provider.WorkItemQueryResult, error) {
+	if scope != "proj" {
+		return nil, provider.ErrQueriesUnsupported
+	}
+	p.ran = append(p.ran, scope+" "+query.Name)
+	epic := newWI(1, "Epic", "Active", "Epic")
+	epic.Fields = map[string]string{"Microsoft.VSTS.Scheduling.StoryPoints": "13"}
+	story := childOf(newWI(2, "Story", "New", "User Story"), 1)
+	return &provider.WorkItemQueryResult{
+		Items: []provider.WorkItem{epic, story},
+		Columns: []provider.WorkItemQueryColumn{
+			{Field: "System.Id", Name: "ID"},
+			{Field: "System.State", Name: "State"},
+			{Field: "Microsoft.VSTS.Scheduling.StoryPoints", Name: "Story Points"},
+		},
+		Tree: query.Tree,
+	}, nil
+}
+
+// pickQuery opens the query picker, lists the saved queries and runs the
+// entry the search matches.
+func pickQuery(t *testing.T, m Model, p *queryProvider, search string) Model {
+	t.Helper()
+	m, _ = m.Update(keyRunes("Q"))
+	if !m.IsQueryPickerVisible() {
+		t.Fatal("Q should open the query picker")
+	}
+	m, _ = m.Update(fetchSavedQueries(p)())
+	for _, r := range search {
+		m, _ = m.Update(keyRunes(string(r)))
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("enter should select the query")
+	}
+	msg, ok := cmd().(querySelectedMsg)
+	if !ok || msg.query == nil {
+		t.Fatalf("enter sent %#v, want a query", msg)
+	}
+	m, _ = m.Update(msg)
+	m, _ = m.Update(runQuery(p, *msg.query)())
+	return m
+}
+
+func TestQueryPicker_ListsConfiguredAndSavedQueries(t *testing.T) {
+	p := &queryProvider{}
+	picker := newQueryPicker(p, NewModel(nil).styles)
+	picker.configured = []provider.WorkItemQuery{{Name: "My bugs"}}
+	picker.Show("")
+	picker.handleSaved(fetchSavedQueries(p)().(savedQueriesMsg))
+
+	var got []string
+	for _, e := range picker.visibleEntries() {
+		got = append(got, e.group+"|"+e.label)
+	}
+	want := []string{"|Default (open work items)", "Configured|My bugs", "Shared Queries|Epics"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("entries = %v, want %v", got, want)
+	}
+	if picker.err != nil {
+		t.Errorf("unsupported scopes should be skipped, got %v", picker.err)
+	}
+
+	picker, _ = picker.Update(keyRunes("e"))
+	picker, _ = picker.Update(keyRunes("p"))
+	got = nil
+	for _, e := range picker.visibleEntries() {
+		got = append(got, e.label)
+	}
+	if !reflect.DeepEqual(got, []string{"Default (open work items)", "Epics"}) {
+		t.Errorf("search entries = %v, want the default list and Epics", got)
+	}
+}
+
+func TestQuery_SavedTreeQueryShowsColumnsAndTree(t *testing.T) {
+	p := &queryProvider{}
+	m := NewModel(p)
+	m.list, _ = m.list.Update(tea.WindowSizeMsg{Width: 120, Height: 30})
+
+	m = pickQuery(t, m, p, "Epics")
+	if m.ActiveQuery() != "Epics" {
+		t.Errorf("ActiveQuery() = %q, want Epics", m.ActiveQuery())
+	}
+	if !reflect.DeepEqual(p.ran, []string{"proj Epics"}) {
+		t.Errorf("ran = %v, want the query once in its project", p.ran)
+	}
+	if !m.IsTreeMode() {
+		t.Error("a tree query should switch the list to tree mode")
+	}
+	view := m.View()
+	for _, want := range []string{"Story Points", "13", "▼ Epic"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view should contain %q:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "Assigned") {
+		t.Errorf("query columns should replace the default columns:\n%s", view)
+	}
+}
+
+func TestQuery_IgnoresResultsOfPreviousSelection(t *testing.T) {
+	p := &queryProvider{}
+	m := NewModel(p)
+	m.list, _ = m.list.Update(tea.WindowSizeMsg{Width: 120, Height: 30})
+	m = pickQuery(t, m, p, "Epics")
+
+	m, _ = m.Update(workItemsMsg{workItems: []provider.WorkItem{newWI(9, "Default item", "New", "Task")}})
+	if len(m.list.Items()) != 2 {
+		t.Errorf("the default list's result should be ignored while a query is selected, got %d items", len(m.list.Items()))
+	}
+}
+
+func TestRunQuery_AllScopesUnsupported(t *testing.T) {
+	p := &queryProvider{}
+	msg := runQuery(p, provider.WorkItemQuery{Name: "Elsewhere", Scope: "o/r"})().(workItemsMsg)
+	if !errors.Is(msg.err, provider.ErrQueriesUnsupported) {
+		t.Errorf("err = %v, want ErrQueriesUnsupported", msg.err)
+	}
+}
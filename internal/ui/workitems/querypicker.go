@@ -0,0 +1,245 @@
+package workitems
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Elpulgo/azdo/internal/provider"
+	"github.com/Elpulgo/azdo/internal/ui/styles"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// queryEntry is a choice of the query picker.
+type queryEntry struct {
+	label string
+	group string                  // "Configured", "My Queries" or "Shared Queries"
+	query *provider.WorkItemQuery // nil for the default list
+}
+
+// queryPicker is the modal choosing the query the list shows: the default
+// list, the queries of the config and the saved queries of each project,
+// which are listed the first time the picker opens.
+type queryPicker struct {
+	styles     *styles.Styles
+	client     provider.Provider
+	visible    bool
+	width      int
+	height     int
+	configured []provider.WorkItemQuery
+	saved      []provider.WorkItemQuery
+	loading    bool
+	loaded     bool
+	err        error
+	active     string // key of the selected query
+	cursor     int    // index into visibleEntries
+	input      textinput.Model
+}
+
+func newQueryPicker(client provider.Provider, s *styles.Styles) queryPicker {
+	ti := textinput.New()
+	ti.Prompt = "🔍 "
+	ti.Placeholder = "search queries..."
+	ti.CharLimit = 100
+	return queryPicker{styles: s, client: client, input: ti}
+}
+
+// Show opens the picker with the active query selected. The saved queries
+// are fetched on the first call.
+func (p *queryPicker) Show(active string) tea.Cmd {
+	p.active = active
+	p.input.SetValue("")
+	p.visible = true
+	p.selectActive()
+	cmds := []tea.Cmd{p.input.Focus()}
+	if !p.loaded && !p.loading && p.client != nil {
+		p.loading = true
+		cmds = append(cmds, fetchSavedQueries(p.client))
+	}
+	return tea.Batch(cmds...)
+}
+
+// Hide closes the picker.
+func (p *queryPicker) Hide() {
+	p.visible = false
+	p.input.Blur()
+}
+
+// IsVisible returns whether the picker is open.
+func (p queryPicker) IsVisible() bool {
+	return p.visible
+}
+
+// SetSize sets the area the picker is centered in.
+func (p *queryPicker) SetSize(width, height int) {
+	p.width = width
+	p.height = height
+}
+
+// handleSaved records the listed saved queries. Queries of the projects
+// that could be listed are kept when others failed.
+func (p *queryPicker) handleSaved(msg savedQueriesMsg) {
+	p.loading = false
+	p.loaded = msg.err == nil
+	p.err = msg.err
+	p.saved = msg.queries
+	p.selectActive()
+}
+
+// selectActive moves the cursor to the active query.
+func (p *queryPicker) selectActive() {
+	p.cursor = 0
+	for i, e := range p.visibleEntries() {
+		if e.query != nil && queryKey(*e.query) == p.active {
+			p.cursor = i
+		}
+	}
+}
+
+// entries returns every choice: the default list, the configured queries
+// and the saved queries. Saved queries are labeled by their path, prefixed
+// with the project when several projects are configured.
+func (p queryPicker) entries() []queryEntry {
+	entries := []queryEntry{{label: "Default (open work items)"}}
+	for i := range p.configured {
+		q := &p.configured[i]
+		entries = append(entries, queryEntry{label: q.Name, group: "Configured", query: q})
+	}
+	multi := p.client != nil && p.client.IsMultiProject()
+	for i := range p.saved {
+		q := &p.saved[i]
+		group, label, _ := strings.Cut(q.Path, "/")
+		if label == "" {
+			group, label = "Saved Queries", q.Name
+		}
+		if multi {
+			label = q.Scope + ": " + label
+		}
+		entries = append(entries, queryEntry{label: label, group: group, query: q})
+	}
+	return entries
+}
+
+// visibleEntries returns the entries matching the search input. The
+// default list is always kept so the query can be cleared while searching.
+func (p queryPicker) visibleEntries() []queryEntry {
+	search := strings.ToLower(strings.TrimSpace(p.input.Value()))
+	entries := p.entries()
+	if search == "" {
+		return entries
+	}
+	visible := []queryEntry{entries[0]}
+	for _, e := range entries[1:] {
+		if strings.Contains(strings.ToLower(e.label), search) {
+			visible = append(visible, e)
+		}
+	}
+	return visible
+}
+
+// Update handles the picker's keys. enter emits querySelectedMsg.
+func (p queryPicker) Update(msg tea.KeyMsg) (queryPicker, tea.Cmd) {
+	if !p.visible {
+		return p, nil
+	}
+	entries := p.visibleEntries()
+	switch msg.String() {
+	case "esc":
+		p.Hide()
+		return p, nil
+	case "up":
+		if p.cursor > 0 {
+			p.cursor--
+		}
+		return p, nil
+	case "down":
+		if p.cursor < len(entries)-1 {
+			p.cursor++
+		}
+		return p, nil
+	case "enter":
+		if p.cursor >= len(entries) {
+			return p, nil
+		}
+		query := entries[p.cursor].query
+		p.Hide()
+		return p, func() tea.Msg { return querySelectedMsg{query: query} }
+	}
+
+	prev := p.input.Value()
+	var cmd tea.Cmd
+	p.input, cmd = p.input.Update(msg)
+	if p.input.Value() != prev {
+		p.cursor = 0
+	}
+	return p, cmd
+}
+
+// View renders the picker centered in its area, with the queries grouped
+// by folder.
+func (p queryPicker) View() string {
+	if !p.visible {
+		return ""
+	}
+	const width = 64
+	const maxRows = 15
+
+	entries := p.visibleEntries()
+	start := max(0, min(p.cursor-maxRows/2, len(entries)-maxRows))
+	end := min(len(entries), start+maxRows)
+
+	var rows []string
+	group := ""
+	for i := start; i < end; i++ {
+		e := entries[i]
+		if e.group != group || (i == start && e.group != "") {
+			group = e.group
+			rows = append(rows, p.styles.Label.Render(group))
+		}
+		cursor, icon := "  ", "○"
+		if i == p.cursor {
+			cursor = "> "
+		}
+		if (e.query == nil && p.active == "") || (e.query != nil && queryKey(*e.query) == p.active) {
+			icon = "●"
+		}
+		if e.query != nil && e.query.Tree {
+			icon += " ⊢"
+		}
+		style := lipgloss.NewStyle().Width(width).Foreground(p.styles.Theme.GetForeground())
+		if i == p.cursor {
+			style = style.Foreground(p.styles.Theme.GetSelectForeground()).Background(p.styles.Theme.GetSelectBackground())
+		}
+		rows = append(rows, style.MaxWidth(width).Render(fmt.Sprintf("%s%s %s", cursor, icon, e.label)))
+	}
+
+	status := ""
+	switch {
+	case p.loading:
+		status = p.styles.Muted.Render("Loading saved queries...")
+	case p.err != nil:
+		status = p.styles.Error.Render(fmt.Sprintf("Could not list saved queries: %v", p.err))
+	}
+
+	title := lipgloss.NewStyle().
+		Foreground(p.styles.Theme.GetPrimary()).
+		Bold(true).
+		Render("Work Item Queries")
+	help := lipgloss.NewStyle().
+		Foreground(p.styles.Theme.GetForegroundMuted()).
+		Render("type to search • ↑/↓: navigate • enter: run • esc: cancel")
+
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		title, "", p.input.View(), "", strings.Join(rows, "\n"), "", status, help)
+	modal := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(p.styles.Theme.GetBorder()).
+		Padding(1, 2).
+		Render(content)
+
+	if p.width > 0 && p.height > 0 {
+		modal = lipgloss.Place(p.width, p.height, lipgloss.Center, lipgloss.Center, modal)
+	}
+	return modal
+}
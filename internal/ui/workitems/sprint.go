@@ -0,0 +1,518 @@
+package workitems
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Elpulgo/azdo/internal/provider"
+	"github.com/Elpulgo/azdo/internal/ui/styles"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// sprintView is the sprint mode of the work items tab: the items planned
+// into one sprint of a project, grouped by state or assignee, with the
+// remaining work set against the team's capacity. Sprints are fetched per
+// project the first time the project is shown, and each sprint's backlog
+// the first time the sprint is.
+type sprintView struct {
+	styles     *styles.Styles
+	enabled    bool
+	width      int
+	height     int
+	scope      string                       // the project shown
+	sprints    map[string][]provider.Sprint // by project, once fetched
+	index      map[string]int               // the sprint shown, by project
+	backlogs   map[string]*provider.SprintBacklog
+	loading    map[string]bool  // by project and by sprintKey
+	errs       map[string]error // by project and by sprintKey
+	byAssignee bool             // group by assignee instead of state
+	row        int              // selected item, counted over all groups
+}
+
+func newSprintView(s *styles.Styles) sprintView {
+	return sprintView{
+		styles:   s,
+		sprints:  map[string][]provider.Sprint{},
+		index:    map[string]int{},
+		backlogs: map[string]*provider.SprintBacklog{},
+		loading:  map[string]bool{},
+		errs:     map[string]error{},
+	}
+}
+
+// sprintKey identifies the backlog of a sprint of a project.
+func sprintKey(scope, sprintID string) string {
+	return scope + "/" + sprintID
+}
+
+// sprint returns the sprint shown, or nil while the project's sprints are
+// not fetched or when it has none.
+func (v *sprintView) sprint() *provider.Sprint {
+	sprints := v.sprints[v.scope]
+	if len(sprints) == 0 {
+		return nil
+	}
+	return &sprints[min(v.index[v.scope], len(sprints)-1)]
+}
+
+// backlog returns the backlog of the sprint shown, or nil while it is not
+// fetched.
+func (v *sprintView) backlog() *provider.SprintBacklog {
+	sprint := v.sprint()
+	if sprint == nil {
+		return nil
+	}
+	return v.backlogs[sprintKey(v.scope, sprint.ID)]
+}
+
+// show switches to the sprints of scope, fetching them the first time.
+func (v *sprintView) show(client provider.Provider, scope string) tea.Cmd {
+	v.scope = scope
+	v.row = 0
+	if client == nil || v.loading[scope] {
+		return nil
+	}
+	if v.sprints[scope] != nil {
+		return v.fetchBacklog(client)
+	}
+	v.loading[scope] = true
+	delete(v.errs, scope)
+	return fetchSprints(client, scope)
+}
+
+// handleSprints records the fetched sprints of a project, shows the
+// current one and fetches its backlog.
+func (v *sprintView) handleSprints(client provider.Provider, msg sprintsMsg) tea.Cmd {
+	v.loading[msg.scope] = false
+	if msg.err != nil {
+		v.errs[msg.scope] = msg.err
+		return nil
+	}
+	if msg.sprints == nil {
+		msg.sprints = []provider.Sprint{}
+	}
+	v.sprints[msg.scope] = msg.sprints
+	v.index[msg.scope] = currentSprint(msg.sprints, time.Now())
+	if msg.scope != v.scope {
+		return nil
+	}
+	return v.fetchBacklog(client)
+}
+
+// currentSprint returns the index of the sprint marked current or, when
+// none is, of the first one not finished by now.
+func currentSprint(sprints []provider.Sprint, now time.Time) int {
+	for i, s := range sprints {
+		if s.Current {
+			return i
+		}
+	}
+	for i, s := range sprints {
+		if !s.Finish.IsZero() && !s.Finish.Before(now.Truncate(24*time.Hour)) {
+			return i
+		}
+	}
+	return max(0, len(sprints)-1)
+}
+
+// fetchBacklog fetches the backlog of the sprint shown unless it is
+// fetched or being fetched.
+func (v *sprintView) fetchBacklog(client provider.Provider) tea.Cmd {
+	sprint := v.sprint()
+	if client == nil || sprint == nil {
+		return nil
+	}
+	key := sprintKey(v.scope, sprint.ID)
+	if v.loading[key] || v.backlogs[key] != nil {
+		return nil
+	}
+	v.loading[key] = true
+	delete(v.errs, key)
+	return fetchSprintBacklog(client, v.scope, *sprint)
+}
+
+// handleBacklog records a fetched sprint backlog.
+func (v *sprintView) handleBacklog(msg sprintBacklogMsg) {
+	key := sprintKey(msg.scope, msg.sprintID)
+	v.loading[key] = false
+	if msg.err != nil {
+		v.errs[key] = msg.err
+		return
+	}
+	v.backlogs[key] = msg.backlog
+}
+
+// step shows the previous (dir -1) or next (dir 1) sprint.
+func (v *sprintView) step(client provider.Provider, dir int) tea.Cmd {
+	i := v.index[v.scope] + dir
+	if i < 0 || i >= len(v.sprints[v.scope]) {
+		return nil
+	}
+	v.index[v.scope] = i
+	v.row = 0
+	return v.fetchBacklog(client)
+}
+
+// reload refetches the backlog of the sprint shown, and the project's
+// sprints when they failed to load.
+func (v *sprintView) reload(client provider.Provider) tea.Cmd {
+	if v.errs[v.scope] != nil {
+		return v.show(client, v.scope)
+	}
+	sprint := v.sprint()
+	if sprint == nil {
+		return nil
+	}
+	key := sprintKey(v.scope, sprint.ID)
+	if v.loading[key] {
+		return nil
+	}
+	delete(v.backlogs, key)
+	return v.fetchBacklog(client)
+}
+
+// sprintGroup is a heading of the sprint view with the items under it.
+type sprintGroup struct {
+	name      string
+	items     []provider.WorkItem
+	remaining float64 // hours of remaining work of the items
+	capacity  float64 // hours the assignee has left; grouping by assignee only
+	// hasCapacity is set for assignees with capacity in the sprint.
+	hasCapacity bool
+}
+
+// sprintGroups groups the backlog's items by state, in the order the
+// states first appear, or by assignee. Assignees with capacity come first
+// in the order of the capacity, even without items, then the others as
+// they first appear, then the unassigned items.
+func sprintGroups(backlog provider.SprintBacklog, byAssignee bool) []sprintGroup {
+	var groups []sprintGroup
+	index := map[string]int{}
+	add := func(key, name string) int {
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, sprintGroup{name: name})
+		}
+		return i
+	}
+	if !byAssignee {
+		for _, wi := range backlog.Items {
+			i := add(strings.ToLower(wi.State), wi.State)
+			groups[i].items = append(groups[i].items, wi)
+			groups[i].remaining += wi.RemainingWork
+		}
+		return groups
+	}
+
+	for _, c := range backlog.Capacity {
+		i := add(strings.ToLower(c.Login), c.Name)
+		groups[i].capacity += c.Hours
+		groups[i].hasCapacity = true
+	}
+	var unassigned []provider.WorkItem
+	for _, wi := range backlog.Items {
+		if wi.AssignedTo == "" && wi.AssignedToName == "" {
+			unassigned = append(unassigned, wi)
+			continue
+		}
+		key := strings.ToLower(wi.AssignedTo)
+		if key == "" {
+			key = strings.ToLower(wi.AssignedToName)
+		}
+		i := add(key, wi.AssignedToName)
+		groups[i].items = append(groups[i].items, wi)
+		groups[i].remaining += wi.RemainingWork
+	}
+	if len(unassigned) > 0 {
+		g := sprintGroup{name: "Unassigned", items: unassigned}
+		for _, wi := range unassigned {
+			g.remaining += wi.RemainingWork
+		}
+		groups = append(groups, g)
+	}
+	return groups
+}
+
+// selected returns the selected item of groups, if any, clamping the
+// selection to the items.
+func (v *sprintView) selected(groups []sprintGroup) (provider.WorkItem, bool) {
+	var items []provider.WorkItem
+	for _, g := range groups {
+		items = append(items, g.items...)
+	}
+	if len(items) == 0 {
+		v.row = 0
+		return provider.WorkItem{}, false
+	}
+	v.row = max(0, min(v.row, len(items)-1))
+	return items[v.row], true
+}
+
+// formatHours renders hours with at most one decimal, e.g. "7.5h".
+func formatHours(h float64) string {
+	return strconv.FormatFloat(math.Round(h*10)/10, 'f', -1, 64) + "h"
+}
+
+// View renders the sprint shown with its items grouped.
+func (v *sprintView) View() string {
+	s := v.styles
+	if err := v.errs[v.scope]; err != nil {
+		if errors.Is(err, provider.ErrSprintsUnsupported) {
+			return s.Muted.Render(fmt.Sprintf("%s has no sprints", v.scope))
+		}
+		return s.Error.Render(fmt.Sprintf("Could not load sprints of %s: %v", v.scope, err))
+	}
+	if v.sprints[v.scope] == nil {
+		return s.Muted.Render("Loading sprints...")
+	}
+	sprint := v.sprint()
+	if sprint == nil {
+		return s.Muted.Render(fmt.Sprintf("The team of %s has no sprints", v.scope))
+	}
+
+	header := s.Title.Render(sprint.Name) + s.Muted.Render(" · "+v.scope)
+	if n := len(v.sprints[v.scope]); n > 1 {
+		header += s.Muted.Render(fmt.Sprintf(" (%d/%d)", v.index[v.scope]+1, n))
+	}
+	if sprint.Current {
+		header += " " + s.Value.Render("current")
+	}
+
+	key := sprintKey(v.scope, sprint.ID)
+	if err := v.errs[key]; err != nil {
+		return lipgloss.JoinVertical(lipgloss.Left, header, "",
+			s.Error.Render(fmt.Sprintf("Could not load %s: %v", sprint.Name, err)))
+	}
+	backlog := v.backlogs[key]
+	if backlog == nil {
+		return lipgloss.JoinVertical(lipgloss.Left, header, "", s.Muted.Render("Loading sprint..."))
+	}
+
+	groups := sprintGroups(*backlog, v.byAssignee)
+	v.selected(groups)
+	lines := []string{header, v.summaryView(*sprint, *backlog), ""}
+	body, selectedLine := v.groupsView(groups)
+	if len(body) == 0 {
+		body = []string{s.Muted.Render("No work items in this sprint")}
+	}
+
+	// Window the body around the selected item.
+	maxLines := max(1, v.height-len(lines))
+	first := max(0, min(selectedLine-maxLines/2, len(body)-maxLines))
+	last := min(len(body), first+maxLines)
+	lines = append(lines, body[first:last]...)
+	return strings.Join(lines, "\n")
+}
+
+// summaryView renders the sprint's dates, the working days left and the
+// remaining work against the team's capacity.
+func (v *sprintView) summaryView(sprint provider.Sprint, backlog provider.SprintBacklog) string {
+	s := v.styles
+	var parts []string
+	if !sprint.Start.IsZero() && !sprint.Finish.IsZero() {
+		parts = append(parts, sprint.Start.UTC().Format("Jan 2")+" – "+sprint.Finish.UTC().Format("Jan 2"))
+	}
+	switch {
+	case !sprint.Finish.IsZero() && sprint.Finish.UTC().Before(time.Now().UTC().Truncate(24*time.Hour)):
+		parts = append(parts, "ended")
+	case backlog.DaysLeft == 1:
+		parts = append(parts, "1 working day left")
+	default:
+		parts = append(parts, fmt.Sprintf("%d working days left", backlog.DaysLeft))
+	}
+	summary := s.Muted.Render(strings.Join(parts, " · "))
+
+	var remaining, capacity float64
+	for _, wi := range backlog.Items {
+		remaining += wi.RemainingWork
+	}
+	for _, c := range backlog.Capacity {
+		capacity += c.Hours
+	}
+	work := formatHours(remaining) + " remaining"
+	if len(backlog.Capacity) == 0 {
+		return summary + s.Muted.Render(" · ") + s.Value.Render(work)
+	}
+	work += " / " + formatHours(capacity) + " capacity"
+	style := s.Value
+	if remaining > capacity {
+		style = s.Error
+	}
+	return summary + s.Muted.Render(" · ") + style.Render(work)
+}
+
+// groupsView renders the group headings with their items, returning the
+// lines and the index of the selected item's line.
+func (v *sprintView) groupsView(groups []sprintGroup) ([]string, int) {
+	s := v.styles
+	width := max(v.width, 40)
+	var lines []string
+	selectedLine, row := 0, 0
+	for _, g := range groups {
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		count := fmt.Sprintf(" %d", len(g.items))
+		hours := formatHours(g.remaining)
+		hoursStyle := s.Muted
+		if g.hasCapacity {
+			hours += " / " + formatHours(g.capacity)
+			if g.remaining > g.capacity {
+				hoursStyle = s.Error
+			}
+		}
+		lines = append(lines, s.Value.Bold(true).Render(g.name)+s.Muted.Render(count)+"  "+hoursStyle.Render(hours))
+		for _, wi := range g.items {
+			if row == v.row {
+				selectedLine = len(lines)
+			}
+			lines = append(lines, v.itemView(wi, row == v.row, width))
+			row++
+		}
+	}
+	return lines, selectedLine
+}
+
+// itemView renders an item: the type icon, ID and title, then the state
+// or the assignee, whichever the items are not grouped by, and the
+// remaining work.
+func (v *sprintView) itemView(wi provider.WorkItem, selected bool, width int) string {
+	s := v.styles
+	other := wi.AssignedToName
+	if v.byAssignee {
+		other = wi.State
+	} else if other == "" {
+		other = "Unassigned"
+	}
+	remaining := ""
+	if wi.RemainingWork > 0 {
+		remaining = formatHours(wi.RemainingWork)
+	}
+	const otherWidth, hoursWidth = 20, 7
+	titleWidth := max(10, width-otherWidth-hoursWidth-6)
+	title := ansi.Truncate(fmt.Sprintf("#%s %s", wi.Identity.ID, wi.Title), titleWidth, "…")
+	line := fmt.Sprintf("%-*s  %-*s %*s", titleWidth, title, otherWidth, ansi.Truncate(other, otherWidth, "…"), hoursWidth, remaining)
+
+	style := lipgloss.NewStyle()
+	if selected {
+		style = style.Foreground(s.Theme.GetSelectForeground()).Background(s.Theme.GetSelectBackground())
+	}
+	return "  " + typeIconWithStyles(wi.ItemKind, s) + " " + style.Render(line)
+}
+
+// sprintsMsg is sent when the sprints of a project have been fetched
+type sprintsMsg struct {
+	scope   string
+	sprints []provider.Sprint
+	err     error
+}
+
+// fetchSprints fetches the sprints of scope.
+func fetchSprints(client provider.Provider, scope string) tea.Cmd {
+	return func() tea.Msg {
+		sprints, err := client.GetSprints(scope)
+		return sprintsMsg{scope: scope, sprints: sprints, err: err}
+	}
+}
+
+// sprintBacklogMsg is sent when the backlog of a sprint has been fetched
+type sprintBacklogMsg struct {
+	scope    string
+	sprintID string
+	backlog  *provider.SprintBacklog
+	err      error
+}
+
+// fetchSprintBacklog fetches the backlog of sprint of scope.
+func fetchSprintBacklog(client provider.Provider, scope string, sprint provider.Sprint) tea.Cmd {
+	return func() tea.Msg {
+		backlog, err := client.GetSprintBacklog(scope, sprint)
+		if err == nil && backlog == nil {
+			backlog = &provider.SprintBacklog{}
+		}
+		return sprintBacklogMsg{scope: scope, sprintID: sprint.ID, backlog: backlog, err: err}
+	}
+}
+
+// IsSprintMode returns true while the sprint view is shown.
+func (m Model) IsSprintMode() bool {
+	return m.sprint.enabled && m.GetViewMode() == ViewList
+}
+
+// toggleSprint switches between the list and the sprint view. The view
+// opens on the project it showed last, or the first one with listed
+// items.
+func (m Model) toggleSprint() (Model, tea.Cmd) {
+	m.sprint.enabled = !m.sprint.enabled
+	if !m.sprint.enabled {
+		return m, nil
+	}
+	m.board.enabled = false
+	scopes := m.boardScopes()
+	if len(scopes) == 0 {
+		return m, nil
+	}
+	scope := scopes[0]
+	for _, s := range scopes {
+		if s == m.sprint.scope {
+			scope = s
+		}
+	}
+	return m, m.sprint.show(m.client, scope)
+}
+
+// updateSprint handles the keys of the sprint view: the arrows select an
+// item, left and right or [ and ] switch to the previous and next sprint,
+// g switches the grouping, p the project, and enter opens the item.
+func (m Model) updateSprint(msg tea.KeyMsg) (Model, tea.Cmd) {
+	var groups []sprintGroup
+	if backlog := m.sprint.backlog(); backlog != nil {
+		groups = sprintGroups(*backlog, m.sprint.byAssignee)
+	}
+	m.sprint.selected(groups)
+
+	switch msg.String() {
+	case "esc", "S":
+		m.sprint.enabled = false
+	case "left", "h", "[":
+		return m, m.sprint.step(m.client, -1)
+	case "right", "l", "]":
+		return m, m.sprint.step(m.client, 1)
+	case "up", "k":
+		if m.sprint.row > 0 {
+			m.sprint.row--
+		}
+	case "down", "j":
+		m.sprint.row++
+		m.sprint.selected(groups)
+	case "g":
+		m.sprint.byAssignee = !m.sprint.byAssignee
+		m.sprint.row = 0
+	case "p":
+		scopes := m.boardScopes()
+		for i, scope := range scopes {
+			if scope == m.sprint.scope {
+				return m, m.sprint.show(m.client, scopes[(i+1)%len(scopes)])
+			}
+		}
+	case "enter":
+		wi, ok := m.sprint.selected(groups)
+		if !ok {
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.list, cmd = m.list.OpenDetail(wi)
+		return m, cmd
+	case "r":
+		return m, m.sprint.reload(m.client)
+	}
+	return m, nil
+}
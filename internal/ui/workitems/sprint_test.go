@@ -0,0 +1,204 @@
+package workitems
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Elpulgo/azdo/internal/provider"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// sprintProvider serves three sprints of "testproject", the second one
+// current, and records the backlogs fetched. Every other method panics
+// via the nil embedded interface.
+type sprintProvider struct {
+	provider.Provider
+	backlogs map[string]*provider.SprintBacklog
+	fetched  []string
+	err      error
+}
+
+func (p *sprintProvider) Scopes() []string     { return []string{"testproject"} }
+func (p *sprintProvider) IsMultiProject() bool { return false }
+
+func (p *sprintProvider) WorkItemURL(scope string, id int) string { return "" }
+
+func (p *sprintProvider) GetSprints(scope string) ([]provider.Sprint, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	return []provider.Sprint{
+		{ID: "s23", Name: "Sprint 23"},
+		{ID: "s24", Name: "Sprint 24", Current: true},
+		{ID: "s25", Name: "Sprint 25"},
+	}, nil
+}
+
+func (p *sprintProvider) GetSprintBacklog(scope string, sprint provider.Sprint) (*provider.SprintBacklog, error) {
+	p.fetched = append(p.fetched, sprint.ID)
+	if b := p.backlogs[sprint.ID]; b != nil {
+		return b, nil
+	}
+	return &provider.SprintBacklog{}, nil
+}
+
+// sprintItem returns an item assigned to login with hours of remaining work.
+func sprintItem(id int, title, state, login string, hours float64) provider.WorkItem {
+	wi := newWI(id, title, state, "Task")
+	wi.AssignedTo = login
+	if login != "" {
+		wi.AssignedToName = strings.ToUpper(login[:1]) + login[1:]
+	}
+	wi.RemainingWork = hours
+	return wi
+}
+
+// newSprintModel opens the sprint view and runs the commands fetching the
+// sprints and the current sprint's backlog.
+func newSprintModel(t *testing.T, p *sprintProvider) Model {
+	t.Helper()
+	m := NewModel(p)
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 120, Height: 30})
+	m, cmd := m.Update(keyRunes("S"))
+	if !m.IsSprintMode() {
+		t.Fatal("S should show the sprint view")
+	}
+	for cmd != nil {
+		m, cmd = m.Update(cmd())
+	}
+	return m
+}
+
+func TestSprintGroups_ByAssigneeWithCapacity(t *testing.T) {
+	backlog := provider.SprintBacklog{
+		Items: []provider.WorkItem{
+			sprintItem(1, "API", "Active", "ada", 6),
+			sprintItem(2, "Docs", "New", "", 2),
+			sprintItem(3, "UI", "New", "cy", 4),
+			sprintItem(4, "Tests", "Active", "ada", 5.5),
+		},
+		Capacity: []provider.SprintCapacity{
+			{Name: "Bob", Login: "bob", Hours: 12},
+			{Name: "Ada", Login: "ADA", Hours: 10},
+		},
+	}
+
+	type group struct {
+		name                string
+		items               int
+		remaining, capacity float64
+	}
+	summarize := func(groups []sprintGroup) []group {
+		var got []group
+		for _, g := range groups {
+			got = append(got, group{g.name, len(g.items), g.remaining, g.capacity})
+		}
+		return got
+	}
+
+	byAssignee := summarize(sprintGroups(backlog, true))
+	want := []group{{"Bob", 0, 0, 12}, {"Ada", 2, 11.5, 10}, {"Cy", 1, 4, 0}, {"Unassigned", 1, 2, 0}}
+	if !reflect.DeepEqual(byAssignee, want) {
+		t.Errorf("by assignee = %+v\nwant %+v", byAssignee, want)
+	}
+	byState := summarize(sprintGroups(backlog, false))
+	want = []group{{"Active", 2, 11.5, 0}, {"New", 2, 6, 0}}
+	if !reflect.DeepEqual(byState, want) {
+		t.Errorf("by state = %+v\nwant %+v", byState, want)
+	}
+}
+
+func TestSprint_ShowsCurrentSprintWithCapacity(t *testing.T) {
+	p := &sprintProvider{backlogs: map[string]*provider.SprintBacklog{
+		"s24": {
+			Items:    []provider.WorkItem{sprintItem(1, "API", "Active", "ada", 12), sprintItem(2, "Docs", "New", "", 2)},
+			Capacity: []provider.SprintCapacity{{Name: "Ada", Login: "ada", Hours: 10}, {Name: "Bob", Login: "bob", Hours: 20}},
+			DaysLeft: 5,
+		},
+	}}
+	m := newSprintModel(t, p)
+
+	if !reflect.DeepEqual(p.fetched, []string{"s24"}) {
+		t.Errorf("fetched = %v, want the current sprint", p.fetched)
+	}
+	view := m.View()
+	for _, want := range []string{"Sprint 24", "(2/3)", "current", "5 working days left", "14h remaining / 30h capacity", "Active 1", "#1 API"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view should contain %q:\n%s", want, view)
+		}
+	}
+
+	m, _ = m.Update(keyRunes("g"))
+	view = m.View()
+	for _, want := range []string{"Ada 1  12h / 10h", "Bob 0  0h / 20h", "Unassigned 1  2h"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("grouped by assignee, view should contain %q:\n%s", want, view)
+		}
+	}
+}
+
+func TestSprint_SwitchesSprintsAndFetchesEachOnce(t *testing.T) {
+	p := &sprintProvider{backlogs: map[string]*provider.SprintBacklog{
+		"s25": {Items: []provider.WorkItem{sprintItem(7, "Next", "New", "", 0)}},
+	}}
+	m := newSprintModel(t, p)
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	if cmd == nil {
+		t.Fatal("the next sprint's backlog should be fetched")
+	}
+	m, _ = m.Update(cmd())
+	if view := m.View(); !strings.Contains(view, "Sprint 25") || !strings.Contains(view, "#7 Next") {
+		t.Errorf("right should show the next sprint:\n%s", view)
+	}
+	m, cmd = m.Update(keyRunes("]"))
+	if cmd != nil || !strings.Contains(m.View(), "Sprint 25") {
+		t.Error("there is no sprint after the last one")
+	}
+
+	m, cmd = m.Update(keyRunes("["))
+	if cmd != nil || !strings.Contains(m.View(), "Sprint 24") {
+		t.Error("[ should show the fetched previous sprint without fetching it again")
+	}
+	if !reflect.DeepEqual(p.fetched, []string{"s24", "s25"}) {
+		t.Errorf("fetched = %v", p.fetched)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.IsSprintMode() {
+		t.Error("esc should return to the list")
+	}
+}
+
+func TestSprint_OpensItemNotInTheList(t *testing.T) {
+	p := &sprintProvider{backlogs: map[string]*provider.SprintBacklog{
+		"s24": {Items: []provider.WorkItem{sprintItem(1, "API", "Active", "ada", 1), sprintItem(2, "Style the login form", "Active", "ada", 1)}},
+	}}
+	m := newSprintModel(t, p)
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.GetViewMode() != ViewDetail {
+		t.Fatal("enter should open the selected item")
+	}
+	if m.IsSprintMode() {
+		t.Error("the detail view replaces the sprint view")
+	}
+	if view := m.View(); !strings.Contains(view, "Style the login form") {
+		t.Errorf("detail should show the second item:\n%s", view)
+	}
+}
+
+func TestSprint_BackendWithoutSprints(t *testing.T) {
+	m := newSprintModel(t, &sprintProvider{err: provider.ErrSprintsUnsupported})
+	if view := m.View(); !strings.Contains(view, "testproject has no sprints") {
+		t.Errorf("view = %q", view)
+	}
+
+	m = newSprintModel(t, &sprintProvider{err: errors.New("boom")})
+	if view := m.View(); !strings.Contains(view, "Could not load sprints of testproject: boom") {
+		t.Errorf("view = %q", view)
+	}
+}
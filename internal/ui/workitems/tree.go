@@ -0,0 +1,164 @@
+package workitems
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/Elpulgo/azdo/internal/provider"
+)
+
+// WorkItemNode represents a work item in the hierarchy tree with its
+// children
+type WorkItemNode struct {
+	Item     provider.WorkItem
+	Children []*WorkItemNode
+	Depth    int // depth in the displayed tree
+	Expanded bool
+}
+
+// HasChildren returns true if the node has child nodes.
+func (n *WorkItemNode) HasChildren() bool {
+	return len(n.Children) > 0
+}
+
+// hierarchy is the tree mode of the list. It is shared by pointer with the
+// row renderer, which indents titles by the depth of their node.
+type hierarchy struct {
+	enabled   bool
+	collapsed map[string]bool          // nodes the user collapsed; all others are expanded
+	nodes     map[string]*WorkItemNode // the displayed nodes by nodeKey
+}
+
+func newHierarchy() *hierarchy {
+	return &hierarchy{collapsed: map[string]bool{}, nodes: map[string]*WorkItemNode{}}
+}
+
+// nodeKey identifies a work item across projects.
+func nodeKey(wi provider.WorkItem) string {
+	return wi.Identity.Scope + "#" + wi.Identity.ID
+}
+
+// arrange returns items in tree order, hiding the children of collapsed
+// nodes, and records the displayed nodes for the row renderer. Outside tree
+// mode items are returned as they are.
+func (h *hierarchy) arrange(items []provider.WorkItem) []provider.WorkItem {
+	if h == nil || !h.enabled {
+		return items
+	}
+	roots := buildWorkItemTree(items)
+	h.nodes = map[string]*WorkItemNode{}
+	var result []provider.WorkItem
+	for _, node := range flattenWorkItemTree(roots, h.collapsed) {
+		h.nodes[nodeKey(node.Item)] = node
+		result = append(result, node.Item)
+	}
+	return result
+}
+
+// toggle collapses or expands the node of wi. It reports false when the
+// item has no children to show or hide.
+func (h *hierarchy) toggle(wi provider.WorkItem) bool {
+	node, ok := h.nodes[nodeKey(wi)]
+	if !ok || !node.HasChildren() {
+		return false
+	}
+	key := nodeKey(wi)
+	if h.collapsed[key] {
+		delete(h.collapsed, key)
+	} else {
+		h.collapsed[key] = true
+	}
+	return true
+}
+
+// titlePrefix returns the indentation and expand/collapse indicator shown
+// before the title of wi, or "" outside tree mode.
+func (h *hierarchy) titlePrefix(wi provider.WorkItem) string {
+	if h == nil || !h.enabled {
+		return ""
+	}
+	node, ok := h.nodes[nodeKey(wi)]
+	if !ok {
+		return ""
+	}
+	indicator := "  "
+	if node.HasChildren() {
+		if node.Expanded {
+			indicator = "▼ "
+		} else {
+			indicator = "▶ "
+		}
+	}
+	return strings.Repeat("  ", node.Depth) + indicator
+}
+
+// buildWorkItemTree builds the hierarchy from the parent IDs of items.
+// Items whose parent is not among them are roots, as is the first item of
+// a parent cycle. Roots and children keep the order of items.
+func buildWorkItemTree(items []provider.WorkItem) []*WorkItemNode {
+	nodeMap := make(map[string]*WorkItemNode, len(items))
+	nodes := make([]*WorkItemNode, len(items))
+	for i, wi := range items {
+		nodes[i] = &WorkItemNode{Item: wi, Children: []*WorkItemNode{}}
+		nodeMap[nodeKey(wi)] = nodes[i]
+	}
+
+	var roots []*WorkItemNode
+	for _, node := range nodes {
+		var parent *WorkItemNode
+		if node.Item.ParentID > 0 {
+			parentItem := node.Item
+			parentItem.Identity.ID = strconv.Itoa(node.Item.ParentID)
+			parent = nodeMap[nodeKey(parentItem)]
+		}
+		if parent != nil && parent != node {
+			parent.Children = append(parent.Children, node)
+		} else {
+			roots = append(roots, node)
+		}
+	}
+
+	reached := map[*WorkItemNode]bool{}
+	var reach func(nodes []*WorkItemNode)
+	reach = func(nodes []*WorkItemNode) {
+		for _, node := range nodes {
+			if !reached[node] {
+				reached[node] = true
+				reach(node.Children)
+			}
+		}
+	}
+	reach(roots)
+	for _, node := range nodes {
+		if !reached[node] {
+			roots = append(roots, node)
+			reach([]*WorkItemNode{node})
+		}
+	}
+	return roots
+}
+
+// flattenWorkItemTree converts the tree to a flat list (depth-first),
+// setting each node's depth and skipping the children of collapsed nodes.
+// Nodes are visited once, so a parent cycle cannot loop forever.
+func flattenWorkItemTree(roots []*WorkItemNode, collapsed map[string]bool) []*WorkItemNode {
+	var result []*WorkItemNode
+	seen := map[*WorkItemNode]bool{}
+	var walk func(nodes []*WorkItemNode, depth int)
+	walk = func(nodes []*WorkItemNode, depth int) {
+		for _, node := range nodes {
+			if seen[node] {
+				continue
+			}
+			seen[node] = true
+			node.Depth = depth
+			node.Expanded = !collapsed[nodeKey(node.Item)]
+			result = append(result, node)
+			if node.Expanded {
+				walk(node.Children, depth+1)
+			}
+		}
+	}
+	walk(roots, 0)
+	return result
+}
@@ -0,0 +1,122 @@
+package workitems
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Elpulgo/azdo/internal/provider"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// childOf returns wi with the given parent.
+func childOf(wi provider.WorkItem, parent int) provider.WorkItem {
+	wi.ParentID = parent
+	return wi
+}
+
+// displayed returns "ID@depth" for each flattened node.
+func displayed(nodes []*WorkItemNode) []string {
+	var got []string
+	for _, n := range nodes {
+		got = append(got, fmt.Sprintf("%s@%d", n.Item.Identity.ID, n.Depth))
+	}
+	return got
+}
+
+func TestBuildWorkItemTree(t *testing.T) {
+	items := []provider.WorkItem{
+		childOf(newWI(3, "Task", "", "Task"), 2),
+		newWI(1, "Epic", "", "Epic"),
+		childOf(newWI(2, "Story", "", "User Story"), 1),
+		childOf(newWI(4, "Orphan", "", "Task"), 99),
+		childOf(newWI(5, "Second task", "", "Task"), 2),
+	}
+
+	roots := buildWorkItemTree(items)
+	got := displayed(flattenWorkItemTree(roots, nil))
+	want := []string{"1@0", "2@1", "3@2", "5@2", "4@0"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("tree = %v, want %v", got, want)
+	}
+}
+
+func TestFlattenWorkItemTree_SkipsCollapsedChildren(t *testing.T) {
+	items := []provider.WorkItem{
+		newWI(1, "Epic", "", "Epic"),
+		childOf(newWI(2, "Story", "", "User Story"), 1),
+		childOf(newWI(3, "Task", "", "Task"), 2),
+		newWI(4, "Other", "", "Epic"),
+	}
+
+	roots := buildWorkItemTree(items)
+	nodes := flattenWorkItemTree(roots, map[string]bool{nodeKey(items[1]): true})
+	got := displayed(nodes)
+	want := []string{"1@0", "2@1", "4@0"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("tree = %v, want %v", got, want)
+	}
+	if nodes[1].Expanded || !nodes[0].Expanded {
+		t.Errorf("expanded = %v, %v; want true, false", nodes[0].Expanded, nodes[1].Expanded)
+	}
+}
+
+func TestBuildWorkItemTree_ParentCycle(t *testing.T) {
+	items := []provider.WorkItem{
+		childOf(newWI(1, "A", "", ""), 2),
+		childOf(newWI(2, "B", "", ""), 1),
+		childOf(newWI(3, "Self", "", ""), 3),
+	}
+
+	got := displayed(flattenWorkItemTree(buildWorkItemTree(items), nil))
+	want := []string{"3@0", "1@0", "2@1"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("tree = %v, want %v", got, want)
+	}
+}
+
+func TestTreeMode_ToggleAndCollapse(t *testing.T) {
+	m := NewModel(nil)
+	m.list, _ = m.list.Update(tea.WindowSizeMsg{Width: 120, Height: 30})
+	m, _ = m.Update(SetWorkItemsMsg{WorkItems: []provider.WorkItem{
+		childOf(newWI(2, "Story", "", "User Story"), 1),
+		newWI(1, "Epic", "", "Epic"),
+	}})
+
+	ids := func() []string {
+		var got []string
+		for _, wi := range m.list.Items() {
+			got = append(got, wi.Identity.ID)
+		}
+		return got
+	}
+	if got := ids(); !reflect.DeepEqual(got, []string{"2", "1"}) {
+		t.Fatalf("flat list = %v", got)
+	}
+
+	m, _ = m.Update(keyRunes("H"))
+	if !m.IsTreeMode() {
+		t.Fatal("H should switch to tree mode")
+	}
+	if got := ids(); !reflect.DeepEqual(got, []string{"1", "2"}) {
+		t.Errorf("tree list = %v, want [1 2]", got)
+	}
+	if view := m.View(); !strings.Contains(view, "▼ Epic") || !strings.Contains(view, "    Story") {
+		t.Errorf("tree view should indent the story under the expanded epic:\n%s", view)
+	}
+
+	// The epic is selected; space collapses it.
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}})
+	if got := ids(); !reflect.DeepEqual(got, []string{"1"}) {
+		t.Errorf("collapsed list = %v, want [1]", got)
+	}
+	if view := m.View(); !strings.Contains(view, "▶ Epic") {
+		t.Errorf("collapsed epic should show ▶:\n%s", view)
+	}
+
+	m, _ = m.Update(keyRunes("H"))
+	if m.IsTreeMode() || !reflect.DeepEqual(ids(), []string{"2", "1"}) {
+		t.Errorf("H again should restore the flat list, got %v", ids())
+	}
+}